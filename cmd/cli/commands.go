@@ -4,8 +4,11 @@ import (
 	"family-catering/config"
 	"family-catering/internal/app"
 	"family-catering/pkg/db/migration"
+	"family-catering/pkg/utils"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	cli "github.com/urfave/cli/v2"
 )
@@ -20,7 +23,8 @@ func init() {
 		step(),
 		drop(),
 		run(),
-		start())
+		start(),
+		keygen())
 }
 
 func RegisterCommands(args ...*cli.Command) {
@@ -112,6 +116,56 @@ func start() *cli.Command {
 	return command
 }
 
+func keygen() *cli.Command {
+	command := &cli.Command{
+		Name:        "keygen",
+		Description: "generate a RS256 or EdDSA key pair of the tokens, <name>.pem is the private key and <name>.pub.pem the public one",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "alg", Value: utils.TokenAlgEdDSA, Usage: "algorithm of the key, RS256 or EdDSA"},
+			&cli.StringFlag{Name: "out", Required: true, Usage: "private key file, relative to the config directory unless absolute"},
+		},
+		Action: func(c *cli.Context) error {
+			privatePEM, publicPEM, err := utils.GenerateTokenKeyPEM(c.String("alg"))
+			if err != nil {
+				return err
+			}
+
+			privatePath := c.String("out")
+			if !filepath.IsAbs(privatePath) {
+				privatePath = filepath.Join(config.Path(), privatePath)
+			}
+			publicPath := strings.TrimSuffix(privatePath, ".pem") + ".pub.pem"
+
+			// never overwrite a key, the tokens it signed would be invalid
+			err = writeNewFile(privatePath, privatePEM, 0600)
+			if err != nil {
+				return err
+			}
+			err = writeNewFile(publicPath, publicPEM, 0644)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("private key: %s\npublic key: %s\n", privatePath, publicPath)
+			return nil
+		},
+	}
+	return command
+}
+
+func writeNewFile(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func Execute() error {
 	app := cli.NewApp()
 	app.Name = "family-catering CLI app"
@@ -34,6 +34,10 @@ type (
 		Postgres postgres `yaml:"postgres"`
 		Redis    redis    `yaml:"redis"`
 		Mailer   mailer   `yaml:"mailer"`
+		Payment  payment  `yaml:"payment"`
+		Tax      tax      `yaml:"tax"`
+		Delivery delivery `yaml:"delivery"`
+		Report   report   `yaml:"report"`
 	}
 
 	app struct {
@@ -42,16 +46,54 @@ type (
 	}
 
 	web struct {
-		PaginationLimit       int           `yaml:"pagination-limit" env-default:"10" env-layout:"int"`
-		AllowedOrigins        []string      `yaml:"allowed-origins" env-default:"https://*, http://*" env-layout:"slice"`
-		AllowedMethods        []string      `yaml:"allowed-methods" env-default:"GET,POST,PUT,DELETE,OPTIONS" env-layout:"slice"`
-		AllowedHeaders        []string      `yaml:"allowed-headers" env-default:"Accept,Authorization,Content-Type" env-layout:"slice"`
-		MaxAge                int           `yaml:"max-age"`
-		GeneralRequestLimit   int           `yaml:"limit-general-request-per-minute"`
-		AccessTokenSecretKey  string        `env:"SECRET_KEY_ACCESS_TOKEN"`
-		RefreshTokenSecretKey string        `env:"SECRET_KEY_REFRESH_TOKEN"`
-		AccessTokenTTL        time.Duration `yaml:"access-token-ttl" env-layout:"time.Duration"`
-		RefreshTokenTTL       time.Duration `yaml:"refresh-token-ttl" env-layout:"time.Duration"`
+		PaginationLimit       int               `yaml:"pagination-limit" env-default:"10" env-layout:"int"`
+		AllowedOrigins        []string          `yaml:"allowed-origins" env-default:"https://*, http://*" env-layout:"slice"`
+		AllowedMethods        []string          `yaml:"allowed-methods" env-default:"GET,POST,PUT,PATCH,DELETE,OPTIONS" env-layout:"slice"`
+		AllowedHeaders        []string          `yaml:"allowed-headers" env-default:"Accept,Authorization,Content-Type" env-layout:"slice"`
+		TrustedProxies        []string          `yaml:"trusted-proxies" env-layout:"slice"` // ips or CIDRs whose forwarded client ip is trusted
+		MaxAge                int               `yaml:"max-age"`
+		GeneralRequestLimit   int               `yaml:"limit-general-request-per-minute"`
+		AccessTokenSecretKey  string            `env:"SECRET_KEY_ACCESS_TOKEN"`
+		RefreshTokenSecretKey string            `env:"SECRET_KEY_REFRESH_TOKEN"`
+		TokenKeysFile         string            `yaml:"token-keys-file" env:"TOKEN_KEYS_FILE"`
+		TokenIssuer           string            `yaml:"token-issuer" env-default:"family-catering"`
+		TokenAudience         string            `yaml:"token-audience" env-default:"family-catering"`
+		AccessTokenTTL        time.Duration     `yaml:"access-token-ttl" env-layout:"time.Duration"`
+		RefreshTokenTTL       time.Duration     `yaml:"refresh-token-ttl" env-layout:"time.Duration"`
+		LoginLockout          loginLockout      `yaml:"login-lockout"`
+		TwoFactor             twoFactor         `yaml:"two-factor"`
+		EmailVerification     emailVerification `yaml:"email-verification"`
+		PasswordReset         passwordReset     `yaml:"password-reset"`
+	}
+
+	// loginLockout is the brute force protection of the login, the failed logins are counted by email and by ip
+	loginLockout struct {
+		MaxAttempts      int           `yaml:"max-attempts" env-default:"5" env-layout:"int"`
+		MaxAttemptsPerIP int           `yaml:"max-attempts-per-ip" env-default:"20" env-layout:"int"`
+		Window           time.Duration `yaml:"window" env-default:"15m" env-layout:"time.Duration"`
+		Backoff          time.Duration `yaml:"backoff" env-default:"1s" env-layout:"time.Duration"`
+		Duration         time.Duration `yaml:"duration" env-default:"15m" env-layout:"time.Duration"`
+	}
+
+	// twoFactor is the owners' optional TOTP second factor, an owner who enabled it logs in with a pre-auth token until its
+	// code is verified
+	twoFactor struct {
+		Issuer          string        `yaml:"issuer" env-default:"Family Catering"`
+		PreAuthTokenTTL time.Duration `yaml:"pre-auth-token-ttl" env-default:"5m" env-layout:"time.Duration"`
+		RecoveryCodes   int           `yaml:"recovery-codes" env-default:"10" env-layout:"int"`
+	}
+
+	// emailVerification is the link sent to verify an owner's email, a registered owner logs in and a changed email is
+	// applied once the email is verified
+	emailVerification struct {
+		TokenTTL time.Duration `yaml:"token-ttl" env-default:"24h" env-layout:"time.Duration"`
+	}
+
+	// passwordReset is the forgot password link, a link resets the password once and the requests are limited by email
+	passwordReset struct {
+		TokenTTL    time.Duration `yaml:"token-ttl" env-default:"30m" env-layout:"time.Duration"`
+		MaxRequests int           `yaml:"max-requests" env-default:"3" env-layout:"int"`
+		Window      time.Duration `yaml:"window" env-default:"1h" env-layout:"time.Duration"`
 	}
 
 	server struct {
@@ -93,10 +135,56 @@ type (
 		Password               string `env:"MAILER_PASSWORD" env-layout:"string"`
 		SupportEmail           string `yaml:"support-email"`
 		TemplateForgotPassword string `yaml:"template-forgot-password" env-default:"forgot_password_template.txt" env-layout:"string"`
+		TemplateVerifyEmail    string `yaml:"template-verify-email" env-default:"verify_email_template.txt" env-layout:"string"`
+		TemplateEmailChanged   string `yaml:"template-email-changed" env-default:"email_changed_template.txt" env-layout:"string"`
 		Identity               string `yaml:"identity"`
 	}
+
+	payment struct {
+		FakeProviderEnabled     bool   `yaml:"fake-provider-enabled" env-default:"false"`
+		FakeProviderCheckoutURL string `yaml:"fake-provider-checkout-url"`
+		FakeProviderSecret      string `env:"PAYMENT_FAKE_PROVIDER_SECRET" env-layout:"string"`
+	}
+
+	tax struct {
+		Inclusive         bool               `yaml:"inclusive" env-default:"false"`
+		Rate              float64            `yaml:"rate"`
+		CategoryRates     map[string]float64 `yaml:"category-rates"`
+		ServiceChargeRate float64            `yaml:"service-charge-rate"`
+	}
+
+	delivery struct {
+		Slots         []deliverySlot `yaml:"slots"`
+		LeadTime      time.Duration  `yaml:"lead-time" env-layout:"time.Duration"`
+		SameDayCutoff string         `yaml:"same-day-cutoff"`
+		MaxDaysAhead  int            `yaml:"max-days-ahead"`
+		Kitchen       location       `yaml:"kitchen"`
+	}
+
+	report struct {
+		CacheTTL time.Duration `yaml:"cache-ttl" env-layout:"time.Duration"`
+	}
+
+	deliverySlot struct {
+		Start     string `yaml:"start"`
+		End       string `yaml:"end"`
+		MaxOrders int    `yaml:"max-orders"`
+	}
+
+	location struct {
+		Latitude  float64 `yaml:"latitude"`
+		Longitude float64 `yaml:"longitude"`
+	}
+
+	tokenKeys struct {
+		AccessToken  utils.TokenKeySet `yaml:"access-token"`
+		RefreshToken utils.TokenKeySet `yaml:"refresh-token"`
+	}
 )
 
+// envTokenKeyID is the kid of the keys read from SECRET_KEY_ACCESS_TOKEN and SECRET_KEY_REFRESH_TOKEN
+const envTokenKeyID = "env"
+
 func (s server) Addr() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
@@ -113,6 +201,40 @@ func (m mailer) Addr() string {
 	return fmt.Sprintf("%s:%d", m.Host, m.Port)
 }
 
+// TokenKeys return the keys of the access and refresh tokens. The keys are read from the token keys file on every call so
+// a rotated file is picked up by the running app, without a file they are the single keys of the environment variables
+func (w web) TokenKeys() (access, refresh utils.TokenKeySet, err error) {
+	if w.TokenKeysFile == "" {
+		if w.AccessTokenSecretKey == "" || w.RefreshTokenSecretKey == "" {
+			return access, refresh, fmt.Errorf("config.TokenKeys: SECRET_KEY_ACCESS_TOKEN and SECRET_KEY_REFRESH_TOKEN are required without token keys file")
+		}
+		access = utils.TokenKeySet{Primary: envTokenKeyID, Keys: []utils.TokenKey{{ID: envTokenKeyID, Secret: w.AccessTokenSecretKey}}}
+		refresh = utils.TokenKeySet{Primary: envTokenKeyID, Keys: []utils.TokenKey{{ID: envTokenKeyID, Secret: w.RefreshTokenSecretKey}}}
+		return access, refresh, nil
+	}
+
+	path := w.TokenKeysFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(Path(), path)
+	}
+	keys := tokenKeys{}
+	err = cleanenv.ReadConfig(path, &keys)
+	if err != nil {
+		return access, refresh, fmt.Errorf("config.TokenKeys: %w", err)
+	}
+
+	// the PEM files are relative to the config directory
+	for _, set := range []utils.TokenKeySet{keys.AccessToken, keys.RefreshToken} {
+		for i, key := range set.Keys {
+			if key.KeyFile != "" && !filepath.IsAbs(key.KeyFile) {
+				set.Keys[i].KeyFile = filepath.Join(Path(), key.KeyFile)
+			}
+		}
+	}
+
+	return keys.AccessToken, keys.RefreshToken, nil
+}
+
 func init() {
 
 	env := utils.GetEnv("FCAT_ENV", "development")
@@ -1,27 +1,18 @@
-// GENERATED BY THE COMMAND ABOVE; DO NOT EDIT
-// This file was generated by swaggo/swag
-
+// Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
-import (
-	"bytes"
-	"encoding/json"
-	"strings"
-
-	"github.com/alecthomas/template"
-	"github.com/swaggo/swag"
-)
+import "github.com/swaggo/swag"
 
-var doc = `{
+const docTemplate = `{
     "schemes": {{ marshal .Schemes }},
     "swagger": "2.0",
     "info": {
-        "description": "{{.Description}}",
+        "description": "{{escape .Description}}",
         "title": "{{.Title}}",
         "termsOfService": "http://swagger.io/terms/",
         "contact": {
-            "name": "Family catering API Support",
-            "url": "http://www.family-catering.com/support",
+            "name": "Family catering Support",
+            "url": "http://www.family-catering/support",
             "email": "support.family-catering@example.com"
         },
         "license": {
@@ -33,9 +24,29 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "JSON Web Key Set (RFC 7517) of the RS256/EdDSA keys verifying the access tokens, served at the root (not under /api/v1)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Public keys of the access tokens",
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONWebKeySet"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/forgot-password": {
             "put": {
-                "description": "Auth Forgot password",
+                "description": "Send the reset password link to a registered email, the answer is the same whether the email is registered or not. A link resets the password once and a new link voids the previous one, the requests of an email are limited",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,14 +87,14 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "422": {
+                        "description": "Unprocessable entity",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity",
+                    "429": {
+                        "description": "Too many password reset requests",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -99,7 +110,7 @@ var doc = `{
         },
         "/auth/login": {
             "post": {
-                "description": "Login owner using registered email and password",
+                "description": "Login owner using registered email and password, an owner with two-factor authentication gets a pre-auth token to verify its code with (see /auth/login/verify). An owner logs in once its email is verified. An unregistered email answers as a wrong password",
                 "consumes": [
                     "application/json"
                 ],
@@ -153,7 +164,107 @@ var doc = `{
                         }
                     },
                     "400": {
-                        "description": "Not Found",
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (wrong email or password)",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked (too many failed logins)",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login/verify": {
+            "post": {
+                "description": "Login the owner of the pre-auth token (see /auth/login) with a code of its authenticator app or one of its recovery codes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify the two-factor code of a login",
+                "parameters": [
+                    {
+                        "description": "Verify login payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AuthLoginVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.AuthResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "auth": {
+                                                            "$ref": "#/definitions/model.AuthLoginResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid code or pre-auth token)",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -164,6 +275,12 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
+                    "423": {
+                        "description": "Locked (too many failed logins)",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -233,7 +350,7 @@ var doc = `{
         },
         "/auth/renew-access-token": {
             "get": {
-                "description": "Auth Forgot password",
+                "description": "Renew the access token with the refresh token, the refresh token is rotated: the response's refresh token replaces the used one. A used refresh token being replayed revokes its session",
                 "consumes": [
                     "application/json"
                 ],
@@ -243,7 +360,7 @@ var doc = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Auth Forgot password",
+                "summary": "Renew access token",
                 "responses": {
                     "200": {
                         "description": "Ok",
@@ -290,16 +407,16 @@ var doc = `{
                 }
             }
         },
-        "/menu": {
+        "/auth/sessions": {
             "get": {
-                "description": "Show list of menus by (optionally) by given limit of offset",
+                "description": "List the devices the owner is logged in on, the last seen first. The current one is the session of the request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu"
+                    "auth"
                 ],
-                "summary": "Show list of menus",
+                "summary": "List sessions",
                 "parameters": [
                     {
                         "type": "string",
@@ -308,20 +425,6 @@ var doc = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Pagination limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Pagination offset",
-                        "name": "offset",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -338,15 +441,15 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                    "$ref": "#/definitions/model.AuthSessionsResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "menu": {
+                                                        "sessions": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/model.GetMenuResponse"
+                                                                "$ref": "#/definitions/model.AuthOwnerSession"
                                                             }
                                                         }
                                                     }
@@ -364,6 +467,12 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -372,18 +481,15 @@ var doc = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a new menu",
-                "consumes": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Log out the owner from every device, the current one included",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu"
+                    "auth"
                 ],
-                "summary": "Create a menu",
+                "summary": "Log out everywhere",
                 "parameters": [
                     {
                         "type": "string",
@@ -392,46 +498,13 @@ var doc = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "body request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateMenuRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/web.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/model.MenuResponse"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "menu": {
-                                                            "$ref": "#/definitions/model.CreateMenuResponse"
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/web.JSONResponse"
                         }
                     },
                     "400": {
@@ -440,8 +513,8 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -455,16 +528,16 @@ var doc = `{
                 }
             }
         },
-        "/menu/{id}": {
-            "get": {
-                "description": "Show interest menu detail by given id",
+        "/auth/sessions/{sid}": {
+            "delete": {
+                "description": "Log out the owner's session of the given id (as listed by /auth/sessions)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu"
+                    "auth"
                 ],
-                "summary": "Get menu",
+                "summary": "Log out a session",
                 "parameters": [
                     {
                         "type": "string",
@@ -475,10 +548,9 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Menu id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "session id",
+                        "name": "sid",
                         "in": "path",
                         "required": true
                     }
@@ -487,31 +559,7 @@ var doc = `{
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/web.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/model.MenuResponse"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "menu": {
-                                                            "$ref": "#/definitions/model.GetMenuResponse"
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/web.JSONResponse"
                         }
                     },
                     "400": {
@@ -527,7 +575,7 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "Menu not found",
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -539,28 +587,19 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update menu by given id",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/auth/two-factor": {
+            "post": {
+                "description": "Generate the TOTP secret of the owner's authenticator app, it's enabled once a code is confirmed (see /auth/two-factor/confirm). Enrolling again replaces an unconfirmed secret",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu"
+                    "auth"
                 ],
-                "summary": "Update  menu",
+                "summary": "Enroll two-factor authentication",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Menu id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "default": "Bearer \u003cyour access token here\u003e",
@@ -568,15 +607,6 @@ var doc = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "body request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateMenuRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -593,13 +623,13 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                    "$ref": "#/definitions/model.AuthResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "menu": {
-                                                            "$ref": "#/definitions/model.UpdateMenuResponse"
+                                                        "auth": {
+                                                            "$ref": "#/definitions/model.AuthTwoFactorEnrollResponse"
                                                         }
                                                     }
                                                 }
@@ -610,20 +640,14 @@ var doc = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity",
+                    "409": {
+                        "description": "Two-factor authentication enabled already",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -635,25 +659,22 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete menu by given id",
+            }
+        },
+        "/auth/two-factor/confirm": {
+            "post": {
+                "description": "Enable the enrolled two-factor authentication with a code of the authenticator app, the recovery codes are only shown once",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu"
+                    "auth"
                 ],
-                "summary": "Delete menu",
+                "summary": "Confirm two-factor authentication",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Menu id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "default": "Bearer \u003cyour access token here\u003e",
@@ -661,13 +682,46 @@ var doc = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Confirm two-factor payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AuthTwoFactorConfirmRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.AuthResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "auth": {
+                                                            "$ref": "#/definitions/model.AuthTwoFactorConfirmResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -683,7 +737,19 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "Menu not found",
+                        "description": "Not enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication enabled already",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid code",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -697,16 +763,16 @@ var doc = `{
                 }
             }
         },
-        "/menu/{name}": {
+        "/business": {
             "get": {
-                "description": "Show interest menu detail by given name",
+                "description": "Show the business (catering kitchen) of the logged in owner",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu"
+                    "business"
                 ],
-                "summary": "Get menu by given name",
+                "summary": "Show the business",
                 "parameters": [
                     {
                         "type": "string",
@@ -715,13 +781,6 @@ var doc = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Menu name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -738,13 +797,13 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                    "$ref": "#/definitions/model.BusinessResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "menu": {
-                                                            "$ref": "#/definitions/model.GetMenuResponse"
+                                                        "business": {
+                                                            "$ref": "#/definitions/model.GetBusinessResponse"
                                                         }
                                                     }
                                                 }
@@ -755,12 +814,6 @@ var doc = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -768,7 +821,7 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "Menu not found",
+                        "description": "Business not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -780,11 +833,9 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/order": {
-            "post": {
-                "description": "Create a new order",
+            },
+            "put": {
+                "description": "Rename the business (catering kitchen) of the logged in owner, admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -792,9 +843,9 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "business"
                 ],
-                "summary": "Create order",
+                "summary": "Update the business",
                 "parameters": [
                     {
                         "type": "string",
@@ -810,7 +861,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateOrderRequest"
+                            "$ref": "#/definitions/model.UpdateBusinessRequest"
                         }
                     }
                 ],
@@ -828,13 +879,13 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                    "$ref": "#/definitions/model.BusinessResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "order": {
-                                                            "$ref": "#/definitions/model.CreateOrderResponse"
+                                                        "business": {
+                                                            "$ref": "#/definitions/model.UpdateBusinessResponse"
                                                         }
                                                     }
                                                 }
@@ -851,14 +902,14 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
                     "404": {
-                        "description": "Not found",
+                        "description": "Business not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -878,16 +929,100 @@ var doc = `{
                 }
             }
         },
-        "/order/confirm/": {
-            "put": {
-                "description": "Confirm unpaid order based on given email",
+        "/customer": {
+            "get": {
+                "description": "Show list of customers (without their addresses) by (optionally) by given limit of offset",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "customer"
                 ],
-                "summary": "Confirm order payment",
+                "summary": "Show list of customers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.CustomerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "customer": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetCustomerResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new customer, customers are created on their first order as well",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customer"
+                ],
+                "summary": "Create a customer",
                 "parameters": [
                     {
                         "type": "string",
@@ -898,12 +1033,12 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "customer email",
+                        "description": "body request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ConfirmPaymentRequest"
+                            "$ref": "#/definitions/model.CreateCustomerRequest"
                         }
                     }
                 ],
@@ -911,7 +1046,31 @@ var doc = `{
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.CustomerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "customer": {
+                                                            "$ref": "#/definitions/model.CreateCustomerResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -920,14 +1079,14 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Email already registered",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "422": {
+                        "description": "Unprocessable entity",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -941,19 +1100,16 @@ var doc = `{
                 }
             }
         },
-        "/order/search": {
+        "/customer/{id}": {
             "get": {
-                "description": "Search an order based on given query params",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Show customer detail along with its saved addresses (default address first) by given id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "customer"
                 ],
-                "summary": "Search order",
+                "summary": "Get customer",
                 "parameters": [
                     {
                         "type": "string",
@@ -963,119 +1119,13 @@ var doc = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "menu'ss name (if more than one name separated with comma without additional space)",
-                        "name": "menu-names",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "customer's email (if more than one email separated with comma without additional space)",
-                        "name": "emails",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "menu's name",
-                        "name": "exact-names",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "the number of menus ordered per menu",
-                        "name": "qty",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "maximum price of menu",
-                        "name": "max-price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "minimum price of menu",
-                        "name": "min-price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "status or ordered menu",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "ordered menu start at given day",
-                        "name": "start-day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "ordered menu end at given day",
-                        "name": "end-day",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Ok",
-                        "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/owner": {
-            "get": {
-                "description": "Show list of owners by (optionally) given limit and/or offset",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "owner"
-                ],
-                "summary": "Show list of owners",
-                "parameters": [
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "Pagination limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Pagination offset",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Customer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1092,16 +1142,13 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.OwnerResponse"
+                                                    "$ref": "#/definitions/model.CustomerResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "owner": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/model.GetOwnerResponse"
-                                                            }
+                                                        "customer": {
+                                                            "$ref": "#/definitions/model.GetCustomerResponse"
                                                         }
                                                     }
                                                 }
@@ -1118,6 +1165,18 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1126,8 +1185,8 @@ var doc = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create an owner through Sign up",
+            "put": {
+                "description": "Replace customer by given id, the orders keep the email they were placed with",
                 "consumes": [
                     "application/json"
                 ],
@@ -1135,17 +1194,33 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "customer"
                 ],
-                "summary": "Create an owner",
+                "summary": "Update customer",
                 "parameters": [
                     {
-                        "description": "Create owner payload",
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Customer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateOwnerRequest"
+                            "$ref": "#/definitions/model.UpdateCustomerRequest"
                         }
                     }
                 ],
@@ -1163,13 +1238,13 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.OwnerResponse"
+                                                    "$ref": "#/definitions/model.CustomerResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "owner": {
-                                                            "$ref": "#/definitions/model.CreateOwnerResponse"
+                                                        "customer": {
+                                                            "$ref": "#/definitions/model.UpdateCustomerResponse"
                                                         }
                                                     }
                                                 }
@@ -1186,6 +1261,18 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
                     "409": {
                         "description": "Email already registered",
                         "schema": {
@@ -1205,42 +1292,32 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/owner/reset-password/{rpid}": {
-            "put": {
-                "description": "Reset owner password by given rpid",
+            },
+            "delete": {
+                "description": "Delete customer along with its addresses by given id, a customer who has ordered can't be deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "customer"
                 ],
-                "summary": "Reset owner password (logged out state)",
+                "summary": "Delete customer",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Owner reset password id",
-                        "name": "rpid",
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Customer id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "Bearer \u003cAdd password token here\u003e",
-                        "description": "Insert your password token",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Reset Password payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ResetPasswordRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -1263,13 +1340,13 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "Owner not found",
+                        "description": "Customer not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity",
+                    "409": {
+                        "description": "Customer has ordered",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -1283,24 +1360,44 @@ var doc = `{
                 }
             }
         },
-        "/owner/{id}": {
-            "get": {
-                "description": "Show interest owner details",
+        "/customer/{id}/addresses": {
+            "post": {
+                "description": "Save a delivery address of the customer, the first address (or an address with is_default) becomes the default address",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "customer"
                 ],
-                "summary": "Get owner by given id",
+                "summary": "Add customer's address",
                 "parameters": [
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "Owner id",
+                        "description": "Customer id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerAddressRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1317,13 +1414,13 @@ var doc = `{
                                         "data": {
                                             "allOf": [
                                                 {
-                                                    "$ref": "#/definitions/model.OwnerResponse"
+                                                    "$ref": "#/definitions/model.CustomerResponse"
                                                 },
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "owner": {
-                                                            "$ref": "#/definitions/model.GetOwnerResponse"
+                                                        "customer": {
+                                                            "$ref": "#/definitions/model.GetCustomerResponse"
                                                         }
                                                     }
                                                 }
@@ -1347,7 +1444,13 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "Owner not found",
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -1359,40 +1462,53 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/customer/{id}/addresses/{address_id}": {
             "put": {
-                "description": "Update owner by given owner's id",
+                "description": "Replace the customer's address by given id, an address with is_default becomes the default address",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "customer"
                 ],
-                "summary": "Update owner",
+                "summary": "Update customer's address",
                 "parameters": [
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "Owner id",
+                        "description": "Customer id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Address id",
+                        "name": "address_id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "default": "Bearer \u003cyour access token here\u003e",
                         "description": "Insert your access token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Update owner payload",
+                        "description": "body request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateOwnerRequest"
+                            "$ref": "#/definitions/model.CustomerAddressRequest"
                         }
                     }
                 ],
@@ -1400,19 +1516,49 @@ var doc = `{
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Owner not found",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.CustomerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "customer": {
+                                                            "$ref": "#/definitions/model.GetCustomerResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Address not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
                     "422": {
@@ -1430,36 +1576,36 @@ var doc = `{
                 }
             },
             "delete": {
-                "description": "Delete owner by given owner's id",
+                "description": "Delete the customer's address by given id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "customer"
                 ],
-                "summary": "Delete owner",
+                "summary": "Delete customer's address",
                 "parameters": [
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "Owner id",
+                        "description": "Customer id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
-                        "description": "Insert your access token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Address id",
+                        "name": "address_id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "sid=\u003cAdd your session id\u003e",
-                        "description": "Session id",
-                        "name": "Cookie",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
                         "in": "header",
                         "required": true
                     }
@@ -1468,7 +1614,31 @@ var doc = `{
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.CustomerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "customer": {
+                                                            "$ref": "#/definitions/model.GetCustomerResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1477,8 +1647,14 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Owner not found",
+                        "description": "Address not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
@@ -1492,56 +1668,72 @@ var doc = `{
                 }
             }
         },
-        "/owner/{id}/reset-password": {
-            "put": {
-                "description": "Reset owner password by given id",
+        "/delivery/drivers": {
+            "get": {
+                "description": "Show list of drivers by (optionally) by given limit of offset",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "delivery"
                 ],
-                "summary": "Reset owner password (logged in state)",
+                "summary": "Show list of drivers",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Owner reset password id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "default": "Bearer \u003cyour access token here\u003e",
                         "description": "Insert your access token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "sid=\u003cAdd your session id\u003e",
-                        "description": "Session id",
-                        "name": "Cookie",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Reset Password payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ResetPasswordRequest"
-                        }
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DriverResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "driver": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetDriverResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1550,24 +1742,6 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Owner not found",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable entity",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1575,49 +1749,67 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/owner/{id}/update-email": {
-            "put": {
-                "description": "Update owner's email by given id",
+            },
+            "post": {
+                "description": "Create a new driver, only active drivers could be assigned orders",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "delivery"
                 ],
-                "summary": "Update Email owner",
+                "summary": "Create a driver",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Owner reset password id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "default": "Bearer \u003cyour access token here\u003e",
                         "description": "Insert your access token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "sid=\u003cAdd your session id\u003e",
-                        "description": "Session id",
-                        "name": "Cookie",
-                        "in": "header",
-                        "required": true
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateDriverRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/web.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DriverResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "driver": {
+                                                            "$ref": "#/definitions/model.CreateDriverResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1626,18 +1818,6 @@ var doc = `{
                             "$ref": "#/definitions/web.ErrJSONResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Owner not found",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrJSONResponse"
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable entity",
                         "schema": {
@@ -1652,103 +1832,6964 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AuthForgotPasswordRequest": {
-            "type": "object",
-            "required": [
-                "email"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                }
-            }
         },
-        "model.AuthLoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
+        "/delivery/drivers/{id}": {
+            "get": {
+                "description": "Show driver detail by given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Get driver",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DriverResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "driver": {
+                                                            "$ref": "#/definitions/model.GetDriverResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace driver by given id, an inactive driver keeps the orders already assigned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Update driver",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateDriverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DriverResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "driver": {
+                                                            "$ref": "#/definitions/model.UpdateDriverResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete driver by given id along with its undelivered stops, a driver who has delivered orders can't be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Delete driver",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Driver has delivered orders",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/drivers/{id}/assignments": {
+            "post": {
+                "description": "Assign the orders delivered on the date to an active driver and show the driver's route of the day.\nAn order assigned to another driver is moved unless it has been delivered, none of the orders is assigned when one of them can't be.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Assign orders to driver",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AssignDeliveryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryRouteResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "route": {
+                                                            "$ref": "#/definitions/model.GetDeliveryRouteResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can't be assigned",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/drivers/{id}/assignments/{order_id}": {
+            "delete": {
+                "description": "Remove the undelivered stop of the order from the driver's route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Unassign order from driver",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Undelivered stop not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/drivers/{id}/route": {
+            "get": {
+                "description": "Show the stops of the driver on a date in the order they should be delivered: batched by delivery slot and,\nwithin a slot, from the kitchen to the nearest stop not visited yet. The stops without coordinates end their slot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Driver's route",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "today",
+                        "description": "Delivery date (YYYY-MM-DD, today or tomorrow)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryRouteResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "route": {
+                                                            "$ref": "#/definitions/model.GetDeliveryRouteResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/drivers/{id}/stops/{order_id}/delivered": {
+            "put": {
+                "description": "Record the stop of the driver as delivered now and move its order (READY or OUT_FOR_DELIVERY) to DELIVERED",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Mark stop delivered",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Driver id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryStopResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "stop": {
+                                                            "$ref": "#/definitions/model.GetDeliveryStopResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stop not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid order status transition",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/quote": {
+            "get": {
+                "description": "Show the delivery fee and the minimum order of the zone delivering to the address of given postal code or coordinates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Quote delivery fee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal code",
+                        "name": "postal-code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude, along with longitude",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude, along with latitude",
+                        "name": "longitude",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryQuoteResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "quote": {
+                                                            "$ref": "#/definitions/model.GetDeliveryQuoteResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Address is outside of the delivery area",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/zones": {
+            "get": {
+                "description": "Show list of delivery zones by (optionally) by given limit of offset",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Show list of delivery zones",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryZoneResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "delivery_zone": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetDeliveryZoneResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new delivery zone defined by postal codes and/or a polygon of [latitude, longitude] points, with its delivery fee and minimum order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Create a delivery zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateDeliveryZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryZoneResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "delivery_zone": {
+                                                            "$ref": "#/definitions/model.CreateDeliveryZoneResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/delivery/zones/{id}": {
+            "get": {
+                "description": "Show delivery zone detail by given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Get delivery zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Delivery zone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryZoneResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "delivery_zone": {
+                                                            "$ref": "#/definitions/model.GetDeliveryZoneResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace delivery zone by given id, the orders already placed keep their delivery fee",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Update delivery zone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Delivery zone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateDeliveryZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.DeliveryZoneResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "delivery_zone": {
+                                                            "$ref": "#/definitions/model.UpdateDeliveryZoneResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete delivery zone by given id, the orders already placed keep their destination and delivery fee",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Delete delivery zone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Delivery zone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kitchen/production": {
+            "get": {
+                "description": "Show the portions to prepare for the orders (neither cancelled nor refunded) delivered on a date, summed by menu and grouped by the menu's first category with the breakdown per order.\nThe sheet is returned as JSON, a CSV file (one row per order of a menu) or a printable HTML page.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "text/html"
+                ],
+                "tags": [
+                    "kitchen"
+                ],
+                "summary": "Production sheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "today",
+                        "description": "Delivery date (YYYY-MM-DD, today or tomorrow)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "html"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.KitchenResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "production": {
+                                                            "$ref": "#/definitions/model.ProductionSheetResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu": {
+            "get": {
+                "description": "Show list of menus by (optionally) by given limit of offset",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Show list of menus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "menu": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetMenuResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new menu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Create a menu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateMenuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "menu": {
+                                                            "$ref": "#/definitions/model.CreateMenuResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu/{id}": {
+            "get": {
+                "description": "Show interest menu detail by given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Get menu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Menu id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "menu": {
+                                                            "$ref": "#/definitions/model.GetMenuResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update menu by given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Update  menu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Menu id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateMenuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "menu": {
+                                                            "$ref": "#/definitions/model.UpdateMenuResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete menu by given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Delete menu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Menu id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu/{id}/availability": {
+            "put": {
+                "description": "Update menu's stock and daily capacity by given id, null means unlimited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Update menu availability",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Menu id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateMenuAvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "menu": {
+                                                            "$ref": "#/definitions/model.GetMenuResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu/{name}": {
+            "get": {
+                "description": "Show interest menu detail by given name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu"
+                ],
+                "summary": "Get menu by given name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Menu name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.MenuResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "menu": {
+                                                            "$ref": "#/definitions/model.GetMenuResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order": {
+            "post": {
+                "description": "Create a new order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Create order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.CreateOrderResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Menu is sold out or delivery slot is full",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/confirm-payment": {
+            "put": {
+                "description": "Record a (partial) payment of an unpaid order, the order becomes PAID once its total price is covered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Confirm order payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "order id, amount and payment method",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ConfirmPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.ConfirmPaymentResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not payable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Amount exceeds the remaining balance",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/search": {
+            "get": {
+                "description": "Search an order based on given query params",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Search order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "menu'ss name (if more than one name separated with comma without additional space)",
+                        "name": "menu-names",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "customer's email (if more than one email separated with comma without additional space)",
+                        "name": "emails",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "menu's name",
+                        "name": "exact-names",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the number of menus ordered per menu",
+                        "name": "qty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "maximum price of menu",
+                        "name": "max-price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "minimum price of menu",
+                        "name": "min-price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "status or ordered menu",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ordered menu start at given day",
+                        "name": "start-day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ordered menu end at given day",
+                        "name": "end-day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "delivered on given day (YYYY-MM-DD, today or tomorrow)",
+                        "name": "delivery-date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "delivered on or after given day (YYYY-MM-DD, today or tomorrow)",
+                        "name": "delivery-start-day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "delivered on or before given day (YYYY-MM-DD, today or tomorrow)",
+                        "name": "delivery-end-day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{order_id}": {
+            "get": {
+                "description": "Show an order with all of its items and total price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.OrderDetailResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel an unpaid (NEW or CONFIRMED) order, paid order must be refunded instead. An order with payments (even partial) can't be cancelled",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.UpdateOrderStatusResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition (or order has payments)",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update customer's email of an unpaid (NEW or CONFIRMED) order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Update order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.OrderDetailResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not editable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{order_id}/history": {
+            "get": {
+                "description": "Show every status change of an order ordered from the oldest one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Order status history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.OrderStatusHistoryResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{order_id}/items": {
+            "post": {
+                "description": "Add a menu to an unpaid (NEW or CONFIRMED) order, qty is added up when the menu is already ordered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Add order item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.OrderDetailResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not editable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{order_id}/items/{base_order_id}": {
+            "put": {
+                "description": "Change qty of an item of an unpaid (NEW or CONFIRMED) order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Update order item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order item id",
+                        "name": "base_order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateOrderItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.OrderDetailResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not editable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an item of an unpaid (NEW or CONFIRMED) order, the last item can't be removed (cancel the order instead)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Delete order item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order item id",
+                        "name": "base_order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.OrderDetailResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not editable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{order_id}/status": {
+            "put": {
+                "description": "Move an order to the next status of its lifecycle (NEW, CONFIRMED, PREPARING, READY, OUT_FOR_DELIVERY, DELIVERED with the side states PAID, CANCELLED, REFUNDED), a CONFIRMED order may be prepared before it is paid. The kitchen only moves an order to PREPARING or READY and a driver to OUT_FOR_DELIVERY or DELIVERED, cancelling and refunding are for cashiers and admins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Order id",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OrderResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order": {
+                                                            "$ref": "#/definitions/model.UpdateOrderStatusResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (status not allowed for the role)",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner": {
+            "get": {
+                "description": "Show list of owners by (optionally) given limit and/or offset",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Show list of owners",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OwnerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "owner": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetOwnerResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an owner through Sign up",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Create an owner",
+                "parameters": [
+                    {
+                        "description": "Create owner payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOwnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OwnerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "owner": {
+                                                            "$ref": "#/definitions/model.CreateOwnerResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner/reset-password/{rpid}": {
+            "put": {
+                "description": "Reset owner password by given rpid, a rpid resets the password once and the owner is logged out of every device",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Reset owner password (logged out state)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner reset password id",
+                        "name": "rpid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd password token here\u003e",
+                        "description": "Insert your password token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Reset Password payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner/verify-email": {
+            "post": {
+                "description": "Send a new verification link to a registered owner whose email is not verified yet, the previous links are voided.\nThe answer is the same whether the email is registered, verified already or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Resend email verification",
+                "parameters": [
+                    {
+                        "description": "Resend email verification payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResendEmailVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner/verify-email/{token}": {
+            "get": {
+                "description": "Verify the registered email of an owner or the new email of an owner changing it by the token of the link sent to the email, the link is used once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Verify owner's email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email verification token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid or expired verification link",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner/{id}": {
+            "get": {
+                "description": "Show interest owner details",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Get owner by given id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Owner id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.OwnerResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "owner": {
+                                                            "$ref": "#/definitions/model.GetOwnerResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update owner by given owner's id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Update owner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Owner id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update owner payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateOwnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete owner by given owner's id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Delete owner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Owner id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "sid=\u003cAdd your session id\u003e",
+                        "description": "Session id",
+                        "name": "Cookie",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner/{id}/reset-password": {
+            "put": {
+                "description": "Reset owner password by given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Reset owner password (logged in state)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Owner reset password id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "sid=\u003cAdd your session id\u003e",
+                        "description": "Session id",
+                        "name": "Cookie",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Reset Password payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owner/{id}/update-email": {
+            "put": {
+                "description": "Send the verification link to the new email of the owner by given id, the email is updated once the link is clicked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "Update Email owner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Owner reset password id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "sid=\u003cAdd your session id\u003e",
+                        "description": "Session id",
+                        "name": "Cookie",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook/{provider}": {
+            "post": {
+                "description": "Receive a signed payment event from the payment provider, replayed event is acknowledged without being recorded twice",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "signature of the raw body",
+                        "name": "X-Webhook-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "payment provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payment event",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.PaymentResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "payment": {
+                                                            "$ref": "#/definitions/model.PaymentWebhookResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{provider}/charges": {
+            "post": {
+                "description": "Request a charge of an unpaid order to the payment provider, the customer pays it through the checkout url",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Create online payment charge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "payment provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "order id and amount (default to the remaining balance)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateChargeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.PaymentResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "payment": {
+                                                            "$ref": "#/definitions/model.CreateChargeResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or provider not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not payable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Amount exceeds the remaining balance",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotion": {
+            "get": {
+                "description": "Show list of promotions by (optionally) by given limit of offset",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Show list of promotions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.PromotionResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "promotion": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetPromotionResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new discount code, percentage or fixed amount discount optionally targeting menu's ids or categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Create a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.PromotionResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "promotion": {
+                                                            "$ref": "#/definitions/model.CreatePromotionResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo code already registered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotion/{id}": {
+            "get": {
+                "description": "Show promotion detail by given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Get promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Promotion id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.PromotionResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "promotion": {
+                                                            "$ref": "#/definitions/model.GetPromotionResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace promotion by given id, set ends_at to end a promotion which has been used",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Update promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Promotion id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.PromotionResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "promotion": {
+                                                            "$ref": "#/definitions/model.UpdatePromotionResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo code already registered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete promotion by given id, a promotion which has been used by an order can't be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Delete promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Promotion id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Promotion has been used",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/customers": {
+            "get": {
+                "description": "Show the lifetime spend (orders' grand total), order count, average order value, first and last order date, average days between orders and the 3 favourite menus\nof each customer's email. A customer is churned when it hasn't ordered for more than churn-days days. Only paid orders which haven't been refunded are counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Customer report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "lifetime_spend",
+                            "orders",
+                            "average_order_value",
+                            "first_order",
+                            "last_order",
+                            "email"
+                        ],
+                        "type": "string",
+                        "default": "lifetime_spend",
+                        "description": "Sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "Days without order to be churned",
+                        "name": "churn-days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.ReportResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "report": {
+                                                            "$ref": "#/definitions/model.CustomerReportResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "description": "Show the revenue (orders' grand total after discount, service charge and tax), order count, average order value and items sold of the orders created within a range,\ngrouped by day, week (starts on monday), month, menu, category (menu's first category) or customer's email. Only paid orders which haven't been refunded are counted.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Sales report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "menu",
+                            "category",
+                            "customer"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Grouping",
+                        "name": "group-by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD or today)",
+                        "name": "start-day",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, inclusive (YYYY-MM-DD or today)",
+                        "name": "end-day",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.ReportResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "report": {
+                                                            "$ref": "#/definitions/model.SalesReportResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff": {
+            "get": {
+                "description": "Show list of staff accounts and their role by (optionally) by given limit of offset, admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "staff"
+                ],
+                "summary": "Show list of staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.StaffResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "staff": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.GetStaffResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a staff account with the given role, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "staff"
+                ],
+                "summary": "Create a staff account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.StaffResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "staff": {
+                                                            "$ref": "#/definitions/model.GetStaffResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/{id}": {
+            "delete": {
+                "description": "Delete a staff account by given id, the last admin can't be deleted, admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "staff"
+                ],
+                "summary": "Delete staff account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Staff (owner) id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Last admin",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/{id}/role": {
+            "put": {
+                "description": "Change the role of a staff account, effective from the staff's next access token. The last admin can't lose its role, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "staff"
+                ],
+                "summary": "Update staff's role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Staff (owner) id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateStaffRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/model.StaffResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "staff": {
+                                                            "$ref": "#/definitions/model.GetStaffResponse"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Last admin",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/{id}/two-factor": {
+            "delete": {
+                "description": "Disable the two-factor authentication of a staff who lost its authenticator app and recovery codes, admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "staff"
+                ],
+                "summary": "Reset staff's two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Staff (owner) id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cyour access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/web.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrJSONResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AssignDeliveryRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "order_ids"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "order_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.AuthForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthLoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "model.AuthLoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "pre_auth_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AuthLoginVerifyRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "pre_auth_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "pre_auth_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthLogoutRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "model.AuthOwnerSession": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "the session of the request",
+                    "type": "boolean"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthRenewAccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken replaces the used refresh token, which is invalid from now",
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "auth": {}
+            }
+        },
+        "model.AuthSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {}
+            }
+        },
+        "model.AuthTwoFactorConfirmRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthTwoFactorConfirmResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.AuthTwoFactorEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "qty"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BusinessResponse": {
+            "type": "object",
+            "properties": {
+                "business": {}
+            }
+        },
+        "model.ConfirmPaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "order_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "method": {
+                    "description": "default to cash",
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "transfer",
+                        "card"
+                    ]
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.ConfirmPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "remaining_balance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_paid": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateChargeRequest": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "default to the remaining balance",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateChargeResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "charge_id": {
+                    "type": "string"
+                },
+                "checkout_url": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "dietary_notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "marketing_consent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "model.CreateCustomerResponse": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "omitted by the list and when there is none",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerAddressResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dietary_notes": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "marketing_consent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateDeliveryZoneRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "postal_codes"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "fee": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "min_order": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "polygon": {
+                    "description": "[[latitude, longitude], ...], at least a triangle",
+                    "type": "array",
+                    "minItems": 3,
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CreateDeliveryZoneResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_order": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateDriverRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "model.CreateDriverResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateMenuRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "price"
+            ],
+            "properties": {
+                "categories": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "price": {
+                    "description": "validated in minor units, at least 0.05",
+                    "type": "string",
+                    "minLength": 5
+                }
+            }
+        },
+        "model.CreateMenuResponse": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "description": "Availability is only shown by get and list menu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.MenuAvailabilityResponse"
+                        }
+                    ]
+                },
+                "categories": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "delivery_date"
+            ],
+            "properties": {
+                "customer": {
+                    "$ref": "#/definitions/model.CreateCustomerRequest"
+                },
+                "customer_address_id": {
+                    "description": "a saved address of the customer instead of DeliveryAddress",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "customer_email": {
+                    "description": "shortcut of customer's details with only the email",
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "delivery_address": {
+                    "description": "DeliveryAddress is required when there are delivery zones unless the customer has a default address",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DeliveryAddressRequest"
+                        }
+                    ]
+                },
+                "delivery_date": {
+                    "type": "string"
+                },
+                "delivery_slot": {
+                    "description": "DeliverySlot is one of the configured delivery slots (e.g. 10:00-12:00), required when there are delivery slots",
+                    "type": "string",
+                    "maxLength": 11
+                },
+                "notes": {
+                    "description": "e.g. allergies, shown on the kitchen's production sheet",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BaseOrderRequest"
+                    }
+                },
+                "promo_code": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "model.CreateOrderResponse": {
+            "type": "object",
+            "properties": {
+                "charge": {
+                    "$ref": "#/definitions/model.OrderChargeResponse"
+                },
+                "customer_email": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "delivery": {
+                    "$ref": "#/definitions/model.OrderDeliveryResponse"
+                },
+                "discount": {
+                    "$ref": "#/definitions/model.OrderDiscountResponse"
+                },
+                "message": {
+                    "description": "Orders        []BaseOrder ` + "`" + `json:\"orders\"` + "`" + `",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "description": "grand total",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateOwnerRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "phone_number"
+            ],
+            "properties": {
+                "business_name": {
+                    "description": "BusinessName is the name of the business run by the registering owner, the owner's name by default",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateOwnerResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreatePromotionRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "discount_type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "categories": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ]
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "max_discount": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "menu_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "min_spend": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "percentage": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "starts_at": {
+                    "description": "RFC3339, default now",
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "model.CreatePromotionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_discount": {
+                    "type": "string"
+                },
+                "menu_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "min_spend": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateStaffRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "phone_number",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "cashier",
+                        "kitchen",
+                        "driver",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "model.CustomerAddressRequest": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "is_default": {
+                    "description": "the previous default address is unset",
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 16
+                }
+            }
+        },
+        "model.CustomerAddressResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerFavouriteMenu": {
+            "type": "object",
+            "properties": {
+                "menu_id": {
+                    "type": "integer"
+                },
+                "menu_name": {
+                    "type": "string"
+                },
+                "qty": {
+                    "description": "portions ordered",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CustomerReportResponse": {
+            "type": "object",
+            "properties": {
+                "churn_days": {
+                    "type": "integer"
+                },
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerStatsResponse"
+                    }
+                },
+                "order": {
+                    "type": "string"
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "number of customers",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CustomerResponse": {
+            "type": "object",
+            "properties": {
+                "customer": {}
+            }
+        },
+        "model.CustomerStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average_days_between_orders": {
+                    "type": "number"
+                },
+                "average_order_value": {
+                    "type": "string"
+                },
+                "churned": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "favourite_menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerFavouriteMenu"
+                    }
+                },
+                "first_order_at": {
+                    "type": "string"
+                },
+                "last_order_at": {
+                    "type": "string"
+                },
+                "lifetime_spend": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DeliveryAddressRequest": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 16
+                }
+            }
+        },
+        "model.DeliveryAddressResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "postal_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeliveryQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "quote": {}
+            }
+        },
+        "model.DeliveryRouteResponse": {
+            "type": "object",
+            "properties": {
+                "route": {}
+            }
+        },
+        "model.DeliveryStopResponse": {
+            "type": "object",
+            "properties": {
+                "stop": {}
+            }
+        },
+        "model.DeliveryZoneResponse": {
+            "type": "object",
+            "properties": {
+                "delivery_zone": {}
+            }
+        },
+        "model.DriverResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {}
+            }
+        },
+        "model.GetBusinessResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetCustomerResponse": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "omitted by the list and when there is none",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerAddressResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dietary_notes": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "marketing_consent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetDeliveryQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "fee": {
+                    "type": "string"
+                },
+                "min_order": {
+                    "type": "string"
+                },
+                "zone_id": {
+                    "type": "integer"
+                },
+                "zone_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetDeliveryRouteResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "description": "straight line from the kitchen through every pinned stop",
+                    "type": "number"
+                },
+                "driver_id": {
+                    "type": "integer"
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetDeliveryStopResponse"
+                    }
+                }
+            }
+        },
+        "model.GetDeliveryStopResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/model.DeliveryAddressResponse"
+                },
+                "customer_email": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "description": "from the previous stop (or the kitchen), omitted when the stop isn't pinned",
+                    "type": "number"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetDeliveryZoneResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_order": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetDriverResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetMenuResponse": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "description": "Availability is only shown by get and list menu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.MenuAvailabilityResponse"
+                        }
+                    ]
+                },
+                "categories": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetOwnerResponse": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GetPromotionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_discount": {
+                    "type": "string"
+                },
+                "menu_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "min_spend": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GetStaffResponse": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.KitchenResponse": {
+            "type": "object",
+            "properties": {
+                "production": {}
+            }
+        },
+        "model.MenuAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "daily_capacity": {
+                    "description": "null is unlimited",
+                    "type": "integer"
+                },
+                "ordered_today": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "portions could still be ordered today, null is unlimited",
+                    "type": "integer"
+                },
+                "sold_out": {
+                    "type": "boolean"
+                },
+                "stock": {
+                    "description": "null is not tracked",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MenuResponse": {
+            "type": "object",
+            "properties": {
+                "menu": {}
+            }
+        },
+        "model.OrderChargeResponse": {
+            "type": "object",
+            "properties": {
+                "delivery_fee": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string"
+                },
+                "grand_total": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "service_charge": {
+                    "type": "string"
+                },
+                "sub_total": {
+                    "type": "string"
+                },
+                "tax": {
+                    "type": "string"
+                },
+                "tax_inclusive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.OrderDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/model.DeliveryAddressResponse"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "slot": {
+                    "description": "HH:MM-HH:MM",
+                    "type": "string"
+                },
+                "zone_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.OrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "charge": {
+                    "$ref": "#/definitions/model.OrderChargeResponse"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_email": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "delivery": {
+                    "$ref": "#/definitions/model.OrderDeliveryResponse"
+                },
+                "discount": {
+                    "$ref": "#/definitions/model.OrderDiscountResponse"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "description": "grand total",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderDiscountResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "promo_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "base_order_id": {
+                    "type": "integer"
+                },
+                "menu_id": {
+                    "type": "integer"
+                },
+                "menu_name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "sub_total": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "order": {}
+            }
+        },
+        "model.OrderStatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OwnerResponse": {
+            "type": "object",
+            "properties": {
+                "owner": {}
+            }
+        },
+        "model.PaymentEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "charge_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "payment": {}
+            }
+        },
+        "model.PaymentWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "order_status": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "processed, ignored, duplicate or refunded",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductionCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductionMenuResponse"
+                    }
+                },
+                "total_qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductionMenuResponse": {
+            "type": "object",
+            "properties": {
+                "menu_id": {
+                    "type": "integer"
+                },
+                "menu_name": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductionOrderResponse"
+                    }
+                },
+                "total_qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductionOrderResponse": {
+            "type": "object",
+            "properties": {
+                "customer_email": {
+                    "type": "string"
+                },
+                "delivery_slot": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductionSheetResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductionCategoryResponse"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "promotion": {}
+            }
+        },
+        "model.ReportResponse": {
+            "type": "object",
+            "properties": {
+                "report": {}
+            }
+        },
+        "model.ResendEmailVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "password_confirm"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "password_confirm": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "model.SalesReportResponse": {
+            "type": "object",
+            "properties": {
+                "end_day": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalesReportRowResponse"
+                    }
+                },
+                "start_day": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/model.SalesReportRowResponse"
+                }
+            }
+        },
+        "model.SalesReportRowResponse": {
+            "type": "object",
+            "properties": {
+                "average_order_value": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "items_sold": {
+                    "type": "integer"
+                },
+                "menu_id": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StaffResponse": {
+            "type": "object",
+            "properties": {
+                "staff": {}
+            }
+        },
+        "model.UpdateBusinessRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.UpdateBusinessResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UpdateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "email"
             ],
             "properties": {
+                "dietary_notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "marketing_consent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "model.UpdateCustomerResponse": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "omitted by the list and when there is none",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerAddressResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dietary_notes": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
-                "password": {
+                "id": {
+                    "type": "integer"
+                },
+                "marketing_consent": {
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
-                }
-            }
-        },
-        "model.AuthLoginResponse": {
-            "type": "object",
-            "properties": {
-                "access_token": {
+                },
+                "phone": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.AuthLogoutRequest": {
+        "model.UpdateDeliveryZoneRequest": {
             "type": "object",
             "required": [
-                "password"
+                "name",
+                "postal_codes"
             ],
             "properties": {
-                "password": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
+                },
+                "fee": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "min_order": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "polygon": {
+                    "description": "[[latitude, longitude], ...], at least a triangle",
+                    "type": "array",
+                    "minItems": 3,
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "model.AuthRenewAccessTokenResponse": {
+        "model.UpdateDeliveryZoneResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "expired_at": {
+                "fee": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_order": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.AuthResponse": {
+        "model.UpdateDriverRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "auth": {
-                    "type": "object"
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
                 }
             }
         },
-        "model.BaseOrderRequest": {
+        "model.UpdateDriverResponse": {
             "type": "object",
-            "required": [
-                "name",
-                "qty"
-            ],
             "properties": {
-                "name": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "qty": {
+                "id": {
                     "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "model.ConfirmPaymentRequest": {
+        "model.UpdateMenuAvailabilityRequest": {
             "type": "object",
-            "required": [
-                "email"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "daily_capacity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "model.CreateMenuRequest": {
+        "model.UpdateMenuRequest": {
             "type": "object",
             "required": [
                 "name",
@@ -1759,16 +8800,27 @@ var doc = `{
                     "type": "string"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 250
                 },
                 "price": {
-                    "type": "number"
+                    "description": "validated in minor units, at least 0.05",
+                    "type": "string",
+                    "minLength": 5
                 }
             }
         },
-        "model.CreateMenuResponse": {
+        "model.UpdateMenuResponse": {
             "type": "object",
             "properties": {
+                "availability": {
+                    "description": "Availability is only shown by get and list menu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.MenuAvailabilityResponse"
+                        }
+                    ]
+                },
                 "categories": {
                     "type": "string"
                 },
@@ -1779,11 +8831,23 @@ var doc = `{
                     "type": "string"
                 },
                 "price": {
-                    "type": "number"
+                    "type": "string"
                 }
             }
         },
-        "model.CreateOrderRequest": {
+        "model.UpdateOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "qty"
+            ],
+            "properties": {
+                "qty": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "model.UpdateOrderRequest": {
             "type": "object",
             "required": [
                 "customer_email"
@@ -1791,166 +8855,231 @@ var doc = `{
             "properties": {
                 "customer_email": {
                     "type": "string"
-                },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.BaseOrderRequest"
-                    }
                 }
             }
         },
-        "model.CreateOrderResponse": {
+        "model.UpdateOrderStatusRequest": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "customer_email": {
-                    "type": "string"
-                },
-                "message": {
-                    "description": "Orders        []BaseOrder ` + "`" + `json:\"orders\"` + "`" + `",
+                "status": {
                     "type": "string"
-                },
+                }
+            }
+        },
+        "model.UpdateOrderStatusResponse": {
+            "type": "object",
+            "properties": {
                 "order_id": {
                     "type": "integer"
                 },
-                "total_price": {
-                    "type": "number"
+                "previous_status": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "model.CreateOwnerRequest": {
+        "model.UpdateOwnerRequest": {
             "type": "object",
             "required": [
-                "email",
-                "name",
-                "password",
-                "phone_number"
+                "name"
             ],
             "properties": {
-                "email": {
+                "date_of_birth": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
-                },
                 "phone_number": {
                     "type": "string"
                 }
             }
         },
-        "model.CreateOwnerResponse": {
+        "model.UpdatePromotionRequest": {
             "type": "object",
+            "required": [
+                "code",
+                "discount_type"
+            ],
             "properties": {
-                "email": {
+                "amount": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "categories": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "code": {
+                    "type": "string",
+                    "maxLength": 32
                 },
-                "name": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "password": {
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ]
+                },
+                "ends_at": {
                     "type": "string"
                 },
-                "phone_number": {
+                "max_discount": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "menu_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "min_spend": {
+                    "type": "string",
+                    "minLength": 0
+                },
+                "percentage": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "starts_at": {
+                    "description": "RFC3339, default now",
                     "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "model.GetMenuResponse": {
-            "$ref": "#/definitions/model.CreateMenuResponse"
-        },
-        "model.GetOwnerResponse": {
+        "model.UpdatePromotionResponse": {
             "type": "object",
             "properties": {
-                "date_of_birth": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount": {
                     "type": "string"
                 },
-                "email": {
+                "categories": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "ends_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "max_discount": {
                     "type": "string"
                 },
-                "phone_number": {
+                "menu_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "min_spend": {
                     "type": "string"
+                },
+                "percentage": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer"
                 }
             }
         },
-        "model.MenuResponse": {
-            "type": "object",
-            "properties": {
-                "menu": {
-                    "type": "object"
-                }
-            }
-        },
-        "model.OrderResponse": {
-            "type": "object",
-            "properties": {
-                "order": {
-                    "type": "object"
-                }
-            }
-        },
-        "model.OwnerResponse": {
+        "model.UpdateStaffRoleRequest": {
             "type": "object",
+            "required": [
+                "role"
+            ],
             "properties": {
-                "owner": {
-                    "type": "object"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "cashier",
+                        "kitchen",
+                        "driver",
+                        "viewer"
+                    ]
                 }
             }
         },
-        "model.ResetPasswordRequest": {
+        "utils.JSONWebKey": {
             "type": "object",
-            "required": [
-                "password",
-                "password_confirm"
-            ],
             "properties": {
-                "password": {
+                "alg": {
                     "type": "string"
                 },
-                "password_confirm": {
+                "crv": {
                     "type": "string"
-                }
-            }
-        },
-        "model.UpdateMenuRequest": {
-            "$ref": "#/definitions/model.CreateMenuRequest"
-        },
-        "model.UpdateMenuResponse": {
-            "$ref": "#/definitions/model.CreateMenuResponse"
-        },
-        "model.UpdateOwnerRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "date_of_birth": {
+                },
+                "e": {
                     "type": "string"
                 },
-                "name": {
+                "kid": {
                     "type": "string"
                 },
-                "phone_number": {
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
                     "type": "string"
                 }
             }
         },
+        "utils.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.JSONWebKey"
+                    }
+                }
+            }
+        },
         "web.ErrJSONResponse": {
             "type": "object",
             "properties": {
                 "error": {
-                    "type": "object",
                     "$ref": "#/definitions/web.Error"
                 },
                 "process_time": {
@@ -1977,7 +9106,6 @@ var doc = `{
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "object",
                     "x-omitempty": true
                 },
                 "process_time": {
@@ -2002,49 +9130,18 @@ var doc = `{
     }
 }`
 
-type swaggerInfo struct {
-	Version     string
-	Host        string
-	BasePath    string
-	Schemes     []string
-	Title       string
-	Description string
-}
-
 // SwaggerInfo holds exported Swagger Info so clients can modify it
-var SwaggerInfo = swaggerInfo{
-	Version:     "1.0",
-	Host:        "localhost:9000",
-	BasePath:    "/api/v1",
-	Schemes:     []string{},
-	Title:       "Family Catering API",
-	Description: "Documentation for Family Catering API.",
-}
-
-type s struct{}
-
-func (s *s) ReadDoc() string {
-	sInfo := SwaggerInfo
-	sInfo.Description = strings.Replace(sInfo.Description, "\n", "\\n", -1)
-
-	t, err := template.New("swagger_info").Funcs(template.FuncMap{
-		"marshal": func(v interface{}) string {
-			a, _ := json.Marshal(v)
-			return string(a)
-		},
-	}).Parse(doc)
-	if err != nil {
-		return doc
-	}
-
-	var tpl bytes.Buffer
-	if err := t.Execute(&tpl, sInfo); err != nil {
-		return doc
-	}
-
-	return tpl.String()
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:9000",
+	BasePath:         "/api/v1",
+	Schemes:          []string{},
+	Title:            "Family catering API",
+	Description:      "API documentation for Family-catering service.",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
 }
 
 func init() {
-	swag.Register(swag.Name, &s{})
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
 }
@@ -1,13 +1,13 @@
 module family-catering
 
-go 1.17
+go 1.23
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.0
-	github.com/alecthomas/template v0.0.0-20190718012654-fb15b899a751
 	github.com/alicebob/miniredis/v2 v2.30.0
 	github.com/elliotchance/redismock/v8 v8.11.1
 	github.com/go-chi/chi v4.1.2+incompatible
+	github.com/go-chi/chi/v5 v5.3.2
 	github.com/go-chi/cors v1.2.1
 	github.com/go-chi/httprate v0.7.1
 	github.com/go-playground/validator/v10 v10.11.1
@@ -9,6 +9,7 @@ import (
 	"family-catering/pkg/db/redis"
 	"family-catering/pkg/logger"
 	"family-catering/pkg/server"
+	"family-catering/pkg/utils"
 	"fmt"
 	"os"
 	"os/signal"
@@ -40,6 +41,12 @@ func Run() error {
 
 	cfg := config.Cfg()
 
+	err := loadTokenKeys()
+	if err != nil {
+		err = fmt.Errorf("app.Run: %w", err)
+		logger.Fatal(err, "can't load the token keys")
+	}
+
 	pg, err := postgres.New(
 		cfg.Postgres.URL(),
 		postgres.WithMaxIdleConns(cfg.Postgres.IdleConnection),
@@ -87,12 +94,28 @@ func Run() error {
 	srv.Start()
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	// SIGHUP reload the token keys, the keys are rotated without restart
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
 
-	select {
-	case s := <-interrupt:
-		logger.Info("app.Run - os.Signal: " + s.String())
-	case err = <-srv.Notify():
-		logger.Error(err, "app.Run: "+err.Error())
+wait:
+	for {
+		select {
+		case <-reload:
+			err := loadTokenKeys()
+			if err != nil {
+				err = fmt.Errorf("app.Run: %w", err)
+				logger.Error(err, "error reload the token keys, the previous keys are kept")
+				continue
+			}
+			logger.Info("app.Run: token keys reloaded")
+		case s := <-interrupt:
+			logger.Info("app.Run - os.Signal: " + s.String())
+			break wait
+		case err = <-srv.Notify():
+			logger.Error(err, "app.Run: "+err.Error())
+			break wait
+		}
 	}
 
 	err = srv.Shutdown()
@@ -104,3 +127,18 @@ func Run() error {
 
 	return nil
 }
+
+func loadTokenKeys() error {
+	access, refresh, err := config.Cfg().Web.TokenKeys()
+	if err != nil {
+		return fmt.Errorf("app.loadTokenKeys: %w", err)
+	}
+
+	err = utils.SetTokenKeys(access, refresh)
+	if err != nil {
+		return fmt.Errorf("app.loadTokenKeys: %w", err)
+	}
+	utils.SetTokenIssuer(config.Cfg().Web.TokenIssuer, config.Cfg().Web.TokenAudience)
+
+	return nil
+}
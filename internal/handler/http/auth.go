@@ -20,9 +20,20 @@ type AuthHandler interface {
 	Logout() http.HandlerFunc
 	ForgotPassword() http.HandlerFunc
 	RenewAccessToken() http.HandlerFunc
+	JWKS() http.HandlerFunc
+	ListSessions() http.HandlerFunc
+	DeleteSession() http.HandlerFunc
+	DeleteAllSessions() http.HandlerFunc
+	LoginVerify() http.HandlerFunc
+	EnrollTwoFactor() http.HandlerFunc
+	ConfirmTwoFactor() http.HandlerFunc
 
 	AuthorizationRequired(next http.Handler) http.Handler
 	SessionRequired(next http.Handler) http.Handler
+	// RequireRole allows the request only when the access token has one of the roles
+	RequireRole(roles ...string) func(next http.Handler) http.Handler
+	// RequireSelfOrRole allows the request when the path param id is the token's owner or the token has one of the roles
+	RequireSelfOrRole(roles ...string) func(next http.Handler) http.Handler
 }
 
 type authHandler struct {
@@ -36,15 +47,17 @@ func NewAuthandler(authService service.AuthService) AuthHandler {
 // LoginAuth godoc
 //	@Router			/auth/login [post]
 //	@Summary		Login owner
-//	@Description	Login owner using registered email and password
+//	@Description	Login owner using registered email and password, an owner with two-factor authentication gets a pre-auth token to verify its code with (see /auth/login/verify). An owner logs in once its email is verified. An unregistered email answers as a wrong password
 //	@Tags			auth
 //	@Accept			json
 //	@produce		json
 //	@param			payload	body		model.AuthLoginRequest													true	"Create owner payload"
 //	@Success		200		{object}	web.JSONResponse{data=model.AuthResponse{auth=model.AuthLoginResponse}}	"Ok"
 //	@Failure		400		{object}	web.ErrJSONResponse														"Bad request"
-//	@Failure		400		{object}	web.ErrJSONResponse{}													"Not Found"
+//	@Failure		401		{object}	web.ErrJSONResponse														"Unauthorized (wrong email or password)"
+//	@Failure		403		{object}	web.ErrJSONResponse														"Email not verified"
 //	@Failure		422		{object}	web.ErrJSONResponse														"Unprocessable entity"
+//	@Failure		423		{object}	web.ErrJSONResponse														"Locked (too many failed logins)"
 //	@Failure		500		{object}	web.ErrJSONResponse														"Internal server error"
 func (handler *authHandler) Login() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -58,6 +71,8 @@ func (handler *authHandler) Login() http.HandlerFunc {
 			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request's payload", start)
 			return
 		}
+		req.UserAgent = r.UserAgent()
+		req.IP = web.ClientIP(r, config.Cfg().Web.TrustedProxies)
 
 		resp, err := handler.authService.Login(r.Context(), req)
 
@@ -65,13 +80,15 @@ func (handler *authHandler) Login() http.HandlerFunc {
 			web.WriteHTTPError(w, err, start)
 			return
 		}
-		// set sid to cookie
-		http.SetCookie(w, &http.Cookie{
-			Name:     consts.CookieSID,
-			Value:    resp.SID,
-			HttpOnly: true,
-			Expires:  time.Now().Add(config.Cfg().Web.RefreshTokenTTL), //? should use config?
-		})
+		// set sid to cookie, an owner with two-factor authentication has no session until its code is verified
+		if resp.SID != "" {
+			http.SetCookie(w, &http.Cookie{
+				Name:     consts.CookieSID,
+				Value:    resp.SID,
+				HttpOnly: true,
+				Expires:  time.Now().Add(config.Cfg().Web.RefreshTokenTTL), //? should use config?
+			})
+		}
 
 		payload := model.AuthResponse{Auth: resp}
 		web.WriteSuccessJSON(w, payload, start)
@@ -118,7 +135,7 @@ func (handler *authHandler) Logout() http.HandlerFunc {
 // ForgotPasswordAuth godoc
 //	@Router			/auth/forgot-password [put]
 //	@Summary		Auth Forgot password
-//	@Description	Auth Forgot password
+//	@Description	Send the reset password link to a registered email, the answer is the same whether the email is registered or not. A link resets the password once and a new link voids the previous one, the requests of an email are limited
 //	@Tags			auth
 //	@Accept			json
 //	@produce		json
@@ -126,8 +143,8 @@ func (handler *authHandler) Logout() http.HandlerFunc {
 //	@Success		200		{object}	web.JSONResponse				"Ok"
 //	@Failure		400		{object}	web.ErrJSONResponse				"Bad request"
 //	@Failure		401		{object}	web.ErrJSONResponse				"Unauthorized"
-//	@Failure		404		{object}	web.ErrJSONResponse				"Not found"
 //	@Failure		422		{object}	web.ErrJSONResponse				"Unprocessable entity"
+//	@Failure		429		{object}	web.ErrJSONResponse				"Too many password reset requests"
 //	@Failure		500		{object}	web.ErrJSONResponse				"Internal server error"
 func (handler *authHandler) ForgotPassword() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -152,7 +169,7 @@ func (handler *authHandler) ForgotPassword() http.HandlerFunc {
 			Name:     consts.CookieResetPasswordToken, //  used to validate given access token
 			Value:    token,
 			HttpOnly: true,
-			Expires:  time.Now().Add(config.Cfg().Web.AccessTokenTTL),
+			Expires:  time.Now().Add(config.Cfg().Web.PasswordReset.TokenTTL),
 		})
 
 		web.WriteSuccessJSON(w, nil, start)
@@ -161,8 +178,8 @@ func (handler *authHandler) ForgotPassword() http.HandlerFunc {
 
 // RenewAccessTokenAuth godoc
 //	@Router			/auth/renew-access-token [get]
-//	@Summary		Auth Forgot password
-//	@Description	Auth Forgot password
+//	@Summary		Renew access token
+//	@Description	Renew the access token with the refresh token, the refresh token is rotated: the response's refresh token replaces the used one. A used refresh token being replayed revokes its session
 //	@Tags			auth
 //	@Accept			json
 //	@produce		json
@@ -177,6 +194,13 @@ func (handler *authHandler) RenewAccessToken() http.HandlerFunc {
 			web.WriteHTTPError(w, err, start)
 			return
 		}
+		// the session lives as long as its last refresh token
+		http.SetCookie(w, &http.Cookie{
+			Name:     consts.CookieSID,
+			Value:    resp.SID,
+			HttpOnly: true,
+			Expires:  time.Now().Add(config.Cfg().Web.RefreshTokenTTL),
+		})
 
 		payload := model.AuthResponse{Auth: resp}
 		web.WriteSuccessJSON(w, payload, start)
@@ -184,6 +208,103 @@ func (handler *authHandler) RenewAccessToken() http.HandlerFunc {
 	}
 }
 
+// JWKS godoc
+//	@Router			/.well-known/jwks.json [get]
+//	@Summary		Public keys of the access tokens
+//	@Description	JSON Web Key Set (RFC 7517) of the RS256/EdDSA keys verifying the access tokens, served at the root (not under /api/v1)
+//	@Tags			auth
+//	@produce		json
+//	@Success		200	{object}	utils.JSONWebKeySet	"Ok"
+func (handler *authHandler) JWKS() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the keys are rotated, caches must refresh them before the retired ones expire
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		web.WriteJSON(w, http.StatusOK, handler.authService.JWKS(r.Context()))
+	}
+}
+
+// ListSessionsAuth godoc
+//	@Router			/auth/sessions [get]
+//	@Summary		List sessions
+//	@Description	List the devices the owner is logged in on, the last seen first. The current one is the session of the request
+//	@Tags			auth
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.AuthSessionsResponse{sessions=[]model.AuthOwnerSession}}	"Ok"
+//	@Failure		400	{object}	web.ErrJSONResponse																		"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse																		"Unauthorized"
+//	@Failure		500	{object}	web.ErrJSONResponse																		"Internal server error"
+func (handler *authHandler) ListSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		sessions, err := handler.authService.ListSessions(r.Context())
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.AuthSessionsResponse{Sessions: sessions}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteSessionAuth godoc
+//	@Router			/auth/sessions/{sid} [delete]
+//	@Summary		Log out a session
+//	@Description	Log out the owner's session of the given id (as listed by /auth/sessions)
+//	@Tags			auth
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Param			sid				path	string	true	"session id"
+//	@produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Not found"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+func (handler *authHandler) DeleteSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		err := handler.authService.DeleteSession(r.Context(), web.PathParamString(r, "sid"))
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// DeleteAllSessionsAuth godoc
+//	@Router			/auth/sessions [delete]
+//	@Summary		Log out everywhere
+//	@Description	Log out the owner from every device, the current one included
+//	@Tags			auth
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+func (handler *authHandler) DeleteAllSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		err := handler.authService.DeleteAllSessions(r.Context())
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		// the current session is gone too
+		http.SetCookie(w, &http.Cookie{
+			Name:     consts.CookieSID,
+			Value:    "",
+			HttpOnly: true,
+			MaxAge:   -1,
+		})
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
 func (handler *authHandler) AuthorizationRequired(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := web.RequestStartTimeFromContext(r.Context())
@@ -233,3 +354,156 @@ func (handler *authHandler) SessionRequired(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (handler *authHandler) RequireRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := web.RequestStartTimeFromContext(r.Context())
+			err := handler.authService.Authorize(r.Context(), 0, roles...)
+			if err != nil {
+				err = fmt.Errorf("handler.authHandler.RequireRole: %w", err)
+				log.Error(err, "request is not authorized")
+				web.WriteHTTPError(w, err, start)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (handler *authHandler) RequireSelfOrRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := web.RequestStartTimeFromContext(r.Context())
+			id, err := web.PathParamInt64(r, "id")
+			if err != nil {
+				err = fmt.Errorf("handler.authHandler.RequireSelfOrRole: %w", err)
+				log.Error(err, "invalid path params")
+				web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+				return
+			}
+
+			err = handler.authService.Authorize(r.Context(), id, roles...)
+			if err != nil {
+				err = fmt.Errorf("handler.authHandler.RequireSelfOrRole: %w", err)
+				log.Error(err, "request is not authorized")
+				web.WriteHTTPError(w, err, start)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// LoginVerifyAuth godoc
+//	@Router			/auth/login/verify [post]
+//	@Summary		Verify the two-factor code of a login
+//	@Description	Login the owner of the pre-auth token (see /auth/login) with a code of its authenticator app or one of its recovery codes
+//	@Tags			auth
+//	@Accept			json
+//	@produce		json
+//	@param			payload	body		model.AuthLoginVerifyRequest											true	"Verify login payload"
+//	@Success		200		{object}	web.JSONResponse{data=model.AuthResponse{auth=model.AuthLoginResponse}}	"Ok"
+//	@Failure		400		{object}	web.ErrJSONResponse														"Bad request"
+//	@Failure		401		{object}	web.ErrJSONResponse														"Unauthorized (invalid code or pre-auth token)"
+//	@Failure		422		{object}	web.ErrJSONResponse														"Unprocessable entity"
+//	@Failure		423		{object}	web.ErrJSONResponse														"Locked (too many failed logins)"
+//	@Failure		500		{object}	web.ErrJSONResponse														"Internal server error"
+func (handler *authHandler) LoginVerify() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.AuthLoginVerifyRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error(err, "error unmarshal request's payload")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request's payload", start)
+			return
+		}
+		req.UserAgent = r.UserAgent()
+		req.IP = web.ClientIP(r, config.Cfg().Web.TrustedProxies)
+
+		resp, err := handler.authService.LoginVerify(r.Context(), req)
+		if err != nil || resp == nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     consts.CookieSID,
+			Value:    resp.SID,
+			HttpOnly: true,
+			Expires:  time.Now().Add(config.Cfg().Web.RefreshTokenTTL),
+		})
+
+		payload := model.AuthResponse{Auth: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// EnrollTwoFactorAuth godoc
+//	@Router			/auth/two-factor [post]
+//	@Summary		Enroll two-factor authentication
+//	@Description	Generate the TOTP secret of the owner's authenticator app, it's enabled once a code is confirmed (see /auth/two-factor/confirm). Enrolling again replaces an unconfirmed secret
+//	@Tags			auth
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.AuthResponse{auth=model.AuthTwoFactorEnrollResponse}}	"Ok"
+//	@Failure		401	{object}	web.ErrJSONResponse																"Unauthorized"
+//	@Failure		409	{object}	web.ErrJSONResponse																"Two-factor authentication enabled already"
+//	@Failure		500	{object}	web.ErrJSONResponse																"Internal server error"
+func (handler *authHandler) EnrollTwoFactor() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		resp, err := handler.authService.EnrollTwoFactor(r.Context())
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.AuthResponse{Auth: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// ConfirmTwoFactorAuth godoc
+//	@Router			/auth/two-factor/confirm [post]
+//	@Summary		Confirm two-factor authentication
+//	@Description	Enable the enrolled two-factor authentication with a code of the authenticator app, the recovery codes are only shown once
+//	@Tags			auth
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string								true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.AuthTwoFactorConfirmRequest	true	"Confirm two-factor payload"
+//	@Success		200				{object}	web.JSONResponse{data=model.AuthResponse{auth=model.AuthTwoFactorConfirmResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse															"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse															"Not enrolled"
+//	@Failure		409				{object}	web.ErrJSONResponse															"Two-factor authentication enabled already"
+//	@Failure		422				{object}	web.ErrJSONResponse															"Invalid code"
+//	@Failure		500				{object}	web.ErrJSONResponse															"Internal server error"
+func (handler *authHandler) ConfirmTwoFactor() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.AuthTwoFactorConfirmRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error(err, "error unmarshal request's payload")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request's payload", start)
+			return
+		}
+
+		resp, err := handler.authService.ConfirmTwoFactor(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.AuthResponse{Auth: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"family-catering/config"
 	"family-catering/internal/model"
 	"family-catering/internal/service"
 	"family-catering/pkg/apperrors"
@@ -12,6 +14,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -55,12 +58,56 @@ func Test_authHandler_Login(t *testing.T) {
 			payload: `{"email":"test@example.com", "password":"12345pass"}`,
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/json")
-				m.authServiceMock.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
+				m.r.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64)")
+				m.authServiceMock.EXPECT().Login(gomock.Any(), model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", UserAgent: "Mozilla/5.0 (X11; Linux x86_64)", IP: "192.0.2.1"}).
+					Return(&model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
 
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody:       `{"success":true,"status":"success","data":{"auth":{"access_token":"access-token","refresh_token":"refresh-token"}},"process_time":0}`,
 		},
+		{
+			// a client rotating the forwarded header still fails its logins from its own ip (see loginAttemptRepository)
+			name:    "success hit api /api/v1/auth/login [post] 'forwarded ip of an untrusted client'",
+			handler: &authHandler{},
+			payload: `{"email":"test@example.com", "password":"12345pass"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("X-Forwarded-For", "203.0.113.7")
+				m.r.Header.Set("X-Real-IP", "203.0.113.8")
+				m.authServiceMock.EXPECT().Login(gomock.Any(), model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", IP: "192.0.2.1"}).
+					Return(&model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"access_token":"access-token","refresh_token":"refresh-token"}},"process_time":0}`,
+		},
+		{
+			name:    "success hit api /api/v1/auth/login [post] 'forwarded ip from a trusted proxy'",
+			handler: &authHandler{},
+			payload: `{"email":"test@example.com", "password":"12345pass"}`,
+			prepareMocks: func(m *mocks) {
+				config.Cfg().Web.TrustedProxies = []string{"192.0.2.0/24", "198.51.100.2"}
+				m.r.Header.Set("Content-Type", "application/json")
+				// the left-most ip is set by the client, the right-most untrusted one is set by the proxies
+				m.r.Header.Set("X-Forwarded-For", "10.1.1.1, 203.0.113.7, 198.51.100.2")
+				m.authServiceMock.EXPECT().Login(gomock.Any(), model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", IP: "203.0.113.7"}).
+					Return(&model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"access_token":"access-token","refresh_token":"refresh-token"}},"process_time":0}`,
+		},
+		{
+			name:    "success hit api /api/v1/auth/login [post] 'two-factor authentication required'",
+			handler: &authHandler{},
+			payload: `{"email":"test@example.com", "password":"12345pass"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.authServiceMock.EXPECT().Login(gomock.Any(), gomock.Any()).
+					Return(&model.AuthLoginResponse{TwoFactorRequired: true, PreAuthToken: "pre-auth-token"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"two_factor_required":true,"pre_auth_token":"pre-auth-token"}},"process_time":0}`,
+		},
 		{
 			name:    "fail hit api /api/v1/auth/login [post] 'unmarshal request payload'",
 			handler: &authHandler{},
@@ -96,6 +143,17 @@ func Test_authHandler_Login(t *testing.T) {
 			wantStatusCode: http.StatusUnprocessableEntity,
 			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
 		},
+		{
+			name:    "fail hit api /api/v1/auth/login [post] 'locked out'",
+			handler: &authHandler{},
+			payload: `{"email":"test@example.com","password":"wrongpass"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.authServiceMock.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, apperrors.ErrLoginLocked)
+			},
+			wantStatusCode: http.StatusLocked,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
 		{
 			name:    "fail hit api /api/v1/auth/login [post] 'not found email'",
 			handler: &authHandler{},
@@ -137,6 +195,8 @@ func Test_authHandler_Login(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			authServiceMock := service.NewMockAuthService(ctrl)
+			config.InitMock()
+			defer config.DestroyMock()
 			r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(tt.payload))
 			w := httptest.NewRecorder()
 
@@ -353,14 +413,14 @@ func Test_authHandler_ForgotPassword(t *testing.T) {
 			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
 		},
 		{
-			name:    "fail hit api /api/v1/auth/forgot-password [put] 'email not found'",
+			name:    "fail hit api /api/v1/auth/forgot-password [put] 'too many requests'",
 			handler: &authHandler{},
-			payload: `{"email":"invalid.email@.com"}`,
+			payload: `{"email":"test@example.com"}`,
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/json")
-				m.authServiceMock.EXPECT().ForgotPassword(gomock.Any(), gomock.Any()).Return("", apperrors.ErrNotFound)
+				m.authServiceMock.EXPECT().ForgotPassword(gomock.Any(), gomock.Any()).Return("", apperrors.ErrPasswordResetLimited)
 			},
-			wantStatusCode: http.StatusNotFound,
+			wantStatusCode: http.StatusTooManyRequests,
 			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
 		},
 		{
@@ -419,10 +479,10 @@ func Test_authHandler_RenewAccessToken(t *testing.T) {
 				m.r.Header.Set("Content-Type", "application/json")
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Session", model.AuthSessionResponse{Valid: true}))
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "refresh-token"))
-				m.authServiceMock.EXPECT().RenewAccessToken(gomock.Any()).Return(&model.AuthRenewAccessTokenResponse{AccessToken: "access-token", ExpiredAt: "2023-02-20:00.00"}, nil)
+				m.authServiceMock.EXPECT().RenewAccessToken(gomock.Any()).Return(&model.AuthRenewAccessTokenResponse{AccessToken: "access-token", RefreshToken: "new-refresh-token", SID: "sid", ExpiredAt: "2023-02-20:00.00"}, nil)
 			},
 			wantStatusCode: http.StatusOK,
-			wantBody:       `{"success":true,"status":"success","data":{"auth":{"access_token":"access-token","expired_at":"2023-02-20:00.00"}},"process_time":0}`,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"access_token":"access-token","refresh_token":"new-refresh-token","expired_at":"2023-02-20:00.00"}},"process_time":0}`,
 		},
 		{
 			name:    "fail hit /api/v1/auth/renew-access-token [get] 'error auth no invalid refresh-token'",
@@ -482,3 +542,495 @@ func Test_authHandler_RenewAccessToken(t *testing.T) {
 		})
 	}
 }
+
+func Test_authHandler_JWKS(t *testing.T) {
+	type mocks struct {
+		r               *http.Request
+		authServiceMock *service.MockAuthService
+	}
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit /.well-known/jwks.json [get] 'ok'",
+			handler: &authHandler{},
+			prepareMocks: func(m *mocks) {
+				m.authServiceMock.EXPECT().JWKS(gomock.Any()).Return(utils.JSONWebKeySet{Keys: []utils.JSONWebKey{
+					{Kty: "OKP", Kid: "2024-06", Use: "sig", Alg: utils.TokenAlgEdDSA, Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+				}})
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"keys":[{"kty":"OKP","kid":"2024-06","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`,
+		},
+		{
+			name:    "success hit /.well-known/jwks.json [get] 'no asymmetric keys'",
+			handler: &authHandler{},
+			prepareMocks: func(m *mocks) {
+				m.authServiceMock.EXPECT().JWKS(gomock.Any()).Return(utils.JSONWebKeySet{Keys: []utils.JSONWebKey{}})
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"keys":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			w := httptest.NewRecorder()
+
+			tt.handler.authService = authServiceMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{r: r, authServiceMock: authServiceMock})
+			}
+
+			handler := tt.handler.JWKS()
+			handler(w, r)
+
+			resp := w.Result()
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func Test_authHandler_RequireRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit role required route 'ok'",
+			handler: &authHandler{},
+			prepareMocks: func(m *service.MockAuthService) {
+				m.EXPECT().Authorize(gomock.Any(), int64(0), consts.RoleAdmin, consts.RoleCashier).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:    "fail hit role required route 'forbidden'",
+			handler: &authHandler{},
+			prepareMocks: func(m *service.MockAuthService) {
+				m.EXPECT().Authorize(gomock.Any(), int64(0), consts.RoleAdmin, consts.RoleCashier).Return(apperrors.ErrForbidden)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit role required route 'invalid token'",
+			handler: &authHandler{},
+			prepareMocks: func(m *service.MockAuthService) {
+				m.EXPECT().Authorize(gomock.Any(), int64(0), consts.RoleAdmin, consts.RoleCashier).Return(apperrors.ErrAuth)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/menu", nil)
+			w := httptest.NewRecorder()
+
+			tt.handler.authService = authServiceMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(authServiceMock)
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+			tt.handler.RequireRole(consts.RoleAdmin, consts.RoleCashier)(next).ServeHTTP(w, r)
+
+			resp := w.Result()
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			if tt.wantBody != "" {
+				gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+				assert.JSONEq(t, tt.wantBody, gotRespBody)
+			}
+		})
+	}
+}
+
+func Test_authHandler_RequireSelfOrRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		id             string
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+	}{
+		{
+			name:    "success hit self or role required route 'ok'",
+			handler: &authHandler{},
+			id:      "2",
+			prepareMocks: func(m *service.MockAuthService) {
+				m.EXPECT().Authorize(gomock.Any(), int64(2), consts.RoleAdmin).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:    "fail hit self or role required route 'forbidden'",
+			handler: &authHandler{},
+			id:      "2",
+			prepareMocks: func(m *service.MockAuthService) {
+				m.EXPECT().Authorize(gomock.Any(), int64(2), consts.RoleAdmin).Return(apperrors.ErrForbidden)
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "fail hit self or role required route 'invalid path params'",
+			handler:        &authHandler{},
+			id:             "two",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/owner/"+tt.id, nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			tt.handler.authService = authServiceMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(authServiceMock)
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+			tt.handler.RequireSelfOrRole(consts.RoleAdmin)(next).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatusCode, w.Result().StatusCode)
+		})
+	}
+}
+
+func Test_authHandler_ListSessions(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit /api/v1/auth/sessions [get] 'ok'",
+			handler: &authHandler{},
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().ListSessions(gomock.Any()).Return([]model.AuthOwnerSession{
+					{ID: "5f1d", UserAgent: "Mozilla/5.0 (X11; Linux x86_64)", IP: "10.0.0.3", Current: true, LastSeenAt: "2023-01-02T10:00:00Z", ExpiredAt: "2023-04-02T10:00:00Z", CreatedAt: "2023-01-02T09:00:00Z"},
+				}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"sessions":[{"id":"5f1d","user_agent":"Mozilla/5.0 (X11; Linux x86_64)","ip":"10.0.0.3","current":true,"last_seen_at":"2023-01-02T10:00:00Z","expired_at":"2023-04-02T10:00:00Z","created_at":"2023-01-02T09:00:00Z"}]},"process_time":0}`,
+		},
+		{
+			name:    "fail hit /api/v1/auth/sessions [get] 'unauthorized'",
+			handler: &authHandler{},
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().ListSessions(gomock.Any()).Return(nil, apperrors.ErrAuth)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+			w := httptest.NewRecorder()
+			tt.handler.authService = authServiceMock
+			tt.prepareMocks(authServiceMock)
+
+			tt.handler.ListSessions()(w, r)
+
+			gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, w.Result().StatusCode)
+			assert.JSONEq(t, tt.wantBody, gotRespBody)
+		})
+	}
+}
+
+func Test_authHandler_DeleteSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		sid            string
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+	}{
+		{
+			name:    "success hit /api/v1/auth/sessions/{sid} [delete] 'ok'",
+			handler: &authHandler{},
+			sid:     "5f1d",
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().DeleteSession(gomock.Any(), "5f1d").Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:    "fail hit /api/v1/auth/sessions/{sid} [delete] 'not found'",
+			handler: &authHandler{},
+			sid:     "a0b1",
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().DeleteSession(gomock.Any(), "a0b1").Return(apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/auth/sessions/"+tt.sid, nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("sid", tt.sid)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			tt.handler.authService = authServiceMock
+			tt.prepareMocks(authServiceMock)
+
+			tt.handler.DeleteSession()(w, r)
+
+			assert.Equal(t, tt.wantStatusCode, w.Result().StatusCode)
+		})
+	}
+}
+
+func Test_authHandler_DeleteAllSessions(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+		wantCookie     bool
+	}{
+		{
+			name:    "success hit /api/v1/auth/sessions [delete] 'ok'",
+			handler: &authHandler{},
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().DeleteAllSessions(gomock.Any()).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantCookie:     true,
+		},
+		{
+			name:    "error hit /api/v1/auth/sessions [delete] 'internal server error'",
+			handler: &authHandler{},
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().DeleteAllSessions(gomock.Any()).Return(errors.New("oops! error from redis"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/auth/sessions", nil)
+			w := httptest.NewRecorder()
+			tt.handler.authService = authServiceMock
+			tt.prepareMocks(authServiceMock)
+
+			tt.handler.DeleteAllSessions()(w, r)
+
+			resp := w.Result()
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			// the sid cookie is cleared
+			assert.Equal(t, tt.wantCookie, len(resp.Cookies()) == 1 && resp.Cookies()[0].Name == consts.CookieSID && resp.Cookies()[0].MaxAge < 0)
+		})
+	}
+}
+
+func Test_authHandler_LoginVerify(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		payload        string
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+		wantBody       string
+		wantCookie     bool
+	}{
+		{
+			name:    "success hit api /api/v1/auth/login/verify [post] 'ok'",
+			handler: &authHandler{},
+			payload: `{"pre_auth_token":"pre-auth-token","code":"287082"}`,
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().LoginVerify(gomock.Any(), model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: "287082", UserAgent: "Mozilla/5.0 (X11; Linux x86_64)", IP: "192.0.2.1"}).
+					Return(&model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"access_token":"access-token","refresh_token":"refresh-token"}},"process_time":0}`,
+			wantCookie:     true,
+		},
+		{
+			name:           "fail hit api /api/v1/auth/login/verify [post] 'unmarshal request payload'",
+			handler:        &authHandler{},
+			payload:        `{"pre_auth_token:"pre-auth-token"}`,
+			prepareMocks:   func(*service.MockAuthService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/auth/login/verify [post] 'invalid code'",
+			handler: &authHandler{},
+			payload: `{"pre_auth_token":"pre-auth-token","code":"000000"}`,
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().LoginVerify(gomock.Any(), gomock.Any()).Return(nil, apperrors.ErrAuth)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/auth/login/verify [post] 'locked out'",
+			handler: &authHandler{},
+			payload: `{"pre_auth_token":"pre-auth-token","code":"000000"}`,
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().LoginVerify(gomock.Any(), gomock.Any()).Return(nil, apperrors.ErrLoginLocked)
+			},
+			wantStatusCode: http.StatusLocked,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/verify", strings.NewReader(tt.payload))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64)")
+			w := httptest.NewRecorder()
+			tt.handler.authService = authServiceMock
+			tt.prepareMocks(authServiceMock)
+
+			tt.handler.LoginVerify()(w, r)
+
+			resp := w.Result()
+			gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, gotRespBody)
+			assert.Equal(t, tt.wantCookie, len(resp.Cookies()) == 1 && resp.Cookies()[0].Name == consts.CookieSID && resp.Cookies()[0].Value == "sid")
+		})
+	}
+}
+
+func Test_authHandler_EnrollTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit /api/v1/auth/two-factor [post] 'ok'",
+			handler: &authHandler{},
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().EnrollTwoFactor(gomock.Any()).Return(&model.AuthTwoFactorEnrollResponse{
+					Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+					URI:    "otpauth://totp/Family%20Catering:test@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+				}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"secret":"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ","otpauth_uri":"otpauth://totp/Family%20Catering:test@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit /api/v1/auth/two-factor [post] 'enabled already'",
+			handler: &authHandler{},
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().EnrollTwoFactor(gomock.Any()).Return(nil, apperrors.ErrTwoFactorEnabled)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/two-factor", nil)
+			w := httptest.NewRecorder()
+			tt.handler.authService = authServiceMock
+			tt.prepareMocks(authServiceMock)
+
+			tt.handler.EnrollTwoFactor()(w, r)
+
+			gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, w.Result().StatusCode)
+			assert.JSONEq(t, tt.wantBody, gotRespBody)
+		})
+	}
+}
+
+func Test_authHandler_ConfirmTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *authHandler
+		payload        string
+		prepareMocks   func(*service.MockAuthService)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit /api/v1/auth/two-factor/confirm [post] 'ok'",
+			handler: &authHandler{},
+			payload: `{"code":"287082"}`,
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().ConfirmTwoFactor(gomock.Any(), model.AuthTwoFactorConfirmRequest{Code: "287082"}).
+					Return(&model.AuthTwoFactorConfirmResponse{RecoveryCodes: []string{"abcde-23456", "fghjk-78923"}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"auth":{"recovery_codes":["abcde-23456","fghjk-78923"]}},"process_time":0}`,
+		},
+		{
+			name:           "fail hit /api/v1/auth/two-factor/confirm [post] 'unmarshal request payload'",
+			handler:        &authHandler{},
+			payload:        `{"code:"287082"}`,
+			prepareMocks:   func(*service.MockAuthService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit /api/v1/auth/two-factor/confirm [post] 'invalid code'",
+			handler: &authHandler{},
+			payload: `{"code":"000000"}`,
+			prepareMocks: func(authServiceMock *service.MockAuthService) {
+				authServiceMock.EXPECT().ConfirmTwoFactor(gomock.Any(), gomock.Any()).Return(nil, apperrors.ErrFieldValidation)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authServiceMock := service.NewMockAuthService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/two-factor/confirm", strings.NewReader(tt.payload))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			tt.handler.authService = authServiceMock
+			tt.prepareMocks(authServiceMock)
+
+			tt.handler.ConfirmTwoFactor()(w, r)
+
+			gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, w.Result().StatusCode)
+			assert.JSONEq(t, tt.wantBody, gotRespBody)
+		})
+	}
+}
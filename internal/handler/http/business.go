@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+)
+
+type BusinessHandler interface {
+	Get() http.HandlerFunc
+	Update() http.HandlerFunc
+}
+
+type businessHandler struct {
+	businessService service.BusinessService
+}
+
+// authorization token and role assume checked by authHandler middlewares, the business is the one of the token
+
+func NewBusinessHandler(businessService service.BusinessService) BusinessHandler {
+	return &businessHandler{businessService: businessService}
+}
+
+// GetBusiness godoc
+//	@Router			/business [get]
+//	@Summary		Show the business
+//	@Description	Show the business (catering kitchen) of the logged in owner
+//	@Tags			business
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.BusinessResponse{business=model.GetBusinessResponse}}	"Ok"
+//	@Failure		401	{object}	web.ErrJSONResponse																	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse																	"Business not found"
+//	@Failure		500	{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *businessHandler) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		business, err := handler.businessService.Get(r.Context())
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.BusinessResponse{Business: business}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateBusiness godoc
+//	@Router			/business [put]
+//	@Summary		Update the business
+//	@Description	Rename the business (catering kitchen) of the logged in owner, admin only
+//	@Tags			business
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdateBusinessRequest															true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.BusinessResponse{business=model.UpdateBusinessResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		403				{object}	web.ErrJSONResponse																	"Forbidden"
+//	@Failure		404				{object}	web.ErrJSONResponse																	"Business not found"
+//	@Failure		422				{object}	web.ErrJSONResponse																	"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *businessHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateBusinessRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.businessHandler.Update: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		business, err := handler.businessService.Update(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.BusinessResponse{Business: business}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
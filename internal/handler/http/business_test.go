@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBusinessHandler(t *testing.T) {
+	type args struct {
+		businessService service.BusinessService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewBusinessHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewBusinessHandler(tt.args.businessService))
+		})
+	}
+}
+
+func Test_businessHandler_Get(t *testing.T) {
+	type mocks struct {
+		r                   *http.Request
+		businessServiceMock *service.MockBusinessService
+	}
+	tests := []struct {
+		name           string
+		handler        *businessHandler
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/business [get] 'ok'",
+			handler: &businessHandler{},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.businessServiceMock.EXPECT().Get(m.r.Context()).
+					Return(&model.GetBusinessResponse{ID: 2, Name: "Dapur Bu Tini", CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"business":{"id":2,"name":"Dapur Bu Tini","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-10 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/business [get] 'not found'",
+			handler: &businessHandler{},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.businessServiceMock.EXPECT().Get(m.r.Context()).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/business [get] 'internal server error'",
+			handler: &businessHandler{},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.businessServiceMock.EXPECT().Get(m.r.Context()).Return(nil, errors.New("oops! db error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			businessServiceMock := service.NewMockBusinessService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/business", nil)
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, businessServiceMock: businessServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.businessService = m.businessServiceMock
+
+			handler := tt.handler.Get()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_businessHandler_Update(t *testing.T) {
+	type mocks struct {
+		r                   *http.Request
+		businessServiceMock *service.MockBusinessService
+	}
+	type params struct {
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *businessHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/business [put] 'ok'",
+			handler: &businessHandler{},
+			params:  params{payload: `{"name":"Dapur Bu Tini"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.businessServiceMock.EXPECT().Update(m.r.Context(), model.UpdateBusinessRequest{Name: "Dapur Bu Tini"}).
+					Return(&model.UpdateBusinessResponse{ID: 2, Name: "Dapur Bu Tini", CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-11 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"business":{"id":2,"name":"Dapur Bu Tini","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-11 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/business [put] 'invalid payload'",
+			handler: &businessHandler{},
+			params:  params{payload: `{"name":`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/business [put] 'blank name'",
+			handler: &businessHandler{},
+			params:  params{payload: `{"name":""}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.businessServiceMock.EXPECT().Update(m.r.Context(), model.UpdateBusinessRequest{}).
+					Return(nil, apperrors.ErrFieldValidationRequired)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			businessServiceMock := service.NewMockBusinessService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/business", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, businessServiceMock: businessServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.businessService = m.businessServiceMock
+
+			handler := tt.handler.Update()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+)
+
+type CustomerHandler interface {
+	GetByID() http.HandlerFunc
+	List() http.HandlerFunc
+	Create() http.HandlerFunc
+	Update() http.HandlerFunc
+	Delete() http.HandlerFunc
+	CreateAddress() http.HandlerFunc
+	UpdateAddress() http.HandlerFunc
+	DeleteAddress() http.HandlerFunc
+}
+
+type customerHandler struct {
+	customerService service.CustomerService
+}
+
+// authorization token assume exists on context passed by authHandler.Authorize middleware
+
+func NewCustomerHandler(customerService service.CustomerService) CustomerHandler {
+	return &customerHandler{customerService: customerService}
+}
+
+// GetCustomerByID godoc
+//	@Router			/customer/{id} [get]
+//	@Summary		Get customer
+//	@Description	Show customer detail along with its saved addresses (default address first) by given id
+//	@Tags			customer
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			id				path	int		true	"Customer id"				Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.CustomerResponse{customer=model.GetCustomerResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		404	{object}	web.ErrJSONResponse																	"Customer not found"
+//	@Failure		401	{object}	web.ErrJSONResponse																	"Unauthorized"
+func (handler *customerHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.GetByID: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		customer, err := handler.customerService.GetByID(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customer}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// ListCustomer godoc
+//	@Router			/customer [get]
+//	@Summary		Show list of customers
+//	@Description	Show list of customers (without their addresses) by (optionally) by given limit of offset
+//	@Tags			customer
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			limit			query	int		false	"Pagination limit"			Format(int64)
+//	@param			offset			query	int		false	"Pagination offset"			Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.CustomerResponse{customer=[]model.GetCustomerResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																	"Bad request"
+func (handler *customerHandler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		limit, offset, err := web.PaginationLimitOffset(r)
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.List: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid query params", start)
+			return
+		}
+
+		customers, err := handler.customerService.List(r.Context(), limit, offset)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customers}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// CreateCustomer godoc
+//	@Router			/customer [post]
+//	@Summary		Create a customer
+//	@Description	Create a new customer, customers are created on their first order as well
+//	@Tags			customer
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CreateCustomerRequest															true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.CustomerResponse{customer=model.CreateCustomerResponse}}	"Ok"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		409				{object}	web.ErrJSONResponse																	"Email already registered"
+//	@Failure		422				{object}	web.ErrJSONResponse																	"Unprocessable entity"
+func (handler *customerHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CreateCustomerRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.customerHandler.Create: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		customer, err := handler.customerService.Create(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customer}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateCustomer godoc
+//	@Router			/customer/{id} [put]
+//	@Summary		Update customer
+//	@Description	Replace customer by given id, the orders keep the email they were placed with
+//	@Tags			customer
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																					true	"Customer id"				Format(int64)
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdateCustomerRequest															true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.CustomerResponse{customer=model.UpdateCustomerResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																	"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																	"Customer not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																	"Email already registered"
+//	@Failure		422				{object}	web.ErrJSONResponse																	"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *customerHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateCustomerRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.Update: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.customerHandler.Update: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		customer, err := handler.customerService.Update(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customer}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteCustomer godoc
+//	@Router			/customer/{id} [delete]
+//	@Summary		Delete customer
+//	@Description	Delete customer along with its addresses by given id, a customer who has ordered can't be deleted
+//	@Tags			customer
+//	@param			id				path	int		true	"Customer id"				Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Customer not found"
+//	@Failure		409	{object}	web.ErrJSONResponse	"Customer has ordered"
+func (handler *customerHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.Delete: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		_, err = handler.customerService.Delete(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// CreateCustomerAddress godoc
+//	@Router			/customer/{id}/addresses [post]
+//	@Summary		Add customer's address
+//	@Description	Save a delivery address of the customer, the first address (or an address with is_default) becomes the default address
+//	@Tags			customer
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																				true	"Customer id"				Format(int64)
+//	@Param			Authorization	header		string																			true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CustomerAddressRequest													true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.CustomerResponse{customer=model.GetCustomerResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																"Customer not found"
+//	@Failure		422				{object}	web.ErrJSONResponse																"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																"Internal server error"
+func (handler *customerHandler) CreateAddress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CustomerAddressRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.CreateAddress: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.customerHandler.CreateAddress: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		customer, err := handler.customerService.CreateAddress(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customer}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateCustomerAddress godoc
+//	@Router			/customer/{id}/addresses/{address_id} [put]
+//	@Summary		Update customer's address
+//	@Description	Replace the customer's address by given id, an address with is_default becomes the default address
+//	@Tags			customer
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																				true	"Customer id"				Format(int64)
+//	@param			address_id		path		int																				true	"Address id"				Format(int64)
+//	@Param			Authorization	header		string																			true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CustomerAddressRequest													true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.CustomerResponse{customer=model.GetCustomerResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																"Address not found"
+//	@Failure		422				{object}	web.ErrJSONResponse																"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																"Internal server error"
+func (handler *customerHandler) UpdateAddress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CustomerAddressRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.UpdateAddress: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		addressID, err := web.PathParamInt64(r, "address_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.UpdateAddress: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.customerHandler.UpdateAddress: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		customer, err := handler.customerService.UpdateAddress(r.Context(), id, addressID, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customer}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteCustomerAddress godoc
+//	@Router			/customer/{id}/addresses/{address_id} [delete]
+//	@Summary		Delete customer's address
+//	@Description	Delete the customer's address by given id
+//	@Tags			customer
+//	@produce		json
+//	@param			id				path		int																				true	"Customer id"				Format(int64)
+//	@param			address_id		path		int																				true	"Address id"				Format(int64)
+//	@Param			Authorization	header		string																			true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Success		200				{object}	web.JSONResponse{data=model.CustomerResponse{customer=model.GetCustomerResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																"Address not found"
+//	@Failure		500				{object}	web.ErrJSONResponse																"Internal server error"
+func (handler *customerHandler) DeleteAddress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.DeleteAddress: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		addressID, err := web.PathParamInt64(r, "address_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.customerHandler.DeleteAddress: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		customer, err := handler.customerService.DeleteAddress(r.Context(), id, addressID)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.CustomerResponse{Customer: customer}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestNewCustomerHandler(t *testing.T) {
+	type args struct {
+		customerService service.CustomerService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewCustomerHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewCustomerHandler(tt.args.customerService))
+		})
+	}
+}
+
+func Test_customerHandler_GetByID(t *testing.T) {
+	type mocks struct {
+		r                   *http.Request
+		rctx                *chi.Context
+		customerServiceMock *service.MockCustomerService
+	}
+	type params struct {
+		id string
+	}
+	tests := []struct {
+		name           string
+		handler        *customerHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api/v1/customer/{id} [get] 'ok'",
+			handler: &customerHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.customerServiceMock.EXPECT().GetByID(m.r.Context(), int64(1)).
+					Return(&model.GetCustomerResponse{
+						ID: 1, Name: "Budi", Email: "budi@example.com", Phone: "0812", DietaryNotes: "no peanut",
+						Addresses: []*model.CustomerAddressResponse{{ID: 2, Label: "office", Address: "Jl. Sudirman 1", PostalCode: "10220", IsDefault: true}},
+						CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "customer": {
+					"id": 1,
+					"name": "Budi",
+					"email": "budi@example.com",
+					"phone": "0812",
+					"dietary_notes": "no peanut",
+					"marketing_consent": false,
+					"addresses": [
+						{"id":2,"label":"office","address":"Jl. Sudirman 1","postal_code":"10220","notes":"","is_default":true}
+					],
+					"created_at": "2022-11-01T00:00:00Z",
+					"updated_at": "2022-11-01T00:00:00Z"
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api/v1/customer/{id} [get] 'invalid path params'",
+			handler: &customerHandler{},
+			params:  params{id: "one"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "one")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/customer/{id} [get] 'not found'",
+			handler: &customerHandler{},
+			params:  params{id: "1000000"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1000000")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.customerServiceMock.EXPECT().GetByID(m.r.Context(), int64(1_000_000)).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerServiceMock := service.NewMockCustomerService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/customer/%s", tt.params.id), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, customerServiceMock: customerServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.customerService = m.customerServiceMock
+
+			handler := tt.handler.GetByID()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_customerHandler_Create(t *testing.T) {
+	type mocks struct {
+		r                   *http.Request
+		customerServiceMock *service.MockCustomerService
+	}
+	type params struct {
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *customerHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/customer [post] 'ok'",
+			handler: &customerHandler{},
+			params:  params{payload: `{"name":"Budi","email":"budi@example.com","marketing_consent":true}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.customerServiceMock.EXPECT().
+					Create(m.r.Context(), model.CreateCustomerRequest{Name: "Budi", Email: "budi@example.com", MarketingConsent: true}).
+					Return(&model.CreateCustomerResponse{
+						ID: 1, Name: "Budi", Email: "budi@example.com", MarketingConsent: true, Addresses: []*model.CustomerAddressResponse{},
+						CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "customer": {
+					"id": 1,
+					"name": "Budi",
+					"email": "budi@example.com",
+					"phone": "",
+					"dietary_notes": "",
+					"marketing_consent": true,
+					"created_at": "2022-11-01T00:00:00Z",
+					"updated_at": "2022-11-01T00:00:00Z"
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/customer [post] 'email registered'",
+			handler: &customerHandler{},
+			params:  params{payload: `{"email":"budi@example.com"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.customerServiceMock.EXPECT().
+					Create(m.r.Context(), model.CreateCustomerRequest{Email: "budi@example.com"}).
+					Return(nil, apperrors.ErrEmailRegistered)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/customer [post] 'error unmarshal request payload'",
+			handler: &customerHandler{},
+			params:  params{payload: `{"email":"budi@example.com","marketing_consent":"yes"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/customer [post] 'internal server error'",
+			handler: &customerHandler{},
+			params:  params{payload: `{"email":"budi@example.com"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.customerServiceMock.EXPECT().
+					Create(m.r.Context(), gomock.AssignableToTypeOf(model.CreateCustomerRequest{})).
+					Return(nil, errors.New("oops! error internal server"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerServiceMock := service.NewMockCustomerService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/customer", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, customerServiceMock: customerServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.customerService = m.customerServiceMock
+
+			handler := tt.handler.Create()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_customerHandler_Delete(t *testing.T) {
+	type mocks struct {
+		r                   *http.Request
+		rctx                *chi.Context
+		customerServiceMock *service.MockCustomerService
+	}
+	type params struct {
+		id string
+	}
+	tests := []struct {
+		name           string
+		handler        *customerHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/customer/{id} [delete] 'ok'",
+			handler: &customerHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.customerServiceMock.EXPECT().Delete(m.r.Context(), int64(1)).Return(int64(1), nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/customer/{id} [delete] 'customer has ordered'",
+			handler: &customerHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.customerServiceMock.EXPECT().Delete(m.r.Context(), int64(1)).Return(int64(0), apperrors.ErrCustomerInUse)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerServiceMock := service.NewMockCustomerService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/customer/%s", tt.params.id), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, customerServiceMock: customerServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.customerService = m.customerServiceMock
+
+			handler := tt.handler.Delete()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_customerHandler_UpdateAddress(t *testing.T) {
+	type mocks struct {
+		r                   *http.Request
+		rctx                *chi.Context
+		customerServiceMock *service.MockCustomerService
+	}
+	type params struct {
+		id, addressID string
+		payload       string
+	}
+	tests := []struct {
+		name           string
+		handler        *customerHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/customer/{id}/addresses/{address_id} [put] 'ok'",
+			handler: &customerHandler{},
+			params:  params{id: "1", addressID: "2", payload: `{"label":"home","address":"Jl. Kenanga 5","is_default":true}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				m.rctx.URLParams.Add("address_id", "2")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.customerServiceMock.EXPECT().
+					UpdateAddress(m.r.Context(), int64(1), int64(2), model.CustomerAddressRequest{Label: "home", Address: "Jl. Kenanga 5", IsDefault: true}).
+					Return(&model.GetCustomerResponse{
+						ID: 1, Email: "budi@example.com",
+						Addresses: []*model.CustomerAddressResponse{{ID: 2, Label: "home", Address: "Jl. Kenanga 5", IsDefault: true}},
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "customer": {
+					"id": 1,
+					"name": "",
+					"email": "budi@example.com",
+					"phone": "",
+					"dietary_notes": "",
+					"marketing_consent": false,
+					"addresses": [
+						{"id":2,"label":"home","address":"Jl. Kenanga 5","postal_code":"","notes":"","is_default":true}
+					],
+					"created_at": "",
+					"updated_at": ""
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/customer/{id}/addresses/{address_id} [put] 'invalid path params'",
+			handler: &customerHandler{},
+			params:  params{id: "1", addressID: "home", payload: `{"address":"Jl. Kenanga 5"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				m.rctx.URLParams.Add("address_id", "home")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/customer/{id}/addresses/{address_id} [put] 'not found'",
+			handler: &customerHandler{},
+			params:  params{id: "1", addressID: "5", payload: `{"address":"Jl. Kenanga 5"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				m.rctx.URLParams.Add("address_id", "5")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.customerServiceMock.EXPECT().
+					UpdateAddress(m.r.Context(), int64(1), int64(5), model.CustomerAddressRequest{Address: "Jl. Kenanga 5"}).
+					Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerServiceMock := service.NewMockCustomerService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/customer/%s/addresses/%s", tt.params.id, tt.params.addressID),
+				strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, customerServiceMock: customerServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.customerService = m.customerServiceMock
+
+			handler := tt.handler.UpdateAddress()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
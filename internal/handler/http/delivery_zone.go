@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type DeliveryZoneHandler interface {
+	GetByID() http.HandlerFunc
+	List() http.HandlerFunc
+	Create() http.HandlerFunc
+	Update() http.HandlerFunc
+	Delete() http.HandlerFunc
+	Quote() http.HandlerFunc
+}
+
+type deliveryZoneHandler struct {
+	deliveryZoneService service.DeliveryZoneService
+}
+
+// authorization token assume exists on context passed by authHandler.Authorize middleware
+
+func NewDeliveryZoneHandler(deliveryZoneService service.DeliveryZoneService) DeliveryZoneHandler {
+	return &deliveryZoneHandler{deliveryZoneService: deliveryZoneService}
+}
+
+// GetDeliveryZoneByID godoc
+//	@Router			/delivery/zones/{id} [get]
+//	@Summary		Get delivery zone
+//	@Description	Show delivery zone detail by given id
+//	@Tags			delivery
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			id				path	int		true	"Delivery zone id"			Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.DeliveryZoneResponse{delivery_zone=model.GetDeliveryZoneResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																			"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																			"Bad request"
+//	@Failure		404	{object}	web.ErrJSONResponse																			"Delivery zone not found"
+//	@Failure		401	{object}	web.ErrJSONResponse																			"Unauthorized"
+func (handler *deliveryZoneHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.deliveryZoneHandler.GetByID: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		zone, err := handler.deliveryZoneService.GetByID(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryZoneResponse{DeliveryZone: zone}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// ListDeliveryZone godoc
+//	@Router			/delivery/zones [get]
+//	@Summary		Show list of delivery zones
+//	@Description	Show list of delivery zones by (optionally) by given limit of offset
+//	@Tags			delivery
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			limit			query	int		false	"Pagination limit"			Format(int64)
+//	@param			offset			query	int		false	"Pagination offset"			Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.DeliveryZoneResponse{delivery_zone=[]model.GetDeliveryZoneResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																				"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																				"Bad request"
+func (handler *deliveryZoneHandler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		limit, offset, err := web.PaginationLimitOffset(r)
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.deliveryZoneHandler.List: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid query params", start)
+			return
+		}
+
+		zones, err := handler.deliveryZoneService.List(r.Context(), limit, offset)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryZoneResponse{DeliveryZone: zones}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// CreateDeliveryZone godoc
+//	@Router			/delivery/zones [post]
+//	@Summary		Create a delivery zone
+//	@Description	Create a new delivery zone defined by postal codes and/or a polygon of [latitude, longitude] points, with its delivery fee and minimum order
+//	@Tags			delivery
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																								true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CreateDeliveryZoneRequest																		true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.DeliveryZoneResponse{delivery_zone=model.CreateDeliveryZoneResponse}}	"Ok"
+//	@Failure		500				{object}	web.ErrJSONResponse																					"Internal server error"
+//	@Failure		400				{object}	web.ErrJSONResponse																					"Bad request"
+//	@Failure		422				{object}	web.ErrJSONResponse																					"Unprocessable entity"
+func (handler *deliveryZoneHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CreateDeliveryZoneRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.deliveryZoneHandler.Create: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		zone, err := handler.deliveryZoneService.Create(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryZoneResponse{DeliveryZone: zone}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateDeliveryZone godoc
+//	@Router			/delivery/zones/{id} [put]
+//	@Summary		Update delivery zone
+//	@Description	Replace delivery zone by given id, the orders already placed keep their delivery fee
+//	@Tags			delivery
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																									true	"Delivery zone id"			Format(int64)
+//	@Param			Authorization	header		string																								true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdateDeliveryZoneRequest																		true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.DeliveryZoneResponse{delivery_zone=model.UpdateDeliveryZoneResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																					"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																					"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																					"Delivery zone not found"
+//	@Failure		422				{object}	web.ErrJSONResponse																					"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																					"Internal server error"
+func (handler *deliveryZoneHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateDeliveryZoneRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.deliveryZoneHandler.Update: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.deliveryZoneHandler.Update: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		zone, err := handler.deliveryZoneService.Update(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryZoneResponse{DeliveryZone: zone}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteDeliveryZone godoc
+//	@Router			/delivery/zones/{id} [delete]
+//	@Summary		Delete delivery zone
+//	@Description	Delete delivery zone by given id, the orders already placed keep their destination and delivery fee
+//	@Tags			delivery
+//	@param			id				path	int		true	"Delivery zone id"			Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Delivery zone not found"
+func (handler *deliveryZoneHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.deliveryZoneHandler.Delete: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		_, err = handler.deliveryZoneService.Delete(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// QuoteDelivery godoc
+//	@Router			/delivery/quote [get]
+//	@Summary		Quote delivery fee
+//	@Description	Show the delivery fee and the minimum order of the zone delivering to the address of given postal code or coordinates
+//	@Tags			delivery
+//	@Param			Authorization	header		string																	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Param			postal-code		query		string																	false	"Postal code"
+//	@Param			latitude		query		number																	false	"Latitude, along with longitude"
+//	@Param			longitude		query		number																	false	"Longitude, along with latitude"
+//	@Produce		json
+//	@Success		200				{object}	web.JSONResponse{data=model.DeliveryQuoteResponse{quote=model.GetDeliveryQuoteResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse														"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse														"Unauthorized"
+//	@Failure		422				{object}	web.ErrJSONResponse														"Address is outside of the delivery area"
+//	@Failure		500				{object}	web.ErrJSONResponse														"Internal server error"
+func (handler *deliveryZoneHandler) Quote() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.DeliveryQuoteRequest{PostalCode: r.URL.Query().Get("postal-code")}
+
+		var err error
+		req.Latitude, err = coordinateParam(r, "latitude")
+		if err == nil {
+			req.Longitude, err = coordinateParam(r, "longitude")
+		}
+		if err != nil {
+			err = fmt.Errorf("handler.deliveryZoneHandler.Quote: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "latitude and longitude must be numbers", start)
+			return
+		}
+
+		quote, err := handler.deliveryZoneService.Quote(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryQuoteResponse{Quote: quote}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// coordinateParam return the coordinate of the query param, nil when it is missing
+func coordinateParam(r *http.Request, key string) (*float64, error) {
+	val := r.URL.Query().Get(key)
+	if val == "" {
+		return nil, nil
+	}
+
+	coordinate, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coordinate, nil
+}
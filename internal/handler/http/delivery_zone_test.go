@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeliveryZoneHandler(t *testing.T) {
+	type args struct {
+		deliveryZoneService service.DeliveryZoneService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewDeliveryZoneHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewDeliveryZoneHandler(tt.args.deliveryZoneService))
+		})
+	}
+}
+
+func Test_deliveryZoneHandler_Create(t *testing.T) {
+	type mocks struct {
+		r                       *http.Request
+		deliveryZoneServiceMock *service.MockDeliveryZoneService
+	}
+	type params struct {
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *deliveryZoneHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/delivery/zones [post] 'ok'",
+			handler: &deliveryZoneHandler{},
+			params: params{
+				payload: `{
+					"name":"South Jakarta",
+					"postal_codes":["12430","12440"],
+					"fee":"10000",
+					"min_order":"100000",
+					"active":true
+				  }`,
+			},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.deliveryZoneServiceMock.EXPECT().
+					Create(m.r.Context(), model.CreateDeliveryZoneRequest{
+						Name: "South Jakarta", PostalCodes: []string{"12430", "12440"}, Fee: money.MustParse("10000"),
+						MinOrder: money.MustParse("100000"), Active: true,
+					}).
+					Return(&model.CreateDeliveryZoneResponse{
+						ID: 1, Name: "South Jakarta", PostalCodes: []string{"12430", "12440"}, Fee: money.MustParse("10000"),
+						MinOrder: money.MustParse("100000"), Active: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "delivery_zone": {
+					"id": 1,
+					"name": "South Jakarta",
+					"postal_codes": ["12430", "12440"],
+					"fee": "10000.00",
+					"min_order": "100000.00",
+					"active": true,
+					"created_at": "2022-11-01T00:00:00Z",
+					"updated_at": "2022-11-01T00:00:00Z"
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/zones [post] 'invalid payload'",
+			handler: &deliveryZoneHandler{},
+			params:  params{payload: `{"name":"South Jakarta","polygon":"somewhere"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/zones [post] 'neither postal codes nor polygon'",
+			handler: &deliveryZoneHandler{},
+			params:  params{payload: `{"name":"South Jakarta"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.deliveryZoneServiceMock.EXPECT().
+					Create(m.r.Context(), model.CreateDeliveryZoneRequest{Name: "South Jakarta"}).
+					Return(nil, apperrors.ErrFieldValidationRequired)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			deliveryZoneServiceMock := service.NewMockDeliveryZoneService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/delivery/zones", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, deliveryZoneServiceMock: deliveryZoneServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.deliveryZoneService = m.deliveryZoneServiceMock
+
+			handler := tt.handler.Create()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_deliveryZoneHandler_Quote(t *testing.T) {
+	type mocks struct {
+		r                       *http.Request
+		deliveryZoneServiceMock *service.MockDeliveryZoneService
+	}
+	type params struct {
+		query string
+	}
+	latitude, longitude := -6.22, 106.82
+	tests := []struct {
+		name           string
+		handler        *deliveryZoneHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/delivery/quote [get] 'ok'",
+			handler: &deliveryZoneHandler{},
+			params:  params{query: "postal-code=12430&latitude=-6.22&longitude=106.82"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.deliveryZoneServiceMock.EXPECT().
+					Quote(m.r.Context(), model.DeliveryQuoteRequest{PostalCode: "12430", Latitude: &latitude, Longitude: &longitude}).
+					Return(&model.GetDeliveryQuoteResponse{
+						ZoneID: 1, ZoneName: "South Jakarta", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000"),
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "quote": {
+					"zone_id": 1,
+					"zone_name": "South Jakarta",
+					"fee": "10000.00",
+					"min_order": "100000.00"
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/quote [get] 'invalid coordinates'",
+			handler: &deliveryZoneHandler{},
+			params:  params{query: "latitude=south&longitude=106.82"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/quote [get] 'out of delivery area'",
+			handler: &deliveryZoneHandler{},
+			params:  params{query: "postal-code=40111"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.deliveryZoneServiceMock.EXPECT().
+					Quote(m.r.Context(), model.DeliveryQuoteRequest{PostalCode: "40111"}).
+					Return(nil, apperrors.ErrOutOfDeliveryArea)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/quote [get] 'internal server error'",
+			handler: &deliveryZoneHandler{},
+			params:  params{query: "postal-code=12430"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.deliveryZoneServiceMock.EXPECT().
+					Quote(m.r.Context(), gomock.AssignableToTypeOf(model.DeliveryQuoteRequest{})).
+					Return(nil, errors.New("oops! internal server error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			deliveryZoneServiceMock := service.NewMockDeliveryZoneService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/delivery/quote?"+tt.params.query, nil)
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, deliveryZoneServiceMock: deliveryZoneServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.deliveryZoneService = m.deliveryZoneServiceMock
+
+			handler := tt.handler.Quote()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
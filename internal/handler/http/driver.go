@@ -0,0 +1,413 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+)
+
+type DriverHandler interface {
+	GetByID() http.HandlerFunc
+	List() http.HandlerFunc
+	Create() http.HandlerFunc
+	Update() http.HandlerFunc
+	Delete() http.HandlerFunc
+	Assign() http.HandlerFunc
+	Unassign() http.HandlerFunc
+	Route() http.HandlerFunc
+	MarkDelivered() http.HandlerFunc
+}
+
+type driverHandler struct {
+	driverService service.DriverService
+}
+
+// authorization token assume exists on context passed by authHandler.Authorize middleware
+
+func NewDriverHandler(driverService service.DriverService) DriverHandler {
+	return &driverHandler{driverService: driverService}
+}
+
+// GetDriverByID godoc
+//	@Router			/delivery/drivers/{id} [get]
+//	@Summary		Get driver
+//	@Description	Show driver detail by given id
+//	@Tags			delivery
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			id				path	int		true	"Driver id"					Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.DriverResponse{driver=model.GetDriverResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse															"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		404	{object}	web.ErrJSONResponse															"Driver not found"
+//	@Failure		401	{object}	web.ErrJSONResponse															"Unauthorized"
+func (handler *driverHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.GetByID: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		driver, err := handler.driverService.GetByID(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DriverResponse{Driver: driver}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// ListDriver godoc
+//	@Router			/delivery/drivers [get]
+//	@Summary		Show list of drivers
+//	@Description	Show list of drivers by (optionally) by given limit of offset
+//	@Tags			delivery
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			limit			query	int		false	"Pagination limit"			Format(int64)
+//	@param			offset			query	int		false	"Pagination offset"			Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.DriverResponse{driver=[]model.GetDriverResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																"Bad request"
+func (handler *driverHandler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		limit, offset, err := web.PaginationLimitOffset(r)
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.List: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid query params", start)
+			return
+		}
+
+		drivers, err := handler.driverService.List(r.Context(), limit, offset)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DriverResponse{Driver: drivers}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// CreateDriver godoc
+//	@Router			/delivery/drivers [post]
+//	@Summary		Create a driver
+//	@Description	Create a new driver, only active drivers could be assigned orders
+//	@Tags			delivery
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																		true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CreateDriverRequest													true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.DriverResponse{driver=model.CreateDriverResponse}}	"Ok"
+//	@Failure		500				{object}	web.ErrJSONResponse															"Internal server error"
+//	@Failure		400				{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		422				{object}	web.ErrJSONResponse															"Unprocessable entity"
+func (handler *driverHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CreateDriverRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.driverHandler.Create: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		driver, err := handler.driverService.Create(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DriverResponse{Driver: driver}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateDriver godoc
+//	@Router			/delivery/drivers/{id} [put]
+//	@Summary		Update driver
+//	@Description	Replace driver by given id, an inactive driver keeps the orders already assigned
+//	@Tags			delivery
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																			true	"Driver id"					Format(int64)
+//	@Param			Authorization	header		string																		true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdateDriverRequest													true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.DriverResponse{driver=model.UpdateDriverResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse															"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse															"Driver not found"
+//	@Failure		422				{object}	web.ErrJSONResponse															"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse															"Internal server error"
+func (handler *driverHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateDriverRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.Update: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.driverHandler.Update: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		driver, err := handler.driverService.Update(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DriverResponse{Driver: driver}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteDriver godoc
+//	@Router			/delivery/drivers/{id} [delete]
+//	@Summary		Delete driver
+//	@Description	Delete driver by given id along with its undelivered stops, a driver who has delivered orders can't be deleted
+//	@Tags			delivery
+//	@param			id				path	int		true	"Driver id"					Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Driver not found"
+//	@Failure		409	{object}	web.ErrJSONResponse	"Driver has delivered orders"
+func (handler *driverHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.Delete: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		_, err = handler.driverService.Delete(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// AssignDelivery godoc
+//	@Router			/delivery/drivers/{id}/assignments [post]
+//	@Summary		Assign orders to driver
+//	@Description	Assign the orders delivered on the date to an active driver and show the driver's route of the day.
+//	@Description	An order assigned to another driver is moved unless it has been delivered, none of the orders is assigned when one of them can't be.
+//	@Tags			delivery
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																				true	"Driver id"					Format(int64)
+//	@Param			Authorization	header		string																			true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.AssignDeliveryRequest														true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.DeliveryRouteResponse{route=model.GetDeliveryRouteResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																"Driver not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																"Order can't be assigned"
+//	@Failure		422				{object}	web.ErrJSONResponse																"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																"Internal server error"
+func (handler *driverHandler) Assign() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.AssignDeliveryRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.Assign: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.driverHandler.Assign: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		route, err := handler.driverService.Assign(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryRouteResponse{Route: route}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UnassignDelivery godoc
+//	@Router			/delivery/drivers/{id}/assignments/{order_id} [delete]
+//	@Summary		Unassign order from driver
+//	@Description	Remove the undelivered stop of the order from the driver's route
+//	@Tags			delivery
+//	@param			id				path	int		true	"Driver id"					Format(int64)
+//	@param			order_id		path	int		true	"Order id"					Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Undelivered stop not found"
+func (handler *driverHandler) Unassign() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.Unassign: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.Unassign: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		_, err = handler.driverService.Unassign(r.Context(), id, orderID)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// DeliveryRoute godoc
+//	@Router			/delivery/drivers/{id}/route [get]
+//	@Summary		Driver's route
+//	@Description	Show the stops of the driver on a date in the order they should be delivered: batched by delivery slot and,
+//	@Description	within a slot, from the kitchen to the nearest stop not visited yet. The stops without coordinates end their slot.
+//	@Tags			delivery
+//	@Produce		json
+//	@param			id				path		int																				true	"Driver id"									Format(int64)
+//	@Param			Authorization	header		string																			true	"Insert your access token"					default(Bearer <your access token here>)
+//	@Param			date			query		string																			false	"Delivery date (YYYY-MM-DD, today or tomorrow)"	default(today)
+//	@Success		200				{object}	web.JSONResponse{data=model.DeliveryRouteResponse{route=model.GetDeliveryRouteResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																"Driver not found"
+//	@Failure		500				{object}	web.ErrJSONResponse																"Internal server error"
+func (handler *driverHandler) Route() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.Route: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			date = "today"
+		}
+		day, err := web.ParseDay(date)
+		if err != nil {
+			err = fmt.Errorf("handler.driverHandler.Route: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "date must be formatted as YYYY-MM-DD, today or tomorrow", start)
+			return
+		}
+
+		route, err := handler.driverService.Route(r.Context(), id, day)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryRouteResponse{Route: route}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// MarkDelivered godoc
+//	@Router			/delivery/drivers/{id}/stops/{order_id}/delivered [put]
+//	@Summary		Mark stop delivered
+//	@Description	Record the stop of the driver as delivered now and move its order (READY or OUT_FOR_DELIVERY) to DELIVERED
+//	@Tags			delivery
+//	@Produce		json
+//	@param			id				path		int																		true	"Driver id"					Format(int64)
+//	@param			order_id		path		int																		true	"Order id"					Format(int64)
+//	@Param			Authorization	header		string																	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Success		200				{object}	web.JSONResponse{data=model.DeliveryStopResponse{stop=model.GetDeliveryStopResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse														"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse														"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse														"Stop not found"
+//	@Failure		409				{object}	web.ErrJSONResponse														"Invalid order status transition"
+//	@Failure		500				{object}	web.ErrJSONResponse														"Internal server error"
+func (handler *driverHandler) MarkDelivered() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.MarkDelivered: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.driverHandler.MarkDelivered: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		stop, err := handler.driverService.MarkDelivered(r.Context(), id, orderID)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.DeliveryStopResponse{Stop: stop}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
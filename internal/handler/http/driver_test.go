@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDriverHandler(t *testing.T) {
+	type args struct {
+		driverService service.DriverService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewDriverHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewDriverHandler(tt.args.driverService))
+		})
+	}
+}
+
+func Test_driverHandler_Assign(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		rctx              *chi.Context
+		driverServiceMock *service.MockDriverService
+	}
+	type params struct {
+		id      string
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *driverHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/delivery/drivers/{id}/assignments [post] 'ok'",
+			handler: &driverHandler{},
+			params:  params{id: "1", payload: `{"date":"2023-01-02","order_ids":[1,2]}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.driverServiceMock.EXPECT().
+					Assign(m.r.Context(), int64(1), model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{1, 2}}).
+					Return(&model.GetDeliveryRouteResponse{DriverID: 1, Date: "2023-01-02", Distance: 3.34, Stops: []*model.GetDeliveryStopResponse{
+						{Sequence: 1, OrderID: 2, CustomerEmail: "ani@example.com", Slot: "08:00-10:00", Distance: 3.34, Status: "READY",
+							Address: &model.DeliveryAddressResponse{Address: "Jl. Melati 1"}},
+						{Sequence: 2, OrderID: 1, CustomerEmail: "budi@example.com", Slot: "10:00-12:00", Status: "READY"},
+					}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "route": {
+					"driver_id": 1,
+					"date": "2023-01-02",
+					"distance_km": 3.34,
+					"stops": [
+					  {
+						"sequence": 1,
+						"order_id": 2,
+						"customer_email": "ani@example.com",
+						"slot": "08:00-10:00",
+						"address": {"address": "Jl. Melati 1"},
+						"distance_km": 3.34,
+						"status": "READY"
+					  },
+					  {
+						"sequence": 2,
+						"order_id": 1,
+						"customer_email": "budi@example.com",
+						"slot": "10:00-12:00",
+						"status": "READY"
+					  }
+					]
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/drivers/{id}/assignments [post] 'invalid payload'",
+			handler: &driverHandler{},
+			params:  params{id: "1", payload: `{"date":"2023-01-02","order_ids":"1,2"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/drivers/{id}/assignments [post] 'order not assignable'",
+			handler: &driverHandler{},
+			params:  params{id: "1", payload: `{"date":"2023-01-02","order_ids":[3]}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.driverServiceMock.EXPECT().
+					Assign(m.r.Context(), int64(1), model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{3}}).
+					Return(nil, apperrors.ErrOrderNotAssignable)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverServiceMock := service.NewMockDriverService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/delivery/drivers/%s/assignments", tt.params.id), strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, driverServiceMock: driverServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.driverService = m.driverServiceMock
+
+			handler := tt.handler.Assign()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_driverHandler_Route(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		rctx              *chi.Context
+		driverServiceMock *service.MockDriverService
+	}
+	type params struct {
+		id    string
+		query string
+	}
+	tests := []struct {
+		name           string
+		handler        *driverHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/delivery/drivers/{id}/route [get] 'ok'",
+			handler: &driverHandler{},
+			params:  params{id: "1", query: "date=2023-01-02"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.driverServiceMock.EXPECT().Route(m.r.Context(), int64(1), "2023-01-02").
+					Return(&model.GetDeliveryRouteResponse{DriverID: 1, Date: "2023-01-02", Stops: []*model.GetDeliveryStopResponse{}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {"route": {"driver_id": 1, "date": "2023-01-02", "distance_km": 0, "stops": []}},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/drivers/{id}/route [get] 'invalid date'",
+			handler: &driverHandler{},
+			params:  params{id: "1", query: "date=yesterday"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/drivers/{id}/route [get] 'driver not found'",
+			handler: &driverHandler{},
+			params:  params{id: "1000000", query: "date=2023-01-02"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1000000")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.driverServiceMock.EXPECT().Route(m.r.Context(), int64(1_000_000), "2023-01-02").Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverServiceMock := service.NewMockDriverService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/delivery/drivers/%s/route?%s", tt.params.id, tt.params.query), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, driverServiceMock: driverServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.driverService = m.driverServiceMock
+
+			handler := tt.handler.Route()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_driverHandler_MarkDelivered(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		rctx              *chi.Context
+		driverServiceMock *service.MockDriverService
+	}
+	type params struct {
+		id      string
+		orderID string
+	}
+	tests := []struct {
+		name           string
+		handler        *driverHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/delivery/drivers/{id}/stops/{order_id}/delivered [put] 'ok'",
+			handler: &driverHandler{},
+			params:  params{id: "1", orderID: "2"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				m.rctx.URLParams.Add("order_id", "2")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.driverServiceMock.EXPECT().MarkDelivered(m.r.Context(), int64(1), int64(2)).
+					Return(&model.GetDeliveryStopResponse{OrderID: 2, CustomerEmail: "ani@example.com", Slot: "08:00-10:00",
+						Status: "DELIVERED", DeliveredAt: "2023-01-02T08:30:00Z"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "stop": {
+					"order_id": 2,
+					"customer_email": "ani@example.com",
+					"slot": "08:00-10:00",
+					"status": "DELIVERED",
+					"delivered_at": "2023-01-02T08:30:00Z"
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/drivers/{id}/stops/{order_id}/delivered [put] 'invalid path params'",
+			handler: &driverHandler{},
+			params:  params{id: "1", orderID: "two"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				m.rctx.URLParams.Add("order_id", "two")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/delivery/drivers/{id}/stops/{order_id}/delivered [put] 'delivered already'",
+			handler: &driverHandler{},
+			params:  params{id: "1", orderID: "2"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				m.rctx.URLParams.Add("order_id", "2")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.driverServiceMock.EXPECT().MarkDelivered(m.r.Context(), int64(1), int64(2)).Return(nil, apperrors.ErrOrderStatusTransition)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverServiceMock := service.NewMockDriverService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/delivery/drivers/%s/stops/%s/delivered", tt.params.id, tt.params.orderID), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, driverServiceMock: driverServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.driverService = m.driverServiceMock
+
+			handler := tt.handler.MarkDelivered()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type KitchenHandler interface {
+	Production() http.HandlerFunc
+}
+
+type kitchenHandler struct {
+	kitchenService service.KitchenService
+}
+
+// authorization token assume exists on context passed by authHandler.Authorize middleware
+
+func NewKitchenHandler(kitchenService service.KitchenService) KitchenHandler {
+	return &kitchenHandler{kitchenService: kitchenService}
+}
+
+// ProductionSheet godoc
+//	@Router			/kitchen/production [get]
+//	@Summary		Production sheet
+//	@Description	Show the portions to prepare for the orders (neither cancelled nor refunded) delivered on a date, summed by menu and grouped by the menu's first category with the breakdown per order.
+//	@Description	The sheet is returned as JSON, a CSV file (one row per order of a menu) or a printable HTML page.
+//	@Tags			kitchen
+//	@Produce		json,text/csv,html
+//	@Param			Authorization	header		string																	true	"Insert your access token"					default(Bearer <your access token here>)
+//	@Param			date			query		string																	false	"Delivery date (YYYY-MM-DD, today or tomorrow)"	default(today)
+//	@Param			format			query		string																	false	"Response format"							Enums(json, csv, html)	default(json)
+//	@Success		200				{object}	web.JSONResponse{data=model.KitchenResponse{production=model.ProductionSheetResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse														"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse														"Unauthorized"
+//	@Failure		422				{object}	web.ErrJSONResponse														"Invalid date"
+//	@Failure		500				{object}	web.ErrJSONResponse														"Internal server error"
+func (handler *kitchenHandler) Production() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			date = "today"
+		}
+		day, err := web.ParseDay(date)
+		if err != nil {
+			err = fmt.Errorf("handler.kitchenHandler.Production: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "date must be formatted as YYYY-MM-DD, today or tomorrow", start)
+			return
+		}
+
+		format := strings.ToLower(r.URL.Query().Get("format"))
+		if format != "" && format != "json" && format != "csv" && format != "html" {
+			err = fmt.Errorf("handler.kitchenHandler.Production: unknown format %q", format)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "format must be one of json, csv or html", start)
+			return
+		}
+
+		sheet, err := handler.kitchenService.Production(r.Context(), day)
+		if err != nil {
+			err = fmt.Errorf("handler.kitchenHandler.Production: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		switch format {
+		case "csv":
+			err = web.WriteCSV(w, fmt.Sprintf("production-%s.csv", sheet.Date), productionSheetRecords(sheet))
+		case "html":
+			err = web.WriteHTML(w, productionSheetTemplate, sheet)
+		default:
+			payload := model.KitchenResponse{Production: sheet}
+			web.WriteSuccessJSON(w, payload, start)
+		}
+		if err != nil {
+			err = fmt.Errorf("handler.kitchenHandler.Production: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+	}
+}
+
+// productionSheetRecords flatten the production sheet to one record per order of a menu
+func productionSheetRecords(sheet *model.ProductionSheetResponse) [][]string {
+	records := [][]string{{"date", "category", "menu_id", "menu_name", "menu_total_qty", "order_id", "customer_email", "delivery_slot", "status", "qty", "notes"}}
+	for _, category := range sheet.Categories {
+		for _, menu := range category.Menus {
+			for _, order := range menu.Orders {
+				records = append(records, []string{
+					sheet.Date,
+					category.Category,
+					strconv.FormatInt(menu.MenuID, 10),
+					menu.MenuName,
+					strconv.Itoa(menu.TotalQty),
+					strconv.FormatInt(order.OrderID, 10),
+					order.CustomerEmail,
+					order.DeliverySlot,
+					order.Status,
+					strconv.Itoa(order.Qty),
+					order.Notes,
+				})
+			}
+		}
+	}
+
+	return records
+}
+
+var productionSheetTemplate = template.Must(template.New("production").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Production sheet {{.Date}}</title>
+<style>
+	body { font-family: sans-serif; font-size: 12pt; margin: 1cm; }
+	h1 { font-size: 18pt; margin: 0 0 4pt; }
+	h2 { font-size: 14pt; border-bottom: 2px solid #000; margin: 16pt 0 4pt; }
+	h3 { font-size: 12pt; margin: 8pt 0 2pt; }
+	table { width: 100%; border-collapse: collapse; }
+	th, td { border: 1px solid #999; padding: 2pt 4pt; text-align: left; vertical-align: top; }
+	td.qty, th.qty { text-align: right; width: 3em; }
+	td.check { width: 2em; }
+	section, .menu { page-break-inside: avoid; }
+	@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>Production sheet {{.Date}}</h1>
+<p>{{.TotalOrders}} orders, {{.TotalQty}} portions</p>
+{{- range .Categories}}
+<section>
+<h2>{{.Category}} ({{.TotalQty}})</h2>
+{{- range .Menus}}
+<div class="menu">
+<h3>{{.MenuName}} &times; {{.TotalQty}}</h3>
+<table>
+<tr><th class="qty">Qty</th><th>Order</th><th>Customer</th><th>Slot</th><th>Status</th><th>Notes</th><th></th></tr>
+{{- range .Orders}}
+<tr><td class="qty">{{.Qty}}</td><td>#{{.OrderID}}</td><td>{{.CustomerEmail}}</td><td>{{.DeliverySlot}}</td><td>{{.Status}}</td><td>{{.Notes}}</td><td class="check">&#9744;</td></tr>
+{{- end}}
+</table>
+</div>
+{{- end}}
+</section>
+{{- else}}
+<p>No order to prepare.</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKitchenHandler(t *testing.T) {
+	type args struct {
+		kitchenService service.KitchenService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewKitchenHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewKitchenHandler(tt.args.kitchenService))
+		})
+	}
+}
+
+func Test_kitchenHandler_Production(t *testing.T) {
+	type mocks struct {
+		r                  *http.Request
+		kitchenServiceMock *service.MockKitchenService
+	}
+	type params struct {
+		date   string
+		format string
+	}
+	sheet := &model.ProductionSheetResponse{
+		Date: "2022-11-11", TotalOrders: 2, TotalQty: 6,
+		Categories: []*model.ProductionCategoryResponse{
+			{Category: "Indonesian food", TotalQty: 6, Menus: []*model.ProductionMenuResponse{
+				{MenuID: 2, MenuName: "sate", TotalQty: 6, Orders: []*model.ProductionOrderResponse{
+					{OrderID: 1, CustomerEmail: "test@example.com", Qty: 2, DeliverySlot: "07:00-09:00", Status: "PAID", Notes: "no peanut, <extra> spicy"},
+					{OrderID: 2, CustomerEmail: "test2@example.com", Qty: 4, Status: "NEW"},
+				}},
+			}},
+		},
+	}
+	tests := []struct {
+		name            string
+		handler         *kitchenHandler
+		params          params
+		prepareMocks    func(*mocks)
+		wantStatusCode  int
+		wantContentType string
+		wantBody        string // the whole JSON or CSV body, a part of the HTML body
+	}{
+		{
+			name:    "success hit api/v1/kitchen/production [get] 'ok'",
+			handler: &kitchenHandler{},
+			params:  params{date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.kitchenServiceMock.EXPECT().Production(m.r.Context(), "2022-11-11").Return(sheet, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/json",
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "production": {
+					"date": "2022-11-11",
+					"total_orders": 2,
+					"total_qty": 6,
+					"categories": [
+					  {
+						"category": "Indonesian food",
+						"total_qty": 6,
+						"menus": [
+						  {
+							"menu_id": 2,
+							"menu_name": "sate",
+							"total_qty": 6,
+							"orders": [
+							  {"order_id": 1, "customer_email": "test@example.com", "qty": 2, "delivery_slot": "07:00-09:00", "status": "PAID", "notes": "no peanut, <extra> spicy"},
+							  {"order_id": 2, "customer_email": "test2@example.com", "qty": 4, "status": "NEW"}
+							]
+						  }
+						]
+					  }
+					]
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "success hit api/v1/kitchen/production [get] 'ok' (csv)",
+			handler: &kitchenHandler{},
+			params:  params{date: "2022-11-11", format: "csv"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.kitchenServiceMock.EXPECT().Production(m.r.Context(), "2022-11-11").Return(sheet, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "date,category,menu_id,menu_name,menu_total_qty,order_id,customer_email,delivery_slot,status,qty,notes\n" +
+				"2022-11-11,Indonesian food,2,sate,6,1,test@example.com,07:00-09:00,PAID,2,\"no peanut, <extra> spicy\"\n" +
+				"2022-11-11,Indonesian food,2,sate,6,2,test2@example.com,,NEW,4,\n",
+		},
+		{
+			name:    "success hit api/v1/kitchen/production [get] 'ok' (html)",
+			handler: &kitchenHandler{},
+			params:  params{date: "2022-11-11", format: "HTML"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.kitchenServiceMock.EXPECT().Production(m.r.Context(), "2022-11-11").Return(sheet, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/html; charset=utf-8",
+			wantBody:        "<td>no peanut, &lt;extra&gt; spicy</td>",
+		},
+		{
+			name:    "success hit api/v1/kitchen/production [get] 'ok' (today by default)",
+			handler: &kitchenHandler{},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.kitchenServiceMock.EXPECT().Production(m.r.Context(), gomock.Any()).
+					Return(&model.ProductionSheetResponse{Date: "2022-11-11", Categories: []*model.ProductionCategoryResponse{}}, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/json",
+			wantBody: `{"success":true,"status":"success","data":{"production":{"date":"2022-11-11","total_orders":0,"total_qty":0,"categories":[]}},
+				"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/kitchen/production [get] 'invalid date'",
+			handler: &kitchenHandler{},
+			params:  params{date: "11/11/2022"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/kitchen/production [get] 'invalid format'",
+			handler: &kitchenHandler{},
+			params:  params{date: "2022-11-11", format: "pdf"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/kitchen/production [get] 'unauthorized'",
+			handler: &kitchenHandler{},
+			params:  params{date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.kitchenServiceMock.EXPECT().Production(m.r.Context(), "2022-11-11").Return(nil, apperrors.ErrAuth)
+			},
+			wantStatusCode:  http.StatusUnauthorized,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/kitchen/production [get] 'internal server error'",
+			handler: &kitchenHandler{},
+			params:  params{date: "2022-11-11", format: "csv"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.kitchenServiceMock.EXPECT().Production(m.r.Context(), "2022-11-11").Return(nil, errors.New("oops! error internal server"))
+			},
+			wantStatusCode:  http.StatusInternalServerError,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			kitchenServiceMock := service.NewMockKitchenService(ctrl)
+			query := url.Values{}
+			if tt.params.date != "" {
+				query.Set("date", tt.params.date)
+			}
+			if tt.params.format != "" {
+				query.Set("format", tt.params.format)
+			}
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/kitchen/production?"+query.Encode(), nil)
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, kitchenServiceMock: kitchenServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.kitchenService = m.kitchenServiceMock
+
+			handler := tt.handler.Production()
+
+			handler(w, r)
+
+			resp := w.Result()
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"))
+			switch {
+			case strings.HasPrefix(tt.wantContentType, "text/html"):
+				assert.Contains(t, w.Body.String(), tt.wantBody)
+			case strings.HasPrefix(tt.wantContentType, "text/csv"):
+				assert.Equal(t, `attachment; filename=production-2022-11-11.csv`, resp.Header.Get("Content-Disposition"))
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			default:
+				// resetting processing time to 0 & error message to a unchanged string
+				respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+				assert.JSONEq(t, tt.wantBody, respBodyStr)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ type MenuHandler interface {
 	List() http.HandlerFunc
 	Create() http.HandlerFunc
 	Update() http.HandlerFunc
+	UpdateAvailability() http.HandlerFunc
 	Delete() http.HandlerFunc
 }
 
@@ -223,6 +224,53 @@ func (handler *menuHandler) Update() http.HandlerFunc {
 	}
 }
 
+// UpdateMenuAvailability godoc
+//	@Router			/menu/{id}/availability [put]
+//	@Summary		Update menu availability
+//	@Description	Update menu's stock and daily capacity by given id, null means unlimited
+//	@Tags			menu
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																		true	"Menu id"					Format(int64)
+//	@Param			Authorization	header		string																	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdateMenuAvailabilityRequest										true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.MenuResponse{menu=model.GetMenuResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse														"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse														"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse														"Not found"
+//	@Failure		422				{object}	web.ErrJSONResponse														"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse														"Internal server error"
+func (handler *menuHandler) UpdateAvailability() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateMenuAvailabilityRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.menuHandler.UpdateAvailability: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.menuHandler.UpdateAvailability: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		menu, err := handler.menuService.UpdateAvailability(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+		payload := model.MenuResponse{Menu: menu}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
 // DeleteMenu godoc
 //	@Router			/menu/{id} [delete]
 //	@Summary		Delete menu
@@ -231,7 +279,7 @@ func (handler *menuHandler) Update() http.HandlerFunc {
 //	@param			id				path	int		true	"Menu id"					Format(int64)
 //	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
 //	@Produce		json
-//	@Success		200	{object}	web.JSONResponse	required	"Ok"
+//	@Success		200	{object}	web.JSONResponse	"Ok"
 //	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
 //	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
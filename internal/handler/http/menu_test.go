@@ -5,6 +5,7 @@ import (
 	"family-catering/internal/model"
 	"family-catering/internal/service"
 	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -63,7 +64,7 @@ func Test_menuHandler_GetByID(t *testing.T) {
 				m.rctx.URLParams.Add("id", "1")
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
 				m.menuServiceMock.EXPECT().GetByID(m.r.Context(), int64(1)).
-					Return(&model.GetMenuResponse{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"}, nil)
+					Return(&model.GetMenuResponse{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food"}, nil)
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody: `{
@@ -73,7 +74,7 @@ func Test_menuHandler_GetByID(t *testing.T) {
 				  "menu": {
 					"id": 1,
 					"name": "sate",
-					"price":25000,
+					"price":"25000.00",
 					"categories":"Indonesian food"
 				  }
 				},
@@ -216,7 +217,7 @@ func Test_menuHandler_GetByName(t *testing.T) {
 				m.rctx.URLParams.Add("name", "sate")
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
 				m.menuServiceMock.EXPECT().GetByName(m.r.Context(), "sate").
-					Return(&model.GetMenuResponse{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"}, nil)
+					Return(&model.GetMenuResponse{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food"}, nil)
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody: `{
@@ -226,7 +227,7 @@ func Test_menuHandler_GetByName(t *testing.T) {
 				  "menu": {
 					"id": 1,
 					"name": "sate",
-					"price":25000,
+					"price":"25000.00",
 					"categories":"Indonesian food"
 				  }
 				},
@@ -373,8 +374,8 @@ func Test_menuHandler_List(t *testing.T) {
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
 				m.menuServiceMock.EXPECT().List(m.r.Context(), 2, 1).
 					Return([]*model.GetMenuResponse{
-						{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"},
-						{ID: 2, Name: "soto babat", Price: 30_000, Categories: "Indonesian food"},
+						{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food"},
+						{ID: 2, Name: "soto babat", Price: money.MustParse("30000"), Categories: "Indonesian food"},
 					}, nil)
 			},
 			wantStatusCode: http.StatusOK,
@@ -386,13 +387,13 @@ func Test_menuHandler_List(t *testing.T) {
 					{
 					  "id": 1,
 					  "name": "sate",
-					  "price": 25000,
+					  "price":"25000.00",
 					  "categories": "Indonesian food"
 					},
 					{
 					  "id": 2,
 					  "name": "soto babat",
-					  "price": 30000,
+					  "price":"30000.00",
 					  "categories": "Indonesian food"
 					}
 				  ]
@@ -555,7 +556,7 @@ func Test_menuHandler_Create(t *testing.T) {
 				m.r.Header.Set("Authorization", "Bearer access-token")
 				m.menuServiceMock.EXPECT().
 					Create(m.r.Context(), gomock.AssignableToTypeOf(model.CreateMenuRequest{})).
-					Return(&model.CreateMenuResponse{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"}, nil)
+					Return(&model.CreateMenuResponse{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food"}, nil)
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody: `{
@@ -566,7 +567,7 @@ func Test_menuHandler_Create(t *testing.T) {
 					{
 					  "id": 1,
 					  "name": "sate",
-					  "price": 25000,
+					  "price":"25000.00",
 					  "categories": "Indonesian food"
 					}
 				},
@@ -725,7 +726,7 @@ func Test_menuHandler_Update(t *testing.T) {
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
 				m.menuServiceMock.EXPECT().
 					Update(m.r.Context(), int64(1), gomock.AssignableToTypeOf(model.UpdateMenuRequest{})).
-					Return(&model.UpdateMenuResponse{ID: 1, Name: "sate padang", Price: 30_000, Categories: "Indonesian food"}, nil)
+					Return(&model.UpdateMenuResponse{ID: 1, Name: "sate padang", Price: money.MustParse("30000"), Categories: "Indonesian food"}, nil)
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody: `{
@@ -736,7 +737,7 @@ func Test_menuHandler_Update(t *testing.T) {
 					{
 					  "id": 1,
 					  "name": "sate padang",
-					  "price": 30000,
+					  "price":"30000.00",
 					  "categories": "Indonesian food"
 					}
 				},
@@ -863,6 +864,143 @@ func Test_menuHandler_Update(t *testing.T) {
 	}
 }
 
+func Test_menuHandler_UpdateAvailability(t *testing.T) {
+	type mocks struct {
+		r               *http.Request
+		rctx            *chi.Context
+		menuServiceMock *service.MockMenuService
+	}
+	type params struct {
+		id      string
+		payload string
+	}
+	stock, dailyCapacity, remaining := 10, 50, 8
+	tests := []struct {
+		name           string
+		handler        *menuHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/menu/{id}/availability [put] 'ok'",
+			handler: &menuHandler{},
+			params:  params{id: "1", payload: `{"stock":10, "daily_capacity":50}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.menuServiceMock.EXPECT().
+					UpdateAvailability(m.r.Context(), int64(1), model.UpdateMenuAvailabilityRequest{Stock: &stock, DailyCapacity: &dailyCapacity}).
+					Return(&model.GetMenuResponse{
+						ID: 1, Name: "sate padang", Price: money.MustParse("30000"), Categories: "Indonesian food",
+						Availability: &model.MenuAvailabilityResponse{Stock: &stock, DailyCapacity: &dailyCapacity, OrderedToday: 42, Remaining: &remaining},
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "menu": 
+					{
+					  "id": 1,
+					  "name": "sate padang",
+					  "price":"30000.00",
+					  "categories": "Indonesian food",
+					  "availability": {"stock": 10, "daily_capacity": 50, "ordered_today": 42, "remaining": 8, "sold_out": false}
+					}
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/menu/{id}/availability [put] 'unmarshal error'",
+			handler: &menuHandler{},
+			params:  params{id: "1", payload: `{"stock":"ten"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/menu/{id}/availability [put] 'invalid path params'",
+			handler: &menuHandler{},
+			params:  params{id: "abc", payload: `{"stock":10}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "abc")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/menu/{id}/availability [put] 'not found'",
+			handler: &menuHandler{},
+			params:  params{id: "1", payload: `{"stock":10}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.menuServiceMock.EXPECT().
+					UpdateAvailability(m.r.Context(), int64(1), gomock.AssignableToTypeOf(model.UpdateMenuAvailabilityRequest{})).
+					Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/menu/{id}/availability [put] 'internal error'",
+			handler: &menuHandler{},
+			params:  params{id: "1", payload: `{"stock":10}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.menuServiceMock.EXPECT().
+					UpdateAvailability(m.r.Context(), int64(1), gomock.AssignableToTypeOf(model.UpdateMenuAvailabilityRequest{})).
+					Return(nil, errors.New("oops! error internal server"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			menuServiceMock := service.NewMockMenuService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/menu/%s/availability", tt.params.id), strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, menuServiceMock: menuServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.menuService = m.menuServiceMock
+
+			handler := tt.handler.UpdateAvailability()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
 func Test_menuHandler_Delete(t *testing.T) {
 	type mocks struct {
 		r               *http.Request
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"family-catering/internal/model"
 	"family-catering/internal/service"
 	log "family-catering/pkg/logger"
@@ -14,6 +15,14 @@ type OrderHandler interface {
 	Create() http.HandlerFunc
 	ConfirmPayment() http.HandlerFunc
 	Search() http.HandlerFunc
+	UpdateStatus() http.HandlerFunc
+	StatusHistory() http.HandlerFunc
+	Get() http.HandlerFunc
+	Update() http.HandlerFunc
+	Cancel() http.HandlerFunc
+	AddItem() http.HandlerFunc
+	UpdateItem() http.HandlerFunc
+	DeleteItem() http.HandlerFunc
 }
 
 type orderHandler struct {
@@ -37,6 +46,7 @@ func NewOrderHandler(orderService service.OrderService) OrderHandler {
 //	@Failure		400				{object}	web.ErrJSONResponse															"Bad request"
 //	@Failure		401				{object}	web.ErrJSONResponse															"Unauthorized"
 //	@Failure		404				{object}	web.ErrJSONResponse															"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse															"Menu is sold out or delivery slot is full"
 //	@Failure		422				{object}	web.ErrJSONResponse															"Unprocessable entity"
 //	@Failure		500				{object}	web.ErrJSONResponse															"Internal server error"
 func (handler *orderHandler) Create() http.HandlerFunc {
@@ -67,39 +77,43 @@ func (handler *orderHandler) Create() http.HandlerFunc {
 }
 
 // ConfirmPaymentOrder godoc
-//	@Router			/order/confirm/ [put]
+//	@Router			/order/confirm-payment [put]
 //	@Summary		Confirm order payment
-//	@Description	Confirm unpaid order based on given email
+//	@Description	Record a (partial) payment of an unpaid order, the order becomes PAID once its total price is covered
 //	@Tags			order
+//	@Accept			json
 //	@produce		json
-//	@Param			Authorization	header		string				true	"Insert your access token"	default(Bearer <your access token here>)
-//	@param			payload			body		model.ConfirmPaymentRequest				true	"customer email"
-//	@Success		200				{object}	web.JSONResponse	"Ok"
-//	@Failure		400				{object}	web.ErrJSONResponse	"Bad request"
-//	@Failure		401				{object}	web.ErrJSONResponse	"Unauthorized"
-//	@Failure		404				{object}	web.ErrJSONResponse	"Not found"
-//	@Failure		500				{object}	web.ErrJSONResponse	"Internal server error"
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.ConfirmPaymentRequest															true	"order id, amount and payment method"
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.ConfirmPaymentResponse}}		"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																	"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																	"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																	"Order is not payable"
+//	@Failure		422				{object}	web.ErrJSONResponse																	"Amount exceeds the remaining balance"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
 func (handler *orderHandler) ConfirmPayment() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := web.RequestStartTimeFromContext(r.Context())
-		// email := web.PathParamString(r, "email")
 		req := model.ConfirmPaymentRequest{}
 		defer r.Body.Close()
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			err := fmt.Errorf("handler.orderHandler.Create: %w", err)
+			err := fmt.Errorf("handler.orderHandler.ConfirmPayment: %w", err)
 			log.Error(err, "error unmarshal request")
 			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
 			return
 		}
 
-		err = handler.orderService.ConfirmPayment(r.Context(), req)
+		resp, err := handler.orderService.ConfirmPayment(r.Context(), req)
 		if err != nil {
 			err = fmt.Errorf("handler.orderHandler.ConfirmPayment: %w", err)
 			web.WriteHTTPError(w, err, start)
 			return
 		}
-		web.WriteSuccessJSON(w, nil, start)
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
 	}
 }
 
@@ -122,6 +136,9 @@ func (handler *orderHandler) ConfirmPayment() http.HandlerFunc {
 //	@param			status			query		string				false	"status or ordered menu"
 //	@param			start-day		query		string				false	"ordered menu start at given day"
 //	@param			end-day			query		string				false	"ordered menu end at given day"
+//	@param			delivery-date		query		string				false	"delivered on given day (YYYY-MM-DD, today or tomorrow)"
+//	@param			delivery-start-day	query		string				false	"delivered on or after given day (YYYY-MM-DD, today or tomorrow)"
+//	@param			delivery-end-day	query		string				false	"delivered on or before given day (YYYY-MM-DD, today or tomorrow)"
 //	@Success		200				{object}	web.JSONResponse	"Ok"
 //	@Failure		400				{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		401				{object}	web.ErrJSONResponse	"Unauthorized"
@@ -150,3 +167,374 @@ func (handler *orderHandler) Search() http.HandlerFunc {
 		web.WriteSuccessJSON(w, payload, start)
 	}
 }
+
+// UpdateStatusOrder godoc
+//	@Router			/order/{order_id}/status [put]
+//	@Summary		Update order status
+//	@Description	Move an order to the next status of its lifecycle (NEW, CONFIRMED, PREPARING, READY, OUT_FOR_DELIVERY, DELIVERED with the side states PAID, CANCELLED, REFUNDED), a CONFIRMED order may be prepared before it is paid. The kitchen only moves an order to PREPARING or READY and a driver to OUT_FOR_DELIVERY or DELIVERED, cancelling and refunding are for cashiers and admins
+//	@Tags			order
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int																					true	"Order id"					Format(int64)
+//	@param			payload			body		model.UpdateOrderStatusRequest														true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.UpdateOrderStatusResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																	"Unauthorized"
+//	@Failure		403				{object}	web.ErrJSONResponse																	"Forbidden (status not allowed for the role)"
+//	@Failure		404				{object}	web.ErrJSONResponse																	"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																	"Invalid status transition"
+//	@Failure		422				{object}	web.ErrJSONResponse																	"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *orderHandler) UpdateStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateOrderStatusRequest{}
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.UpdateStatus: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.orderHandler.UpdateStatus: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		resp, err := handler.orderService.UpdateStatus(r.Context(), orderID, req)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.UpdateStatus: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// StatusHistoryOrder godoc
+//	@Router			/order/{order_id}/history [get]
+//	@Summary		Order status history
+//	@Description	Show every status change of an order ordered from the oldest one
+//	@Tags			order
+//	@produce		json
+//	@Param			Authorization	header		string																					true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int																						true	"Order id"					Format(int64)
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=[]model.OrderStatusHistoryResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																		"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																		"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																		"Not found"
+//	@Failure		500				{object}	web.ErrJSONResponse																		"Internal server error"
+func (handler *orderHandler) StatusHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.StatusHistory: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		resp, err := handler.orderService.StatusHistory(r.Context(), orderID)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.StatusHistory: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// GetOrder godoc
+//	@Router			/order/{order_id} [get]
+//	@Summary		Get order
+//	@Description	Show an order with all of its items and total price
+//	@Tags			order
+//	@produce		json
+//	@Param			Authorization	header		string														true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int															true	"Order id"					Format(int64)
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.OrderDetailResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse											"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse											"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse											"Not found"
+//	@Failure		500				{object}	web.ErrJSONResponse											"Internal server error"
+func (handler *orderHandler) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.Get: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		resp, err := handler.orderService.Get(r.Context(), orderID)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.Get: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateOrder godoc
+//	@Router			/order/{order_id} [patch]
+//	@Summary		Update order
+//	@Description	Update customer's email of an unpaid (NEW or CONFIRMED) order
+//	@Tags			order
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string														true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int															true	"Order id"					Format(int64)
+//	@param			payload			body		model.UpdateOrderRequest									true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.OrderDetailResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse											"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse											"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse											"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse											"Order is not editable"
+//	@Failure		422				{object}	web.ErrJSONResponse											"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse											"Internal server error"
+func (handler *orderHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateOrderRequest{}
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.Update: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.orderHandler.Update: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		resp, err := handler.orderService.Update(r.Context(), orderID, req)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.Update: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// CancelOrder godoc
+//	@Router			/order/{order_id} [delete]
+//	@Summary		Cancel order
+//	@Description	Cancel an unpaid (NEW or CONFIRMED) order, paid order must be refunded instead. An order with payments (even partial) can't be cancelled
+//	@Tags			order
+//	@produce		json
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int																					true	"Order id"					Format(int64)
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.UpdateOrderStatusResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																	"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																	"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																	"Invalid status transition (or order has payments)"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *orderHandler) Cancel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.Cancel: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		resp, err := handler.orderService.Cancel(r.Context(), orderID)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.Cancel: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// AddItemOrder godoc
+//	@Router			/order/{order_id}/items [post]
+//	@Summary		Add order item
+//	@Description	Add a menu to an unpaid (NEW or CONFIRMED) order, qty is added up when the menu is already ordered
+//	@Tags			order
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string														true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int															true	"Order id"					Format(int64)
+//	@param			payload			body		model.BaseOrderRequest										true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.OrderDetailResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse											"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse											"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse											"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse											"Order is not editable"
+//	@Failure		422				{object}	web.ErrJSONResponse											"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse											"Internal server error"
+func (handler *orderHandler) AddItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.BaseOrderRequest{}
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.AddItem: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.orderHandler.AddItem: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		resp, err := handler.orderService.AddItem(r.Context(), orderID, req)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.AddItem: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateItemOrder godoc
+//	@Router			/order/{order_id}/items/{base_order_id} [put]
+//	@Summary		Update order item
+//	@Description	Change qty of an item of an unpaid (NEW or CONFIRMED) order
+//	@Tags			order
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string														true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int															true	"Order id"					Format(int64)
+//	@param			base_order_id	path		int															true	"Order item id"				Format(int64)
+//	@param			payload			body		model.UpdateOrderItemRequest								true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.OrderDetailResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse											"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse											"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse											"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse											"Order is not editable"
+//	@Failure		422				{object}	web.ErrJSONResponse											"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse											"Internal server error"
+func (handler *orderHandler) UpdateItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateOrderItemRequest{}
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.UpdateItem: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		baseOrderID, err := web.PathParamInt64(r, "base_order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.UpdateItem: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.orderHandler.UpdateItem: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		resp, err := handler.orderService.UpdateItem(r.Context(), orderID, baseOrderID, req)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.UpdateItem: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteItemOrder godoc
+//	@Router			/order/{order_id}/items/{base_order_id} [delete]
+//	@Summary		Delete order item
+//	@Description	Remove an item of an unpaid (NEW or CONFIRMED) order, the last item can't be removed (cancel the order instead)
+//	@Tags			order
+//	@produce		json
+//	@Param			Authorization	header		string														true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			order_id		path		int															true	"Order id"					Format(int64)
+//	@param			base_order_id	path		int															true	"Order item id"				Format(int64)
+//	@Success		200				{object}	web.JSONResponse{data=model.OrderResponse{order=model.OrderDetailResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse											"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse											"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse											"Not found"
+//	@Failure		409				{object}	web.ErrJSONResponse											"Order is not editable"
+//	@Failure		500				{object}	web.ErrJSONResponse											"Internal server error"
+func (handler *orderHandler) DeleteItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		orderID, err := web.PathParamInt64(r, "order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.DeleteItem: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		baseOrderID, err := web.PathParamInt64(r, "base_order_id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.orderHandler.DeleteItem: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		resp, err := handler.orderService.DeleteItem(r.Context(), orderID, baseOrderID)
+		if err != nil {
+			err = fmt.Errorf("handler.orderHandler.DeleteItem: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.OrderResponse{Order: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"family-catering/internal/model"
 	"family-catering/internal/service"
 	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
 	"family-catering/pkg/utils"
 	"net/http"
 	"net/http/httptest"
@@ -79,7 +80,7 @@ func Test_orderHandler_Create(t *testing.T) {
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
 				m.orderServiceMock.EXPECT().
 					Create(m.r.Context(), gomock.AssignableToTypeOf(model.CreateOrderRequest{})).
-					Return(&model.CreateOrderResponse{OrderID: 1, CustomerEmail: "test@example.com", Message: "success create order", TotalPrice: 200_000}, nil)
+					Return(&model.CreateOrderResponse{OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", Message: "success create order", TotalPrice: money.MustParse("200000")}, nil)
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody: `{
@@ -89,9 +90,10 @@ func Test_orderHandler_Create(t *testing.T) {
 				  "order": 
 					{
 					  "order_id": 1,
+					  "customer_id": 3,
 					  "customer_email": "test@example.com",
 					  "message": "success create order",
-					  "total_price": 200000
+					  "total_price":"200000.00"
 					}
 				},
 				"process_time": 0
@@ -268,69 +270,81 @@ func Test_orderHandler_ConfirmPayment(t *testing.T) {
 		{
 			name:    "success hit api /api/v1/order/confirm-payment [put] 'ok'",
 			handler: &orderHandler{},
-			params:  params{payload: `{"email":"test@example.com"}`},
+			params:  params{payload: `{"order_id":1,"amount":20000}`},
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/json")
 				m.r.Header.Set("Authorization", "Bearer access-token")
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
-				m.rctx.URLParams.Add("email", "test@example.com")
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
-				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(nil)
+				m.orderServiceMock.EXPECT().
+					ConfirmPayment(m.r.Context(), model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("20000")}).
+					Return(&model.ConfirmPaymentResponse{PaymentID: 1, OrderID: 1, Amount: money.MustParse("20000"), TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), RemainingBalance: money.MustParse("30000"), Status: "NEW"}, nil)
 			},
 			wantStatusCode: http.StatusOK,
-			wantBody:       `{"success":true,"status":"success","process_time":0}`,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"payment_id":1,"order_id":1,"amount":"20000.00","total_price":"50000.00","total_paid":"20000.00","remaining_balance":"30000.00","status":"NEW"}},"process_time":0}`,
 		},
 		{
 			name:    "fail hit api /api/v1/order/confirm-payment [put] 'not found'",
 			handler: &orderHandler{},
-			params:  params{payload: `{"email":"not.found@example.com"}`},
+			params:  params{payload: `{"order_id":1000000000,"amount":20000}`},
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/json")
 				m.r.Header.Set("Authorization", "Bearer access-token")
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
-				m.rctx.URLParams.Add("email", "not.found@example.com")
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
-				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(apperrors.ErrNotFound)
+				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(nil, apperrors.ErrNotFound)
 			},
 			wantStatusCode: http.StatusNotFound,
 			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
 		},
 		{
-			name:    "fail hit api /api/v1/order/confirm [put] 'error auth'",
+			name:    "fail hit api /api/v1/order/confirm-payment [put] 'error auth'",
 			handler: &orderHandler{},
-			params:  params{payload: `{"email":"test@example.com"}`},
+			params:  params{payload: `{"order_id":1,"amount":20000}`},
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/json")
 				m.r.Header.Set("Authorization", "invalid-token")
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
-				m.rctx.URLParams.Add("email", "test@example.com")
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
-				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(apperrors.ErrAuth)
+				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(nil, apperrors.ErrAuth)
 			},
 			wantStatusCode: http.StatusUnauthorized,
 			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
 		},
 		{
-			name:    "fail hit api /api/v1/order/confirm/{email} [put] 'error internal server'",
+			name:    "fail hit api /api/v1/order/confirm-payment [put] 'error internal server'",
 			handler: &orderHandler{},
-			params:  params{payload: `{"email":"test@example.com"}`},
+			params:  params{payload: `{"order_id":1,"amount":20000}`},
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/json")
 				m.r.Header.Set("Authorization", "Bearer access-token")
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
-				m.rctx.URLParams.Add("email", "test@example.com")
 				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
-				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(errors.New("oops! internal server error"))
+				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), gomock.AssignableToTypeOf(model.ConfirmPaymentRequest{})).Return(nil, errors.New("oops! internal server error"))
 			},
 			wantStatusCode: http.StatusInternalServerError,
 			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
 		},
+		{
+			name:    "fail hit api /api/v1/order/confirm-payment [put] 'amount exceeds remaining balance'",
+			handler: &orderHandler{},
+			params:  params{payload: `{"order_id":1,"amount":90000}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.orderServiceMock.EXPECT().ConfirmPayment(m.r.Context(), model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("90000")}).Return(nil, apperrors.ErrFieldValidation)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			orderServiceMock := service.NewMockOrderService(ctrl)
-			r := httptest.NewRequest(http.MethodPut, "/api/v1/order/confirm/", strings.NewReader(tt.params.payload))
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/order/confirm-payment", strings.NewReader(tt.params.payload))
 			w := httptest.NewRecorder()
 			rctx := chi.NewRouteContext()
 			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
@@ -359,6 +373,8 @@ func Test_orderHandler_Search(t *testing.T) {
 		exactNames string
 		emails     string
 		qty        string
+		// delivery-date is only sent when it's set
+		deliveryDate string
 		// status string
 		// maxPrice string
 		// minPrice string
@@ -382,6 +398,9 @@ func Test_orderHandler_Search(t *testing.T) {
 		val.Add("menu-names", q.menuNames)
 		val.Add("exact-names", q.exactNames)
 		val.Add("qty", q.qty)
+		if q.deliveryDate != "" {
+			val.Add("delivery-date", q.deliveryDate)
+		}
 		// if q.emails != "" {
 		// 	val.Add("emails", q.emails)
 		// }
@@ -410,32 +429,41 @@ func Test_orderHandler_Search(t *testing.T) {
 			// menu-names={menus-names}&emails={emails}&exact-matches-names={exact-matches-names}&max-price={max-price}&min-price={min-price}&status={status}&qty={qty}&start-day={start-day}&end-day={end-day}
 			handler: &orderHandler{},
 			params: params{
-				menuNames:  "sate,sop buah",
-				exactNames: "true",
-				emails:     "test@example.com",
+				menuNames:    "sate,sop buah",
+				exactNames:   "true",
+				emails:       "test@example.com",
+				deliveryDate: "tomorrow",
 			},
 			prepareMocks: func(m *mocks) {
 				m.r.Header.Set("Content-Type", "application/x-www-form-url-encoded")
 				m.r.Header.Set("Authorization", "Bearer access-token")
 				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
 				m.orderServiceMock.EXPECT().Search(m.r.Context(), gomock.AssignableToTypeOf(model.OrderQuery{})).Return(&model.SearchOrdersResponse{
-					TotalPrice: 125_000,
+					TotalPrice: money.MustParse("125000"),
+					GrandTotal: money.MustParse("145687.50"),
+					Charges: []*model.OrderChargeResponse{
+						{OrderID: 1, SubTotal: money.MustParse("80000"), Discount: money.MustParse("0"), ServiceCharge: money.MustParse("4000"), Tax: money.MustParse("9240"), GrandTotal: money.MustParse("93240")},
+						{OrderID: 2, SubTotal: money.MustParse("45000"), Discount: money.MustParse("0"), ServiceCharge: money.MustParse("2250"), Tax: money.MustParse("5197.50"), GrandTotal: money.MustParse("52447.50")},
+					},
 					Orders: []*model.SearchResponse{
 						{
 							OrderID:       1,
 							CustomerEmail: "test@example.com",
 							MenuName:      "sate",
-							Price:         20_000,
+							Price:         money.MustParse("20000"),
 							Status:        2,
 							Qty:           4,
+							DeliveryDate:  "2022-11-11",
+							DeliverySlot:  "11:00-13:00",
 						},
 						{
 							OrderID:       2,
 							CustomerEmail: "test@example.com",
 							MenuName:      "sop buah",
-							Price:         15_000,
+							Price:         money.MustParse("15000"),
 							Status:        1,
 							Qty:           3,
+							DeliveryDate:  "2022-11-11",
 						},
 					},
 				}, nil)
@@ -446,24 +474,32 @@ func Test_orderHandler_Search(t *testing.T) {
 				"status": "success",
 				"data": {
 				  "order": {
-					"total_price":125000,
+					"total_price":"125000.00",
+					"grand_total":"145687.50",
+					"charges": [
+						{"order_id":1,"sub_total":"80000.00","discount":"0.00","service_charge":"4000.00","tax":"9240.00","tax_inclusive":false,"delivery_fee":"0.00","grand_total":"93240.00"},
+						{"order_id":2,"sub_total":"45000.00","discount":"0.00","service_charge":"2250.00","tax":"5197.50","tax_inclusive":false,"delivery_fee":"0.00","grand_total":"52447.50"}
+					],
 					  "orders": [
 						{
 						  "order_id":1,
 						  "customer_email":"test@example.com",
 						  "qty":4,
-						  "price":20000,
+						  "price":"20000.00",
 						  "menu_name":"sate",
 						  "status":2,
+						  "delivery_date":"2022-11-11",
+						  "delivery_slot":"11:00-13:00",
 						  "created_at":""
 						},
 						{
 						  "order_id":2,
 						  "customer_email":"test@example.com",
 						  "qty":3,
-						  "price":15000,
+						  "price":"15000.00",
 						  "menu_name":"sop buah",
 						  "status":1,
+						  "delivery_date":"2022-11-11",
 						  "created_at":""
 						}
 					  ]
@@ -538,6 +574,28 @@ func Test_orderHandler_Search(t *testing.T) {
 				"process_time": 0
 			  }`,
 		},
+		{
+			name:    "fail hit api /api/v1/order/search?<query-params> [get] 'error invalid delivery date'",
+			handler: &orderHandler{},
+			params: params{
+				menuNames:    "sate,sop buah",
+				exactNames:   "true",
+				emails:       "test@example.com",
+				deliveryDate: "11-11-2022",
+			},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/x-www-form-url-encoded")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"success": false,
+				"status": "fail",
+				"error":{"message":"oops! error"},
+				"process_time": 0
+			  }`,
+		},
 		{
 			name:    "fail hit api /api/v1/order/search?<query-params> [get] 'error invalid query params'",
 			handler: &orderHandler{},
@@ -590,3 +648,652 @@ func Test_orderHandler_Search(t *testing.T) {
 		})
 	}
 }
+
+func Test_orderHandler_UpdateStatus(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID string
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id}/status [put] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"status":"PREPARING"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				m.rctx.URLParams.Add("order_id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.orderServiceMock.EXPECT().
+					UpdateStatus(m.r.Context(), int64(1), model.UpdateOrderStatusRequest{Status: "PREPARING"}).
+					Return(&model.UpdateOrderStatusResponse{OrderID: 1, PreviousStatus: "PAID", Status: "PREPARING"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"previous_status":"PAID","status":"PREPARING"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/status [put] 'invalid transition'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"status":"PREPARING"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				m.rctx.URLParams.Add("order_id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.orderServiceMock.EXPECT().
+					UpdateStatus(m.r.Context(), int64(1), model.UpdateOrderStatusRequest{Status: "PREPARING"}).
+					Return(nil, apperrors.ErrOrderStatusTransition)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/status [put] 'invalid payload'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"status":`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.rctx.URLParams.Add("order_id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/status [put] 'invalid path params'",
+			handler: &orderHandler{},
+			params:  params{orderID: "abc", payload: `{"status":"PAID"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.rctx.URLParams.Add("order_id", "abc")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/order/"+tt.params.orderID+"/status", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.UpdateStatus()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_StatusHistory(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id}/history [get] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				m.rctx.URLParams.Add("order_id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.orderServiceMock.EXPECT().
+					StatusHistory(m.r.Context(), int64(1)).
+					Return([]*model.OrderStatusHistoryResponse{
+						{ToStatus: "NEW", CreatedAt: "2022-11-10 10:00:00"},
+						{FromStatus: "NEW", ToStatus: "PAID", ChangedBy: 1, CreatedAt: "2022-11-10 11:00:00"},
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+					"order": [
+						{"to_status": "NEW", "created_at": "2022-11-10 10:00:00"},
+						{"from_status": "NEW", "to_status": "PAID", "changed_by": 1, "created_at": "2022-11-10 11:00:00"}
+					]
+				},
+				"process_time": 0
+			}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/history [get] 'not found'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1000000000"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				m.rctx.URLParams.Add("order_id", "1000000000")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.orderServiceMock.EXPECT().StatusHistory(m.r.Context(), int64(1_000_000_000)).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/history [get] 'error internal server'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				m.rctx.URLParams.Add("order_id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.orderServiceMock.EXPECT().StatusHistory(m.r.Context(), int64(1)).Return(nil, errors.New("oops! internal server error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/order/"+tt.params.orderID+"/history", nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.StatusHistory()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_Get(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID     string
+		baseOrderID string
+		payload     string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id} [get] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1"},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().Get(m.r.Context(), int64(1)).Return(&model.OrderDetailResponse{OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", Status: "NEW", Items: []*model.OrderItemResponse{{BaseOrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, SubTotal: money.MustParse("50000")}}, TotalPrice: money.MustParse("50000"), CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"customer_id":3,"customer_email":"test@example.com","status":"NEW","items":[{"base_order_id":1,"menu_id":1,"menu_name":"sate","price":"25000.00","qty":2,"sub_total":"50000.00"}],"total_price":"50000.00","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-10 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id} [get] 'not found'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1000000000"},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().Get(m.r.Context(), int64(1_000_000_000)).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:           "fail hit api /api/v1/order/{order_id} [get] 'invalid path params'",
+			handler:        &orderHandler{},
+			params:         params{orderID: "abc"},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/order/1", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.params.orderID)
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.Get()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_Update(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID     string
+		baseOrderID string
+		payload     string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id} [patch] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"customer_email":"test@example.com"}`},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().Update(m.r.Context(), int64(1), model.UpdateOrderRequest{CustomerEmail: "test@example.com"}).Return(&model.OrderDetailResponse{OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", Status: "NEW", Items: []*model.OrderItemResponse{{BaseOrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, SubTotal: money.MustParse("50000")}}, TotalPrice: money.MustParse("50000"), CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"customer_id":3,"customer_email":"test@example.com","status":"NEW","items":[{"base_order_id":1,"menu_id":1,"menu_name":"sate","price":"25000.00","qty":2,"sub_total":"50000.00"}],"total_price":"50000.00","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-10 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id} [patch] 'not editable'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"customer_email":"test@example.com"}`},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().Update(m.r.Context(), int64(1), model.UpdateOrderRequest{CustomerEmail: "test@example.com"}).Return(nil, apperrors.ErrOrderNotEditable)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:           "fail hit api /api/v1/order/{order_id} [patch] 'invalid payload'",
+			handler:        &orderHandler{},
+			params:         params{orderID: "1", payload: `{"customer_email":`},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodPatch, "/api/v1/order/1", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.params.orderID)
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.Update()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_Cancel(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID     string
+		baseOrderID string
+		payload     string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id} [delete] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1"},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().Cancel(m.r.Context(), int64(1)).Return(&model.UpdateOrderStatusResponse{OrderID: 1, PreviousStatus: "NEW", Status: "CANCELLED"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"previous_status":"NEW","status":"CANCELLED"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id} [delete] 'order is paid'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1"},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().Cancel(m.r.Context(), int64(1)).Return(nil, apperrors.ErrOrderStatusTransition)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/order/1", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.params.orderID)
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.Cancel()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_AddItem(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID     string
+		baseOrderID string
+		payload     string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id}/items [post] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"name":"sate","qty":2}`},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().AddItem(m.r.Context(), int64(1), model.BaseOrderRequest{Name: "sate", Qty: 2}).Return(&model.OrderDetailResponse{OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", Status: "NEW", Items: []*model.OrderItemResponse{{BaseOrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, SubTotal: money.MustParse("50000")}}, TotalPrice: money.MustParse("50000"), CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"customer_id":3,"customer_email":"test@example.com","status":"NEW","items":[{"base_order_id":1,"menu_id":1,"menu_name":"sate","price":"25000.00","qty":2,"sub_total":"50000.00"}],"total_price":"50000.00","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-10 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/items [post] 'error internal server'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", payload: `{"name":"sate","qty":2}`},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().AddItem(m.r.Context(), int64(1), model.BaseOrderRequest{Name: "sate", Qty: 2}).Return(nil, errors.New("oops! internal server error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/order/1/items", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.params.orderID)
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.AddItem()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_UpdateItem(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID     string
+		baseOrderID string
+		payload     string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id}/items/{base_order_id} [put] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", baseOrderID: "1", payload: `{"qty":2}`},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().UpdateItem(m.r.Context(), int64(1), int64(1), model.UpdateOrderItemRequest{Qty: 2}).Return(&model.OrderDetailResponse{OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", Status: "NEW", Items: []*model.OrderItemResponse{{BaseOrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, SubTotal: money.MustParse("50000")}}, TotalPrice: money.MustParse("50000"), CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"customer_id":3,"customer_email":"test@example.com","status":"NEW","items":[{"base_order_id":1,"menu_id":1,"menu_name":"sate","price":"25000.00","qty":2,"sub_total":"50000.00"}],"total_price":"50000.00","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-10 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/items/{base_order_id} [put] 'not editable'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", baseOrderID: "1", payload: `{"qty":2}`},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().UpdateItem(m.r.Context(), int64(1), int64(1), model.UpdateOrderItemRequest{Qty: 2}).Return(nil, apperrors.ErrOrderNotEditable)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:           "fail hit api /api/v1/order/{order_id}/items/{base_order_id} [put] 'invalid path params'",
+			handler:        &orderHandler{},
+			params:         params{orderID: "1", baseOrderID: "abc", payload: `{"qty":2}`},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/order/1/items/1", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.params.orderID)
+			rctx.URLParams.Add("base_order_id", tt.params.baseOrderID)
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.UpdateItem()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_orderHandler_DeleteItem(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		w                *httptest.ResponseRecorder
+		rctx             *chi.Context
+		orderServiceMock *service.MockOrderService
+	}
+	type params struct {
+		orderID     string
+		baseOrderID string
+		payload     string
+	}
+	tests := []struct {
+		name           string
+		handler        *orderHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/order/{order_id}/items/{base_order_id} [delete] 'ok'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", baseOrderID: "2"},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().DeleteItem(m.r.Context(), int64(1), int64(2)).Return(&model.OrderDetailResponse{OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", Status: "NEW", Items: []*model.OrderItemResponse{{BaseOrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, SubTotal: money.MustParse("50000")}}, TotalPrice: money.MustParse("50000"), CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"order":{"order_id":1,"customer_id":3,"customer_email":"test@example.com","status":"NEW","items":[{"base_order_id":1,"menu_id":1,"menu_name":"sate","price":"25000.00","qty":2,"sub_total":"50000.00"}],"total_price":"50000.00","created_at":"2022-11-10 10:00:00","updated_at":"2022-11-10 10:00:00"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/order/{order_id}/items/{base_order_id} [delete] 'not found'",
+			handler: &orderHandler{},
+			params:  params{orderID: "1", baseOrderID: "2"},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().DeleteItem(m.r.Context(), int64(1), int64(2)).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := service.NewMockOrderService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/order/1/items/2", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.params.orderID)
+			rctx.URLParams.Add("base_order_id", tt.params.baseOrderID)
+			m := &mocks{r: r, w: w, rctx: rctx, orderServiceMock: orderServiceMock}
+			*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+			*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.orderService = m.orderServiceMock
+
+			handler := tt.handler.DeleteItem()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
@@ -21,6 +21,8 @@ type OwnerHandler interface {
 	ResetPasswordById() http.HandlerFunc
 	ResetPasswordByEmail() http.HandlerFunc
 	UpdateEmailByID() http.HandlerFunc
+	VerifyEmail() http.HandlerFunc
+	ResendEmailVerification() http.HandlerFunc
 }
 
 type ownerHandler struct {
@@ -150,7 +152,7 @@ func (handler *ownerHandler) List() http.HandlerFunc {
 //	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@param			Cookie			header	string	true	"Session id"				default(sid=<Add your session id>)
 //	@Produce		json
-//	@Success		200	{object}	web.JSONResponse	required	"Ok"
+//	@Success		200	{object}	web.JSONResponse	"Ok"
 //	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		404	{object}	web.ErrJSONResponse	"Owner not found"
 //	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
@@ -187,7 +189,7 @@ func (handler *ownerHandler) Delete() http.HandlerFunc {
 //	@Param			Authorization	header	string						true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@param			payload			body	model.UpdateOwnerRequest	true	"Update owner payload"
 //	@Produce		json
-//	@Success		200	{object}	web.JSONResponse	required	"Ok"
+//	@Success		200	{object}	web.JSONResponse	"Ok"
 //	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		404	{object}	web.ErrJSONResponse	"Owner not found"
 //	@Failure		422	{object}	web.ErrJSONResponse	"Unprocessable entity"
@@ -230,13 +232,13 @@ func (handler *ownerHandler) Update() http.HandlerFunc {
 // ResetPasswordByEmailOwner godoc
 //	@Router			/owner/reset-password/{rpid} [put]
 //	@Summary		Reset owner password (logged out state)
-//	@Description	Reset owner password by given rpid
+//	@Description	Reset owner password by given rpid, a rpid resets the password once and the owner is logged out of every device
 //	@Tags			owner
 //	@param			rpid			path	string						true	"Owner reset password id"
 //	@Param			Authorization	header	string						true	"Insert your password token"	default(Bearer <Add password token here>)
 //	@param			payload			body	model.ResetPasswordRequest	true	"Reset Password payload"
 //	@Produce		json
-//	@Success		200	{object}	web.JSONResponse{}	required	"Ok"
+//	@Success		200	{object}	web.JSONResponse	"Ok"
 //	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
 //	@Failure		404	{object}	web.ErrJSONResponse	"Owner not found"
@@ -281,7 +283,7 @@ func (handler *ownerHandler) ResetPasswordByEmail() http.HandlerFunc {
 //	@param			Cookie			header	string						true	"Session id"				default(sid=<Add your session id>)
 //	@param			payload			body	model.ResetPasswordRequest	true	"Reset Password payload"
 //	@Produce		json
-//	@Success		200	{object}	web.JSONResponse	required	"Ok"
+//	@Success		200	{object}	web.JSONResponse	"Ok"
 //	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
 //	@Failure		404	{object}	web.ErrJSONResponse	"Owner not found"
@@ -322,16 +324,17 @@ func (handler *ownerHandler) ResetPasswordById() http.HandlerFunc {
 // UpdateEmailByIDOwner godoc
 //	@Router			/owner/{id}/update-email [put]
 //	@Summary		Update Email owner
-//	@Description	Update owner's email by given id
+//	@Description	Send the verification link to the new email of the owner by given id, the email is updated once the link is clicked
 //	@Tags			owner
 //	@param			id				path	int		true	"Owner reset password id"	Format(int64)
 //	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@param			Cookie			header	string	true	"Session id"				default(sid=<Add your session id>)
 //	@Produce		json
-//	@Success		200	{object}	web.JSONResponse	required	"Ok"
+//	@Success		200	{object}	web.JSONResponse	"Ok"
 //	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
 //	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
 //	@Failure		404	{object}	web.ErrJSONResponse	"Owner not found"
+//	@Failure		409	{object}	web.ErrJSONResponse	"Email already registered"
 //	@Failure		422	{object}	web.ErrJSONResponse	"Unprocessable entity"
 //	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
 func (handler *ownerHandler) UpdateEmailByID() http.HandlerFunc {
@@ -365,3 +368,66 @@ func (handler *ownerHandler) UpdateEmailByID() http.HandlerFunc {
 		web.WriteSuccessJSON(w, nil, start)
 	}
 }
+
+// VerifyEmailOwner godoc
+//	@Router			/owner/verify-email/{token} [get]
+//	@Summary		Verify owner's email
+//	@Description	Verify the registered email of an owner or the new email of an owner changing it by the token of the link sent to the email, the link is used once
+//	@Tags			owner
+//	@param			token	path	string	true	"Email verification token"
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Invalid or expired verification link"
+//	@Failure		409	{object}	web.ErrJSONResponse	"Email already registered"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+func (handler *ownerHandler) VerifyEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		token := web.PathParamString(r, "token")
+
+		err := handler.ownerService.VerifyEmail(r.Context(), token)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// ResendEmailVerificationOwner godoc
+//	@Router			/owner/verify-email [post]
+//	@Summary		Resend email verification
+//	@Description	Send a new verification link to a registered owner whose email is not verified yet, the previous links are voided.
+//	@Description	The answer is the same whether the email is registered, verified already or not
+//	@Tags			owner
+//	@Accept			json
+//	@param			payload	body	model.ResendEmailVerificationRequest	true	"Resend email verification payload"
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		422	{object}	web.ErrJSONResponse	"Unprocessable entity"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+func (handler *ownerHandler) ResendEmailVerification() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.ResendEmailVerificationRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.ownerHandler.ResendEmailVerification: %w", err)
+			log.Error(err, "error unmarshal request's payload")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request payload", start)
+			return
+		}
+
+		err = handler.ownerService.ResendEmailVerification(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
@@ -1158,3 +1158,190 @@ func Test_ownerHandler_UpdateEmailByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_ownerHandler_VerifyEmail(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		rctx              *chi.Context
+		ownerServiceMocks *service.MockOwnerService
+	}
+	tests := []struct {
+		name           string
+		handler        *ownerHandler
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/owner/verify-email/{token} [get] 'ok'",
+			handler: &ownerHandler{},
+			prepareMocks: func(m *mocks) {
+				m.rctx.URLParams.Add("token", "verification-token")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.ownerServiceMocks.
+					EXPECT().
+					VerifyEmail(m.r.Context(), "verification-token").
+					Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/owner/verify-email/{token} [get] 'error invalid or expired link'",
+			handler: &ownerHandler{},
+			prepareMocks: func(m *mocks) {
+				m.rctx.URLParams.Add("token", "verification-token")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.ownerServiceMocks.
+					EXPECT().
+					VerifyEmail(m.r.Context(), "verification-token").
+					Return(apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/owner/verify-email/{token} [get] 'error email registered'",
+			handler: &ownerHandler{},
+			prepareMocks: func(m *mocks) {
+				m.rctx.URLParams.Add("token", "verification-token")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.ownerServiceMocks.
+					EXPECT().
+					VerifyEmail(m.r.Context(), "verification-token").
+					Return(apperrors.ErrEmailRegistered)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/owner/verify-email/{token} [get] 'error internal server'",
+			handler: &ownerHandler{},
+			prepareMocks: func(m *mocks) {
+				m.rctx.URLParams.Add("token", "verification-token")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.ownerServiceMocks.
+					EXPECT().
+					VerifyEmail(m.r.Context(), "verification-token").
+					Return(errors.New("oops! error internal server"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ownerServiceMock := service.NewMockOwnerService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/owner/verify-email/verification-token", nil)
+			rctx := chi.NewRouteContext()
+			w := httptest.NewRecorder()
+
+			m := &mocks{r: r, rctx: rctx, ownerServiceMocks: ownerServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+
+			tt.handler.ownerService = m.ownerServiceMocks
+
+			handler := tt.handler.VerifyEmail()
+			handler(w, r)
+			resp := w.Result()
+
+			gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, gotRespBody)
+		})
+	}
+}
+
+func Test_ownerHandler_ResendEmailVerification(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		ownerServiceMocks *service.MockOwnerService
+	}
+	tests := []struct {
+		name           string
+		handler        *ownerHandler
+		payload        string
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/owner/verify-email [post] 'ok'",
+			handler: &ownerHandler{},
+			payload: `{"email":"test@example.com"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.ownerServiceMocks.
+					EXPECT().
+					ResendEmailVerification(m.r.Context(), model.ResendEmailVerificationRequest{Email: "test@example.com"}).
+					Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/owner/verify-email [post] 'error unmarshal request'",
+			handler: &ownerHandler{},
+			payload: `{"email-bad-key-no-enclosed-by-double-quoted:"test@example.com"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/owner/verify-email [post] 'error invalid email'",
+			handler: &ownerHandler{},
+			payload: `{"email":"not-an-email"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.ownerServiceMocks.
+					EXPECT().
+					ResendEmailVerification(m.r.Context(), model.ResendEmailVerificationRequest{Email: "not-an-email"}).
+					Return(apperrors.ErrFieldValidation)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/owner/verify-email [post] 'error internal server'",
+			handler: &ownerHandler{},
+			payload: `{"email":"test@example.com"}`,
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.ownerServiceMocks.
+					EXPECT().
+					ResendEmailVerification(m.r.Context(), model.ResendEmailVerificationRequest{Email: "test@example.com"}).
+					Return(errors.New("oops! error internal server"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ownerServiceMock := service.NewMockOwnerService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/owner/verify-email", strings.NewReader(tt.payload))
+			w := httptest.NewRecorder()
+
+			m := &mocks{r: r, ownerServiceMocks: ownerServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+
+			tt.handler.ownerService = m.ownerServiceMocks
+
+			handler := tt.handler.ResendEmailVerification()
+			handler(w, r)
+			resp := w.Result()
+
+			gotRespBody := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".+"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, gotRespBody)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/consts"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxWebhookPayloadSize limit the webhook's body read into memory, provider's payload is only a few hundred bytes
+const maxWebhookPayloadSize = 64 << 10
+
+type PaymentHandler interface {
+	CreateCharge() http.HandlerFunc
+	Webhook() http.HandlerFunc
+}
+
+type paymentHandler struct {
+	paymentService service.PaymentService
+}
+
+func NewPaymentHandler(paymentService service.PaymentService) PaymentHandler {
+	return &paymentHandler{paymentService: paymentService}
+}
+
+// CreateCharge godoc
+//	@Router			/payments/{provider}/charges [post]
+//	@Summary		Create online payment charge
+//	@Description	Request a charge of an unpaid order to the payment provider, the customer pays it through the checkout url
+//	@Tags			payment
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																				true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Param			provider		path		string																				true	"payment provider"
+//	@param			payload			body		model.CreateChargeRequest															true	"order id and amount (default to the remaining balance)"
+//	@Success		200				{object}	web.JSONResponse{data=model.PaymentResponse{payment=model.CreateChargeResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																	"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																	"Order or provider not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																	"Order is not payable"
+//	@Failure		422				{object}	web.ErrJSONResponse																	"Amount exceeds the remaining balance"
+//	@Failure		500				{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *paymentHandler) CreateCharge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		provider := web.PathParamString(r, "provider")
+		req := model.CreateChargeRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.paymentHandler.CreateCharge: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		resp, err := handler.paymentService.CreateCharge(r.Context(), provider, req)
+		if err != nil {
+			err = fmt.Errorf("handler.paymentHandler.CreateCharge: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.PaymentResponse{Payment: resp}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// PaymentWebhook godoc
+//	@Router			/payments/webhook/{provider} [post]
+//	@Summary		Payment provider webhook
+//	@Description	Receive a signed payment event from the payment provider, replayed event is acknowledged without being recorded twice
+//	@Tags			payment
+//	@Accept			json
+//	@produce		json
+//	@Param			X-Webhook-Signature	header		string																				true	"signature of the raw body"
+//	@Param			provider			path		string																				true	"payment provider"
+//	@param			payload				body		model.PaymentEvent																	true	"payment event"
+//	@Success		200					{object}	web.JSONResponse{data=model.PaymentResponse{payment=model.PaymentWebhookResponse}}	"Ok"
+//	@Failure		400					{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		401					{object}	web.ErrJSONResponse																	"Invalid signature"
+//	@Failure		404					{object}	web.ErrJSONResponse																	"Provider not found"
+//	@Failure		422					{object}	web.ErrJSONResponse																	"Invalid payload"
+//	@Failure		500					{object}	web.ErrJSONResponse																	"Internal server error"
+func (handler *paymentHandler) Webhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		provider := web.PathParamString(r, "provider")
+
+		// signature is computed over the raw body so it must not be decoded before verified
+		defer r.Body.Close()
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+		if err != nil {
+			err := fmt.Errorf("handler.paymentHandler.Webhook: %w", err)
+			log.Error(err, "error read request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error read request", start)
+			return
+		}
+
+		resp, err := handler.paymentService.HandleWebhook(r.Context(), provider, payload, r.Header.Get(consts.HeaderWebhookSignature))
+		if err != nil {
+			err = fmt.Errorf("handler.paymentHandler.Webhook: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, model.PaymentResponse{Payment: resp}, start)
+	}
+}
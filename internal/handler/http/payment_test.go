@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"family-catering/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPaymentHandler(t *testing.T) {
+	type args struct {
+		paymentService service.PaymentService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewPaymentHandler"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewPaymentHandler(tt.args.paymentService))
+		})
+	}
+}
+
+func Test_paymentHandler_CreateCharge(t *testing.T) {
+	type mocks struct {
+		r                  *http.Request
+		w                  *httptest.ResponseRecorder
+		rctx               *chi.Context
+		paymentServiceMock *service.MockPaymentService
+	}
+	type params struct {
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *paymentHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/payments/fake/charges [post] 'ok'",
+			handler: &paymentHandler{},
+			params:  params{payload: `{"order_id":1}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().
+					CreateCharge(m.r.Context(), "fake", model.CreateChargeRequest{OrderID: 1}).
+					Return(&model.CreateChargeResponse{ChargeID: "fake_ch_1_1", Provider: "fake", OrderID: 1, Amount: money.MustParse("50000"), CheckoutURL: "http://localhost:9000/checkout/fake_ch_1_1"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"payment":{"charge_id":"fake_ch_1_1","provider":"fake","order_id":1,"amount":"50000.00","checkout_url":"http://localhost:9000/checkout/fake_ch_1_1"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/payments/unknown/charges [post] 'not found'",
+			handler: &paymentHandler{},
+			params:  params{payload: `{"order_id":1}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("provider", "unknown")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().CreateCharge(m.r.Context(), "unknown", model.CreateChargeRequest{OrderID: 1}).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/payments/fake/charges [post] 'order is not payable'",
+			handler: &paymentHandler{},
+			params:  params{payload: `{"order_id":1}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(utils.ContextWithValue(m.r.Context(), "Authorization", "access-token"))
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().CreateCharge(m.r.Context(), "fake", model.CreateChargeRequest{OrderID: 1}).Return(nil, apperrors.ErrOrderStatusTransition)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/payments/fake/charges [post] 'error unmarshal request'",
+			handler: &paymentHandler{},
+			params:  params{payload: `{"order_id":"1"`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			paymentServiceMock := service.NewMockPaymentService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/payments/fake/charges", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, w: w, rctx: rctx, paymentServiceMock: paymentServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.paymentService = m.paymentServiceMock
+
+			handler := tt.handler.CreateCharge()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_paymentHandler_Webhook(t *testing.T) {
+	type mocks struct {
+		r                  *http.Request
+		w                  *httptest.ResponseRecorder
+		rctx               *chi.Context
+		paymentServiceMock *service.MockPaymentService
+	}
+	type params struct {
+		payload string
+	}
+	payload := `{"id":"evt_1","type":"payment.succeeded","charge_id":"ch_1","order_id":1,"amount":50000}`
+	tests := []struct {
+		name           string
+		handler        *paymentHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/payments/webhook/fake [post] 'processed'",
+			handler: &paymentHandler{},
+			params:  params{payload: payload},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("X-Webhook-Signature", "signature")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().
+					HandleWebhook(m.r.Context(), "fake", []byte(payload), "signature").
+					Return(&model.PaymentWebhookResponse{EventID: "evt_1", Result: "processed", OrderStatus: "PAID"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"payment":{"event_id":"evt_1","result":"processed","order_status":"PAID"}},"process_time":0}`,
+		},
+		{
+			name:    "success hit api /api/v1/payments/webhook/fake [post] 'replayed event'",
+			handler: &paymentHandler{},
+			params:  params{payload: payload},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("X-Webhook-Signature", "signature")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().
+					HandleWebhook(m.r.Context(), "fake", []byte(payload), "signature").
+					Return(&model.PaymentWebhookResponse{EventID: "evt_1", Result: "duplicate"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"payment":{"event_id":"evt_1","result":"duplicate"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/payments/webhook/fake [post] 'invalid signature'",
+			handler: &paymentHandler{},
+			params:  params{payload: payload},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("X-Webhook-Signature", "forged-signature")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().
+					HandleWebhook(m.r.Context(), "fake", []byte(payload), "forged-signature").
+					Return(nil, apperrors.ErrInvalidSignature)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/payments/webhook/fake [post] 'error internal server'",
+			handler: &paymentHandler{},
+			params:  params{payload: payload},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("X-Webhook-Signature", "signature")
+				m.rctx.URLParams.Add("provider", "fake")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.paymentServiceMock.EXPECT().
+					HandleWebhook(m.r.Context(), "fake", []byte(payload), "signature").
+					Return(nil, errors.New("oops! internal server error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			paymentServiceMock := service.NewMockPaymentService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/payments/webhook/fake", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, w: w, rctx: rctx, paymentServiceMock: paymentServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.paymentService = m.paymentServiceMock
+
+			handler := tt.handler.Webhook()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"error":{"message":".*"`, `"error":{"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
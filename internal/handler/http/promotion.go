@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+)
+
+type PromotionHandler interface {
+	GetByID() http.HandlerFunc
+	List() http.HandlerFunc
+	Create() http.HandlerFunc
+	Update() http.HandlerFunc
+	Delete() http.HandlerFunc
+}
+
+type promotionHandler struct {
+	promotionService service.PromotionService
+}
+
+// authorization token assume exists on context passed by authHandler.Authorize middleware
+
+func NewPromotionHandler(promotionService service.PromotionService) PromotionHandler {
+	return &promotionHandler{promotionService: promotionService}
+}
+
+// GetPromotionByID godoc
+//	@Router			/promotion/{id} [get]
+//	@Summary		Get promotion
+//	@Description	Show promotion detail by given id
+//	@Tags			promotion
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			id				path	int		true	"Promotion id"				Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.PromotionResponse{promotion=model.GetPromotionResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																	"Bad request"
+//	@Failure		404	{object}	web.ErrJSONResponse																	"Promotion not found"
+//	@Failure		401	{object}	web.ErrJSONResponse																	"Unauthorized"
+func (handler *promotionHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.promotionHandler.GetByID: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		promotion, err := handler.promotionService.GetByID(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.PromotionResponse{Promotion: promotion}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// ListPromotion godoc
+//	@Router			/promotion [get]
+//	@Summary		Show list of promotions
+//	@Description	Show list of promotions by (optionally) by given limit of offset
+//	@Tags			promotion
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			limit			query	int		false	"Pagination limit"			Format(int64)
+//	@param			offset			query	int		false	"Pagination offset"			Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.PromotionResponse{promotion=[]model.GetPromotionResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse																		"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse																		"Bad request"
+func (handler *promotionHandler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		limit, offset, err := web.PaginationLimitOffset(r)
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.promotionHandler.List: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid query params", start)
+			return
+		}
+
+		promotions, err := handler.promotionService.List(r.Context(), limit, offset)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.PromotionResponse{Promotion: promotions}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// CreatePromotion godoc
+//	@Router			/promotion [post]
+//	@Summary		Create a promotion
+//	@Description	Create a new discount code, percentage or fixed amount discount optionally targeting menu's ids or categories
+//	@Tags			promotion
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																					true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CreatePromotionRequest															true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.PromotionResponse{promotion=model.CreatePromotionResponse}}	"Ok"
+//	@Failure		500				{object}	web.ErrJSONResponse																		"Internal server error"
+//	@Failure		400				{object}	web.ErrJSONResponse																		"Bad request"
+//	@Failure		409				{object}	web.ErrJSONResponse																		"Promo code already registered"
+//	@Failure		422				{object}	web.ErrJSONResponse																		"Unprocessable entity"
+func (handler *promotionHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CreatePromotionRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.promotionHandler.Create: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		promotion, err := handler.promotionService.Create(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.PromotionResponse{Promotion: promotion}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdatePromotion godoc
+//	@Router			/promotion/{id} [put]
+//	@Summary		Update promotion
+//	@Description	Replace promotion by given id, set ends_at to end a promotion which has been used
+//	@Tags			promotion
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																						true	"Promotion id"				Format(int64)
+//	@Param			Authorization	header		string																					true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdatePromotionRequest															true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.PromotionResponse{promotion=model.UpdatePromotionResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse																		"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse																		"Unauthorized"
+//	@Failure		404				{object}	web.ErrJSONResponse																		"Promotion not found"
+//	@Failure		409				{object}	web.ErrJSONResponse																		"Promo code already registered"
+//	@Failure		422				{object}	web.ErrJSONResponse																		"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse																		"Internal server error"
+func (handler *promotionHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdatePromotionRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.promotionHandler.Update: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.promotionHandler.Update: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		promotion, err := handler.promotionService.Update(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.PromotionResponse{Promotion: promotion}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeletePromotion godoc
+//	@Router			/promotion/{id} [delete]
+//	@Summary		Delete promotion
+//	@Description	Delete promotion by given id, a promotion which has been used by an order can't be deleted
+//	@Tags			promotion
+//	@param			id				path	int		true	"Promotion id"				Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		401	{object}	web.ErrJSONResponse	"Unauthorized"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Promotion not found"
+//	@Failure		409	{object}	web.ErrJSONResponse	"Promotion has been used"
+func (handler *promotionHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.promotionHandler.Delete: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		_, err = handler.promotionService.Delete(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
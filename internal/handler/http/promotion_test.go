@@ -0,0 +1,360 @@
+package handler
+
+import (
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestNewPromotionHandler(t *testing.T) {
+	type args struct {
+		promotionService service.PromotionService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewPromotionHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewPromotionHandler(tt.args.promotionService))
+		})
+	}
+}
+
+func Test_promotionHandler_GetByID(t *testing.T) {
+	type mocks struct {
+		r                    *http.Request
+		rctx                 *chi.Context
+		promotionServiceMock *service.MockPromotionService
+	}
+	type params struct {
+		id string
+	}
+	tests := []struct {
+		name           string
+		handler        *promotionHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api/v1/promotion/{id} [get] 'ok'",
+			handler: &promotionHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.promotionServiceMock.EXPECT().GetByID(m.r.Context(), int64(1)).
+					Return(&model.GetPromotionResponse{
+						ID: 1, Code: "POTONG5RB", DiscountType: "fixed", Amount: money.MustParse("5000"),
+						MaxDiscount: money.FromMinor(0), MinSpend: money.MustParse("50000"), MenuIDs: []int64{1},
+						StartsAt: "2022-11-01T00:00:00Z", UsageLimitPerCustomer: 1, Active: true,
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "promotion": {
+					"id": 1,
+					"code": "POTONG5RB",
+					"description": "",
+					"discount_type": "fixed",
+					"amount": "5000.00",
+					"max_discount": "0.00",
+					"min_spend": "50000.00",
+					"menu_ids": [1],
+					"categories": "",
+					"starts_at": "2022-11-01T00:00:00Z",
+					"usage_limit": 0,
+					"usage_limit_per_customer": 1,
+					"active": true
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api/v1/promotion/{id} [get] 'invalid path params'",
+			handler: &promotionHandler{},
+			params:  params{id: "one"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "one")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/promotion/{id} [get] 'not found'",
+			handler: &promotionHandler{},
+			params:  params{id: "1000000"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1000000")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.promotionServiceMock.EXPECT().GetByID(m.r.Context(), int64(1_000_000)).Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			promotionServiceMock := service.NewMockPromotionService(ctrl)
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/promotion/%s", tt.params.id), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, promotionServiceMock: promotionServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.promotionService = m.promotionServiceMock
+
+			handler := tt.handler.GetByID()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_promotionHandler_Create(t *testing.T) {
+	type mocks struct {
+		r                    *http.Request
+		promotionServiceMock *service.MockPromotionService
+	}
+	type params struct {
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *promotionHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/promotion [post] 'ok'",
+			handler: &promotionHandler{},
+			params: params{
+				payload: `{
+					"code":"hemat10",
+					"discount_type":"percentage",
+					"percentage":10,
+					"max_discount":"20000",
+					"categories":"Drink"
+				  }`,
+			},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.promotionServiceMock.EXPECT().
+					Create(m.r.Context(), model.CreatePromotionRequest{
+						Code: "hemat10", DiscountType: "percentage", Percentage: 10, MaxDiscount: money.MustParse("20000"), Categories: "Drink",
+					}).
+					Return(&model.CreatePromotionResponse{
+						ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, Amount: money.FromMinor(0),
+						MaxDiscount: money.MustParse("20000"), MinSpend: money.FromMinor(0), MenuIDs: []int64{}, Categories: "Drink",
+						StartsAt: "2022-11-01T00:00:00Z", Active: true,
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "promotion": {
+					"id": 1,
+					"code": "HEMAT10",
+					"description": "",
+					"discount_type": "percentage",
+					"percentage": 10,
+					"amount": "0.00",
+					"max_discount": "20000.00",
+					"min_spend": "0.00",
+					"menu_ids": [],
+					"categories": "Drink",
+					"starts_at": "2022-11-01T00:00:00Z",
+					"usage_limit": 0,
+					"usage_limit_per_customer": 0,
+					"active": true
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "fail hit api /api/v1/promotion [post] 'promo code registered'",
+			handler: &promotionHandler{},
+			params:  params{payload: `{"code":"HEMAT10","discount_type":"fixed","amount":5000}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.promotionServiceMock.EXPECT().
+					Create(m.r.Context(), gomock.AssignableToTypeOf(model.CreatePromotionRequest{})).
+					Return(nil, apperrors.ErrPromoCodeRegistered)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/promotion [post] 'error unmarshal request payload'",
+			handler: &promotionHandler{},
+			params:  params{payload: `{"code":"HEMAT10","discount_type":"fixed","amount":"five thousand"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/promotion [post] 'internal server error'",
+			handler: &promotionHandler{},
+			params:  params{payload: `{"code":"HEMAT10","discount_type":"fixed","amount":5000}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.promotionServiceMock.EXPECT().
+					Create(m.r.Context(), gomock.AssignableToTypeOf(model.CreatePromotionRequest{})).
+					Return(nil, errors.New("oops! internal server error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			promotionServiceMock := service.NewMockPromotionService(ctrl)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/promotion", strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, promotionServiceMock: promotionServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.promotionService = m.promotionServiceMock
+
+			handler := tt.handler.Create()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_promotionHandler_Delete(t *testing.T) {
+	type mocks struct {
+		r                    *http.Request
+		rctx                 *chi.Context
+		promotionServiceMock *service.MockPromotionService
+	}
+	type params struct {
+		id string
+	}
+	tests := []struct {
+		name           string
+		handler        *promotionHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/promotion/{id} [delete] 'ok'",
+			handler: &promotionHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.promotionServiceMock.EXPECT().Delete(m.r.Context(), int64(1)).Return(int64(1), nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/promotion/{id} [delete] 'promotion has been used'",
+			handler: &promotionHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.promotionServiceMock.EXPECT().Delete(m.r.Context(), int64(1)).Return(int64(0), apperrors.ErrPromotionInUse)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/promotion/{id} [delete] 'not found'",
+			handler: &promotionHandler{},
+			params:  params{id: "1"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.promotionServiceMock.EXPECT().Delete(m.r.Context(), int64(1)).Return(int64(0), apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			promotionServiceMock := service.NewMockPromotionService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/promotion/%s", tt.params.id), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, promotionServiceMock: promotionServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.promotionService = m.promotionServiceMock
+
+			handler := tt.handler.Delete()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type ReportHandler interface {
+	Sales() http.HandlerFunc
+	Customers() http.HandlerFunc
+}
+
+// defaultChurnDays is the days without order before a customer is churned when the churn-days query param is missing
+const defaultChurnDays int = 60
+
+type reportHandler struct {
+	reportService service.ReportService
+}
+
+// authorization token assume exists on context passed by authHandler.Authorize middleware
+
+func NewReportHandler(reportService service.ReportService) ReportHandler {
+	return &reportHandler{reportService: reportService}
+}
+
+// SalesReport godoc
+//	@Router			/reports/sales [get]
+//	@Summary		Sales report
+//	@Description	Show the revenue (orders' grand total after discount, service charge and tax), order count, average order value and items sold of the orders created within a range,
+//	@Description	grouped by day, week (starts on monday), month, menu, category (menu's first category) or customer's email. Only paid orders which haven't been refunded are counted.
+//	@Tags			report
+//	@Produce		json,text/csv
+//	@Param			Authorization	header		string															true	"Insert your access token"			default(Bearer <your access token here>)
+//	@Param			group-by		query		string															false	"Grouping"							Enums(day, week, month, menu, category, customer)	default(day)
+//	@Param			start-day		query		string															true	"First day (YYYY-MM-DD or today)"
+//	@Param			end-day			query		string															true	"Last day, inclusive (YYYY-MM-DD or today)"
+//	@Param			format			query		string															false	"Response format"					Enums(json, csv)	default(json)
+//	@Success		200				{object}	web.JSONResponse{data=model.ReportResponse{report=model.SalesReportResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse												"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse												"Unauthorized"
+//	@Failure		422				{object}	web.ErrJSONResponse												"Invalid query"
+//	@Failure		500				{object}	web.ErrJSONResponse												"Internal server error"
+func (handler *reportHandler) Sales() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		query := model.SalesReportQuery{GroupBy: strings.ToLower(r.URL.Query().Get("group-by"))}
+		if query.GroupBy == "" {
+			query.GroupBy = "day"
+		}
+		var err error
+		query.StartDay, err = reportDayParam(r, "start-day")
+		if err == nil {
+			query.EndDay, err = reportDayParam(r, "end-day")
+		}
+		if err != nil {
+			err = fmt.Errorf("handler.reportHandler.Sales: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "start-day and end-day must be formatted as YYYY-MM-DD", start)
+			return
+		}
+
+		format := strings.ToLower(r.URL.Query().Get("format"))
+		if format != "" && format != "json" && format != "csv" {
+			err = fmt.Errorf("handler.reportHandler.Sales: unknown format %q", format)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "format must be one of json or csv", start)
+			return
+		}
+
+		report, err := handler.reportService.Sales(r.Context(), query)
+		if err != nil {
+			err = fmt.Errorf("handler.reportHandler.Sales: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		if format != "csv" {
+			payload := model.ReportResponse{Report: report}
+			web.WriteSuccessJSON(w, payload, start)
+			return
+		}
+
+		filename := fmt.Sprintf("sales-by-%s-%s-%s.csv", report.GroupBy, report.StartDay, report.EndDay)
+		err = web.WriteCSV(w, filename, salesReportRecords(report))
+		if err != nil {
+			err = fmt.Errorf("handler.reportHandler.Sales: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+	}
+}
+
+// CustomerReport godoc
+//	@Router			/reports/customers [get]
+//	@Summary		Customer report
+//	@Description	Show the lifetime spend (orders' grand total), order count, average order value, first and last order date, average days between orders and the 3 favourite menus
+//	@Description	of each customer's email. A customer is churned when it hasn't ordered for more than churn-days days. Only paid orders which haven't been refunded are counted.
+//	@Tags			report
+//	@Produce		json
+//	@Param			Authorization	header		string																true	"Insert your access token"			default(Bearer <your access token here>)
+//	@Param			sort			query		string																false	"Sort by"							Enums(lifetime_spend, orders, average_order_value, first_order, last_order, email)	default(lifetime_spend)
+//	@Param			order			query		string																false	"Sort direction"					Enums(asc, desc)	default(desc)
+//	@Param			churn-days		query		int																	false	"Days without order to be churned"	default(60)
+//	@Param			offset			query		int																	false	"Offset"
+//	@Param			limit			query		int																	false	"Limit"
+//	@Success		200				{object}	web.JSONResponse{data=model.ReportResponse{report=model.CustomerReportResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse													"Bad request"
+//	@Failure		401				{object}	web.ErrJSONResponse													"Unauthorized"
+//	@Failure		422				{object}	web.ErrJSONResponse													"Invalid query"
+//	@Failure		500				{object}	web.ErrJSONResponse													"Internal server error"
+func (handler *reportHandler) Customers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		limit, offset, err := web.PaginationLimitOffset(r)
+		if err != nil {
+			err = fmt.Errorf("handler.reportHandler.Customers: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid query params", start)
+			return
+		}
+
+		query := model.CustomerReportQuery{
+			SortBy:    strings.ToLower(r.URL.Query().Get("sort")),
+			Order:     strings.ToLower(r.URL.Query().Get("order")),
+			ChurnDays: defaultChurnDays,
+			Limit:     limit,
+			Offset:    offset,
+		}
+		if query.SortBy == "" {
+			query.SortBy = "lifetime_spend"
+		}
+		if query.Order == "" {
+			query.Order = "desc"
+		}
+		if val := r.URL.Query().Get("churn-days"); val != "" {
+			query.ChurnDays, err = strconv.Atoi(val)
+			if err != nil {
+				err = fmt.Errorf("handler.reportHandler.Customers: %w", err)
+				log.Error(err, "invalid query params")
+				web.WriteFailJSON(w, http.StatusBadRequest, "churn-days must be a number", start)
+				return
+			}
+		}
+
+		report, err := handler.reportService.Customers(r.Context(), query)
+		if err != nil {
+			err = fmt.Errorf("handler.reportHandler.Customers: %w", err)
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.ReportResponse{Report: report}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// reportDayParam return the day of the query param, empty when it is missing (see web.ParseDay)
+func reportDayParam(r *http.Request, key string) (string, error) {
+	val := r.URL.Query().Get(key)
+	if val == "" {
+		return "", nil
+	}
+
+	return web.ParseDay(val)
+}
+
+// salesReportRecords flatten the sales report to one record per group followed by the total
+func salesReportRecords(report *model.SalesReportResponse) [][]string {
+	records := [][]string{{report.GroupBy, "menu_id", "revenue", "orders", "average_order_value", "items_sold"}}
+	row := func(group string, res *model.SalesReportRowResponse) []string {
+		menuID := ""
+		if res.MenuID != 0 {
+			menuID = strconv.FormatInt(res.MenuID, 10)
+		}
+		return []string{group, menuID, res.Revenue.String(), strconv.Itoa(res.Orders), res.AverageOrderValue.String(), strconv.Itoa(res.ItemsSold)}
+	}
+
+	for _, res := range report.Rows {
+		records = append(records, row(res.Group, res))
+	}
+	records = append(records, row("total", report.Total))
+
+	return records
+}
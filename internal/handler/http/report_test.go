@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReportHandler(t *testing.T) {
+	type args struct {
+		reportService service.ReportService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewReportHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewReportHandler(tt.args.reportService))
+		})
+	}
+}
+
+func Test_reportHandler_Sales(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		reportServiceMock *service.MockReportService
+	}
+	report := &model.SalesReportResponse{
+		GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30",
+		Total: &model.SalesReportRowResponse{Revenue: money.MustParse("75000"), Orders: 2, AverageOrderValue: money.MustParse("37500"), ItemsSold: 4},
+		Rows: []*model.SalesReportRowResponse{
+			{Group: "sate", MenuID: 1, Revenue: money.MustParse("50000"), Orders: 2, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+			{Group: "soto", MenuID: 2, Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+		},
+	}
+	query := model.SalesReportQuery{GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30"}
+	tests := []struct {
+		name            string
+		handler         *reportHandler
+		params          map[string]string
+		prepareMocks    func(*mocks)
+		wantStatusCode  int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:    "success hit api/v1/reports/sales [get] 'ok'",
+			handler: &reportHandler{},
+			params:  map[string]string{"group-by": "menu", "start-day": "2022-11-01", "end-day": "2022-11-30"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Sales(m.r.Context(), query).Return(report, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/json",
+			wantBody: `{
+				"success": true,
+				"status": "success",
+				"data": {
+				  "report": {
+					"group_by": "menu",
+					"start_day": "2022-11-01",
+					"end_day": "2022-11-30",
+					"total": {"group": "", "revenue": "75000.00", "orders": 2, "average_order_value": "37500.00", "items_sold": 4},
+					"rows": [
+					  {"group": "sate", "menu_id": 1, "revenue": "50000.00", "orders": 2, "average_order_value": "25000.00", "items_sold": 2},
+					  {"group": "soto", "menu_id": 2, "revenue": "25000.00", "orders": 1, "average_order_value": "25000.00", "items_sold": 2}
+					]
+				  }
+				},
+				"process_time": 0
+			  }`,
+		},
+		{
+			name:    "success hit api/v1/reports/sales [get] 'ok' (csv)",
+			handler: &reportHandler{},
+			params:  map[string]string{"group-by": "MENU", "start-day": "2022-11-01", "end-day": "2022-11-30", "format": "csv"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Sales(m.r.Context(), query).Return(report, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "menu,menu_id,revenue,orders,average_order_value,items_sold\n" +
+				"sate,1,50000.00,2,25000.00,2\n" +
+				"soto,2,25000.00,1,25000.00,2\n" +
+				"total,,75000.00,2,37500.00,4\n",
+		},
+		{
+			name:    "success hit api/v1/reports/sales [get] 'ok' (by day by default)",
+			handler: &reportHandler{},
+			params:  map[string]string{"start-day": "2022-11-01", "end-day": "2022-11-01"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Sales(m.r.Context(), model.SalesReportQuery{GroupBy: "day", StartDay: "2022-11-01", EndDay: "2022-11-01"}).
+					Return(&model.SalesReportResponse{
+						GroupBy: "day", StartDay: "2022-11-01", EndDay: "2022-11-01",
+						Total: &model.SalesReportRowResponse{}, Rows: []*model.SalesReportRowResponse{},
+					}, nil)
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/json",
+			wantBody: `{"success":true,"status":"success","data":{"report":{"group_by":"day","start_day":"2022-11-01","end_day":"2022-11-01",
+				"total":{"group":"","revenue":"0.00","orders":0,"average_order_value":"0.00","items_sold":0},"rows":[]}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/sales [get] 'invalid day'",
+			handler: &reportHandler{},
+			params:  map[string]string{"start-day": "01/11/2022", "end-day": "2022-11-30"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/sales [get] 'invalid format'",
+			handler: &reportHandler{},
+			params:  map[string]string{"start-day": "2022-11-01", "end-day": "2022-11-30", "format": "xlsx"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/sales [get] 'invalid query'",
+			handler: &reportHandler{},
+			params:  map[string]string{"group-by": "year", "start-day": "2022-11-01", "end-day": "2022-11-30"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Sales(m.r.Context(), model.SalesReportQuery{GroupBy: "year", StartDay: "2022-11-01", EndDay: "2022-11-30"}).
+					Return(nil, apperrors.ErrFieldValidation)
+			},
+			wantStatusCode:  http.StatusUnprocessableEntity,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/sales [get] 'internal server error'",
+			handler: &reportHandler{},
+			params:  map[string]string{"group-by": "menu", "start-day": "2022-11-01", "end-day": "2022-11-30"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Sales(m.r.Context(), query).Return(nil, errors.New("oops! error internal server"))
+			},
+			wantStatusCode:  http.StatusInternalServerError,
+			wantContentType: "application/json",
+			wantBody:        `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			reportServiceMock := service.NewMockReportService(ctrl)
+			params := url.Values{}
+			for key, val := range tt.params {
+				params.Set(key, val)
+			}
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/reports/sales?"+params.Encode(), nil)
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, reportServiceMock: reportServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.reportService = m.reportServiceMock
+
+			handler := tt.handler.Sales()
+
+			handler(w, r)
+
+			resp := w.Result()
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"))
+			if tt.wantContentType != "application/json" {
+				assert.Equal(t, `attachment; filename=sales-by-menu-2022-11-01-2022-11-30.csv`, resp.Header.Get("Content-Disposition"))
+				assert.Equal(t, tt.wantBody, w.Body.String())
+				return
+			}
+			// resetting processing time to 0 & error message to a unchanged string
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_reportHandler_Customers(t *testing.T) {
+	type mocks struct {
+		r                 *http.Request
+		reportServiceMock *service.MockReportService
+	}
+	report := &model.CustomerReportResponse{
+		SortBy: "orders", Order: "asc", ChurnDays: 30, Total: 12,
+		Customers: []*model.CustomerStatsResponse{
+			{
+				Email: "ani@gmail.com", LifetimeSpend: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"),
+				FirstOrderAt: "2022-08-01", LastOrderAt: "2022-08-01", Churned: true,
+				FavouriteMenus: []*model.CustomerFavouriteMenu{{MenuID: 2, MenuName: "nasi kuning", Qty: 1}},
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		handler        *reportHandler
+		params         map[string]string
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api/v1/reports/customers [get] 'ok'",
+			handler: &reportHandler{},
+			params:  map[string]string{"sort": "orders", "order": "ASC", "churn-days": "30", "offset": "0"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Customers(m.r.Context(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, query model.CustomerReportQuery) (*model.CustomerReportResponse, error) {
+						assert.Equal(t, "orders", query.SortBy)
+						assert.Equal(t, "asc", query.Order)
+						assert.Equal(t, 30, query.ChurnDays)
+						return report, nil
+					})
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{"success":true,"status":"success","data":{"report":{"sort_by":"orders","order":"asc","churn_days":30,"total":12,"customers":[
+				{"email":"ani@gmail.com","lifetime_spend":"25000.00","orders":1,"average_order_value":"25000.00","average_days_between_orders":0,
+				"first_order_at":"2022-08-01","last_order_at":"2022-08-01","churned":true,"favourite_menus":[{"menu_id":2,"menu_name":"nasi kuning","qty":1}]}]}},
+				"process_time":0}`,
+		},
+		{
+			name:    "success hit api/v1/reports/customers [get] 'default query'",
+			handler: &reportHandler{},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Customers(m.r.Context(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, query model.CustomerReportQuery) (*model.CustomerReportResponse, error) {
+						assert.Equal(t, "lifetime_spend", query.SortBy)
+						assert.Equal(t, "desc", query.Order)
+						assert.Equal(t, defaultChurnDays, query.ChurnDays)
+						return &model.CustomerReportResponse{SortBy: "lifetime_spend", Order: "desc", ChurnDays: 60, Customers: []*model.CustomerStatsResponse{}}, nil
+					})
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{"success":true,"status":"success","data":{"report":{"sort_by":"lifetime_spend","order":"desc","churn_days":60,"total":0,"customers":[]}},
+				"process_time":0}`,
+		},
+		{
+			name:           "fail hit api/v1/reports/customers [get] 'invalid pagination'",
+			handler:        &reportHandler{},
+			params:         map[string]string{"offset": "one"},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:           "fail hit api/v1/reports/customers [get] 'invalid churn days'",
+			handler:        &reportHandler{},
+			params:         map[string]string{"churn-days": "a month"},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/customers [get] 'invalid query'",
+			handler: &reportHandler{},
+			params:  map[string]string{"sort": "name"},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Customers(m.r.Context(), gomock.Any()).Return(nil, apperrors.ErrFieldValidation)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/customers [get] 'no auth'",
+			handler: &reportHandler{},
+			prepareMocks: func(m *mocks) {
+				m.reportServiceMock.EXPECT().Customers(m.r.Context(), gomock.Any()).Return(nil, apperrors.ErrAuth)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api/v1/reports/customers [get] 'internal server error'",
+			handler: &reportHandler{},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.reportServiceMock.EXPECT().Customers(m.r.Context(), gomock.Any()).Return(nil, errors.New("oops! error internal server"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"success":false,"status":"error","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			reportServiceMock := service.NewMockReportService(ctrl)
+			params := url.Values{}
+			for key, val := range tt.params {
+				params.Set(key, val)
+			}
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/reports/customers?"+params.Encode(), nil)
+			w := httptest.NewRecorder()
+			m := &mocks{r: r, reportServiceMock: reportServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.reportService = m.reportServiceMock
+
+			handler := tt.handler.Customers()
+
+			handler(w, r)
+
+			resp := w.Result()
+			// resetting processing time to 0 & error message to a unchanged string
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
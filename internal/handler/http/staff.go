@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	log "family-catering/pkg/logger"
+	"family-catering/pkg/web"
+	"fmt"
+	"net/http"
+)
+
+type StaffHandler interface {
+	List() http.HandlerFunc
+	Create() http.HandlerFunc
+	UpdateRole() http.HandlerFunc
+	Delete() http.HandlerFunc
+	ResetTwoFactor() http.HandlerFunc
+}
+
+type staffHandler struct {
+	staffService service.StaffService
+}
+
+// authorization token and admin role assume checked by authHandler middlewares
+
+func NewStaffHandler(staffService service.StaffService) StaffHandler {
+	return &staffHandler{staffService: staffService}
+}
+
+// ListStaff godoc
+//	@Router			/staff [get]
+//	@Summary		Show list of staff
+//	@Description	Show list of staff accounts and their role by (optionally) by given limit of offset, admin only
+//	@Tags			staff
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			limit			query	int		false	"Pagination limit"			Format(int64)
+//	@param			offset			query	int		false	"Pagination offset"			Format(int64)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse{data=model.StaffResponse{staff=[]model.GetStaffResponse}}	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse															"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		403	{object}	web.ErrJSONResponse															"Forbidden"
+func (handler *staffHandler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		limit, offset, err := web.PaginationLimitOffset(r)
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.staffHandler.List: %w", err)
+			log.Error(err, "invalid query params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid query params", start)
+			return
+		}
+
+		staff, err := handler.staffService.List(r.Context(), limit, offset)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.StaffResponse{Staff: staff}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// CreateStaff godoc
+//	@Router			/staff [post]
+//	@Summary		Create a staff account
+//	@Description	Create a staff account with the given role, admin only
+//	@Tags			staff
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header		string																		true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.CreateStaffRequest													true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.StaffResponse{staff=model.GetStaffResponse}}	"Ok"
+//	@Failure		500				{object}	web.ErrJSONResponse															"Internal server error"
+//	@Failure		400				{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		403				{object}	web.ErrJSONResponse															"Forbidden"
+//	@Failure		409				{object}	web.ErrJSONResponse															"Email already registered"
+//	@Failure		422				{object}	web.ErrJSONResponse															"Unprocessable entity"
+func (handler *staffHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.CreateStaffRequest{}
+
+		defer r.Body.Close()
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.staffHandler.Create: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		staff, err := handler.staffService.Create(r.Context(), req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.StaffResponse{Staff: staff}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// UpdateStaffRole godoc
+//	@Router			/staff/{id}/role [put]
+//	@Summary		Update staff's role
+//	@Description	Change the role of a staff account, effective from the staff's next access token. The last admin can't lose its role, admin only
+//	@Tags			staff
+//	@Accept			json
+//	@produce		json
+//	@param			id				path		int																			true	"Staff (owner) id"			Format(int64)
+//	@Param			Authorization	header		string																		true	"Insert your access token"	default(Bearer <your access token here>)
+//	@param			payload			body		model.UpdateStaffRoleRequest												true	"body request"
+//	@Success		200				{object}	web.JSONResponse{data=model.StaffResponse{staff=model.GetStaffResponse}}	"Ok"
+//	@Failure		400				{object}	web.ErrJSONResponse															"Bad request"
+//	@Failure		403				{object}	web.ErrJSONResponse															"Forbidden"
+//	@Failure		404				{object}	web.ErrJSONResponse															"Staff not found"
+//	@Failure		409				{object}	web.ErrJSONResponse															"Last admin"
+//	@Failure		422				{object}	web.ErrJSONResponse															"Unprocessable entity"
+//	@Failure		500				{object}	web.ErrJSONResponse															"Internal server error"
+func (handler *staffHandler) UpdateRole() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+		req := model.UpdateStaffRoleRequest{}
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.staffHandler.UpdateRole: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+		defer r.Body.Close()
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			err := fmt.Errorf("handler.staffHandler.UpdateRole: %w", err)
+			log.Error(err, "error unmarshal request")
+			web.WriteFailJSON(w, http.StatusBadRequest, "error unmarshal request", start)
+			return
+		}
+
+		staff, err := handler.staffService.UpdateRole(r.Context(), id, req)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		payload := model.StaffResponse{Staff: staff}
+		web.WriteSuccessJSON(w, payload, start)
+	}
+}
+
+// DeleteStaff godoc
+//	@Router			/staff/{id} [delete]
+//	@Summary		Delete staff account
+//	@Description	Delete a staff account by given id, the last admin can't be deleted, admin only
+//	@Tags			staff
+//	@param			id				path	int		true	"Staff (owner) id"			Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		403	{object}	web.ErrJSONResponse	"Forbidden"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Staff not found"
+//	@Failure		409	{object}	web.ErrJSONResponse	"Last admin"
+func (handler *staffHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.staffHandler.Delete: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		_, err = handler.staffService.Delete(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
+
+// ResetStaffTwoFactor godoc
+//	@Router			/staff/{id}/two-factor [delete]
+//	@Summary		Reset staff's two-factor authentication
+//	@Description	Disable the two-factor authentication of a staff who lost its authenticator app and recovery codes, admin only
+//	@Tags			staff
+//	@param			id				path	int		true	"Staff (owner) id"			Format(int64)
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <your access token here>)
+//	@Produce		json
+//	@Success		200	{object}	web.JSONResponse	"Ok"
+//	@Failure		500	{object}	web.ErrJSONResponse	"Internal server error"
+//	@Failure		400	{object}	web.ErrJSONResponse	"Bad request"
+//	@Failure		403	{object}	web.ErrJSONResponse	"Forbidden"
+//	@Failure		404	{object}	web.ErrJSONResponse	"Staff not found"
+func (handler *staffHandler) ResetTwoFactor() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := web.RequestStartTimeFromContext(r.Context())
+
+		id, err := web.PathParamInt64(r, "id")
+		if !errors.Is(err, nil) {
+			err := fmt.Errorf("handler.staffHandler.ResetTwoFactor: %w", err)
+			log.Error(err, "invalid path params")
+			web.WriteFailJSON(w, http.StatusBadRequest, "invalid path params", start)
+			return
+		}
+
+		err = handler.staffService.ResetTwoFactor(r.Context(), id)
+		if err != nil {
+			web.WriteHTTPError(w, err, start)
+			return
+		}
+
+		web.WriteSuccessJSON(w, nil, start)
+	}
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"family-catering/internal/model"
+	"family-catering/internal/service"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStaffHandler(t *testing.T) {
+	type args struct {
+		staffService service.StaffService
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewStaffHandler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewStaffHandler(tt.args.staffService))
+		})
+	}
+}
+
+func Test_staffHandler_UpdateRole(t *testing.T) {
+	type mocks struct {
+		r                *http.Request
+		rctx             *chi.Context
+		staffServiceMock *service.MockStaffService
+	}
+	type params struct {
+		id      string
+		payload string
+	}
+	tests := []struct {
+		name           string
+		handler        *staffHandler
+		params         params
+		prepareMocks   func(*mocks)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success hit api /api/v1/staff/{id}/role [put] 'ok'",
+			handler: &staffHandler{},
+			params:  params{id: "2", payload: `{"role":"kitchen"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "2")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.staffServiceMock.EXPECT().
+					UpdateRole(m.r.Context(), int64(2), model.UpdateStaffRoleRequest{Role: consts.RoleKitchen}).
+					Return(&model.GetStaffResponse{Id: 2, Name: "Budi", Email: "budi@example.com", Role: consts.RoleKitchen}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"success":true,"status":"success","data":{"staff":{"id":2,"name":"Budi","email":"budi@example.com","role":"kitchen"}},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/staff/{id}/role [put] 'invalid path params'",
+			handler: &staffHandler{},
+			params:  params{id: "two", payload: `{"role":"kitchen"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "two")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+		{
+			name:    "fail hit api /api/v1/staff/{id}/role [put] 'last admin'",
+			handler: &staffHandler{},
+			params:  params{id: "1", payload: `{"role":"viewer"}`},
+			prepareMocks: func(m *mocks) {
+				m.r.Header.Set("Content-Type", "application/json")
+				m.r.Header.Set("Authorization", "Bearer access-token")
+				m.rctx.URLParams.Add("id", "1")
+				*m.r = *m.r.WithContext(context.WithValue(m.r.Context(), chi.RouteCtxKey, m.rctx))
+				m.staffServiceMock.EXPECT().
+					UpdateRole(m.r.Context(), int64(1), model.UpdateStaffRoleRequest{Role: consts.RoleViewer}).
+					Return(nil, apperrors.ErrLastAdmin)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"success":false,"status":"fail","error":{"message":"oops! error"},"process_time":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			staffServiceMock := service.NewMockStaffService(ctrl)
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/staff/%s/role", tt.params.id), strings.NewReader(tt.params.payload))
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			m := &mocks{r: r, rctx: rctx, staffServiceMock: staffServiceMock}
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(m)
+			}
+			tt.handler.staffService = m.staffServiceMock
+
+			handler := tt.handler.UpdateRole()
+
+			handler(w, r)
+
+			// resetting processing time to 0 & error message to a unchanged string
+			resp := w.Result()
+			respBodyStr := regexReplaceAllMultiple(w.Body.String(), `"process_time":\d+`, `"process_time":0`, `"message":".*"`, `"message":"oops! error"`)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.JSONEq(t, tt.wantBody, respBodyStr)
+		})
+	}
+}
+
+func Test_staffHandler_ResetTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        *staffHandler
+		id             string
+		prepareMocks   func(*service.MockStaffService)
+		wantStatusCode int
+	}{
+		{
+			name:    "success hit api /api/v1/staff/{id}/two-factor [delete] 'ok'",
+			handler: &staffHandler{},
+			id:      "2",
+			prepareMocks: func(staffServiceMock *service.MockStaffService) {
+				staffServiceMock.EXPECT().ResetTwoFactor(gomock.Any(), int64(2)).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "fail hit api /api/v1/staff/{id}/two-factor [delete] 'invalid path params'",
+			handler:        &staffHandler{},
+			id:             "two",
+			prepareMocks:   func(*service.MockStaffService) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "fail hit api /api/v1/staff/{id}/two-factor [delete] 'not found'",
+			handler: &staffHandler{},
+			id:      "9",
+			prepareMocks: func(staffServiceMock *service.MockStaffService) {
+				staffServiceMock.EXPECT().ResetTwoFactor(gomock.Any(), int64(9)).Return(apperrors.ErrNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			staffServiceMock := service.NewMockStaffService(ctrl)
+			r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/staff/%s/two-factor", tt.id), nil)
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			tt.handler.staffService = staffServiceMock
+			tt.prepareMocks(staffServiceMock)
+
+			tt.handler.ResetTwoFactor()(w, r)
+
+			assert.Equal(t, tt.wantStatusCode, w.Result().StatusCode)
+		})
+	}
+}
@@ -3,11 +3,13 @@ package v1
 import (
 	"family-catering/config"
 	handler "family-catering/internal/handler/http"
+	"family-catering/internal/model"
 	"family-catering/internal/repository"
 	"family-catering/internal/service"
 	"family-catering/pkg/consts"
 	"family-catering/pkg/db/postgres"
 	"family-catering/pkg/db/redis"
+	log "family-catering/pkg/logger"
 	"family-catering/pkg/utils"
 	"family-catering/pkg/web"
 	"net/http"
@@ -44,9 +46,20 @@ func NewRouter(pg postgres.PostgresClient, redis redis.RedisClient) *chi.Mux {
 	cfg := config.Cfg()
 	// repositories
 	ownerRepository := repository.NewOwnerRepository(pg)
+	businessRepository := repository.NewBusinessRepository(pg)
 	menuRepository := repository.NewMenuRepository(pg)
 	authRepository := repository.NewAuthRepository(pg, redis)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(redis)
+	passwordResetRepository := repository.NewPasswordResetRepository(redis)
+	twoFactorRepository := repository.NewTwoFactorRepository(pg)
+	emailVerificationRepository := repository.NewEmailVerificationRepository(pg)
 	orderRepository := repository.NewOrderRepository(pg)
+	paymentRepository := repository.NewPaymentRepository(pg)
+	promotionRepository := repository.NewPromotionRepository(pg)
+	customerRepository := repository.NewCustomerRepository(pg)
+	deliveryZoneRepository := repository.NewDeliveryZoneRepository(pg)
+	driverRepository := repository.NewDriverRepository(pg)
+	reportRepository := repository.NewReportRepository(pg, redis, cfg.Report.CacheTTL)
 
 	// services
 	// mailer
@@ -58,20 +71,83 @@ func NewRouter(pg postgres.PostgresClient, redis redis.RedisClient) *chi.Mux {
 		SupportEmail:               cfg.Mailer.SupportEmail,
 		AppName:                    cfg.App.Name,
 		TemplateForgotPasswordName: cfg.Mailer.TemplateForgotPassword,
+		TemplateVerifyEmailName:    cfg.Mailer.TemplateVerifyEmail,
+		TemplateEmailChangedName:   cfg.Mailer.TemplateEmailChanged,
 		Identity:                   cfg.Mailer.Identity,
 	}
 	mailer := service.NewMailer(mailerOpts)
 
-	ownerService := service.NewOwnerService(ownerRepository)
+	ownerService := service.NewOwnerService(ownerRepository, authRepository, loginAttemptRepository, passwordResetRepository, emailVerificationRepository, mailer)
 	menuService := service.NewMenuService(menuRepository)
-	authService := service.NewAuthService(ownerRepository, authRepository, mailer)
-	orderService := service.NewOrderService(orderRepository, menuRepository)
+	authService := service.NewAuthService(ownerRepository, authRepository, loginAttemptRepository, twoFactorRepository, passwordResetRepository, mailer)
+	taxCalculator, err := service.NewTaxCalculator(service.TaxOption{
+		Inclusive:         cfg.Tax.Inclusive,
+		Rate:              cfg.Tax.Rate,
+		CategoryRates:     cfg.Tax.CategoryRates,
+		ServiceChargeRate: cfg.Tax.ServiceChargeRate,
+	})
+	if err != nil {
+		log.Fatal(err, "invalid tax config")
+	}
+	deliverySlots := make([]service.DeliverySlotOption, 0, len(cfg.Delivery.Slots))
+	for _, slot := range cfg.Delivery.Slots {
+		deliverySlots = append(deliverySlots, service.DeliverySlotOption{Start: slot.Start, End: slot.End, MaxOrders: slot.MaxOrders})
+	}
+	deliveryScheduler, err := service.NewDeliveryScheduler(service.DeliveryOption{
+		Slots:         deliverySlots,
+		LeadTime:      cfg.Delivery.LeadTime,
+		SameDayCutoff: cfg.Delivery.SameDayCutoff,
+		MaxDaysAhead:  cfg.Delivery.MaxDaysAhead,
+	})
+	if err != nil {
+		log.Fatal(err, "invalid delivery config")
+	}
+	routePlanner, err := service.NewRoutePlanner(service.RouteOption{
+		Kitchen: model.GeoPoint{Latitude: cfg.Delivery.Kitchen.Latitude, Longitude: cfg.Delivery.Kitchen.Longitude},
+	})
+	if err != nil {
+		log.Fatal(err, "invalid delivery config")
+	}
+	orderService := service.NewOrderService(orderRepository, menuRepository, paymentRepository, promotionRepository, customerRepository,
+		taxCalculator, deliveryScheduler, service.NewDeliveryZoneResolver(deliveryZoneRepository))
+	promotionService := service.NewPromotionService(promotionRepository)
+	kitchenService := service.NewKitchenService(orderRepository)
+	reportService := service.NewReportService(reportRepository)
+	customerService := service.NewCustomerService(customerRepository)
+	deliveryZoneService := service.NewDeliveryZoneService(deliveryZoneRepository)
+	driverService := service.NewDriverService(driverRepository, routePlanner)
+	staffService := service.NewStaffService(ownerRepository, twoFactorRepository, emailVerificationRepository, mailer)
+	businessService := service.NewBusinessService(businessRepository)
+
+	// payment providers, the fake one is a local provider without network used for development
+	paymentProviders := []service.PaymentProvider{}
+	if cfg.Payment.FakeProviderEnabled {
+		paymentProviders = append(paymentProviders, service.NewFakePaymentProvider(service.FakePaymentProviderOption{
+			Secret:      cfg.Payment.FakeProviderSecret,
+			CheckoutURL: cfg.Payment.FakeProviderCheckoutURL,
+		}))
+	}
+	paymentService := service.NewPaymentService(paymentRepository, orderService, paymentProviders...)
 
 	// handler
 	ownerHandler := handler.NewOwnerHandler(ownerService)
 	menuHandler := handler.NewMenuHandler(menuService)
 	authHandler := handler.NewAuthandler(authService)
 	orderHandler := handler.NewOrderHandler(orderService)
+	paymentHandler := handler.NewPaymentHandler(paymentService)
+	promotionHandler := handler.NewPromotionHandler(promotionService)
+	kitchenHandler := handler.NewKitchenHandler(kitchenService)
+	reportHandler := handler.NewReportHandler(reportService)
+	customerHandler := handler.NewCustomerHandler(customerService)
+	deliveryZoneHandler := handler.NewDeliveryZoneHandler(deliveryZoneService)
+	driverHandler := handler.NewDriverHandler(driverService)
+	staffHandler := handler.NewStaffHandler(staffService)
+	businessHandler := handler.NewBusinessHandler(businessService)
+
+	// roles' policies, a token without role (e.g. refresh token) is refused by all of them
+	anyRole := authHandler.RequireRole(consts.RoleAdmin, consts.RoleCashier, consts.RoleKitchen, consts.RoleDriver, consts.RoleViewer)
+	adminOnly := authHandler.RequireRole(consts.RoleAdmin)
+	cashierOrAdmin := authHandler.RequireRole(consts.RoleAdmin, consts.RoleCashier)
 
 	r := chi.NewRouter()
 
@@ -89,10 +165,12 @@ func NewRouter(pg postgres.PostgresClient, redis redis.RedisClient) *chi.Mux {
 	r.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("pong"))
 	})
+	r.Get("/.well-known/jwks.json", authHandler.JWKS())
 	v1 := r.Route("/api/v1", func(r chi.Router) {})
 
 	v1.Route("/auth", func(r chi.Router) {
 		r.Post("/login", authHandler.Login())
+		r.Post("/login/verify", authHandler.LoginVerify())
 		r.Put("/forgot-password", authHandler.ForgotPassword())
 
 		r.Group(func(r chi.Router) {
@@ -100,20 +178,26 @@ func NewRouter(pg postgres.PostgresClient, redis redis.RedisClient) *chi.Mux {
 			r.Use(authHandler.SessionRequired)
 			r.Delete("/logout", authHandler.Logout())
 			r.Get("/renew-access-token", authHandler.RenewAccessToken())
+			r.Get("/sessions", authHandler.ListSessions())
+			r.Delete("/sessions", authHandler.DeleteAllSessions())
+			r.Delete("/sessions/{sid}", authHandler.DeleteSession())
+			r.Post("/two-factor", authHandler.EnrollTwoFactor())
+			r.Post("/two-factor/confirm", authHandler.ConfirmTwoFactor())
 		})
 	})
 
 	v1.Route("/owner", func(r chi.Router) {
 		r.Post("/", ownerHandler.Create())
-		r.Get("/", ownerHandler.List())
+		r.With(authHandler.AuthorizationRequired, anyRole).Get("/", ownerHandler.List())
 
 		r.Route("/{id:\\d+}", func(r chi.Router) {
-			r.Get("/", ownerHandler.Get())
+			r.With(authHandler.AuthorizationRequired, anyRole).Get("/", ownerHandler.Get())
 
-			r.With(authHandler.AuthorizationRequired).Put("/", ownerHandler.Update())
+			r.With(authHandler.AuthorizationRequired, authHandler.RequireSelfOrRole(consts.RoleAdmin)).Put("/", ownerHandler.Update())
 
 			r.Group(func(r chi.Router) {
 				r.Use(authHandler.AuthorizationRequired)
+				r.Use(authHandler.RequireSelfOrRole())
 				r.Use(authHandler.SessionRequired)
 				r.Use(httprate.Limit(5, 30*time.Second, httprate.WithKeyFuncs(func(r *http.Request) (string, error) {
 					return utils.ValueContext(r.Context(), consts.CtxKeySID).(string), nil
@@ -126,26 +210,141 @@ func NewRouter(pg postgres.PostgresClient, redis redis.RedisClient) *chi.Mux {
 		})
 
 		r.With(authHandler.AuthorizationRequired).Put("/reset-password/{rpid}", ownerHandler.ResetPasswordByEmail())
+		r.Get("/verify-email/{token}", ownerHandler.VerifyEmail())
+		r.With(httprate.LimitByIP(5, time.Minute)).Post("/verify-email", ownerHandler.ResendEmailVerification())
+	})
+
+	v1.Route("/business", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.With(anyRole).Get("/", businessHandler.Get())
+		r.With(adminOnly).Put("/", businessHandler.Update())
+	})
+
+	v1.Route("/staff", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.Use(adminOnly)
+		r.Get("/", staffHandler.List())
+		r.Post("/", staffHandler.Create())
+
+		r.Route("/{id:[0-9]+}", func(r chi.Router) {
+			r.Put("/role", staffHandler.UpdateRole())
+			r.Delete("/", staffHandler.Delete())
+			r.Delete("/two-factor", staffHandler.ResetTwoFactor())
+		})
 	})
 
 	v1.Route("/menu", func(r chi.Router) {
 		r.Use(authHandler.AuthorizationRequired)
-		r.Get("/", menuHandler.List())
-		r.Post("/", menuHandler.Create())
+		r.With(anyRole).Get("/", menuHandler.List())
+		r.With(adminOnly).Post("/", menuHandler.Create())
+
+		r.Route("/{id:[0-9]+}", func(r chi.Router) {
+			r.With(anyRole).Get("/", menuHandler.GetByID())
+			r.With(adminOnly).Put("/", menuHandler.Update())
+			r.With(authHandler.RequireRole(consts.RoleAdmin, consts.RoleKitchen)).Put("/availability", menuHandler.UpdateAvailability())
+			r.With(adminOnly).Delete("/", menuHandler.Delete())
+		})
+		r.With(anyRole).Get("/name/{name}", menuHandler.GetByName())
+	})
+
+	v1.Route("/promotion", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.With(anyRole).Get("/", promotionHandler.List())
+		r.With(adminOnly).Post("/", promotionHandler.Create())
 
 		r.Route("/{id:[0-9]+}", func(r chi.Router) {
-			r.Get("/", menuHandler.GetByID())
-			r.Put("/", menuHandler.Update())
-			r.Delete("/", menuHandler.Delete())
+			r.With(anyRole).Get("/", promotionHandler.GetByID())
+			r.With(adminOnly).Put("/", promotionHandler.Update())
+			r.With(adminOnly).Delete("/", promotionHandler.Delete())
 		})
-		r.Get("/name/{name}", menuHandler.GetByName())
+	})
+
+	v1.Route("/customer", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.With(anyRole).Get("/", customerHandler.List())
+		r.With(cashierOrAdmin).Post("/", customerHandler.Create())
+
+		r.Route("/{id:[0-9]+}", func(r chi.Router) {
+			r.With(anyRole).Get("/", customerHandler.GetByID())
+			r.With(cashierOrAdmin).Put("/", customerHandler.Update())
+			r.With(cashierOrAdmin).Delete("/", customerHandler.Delete())
+
+			r.With(cashierOrAdmin).Post("/addresses", customerHandler.CreateAddress())
+			r.With(cashierOrAdmin).Put("/addresses/{address_id:[0-9]+}", customerHandler.UpdateAddress())
+			r.With(cashierOrAdmin).Delete("/addresses/{address_id:[0-9]+}", customerHandler.DeleteAddress())
+		})
+	})
+
+	v1.Route("/delivery", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.With(anyRole).Get("/quote", deliveryZoneHandler.Quote())
+
+		r.Route("/zones", func(r chi.Router) {
+			r.With(anyRole).Get("/", deliveryZoneHandler.List())
+			r.With(adminOnly).Post("/", deliveryZoneHandler.Create())
+
+			r.Route("/{id:[0-9]+}", func(r chi.Router) {
+				r.With(anyRole).Get("/", deliveryZoneHandler.GetByID())
+				r.With(adminOnly).Put("/", deliveryZoneHandler.Update())
+				r.With(adminOnly).Delete("/", deliveryZoneHandler.Delete())
+			})
+		})
+
+		r.Route("/drivers", func(r chi.Router) {
+			r.With(anyRole).Get("/", driverHandler.List())
+			r.With(adminOnly).Post("/", driverHandler.Create())
+
+			r.Route("/{id:[0-9]+}", func(r chi.Router) {
+				r.With(anyRole).Get("/", driverHandler.GetByID())
+				r.With(adminOnly).Put("/", driverHandler.Update())
+				r.With(adminOnly).Delete("/", driverHandler.Delete())
+
+				r.With(anyRole).Get("/route", driverHandler.Route())
+				r.With(adminOnly).Post("/assignments", driverHandler.Assign())
+				r.With(adminOnly).Delete("/assignments/{order_id:[0-9]+}", driverHandler.Unassign())
+				r.With(authHandler.RequireRole(consts.RoleAdmin, consts.RoleDriver), authHandler.SessionRequired).
+					Put("/stops/{order_id:[0-9]+}/delivered", driverHandler.MarkDelivered())
+			})
+		})
+	})
+
+	v1.Route("/kitchen", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.Use(anyRole)
+		r.Get("/production", kitchenHandler.Production())
+	})
+
+	v1.Route("/reports", func(r chi.Router) {
+		r.Use(authHandler.AuthorizationRequired)
+		r.Use(authHandler.RequireRole(consts.RoleAdmin, consts.RoleViewer))
+		r.Get("/sales", reportHandler.Sales())
+		r.Get("/customers", reportHandler.Customers())
 	})
 
 	v1.Route("/order", func(r chi.Router) {
 		r.Use(authHandler.AuthorizationRequired)
-		r.Post("/", orderHandler.Create())
-		r.Get("/search", orderHandler.Search())
-		r.Put("/confirm-payment", orderHandler.ConfirmPayment())
+		r.With(cashierOrAdmin).Post("/", orderHandler.Create())
+		r.With(anyRole).Get("/search", orderHandler.Search())
+		r.With(cashierOrAdmin, authHandler.SessionRequired).Put("/confirm-payment", orderHandler.ConfirmPayment())
+
+		r.Route("/{order_id:[0-9]+}", func(r chi.Router) {
+			r.With(anyRole).Get("/", orderHandler.Get())
+			r.With(cashierOrAdmin).Patch("/", orderHandler.Update())
+			r.With(cashierOrAdmin, authHandler.SessionRequired).Delete("/", orderHandler.Cancel())
+			r.With(authHandler.RequireRole(consts.RoleAdmin, consts.RoleCashier, consts.RoleKitchen, consts.RoleDriver), authHandler.SessionRequired).
+				Put("/status", orderHandler.UpdateStatus())
+			r.With(anyRole).Get("/history", orderHandler.StatusHistory())
+
+			r.With(cashierOrAdmin).Post("/items", orderHandler.AddItem())
+			r.With(cashierOrAdmin).Put("/items/{base_order_id:[0-9]+}", orderHandler.UpdateItem())
+			r.With(cashierOrAdmin).Delete("/items/{base_order_id:[0-9]+}", orderHandler.DeleteItem())
+		})
+	})
+
+	v1.Route("/payments", func(r chi.Router) {
+		// called by the payment provider, it's authenticated by the payload's signature
+		r.Post("/webhook/{provider}", paymentHandler.Webhook())
+		r.With(authHandler.AuthorizationRequired, cashierOrAdmin).Post("/{provider}/charges", paymentHandler.CreateCharge())
 	})
 
 	r.Get("/swagger/*", httpSwagger.Handler(
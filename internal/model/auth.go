@@ -13,18 +13,27 @@ type Auth struct {
 	AccessToken  string // access token do not saved at db
 	RefreshToken string `db:"refresh_token"`
 	ExpiredAt    string `db:"expired_at"` // refresh token expired at
+	UserAgent    string `db:"user_agent"`
+	IP           string `db:"ip"`
+	LastSeenAt   string `db:"last_seen_at"`
 	CreatedAt    string `db:"created_at"`
 	UpdatedAt    string `db:"updated_at"`
 }
 
 type AuthRenewAccessTokenResponse struct {
 	AccessToken string `json:"access_token"`
-	ExpiredAt   string `json:"expired_at"`
+	// RefreshToken replaces the used refresh token, which is invalid from now
+	RefreshToken string `json:"refresh_token"`
+	SID          string `json:"-"`
+	ExpiredAt    string `json:"expired_at"`
 }
 
 type AuthLoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,alphanum,min=8,omitempty"`
+	// the device logging in, set from the request's headers
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
 }
 
 type AuthLogoutRequest struct {
@@ -39,12 +48,71 @@ type AuthSessionResponse struct {
 	Jti     string `json:"jti"`
 }
 
+// AuthLoginResponse is the session of the logged in owner, or only a pre-auth token when the owner has two-factor
+// authentication (see AuthLoginVerifyRequest)
 type AuthLoginResponse struct {
-	SID          string `json:"-"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	SID               string `json:"-"`
+	AccessToken       string `json:"access_token,omitempty"`
+	RefreshToken      string `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	PreAuthToken      string `json:"pre_auth_token,omitempty"`
 }
 
+// AuthLoginVerifyRequest is the second step of the login of an owner with two-factor authentication, Code is a code of
+// its authenticator app or one of its recovery codes
+type AuthLoginVerifyRequest struct {
+	PreAuthToken string `json:"pre_auth_token" validate:"required"`
+	Code         string `json:"code" validate:"required,max=32"`
+	// the device logging in, set from the request's headers
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+}
+
+// OwnerTOTP is the TOTP (RFC 6238) second factor of an owner, it's enabled once a code of the secret is confirmed
+type OwnerTOTP struct {
+	OwnerID  int64  `db:"owner_id"`
+	Secret   string `db:"secret"`
+	Enabled  bool   `db:"enabled"`
+	LastStep int64  `db:"last_step"` // the time step of the last used code
+}
+
+type OwnerRecoveryCode struct {
+	ID       int64  `db:"id"`
+	OwnerID  int64  `db:"owner_id"`
+	CodeHash string `db:"code_hash"`
+}
+
+type AuthTwoFactorConfirmRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// AuthTwoFactorEnrollResponse is the secret to add to an authenticator app, by its otpauth URI (QR code) or by hand
+type AuthTwoFactorEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+} //	@name	two-factor-enroll_response
+
+// AuthTwoFactorConfirmResponse is the recovery codes of the enabled two-factor authentication, they are only shown once
+type AuthTwoFactorConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+} //	@name	two-factor-confirm_response
+
 type AuthForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
+
+type AuthSessionsResponse struct {
+	Sessions interface{} `json:"sessions"`
+} //	@name	sessions
+
+// AuthOwnerSession is a device the owner is logged in on, its ID is a digest of the session id so the session cookie is never
+// exposed
+type AuthOwnerSession struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	Current    bool   `json:"current"` // the session of the request
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiredAt  string `json:"expired_at"`
+	CreatedAt  string `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+// Business is a catering kitchen (tenant) hosted on the deployment, its owners only see and edit its own data
+type Business struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	CreatedAt string `db:"created_at"`
+	UpdatedAt string `db:"updated_at"`
+}
+
+type UpdateBusinessRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+} //	@name	update_business_request
+
+type GetBusinessResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+} //	@name	get-update_business_response
+
+type UpdateBusinessResponse = GetBusinessResponse
+
+type BusinessResponse struct {
+	Business interface{} `json:"business"`
+} //	@name	business_response
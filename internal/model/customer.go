@@ -0,0 +1,81 @@
+package model
+
+import "database/sql"
+
+type Customer struct {
+	ID               int64              `db:"id"`
+	Name             string             `db:"name"`
+	Email            string             `db:"email"` // lower case, an order is linked to the customer of its email
+	Phone            string             `db:"phone"`
+	DietaryNotes     string             `db:"dietary_notes"` // e.g. allergies
+	MarketingConsent bool               `db:"marketing_consent"`
+	CreatedAt        string             `db:"created_at"`
+	UpdatedAt        string             `db:"updated_at"`
+	Addresses        []*CustomerAddress // only filled by CustomerRepository.GetByID
+}
+
+// CustomerAddress is a saved delivery address of a customer, a customer has at most one default address
+type CustomerAddress struct {
+	ID         int64           `db:"id"`
+	CustomerID int64           `db:"customer_id"`
+	Label      string          `db:"label"` // e.g. home, office
+	Address    string          `db:"address"`
+	PostalCode string          `db:"postal_code"`
+	Latitude   sql.NullFloat64 `db:"latitude"` // NULL when the address isn't pinned on the map
+	Longitude  sql.NullFloat64 `db:"longitude"`
+	Notes      string          `db:"notes"` // e.g. landmark, gate code
+	IsDefault  bool            `db:"is_default"`
+	CreatedAt  string          `db:"created_at"`
+	UpdatedAt  string          `db:"updated_at"`
+}
+
+type CreateCustomerRequest struct {
+	Name             string `json:"name" validate:"max=255"`
+	Email            string `json:"email" validate:"required,email,max=255"`
+	Phone            string `json:"phone" validate:"max=32"`
+	DietaryNotes     string `json:"dietary_notes" validate:"max=500"`
+	MarketingConsent bool   `json:"marketing_consent"`
+} //	@name	create-update_customer_request
+
+// UpdateCustomerRequest replace every field of the customer, the orders keep the email they were placed with
+type UpdateCustomerRequest = CreateCustomerRequest
+
+type CustomerAddressRequest struct {
+	Label      string   `json:"label" validate:"max=64"`
+	Address    string   `json:"address" validate:"required,max=500"`
+	PostalCode string   `json:"postal_code" validate:"max=16"`
+	Latitude   *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+	Notes      string   `json:"notes" validate:"max=255"`
+	IsDefault  bool     `json:"is_default"` // the previous default address is unset
+} //	@name	create-update_customer-address_request
+
+type CustomerAddressResponse struct {
+	ID         int64    `json:"id"`
+	Label      string   `json:"label"`
+	Address    string   `json:"address"`
+	PostalCode string   `json:"postal_code"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	Notes      string   `json:"notes"`
+	IsDefault  bool     `json:"is_default"`
+} //	@name	customer-address_response
+
+type GetCustomerResponse struct {
+	ID               int64                      `json:"id"`
+	Name             string                     `json:"name"`
+	Email            string                     `json:"email"`
+	Phone            string                     `json:"phone"`
+	DietaryNotes     string                     `json:"dietary_notes"`
+	MarketingConsent bool                       `json:"marketing_consent"`
+	Addresses        []*CustomerAddressResponse `json:"addresses,omitempty"` // omitted by the list and when there is none
+	CreatedAt        string                     `json:"created_at"`
+	UpdatedAt        string                     `json:"updated_at"`
+} //	@name	create-get-update_customer_response
+
+type CreateCustomerResponse = GetCustomerResponse
+type UpdateCustomerResponse = GetCustomerResponse
+
+type CustomerResponse struct {
+	Customer interface{} `json:"customer"`
+} //	@name	customer_response
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"database/sql"
+	"family-catering/pkg/money"
+)
+
+// DeliveryZone is an area delivered to, an address is matched by its postal code first then by its coordinates
+type DeliveryZone struct {
+	ID          int64          `db:"id"`
+	Name        string         `db:"name"`
+	PostalCodes string         `db:"postal_codes"` // comma separated, upper case without spaces
+	Polygon     sql.NullString `db:"polygon"`      // JSON [[latitude, longitude], ...], NULL when matched by postal codes only
+	Fee         money.Money    `db:"fee"`
+	MinOrder    money.Money    `db:"min_order"` // compared with the order's total after discount, zero is no minimum
+	Active      bool           `db:"active"`
+	CreatedAt   string         `db:"created_at"`
+	UpdatedAt   string         `db:"updated_at"`
+}
+
+// DeliveryAddress is the destination of an order, the zero value is no destination
+type DeliveryAddress struct {
+	Address    string
+	PostalCode string
+	Latitude   sql.NullFloat64 // valid along with Longitude
+	Longitude  sql.NullFloat64
+}
+
+type CreateDeliveryZoneRequest struct {
+	Name        string       `json:"name" validate:"required,max=64"`
+	PostalCodes []string     `json:"postal_codes" validate:"dive,required,max=16"`
+	Polygon     [][2]float64 `json:"polygon" validate:"omitempty,min=3"` // [[latitude, longitude], ...], at least a triangle
+	Fee         money.Money  `json:"fee" validate:"gte=0"`
+	MinOrder    money.Money  `json:"min_order" validate:"gte=0"`
+	Active      bool         `json:"active"`
+} //	@name	create-update_delivery-zone_request
+
+// UpdateDeliveryZoneRequest replace every field of the zone, orders already placed keep their delivery fee
+type UpdateDeliveryZoneRequest = CreateDeliveryZoneRequest
+
+type GetDeliveryZoneResponse struct {
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	PostalCodes []string     `json:"postal_codes"`
+	Polygon     [][2]float64 `json:"polygon,omitempty"`
+	Fee         money.Money  `json:"fee"`
+	MinOrder    money.Money  `json:"min_order"`
+	Active      bool         `json:"active"`
+	CreatedAt   string       `json:"created_at"`
+	UpdatedAt   string       `json:"updated_at"`
+} //	@name	create-get-update_delivery-zone_response
+
+type CreateDeliveryZoneResponse = GetDeliveryZoneResponse
+type UpdateDeliveryZoneResponse = GetDeliveryZoneResponse
+
+type DeliveryZoneResponse struct {
+	DeliveryZone interface{} `json:"delivery_zone"`
+} //	@name	delivery-zone_response
+
+// DeliveryAddressRequest is the destination of an order, a customer's saved address is used when it's omitted
+type DeliveryAddressRequest struct {
+	Address    string   `json:"address" validate:"required,max=500"`
+	PostalCode string   `json:"postal_code" validate:"max=16"`
+	Latitude   *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+} //	@name	delivery-address_request
+
+type DeliveryAddressResponse struct {
+	Address    string   `json:"address"`
+	PostalCode string   `json:"postal_code,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+} //	@name	delivery-address_response
+
+// DeliveryQuoteRequest is an address to quote, by its postal code or its coordinates
+type DeliveryQuoteRequest struct {
+	PostalCode string   `validate:"max=16"`
+	Latitude   *float64 `validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `validate:"required_with=Latitude,omitempty,longitude"`
+}
+
+// GetDeliveryQuoteResponse is the delivery fee of an address, the zone is omitted when every address is delivered to for free
+type GetDeliveryQuoteResponse struct {
+	ZoneID   int64       `json:"zone_id,omitempty"`
+	ZoneName string      `json:"zone_name,omitempty"`
+	Fee      money.Money `json:"fee"`
+	MinOrder money.Money `json:"min_order"`
+} //	@name	get-delivery-quote_response
+
+type DeliveryQuoteResponse struct {
+	Quote interface{} `json:"quote"`
+} //	@name	delivery-quote_response
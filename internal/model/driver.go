@@ -0,0 +1,93 @@
+package model
+
+import "database/sql"
+
+type Driver struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	Phone     string `db:"phone"`
+	Active    bool   `db:"active"` // inactive drivers can't be assigned new orders
+	CreatedAt string `db:"created_at"`
+	UpdatedAt string `db:"updated_at"`
+}
+
+// DeliveryStop is an order assigned to a driver on its delivery date along with its destination
+type DeliveryStop struct {
+	OrderID       int64           `db:"order_id"`
+	DriverID      int64           `db:"driver_id"`
+	DeliveryDate  string          `db:"delivery_date"`
+	CustomerEmail string          `db:"customer_email"`
+	Address       string          `db:"delivery_address"`
+	PostalCode    string          `db:"delivery_postal_code"`
+	Latitude      sql.NullFloat64 `db:"delivery_latitude"` // NULL when the destination isn't pinned on the map
+	Longitude     sql.NullFloat64 `db:"delivery_longitude"`
+	DeliveryStart string          `db:"delivery_start"` // HH:MM, empty when there is no slot
+	DeliveryEnd   string          `db:"delivery_end"`
+	Status        int             `db:"status"`
+	DeliveredAt   sql.NullString  `db:"delivered_at"` // NULL while the order is on its way
+}
+
+// GeoPoint is a location on the map in degrees
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type CreateDriverRequest struct {
+	Name   string `json:"name" validate:"required,max=64"`
+	Phone  string `json:"phone" validate:"max=32"`
+	Active bool   `json:"active"`
+} //	@name	create-update_driver_request
+
+// UpdateDriverRequest replace every field of the driver
+type UpdateDriverRequest = CreateDriverRequest
+
+type GetDriverResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Phone     string `json:"phone"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+} //	@name	create-get-update_driver_response
+
+type CreateDriverResponse = GetDriverResponse
+type UpdateDriverResponse = GetDriverResponse
+
+type DriverResponse struct {
+	Driver interface{} `json:"driver"`
+} //	@name	driver_response
+
+// AssignDeliveryRequest assign the orders delivered on the date to a driver, an order already assigned to another
+// driver is moved unless it has been delivered
+type AssignDeliveryRequest struct {
+	Date     string  `json:"date" validate:"required,datetime=2006-01-02"`
+	OrderIDs []int64 `json:"order_ids" validate:"required,min=1,dive,gt=0"`
+} //	@name	assign-delivery_request
+
+// GetDeliveryRouteResponse is the stops of a driver's day in the order they should be delivered
+type GetDeliveryRouteResponse struct {
+	DriverID int64                      `json:"driver_id"`
+	Date     string                     `json:"date"`
+	Distance float64                    `json:"distance_km"` // straight line from the kitchen through every pinned stop
+	Stops    []*GetDeliveryStopResponse `json:"stops"`
+} //	@name	get-delivery-route_response
+
+type GetDeliveryStopResponse struct {
+	Sequence      int                      `json:"sequence,omitempty"`
+	OrderID       int64                    `json:"order_id"`
+	CustomerEmail string                   `json:"customer_email"`
+	Slot          string                   `json:"slot,omitempty"`
+	Address       *DeliveryAddressResponse `json:"address,omitempty"`
+	Distance      float64                  `json:"distance_km,omitempty"` // from the previous stop (or the kitchen), omitted when the stop isn't pinned
+	Status        string                   `json:"status"`
+	DeliveredAt   string                   `json:"delivered_at,omitempty"`
+} //	@name	get-delivery-stop_response
+
+type DeliveryRouteResponse struct {
+	Route interface{} `json:"route"`
+} //	@name	delivery-route_response
+
+type DeliveryStopResponse struct {
+	Stop interface{} `json:"stop"`
+} //	@name	delivery-stop_response
@@ -0,0 +1,36 @@
+package model
+
+// ProductionSheetResponse is every portion the kitchen has to prepare for the orders delivered on a date
+type ProductionSheetResponse struct {
+	Date        string                        `json:"date"`
+	TotalOrders int                           `json:"total_orders"`
+	TotalQty    int                           `json:"total_qty"`
+	Categories  []*ProductionCategoryResponse `json:"categories"`
+} //	@name	production-sheet_response
+
+// ProductionCategoryResponse group the menus by their first category
+type ProductionCategoryResponse struct {
+	Category string                    `json:"category"`
+	TotalQty int                       `json:"total_qty"`
+	Menus    []*ProductionMenuResponse `json:"menus"`
+} //	@name	production-category_response
+
+type ProductionMenuResponse struct {
+	MenuID   int64                      `json:"menu_id"`
+	MenuName string                     `json:"menu_name"`
+	TotalQty int                        `json:"total_qty"`
+	Orders   []*ProductionOrderResponse `json:"orders"`
+} //	@name	production-menu_response
+
+type ProductionOrderResponse struct {
+	OrderID       int64  `json:"order_id"`
+	CustomerEmail string `json:"customer_email"`
+	Qty           int    `json:"qty"`
+	DeliverySlot  string `json:"delivery_slot,omitempty"`
+	Status        string `json:"status"`
+	Notes         string `json:"notes,omitempty"`
+} //	@name	production-order_response
+
+type KitchenResponse struct {
+	Production interface{} `json:"production"`
+}
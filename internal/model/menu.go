@@ -1,35 +1,58 @@
 package model
 
+import "family-catering/pkg/money"
+
 type Menu struct {
-	ID         int64   `db:"id"`
-	Name       string  `db:"name"`
-	Price      float32 `db:"price"`
-	Categories string  `db:"categories"`
+	ID         int64       `db:"id"`
+	Name       string      `db:"name"`
+	Price      money.Money `db:"price"`
+	Categories string      `db:"categories"`
+	// Stock is the portions left (nil is not tracked), it's taken when the menu is ordered and given back when the order is cancelled
+	Stock *int `db:"stock"`
+	// DailyCapacity is the portions the kitchen could make a day (nil is unlimited)
+	DailyCapacity *int `db:"daily_capacity"`
+	OrderedToday  int  `db:"ordered_today"` // portions delivered today by orders which are neither cancelled nor refunded
 }
 
 type MenuQuery struct {
 	IDs             []int64
 	Names           []string
 	ExactNamesMatch bool
-	MaxPrice        float32 // optional for search query
-	MinPrice        float32 // idem
+	MaxPrice        money.Money // optional for search query
+	MinPrice        money.Money // idem
 	// Price           float32 `db:"price"`
 	Categories string `db:"categories"`
 }
 
 type CreateMenuRequest struct {
-	Name       string  `json:"name" validate:"required,max=250"`
-	Price      float32 `json:"price" validate:"required,gte=0.05"`
-	Categories string  `json:"categories"`
+	Name       string      `json:"name" validate:"required,max=250"`
+	Price      money.Money `json:"price" validate:"required,gte=5"` // validated in minor units, at least 0.05
+	Categories string      `json:"categories"`
 } //	@name	create-update_menu_request
 
 type CreateMenuResponse struct {
-	ID         int64   `json:"id"`
-	Name       string  `json:"name"`
-	Price      float32 `json:"price"`
-	Categories string  `json:"categories"`
+	ID         int64       `json:"id"`
+	Name       string      `json:"name"`
+	Price      money.Money `json:"price"`
+	Categories string      `json:"categories"`
+	// Availability is only shown by get and list menu
+	Availability *MenuAvailabilityResponse `json:"availability,omitempty"`
 } //	@name	create-get-update_menu_response
 
+type MenuAvailabilityResponse struct {
+	Stock         *int `json:"stock"`          // null is not tracked
+	DailyCapacity *int `json:"daily_capacity"` // null is unlimited
+	OrderedToday  int  `json:"ordered_today"`
+	Remaining     *int `json:"remaining"` // portions could still be ordered today, null is unlimited
+	SoldOut       bool `json:"sold_out"`
+} //	@name	menu-availability_response
+
+// UpdateMenuAvailabilityRequest replace the stock and the daily capacity of a menu, null removes the limit
+type UpdateMenuAvailabilityRequest struct {
+	Stock         *int `json:"stock" validate:"omitempty,gte=0"`
+	DailyCapacity *int `json:"daily_capacity" validate:"omitempty,gte=0"`
+} //	@name	update-menu-availability_request
+
 type GetMenuResponse = CreateMenuResponse
 
 type UpdateMenuRequest = CreateMenuRequest
@@ -1,16 +1,64 @@
 package model
 
+import (
+	"database/sql"
+	"family-catering/pkg/money"
+)
+
 type Order struct {
-	BaseOrderID   int64   `db:"base_order_id"` // unique per menu_id
-	OrderID       int64   `db:"order_id"`      // to allow one user order multiple menu
-	CustomerEmail string  `db:"customer_email"`
-	Qty           int     `db:"qty"`
-	MenuID        int64   `db:"menu_id"`
-	MenuName      string  `db:"menu_name"` // menu's id
-	Price         float32 `db:"price"`
-	Status        int     `db:"status"` // 1 NEW, 2 PAID, 3 Cancelled
-	CreatedAt     string  `db:"created_at"`
-	UpdatedAt     string  `db:"updated_at"`
+	BaseOrderID    int64       `db:"base_order_id"` // unique per menu_id
+	OrderID        int64       `db:"order_id"`      // to allow one user order multiple menu
+	CustomerID     int64       `db:"customer_id"`
+	CustomerEmail  string      `db:"customer_email"` // the email the order was placed with
+	Qty            int         `db:"qty"`
+	MenuID         int64       `db:"menu_id"`
+	MenuName       string      `db:"menu_name"` // menu's id
+	Price          money.Money `db:"price"`
+	Status         int         `db:"status"` // 1 NEW, 2 PAID, 3 Cancelled
+	CreatedAt      string      `db:"created_at"`
+	UpdatedAt      string      `db:"updated_at"`
+	PromoCode      string      `db:"promo_code"`     // order-level (see order_discount), empty when no promotion is applied
+	Discount       money.Money `db:"discount"`       // order-level discount of the whole order
+	ServiceCharge  money.Money `db:"service_charge"` // order-level (see order_charge)
+	Tax            money.Money `db:"tax"`            // order-level, already included in the prices when TaxInclusive
+	TaxInclusive   bool        `db:"tax_inclusive"`
+	DeliveryDate   string      `db:"delivery_date"`  // YYYY-MM-DD
+	DeliveryStart  string      `db:"delivery_start"` // HH:MM, empty when the order could be delivered at any time of the day
+	DeliveryEnd    string      `db:"delivery_end"`
+	Notes          string      `db:"notes"`           // order-level
+	MenuCategories string      `db:"menu_categories"` // only filled by OrderRepository.ListByDeliveryDate
+	// the destination is order-level, empty when the order was placed without an address
+	DeliveryAddress    string          `db:"delivery_address"`
+	DeliveryPostalCode string          `db:"delivery_postal_code"`
+	DeliveryLatitude   sql.NullFloat64 `db:"delivery_latitude"`
+	DeliveryLongitude  sql.NullFloat64 `db:"delivery_longitude"`
+	DeliveryZoneID     sql.NullInt64   `db:"delivery_zone_id"` // NULL when no zone is defined or the zone is deleted
+	DeliveryFee        money.Money     `db:"delivery_fee"`     // order-level (see order_charge)
+}
+
+// OrderDelivery is the delivery date, slot and destination of an order, every item of the order is delivered at once
+type OrderDelivery struct {
+	Date        string // YYYY-MM-DD
+	Start       string // HH:MM, empty when there is no delivery slot
+	End         string
+	MaxOrders   int // max orders delivered on the slot of the date, 0 is unlimited
+	Destination DeliveryAddress
+	ZoneID      int64 // 0 when no zone is defined
+}
+
+// OrderCharge is the invoice's breakdown of an order, it's repriced whenever the order's items or discount change
+type OrderCharge struct {
+	OrderID           int64       `db:"order_id"`
+	SubTotal          money.Money `db:"sub_total"` // items before discount
+	Discount          money.Money `db:"discount"`
+	ServiceCharge     money.Money `db:"service_charge"`
+	ServiceChargeRate int         `db:"service_charge_rate"` // basis points
+	Tax               money.Money `db:"tax"`
+	TaxInclusive      bool        `db:"tax_inclusive"`
+	DeliveryFee       money.Money `db:"delivery_fee"` // neither discounted nor taxed
+	GrandTotal        money.Money `db:"grand_total"`
+	CreatedAt         string      `db:"created_at"`
+	UpdatedAt         string      `db:"updated_at"`
 }
 
 type OrderQuery struct {
@@ -19,12 +67,14 @@ type OrderQuery struct {
 	ExactMenuNamesMatch bool
 	CustomerEmails      []string
 	Qty                 int
-	Price               float32
+	Price               money.Money
 	Status              int
-	MinPrice            float32
-	MaxPrice            float32
+	MinPrice            money.Money
+	MaxPrice            money.Money
 	StartDay            string
 	EndDay              string
+	DeliveryStartDay    string // YYYY-MM-DD, inclusive
+	DeliveryEndDay      string // YYYY-MM-DD, inclusive
 }
 
 type BaseOrder struct {
@@ -41,43 +91,104 @@ type BaseOrderRequest struct {
 }
 
 type SearchResponse struct {
-	OrderID       int64   `json:"order_id,omitempty"`
-	CustomerEmail string  `json:"customer_email,omitempty"`
-	Qty           int     `json:"qty"`
-	MenuName      string  `json:"menu_name"`
-	MenuId        int64   `json:"menu_id,omitempty"`
-	Price         float32 `json:"price"`
-	Status        int     `json:"status"`
-	CreatedAt     string  `json:"created_at"`
+	OrderID       int64       `json:"order_id,omitempty"`
+	CustomerEmail string      `json:"customer_email,omitempty"`
+	Qty           int         `json:"qty"`
+	MenuName      string      `json:"menu_name"`
+	MenuId        int64       `json:"menu_id,omitempty"`
+	Price         money.Money `json:"price"`
+	Status        int         `json:"status"`
+	DeliveryDate  string      `json:"delivery_date"`
+	DeliverySlot  string      `json:"delivery_slot,omitempty"`
+	CreatedAt     string      `json:"created_at"`
 }
 
 type SearchOrdersResponse struct {
-	Orders     []*SearchResponse `json:"orders"`
-	TotalPrice float32           `json:"total_price"`
+	Orders     []*SearchResponse      `json:"orders"`
+	TotalPrice money.Money            `json:"total_price"` // sum of the items before discount, service charge and tax
+	Charges    []*OrderChargeResponse `json:"charges"`     // one per order of the items
+	GrandTotal money.Money            `json:"grand_total"` // sum of the orders' grand total
 }
 
-type CreateOrderRequest struct {
-	CustomerEmail string             `json:"customer_email" validate:"required,email"`
-	Orders        []BaseOrderRequest `json:"orders"`
-}
+type OrderChargeResponse struct {
+	OrderID       int64       `json:"order_id,omitempty"`
+	SubTotal      money.Money `json:"sub_total"`
+	Discount      money.Money `json:"discount"`
+	ServiceCharge money.Money `json:"service_charge"`
+	Tax           money.Money `json:"tax"`
+	TaxInclusive  bool        `json:"tax_inclusive"`
+	DeliveryFee   money.Money `json:"delivery_fee"`
+	GrandTotal    money.Money `json:"grand_total"`
+} //	@name	order-charge_response
 
-type ConfirmPaymentRequest struct {
-	Email string `json:"email" validate:"required,email"`
+// CreateOrderRequest is ordered by an existing customer (CustomerID) or by the customer of the inline details,
+// the customer of the details is created when its email is not registered yet
+type CreateOrderRequest struct {
+	CustomerID    int64                  `json:"customer_id" validate:"gte=0"`
+	Customer      *CreateCustomerRequest `json:"customer"`
+	CustomerEmail string                 `json:"customer_email" validate:"omitempty,email"` // shortcut of customer's details with only the email
+	Orders        []BaseOrderRequest     `json:"orders"`
+	PromoCode     string                 `json:"promo_code" validate:"omitempty,alphanum,max=32"`
+	DeliveryDate  string                 `json:"delivery_date" validate:"required,datetime=2006-01-02"`
+	// DeliverySlot is one of the configured delivery slots (e.g. 10:00-12:00), required when there are delivery slots
+	DeliverySlot string `json:"delivery_slot" validate:"omitempty,max=11"`
+	// DeliveryAddress is required when there are delivery zones unless the customer has a default address
+	DeliveryAddress   *DeliveryAddressRequest `json:"delivery_address"`
+	CustomerAddressID int64                   `json:"customer_address_id" validate:"gte=0"` // a saved address of the customer instead of DeliveryAddress
+	Notes             string                  `json:"notes" validate:"max=500"`             // e.g. allergies, shown on the kitchen's production sheet
 }
 
 type CreateOrderResponse struct {
 	OrderID       int64  `json:"order_id"`
+	CustomerID    int64  `json:"customer_id"`
 	CustomerEmail string `json:"customer_email"`
 	// Orders        []BaseOrder `json:"orders"`
-	Message    string  `json:"message"`
-	TotalPrice float32 `json:"total_price"`
+	Message    string                 `json:"message"`
+	Discount   *OrderDiscountResponse `json:"discount,omitempty"`
+	Charge     *OrderChargeResponse   `json:"charge,omitempty"`
+	Delivery   *OrderDeliveryResponse `json:"delivery,omitempty"`
+	TotalPrice money.Money            `json:"total_price"` // grand total
 }
 
-// type UpdateOrderRequest struct {
-// 	ID            int64
-// 	CustomerEmail string
-// 	Order
-// }
+type OrderDeliveryResponse struct {
+	Date    string                   `json:"date"`
+	Slot    string                   `json:"slot,omitempty"` // HH:MM-HH:MM
+	Address *DeliveryAddressResponse `json:"address,omitempty"`
+	ZoneID  int64                    `json:"zone_id,omitempty"`
+} //	@name	order-delivery_response
+
+// UpdateOrderRequest move the order to the customer of the email, the customer is created when the email is not registered yet
+type UpdateOrderRequest struct {
+	CustomerEmail string `json:"customer_email" validate:"required,email"`
+} //	@name	update-order_request
+
+type UpdateOrderItemRequest struct {
+	Qty int `json:"qty" validate:"required,numeric,min=1"`
+} //	@name	update-order-item_request
+
+type OrderItemResponse struct {
+	BaseOrderID int64       `json:"base_order_id"`
+	MenuID      int64       `json:"menu_id"`
+	MenuName    string      `json:"menu_name"`
+	Price       money.Money `json:"price"`
+	Qty         int         `json:"qty"`
+	SubTotal    money.Money `json:"sub_total"`
+} //	@name	order-item_response
+
+type OrderDetailResponse struct {
+	OrderID       int64                  `json:"order_id"`
+	CustomerID    int64                  `json:"customer_id"`
+	CustomerEmail string                 `json:"customer_email"`
+	Status        string                 `json:"status"`
+	Items         []*OrderItemResponse   `json:"items"`
+	Discount      *OrderDiscountResponse `json:"discount,omitempty"`
+	Charge        *OrderChargeResponse   `json:"charge,omitempty"`
+	Delivery      *OrderDeliveryResponse `json:"delivery,omitempty"`
+	Notes         string                 `json:"notes,omitempty"`
+	TotalPrice    money.Money            `json:"total_price"` // grand total
+	CreatedAt     string                 `json:"created_at"`
+	UpdatedAt     string                 `json:"updated_at"`
+} //	@name	order-detail_response
 
 type CancelUnpaidOrderResponse struct {
 	Message             string `json:"message"`
@@ -87,3 +198,29 @@ type CancelUnpaidOrderResponse struct {
 type OrderResponse struct {
 	Order interface{} `json:"order"`
 }
+
+type OrderStatusHistory struct {
+	ID         int64         `db:"id"`
+	OrderID    int64         `db:"order_id"`
+	FromStatus sql.NullInt32 `db:"from_status"` // NULL for the very first status (order created)
+	ToStatus   int           `db:"to_status"`
+	ChangedBy  sql.NullInt64 `db:"changed_by"` // owner's id, NULL when changed by the system
+	CreatedAt  string        `db:"created_at"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+} //	@name	update-order-status_request
+
+type UpdateOrderStatusResponse struct {
+	OrderID        int64  `json:"order_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+} //	@name	update-order-status_response
+
+type OrderStatusHistoryResponse struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	ChangedBy  int64  `json:"changed_by,omitempty"`
+	CreatedAt  string `json:"created_at"`
+} //	@name	order-status-history_response
@@ -1,6 +1,9 @@
 package model
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+)
 
 type Owner struct {
 	Id          int64          `db:"id"`
@@ -9,6 +12,20 @@ type Owner struct {
 	PhoneNumber string         `db:"phone_number"`
 	DateOfBirth sql.NullString `db:"date_of_birth"`
 	Password    string         `db:"password"`
+	Role        string         `db:"role"`        // see consts.Role*
+	BusinessID  int64          `db:"business_id"` // the business (tenant) the owner works for
+	// EmailVerified the owner clicked the link sent to its email, an unverified owner can't log in
+	EmailVerified bool `db:"email_verified"`
+}
+
+// OwnerEmailVerification is a link sent to an owner to verify an email, the registered one or the new one it changes to
+type OwnerEmailVerification struct {
+	ID        int64     `db:"id"`
+	OwnerID   int64     `db:"owner_id"`
+	Email     string    `db:"email"`
+	TokenHash string    `db:"token_hash"` // see utils.HashOneTimeToken
+	ExpiredAt time.Time `db:"expired_at"`
+	Used      bool      `db:"used"`
 }
 
 // // db model
@@ -35,6 +52,8 @@ type CreateOwnerRequest struct {
 	Email       string `json:"email" validate:"required,email"`
 	Password    string `json:"password" validate:"required,alphanum,min=8,omitempty"`
 	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+	// BusinessName is the name of the business run by the registering owner, the owner's name by default
+	BusinessName string `json:"business_name,omitempty" validate:"max=255"`
 } //	@name	create-owner_request
 type UpdateOwnerRequest struct {
 	Name        string `json:"name" validate:"required"`
@@ -46,9 +65,13 @@ type UpdateEmailRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
 
+type ResendEmailVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 type ResetPasswordRequest struct {
-	Password        string `json:"password" validate:"required,alphanum,uppercase,lowercase,min=8,omitempty"`
-	PasswordConfirm string `json:"password_confirm" validate:"required,alphanum,uppercase,lowercase,min=8,omitempty"`
+	Password        string `json:"password" validate:"required,alphanum,min=8,omitempty"`
+	PasswordConfirm string `json:"password_confirm" validate:"required,alphanum,min=8,omitempty"`
 }
 
 // Response model
@@ -70,6 +93,7 @@ type GetOwnerResponse struct {
 	Email       string `json:"email,omitempty"`
 	PhoneNumber string `json:"phone_number,omitempty"`
 	DateOfBirth string `json:"date_of_birth,omitempty"`
+	Role        string `json:"role,omitempty"`
 } //	@name	get-update-owner_response
 
 type UpdateOwnerResponse = GetOwnerResponse
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"database/sql"
+	"family-catering/pkg/money"
+)
+
+type Payment struct {
+	ID              int64          `db:"id"`
+	OrderID         int64          `db:"order_id"`
+	Amount          money.Money    `db:"amount"`
+	Method          string         `db:"method"` // cash, transfer or card
+	ReferenceNumber sql.NullString `db:"reference_number"`
+	ReceivedBy      sql.NullInt64  `db:"received_by"` // owner's id, NULL when paid through a payment provider
+	Provider        sql.NullString `db:"provider"`
+	ProviderEventID sql.NullString `db:"provider_event_id"` // webhook's event id, used to ignore replayed webhooks
+	PaidAt          string         `db:"paid_at"`
+}
+
+// PaymentSummary is the state of an order right after a payment is recorded
+type PaymentSummary struct {
+	PaymentID  int64
+	OrderID    int64
+	TotalPrice money.Money
+	TotalPaid  money.Money
+	Status     int
+}
+
+type ConfirmPaymentRequest struct {
+	OrderID         int64       `json:"order_id" validate:"required"`
+	Amount          money.Money `json:"amount" validate:"required,gt=0"`
+	Method          string      `json:"method" validate:"omitempty,oneof=cash transfer card"` // default to cash
+	ReferenceNumber string      `json:"reference_number" validate:"max=255"`
+} //	@name	confirm-payment_request
+
+type ConfirmPaymentResponse struct {
+	PaymentID        int64       `json:"payment_id"`
+	OrderID          int64       `json:"order_id"`
+	Amount           money.Money `json:"amount"`
+	TotalPrice       money.Money `json:"total_price"`
+	TotalPaid        money.Money `json:"total_paid"`
+	RemainingBalance money.Money `json:"remaining_balance"`
+	Status           string      `json:"status"`
+} //	@name	confirm-payment_response
+
+// Charge is an online payment requested to a payment provider, the customer pays it through CheckoutURL
+type Charge struct {
+	ID          string
+	Provider    string
+	OrderID     int64
+	Amount      money.Money
+	CheckoutURL string
+}
+
+type Refund struct {
+	ID       string
+	ChargeID string
+	Amount   money.Money
+}
+
+// PaymentEvent is a verified webhook's payload of a payment provider
+type PaymentEvent struct {
+	ID       string      `json:"id"`
+	Type     string      `json:"type"`
+	ChargeID string      `json:"charge_id"`
+	OrderID  int64       `json:"order_id"`
+	Amount   money.Money `json:"amount"`
+}
+
+type PaymentResponse struct {
+	Payment interface{} `json:"payment"`
+}
+
+type CreateChargeRequest struct {
+	OrderID int64       `json:"order_id" validate:"required"`
+	Amount  money.Money `json:"amount" validate:"omitempty,gt=0"` // default to the remaining balance
+} //	@name	create-charge_request
+
+type CreateChargeResponse struct {
+	ChargeID    string      `json:"charge_id"`
+	Provider    string      `json:"provider"`
+	OrderID     int64       `json:"order_id"`
+	Amount      money.Money `json:"amount"`
+	CheckoutURL string      `json:"checkout_url"`
+} //	@name	create-charge_response
+
+type PaymentWebhookResponse struct {
+	EventID     string `json:"event_id"`
+	Result      string `json:"result"` // processed, ignored, duplicate or refunded
+	OrderStatus string `json:"order_status,omitempty"`
+} //	@name	payment-webhook_response
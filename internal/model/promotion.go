@@ -0,0 +1,86 @@
+package model
+
+import (
+	"database/sql"
+	"family-catering/pkg/money"
+)
+
+type Promotion struct {
+	ID                    int64          `db:"id"`
+	Code                  string         `db:"code"` // upper case
+	Description           string         `db:"description"`
+	DiscountType          string         `db:"discount_type"` // percentage or fixed
+	Percentage            int            `db:"percentage"`    // whole percent, used by percentage discount
+	Amount                money.Money    `db:"amount"`        // used by fixed discount
+	MaxDiscount           money.Money    `db:"max_discount"`  // cap of percentage discount, zero is no cap
+	MinSpend              money.Money    `db:"min_spend"`     // compared with the order's total before discount
+	MenuIDs               string         `db:"menu_ids"`      // comma separated menu's id
+	Categories            string         `db:"categories"`    // comma separated, empty MenuIDs and Categories targets every menu
+	StartsAt              string         `db:"starts_at"`
+	EndsAt                sql.NullString `db:"ends_at"`     // NULL never ends
+	UsageLimit            int            `db:"usage_limit"` // 0 is unlimited
+	UsageLimitPerCustomer int            `db:"usage_limit_per_customer"`
+	Active                bool           `db:"active"` // computed by the database clock (starts_at <= NOW() < ends_at)
+	CreatedAt             string         `db:"created_at"`
+	UpdatedAt             string         `db:"updated_at"`
+}
+
+// OrderDiscount is the discount line of an order, an order has at most one promotion
+type OrderDiscount struct {
+	ID            int64       `db:"id"`
+	OrderID       int64       `db:"order_id"`
+	PromotionID   int64       `db:"promotion_id"`
+	Code          string      `db:"code"`
+	CustomerEmail string      `db:"customer_email"`
+	Amount        money.Money `db:"amount"`
+	CreatedAt     string      `db:"created_at"`
+}
+
+type CreatePromotionRequest struct {
+	Code                  string      `json:"code" validate:"required,alphanum,max=32"`
+	Description           string      `json:"description" validate:"max=255"`
+	DiscountType          string      `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	Percentage            int         `json:"percentage" validate:"required_if=DiscountType percentage,gte=0,lte=100"`
+	Amount                money.Money `json:"amount" validate:"required_if=DiscountType fixed,gte=0"`
+	MaxDiscount           money.Money `json:"max_discount" validate:"gte=0"`
+	MinSpend              money.Money `json:"min_spend" validate:"gte=0"`
+	MenuIDs               []int64     `json:"menu_ids" validate:"dive,gt=0"`
+	Categories            string      `json:"categories"`
+	StartsAt              string      `json:"starts_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC3339, default now
+	EndsAt                string      `json:"ends_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UsageLimit            int         `json:"usage_limit" validate:"gte=0"`
+	UsageLimitPerCustomer int         `json:"usage_limit_per_customer" validate:"gte=0"`
+} //	@name	create-update_promotion_request
+
+type CreatePromotionResponse struct {
+	ID                    int64       `json:"id"`
+	Code                  string      `json:"code"`
+	Description           string      `json:"description"`
+	DiscountType          string      `json:"discount_type"`
+	Percentage            int         `json:"percentage,omitempty"`
+	Amount                money.Money `json:"amount"`
+	MaxDiscount           money.Money `json:"max_discount"`
+	MinSpend              money.Money `json:"min_spend"`
+	MenuIDs               []int64     `json:"menu_ids"`
+	Categories            string      `json:"categories"`
+	StartsAt              string      `json:"starts_at,omitempty"`
+	EndsAt                string      `json:"ends_at,omitempty"`
+	UsageLimit            int         `json:"usage_limit"`
+	UsageLimitPerCustomer int         `json:"usage_limit_per_customer"`
+	Active                bool        `json:"active"`
+} //	@name	create-get-update_promotion_response
+
+type GetPromotionResponse = CreatePromotionResponse
+
+// UpdatePromotionRequest replace every field of the promotion, zero values are meaningful (e.g. no minimum spend)
+type UpdatePromotionRequest = CreatePromotionRequest
+type UpdatePromotionResponse = CreatePromotionResponse
+
+type PromotionResponse struct {
+	Promotion interface{} `json:"promotion"`
+} //	@name	promotion_response
+
+type OrderDiscountResponse struct {
+	PromoCode string      `json:"promo_code"`
+	Amount    money.Money `json:"amount"`
+} //	@name	order-discount_response
@@ -0,0 +1,96 @@
+package model
+
+import "family-catering/pkg/money"
+
+// SalesReportQuery is the grouping and the range (inclusive, YYYY-MM-DD) of the order's creation date of a sales report
+type SalesReportQuery struct {
+	GroupBy  string `validate:"required,oneof=day week month menu category customer"`
+	StartDay string `validate:"required,datetime=2006-01-02"`
+	EndDay   string `validate:"required,datetime=2006-01-02"`
+}
+
+// SalesReportRow is the sales of a group, only the rows of paid orders (which haven't been refunded) are counted. An order
+// is paid when its payments cover its grand total, whatever its status
+type SalesReportRow struct {
+	Group             string      `db:"group" json:"group"`     // the period's first day, the menu's name, the category or the customer's email
+	MenuID            int64       `db:"menu_id" json:"menu_id"` // only filled when grouped by menu
+	Revenue           money.Money `db:"revenue" json:"revenue"` // the orders' grand total, an order's items share it in proportion to their price times qty
+	Orders            int         `db:"orders" json:"orders"`
+	AverageOrderValue money.Money `db:"average_order_value" json:"average_order_value"`
+	ItemsSold         int         `db:"items_sold" json:"items_sold"`
+}
+
+// SalesReport is cached as JSON (see repository.ReportRepository.CacheSales)
+type SalesReport struct {
+	Total *SalesReportRow   `json:"total"`
+	Rows  []*SalesReportRow `json:"rows"`
+}
+
+type SalesReportRowResponse struct {
+	Group             string      `json:"group"`
+	MenuID            int64       `json:"menu_id,omitempty"`
+	Revenue           money.Money `json:"revenue"`
+	Orders            int         `json:"orders"`
+	AverageOrderValue money.Money `json:"average_order_value"`
+	ItemsSold         int         `json:"items_sold"`
+} //	@name	sales-report-row_response
+
+type SalesReportResponse struct {
+	GroupBy  string                    `json:"group_by"`
+	StartDay string                    `json:"start_day"`
+	EndDay   string                    `json:"end_day"`
+	Total    *SalesReportRowResponse   `json:"total"`
+	Rows     []*SalesReportRowResponse `json:"rows"`
+} //	@name	sales-report_response
+
+// CustomerReportQuery is the sorting, the page and the churn threshold of a customer report
+type CustomerReportQuery struct {
+	SortBy    string `validate:"required,oneof=lifetime_spend orders average_order_value first_order last_order email"`
+	Order     string `validate:"required,oneof=asc desc"`
+	ChurnDays int    `validate:"gte=1,lte=3650"` // a customer who hasn't ordered for more than ChurnDays days is churned
+	Limit     int    `validate:"gte=0"`
+	Offset    int    `validate:"gte=0"`
+}
+
+// CustomerStats is the lifetime statistic of the paid orders (which haven't been refunded) of a customer's email
+type CustomerStats struct {
+	Email                    string      `db:"customer_email"`
+	LifetimeSpend            money.Money `db:"lifetime_spend"` // sum of the orders' grand total
+	Orders                   int         `db:"orders"`
+	AverageOrderValue        money.Money `db:"average_order_value"`
+	FirstOrderAt             string      `db:"first_order_at"` // YYYY-MM-DD
+	LastOrderAt              string      `db:"last_order_at"`
+	AverageDaysBetweenOrders float64     `db:"average_days_between_orders"` // 0 when there is only one order
+	Churned                  bool        `db:"churned"`
+	FavouriteMenus           []*CustomerFavouriteMenu
+}
+
+type CustomerFavouriteMenu struct {
+	MenuID   int64  `db:"menu_id" json:"menu_id"`
+	MenuName string `db:"menu_name" json:"menu_name"`
+	Qty      int    `db:"qty" json:"qty"` // portions ordered
+} //	@name	customer-favourite-menu_response
+
+type CustomerStatsResponse struct {
+	Email                    string                   `json:"email"`
+	LifetimeSpend            money.Money              `json:"lifetime_spend"`
+	Orders                   int                      `json:"orders"`
+	AverageOrderValue        money.Money              `json:"average_order_value"`
+	AverageDaysBetweenOrders float64                  `json:"average_days_between_orders"`
+	FirstOrderAt             string                   `json:"first_order_at"`
+	LastOrderAt              string                   `json:"last_order_at"`
+	Churned                  bool                     `json:"churned"`
+	FavouriteMenus           []*CustomerFavouriteMenu `json:"favourite_menus"`
+} //	@name	customer-stats_response
+
+type CustomerReportResponse struct {
+	SortBy    string                   `json:"sort_by"`
+	Order     string                   `json:"order"`
+	ChurnDays int                      `json:"churn_days"`
+	Total     int                      `json:"total"` // number of customers
+	Customers []*CustomerStatsResponse `json:"customers"`
+} //	@name	customer-report_response
+
+type ReportResponse struct {
+	Report interface{} `json:"report"`
+}
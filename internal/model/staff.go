@@ -0,0 +1,22 @@
+package model
+
+// Requests model
+// CreateStaffRequest is an account created by an admin for a member of the staff
+type CreateStaffRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Email       string `json:"email" validate:"required,email"`
+	Password    string `json:"password" validate:"required,alphanum,min=8,omitempty"`
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+	Role        string `json:"role" validate:"required,oneof=admin cashier kitchen driver viewer"`
+} //	@name	create-staff_request
+
+type UpdateStaffRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin cashier kitchen driver viewer"`
+} //	@name	update-staff-role_request
+
+// Response model
+type StaffResponse struct {
+	Staff interface{} `json:"staff"`
+} //	@name	staff_response
+
+type GetStaffResponse = GetOwnerResponse
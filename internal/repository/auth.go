@@ -15,8 +15,8 @@ import (
 )
 
 const (
-	sessionKeyFormat     string = "sid:%s"
-	sessionByEmailFormat string = "email:sid:%s"
+	sessionKeyFormat       string = "sid:%s"
+	ownerSessionsKeyFormat string = "owner:sids:%d" // set of the owner's sid, an owner is logged in on many devices
 )
 
 var (
@@ -30,7 +30,17 @@ type AuthRepository interface {
 	AccessTokenTTL() time.Duration
 	RefreshTokenTTL() time.Duration
 	DeleteSession(ctx context.Context, sid string) error
-	GetSessionIDByEmail(ctx context.Context, email string) (sessionID string, err error)
+	// ListSessions return the unexpired sessions of the owner, the last seen first
+	ListSessions(ctx context.Context, ownerID int64) (sessions []model.Auth, err error)
+	// DeleteOwnerSessions delete every session of the owner (log out everywhere)
+	DeleteOwnerSessions(ctx context.Context, ownerID int64) (nAffected int64, err error)
+	// TouchSession set the session's last seen time to now
+	TouchSession(ctx context.Context, sid string) error
+	// RotateRefreshToken replace the refresh token (jti usedJti) of the auth's session by the auth's one and keep the used jti,
+	// errNoRow when the session's refresh token isn't usedJti anymore (already rotated)
+	RotateRefreshToken(ctx context.Context, auth model.Auth, usedJti string) (errNoRow error, err error)
+	// GetSessionIDByUsedJti return the session a rotated refresh token belongs to, errNoRow when the jti was never rotated
+	GetSessionIDByUsedJti(ctx context.Context, jti string) (sessionID string, errNoRow error, err error)
 }
 
 type authRepository struct {
@@ -56,7 +66,9 @@ func (repo *authRepository) setRedisSession(ctx context.Context, key string, aut
 		p.HSet(ctx, key, "email", auth.Email)
 		p.Expire(ctx, key, repo.refreshTokenTTL)
 
-		p.SetNX(ctx, fmt.Sprintf(sessionByEmailFormat, auth.Email), auth.SID, repo.refreshTokenTTL)
+		ownerKey := fmt.Sprintf(ownerSessionsKeyFormat, auth.OwnerID)
+		p.SAdd(ctx, ownerKey, auth.SID)
+		p.Expire(ctx, ownerKey, repo.refreshTokenTTL)
 		return nil
 	})
 
@@ -67,21 +79,19 @@ func (repo *authRepository) setRedisSession(ctx context.Context, key string, aut
 	return nil
 }
 
-func (repo *authRepository) deleteRedisSession(ctx context.Context, key string) error {
-
-	email := repo.redis.HGet(ctx, key, "email").Val()
-	fmt.Println("email: ", email)
+func (repo *authRepository) deleteRedisSession(ctx context.Context, sid string) error {
+	key := fmt.Sprintf(sessionKeyFormat, sid)
+	ownerID, _ := repo.redis.HGet(ctx, key, "owner_id").Int64()
 	_, err := repo.redis.Pipelined(ctx, func(p redisV8.Pipeliner) error {
 		p.HDel(ctx, key, "owner_id")
 		p.HDel(ctx, key, "valid")
 		p.HDel(ctx, key, "jti")
 		p.HDel(ctx, key, "email")
 		p.Del(ctx, key)
-		p.Del(ctx, fmt.Sprintf(sessionByEmailFormat, email))
+		p.SRem(ctx, fmt.Sprintf(ownerSessionsKeyFormat, ownerID), sid)
 		return nil
 	})
 
-	fmt.Println("error: ", err)
 	if err != nil {
 		return err
 	}
@@ -121,7 +131,7 @@ func (repo *authRepository) Login(ctx context.Context, authLogin model.Auth) (er
 	err = repo.postgres.QueryRowContext(
 		ctx, insertAuthLogin,
 		authLogin.SID, authLogin.OwnerID, authLogin.Email, authLogin.RefreshToken,
-		authLogin.Jti, time.Now().Add(repo.refreshTokenTTL)).Scan(&sid)
+		authLogin.Jti, time.Now().Add(repo.refreshTokenTTL), authLogin.UserAgent, authLogin.IP).Scan(&sid)
 
 	if err != nil {
 		return
@@ -167,6 +177,8 @@ func (repo *authRepository) Session(ctx context.Context, sid string) (authLogout
 		if err != nil {
 			return nil, nil, fmt.Errorf("service.authRepository.Session: %w", err)
 		}
+		// a session is valid until it's deleted (logout or revoked)
+		authLogoutResponse.Valid = true
 		err = repo.setRedisSession(ctx, key, *authLogoutResponse)
 		if err != nil {
 			return nil, nil, fmt.Errorf("service.authRepository.Session: %w", err)
@@ -190,8 +202,7 @@ func (repo *authRepository) DeleteSession(ctx context.Context, sid string) error
 		return fmt.Errorf("service.authRepository.DeleteSession: no rows")
 	}
 	// redis
-	keySID := fmt.Sprintf(sessionKeyFormat, sid)
-	err = repo.deleteRedisSession(ctx, keySID)
+	err = repo.deleteRedisSession(ctx, sid)
 	if err != nil {
 		return fmt.Errorf("service.authRepository.DeleteSession: %w", err)
 	}
@@ -199,17 +210,123 @@ func (repo *authRepository) DeleteSession(ctx context.Context, sid string) error
 	return nil
 }
 
-func (repo *authRepository) GetSessionIDByEmail(ctx context.Context, email string) (sessionID string, err error) {
-	key := fmt.Sprintf(sessionByEmailFormat, email)
-	sid, err := repo.redis.Get(ctx, key).Result()
-	if errors.Is(err, redisV8.Nil) {
-		return "", nil
+func (repo *authRepository) RotateRefreshToken(ctx context.Context, auth model.Auth, usedJti string) (errNoRow error, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, rotateRefreshToken, auth.SID, usedJti, auth.Jti, auth.RefreshToken, time.Now().Add(repo.refreshTokenTTL))
+	if err != nil {
+		return nil, fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", err)
+	}
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", err)
+	}
+	if nAffected == 0 {
+		return fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", sql.ErrNoRows), nil
 	}
+
+	_, err = tx.ExecContext(ctx, insertUsedJti, usedJti, auth.SID)
 	if err != nil {
-		err = fmt.Errorf("service.authRepository.GetSessionByEMail: %w", err)
-		return "", err
+		return nil, fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", err)
 	}
 
-	return sid, nil
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", err)
+	}
 
+	// the session lives as long as its last refresh token
+	err = repo.setRedisSession(ctx, fmt.Sprintf(sessionKeyFormat, auth.SID), auth)
+	if err != nil {
+		return nil, fmt.Errorf("repository.authRepository.RotateRefreshToken: %w", err)
+	}
+
+	return nil, nil
+}
+
+func (repo *authRepository) GetSessionIDByUsedJti(ctx context.Context, jti string) (sessionID string, errNoRow error, err error) {
+	err = repo.postgres.QueryRowContext(ctx, getSessionIDByUsedJti, jti).Scan(&sessionID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("repository.authRepository.GetSessionIDByUsedJti: %w", err), nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("repository.authRepository.GetSessionIDByUsedJti: %w", err)
+	}
+
+	return sessionID, nil, nil
+}
+
+func (repo *authRepository) ListSessions(ctx context.Context, ownerID int64) ([]model.Auth, error) {
+	rows, err := repo.postgres.QueryContext(ctx, listSessionsByOwnerID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.authRepository.ListSessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]model.Auth, 0)
+	for rows.Next() {
+		session := model.Auth{}
+		err = rows.Scan(&session.SID, &session.OwnerID, &session.UserAgent, &session.IP, &session.ExpiredAt, &session.LastSeenAt, &session.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository.authRepository.ListSessions: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.authRepository.ListSessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (repo *authRepository) DeleteOwnerSessions(ctx context.Context, ownerID int64) (int64, error) {
+	rows, err := repo.postgres.QueryContext(ctx, deleteSessionsByOwnerID, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("repository.authRepository.DeleteOwnerSessions: %w", err)
+	}
+	defer rows.Close()
+
+	sids := make([]string, 0)
+	for rows.Next() {
+		var sid string
+		if err = rows.Scan(&sid); err != nil {
+			return 0, fmt.Errorf("repository.authRepository.DeleteOwnerSessions: %w", err)
+		}
+		sids = append(sids, sid)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("repository.authRepository.DeleteOwnerSessions: %w", err)
+	}
+
+	// the owner's set may hold a session cached in redis only, it's deleted too
+	ownerKey := fmt.Sprintf(ownerSessionsKeyFormat, ownerID)
+	cached, err := repo.redis.SMembers(ctx, ownerKey).Result()
+	if err != nil && !errors.Is(err, redisV8.Nil) {
+		return 0, fmt.Errorf("repository.authRepository.DeleteOwnerSessions: %w", err)
+	}
+	_, err = repo.redis.Pipelined(ctx, func(p redisV8.Pipeliner) error {
+		for _, sid := range append(sids, cached...) {
+			p.Del(ctx, fmt.Sprintf(sessionKeyFormat, sid))
+		}
+		p.Del(ctx, ownerKey)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("repository.authRepository.DeleteOwnerSessions: %w", err)
+	}
+
+	return int64(len(sids)), nil
+}
+
+func (repo *authRepository) TouchSession(ctx context.Context, sid string) error {
+	_, err := repo.postgres.ExecContext(ctx, touchSession, sid)
+	if err != nil {
+		return fmt.Errorf("repository.authRepository.TouchSession: %w", err)
+	}
+
+	return nil
 }
@@ -50,6 +50,21 @@ func (mr *MockAuthRepositoryMockRecorder) AccessTokenTTL() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenTTL", reflect.TypeOf((*MockAuthRepository)(nil).AccessTokenTTL))
 }
 
+// DeleteOwnerSessions mocks base method.
+func (m *MockAuthRepository) DeleteOwnerSessions(ctx context.Context, ownerID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOwnerSessions", ctx, ownerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOwnerSessions indicates an expected call of DeleteOwnerSessions.
+func (mr *MockAuthRepositoryMockRecorder) DeleteOwnerSessions(ctx, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOwnerSessions", reflect.TypeOf((*MockAuthRepository)(nil).DeleteOwnerSessions), ctx, ownerID)
+}
+
 // DeleteSession mocks base method.
 func (m *MockAuthRepository) DeleteSession(ctx context.Context, sid string) error {
 	m.ctrl.T.Helper()
@@ -64,19 +79,35 @@ func (mr *MockAuthRepositoryMockRecorder) DeleteSession(ctx, sid interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAuthRepository)(nil).DeleteSession), ctx, sid)
 }
 
-// GetSessionIDByEmail mocks base method.
-func (m *MockAuthRepository) GetSessionIDByEmail(ctx context.Context, email string) (string, error) {
+// GetSessionIDByUsedJti mocks base method.
+func (m *MockAuthRepository) GetSessionIDByUsedJti(ctx context.Context, jti string) (string, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSessionIDByEmail", ctx, email)
+	ret := m.ctrl.Call(m, "GetSessionIDByUsedJti", ctx, jti)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSessionIDByUsedJti indicates an expected call of GetSessionIDByUsedJti.
+func (mr *MockAuthRepositoryMockRecorder) GetSessionIDByUsedJti(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionIDByUsedJti", reflect.TypeOf((*MockAuthRepository)(nil).GetSessionIDByUsedJti), ctx, jti)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthRepository) ListSessions(ctx context.Context, ownerID int64) ([]model.Auth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, ownerID)
+	ret0, _ := ret[0].([]model.Auth)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSessionIDByEmail indicates an expected call of GetSessionIDByEmail.
-func (mr *MockAuthRepositoryMockRecorder) GetSessionIDByEmail(ctx, email interface{}) *gomock.Call {
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthRepositoryMockRecorder) ListSessions(ctx, ownerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionIDByEmail", reflect.TypeOf((*MockAuthRepository)(nil).GetSessionIDByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthRepository)(nil).ListSessions), ctx, ownerID)
 }
 
 // Login mocks base method.
@@ -107,6 +138,21 @@ func (mr *MockAuthRepositoryMockRecorder) RefreshTokenTTL() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokenTTL", reflect.TypeOf((*MockAuthRepository)(nil).RefreshTokenTTL))
 }
 
+// RotateRefreshToken mocks base method.
+func (m *MockAuthRepository) RotateRefreshToken(ctx context.Context, auth model.Auth, usedJti string) (error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, auth, usedJti)
+	ret0, _ := ret[0].(error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) RotateRefreshToken(ctx, auth, usedJti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).RotateRefreshToken), ctx, auth, usedJti)
+}
+
 // Session mocks base method.
 func (m *MockAuthRepository) Session(ctx context.Context, sid string) (*model.Auth, error, error) {
 	m.ctrl.T.Helper()
@@ -122,3 +168,17 @@ func (mr *MockAuthRepositoryMockRecorder) Session(ctx, sid interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Session", reflect.TypeOf((*MockAuthRepository)(nil).Session), ctx, sid)
 }
+
+// TouchSession mocks base method.
+func (m *MockAuthRepository) TouchSession(ctx context.Context, sid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockAuthRepositoryMockRecorder) TouchSession(ctx, sid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockAuthRepository)(nil).TouchSession), ctx, sid)
+}
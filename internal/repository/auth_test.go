@@ -78,7 +78,7 @@ func Test_authRepository_Login(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("INSERT INTO auth .+").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(nil).WillReturnRows(sqlmock.NewRows([]string{"session_id"}).AddRow("sid"))
 				m.redisMock.On("Pipelined").Return([]redisV8.Cmder{redisV8.NewIntResult(1, errNoError)}, errNoError)
 			},
@@ -97,7 +97,7 @@ func Test_authRepository_Login(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("INSERT INTO auth .+").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(errors.New("oops! query error")).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 			},
 			wantErr: true,
@@ -116,7 +116,7 @@ func Test_authRepository_Login(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("INSERT INTO auth .+").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				m.redisMock.On("Pipeline").Return(redisV8.Pipeline{})
 				m.redisMock.On("Pipelined").Return([]redisV8.Cmder{redisV8.NewIntResult(0, errors.New("oops! redis error"))}, errors.New("oops! redis error"))
@@ -210,6 +210,7 @@ func Test_authRepository_Session(t *testing.T) {
 				OwnerID:      1,
 				SID:          "sid",
 				Jti:          "jti",
+				Valid:        true,
 				Email:        "test@example.com",
 				RefreshToken: "refresh-token",
 			},
@@ -373,46 +374,53 @@ func Test_authRepository_DeleteSession(t *testing.T) {
 	}
 }
 
-func Test_authRepository_GetSessionIDByEmail(t *testing.T) {
+func Test_authRepository_DeleteOwnerSessions(t *testing.T) {
 	type args struct {
-		ctx   context.Context
-		email string
+		ctx     context.Context
+		ownerID int64
 	}
 	type mocks struct {
 		redisMock *redismock.ClientMock
+		pgMock    sqlmock.Sqlmock
 	}
 	tests := []struct {
 		name          string
 		repo          *authRepository
 		args          args
 		prepareMocks  func(*mocks)
-		wantSessionID string
+		wantNAffected int64
 		wantErr       bool
 	}{
 		{
-			name: "success get sid",
+			name: "success delete owner sessions",
 			repo: &authRepository{},
-			args: args{
-				ctx:   context.Background(),
-				email: "test@example.com",
-			},
+			args: args{ctx: context.Background(), ownerID: 1},
 			prepareMocks: func(m *mocks) {
-				m.redisMock.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(redisV8.NewStringResult("random-sid-string", nil)).Times(1)
+				m.pgMock.ExpectQuery(`DELETE FROM auth WHERE owner_id = \$1 RETURNING sid`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sid"}).AddRow("sid-phone").AddRow("sid-laptop"))
+				// unstubbed, redis is the in-memory one
+				m.redisMock.SAdd(context.Background(), "owner:sids:1", "sid-phone", "sid-laptop")
 			},
-			wantSessionID: "random-sid-string",
+			wantNAffected: 2,
 		},
 		{
-			name: "fail get sid",
+			name: "fail delete owner sessions (error postgres)",
 			repo: &authRepository{},
-			args: args{
-				ctx:   context.Background(),
-				email: "test@example.com",
+			args: args{ctx: context.Background(), ownerID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`DELETE FROM auth`).WithArgs(int64(1)).WillReturnError(errors.New("oops! error from postgres"))
 			},
+			wantErr: true,
+		},
+		{
+			name: "fail delete owner sessions (error redis)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), ownerID: 1},
 			prepareMocks: func(m *mocks) {
-				m.redisMock.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(redisV8.NewStringResult("random-sid-string", errors.New("oops! error from redis"))).Times(1)
+				m.pgMock.ExpectQuery(`DELETE FROM auth`).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"sid"}).AddRow("sid-phone"))
+				m.redisMock.On("SMembers", mock.Anything, "owner:sids:1").Return(redisV8.NewStringSliceResult(nil, errors.New("oops! error from redis")))
 			},
-			wantSessionID: "",
-			wantErr:       true,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -421,21 +429,294 @@ func Test_authRepository_GetSessionIDByEmail(t *testing.T) {
 			if err != nil {
 				panic(err)
 			}
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.repo.postgres = db
+			tt.repo.redis = redisMock
 
-			fMocks := mocks{
-				redisMock: redisMock,
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{redisMock: redisMock, pgMock: pgMock})
+			}
+
+			gotNAffected, err := tt.repo.DeleteOwnerSessions(tt.args.ctx, tt.args.ownerID)
+			assert.Equal(t, tt.wantErr, (err != nil && !errors.Is(err, errNoError)), err)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_authRepository_ListSessions(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		ownerID int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	sessionCols := []string{"sid", "owner_id", "user_agent", "ip", "expired_at", "last_seen_at", "created_at"}
+	tests := []struct {
+		name         string
+		repo         *authRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantSessions []model.Auth
+		wantErr      bool
+	}{
+		{
+			name: "success list sessions",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), ownerID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT .+ FROM auth WHERE owner_id = \$1 AND expired_at > NOW\(\) ORDER BY last_seen_at DESC`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(sessionCols).
+						AddRow("sid-phone", int64(1), "Mozilla/5.0 (Linux; Android 13)", "10.0.0.2", "2023-03-01T00:00:00Z", "2023-01-02T00:00:00Z", "2023-01-01T00:00:00Z"))
+			},
+			wantSessions: []model.Auth{{
+				SID: "sid-phone", OwnerID: 1, UserAgent: "Mozilla/5.0 (Linux; Android 13)", IP: "10.0.0.2",
+				ExpiredAt: "2023-03-01T00:00:00Z", LastSeenAt: "2023-01-02T00:00:00Z", CreatedAt: "2023-01-01T00:00:00Z",
+			}},
+		},
+		{
+			name: "success list sessions (no session)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), ownerID: 2},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT .+ FROM auth`).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows(sessionCols))
+			},
+			wantSessions: []model.Auth{},
+		},
+		{
+			name: "fail list sessions (error postgres)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), ownerID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT .+ FROM auth`).WithArgs(int64(1)).WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
+			gotSessions, err := tt.repo.ListSessions(tt.args.ctx, tt.args.ownerID)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantSessions, gotSessions)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_authRepository_TouchSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		sid          string
+		prepareMocks func(sqlmock.Sqlmock)
+		wantErr      bool
+	}{
+		{
+			name: "success touch session",
+			sid:  "sid",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectExec(`UPDATE auth SET last_seen_at = NOW\(\) WHERE sid = \$1`).WithArgs("sid").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail touch session (error postgres)",
+			sid:  "sid",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectExec(`UPDATE auth SET last_seen_at`).WithArgs("sid").WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &authRepository{postgres: db}
+			tt.prepareMocks(pgMock)
+
+			err = repo.TouchSession(context.Background(), tt.sid)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_authRepository_RotateRefreshToken(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		auth    model.Auth
+		usedJti string
+	}
+	type mocks struct {
+		redisMock *redismock.ClientMock
+		pgMock    sqlmock.Sqlmock
+	}
+	validAuth := model.Auth{SID: "sid", OwnerID: 1, Email: "test@example.com", Jti: "new-jti", RefreshToken: "new-refresh-token"}
+	tests := []struct {
+		name         string
+		repo         *authRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success rotate refresh token",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), auth: validAuth, usedJti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE auth SET jti = \$3, refresh_token = \$4, expired_at = \$5 WHERE sid = \$1 AND jti = \$2`).
+					WithArgs("sid", "jti", "new-jti", "new-refresh-token", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`INSERT INTO auth_used_jti`).WithArgs("jti", "sid").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectCommit()
+				m.redisMock.On("Pipelined").Return([]redisV8.Cmder{redisV8.NewIntResult(1, errNoError)}, errNoError)
+			},
+		},
+		{
+			name: "fail rotate refresh token (already rotated)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), auth: validAuth, usedJti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE auth SET jti`).
+					WithArgs("sid", "jti", "new-jti", "new-refresh-token", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail rotate refresh token (error postgres)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), auth: validAuth, usedJti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE auth SET jti`).
+					WithArgs("sid", "jti", "new-jti", "new-refresh-token", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`INSERT INTO auth_used_jti`).WithArgs("jti", "sid").WillReturnError(errors.New("oops! error from postgres"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail rotate refresh token (error redis)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), auth: validAuth, usedJti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE auth SET jti`).
+					WithArgs("sid", "jti", "new-jti", "new-refresh-token", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`INSERT INTO auth_used_jti`).WithArgs("jti", "sid").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectCommit()
+				m.redisMock.On("Pipelined").Return([]redisV8.Cmder{redisV8.NewIntResult(1, errors.New("oops! error from redis"))}, errors.New("oops! error from redis"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisMock, err := redis.NewMockWithMiniRedisClient(t)
+			if err != nil {
+				panic(err)
+			}
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.repo.postgres = db
 			tt.repo.redis = redisMock
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&fMocks)
+				tt.prepareMocks(&mocks{redisMock: redisMock, pgMock: pgMock})
 			}
 
-			gotSessionID, err := tt.repo.GetSessionIDByEmail(tt.args.ctx, tt.args.email)
-			assert.Equal(t, tt.wantErr, err != nil)
-			assert.Equal(t, tt.wantSessionID, gotSessionID)
+			errNoRow, err := tt.repo.RotateRefreshToken(tt.args.ctx, tt.args.auth, tt.args.usedJti)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, (err != nil && !errors.Is(err, errNoError)), err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
 
+func Test_authRepository_GetSessionIDByUsedJti(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		jti string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *authRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantSessionID string
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success get sid of used jti",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), jti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT sid FROM auth_used_jti WHERE jti = \$1`).WithArgs("jti").
+					WillReturnRows(sqlmock.NewRows([]string{"sid"}).AddRow("sid"))
+			},
+			wantSessionID: "sid",
+		},
+		{
+			name: "fail get sid of used jti (never rotated)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), jti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT sid FROM auth_used_jti`).WithArgs("jti").WillReturnRows(sqlmock.NewRows([]string{"sid"}))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail get sid of used jti (error postgres)",
+			repo: &authRepository{},
+			args: args{ctx: context.Background(), jti: "jti"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT sid FROM auth_used_jti`).WithArgs("jti").WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotSessionID, errNoRow, err := tt.repo.GetSessionIDByUsedJti(tt.args.ctx, tt.args.jti)
+			assert.Equal(t, tt.wantSessionID, gotSessionID)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
 		})
 	}
 }
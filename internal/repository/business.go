@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+// BusinessRepository a business is created along with its first owner (see OwnerRepository.CreateWithBusiness)
+type BusinessRepository interface {
+	Get(ctx context.Context, id int64) (business *model.Business, errNoRow error, err error)
+	Update(ctx context.Context, business model.Business) (nAffected int64, errNoRow error, err error)
+}
+
+type businessRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewBusinessRepository(postgres postgres.PostgresClient) BusinessRepository {
+	return &businessRepository{postgres: postgres}
+}
+
+func (repo *businessRepository) Get(ctx context.Context, id int64) (business *model.Business, errNoRow error, err error) {
+	business = &model.Business{}
+	err = repo.postgres.QueryRowContext(ctx, getBusinessByID, id).Scan(
+		&business.ID,
+		&business.Name,
+		&business.CreatedAt,
+		&business.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.businessRepository.Get: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.businessRepository.Get: %w", err)
+		return nil, nil, err
+	}
+
+	return business, nil, nil
+}
+
+func (repo *businessRepository) Update(ctx context.Context, business model.Business) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateBusinessByID, business.ID, business.Name)
+	if err != nil {
+		err = fmt.Errorf("repository.businessRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.businessRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.businessRepository.Update: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\business.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBusinessRepository is a mock of BusinessRepository interface.
+type MockBusinessRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusinessRepositoryMockRecorder
+}
+
+// MockBusinessRepositoryMockRecorder is the mock recorder for MockBusinessRepository.
+type MockBusinessRepositoryMockRecorder struct {
+	mock *MockBusinessRepository
+}
+
+// NewMockBusinessRepository creates a new mock instance.
+func NewMockBusinessRepository(ctrl *gomock.Controller) *MockBusinessRepository {
+	mock := &MockBusinessRepository{ctrl: ctrl}
+	mock.recorder = &MockBusinessRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusinessRepository) EXPECT() *MockBusinessRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBusinessRepository) Get(ctx context.Context, id int64) (*model.Business, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*model.Business)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBusinessRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBusinessRepository)(nil).Get), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockBusinessRepository) Update(ctx context.Context, business model.Business) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, business)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBusinessRepositoryMockRecorder) Update(ctx, business interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBusinessRepository)(nil).Update), ctx, business)
+}
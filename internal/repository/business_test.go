@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var businessCols = []string{"id", "name", "created_at", "updated_at"}
+
+func TestNewBusinessRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewBusinessRepository"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewBusinessRepository(tt.args.postgres))
+		})
+	}
+}
+
+func Test_businessRepository_Get(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *businessRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantBusiness *model.Business
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success Get",
+			repo: &businessRepository{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM business WHERE id = \$1`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(businessCols).
+						AddRow(int64(1), "Family Catering", "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantBusiness: &model.Business{ID: 1, Name: "Family Catering", CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z"},
+		},
+		{
+			name: "fail Get (no row)",
+			repo: &businessRepository{},
+			args: args{ctx: context.Background(), id: 2},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM business`).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows(businessCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Get (db error)",
+			repo: &businessRepository{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM business`).WithArgs(int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotBusiness, errNoRow, err := tt.repo.Get(tt.args.ctx, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantBusiness, gotBusiness)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_businessRepository_Update(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		business model.Business
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *businessRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success Update",
+			repo: &businessRepository{},
+			args: args{ctx: context.Background(), business: model.Business{ID: 1, Name: "Dapur Bu Tini"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE business SET name = \$2 WHERE id = \$1`).WithArgs(int64(1), "Dapur Bu Tini").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Update (no row)",
+			repo: &businessRepository{},
+			args: args{ctx: context.Background(), business: model.Business{ID: 2, Name: "Dapur Bu Tini"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE business`).WithArgs(int64(2), "Dapur Bu Tini").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Update (db error)",
+			repo: &businessRepository{},
+			args: args{ctx: context.Background(), business: model.Business{ID: 1, Name: "Dapur Bu Tini"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE business`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.business)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+type CustomerRepository interface {
+	GetByID(ctx context.Context, businessID, id int64) (customer *model.Customer, errNoRow error, err error)
+	GetByEmail(ctx context.Context, businessID int64, email string) (customer *model.Customer, errNoRow error, err error)
+	List(ctx context.Context, businessID int64, limit, offset int) (customers []*model.Customer, errNoRow error, err error)
+	Create(ctx context.Context, businessID int64, customer model.Customer) (id int64, err error)
+	FindOrCreate(ctx context.Context, businessID int64, customer model.Customer) (id int64, err error)
+	Update(ctx context.Context, businessID int64, customer model.Customer) (nAffected int64, errNoRow error, err error)
+	Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error)
+	CreateAddress(ctx context.Context, businessID int64, address model.CustomerAddress) (id int64, err error)
+	UpdateAddress(ctx context.Context, businessID int64, address model.CustomerAddress) (nAffected int64, errNoRow error, err error)
+	DeleteAddress(ctx context.Context, businessID, customerID, id int64) (nAffected int64, errNoRow error, err error)
+}
+
+type customerRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewCustomerRepository(postgres postgres.PostgresClient) CustomerRepository {
+	return &customerRepository{postgres: postgres}
+}
+
+// scanCustomer scan a row selected by the customer's queries (see getCustomerByID)
+func scanCustomer(row rowScanner, customer *model.Customer) error {
+	return row.Scan(
+		&customer.ID,
+		&customer.Name,
+		&customer.Email,
+		&customer.Phone,
+		&customer.DietaryNotes,
+		&customer.MarketingConsent,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+	)
+}
+
+// GetByID return the customer along with its addresses, the default address comes first
+func (repo *customerRepository) GetByID(ctx context.Context, businessID, id int64) (customer *model.Customer, errNoRow error, err error) {
+	customer = &model.Customer{}
+	err = scanCustomer(repo.postgres.QueryRowContext(ctx, getCustomerByID, id, businessID), customer)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.customerRepository.GetByID: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.GetByID: %w", err)
+		return nil, nil, err
+	}
+
+	rows, err := repo.postgres.QueryContext(ctx, listCustomerAddresses, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.GetByID: %w", err)
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	customer.Addresses = make([]*model.CustomerAddress, 0)
+	for rows.Next() {
+		address := new(model.CustomerAddress)
+		err = rows.Scan(
+			&address.ID,
+			&address.CustomerID,
+			&address.Label,
+			&address.Address,
+			&address.PostalCode,
+			&address.Latitude,
+			&address.Longitude,
+			&address.Notes,
+			&address.IsDefault,
+			&address.CreatedAt,
+			&address.UpdatedAt,
+		)
+		if err != nil {
+			err = fmt.Errorf("repository.customerRepository.GetByID: %w", err)
+			return nil, nil, err
+		}
+
+		customer.Addresses = append(customer.Addresses, address)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.GetByID: %w", err)
+		return nil, nil, err
+	}
+
+	return customer, nil, nil
+}
+
+// GetByEmail return the customer of the (lower case) email without its addresses
+func (repo *customerRepository) GetByEmail(ctx context.Context, businessID int64, email string) (customer *model.Customer, errNoRow error, err error) {
+	customer = &model.Customer{}
+	err = scanCustomer(repo.postgres.QueryRowContext(ctx, getCustomerByEmail, email, businessID), customer)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.customerRepository.GetByEmail: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.GetByEmail: %w", err)
+		return nil, nil, err
+	}
+
+	return customer, nil, nil
+}
+
+func (repo *customerRepository) List(ctx context.Context, businessID int64, limit, offset int) (customers []*model.Customer, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listCustomer, limit, offset, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.List: %w", err)
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		customer := new(model.Customer)
+		err = scanCustomer(rows, customer)
+		if err != nil {
+			err = fmt.Errorf("repository.customerRepository.List: %w", err)
+			return nil, nil, err
+		}
+
+		customers = append(customers, customer)
+	}
+
+	err = rows.Err()
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(customers) == 0 {
+		err = fmt.Errorf("repository.customerRepository.List: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.List: %w", err)
+		return nil, nil, err
+	}
+
+	return customers, nil, rows.Close()
+}
+
+func (repo *customerRepository) Create(ctx context.Context, businessID int64, customer model.Customer) (id int64, err error) {
+	err = repo.postgres.QueryRowContext(ctx, createCustomer,
+		customer.Name, customer.Email, customer.Phone, customer.DietaryNotes, customer.MarketingConsent, businessID).
+		Scan(&id)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.Create: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// FindOrCreate return the id of the customer of the email, the customer is created when the email is not registered yet,
+// the details of an existing customer are left as they are
+func (repo *customerRepository) FindOrCreate(ctx context.Context, businessID int64, customer model.Customer) (id int64, err error) {
+	err = repo.postgres.QueryRowContext(ctx, findOrCreateCustomer,
+		customer.Name, customer.Email, customer.Phone, customer.DietaryNotes, customer.MarketingConsent, businessID).
+		Scan(&id)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.FindOrCreate: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo *customerRepository) Update(ctx context.Context, businessID int64, customer model.Customer) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateCustomerByID, customer.ID,
+		customer.Name, customer.Email, customer.Phone, customer.DietaryNotes, customer.MarketingConsent, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.customerRepository.Update: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+// Delete delete a customer who has never ordered along with its addresses,
+// errNoRow is returned when the customer is not found or has ordered
+func (repo *customerRepository) Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteCustomerByID, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.customerRepository.Delete: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+// CreateAddress add an address to the customer, the first address of the customer becomes its default address
+// and a new default address unsets the previous one
+func (repo *customerRepository) CreateAddress(ctx context.Context, businessID int64, address model.CustomerAddress) (id int64, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.CreateAddress: %w", err)
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	if address.IsDefault {
+		_, err = tx.ExecContext(ctx, unsetDefaultCustomerAddress, address.CustomerID, 0, businessID)
+		if err != nil {
+			err = fmt.Errorf("repository.customerRepository.CreateAddress: %w", err)
+			return 0, err
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, createCustomerAddress, address.CustomerID,
+		address.Label, address.Address, address.PostalCode, address.Latitude, address.Longitude, address.Notes, address.IsDefault, businessID).
+		Scan(&id)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.CreateAddress: %w", err)
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.CreateAddress: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UpdateAddress replace every field of the customer's address, a new default address unsets the previous one.
+// errNoRow is returned when the address is not found (or belongs to another customer)
+func (repo *customerRepository) UpdateAddress(ctx context.Context, businessID int64, address model.CustomerAddress) (nAffected int64, errNoRow error, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.UpdateAddress: %w", err)
+		return 0, nil, err
+	}
+
+	defer tx.Rollback()
+
+	if address.IsDefault {
+		_, err = tx.ExecContext(ctx, unsetDefaultCustomerAddress, address.CustomerID, address.ID, businessID)
+		if err != nil {
+			err = fmt.Errorf("repository.customerRepository.UpdateAddress: %w", err)
+			return 0, nil, err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, updateCustomerAddress, address.CustomerID, address.ID,
+		address.Label, address.Address, address.PostalCode, address.Latitude, address.Longitude, address.Notes, address.IsDefault, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.UpdateAddress: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.UpdateAddress: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.customerRepository.UpdateAddress: %w", sql.ErrNoRows), nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.UpdateAddress: %w", err)
+		return 0, nil, err
+	}
+
+	return nAffected, nil, nil
+}
+
+// DeleteAddress delete the customer's address, errNoRow is returned when the address is not found (or belongs to another customer)
+func (repo *customerRepository) DeleteAddress(ctx context.Context, businessID, customerID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteCustomerAddress, customerID, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.DeleteAddress: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.customerRepository.DeleteAddress: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.customerRepository.DeleteAddress: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\customer.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCustomerRepository is a mock of CustomerRepository interface.
+type MockCustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRepositoryMockRecorder
+}
+
+// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
+type MockCustomerRepositoryMockRecorder struct {
+	mock *MockCustomerRepository
+}
+
+// NewMockCustomerRepository creates a new mock instance.
+func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
+	mock := &MockCustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomerRepository) Create(ctx context.Context, businessID int64, customer model.Customer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, businessID, customer)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerRepositoryMockRecorder) Create(ctx, businessID, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerRepository)(nil).Create), ctx, businessID, customer)
+}
+
+// CreateAddress mocks base method.
+func (m *MockCustomerRepository) CreateAddress(ctx context.Context, businessID int64, address model.CustomerAddress) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", ctx, businessID, address)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockCustomerRepositoryMockRecorder) CreateAddress(ctx, businessID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockCustomerRepository)(nil).CreateAddress), ctx, businessID, address)
+}
+
+// Delete mocks base method.
+func (m *MockCustomerRepository) Delete(ctx context.Context, businessID, id int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, businessID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomerRepositoryMockRecorder) Delete(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerRepository)(nil).Delete), ctx, businessID, id)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockCustomerRepository) DeleteAddress(ctx context.Context, businessID, customerID, id int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", ctx, businessID, customerID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockCustomerRepositoryMockRecorder) DeleteAddress(ctx, businessID, customerID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockCustomerRepository)(nil).DeleteAddress), ctx, businessID, customerID, id)
+}
+
+// FindOrCreate mocks base method.
+func (m *MockCustomerRepository) FindOrCreate(ctx context.Context, businessID int64, customer model.Customer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreate", ctx, businessID, customer)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreate indicates an expected call of FindOrCreate.
+func (mr *MockCustomerRepositoryMockRecorder) FindOrCreate(ctx, businessID, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreate", reflect.TypeOf((*MockCustomerRepository)(nil).FindOrCreate), ctx, businessID, customer)
+}
+
+// GetByEmail mocks base method.
+func (m *MockCustomerRepository) GetByEmail(ctx context.Context, businessID int64, email string) (*model.Customer, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, businessID, email)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockCustomerRepositoryMockRecorder) GetByEmail(ctx, businessID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockCustomerRepository)(nil).GetByEmail), ctx, businessID, email)
+}
+
+// GetByID mocks base method.
+func (m *MockCustomerRepository) GetByID(ctx context.Context, businessID, id int64) (*model.Customer, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, businessID, id)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCustomerRepositoryMockRecorder) GetByID(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCustomerRepository)(nil).GetByID), ctx, businessID, id)
+}
+
+// List mocks base method.
+func (m *MockCustomerRepository) List(ctx context.Context, businessID int64, limit, offset int) ([]*model.Customer, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, businessID, limit, offset)
+	ret0, _ := ret[0].([]*model.Customer)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockCustomerRepositoryMockRecorder) List(ctx, businessID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCustomerRepository)(nil).List), ctx, businessID, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockCustomerRepository) Update(ctx context.Context, businessID int64, customer model.Customer) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, businessID, customer)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomerRepositoryMockRecorder) Update(ctx, businessID, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerRepository)(nil).Update), ctx, businessID, customer)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockCustomerRepository) UpdateAddress(ctx context.Context, businessID int64, address model.CustomerAddress) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", ctx, businessID, address)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateAddress(ctx, businessID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateAddress), ctx, businessID, address)
+}
@@ -0,0 +1,486 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	customerCols        = []string{"id", "name", "email", "phone", "dietary_notes", "marketing_consent", "created_at", "updated_at"}
+	customerAddressCols = []string{"id", "customer_id", "label", "address", "postal_code", "latitude", "longitude", "notes", "is_default", "created_at", "updated_at"}
+)
+
+func TestNewCustomerRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewCustomerRepository"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewCustomerRepository(tt.args.postgres))
+		})
+	}
+}
+
+func Test_customerRepository_GetByID(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *customerRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantCustomer *model.Customer
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success GetByID",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer.+WHERE.+id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(customerCols).
+						AddRow(int64(1), "Budi", "budi@example.com", "0812", "no peanut", true, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer_address.+WHERE.+customer_id = \$1.+ORDER BY.+is_default DESC`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(customerAddressCols).
+						AddRow(int64(2), int64(1), "office", "Jl. Sudirman 1", "10220", -6.2088, 106.8456, "", true, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z").
+						AddRow(int64(1), int64(1), "home", "Jl. Kenanga 5", "12430", nil, nil, "gate code 12", false, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantCustomer: &model.Customer{
+				ID: 1, Name: "Budi", Email: "budi@example.com", Phone: "0812", DietaryNotes: "no peanut", MarketingConsent: true,
+				CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+				Addresses: []*model.CustomerAddress{
+					{ID: 2, CustomerID: 1, Label: "office", Address: "Jl. Sudirman 1", PostalCode: "10220",
+						Latitude: sql.NullFloat64{Float64: -6.2088, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.8456, Valid: true},
+						IsDefault: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z"},
+					{ID: 1, CustomerID: 1, Label: "home", Address: "Jl. Kenanga 5", PostalCode: "12430", Notes: "gate code 12", CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z"},
+				},
+			},
+		},
+		{
+			name: "success GetByID (without address)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer.+WHERE.+id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(customerCols).
+						AddRow(int64(1), "", "budi@example.com", "", "", false, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer_address`).WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows(customerAddressCols))
+			},
+			wantCustomer: &model.Customer{
+				ID: 1, Email: "budi@example.com", CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+				Addresses: []*model.CustomerAddress{},
+			},
+		},
+		{
+			name: "fail GetByID (no row)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer.+WHERE.+id = \$1`).WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows(customerCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail GetByID (addresses db error)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer.+WHERE.+id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(customerCols).
+						AddRow(int64(1), "", "budi@example.com", "", "", false, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer_address`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotCustomer, errNoRow, err := tt.repo.GetByID(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCustomer, gotCustomer)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_customerRepository_List(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		businessID    int64
+		limit, offset int
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *customerRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantCustomers []*model.Customer
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success List",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, limit: 10},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer.+LIMIT \$1 OFFSET \$2`).WithArgs(10, 0, int64(1)).
+					WillReturnRows(sqlmock.NewRows(customerCols).
+						AddRow(int64(1), "Budi", "budi@example.com", "", "", false, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantCustomers: []*model.Customer{{ID: 1, Name: "Budi", Email: "budi@example.com", CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z"}},
+		},
+		{
+			name: "fail List (no row)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, limit: 10},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer`).WithArgs(10, 0, int64(1)).WillReturnRows(sqlmock.NewRows(customerCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail List (db error)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, limit: 10},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+customer`).WithArgs(10, 0, int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotCustomers, errNoRow, err := tt.repo.List(tt.args.ctx, tt.args.businessID, tt.args.limit, tt.args.offset)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCustomers, gotCustomers)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_customerRepository_FindOrCreate(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		customer   model.Customer
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *customerRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantID       int64
+		wantErr      bool
+	}{
+		{
+			name: "success FindOrCreate",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: model.Customer{Name: "Budi", Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO customer.+ON CONFLICT \(business_id, email\) DO UPDATE.+RETURNING id`).
+					WithArgs("Budi", "budi@example.com", "", "", false, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+			},
+			wantID: 3,
+		},
+		{
+			name: "fail FindOrCreate",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: model.Customer{Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO customer`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotID, err := tt.repo.FindOrCreate(tt.args.ctx, tt.args.businessID, tt.args.customer)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_customerRepository_Delete(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *customerRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success Delete",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM customer.+WHERE id = \$1 AND business_id = \$2 AND NOT EXISTS`).WithArgs(int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Delete (not found or has ordered)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM customer`).WithArgs(int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Delete (db error)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM customer`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.Delete(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_customerRepository_CreateAddress(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		address    model.CustomerAddress
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *customerRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantID       int64
+		wantErr      bool
+	}{
+		{
+			name: "success CreateAddress",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, address: model.CustomerAddress{CustomerID: 1, Label: "home", Address: "Jl. Kenanga 5"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`INSERT INTO customer_address.+RETURNING id`).
+					WithArgs(int64(1), "home", "Jl. Kenanga 5", "", nil, nil, "", false, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.pgMock.ExpectCommit()
+			},
+			wantID: 2,
+		},
+		{
+			name: "success CreateAddress (default address)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, address: model.CustomerAddress{CustomerID: 1, Address: "Jl. Sudirman 1", IsDefault: true}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE customer_address SET is_default = FALSE`).WithArgs(int64(1), 0, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectQuery(`INSERT INTO customer_address`).
+					WithArgs(int64(1), "", "Jl. Sudirman 1", "", nil, nil, "", true, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+				m.pgMock.ExpectCommit()
+			},
+			wantID: 3,
+		},
+		{
+			name: "fail CreateAddress (unknown customer)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, address: model.CustomerAddress{CustomerID: 1, Address: "Jl. Kenanga 5"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`INSERT INTO customer_address`).WillReturnError(errors.New("oops! foreign key violation"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotID, err := tt.repo.CreateAddress(tt.args.ctx, tt.args.businessID, tt.args.address)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_customerRepository_UpdateAddress(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		address    model.CustomerAddress
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *customerRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success UpdateAddress (default address)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, address: model.CustomerAddress{ID: 2, CustomerID: 1, Address: "Jl. Kenanga 5", IsDefault: true}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE customer_address SET is_default = FALSE`).WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`UPDATE.+customer_address.+SET.+WHERE.+customer_id = \$1 AND id = \$2`).
+					WithArgs(int64(1), int64(2), "", "Jl. Kenanga 5", "", nil, nil, "", true, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectCommit()
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail UpdateAddress (no row)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, address: model.CustomerAddress{ID: 2, CustomerID: 1, Address: "Jl. Kenanga 5"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE.+customer_address.+SET`).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail UpdateAddress (db error)",
+			repo: &customerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, address: model.CustomerAddress{ID: 2, CustomerID: 1, Address: "Jl. Kenanga 5"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin().WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.UpdateAddress(tt.args.ctx, tt.args.businessID, tt.args.address)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+type DeliveryZoneRepository interface {
+	GetByID(ctx context.Context, businessID, id int64) (zone *model.DeliveryZone, errNoRow error, err error)
+	List(ctx context.Context, businessID int64, limit, offset int) (zones []*model.DeliveryZone, errNoRow error, err error)
+	// ListActive return every active zone ordered by id, errNoRow is returned when there is none
+	ListActive(ctx context.Context, businessID int64) (zones []*model.DeliveryZone, errNoRow error, err error)
+	Create(ctx context.Context, businessID int64, zone model.DeliveryZone) (id int64, err error)
+	Update(ctx context.Context, businessID int64, zone model.DeliveryZone) (nAffected int64, errNoRow error, err error)
+	Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error)
+}
+
+type deliveryZoneRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewDeliveryZoneRepository(postgres postgres.PostgresClient) DeliveryZoneRepository {
+	return &deliveryZoneRepository{postgres: postgres}
+}
+
+// scanDeliveryZone scan a row selected by the delivery zone's queries (see getDeliveryZoneByID)
+func scanDeliveryZone(row rowScanner, zone *model.DeliveryZone) error {
+	return row.Scan(
+		&zone.ID,
+		&zone.Name,
+		&zone.PostalCodes,
+		&zone.Polygon,
+		&zone.Fee,
+		&zone.MinOrder,
+		&zone.Active,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+}
+
+func (repo *deliveryZoneRepository) GetByID(ctx context.Context, businessID, id int64) (zone *model.DeliveryZone, errNoRow error, err error) {
+	zone = &model.DeliveryZone{}
+	err = scanDeliveryZone(repo.postgres.QueryRowContext(ctx, getDeliveryZoneByID, id, businessID), zone)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.deliveryZoneRepository.GetByID: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.deliveryZoneRepository.GetByID: %w", err)
+		return nil, nil, err
+	}
+
+	return zone, nil, nil
+}
+
+func (repo *deliveryZoneRepository) List(ctx context.Context, businessID int64, limit, offset int) (zones []*model.DeliveryZone, errNoRow error, err error) {
+	zones, errNoRow, err = repo.list(ctx, listDeliveryZone, limit, offset, businessID)
+	if errNoRow != nil {
+		return nil, fmt.Errorf("repository.deliveryZoneRepository.List: %w", errNoRow), nil
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository.deliveryZoneRepository.List: %w", err)
+	}
+
+	return zones, nil, nil
+}
+
+func (repo *deliveryZoneRepository) ListActive(ctx context.Context, businessID int64) (zones []*model.DeliveryZone, errNoRow error, err error) {
+	zones, errNoRow, err = repo.list(ctx, listActiveDeliveryZone, businessID)
+	if errNoRow != nil {
+		return nil, fmt.Errorf("repository.deliveryZoneRepository.ListActive: %w", errNoRow), nil
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository.deliveryZoneRepository.ListActive: %w", err)
+	}
+
+	return zones, nil, nil
+}
+
+func (repo *deliveryZoneRepository) list(ctx context.Context, query string, args ...interface{}) (zones []*model.DeliveryZone, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		zone := new(model.DeliveryZone)
+		err = scanDeliveryZone(rows, zone)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		zones = append(zones, zone)
+	}
+
+	err = rows.Err()
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(zones) == 0 {
+		return nil, sql.ErrNoRows, nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return zones, nil, rows.Close()
+}
+
+func (repo *deliveryZoneRepository) Create(ctx context.Context, businessID int64, zone model.DeliveryZone) (id int64, err error) {
+	err = repo.postgres.QueryRowContext(ctx, createDeliveryZone,
+		zone.Name, zone.PostalCodes, zone.Polygon.String, zone.Fee, zone.MinOrder, zone.Active, businessID).
+		Scan(&id)
+	if err != nil {
+		err = fmt.Errorf("repository.deliveryZoneRepository.Create: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Update replace every field of the zone, NULL Polygon matches the zone by its postal codes only
+func (repo *deliveryZoneRepository) Update(ctx context.Context, businessID int64, zone model.DeliveryZone) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateDeliveryZoneByID, zone.ID,
+		zone.Name, zone.PostalCodes, zone.Polygon.String, zone.Fee, zone.MinOrder, zone.Active, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.deliveryZoneRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.deliveryZoneRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.deliveryZoneRepository.Update: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+func (repo *deliveryZoneRepository) Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteDeliveryZoneByID, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.deliveryZoneRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.deliveryZoneRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.deliveryZoneRepository.Delete: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
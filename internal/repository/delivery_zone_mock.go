@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\delivery_zone.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeliveryZoneRepository is a mock of DeliveryZoneRepository interface.
+type MockDeliveryZoneRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryZoneRepositoryMockRecorder
+}
+
+// MockDeliveryZoneRepositoryMockRecorder is the mock recorder for MockDeliveryZoneRepository.
+type MockDeliveryZoneRepositoryMockRecorder struct {
+	mock *MockDeliveryZoneRepository
+}
+
+// NewMockDeliveryZoneRepository creates a new mock instance.
+func NewMockDeliveryZoneRepository(ctrl *gomock.Controller) *MockDeliveryZoneRepository {
+	mock := &MockDeliveryZoneRepository{ctrl: ctrl}
+	mock.recorder = &MockDeliveryZoneRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryZoneRepository) EXPECT() *MockDeliveryZoneRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDeliveryZoneRepository) Create(ctx context.Context, businessID int64, zone model.DeliveryZone) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, businessID, zone)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryZoneRepositoryMockRecorder) Create(ctx, businessID, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryZoneRepository)(nil).Create), ctx, businessID, zone)
+}
+
+// Delete mocks base method.
+func (m *MockDeliveryZoneRepository) Delete(ctx context.Context, businessID, id int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, businessID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeliveryZoneRepositoryMockRecorder) Delete(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeliveryZoneRepository)(nil).Delete), ctx, businessID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockDeliveryZoneRepository) GetByID(ctx context.Context, businessID, id int64) (*model.DeliveryZone, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, businessID, id)
+	ret0, _ := ret[0].(*model.DeliveryZone)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDeliveryZoneRepositoryMockRecorder) GetByID(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeliveryZoneRepository)(nil).GetByID), ctx, businessID, id)
+}
+
+// List mocks base method.
+func (m *MockDeliveryZoneRepository) List(ctx context.Context, businessID int64, limit, offset int) ([]*model.DeliveryZone, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, businessID, limit, offset)
+	ret0, _ := ret[0].([]*model.DeliveryZone)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeliveryZoneRepositoryMockRecorder) List(ctx, businessID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeliveryZoneRepository)(nil).List), ctx, businessID, limit, offset)
+}
+
+// ListActive mocks base method.
+func (m *MockDeliveryZoneRepository) ListActive(ctx context.Context, businessID int64) ([]*model.DeliveryZone, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, businessID)
+	ret0, _ := ret[0].([]*model.DeliveryZone)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockDeliveryZoneRepositoryMockRecorder) ListActive(ctx, businessID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockDeliveryZoneRepository)(nil).ListActive), ctx, businessID)
+}
+
+// Update mocks base method.
+func (m *MockDeliveryZoneRepository) Update(ctx context.Context, businessID int64, zone model.DeliveryZone) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, businessID, zone)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeliveryZoneRepositoryMockRecorder) Update(ctx, businessID, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeliveryZoneRepository)(nil).Update), ctx, businessID, zone)
+}
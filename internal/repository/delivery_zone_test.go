@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/money"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var deliveryZoneCols = []string{"id", "name", "postal_codes", "polygon", "fee", "min_order", "active", "created_at", "updated_at"}
+
+func TestNewDeliveryZoneRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewDeliveryZoneRepository"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewDeliveryZoneRepository(tt.args.postgres))
+		})
+	}
+}
+
+func Test_deliveryZoneRepository_GetByID(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *deliveryZoneRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantZone     *model.DeliveryZone
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success GetByID",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+delivery_zone.+WHERE.+id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(deliveryZoneCols).
+						AddRow(int64(1), "Central Jakarta", "10110,10220", nil, int64(1_000_000), int64(5_000_000), true,
+							"2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantZone: &model.DeliveryZone{
+				ID: 1, Name: "Central Jakarta", PostalCodes: "10110,10220", Fee: money.MustParse("10000"), MinOrder: money.MustParse("50000"),
+				Active: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+			},
+		},
+		{
+			name: "fail GetByID (no row)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+delivery_zone`).WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows(deliveryZoneCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail GetByID (db error)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+delivery_zone`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotZone, errNoRow, err := tt.repo.GetByID(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantZone, gotZone)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_deliveryZoneRepository_ListActive(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *deliveryZoneRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantZones    []*model.DeliveryZone
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success ListActive",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+delivery_zone.+WHERE.+active.+ORDER BY id`).
+					WillReturnRows(sqlmock.NewRows(deliveryZoneCols).
+						AddRow(int64(2), "South Jakarta", "", "[[-6.2, 106.8], [-6.3, 106.8], [-6.3, 106.9]]", int64(1_500_000), int64(0), true,
+							"2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantZones: []*model.DeliveryZone{{
+				ID: 2, Name: "South Jakarta", Polygon: sql.NullString{String: "[[-6.2, 106.8], [-6.3, 106.8], [-6.3, 106.9]]", Valid: true},
+				Fee: money.MustParse("15000"), MinOrder: money.FromMinor(0), Active: true,
+				CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+			}},
+		},
+		{
+			name: "fail ListActive (no row)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+delivery_zone`).WillReturnRows(sqlmock.NewRows(deliveryZoneCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail ListActive (db error)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+delivery_zone`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotZones, errNoRow, err := tt.repo.ListActive(tt.args.ctx, tt.args.businessID)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantZones, gotZones)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_deliveryZoneRepository_Create(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		zone       model.DeliveryZone
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *deliveryZoneRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantID       int64
+		wantErr      bool
+	}{
+		{
+			name: "success Create",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, zone: model.DeliveryZone{
+				Name: "Central Jakarta", PostalCodes: "10110", Fee: money.MustParse("10000"), Active: true,
+			}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO delivery_zone.+NULLIF\(\$3, ''\)::JSONB.+RETURNING id`).
+					WithArgs("Central Jakarta", "10110", "", int64(1_000_000), int64(0), true, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+			},
+			wantID: 1,
+		},
+		{
+			name: "fail Create (db error)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, zone: model.DeliveryZone{Name: "Central Jakarta", PostalCodes: "10110"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO delivery_zone`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotID, err := tt.repo.Create(tt.args.ctx, tt.args.businessID, tt.args.zone)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_deliveryZoneRepository_Update(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		zone       model.DeliveryZone
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *deliveryZoneRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success Update",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, zone: model.DeliveryZone{
+				ID: 2, Name: "South Jakarta", Polygon: sql.NullString{String: "[[-6.2,106.8],[-6.3,106.8],[-6.3,106.9]]", Valid: true},
+			}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE.+delivery_zone.+SET.+WHERE.+id = \$1`).
+					WithArgs(int64(2), "South Jakarta", "", "[[-6.2,106.8],[-6.3,106.8],[-6.3,106.9]]", int64(0), int64(0), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Update (no row)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, zone: model.DeliveryZone{ID: 2}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE.+delivery_zone`).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Update (db error)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, zone: model.DeliveryZone{ID: 2}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE.+delivery_zone`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.businessID, tt.args.zone)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_deliveryZoneRepository_Delete(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *deliveryZoneRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success Delete",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM delivery_zone WHERE id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Delete (no row)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM delivery_zone`).WithArgs(int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Delete (db error)",
+			repo: &deliveryZoneRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM delivery_zone`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.Delete(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type DriverRepository interface {
+	GetByID(ctx context.Context, businessID, id int64) (driver *model.Driver, errNoRow error, err error)
+	List(ctx context.Context, businessID int64, limit, offset int) (drivers []*model.Driver, errNoRow error, err error)
+	Create(ctx context.Context, businessID int64, driver model.Driver) (id int64, err error)
+	Update(ctx context.Context, businessID int64, driver model.Driver) (nAffected int64, errNoRow error, err error)
+	// Delete delete the driver along with its undelivered stops, errNoRow is returned when the driver
+	// is not found or has delivered orders
+	Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error)
+	// Assign assign every order to the driver at once, ErrOrderNotAssignable is returned when an order is not
+	// found, is delivered on another date, has been cancelled, delivered or refunded, or has been delivered by another driver
+	Assign(ctx context.Context, businessID, driverID int64, date string, orderIDs []int64) (nAffected int64, err error)
+	// Unassign remove the undelivered stop of the driver, errNoRow is returned when there is none
+	Unassign(ctx context.Context, businessID, driverID, orderID int64) (nAffected int64, errNoRow error, err error)
+	// ListStops return the stops of the driver on the date ordered by order's id, errNoRow is returned when there is none
+	ListStops(ctx context.Context, businessID, driverID int64, date string) (stops []*model.DeliveryStop, errNoRow error, err error)
+	GetStop(ctx context.Context, businessID, driverID, orderID int64) (stop *model.DeliveryStop, errNoRow error, err error)
+	// MarkDelivered record the stop as delivered now and move its order from status `from` to DELIVERED at once,
+	// errNoRow is returned when the stop has been delivered or the order isn't at status `from` anymore
+	MarkDelivered(ctx context.Context, businessID, driverID, orderID int64, from int, changedBy int64) (deliveredAt string, errNoRow error, err error)
+}
+
+type driverRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewDriverRepository(postgres postgres.PostgresClient) DriverRepository {
+	return &driverRepository{postgres: postgres}
+}
+
+func (repo *driverRepository) GetByID(ctx context.Context, businessID, id int64) (driver *model.Driver, errNoRow error, err error) {
+	driver = &model.Driver{}
+	err = repo.postgres.QueryRowContext(ctx, getDriverByID, id, businessID).Scan(
+		&driver.ID,
+		&driver.Name,
+		&driver.Phone,
+		&driver.Active,
+		&driver.CreatedAt,
+		&driver.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.driverRepository.GetByID: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.GetByID: %w", err)
+		return nil, nil, err
+	}
+
+	return driver, nil, nil
+}
+
+func (repo *driverRepository) List(ctx context.Context, businessID int64, limit, offset int) (drivers []*model.Driver, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listDriver, limit, offset, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.List: %w", err)
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		driver := new(model.Driver)
+		err = rows.Scan(
+			&driver.ID,
+			&driver.Name,
+			&driver.Phone,
+			&driver.Active,
+			&driver.CreatedAt,
+			&driver.UpdatedAt,
+		)
+		if err != nil {
+			err = fmt.Errorf("repository.driverRepository.List: %w", err)
+			return nil, nil, err
+		}
+
+		drivers = append(drivers, driver)
+	}
+
+	err = rows.Err()
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(drivers) == 0 {
+		err = fmt.Errorf("repository.driverRepository.List: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.List: %w", err)
+		return nil, nil, err
+	}
+
+	return drivers, nil, rows.Close()
+}
+
+func (repo *driverRepository) Create(ctx context.Context, businessID int64, driver model.Driver) (id int64, err error) {
+	err = repo.postgres.QueryRowContext(ctx, createDriver, driver.Name, driver.Phone, driver.Active, businessID).Scan(&id)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Create: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo *driverRepository) Update(ctx context.Context, businessID int64, driver model.Driver) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateDriverByID, driver.ID, driver.Name, driver.Phone, driver.Active, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.driverRepository.Update: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+func (repo *driverRepository) Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteDriverByID, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.driverRepository.Delete: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+func (repo *driverRepository) Assign(ctx context.Context, businessID, driverID int64, date string, orderIDs []int64) (nAffected int64, err error) {
+	seen := make(map[int64]bool, len(orderIDs))
+	ids := make([]string, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Assign: %w", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, assignDelivery, driverID, date, strings.Join(ids, ","), businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Assign: %w", err)
+		return 0, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Assign: %w", err)
+		return 0, err
+	}
+
+	// every order is assigned or none
+	if nAffected != int64(len(ids)) {
+		err = fmt.Errorf("repository.driverRepository.Assign: %d of %d orders are assignable: %w", nAffected, len(ids), ErrOrderNotAssignable)
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Assign: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+func (repo *driverRepository) Unassign(ctx context.Context, businessID, driverID, orderID int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, unassignDelivery, driverID, orderID, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Unassign: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.Unassign: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.driverRepository.Unassign: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+// scanDeliveryStop scan a row selected by the delivery stop's queries (see getDeliveryStop)
+func scanDeliveryStop(row rowScanner, stop *model.DeliveryStop) error {
+	return row.Scan(
+		&stop.OrderID,
+		&stop.DriverID,
+		&stop.DeliveryDate,
+		&stop.CustomerEmail,
+		&stop.Address,
+		&stop.PostalCode,
+		&stop.Latitude,
+		&stop.Longitude,
+		&stop.DeliveryStart,
+		&stop.DeliveryEnd,
+		&stop.Status,
+		&stop.DeliveredAt,
+	)
+}
+
+func (repo *driverRepository) ListStops(ctx context.Context, businessID, driverID int64, date string) (stops []*model.DeliveryStop, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listDeliveryStops, driverID, date, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.ListStops: %w", err)
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		stop := new(model.DeliveryStop)
+		err = scanDeliveryStop(rows, stop)
+		if err != nil {
+			err = fmt.Errorf("repository.driverRepository.ListStops: %w", err)
+			return nil, nil, err
+		}
+
+		stops = append(stops, stop)
+	}
+
+	err = rows.Err()
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(stops) == 0 {
+		err = fmt.Errorf("repository.driverRepository.ListStops: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.ListStops: %w", err)
+		return nil, nil, err
+	}
+
+	return stops, nil, rows.Close()
+}
+
+func (repo *driverRepository) GetStop(ctx context.Context, businessID, driverID, orderID int64) (stop *model.DeliveryStop, errNoRow error, err error) {
+	stop = &model.DeliveryStop{}
+	err = scanDeliveryStop(repo.postgres.QueryRowContext(ctx, getDeliveryStop, driverID, orderID, businessID), stop)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.driverRepository.GetStop: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.GetStop: %w", err)
+		return nil, nil, err
+	}
+
+	return stop, nil, nil
+}
+
+func (repo *driverRepository) MarkDelivered(ctx context.Context, businessID, driverID, orderID int64, from int, changedBy int64) (deliveredAt string, errNoRow error, err error) {
+	err = repo.postgres.QueryRowContext(ctx, markDeliveryDelivered, driverID, orderID, from, changedBy, businessID).Scan(&deliveredAt)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.driverRepository.MarkDelivered: %w", err)
+		return "", err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.driverRepository.MarkDelivered: %w", err)
+		return "", nil, err
+	}
+
+	return deliveredAt, nil, nil
+}
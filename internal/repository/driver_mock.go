@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\driver.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDriverRepository is a mock of DriverRepository interface.
+type MockDriverRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriverRepositoryMockRecorder
+}
+
+// MockDriverRepositoryMockRecorder is the mock recorder for MockDriverRepository.
+type MockDriverRepositoryMockRecorder struct {
+	mock *MockDriverRepository
+}
+
+// NewMockDriverRepository creates a new mock instance.
+func NewMockDriverRepository(ctrl *gomock.Controller) *MockDriverRepository {
+	mock := &MockDriverRepository{ctrl: ctrl}
+	mock.recorder = &MockDriverRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriverRepository) EXPECT() *MockDriverRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockDriverRepository) Assign(ctx context.Context, businessID, driverID int64, date string, orderIDs []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, businessID, driverID, date, orderIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockDriverRepositoryMockRecorder) Assign(ctx, businessID, driverID, date, orderIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockDriverRepository)(nil).Assign), ctx, businessID, driverID, date, orderIDs)
+}
+
+// Create mocks base method.
+func (m *MockDriverRepository) Create(ctx context.Context, businessID int64, driver model.Driver) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, businessID, driver)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDriverRepositoryMockRecorder) Create(ctx, businessID, driver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDriverRepository)(nil).Create), ctx, businessID, driver)
+}
+
+// Delete mocks base method.
+func (m *MockDriverRepository) Delete(ctx context.Context, businessID, id int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, businessID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDriverRepositoryMockRecorder) Delete(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDriverRepository)(nil).Delete), ctx, businessID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockDriverRepository) GetByID(ctx context.Context, businessID, id int64) (*model.Driver, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, businessID, id)
+	ret0, _ := ret[0].(*model.Driver)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDriverRepositoryMockRecorder) GetByID(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDriverRepository)(nil).GetByID), ctx, businessID, id)
+}
+
+// GetStop mocks base method.
+func (m *MockDriverRepository) GetStop(ctx context.Context, businessID, driverID, orderID int64) (*model.DeliveryStop, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStop", ctx, businessID, driverID, orderID)
+	ret0, _ := ret[0].(*model.DeliveryStop)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetStop indicates an expected call of GetStop.
+func (mr *MockDriverRepositoryMockRecorder) GetStop(ctx, businessID, driverID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStop", reflect.TypeOf((*MockDriverRepository)(nil).GetStop), ctx, businessID, driverID, orderID)
+}
+
+// List mocks base method.
+func (m *MockDriverRepository) List(ctx context.Context, businessID int64, limit, offset int) ([]*model.Driver, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, businessID, limit, offset)
+	ret0, _ := ret[0].([]*model.Driver)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDriverRepositoryMockRecorder) List(ctx, businessID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDriverRepository)(nil).List), ctx, businessID, limit, offset)
+}
+
+// ListStops mocks base method.
+func (m *MockDriverRepository) ListStops(ctx context.Context, businessID, driverID int64, date string) ([]*model.DeliveryStop, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStops", ctx, businessID, driverID, date)
+	ret0, _ := ret[0].([]*model.DeliveryStop)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListStops indicates an expected call of ListStops.
+func (mr *MockDriverRepositoryMockRecorder) ListStops(ctx, businessID, driverID, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStops", reflect.TypeOf((*MockDriverRepository)(nil).ListStops), ctx, businessID, driverID, date)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockDriverRepository) MarkDelivered(ctx context.Context, businessID, driverID, orderID int64, from int, changedBy int64) (string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, businessID, driverID, orderID, from, changedBy)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockDriverRepositoryMockRecorder) MarkDelivered(ctx, businessID, driverID, orderID, from, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockDriverRepository)(nil).MarkDelivered), ctx, businessID, driverID, orderID, from, changedBy)
+}
+
+// Unassign mocks base method.
+func (m *MockDriverRepository) Unassign(ctx context.Context, businessID, driverID, orderID int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unassign", ctx, businessID, driverID, orderID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Unassign indicates an expected call of Unassign.
+func (mr *MockDriverRepositoryMockRecorder) Unassign(ctx, businessID, driverID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockDriverRepository)(nil).Unassign), ctx, businessID, driverID, orderID)
+}
+
+// Update mocks base method.
+func (m *MockDriverRepository) Update(ctx context.Context, businessID int64, driver model.Driver) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, businessID, driver)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDriverRepositoryMockRecorder) Update(ctx, businessID, driver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDriverRepository)(nil).Update), ctx, businessID, driver)
+}
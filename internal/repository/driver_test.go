@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	driverCols       = []string{"id", "name", "phone", "active", "created_at", "updated_at"}
+	deliveryStopCols = []string{"order_id", "driver_id", "delivery_date", "customer_email", "delivery_address", "delivery_postal_code",
+		"delivery_latitude", "delivery_longitude", "delivery_start", "delivery_end", "status", "delivered_at"}
+)
+
+func TestNewDriverRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewDriverRepository"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewDriverRepository(tt.args.postgres))
+		})
+	}
+}
+
+func Test_driverRepository_GetByID(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *driverRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantDriver   *model.Driver
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success GetByID",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+driver.+WHERE.+id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(driverCols).
+						AddRow(int64(1), "Joko", "08123456789", true, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantDriver: &model.Driver{
+				ID: 1, Name: "Joko", Phone: "08123456789", Active: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+			},
+		},
+		{
+			name: "fail GetByID (no row)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+driver`).WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows(driverCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail GetByID (db error)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+driver`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotDriver, errNoRow, err := tt.repo.GetByID(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantDriver, gotDriver)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_driverRepository_Delete(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *driverRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success Delete",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM driver.+NOT EXISTS.+delivery_assignment.+delivered_at IS NOT NULL`).WithArgs(int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Delete (not found or has delivered orders)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM driver`).WithArgs(int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.Delete(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_driverRepository_Assign(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		driverID   int64
+		date       string
+		orderIDs   []int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *driverRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrIs     error
+		wantErr       bool
+	}{
+		{
+			name: "success Assign (duplicated order's id)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, date: "2022-11-11", orderIDs: []int64{3, 1, 3}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`INSERT INTO delivery_assignment.+SELECT DISTINCT.+ON CONFLICT \(order_id\) DO UPDATE.+delivered_at IS NULL`).
+					WithArgs(int64(1), "2022-11-11", "3,1", int64(1)).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.pgMock.ExpectCommit()
+			},
+			wantNAffected: 2,
+		},
+		{
+			name: "fail Assign (an order isn't assignable)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, date: "2022-11-11", orderIDs: []int64{1, 2}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`INSERT INTO delivery_assignment`).
+					WithArgs(int64(1), "2022-11-11", "1,2", int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrIs: ErrOrderNotAssignable,
+			wantErr:   true,
+		},
+		{
+			name: "fail Assign (db error)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, date: "2022-11-11", orderIDs: []int64{1}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`INSERT INTO delivery_assignment`).WillReturnError(errors.New("oops! db error"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, err := tt.repo.Assign(tt.args.ctx, tt.args.businessID, tt.args.driverID, tt.args.date, tt.args.orderIDs)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_driverRepository_ListStops(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		driverID   int64
+		date       string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *driverRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantStops    []*model.DeliveryStop
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success ListStops",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT DISTINCT ON \(a.order_id\).+FROM.+delivery_assignment a.+JOIN.+"order" o.+WHERE.+a.driver_id = \$1 AND a.delivery_date = \$2::DATE`).
+					WithArgs(int64(1), "2022-11-11", int64(1)).
+					WillReturnRows(sqlmock.NewRows(deliveryStopCols).
+						AddRow(int64(1), int64(1), "2022-11-11", "budi@example.com", "Jl. Sudirman 1", "10220", -6.22, 106.82, "11:00", "13:00", 7, nil).
+						AddRow(int64(2), int64(1), "2022-11-11", "test@example.com", "", "", nil, nil, "", "", 8, "2022-11-11T11:30:00Z"))
+			},
+			wantStops: []*model.DeliveryStop{
+				{
+					OrderID: 1, DriverID: 1, DeliveryDate: "2022-11-11", CustomerEmail: "budi@example.com", Address: "Jl. Sudirman 1", PostalCode: "10220",
+					Latitude: sql.NullFloat64{Float64: -6.22, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.82, Valid: true},
+					DeliveryStart: "11:00", DeliveryEnd: "13:00", Status: 7,
+				},
+				{
+					OrderID: 2, DriverID: 1, DeliveryDate: "2022-11-11", CustomerEmail: "test@example.com", Status: 8,
+					DeliveredAt: sql.NullString{String: "2022-11-11T11:30:00Z", Valid: true},
+				},
+			},
+		},
+		{
+			name: "fail ListStops (no row)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT DISTINCT ON \(a.order_id\)`).WithArgs(int64(1), "2022-11-11", int64(1)).WillReturnRows(sqlmock.NewRows(deliveryStopCols))
+			},
+			wantErrNoRow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotStops, errNoRow, err := tt.repo.ListStops(tt.args.ctx, tt.args.businessID, tt.args.driverID, tt.args.date)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantStops, gotStops)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_driverRepository_MarkDelivered(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		driverID   int64
+		orderID    int64
+		from       int
+		changedBy  int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name            string
+		repo            *driverRepository
+		args            args
+		prepareMocks    func(*mocks)
+		wantDeliveredAt string
+		wantErrNoRow    bool
+		wantErr         bool
+	}{
+		{
+			name: "success MarkDelivered",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, orderID: 2, from: 7, changedBy: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH delivered AS.+UPDATE delivery_assignment SET delivered_at = NOW\(\).+UPDATE "order".+INSERT INTO order_status_history.+SELECT delivered_at FROM delivered`).
+					WithArgs(int64(1), int64(2), 7, int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"delivered_at"}).AddRow("2022-11-11T11:30:00Z"))
+			},
+			wantDeliveredAt: "2022-11-11T11:30:00Z",
+		},
+		{
+			name: "fail MarkDelivered (delivered or moved in the meantime)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, orderID: 2, from: 7, changedBy: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH delivered AS`).WithArgs(int64(1), int64(2), 7, int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"delivered_at"}))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail MarkDelivered (db error)",
+			repo: &driverRepository{},
+			args: args{ctx: context.Background(), businessID: 1, driverID: 1, orderID: 2, from: 7, changedBy: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH delivered AS`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotDeliveredAt, errNoRow, err := tt.repo.MarkDelivered(tt.args.ctx, tt.args.businessID, tt.args.driverID, tt.args.orderID, tt.args.from, tt.args.changedBy)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantDeliveredAt, gotDeliveredAt)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+type EmailVerificationRepository interface {
+	// Create store the verification link of the email, the owner's pending links are voided
+	Create(ctx context.Context, verification model.OwnerEmailVerification) error
+	// GetByTokenHash return the verification whatever its expiry or use, errNoRow when the token is unknown
+	GetByTokenHash(ctx context.Context, tokenHash string) (verification *model.OwnerEmailVerification, errNoRow error, err error)
+	// Verify use the verification and set its email as the owner's verified email at once, errNoRow when it's used
+	// already
+	Verify(ctx context.Context, id int64) (errNoRow error, err error)
+}
+
+type emailVerificationRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewEmailVerificationRepository(postgres postgres.PostgresClient) EmailVerificationRepository {
+	return &emailVerificationRepository{postgres: postgres}
+}
+
+func (repo *emailVerificationRepository) Create(ctx context.Context, verification model.OwnerEmailVerification) error {
+	_, err := repo.postgres.ExecContext(ctx, createOwnerEmailVerification, verification.OwnerID, verification.Email, verification.TokenHash, verification.ExpiredAt)
+	if err != nil {
+		return fmt.Errorf("repository.emailVerificationRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *emailVerificationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.OwnerEmailVerification, error, error) {
+	verification := model.OwnerEmailVerification{}
+	err := repo.postgres.QueryRowContext(ctx, getOwnerEmailVerification, tokenHash).Scan(
+		&verification.ID,
+		&verification.OwnerID,
+		&verification.Email,
+		&verification.TokenHash,
+		&verification.ExpiredAt,
+		&verification.Used,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("repository.emailVerificationRepository.GetByTokenHash: %w", err), nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository.emailVerificationRepository.GetByTokenHash: %w", err)
+	}
+
+	return &verification, nil, nil
+}
+
+func (repo *emailVerificationRepository) Verify(ctx context.Context, id int64) (error, error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("repository.emailVerificationRepository.Verify: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		ownerID int64
+		email   string
+	)
+	// the link is used once, a concurrent click finds it used
+	err = tx.QueryRowContext(ctx, useOwnerEmailVerification, id).Scan(&ownerID, &email)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("repository.emailVerificationRepository.Verify: %w", err), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository.emailVerificationRepository.Verify: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, verifyOwnerEmail, ownerID, email)
+	if err != nil {
+		return nil, fmt.Errorf("repository.emailVerificationRepository.Verify: %w", err)
+	}
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("repository.emailVerificationRepository.Verify: %w", err)
+	}
+	if nAffected == 0 {
+		return fmt.Errorf("repository.emailVerificationRepository.Verify: %w", sql.ErrNoRows), nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.emailVerificationRepository.Verify: %w", err)
+	}
+
+	return nil, nil
+}
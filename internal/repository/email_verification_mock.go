@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\email_verification.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailVerificationRepository is a mock of EmailVerificationRepository interface.
+type MockEmailVerificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationRepositoryMockRecorder
+}
+
+// MockEmailVerificationRepositoryMockRecorder is the mock recorder for MockEmailVerificationRepository.
+type MockEmailVerificationRepositoryMockRecorder struct {
+	mock *MockEmailVerificationRepository
+}
+
+// NewMockEmailVerificationRepository creates a new mock instance.
+func NewMockEmailVerificationRepository(ctrl *gomock.Controller) *MockEmailVerificationRepository {
+	mock := &MockEmailVerificationRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationRepository) EXPECT() *MockEmailVerificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEmailVerificationRepository) Create(ctx context.Context, verification model.OwnerEmailVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, verification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailVerificationRepositoryMockRecorder) Create(ctx, verification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailVerificationRepository)(nil).Create), ctx, verification)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockEmailVerificationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.OwnerEmailVerification, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*model.OwnerEmailVerification)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockEmailVerificationRepositoryMockRecorder) GetByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockEmailVerificationRepository)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// Verify mocks base method.
+func (m *MockEmailVerificationRepository) Verify(ctx context.Context, id int64) (error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, id)
+	ret0, _ := ret[0].(error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockEmailVerificationRepositoryMockRecorder) Verify(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockEmailVerificationRepository)(nil).Verify), ctx, id)
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEmailVerificationRepository(t *testing.T) {
+	assert.NotNil(t, NewEmailVerificationRepository(nil))
+}
+
+func Test_emailVerificationRepository_Create(t *testing.T) {
+	expiredAt := time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		prepareMocks func(sqlmock.Sqlmock)
+		wantErr      bool
+	}{
+		{
+			name: "success create email verification",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectExec(`DELETE FROM owner_email_verification WHERE owner_id = \$1 AND used_at IS NULL.*INSERT INTO owner_email_verification`).
+					WithArgs(int64(1), "new@example.com", "token-hash", expiredAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "fail create email verification (error postgres)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectExec(`INSERT INTO owner_email_verification`).WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &emailVerificationRepository{postgres: db}
+			tt.prepareMocks(pgMock)
+
+			err = repo.Create(context.Background(), model.OwnerEmailVerification{OwnerID: 1, Email: "new@example.com", TokenHash: "token-hash", ExpiredAt: expiredAt})
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_emailVerificationRepository_GetByTokenHash(t *testing.T) {
+	expiredAt := time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "owner_id", "email", "token_hash", "expired_at", "used"}
+	tests := []struct {
+		name         string
+		prepareMocks func(sqlmock.Sqlmock)
+		want         *model.OwnerEmailVerification
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success get email verification",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectQuery(`SELECT id, owner_id, email, token_hash, expired_at, used_at IS NOT NULL FROM owner_email_verification WHERE token_hash = \$1`).
+					WithArgs("token-hash").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, "new@example.com", "token-hash", expiredAt, false))
+			},
+			want: &model.OwnerEmailVerification{ID: 3, OwnerID: 1, Email: "new@example.com", TokenHash: "token-hash", ExpiredAt: expiredAt},
+		},
+		{
+			name: "fail get email verification (unknown token)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectQuery(`FROM owner_email_verification`).WithArgs("token-hash").WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail get email verification (error postgres)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectQuery(`FROM owner_email_verification`).WithArgs("token-hash").WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &emailVerificationRepository{postgres: db}
+			tt.prepareMocks(pgMock)
+
+			got, errNoRow, err := repo.GetByTokenHash(context.Background(), "token-hash")
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_emailVerificationRepository_Verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		prepareMocks func(sqlmock.Sqlmock)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success verify email",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectBegin()
+				pgMock.ExpectQuery(`UPDATE owner_email_verification SET used_at = NOW\(\) WHERE id = \$1 AND used_at IS NULL RETURNING owner_id, email`).
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"owner_id", "email"}).AddRow(1, "new@example.com"))
+				pgMock.ExpectExec(`UPDATE owner SET email = \$2, email_verified_at = NOW\(\) WHERE id = \$1`).
+					WithArgs(int64(1), "new@example.com").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				pgMock.ExpectCommit()
+			},
+		},
+		{
+			name: "fail verify email (used already)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectBegin()
+				pgMock.ExpectQuery(`UPDATE owner_email_verification`).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows([]string{"owner_id", "email"}))
+				pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail verify email (owner deleted)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectBegin()
+				pgMock.ExpectQuery(`UPDATE owner_email_verification`).WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"owner_id", "email"}).AddRow(1, "new@example.com"))
+				pgMock.ExpectExec(`UPDATE owner SET email`).WithArgs(int64(1), "new@example.com").WillReturnResult(sqlmock.NewResult(0, 0))
+				pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail verify email (error postgres)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectBegin()
+				pgMock.ExpectQuery(`UPDATE owner_email_verification`).WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"owner_id", "email"}).AddRow(1, "new@example.com"))
+				pgMock.ExpectExec(`UPDATE owner SET email`).WillReturnError(errors.New("oops! error from postgres"))
+				pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &emailVerificationRepository{postgres: db}
+			tt.prepareMocks(pgMock)
+
+			errNoRow, err := repo.Verify(context.Background(), 3)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
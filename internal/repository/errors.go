@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errors returned by the repositories when a guarded write is refused by the current state of the data,
+// services should check them with errors.Is and map them into apperrors
+var (
+	ErrOrderNotPayable       = errors.New("order is not payable")
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the remaining balance")
+	ErrDuplicatePaymentEvent = errors.New("payment event has been recorded")
+	ErrPromotionUsageLimit   = errors.New("promotion has reached its usage limit")
+	ErrMenuSoldOut           = errors.New("menu is sold out")
+	ErrDeliverySlotFull      = errors.New("delivery slot is full")
+	ErrOrderNotAssignable    = errors.New("order is not assignable")
+)
+
+// MenuSoldOutError is returned when a menu doesn't have enough portions left for an order,
+// it matches ErrMenuSoldOut and could be extracted with errors.As to tell which menu is sold out
+type MenuSoldOutError struct {
+	MenuID    int64
+	Remaining int
+}
+
+func (e *MenuSoldOutError) Error() string {
+	return fmt.Sprintf("menu %d is sold out (%d left)", e.MenuID, e.Remaining)
+}
+
+func (e *MenuSoldOutError) Is(target error) bool {
+	return target == ErrMenuSoldOut
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"family-catering/pkg/db/redis"
+	"fmt"
+	"strings"
+	"time"
+
+	redisV8 "github.com/go-redis/redis/v8"
+)
+
+const (
+	loginFailuresKeyFormat string = "login:failures:%s:%s" // by email or ip
+	loginLockKeyFormat     string = "login:lock:%s:%s"     // by email or ip
+)
+
+type LoginAttemptRepository interface {
+	// RetryAfter return how long the logins of the email or from the ip are refused for, 0 when they aren't
+	RetryAfter(ctx context.Context, email, ip string) (time.Duration, error)
+	// Fail count a failed login of the email from the ip, the failures are forgotten window after the first one. The ip
+	// failures are 0 without ip
+	Fail(ctx context.Context, email, ip string, window time.Duration) (emailFailures, ipFailures int64, err error)
+	// LockEmail refuse the logins of the email for d
+	LockEmail(ctx context.Context, email string, d time.Duration) error
+	// LockIP refuse the logins from the ip for d, whatever the email
+	LockIP(ctx context.Context, ip string, d time.Duration) error
+	// Reset forget the failed logins and the lock of the email
+	Reset(ctx context.Context, email string) error
+}
+
+type loginAttemptRepository struct {
+	redis redis.RedisClient
+}
+
+func NewLoginAttemptRepository(redis redis.RedisClient) LoginAttemptRepository {
+	return &loginAttemptRepository{redis: redis}
+}
+
+// loginEmail the emails differing by case are the same account
+func loginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (repo *loginAttemptRepository) RetryAfter(ctx context.Context, email, ip string) (time.Duration, error) {
+	keys := []string{fmt.Sprintf(loginLockKeyFormat, "email", loginEmail(email))}
+	if ip != "" {
+		keys = append(keys, fmt.Sprintf(loginLockKeyFormat, "ip", ip))
+	}
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		ttl, err := repo.redis.PTTL(ctx, key).Result()
+		if err != nil {
+			return 0, fmt.Errorf("repository.loginAttemptRepository.RetryAfter: %w", err)
+		}
+		// a missing key has a negative ttl
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	return retryAfter, nil
+}
+
+func (repo *loginAttemptRepository) Fail(ctx context.Context, email, ip string, window time.Duration) (emailFailures, ipFailures int64, err error) {
+	emailFailures, err = repo.fail(ctx, fmt.Sprintf(loginFailuresKeyFormat, "email", loginEmail(email)), window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("repository.loginAttemptRepository.Fail: %w", err)
+	}
+	if ip == "" {
+		return emailFailures, 0, nil
+	}
+
+	ipFailures, err = repo.fail(ctx, fmt.Sprintf(loginFailuresKeyFormat, "ip", ip), window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("repository.loginAttemptRepository.Fail: %w", err)
+	}
+
+	return emailFailures, ipFailures, nil
+}
+
+// fail increment the failures of the key, the window starts on its first failure
+func (repo *loginAttemptRepository) fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	failures, err := repo.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if failures == 1 {
+		err = repo.redis.Expire(ctx, key, window).Err()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return failures, nil
+}
+
+func (repo *loginAttemptRepository) LockEmail(ctx context.Context, email string, d time.Duration) error {
+	err := repo.redis.Set(ctx, fmt.Sprintf(loginLockKeyFormat, "email", loginEmail(email)), 1, d).Err()
+	if err != nil {
+		return fmt.Errorf("repository.loginAttemptRepository.LockEmail: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *loginAttemptRepository) LockIP(ctx context.Context, ip string, d time.Duration) error {
+	err := repo.redis.Set(ctx, fmt.Sprintf(loginLockKeyFormat, "ip", ip), 1, d).Err()
+	if err != nil {
+		return fmt.Errorf("repository.loginAttemptRepository.LockIP: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *loginAttemptRepository) Reset(ctx context.Context, email string) error {
+	email = loginEmail(email)
+	_, err := repo.redis.Pipelined(ctx, func(p redisV8.Pipeliner) error {
+		p.Del(ctx, fmt.Sprintf(loginFailuresKeyFormat, "email", email))
+		p.Del(ctx, fmt.Sprintf(loginLockKeyFormat, "email", email))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository.loginAttemptRepository.Reset: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\login_attempt.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAttemptRepository is a mock of LoginAttemptRepository interface.
+type MockLoginAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryMockRecorder
+}
+
+// MockLoginAttemptRepositoryMockRecorder is the mock recorder for MockLoginAttemptRepository.
+type MockLoginAttemptRepositoryMockRecorder struct {
+	mock *MockLoginAttemptRepository
+}
+
+// NewMockLoginAttemptRepository creates a new mock instance.
+func NewMockLoginAttemptRepository(ctrl *gomock.Controller) *MockLoginAttemptRepository {
+	mock := &MockLoginAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepository) EXPECT() *MockLoginAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Fail mocks base method.
+func (m *MockLoginAttemptRepository) Fail(ctx context.Context, email, ip string, window time.Duration) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, email, ip, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginAttemptRepositoryMockRecorder) Fail(ctx, email, ip, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginAttemptRepository)(nil).Fail), ctx, email, ip, window)
+}
+
+// LockEmail mocks base method.
+func (m *MockLoginAttemptRepository) LockEmail(ctx context.Context, email string, d time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockEmail", ctx, email, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockEmail indicates an expected call of LockEmail.
+func (mr *MockLoginAttemptRepositoryMockRecorder) LockEmail(ctx, email, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockEmail", reflect.TypeOf((*MockLoginAttemptRepository)(nil).LockEmail), ctx, email, d)
+}
+
+// LockIP mocks base method.
+func (m *MockLoginAttemptRepository) LockIP(ctx context.Context, ip string, d time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockIP", ctx, ip, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockIP indicates an expected call of LockIP.
+func (mr *MockLoginAttemptRepositoryMockRecorder) LockIP(ctx, ip, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockIP", reflect.TypeOf((*MockLoginAttemptRepository)(nil).LockIP), ctx, ip, d)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttemptRepository) Reset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptRepositoryMockRecorder) Reset(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttemptRepository)(nil).Reset), ctx, email)
+}
+
+// RetryAfter mocks base method.
+func (m *MockLoginAttemptRepository) RetryAfter(ctx context.Context, email, ip string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryAfter", ctx, email, ip)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryAfter indicates an expected call of RetryAfter.
+func (mr *MockLoginAttemptRepositoryMockRecorder) RetryAfter(ctx, email, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryAfter", reflect.TypeOf((*MockLoginAttemptRepository)(nil).RetryAfter), ctx, email, ip)
+}
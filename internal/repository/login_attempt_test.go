@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"family-catering/pkg/db/redis"
+	"testing"
+	"time"
+
+	redisV8 "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewLoginAttemptRepository(t *testing.T) {
+	assert.NotNil(t, NewLoginAttemptRepository(nil))
+}
+
+func Test_loginAttemptRepository_Fail(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	repo := &loginAttemptRepository{redis: redisMock}
+	ctx := context.Background()
+
+	emailFailures, ipFailures, err := repo.Fail(ctx, "test@example.com", "10.0.0.2", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), emailFailures)
+	assert.Equal(t, int64(1), ipFailures)
+
+	// the email is counted whatever its case, the ip whatever the email
+	emailFailures, ipFailures, err = repo.Fail(ctx, "Test@Example.com", "10.0.0.2", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), emailFailures)
+	assert.Equal(t, int64(2), ipFailures)
+
+	emailFailures, ipFailures, err = repo.Fail(ctx, "another@example.com", "10.0.0.2", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), emailFailures)
+	assert.Equal(t, int64(3), ipFailures)
+
+	// the window starts on the first failure
+	ttl := redisMock.TTL(ctx, "login:failures:email:test@example.com").Val()
+	assert.True(t, ttl > 0 && ttl <= time.Minute, ttl)
+
+	emailFailures, ipFailures, err = repo.Fail(ctx, "test@example.com", "", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), emailFailures)
+	assert.Equal(t, int64(0), ipFailures)
+}
+
+func Test_loginAttemptRepository_Fail_error(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	redisMock.On("Incr", mock.Anything, mock.Anything).Return(redisV8.NewIntResult(0, errors.New("oops! error from redis")))
+	repo := &loginAttemptRepository{redis: redisMock}
+
+	_, _, err = repo.Fail(context.Background(), "test@example.com", "10.0.0.2", time.Minute)
+	assert.Error(t, err)
+}
+
+func Test_loginAttemptRepository_RetryAfter(t *testing.T) {
+	type args struct {
+		email string
+		ip    string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		prepare        func(*loginAttemptRepository)
+		wantRetryAfter time.Duration
+	}{
+		{
+			name: "success retry after (not locked)",
+			args: args{email: "test@example.com", ip: "10.0.0.2"},
+		},
+		{
+			name: "success retry after (email locked)",
+			args: args{email: "TEST@example.com", ip: "10.0.0.2"},
+			prepare: func(repo *loginAttemptRepository) {
+				repo.LockEmail(context.Background(), "test@example.com", time.Minute)
+			},
+			wantRetryAfter: time.Minute,
+		},
+		{
+			name: "success retry after (ip locked for longer)",
+			args: args{email: "test@example.com", ip: "10.0.0.2"},
+			prepare: func(repo *loginAttemptRepository) {
+				repo.LockEmail(context.Background(), "test@example.com", time.Minute)
+				repo.LockIP(context.Background(), "10.0.0.2", time.Hour)
+			},
+			wantRetryAfter: time.Hour,
+		},
+		{
+			name: "success retry after (another email locked)",
+			args: args{email: "test@example.com", ip: "10.0.0.2"},
+			prepare: func(repo *loginAttemptRepository) {
+				repo.LockEmail(context.Background(), "another@example.com", time.Minute)
+			},
+		},
+		{
+			name: "success retry after (reset)",
+			args: args{email: "test@example.com", ip: "10.0.0.2"},
+			prepare: func(repo *loginAttemptRepository) {
+				repo.LockEmail(context.Background(), "test@example.com", time.Minute)
+				repo.Reset(context.Background(), "Test@example.com")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisMock, err := redis.NewMockWithMiniRedisClient(t)
+			if err != nil {
+				panic(err)
+			}
+			repo := &loginAttemptRepository{redis: redisMock}
+			if tt.prepare != nil {
+				tt.prepare(repo)
+			}
+
+			gotRetryAfter, err := repo.RetryAfter(context.Background(), tt.args.email, tt.args.ip)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRetryAfter, gotRetryAfter)
+		})
+	}
+}
+
+func Test_loginAttemptRepository_Reset(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	repo := &loginAttemptRepository{redis: redisMock}
+	ctx := context.Background()
+	repo.Fail(ctx, "test@example.com", "10.0.0.2", time.Minute)
+
+	err = repo.Reset(ctx, "test@example.com")
+	assert.NoError(t, err)
+
+	// the ip failures are kept, they aren't the owner's
+	emailFailures, ipFailures, err := repo.Fail(ctx, "test@example.com", "10.0.0.2", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), emailFailures)
+	assert.Equal(t, int64(2), ipFailures)
+}
@@ -9,13 +9,14 @@ import (
 )
 
 type MenuRepository interface {
-	GetByID(ctx context.Context, id int64) (menu *model.Menu, errNoRow error, err error)
-	GetByName(ctx context.Context, name string) (menu *model.Menu, errNoRow error, err error)
-	List(ctx context.Context, limit, offset int) (menus []*model.Menu, errNoRow error, err error)
-	Update(ctx context.Context, menu model.Menu) (nAffected int64, errNoRow error, err error)
-	Create(ctx context.Context, menu model.Menu) (id int64, err error)
-	Delete(ctx context.Context, id int64) (nAffected int64, errNoRow error, err error)
-	Search(ctx context.Context, menu model.MenuQuery) (menus []*model.Menu, errNoRow error, err error)
+	GetByID(ctx context.Context, businessID, id int64) (menu *model.Menu, errNoRow error, err error)
+	GetByName(ctx context.Context, businessID int64, name string) (menu *model.Menu, errNoRow error, err error)
+	List(ctx context.Context, businessID int64, limit, offset int) (menus []*model.Menu, errNoRow error, err error)
+	Update(ctx context.Context, businessID int64, menu model.Menu) (nAffected int64, errNoRow error, err error)
+	Create(ctx context.Context, businessID int64, menu model.Menu) (id int64, err error)
+	Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error)
+	Search(ctx context.Context, businessID int64, menu model.MenuQuery) (menus []*model.Menu, errNoRow error, err error)
+	UpdateAvailability(ctx context.Context, businessID, id int64, stock, dailyCapacity *int) (nAffected int64, errNoRow error, err error)
 }
 
 type menuRepository struct {
@@ -26,16 +27,19 @@ func NewMenuRepository(postgres postgres.PostgresClient) MenuRepository {
 	return &menuRepository{postgres: postgres}
 }
 
-func (repo *menuRepository) GetByID(ctx context.Context, id int64) (menu *model.Menu, errNoRow error, err error) {
+func (repo *menuRepository) GetByID(ctx context.Context, businessID, id int64) (menu *model.Menu, errNoRow error, err error) {
 
 	menu = &model.Menu{}
 	err = repo.postgres.
-		QueryRowContext(ctx, getMenuByID, id).
+		QueryRowContext(ctx, getMenuByID, id, businessID).
 		Scan(
 			&menu.ID,
 			&menu.Name,
 			&menu.Price,
 			&menu.Categories,
+			&menu.Stock,
+			&menu.DailyCapacity,
+			&menu.OrderedToday,
 		)
 
 	if err == sql.ErrNoRows {
@@ -51,16 +55,19 @@ func (repo *menuRepository) GetByID(ctx context.Context, id int64) (menu *model.
 	return menu, nil, nil
 }
 
-func (repo *menuRepository) GetByName(ctx context.Context, name string) (menu *model.Menu, errNoRow error, err error) {
+func (repo *menuRepository) GetByName(ctx context.Context, businessID int64, name string) (menu *model.Menu, errNoRow error, err error) {
 
 	menu = &model.Menu{}
 	err = repo.postgres.
-		QueryRowContext(ctx, getMenuByName, name).
+		QueryRowContext(ctx, getMenuByName, name, businessID).
 		Scan(
 			&menu.ID,
 			&menu.Name,
 			&menu.Price,
 			&menu.Categories,
+			&menu.Stock,
+			&menu.DailyCapacity,
+			&menu.OrderedToday,
 		)
 
 	if err == sql.ErrNoRows {
@@ -76,8 +83,8 @@ func (repo *menuRepository) GetByName(ctx context.Context, name string) (menu *m
 	return menu, nil, nil
 }
 
-func (repo *menuRepository) List(ctx context.Context, limit, offset int) (menus []*model.Menu, errNoRow error, err error) {
-	rows, err := repo.postgres.QueryContext(ctx, listMenu, limit, offset)
+func (repo *menuRepository) List(ctx context.Context, businessID int64, limit, offset int) (menus []*model.Menu, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listMenu, limit, offset, businessID)
 	if err != nil {
 		err = fmt.Errorf("repository.menuRepository.List: %w", err)
 		return nil, nil, err
@@ -92,6 +99,9 @@ func (repo *menuRepository) List(ctx context.Context, limit, offset int) (menus
 			&menu.Name,
 			&menu.Price,
 			&menu.Categories,
+			&menu.Stock,
+			&menu.DailyCapacity,
+			&menu.OrderedToday,
 		)
 
 		if err != nil {
@@ -118,8 +128,8 @@ func (repo *menuRepository) List(ctx context.Context, limit, offset int) (menus
 	return menus, nil, rows.Close()
 }
 
-func (repo *menuRepository) Create(ctx context.Context, menu model.Menu) (id int64, err error) {
-	err = repo.postgres.QueryRowContext(ctx, createMenu, menu.Name, menu.Price, menu.Categories).Scan(&id)
+func (repo *menuRepository) Create(ctx context.Context, businessID int64, menu model.Menu) (id int64, err error) {
+	err = repo.postgres.QueryRowContext(ctx, createMenu, menu.Name, menu.Price, menu.Categories, businessID).Scan(&id)
 	if err != nil {
 		err = fmt.Errorf("repository.menuRepository.Create: %w", err)
 		return 0, err
@@ -127,9 +137,9 @@ func (repo *menuRepository) Create(ctx context.Context, menu model.Menu) (id int
 	return id, nil
 }
 
-func (repo *menuRepository) Update(ctx context.Context, menu model.Menu) (nAffected int64, errNoRow error, err error) {
+func (repo *menuRepository) Update(ctx context.Context, businessID int64, menu model.Menu) (nAffected int64, errNoRow error, err error) {
 
-	res, err := repo.postgres.ExecContext(ctx, updateMenuByID, menu.ID, menu.Name, menu.Price, menu.Categories)
+	res, err := repo.postgres.ExecContext(ctx, updateMenuByID, menu.ID, menu.Name, menu.Price, menu.Categories, businessID)
 
 	if err != nil {
 		err = fmt.Errorf("repository.menuRepository.Update: %w", err)
@@ -149,8 +159,8 @@ func (repo *menuRepository) Update(ctx context.Context, menu model.Menu) (nAffec
 	return nAffected, nil, nil
 }
 
-func (repo *menuRepository) Delete(ctx context.Context, id int64) (nAffected int64, errNoRow error, err error) {
-	res, err := repo.postgres.ExecContext(ctx, deleteMenuByID, id)
+func (repo *menuRepository) Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteMenuByID, id, businessID)
 
 	if err != nil {
 		err = fmt.Errorf("repository.menuRepository.Delete: %w", err)
@@ -170,9 +180,30 @@ func (repo *menuRepository) Delete(ctx context.Context, id int64) (nAffected int
 	return nAffected, nil, nil
 }
 
-func (repo *menuRepository) Search(ctx context.Context, menu model.MenuQuery) (menus []*model.Menu, errNoRow error, err error) {
+// UpdateAvailability replace the stock and the daily capacity of the menu, nil removes the limit
+func (repo *menuRepository) UpdateAvailability(ctx context.Context, businessID, id int64, stock, dailyCapacity *int) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateMenuAvailabilityByID, id, stock, dailyCapacity, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.menuRepository.UpdateAvailability: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.menuRepository.UpdateAvailability: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.menuRepository.UpdateAvailability: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+func (repo *menuRepository) Search(ctx context.Context, businessID int64, menu model.MenuQuery) (menus []*model.Menu, errNoRow error, err error) {
 	menus = make([]*model.Menu, 0)
-	query, args := menuDynamicSearchQuery(menu)
+	query, args := menuDynamicSearchQuery(businessID, menu)
 	rows, err := repo.postgres.QueryContext(ctx, query, args...)
 	if err != nil {
 		err = fmt.Errorf("repository.menuRepository.Search: %w", err)
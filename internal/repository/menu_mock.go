@@ -36,24 +36,24 @@ func (m *MockMenuRepository) EXPECT() *MockMenuRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockMenuRepository) Create(ctx context.Context, menu model.Menu) (int64, error) {
+func (m *MockMenuRepository) Create(ctx context.Context, businessID int64, menu model.Menu) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, menu)
+	ret := m.ctrl.Call(m, "Create", ctx, businessID, menu)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockMenuRepositoryMockRecorder) Create(ctx, menu interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) Create(ctx, businessID, menu interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMenuRepository)(nil).Create), ctx, menu)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMenuRepository)(nil).Create), ctx, businessID, menu)
 }
 
 // Delete mocks base method.
-func (m *MockMenuRepository) Delete(ctx context.Context, id int64) (int64, error, error) {
+func (m *MockMenuRepository) Delete(ctx context.Context, businessID, id int64) (int64, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, businessID, id)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -61,15 +61,15 @@ func (m *MockMenuRepository) Delete(ctx context.Context, id int64) (int64, error
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockMenuRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) Delete(ctx, businessID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMenuRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMenuRepository)(nil).Delete), ctx, businessID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockMenuRepository) GetByID(ctx context.Context, id int64) (*model.Menu, error, error) {
+func (m *MockMenuRepository) GetByID(ctx context.Context, businessID, id int64) (*model.Menu, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, businessID, id)
 	ret0, _ := ret[0].(*model.Menu)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -77,15 +77,15 @@ func (m *MockMenuRepository) GetByID(ctx context.Context, id int64) (*model.Menu
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockMenuRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) GetByID(ctx, businessID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMenuRepository)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMenuRepository)(nil).GetByID), ctx, businessID, id)
 }
 
 // GetByName mocks base method.
-func (m *MockMenuRepository) GetByName(ctx context.Context, name string) (*model.Menu, error, error) {
+func (m *MockMenuRepository) GetByName(ctx context.Context, businessID int64, name string) (*model.Menu, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret := m.ctrl.Call(m, "GetByName", ctx, businessID, name)
 	ret0, _ := ret[0].(*model.Menu)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -93,15 +93,15 @@ func (m *MockMenuRepository) GetByName(ctx context.Context, name string) (*model
 }
 
 // GetByName indicates an expected call of GetByName.
-func (mr *MockMenuRepositoryMockRecorder) GetByName(ctx, name interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) GetByName(ctx, businessID, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockMenuRepository)(nil).GetByName), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockMenuRepository)(nil).GetByName), ctx, businessID, name)
 }
 
 // List mocks base method.
-func (m *MockMenuRepository) List(ctx context.Context, limit, offset int) ([]*model.Menu, error, error) {
+func (m *MockMenuRepository) List(ctx context.Context, businessID int64, limit, offset int) ([]*model.Menu, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, businessID, limit, offset)
 	ret0, _ := ret[0].([]*model.Menu)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -109,15 +109,15 @@ func (m *MockMenuRepository) List(ctx context.Context, limit, offset int) ([]*mo
 }
 
 // List indicates an expected call of List.
-func (mr *MockMenuRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) List(ctx, businessID, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMenuRepository)(nil).List), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMenuRepository)(nil).List), ctx, businessID, limit, offset)
 }
 
 // Search mocks base method.
-func (m *MockMenuRepository) Search(ctx context.Context, menu model.MenuQuery) ([]*model.Menu, error, error) {
+func (m *MockMenuRepository) Search(ctx context.Context, businessID int64, menu model.MenuQuery) ([]*model.Menu, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, menu)
+	ret := m.ctrl.Call(m, "Search", ctx, businessID, menu)
 	ret0, _ := ret[0].([]*model.Menu)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -125,15 +125,15 @@ func (m *MockMenuRepository) Search(ctx context.Context, menu model.MenuQuery) (
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockMenuRepositoryMockRecorder) Search(ctx, menu interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) Search(ctx, businessID, menu interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMenuRepository)(nil).Search), ctx, menu)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMenuRepository)(nil).Search), ctx, businessID, menu)
 }
 
 // Update mocks base method.
-func (m *MockMenuRepository) Update(ctx context.Context, menu model.Menu) (int64, error, error) {
+func (m *MockMenuRepository) Update(ctx context.Context, businessID int64, menu model.Menu) (int64, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, menu)
+	ret := m.ctrl.Call(m, "Update", ctx, businessID, menu)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -141,7 +141,23 @@ func (m *MockMenuRepository) Update(ctx context.Context, menu model.Menu) (int64
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockMenuRepositoryMockRecorder) Update(ctx, menu interface{}) *gomock.Call {
+func (mr *MockMenuRepositoryMockRecorder) Update(ctx, businessID, menu interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMenuRepository)(nil).Update), ctx, menu)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMenuRepository)(nil).Update), ctx, businessID, menu)
+}
+
+// UpdateAvailability mocks base method.
+func (m *MockMenuRepository) UpdateAvailability(ctx context.Context, businessID, id int64, stock, dailyCapacity *int) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvailability", ctx, businessID, id, stock, dailyCapacity)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateAvailability indicates an expected call of UpdateAvailability.
+func (mr *MockMenuRepositoryMockRecorder) UpdateAvailability(ctx, businessID, id, stock, dailyCapacity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvailability", reflect.TypeOf((*MockMenuRepository)(nil).UpdateAvailability), ctx, businessID, id, stock, dailyCapacity)
 }
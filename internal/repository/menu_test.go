@@ -6,6 +6,7 @@ import (
 	"errors"
 	"family-catering/internal/model"
 	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/money"
 	"fmt"
 	"testing"
 
@@ -13,6 +14,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func intPtr(i int) *int {
+	return &i
+}
+
 func TestNewMenuRepository(t *testing.T) {
 	type args struct {
 		postgres postgres.PostgresClient
@@ -35,8 +40,9 @@ func TestNewMenuRepository(t *testing.T) {
 
 func Test_menuRepository_GetByID(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  int64
+		ctx        context.Context
+		businessID int64
+		id         int64
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -53,33 +59,38 @@ func Test_menuRepository_GetByID(t *testing.T) {
 			name: "success GetByID",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
-				id:  1,
+				ctx:        context.Background(),
+				businessID: 1,
+				id:         1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+id.+").
-					WithArgs(int64(1)).
+					WithArgs(int64(1), int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
-							AddRow(int64(1), "sate", float32(25_000), "Indonesian food")).WillReturnError(nil)
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"}).
+							AddRow(int64(1), "sate", int64(2_500_000), "Indonesian food", int64(10), int64(50), int64(42))).WillReturnError(nil)
 			},
 			wantMenu: &model.Menu{
-				ID:         1,
-				Name:       "sate",
-				Price:      25_000,
-				Categories: "Indonesian food",
+				ID:            1,
+				Name:          "sate",
+				Price:         money.MustParse("25000"),
+				Categories:    "Indonesian food",
+				Stock:         intPtr(10),
+				DailyCapacity: intPtr(50),
+				OrderedToday:  42,
 			},
 		},
 		{
 			name: "fail GetByID (no row)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
-				id:  1_000_000_000_000_000, // assume no menu with this id
+				ctx:        context.Background(),
+				businessID: 1,
+				id:         1_000_000_000_000_000, // assume no menu with this id
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+id.+").
-					WithArgs(int64(1_000_000_000_000_000)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"})).WillReturnError(sql.ErrNoRows)
+					WithArgs(int64(1_000_000_000_000_000), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"})).WillReturnError(sql.ErrNoRows)
 			},
 			wantErr: true,
 		},
@@ -87,12 +98,13 @@ func Test_menuRepository_GetByID(t *testing.T) {
 			name: "fail GetByID (db error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
-				id:  1,
+				ctx:        context.Background(),
+				businessID: 1,
+				id:         1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+id.+").
-					WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"})).WillReturnError(errors.New("oops! db error"))
+					WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"})).WillReturnError(errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -109,7 +121,7 @@ func Test_menuRepository_GetByID(t *testing.T) {
 			}
 
 			tt.repo.postgres = db
-			gotMenu, errNoRow, err := tt.repo.GetByID(tt.args.ctx, tt.args.id)
+			gotMenu, errNoRow, err := tt.repo.GetByID(tt.args.ctx, tt.args.businessID, tt.args.id)
 
 			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil))
 			assert.Equal(t, tt.wantMenu, gotMenu)
@@ -119,8 +131,9 @@ func Test_menuRepository_GetByID(t *testing.T) {
 
 func Test_menuRepository_GetByName(t *testing.T) {
 	type args struct {
-		ctx  context.Context
-		name string
+		ctx        context.Context
+		businessID int64
+		name       string
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -137,33 +150,38 @@ func Test_menuRepository_GetByName(t *testing.T) {
 			name: "success GetByName",
 			repo: &menuRepository{},
 			args: args{
-				ctx:  context.Background(),
-				name: "sate",
+				ctx:        context.Background(),
+				businessID: 1,
+				name:       "sate",
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+name.+").
-					WithArgs("sate").
+					WithArgs("sate", int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
-							AddRow(int64(1), "sate", float32(25_000), "Indonesian food")).WillReturnError(nil)
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"}).
+							AddRow(int64(1), "sate", int64(2_500_000), "Indonesian food", int64(10), int64(50), int64(42))).WillReturnError(nil)
 			},
 			wantMenu: &model.Menu{
-				ID:         1,
-				Name:       "sate",
-				Price:      25_000,
-				Categories: "Indonesian food",
+				ID:            1,
+				Name:          "sate",
+				Price:         money.MustParse("25000"),
+				Categories:    "Indonesian food",
+				Stock:         intPtr(10),
+				DailyCapacity: intPtr(50),
+				OrderedToday:  42,
 			},
 		},
 		{
 			name: "fail GetByName (no row)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:  context.Background(),
-				name: "not-exists-name-in-db", // assume no menu with this id
+				ctx:        context.Background(),
+				businessID: 1,
+				name:       "not-exists-name-in-db", // assume no menu with this id
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+name.+").
-					WithArgs("not-exists-name-in-db").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"})).WillReturnError(sql.ErrNoRows)
+					WithArgs("not-exists-name-in-db", int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"})).WillReturnError(sql.ErrNoRows)
 			},
 			wantErr: true,
 		},
@@ -171,12 +189,13 @@ func Test_menuRepository_GetByName(t *testing.T) {
 			name: "fail GetByName (no rows)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:  context.Background(),
-				name: "sate",
+				ctx:        context.Background(),
+				businessID: 1,
+				name:       "sate",
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+name.+").
-					WithArgs("sate").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"}).CloseError(sql.ErrNoRows))
+					WithArgs("sate", int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"}).CloseError(sql.ErrNoRows))
 			},
 			wantErr: true,
 		},
@@ -184,12 +203,13 @@ func Test_menuRepository_GetByName(t *testing.T) {
 			name: "fail GetByName (db error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:  context.Background(),
-				name: "sate",
+				ctx:        context.Background(),
+				businessID: 1,
+				name:       "sate",
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+FROM.+menu.+name.+").
-					WithArgs("sate").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"})).WillReturnError(errors.New("oops! db error"))
+					WithArgs("sate", int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"})).WillReturnError(errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -206,7 +226,7 @@ func Test_menuRepository_GetByName(t *testing.T) {
 			}
 
 			tt.repo.postgres = db
-			gotMenu, errNoRow, err := tt.repo.GetByName(tt.args.ctx, tt.args.name)
+			gotMenu, errNoRow, err := tt.repo.GetByName(tt.args.ctx, tt.args.businessID, tt.args.name)
 
 			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil))
 			assert.Equal(t, tt.wantMenu, gotMenu)
@@ -216,9 +236,10 @@ func Test_menuRepository_GetByName(t *testing.T) {
 
 func Test_menuRepository_List(t *testing.T) {
 	type args struct {
-		ctx    context.Context
-		limit  int
-		offset int
+		ctx        context.Context
+		businessID int64
+		limit      int
+		offset     int
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -235,31 +256,35 @@ func Test_menuRepository_List(t *testing.T) {
 			name: "success GetList menu",
 			repo: &menuRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+menu.+LIMIT.+OFFSET").
-					WithArgs(2, 1).
+					WithArgs(2, 1, int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
-							AddRow(1, "sate", "25_000", "Indonesian food").
-							AddRow(2, "rendang", "35_000", "Indonesian food"),
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"}).
+							AddRow(1, "sate", int64(2_500_000), "Indonesian food", int64(10), int64(50), int64(42)).
+							AddRow(2, "rendang", int64(3_500_000), "Indonesian food", nil, nil, int64(0)),
 					).
 					WillReturnError(nil)
 			},
 			wantMenu: []*model.Menu{
 				{
-					ID:         1,
-					Name:       "sate",
-					Price:      25_000,
-					Categories: "Indonesian food",
+					ID:            1,
+					Name:          "sate",
+					Price:         money.MustParse("25000"),
+					Categories:    "Indonesian food",
+					Stock:         intPtr(10),
+					DailyCapacity: intPtr(50),
+					OrderedToday:  42,
 				},
 				{
 					ID:         2,
 					Name:       "rendang",
-					Price:      35_000,
+					Price:      money.MustParse("35000"),
 					Categories: "Indonesian food",
 				},
 			},
@@ -268,15 +293,16 @@ func Test_menuRepository_List(t *testing.T) {
 			name: "fail GetList menu (db error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  -2,
-				offset: -100,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      -2,
+				offset:     -100,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+menu.+LIMIT.+OFFSET").
-					WithArgs(-2, -1).
+					WithArgs(-2, -1, int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"})).
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"})).
 					WillReturnError(errors.New("oops! db error"))
 			},
 			wantErr: true,
@@ -285,17 +311,18 @@ func Test_menuRepository_List(t *testing.T) {
 			name: "fail GetList menu (db error row error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+menu.+LIMIT.+OFFSET").
-					WithArgs(2, 1).
+					WithArgs(2, 1, int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
-							AddRow(1, "sate", 25_000, "Indonesian food").
-							AddRow(2, "rendang", int(35_000), "Indonesian food").
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"}).
+							AddRow(1, "sate", 25_000, "Indonesian food", nil, nil, 0).
+							AddRow(2, "rendang", int(35_000), "Indonesian food", nil, nil, 0).
 							RowError(1, errors.New("oops! dbe error unmatched type for price columns"))). // price must be float32 not int & start from zero
 					WillReturnError(nil)
 			},
@@ -305,16 +332,17 @@ func Test_menuRepository_List(t *testing.T) {
 			name: "fail GetList menu (db error scan error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+menu.+LIMIT.+OFFSET").
-					WithArgs(2, 1).
+					WithArgs(2, 1, int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
-							AddRow(nil, nil, nil, nil)).
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"}).
+							AddRow(nil, nil, nil, nil, nil, nil, nil)).
 					WillReturnError(nil)
 			},
 			wantErr: true,
@@ -323,15 +351,16 @@ func Test_menuRepository_List(t *testing.T) {
 			name: "fail GetList menu (no rows)",
 			repo: &menuRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("SELECT.+menu.+LIMIT.+OFFSET").
-					WithArgs(2, 1).
+					WithArgs(2, 1, int64(1)).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "name", "price", "categories"})).
+						sqlmock.NewRows([]string{"id", "name", "price", "categories", "stock", "daily_capacity", "ordered_today"})).
 					WillReturnError(nil)
 			},
 			wantErr: true,
@@ -349,7 +378,7 @@ func Test_menuRepository_List(t *testing.T) {
 			}
 
 			tt.repo.postgres = db
-			gotMenu, errNoRow, err := tt.repo.List(tt.args.ctx, tt.args.limit, tt.args.offset)
+			gotMenu, errNoRow, err := tt.repo.List(tt.args.ctx, tt.args.businessID, tt.args.limit, tt.args.offset)
 
 			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil), err)
 			assert.Equal(t, tt.wantMenu, gotMenu)
@@ -359,8 +388,9 @@ func Test_menuRepository_List(t *testing.T) {
 
 func Test_menuRepository_Create(t *testing.T) {
 	type args struct {
-		ctx  context.Context
-		menu model.Menu
+		ctx        context.Context
+		businessID int64
+		menu       model.Menu
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -377,16 +407,17 @@ func Test_menuRepository_Create(t *testing.T) {
 			name: "success Create menu",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.Menu{
 					Name:       "sate",
-					Price:      25_000,
+					Price:      money.MustParse("25000"),
 					Categories: "Indonesian food",
 				},
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("INSERT INTO menu.+").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1))).
 					WillReturnError(nil)
 			},
@@ -396,16 +427,17 @@ func Test_menuRepository_Create(t *testing.T) {
 			name: "fail Create menu",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.Menu{
 					Name:       "sate",
-					Price:      25_000,
+					Price:      money.MustParse("25000"),
 					Categories: "Indonesian food",
 				},
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectQuery("INSERT INTO menu.+").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1))).
 					WillReturnError(errors.New("oops! db error"))
 			},
@@ -425,7 +457,7 @@ func Test_menuRepository_Create(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
-			gotId, err := tt.repo.Create(tt.args.ctx, tt.args.menu)
+			gotId, err := tt.repo.Create(tt.args.ctx, tt.args.businessID, tt.args.menu)
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, tt.wantId, gotId)
 		})
@@ -434,8 +466,9 @@ func Test_menuRepository_Create(t *testing.T) {
 
 func Test_menuRepository_Update(t *testing.T) {
 	type args struct {
-		ctx  context.Context
-		menu model.Menu
+		ctx        context.Context
+		businessID int64
+		menu       model.Menu
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -452,11 +485,12 @@ func Test_menuRepository_Update(t *testing.T) {
 			name: "success Update menu",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.Menu{
 					ID:    1,
 					Name:  "sate padang",
-					Price: 40_000,
+					Price: money.MustParse("40000"),
 				},
 			},
 			prepareMocks: func(m *mocks) {
@@ -470,11 +504,12 @@ func Test_menuRepository_Update(t *testing.T) {
 			name: "fail Update menu (no rows)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.Menu{
 					ID:    1_000_000_000_000, // assume no menu with this id
 					Name:  "sate padang",
-					Price: 40_000,
+					Price: money.MustParse("40000"),
 				},
 			},
 			prepareMocks: func(m *mocks) {
@@ -488,11 +523,12 @@ func Test_menuRepository_Update(t *testing.T) {
 			name: "fail Update menu (db error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.Menu{
 					ID:    1, // assume no menu with this id
 					Name:  "sate padang",
-					Price: 40_000,
+					Price: money.MustParse("40000"),
 				},
 			},
 			prepareMocks: func(m *mocks) {
@@ -515,7 +551,7 @@ func Test_menuRepository_Update(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
-			gotNAffected, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.menu)
+			gotNAffected, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.businessID, tt.args.menu)
 
 			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil))
 			assert.Equal(t, tt.wantNAffected, gotNAffected)
@@ -526,8 +562,9 @@ func Test_menuRepository_Update(t *testing.T) {
 
 func Test_menuRepository_Delete(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  int64
+		ctx        context.Context
+		businessID int64
+		id         int64
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -543,13 +580,14 @@ func Test_menuRepository_Delete(t *testing.T) {
 		{
 			name: "succes Delete menu",
 			args: args{
-				ctx: context.Background(),
-				id:  1,
+				ctx:        context.Background(),
+				businessID: 1,
+				id:         1,
 			},
 			repo: &menuRepository{},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectExec("DELETE FROM menu.+id.+").
-					WithArgs(int64(1)).
+					WithArgs(int64(1), int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantNAffected: 1,
@@ -557,13 +595,14 @@ func Test_menuRepository_Delete(t *testing.T) {
 		{
 			name: "fail Delete menu (no rows)",
 			args: args{
-				ctx: context.Background(),
-				id:  1_000_000_000_000_000,
+				ctx:        context.Background(),
+				businessID: 1,
+				id:         1_000_000_000_000_000,
 			},
 			repo: &menuRepository{},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectExec("DELETE FROM menu.+id.+").
-					WithArgs(int64(1_000_000_000_000_000)).
+					WithArgs(int64(1_000_000_000_000_000), int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			wantNAffected: 0,
@@ -572,13 +611,14 @@ func Test_menuRepository_Delete(t *testing.T) {
 		{
 			name: "fail Delete menu (no rows)",
 			args: args{
-				ctx: context.Background(),
-				id:  1,
+				ctx:        context.Background(),
+				businessID: 1,
+				id:         1,
 			},
 			repo: &menuRepository{},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.ExpectExec("DELETE FROM menu.+id.+").
-					WithArgs(int64(1_000_000_000_000_000)).
+					WithArgs(int64(1_000_000_000_000_000), int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 0)).
 					WillReturnError(errors.New("oops! db error"))
 			},
@@ -599,7 +639,7 @@ func Test_menuRepository_Delete(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
-			gotNAffected, errNoRow, err := tt.repo.Delete(tt.args.ctx, tt.args.id)
+			gotNAffected, errNoRow, err := tt.repo.Delete(tt.args.ctx, tt.args.businessID, tt.args.id)
 
 			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil))
 			assert.Equal(t, tt.wantNAffected, gotNAffected)
@@ -607,10 +647,98 @@ func Test_menuRepository_Delete(t *testing.T) {
 	}
 }
 
+func Test_menuRepository_UpdateAvailability(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		businessID    int64
+		id            int64
+		stock         *int
+		dailyCapacity *int
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *menuRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success UpdateAvailability",
+			repo: &menuRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1, stock: intPtr(20), dailyCapacity: intPtr(50)},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE menu SET stock = \$2, daily_capacity = \$3 WHERE id = \$1`).
+					WithArgs(int64(1), int64(20), int64(50), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "success UpdateAvailability (unlimited)",
+			repo: &menuRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE menu SET stock = \$2, daily_capacity = \$3 WHERE id = \$1`).
+					WithArgs(int64(1), nil, nil, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail UpdateAvailability (no rows)",
+			repo: &menuRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1_000_000, stock: intPtr(20)},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE menu SET stock`).
+					WithArgs(int64(1_000_000), int64(20), nil, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail UpdateAvailability (db error)",
+			repo: &menuRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1, stock: intPtr(20)},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE menu SET stock`).
+					WithArgs(int64(1), int64(20), nil, int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.UpdateAvailability(tt.args.ctx, tt.args.businessID, tt.args.id, tt.args.stock, tt.args.dailyCapacity)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
 func Test_menuRepository_Search(t *testing.T) {
 	type args struct {
-		ctx  context.Context
-		menu model.MenuQuery
+		ctx        context.Context
+		businessID int64
+		menu       model.MenuQuery
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -627,37 +755,54 @@ func Test_menuRepository_Search(t *testing.T) {
 			name: "success search menu",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.MenuQuery{
 					Names:           []string{"sate", "nasi goreng"},
 					ExactNamesMatch: false,
-					MaxPrice:        100_000,
-					MinPrice:        10_000,
+					MaxPrice:        money.MustParse("100000"),
+					MinPrice:        money.MustParse("10000"),
 					Categories:      "Indonesian food",
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
+						AddRow(23, "nasi goreng extra pedas", int64(5_500_000), "Indonesian food"))
+			},
+			wantMenus: []*model.Menu{{ID: 23, Name: "nasi goreng extra pedas", Price: money.MustParse("55000"), Categories: "Indonesian food"}},
+		},
+		{
+			name: "success search menu (by ids)",
+			repo: &menuRepository{},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				menu:       model.MenuQuery{IDs: []int64{23, 1}},
+			},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM menu WHERE id = ANY\(string_to_array\(\$1, ','\)::BIGINT\[\]\)`).WithArgs("23,1", int64(1)).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
-						AddRow(23, "nasi goreng extra pedas", float32(55_000), "Indonesian food"))
+						AddRow(23, "nasi goreng extra pedas", int64(5_500_000), "Indonesian food"))
 			},
-			wantMenus: []*model.Menu{{ID: 23, Name: "nasi goreng extra pedas", Price: 55_000, Categories: "Indonesian food"}},
+			wantMenus: []*model.Menu{{ID: 23, Name: "nasi goreng extra pedas", Price: money.MustParse("55000"), Categories: "Indonesian food"}},
 		},
 		{
 			name: "fail search menu (no row)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.MenuQuery{
 					Names:           []string{"not-exist-food-name", "we-don't-have-this-food"},
 					ExactNamesMatch: true,
-					MaxPrice:        100_000,
-					MinPrice:        10_000,
+					MaxPrice:        money.MustParse("100000"),
+					MinPrice:        money.MustParse("10000"),
 					Categories:      "Spicy",
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "categories"})).WillReturnError(nil)
 			},
 			wantErr: true,
@@ -666,17 +811,18 @@ func Test_menuRepository_Search(t *testing.T) {
 			name: "fail search menu (error row)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.MenuQuery{
 					Names:           []string{"nasi goreng asin", "sate"},
 					ExactNamesMatch: true,
-					MaxPrice:        100_000,
-					MinPrice:        10_000,
+					MaxPrice:        money.MustParse("100000"),
+					MinPrice:        money.MustParse("10000"),
 					Categories:      "Indonesian food,Spicy",
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnRows(
 						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
 							AddRow(14, "nasi goreng asin", 55_000, "Indonesian food").
@@ -690,17 +836,18 @@ func Test_menuRepository_Search(t *testing.T) {
 			name: "fail search menu (scan error)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.MenuQuery{
 					Names:           []string{"nasi goreng asin", "sate"},
 					ExactNamesMatch: true,
-					MaxPrice:        100_000,
-					MinPrice:        10_000,
+					MaxPrice:        money.MustParse("100000"),
+					MinPrice:        money.MustParse("10000"),
 					Categories:      "Indonesian food,Spicy",
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnRows(
 						sqlmock.NewRows([]string{"id", "name", "price", "categories"}).
 							AddRow(14, "nasi goreng asin", 55_000, "Indonesian food").
@@ -713,17 +860,18 @@ func Test_menuRepository_Search(t *testing.T) {
 			name: "fail search menu (error query)",
 			repo: &menuRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				menu: model.MenuQuery{
 					Names:           []string{"nasi goreng asin", "sate"},
 					ExactNamesMatch: true,
-					MaxPrice:        100_000,
-					MinPrice:        10_000,
+					MaxPrice:        money.MustParse("100000"),
+					MinPrice:        money.MustParse("10000"),
 					Categories:      "Indonesian food,Spicy",
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				m.pgMock.ExpectQuery("SELECT.+FROM menu WHERE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnRows(
 						sqlmock.NewRows([]string{"id", "name", "price", "categories"})).
 					WillReturnError(errors.New("oops! db error"))
@@ -744,7 +892,7 @@ func Test_menuRepository_Search(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
-			gotMenus, errNoRow, err := tt.repo.Search(tt.args.ctx, tt.args.menu)
+			gotMenus, errNoRow, err := tt.repo.Search(tt.args.ctx, tt.args.businessID, tt.args.menu)
 			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil), err)
 			assert.Equal(t, tt.wantMenus, gotMenus, fmt.Sprintf("%v", gotMenus))
 
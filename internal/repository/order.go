@@ -5,7 +5,10 @@ import (
 	"database/sql"
 	"family-catering/internal/model"
 	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/money"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -16,13 +19,27 @@ import (
 // )
 
 type OrderRepository interface {
-	Search(ctx context.Context, order model.OrderQuery) (orders []*model.Order, errNoRow error, err error)
-	Create(ctx context.Context, orders []*model.Order) (lastInsertbaseOrderID int64, OrderID int64, err error)
-	ConfirmPayment(ctx context.Context, email string) (nAffected int64, errNoRow error, err error)
-	// Report(ctx context.Context) // by id email, price and data
+	Search(ctx context.Context, businessID int64, order model.OrderQuery) (orders []*model.Order, errNoRow error, err error)
+	Create(ctx context.Context, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, charge *model.OrderCharge) (lastInsertbaseOrderID int64, OrderID int64, err error)
+	CreateWithDiscount(ctx context.Context, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, discount *model.OrderDiscount, charge *model.OrderCharge) (lastInsertbaseOrderID int64, OrderID int64, err error)
 	CancelUnpaidOrder(ctx context.Context) (nAffected int64, err error)
+	GetStatus(ctx context.Context, businessID, orderID int64) (status int, errNoRow error, err error)
+	UpdateStatus(ctx context.Context, businessID, orderID int64, from, to int, changedBy int64) (nAffected int64, errNoRow error, err error)
+	StatusHistory(ctx context.Context, businessID, orderID int64) (histories []*model.OrderStatusHistory, errNoRow error, err error)
+	Get(ctx context.Context, businessID, orderID int64) (orders []*model.Order, errNoRow error, err error)
+	UpdateCustomer(ctx context.Context, businessID, orderID, customerID int64, email string) (nAffected int64, errNoRow error, err error)
+	AddItem(ctx context.Context, businessID int64, item *model.Order, reprice OrderRepricer) (baseOrderID int64, errNoRow error, err error)
+	UpdateItemQty(ctx context.Context, businessID, orderID, baseOrderID int64, qty int, reprice OrderRepricer) (nAffected int64, errNoRow error, err error)
+	DeleteItem(ctx context.Context, businessID, orderID, baseOrderID int64, reprice OrderRepricer) (nAffected int64, errNoRow error, err error)
+	GetDiscount(ctx context.Context, businessID, orderID int64) (discount *model.OrderDiscount, errNoRow error, err error)
+	GetCharges(ctx context.Context, businessID int64, orderIDs []int64) (charges []*model.OrderCharge, err error)
+	ListByDeliveryDate(ctx context.Context, businessID int64, date string) (orders []*model.Order, err error)
 }
 
+// OrderRepricer reprice an order whose items have been changed, orders are the order's rows read within the transaction
+// changing the items. It returns the order's discount amount (ignored when the order has no promo code) and its charge
+type OrderRepricer func(orders []*model.Order) (discount money.Money, charge *model.OrderCharge, err error)
+
 type orderRepository struct {
 	postgres postgres.PostgresClient
 }
@@ -31,11 +48,72 @@ func NewOrderRepository(postgres postgres.PostgresClient) OrderRepository {
 	return &orderRepository{postgres: postgres}
 }
 
-func (repo *orderRepository) Create(ctx context.Context, orders []*model.Order) (baseOrderID int64, OrderID int64, err error) {
-	query, args := repo.orderMenusInsertQuery(orders)
-	err = repo.postgres.QueryRowContext(ctx, query, args...).Scan(&baseOrderID, &OrderID)
+// Create create the orders of the customer delivered at the given delivery and record their charge (charge.OrderID is ignored)
+// at once, a customer without id is created along with them (see insertOrders). ErrDeliverySlotFull is returned when the delivery slot has reached its max orders
+// and MenuSoldOutError when a menu doesn't have enough portions left (see reserveMenus)
+func (repo *orderRepository) Create(ctx context.Context, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, charge *model.OrderCharge) (baseOrderID int64, OrderID int64, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.Create: %w", err)
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	baseOrderID, OrderID, err = repo.insertOrders(ctx, tx, businessID, customer, orders, delivery, charge)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.Create: %w", err)
+		return 0, 0, err
+	}
+
+	err = tx.Commit()
 	if err != nil {
-		err = fmt.Errorf("repository.ownerRepository.Create: %w", err)
+		err = fmt.Errorf("repository.orderRepository.Create: %w", err)
+		return 0, 0, err
+	}
+
+	return baseOrderID, OrderID, nil
+}
+
+// CreateWithDiscount create the orders of the customer (see Create) and record the discount of the promotion at once, the promotion is locked
+// so its usage limits hold under concurrent orders. ErrPromotionUsageLimit is returned when the promotion has been
+// used up globally or by the customer (discount.CustomerEmail), ErrDeliverySlotFull when the delivery slot has reached
+// its max orders and MenuSoldOutError when a menu doesn't have enough portions left.
+func (repo *orderRepository) CreateWithDiscount(ctx context.Context, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, discount *model.OrderDiscount, charge *model.OrderCharge) (baseOrderID int64, OrderID int64, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", err)
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	var usageLimit, usageLimitPerCustomer, nUsed, nUsedByCustomer int
+	err = tx.QueryRowContext(ctx, getPromotionUsageForUpdate, discount.PromotionID, discount.CustomerEmail).
+		Scan(&usageLimit, &usageLimitPerCustomer, &nUsed, &nUsedByCustomer)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", err)
+		return 0, 0, err
+	}
+
+	if (usageLimit > 0 && nUsed >= usageLimit) || (usageLimitPerCustomer > 0 && nUsedByCustomer >= usageLimitPerCustomer) {
+		err = fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", ErrPromotionUsageLimit)
+		return 0, 0, err
+	}
+
+	baseOrderID, OrderID, err = repo.insertOrders(ctx, tx, businessID, customer, orders, delivery, charge)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", err)
+		return 0, 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, insertOrderDiscount, OrderID, discount.PromotionID, discount.Code, discount.CustomerEmail, discount.Amount)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", err)
+		return 0, 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", err)
 		return 0, 0, err
 	}
 
@@ -57,92 +135,770 @@ func (repo *orderRepository) CancelUnpaidOrder(ctx context.Context) (int64, erro
 	return nAffected, nil
 }
 
-func (repo *orderRepository) ConfirmPayment(ctx context.Context, email string) (nAffected int64, errNoRow error, err error) {
-	res, err := repo.postgres.ExecContext(ctx, confirmPaymentViaEmail, email)
+func (repo *orderRepository) Search(ctx context.Context, businessID int64, order model.OrderQuery) (orders []*model.Order, errNoRow error, err error) {
+	query, args := dynamicSearchOrderQuery(businessID, &order)
+	fmt.Println("query & args: ", query, args)
+	rows, err := repo.postgres.QueryContext(ctx, query, args...)
+	orders = make([]*model.Order, 0)
+
+	if err != nil {
+		err = fmt.Errorf("repository.ownerRepository.Search: %w", err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		row := new(model.Order)
+		sliceOfPointerOrderedOrder, err := repo.scanSearchOrderColumn(rows, row) // will return slice of pointer of row field (model.Order)
+		if err != nil {
+			err = fmt.Errorf("repository.ownerRepository.Search: %w", err)
+			return nil, nil, err
+		}
+
+		err = rows.Scan(sliceOfPointerOrderedOrder...) // will be mutate row value (side effect)
+		if err != nil {
+			err = fmt.Errorf("repository.ownerRepository.Search: %w", err)
+			return nil, nil, err
+		}
+
+		orders = append(orders, row)
+	}
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(orders) == 0 {
+		err = fmt.Errorf("repository.menuRepository.Search: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.menuRepository.Search: %w", err)
+		return nil, nil, err
+	}
+
+	return orders, nil, rows.Close()
+}
+
+func (repo *orderRepository) GetStatus(ctx context.Context, businessID, orderID int64) (status int, errNoRow error, err error) {
+	err = repo.postgres.QueryRowContext(ctx, getOrderStatus, orderID, businessID).Scan(&status)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.orderRepository.GetStatus: %w", err)
+		return 0, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.GetStatus: %w", err)
+		return 0, nil, err
+	}
+
+	return status, nil, nil
+}
+
+// UpdateStatus move every row of the given order from status `from` to `to` and record it to the order's history.
+// errNoRow is returned when there is no row of the order with status `from` (order not found or already moved)
+func (repo *orderRepository) UpdateStatus(ctx context.Context, businessID, orderID int64, from, to int, changedBy int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateOrderStatus, orderID, from, to, changedBy, businessID)
 	if err != nil {
-		err = fmt.Errorf("repository.ownerRepository.ConfirmPayment: %w", err)
+		err = fmt.Errorf("repository.orderRepository.UpdateStatus: %w", err)
 		return 0, nil, err
 	}
 
 	nAffected, err = res.RowsAffected()
 	if err == nil && nAffected == 0 {
-		err = fmt.Errorf("repository.ownerRepository.ConfirmPayment: %w", sql.ErrNoRows)
+		err = fmt.Errorf("repository.orderRepository.UpdateStatus: %w", sql.ErrNoRows)
 		return 0, err, nil
 	}
 
 	if err != nil {
-		err = fmt.Errorf("repository.ownerRepository.ConfirmPayment: %w", err)
+		err = fmt.Errorf("repository.orderRepository.UpdateStatus: %w", err)
 		return 0, nil, err
 	}
 
 	return nAffected, nil, nil
-
 }
 
-func (repo *orderRepository) Search(ctx context.Context, order model.OrderQuery) (orders []*model.Order, errNoRow error, err error) {
-	query, args := dynamicSearchOrderQuery(&order)
-	fmt.Println("query & args: ", query, args)
-	rows, err := repo.postgres.QueryContext(ctx, query, args...)
-	orders = make([]*model.Order, 0)
-
+func (repo *orderRepository) StatusHistory(ctx context.Context, businessID, orderID int64) (histories []*model.OrderStatusHistory, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listOrderStatusHistory, orderID, businessID)
 	if err != nil {
-		err = fmt.Errorf("repository.ownerRepository.Search: %w", err)
+		err = fmt.Errorf("repository.orderRepository.StatusHistory: %w", err)
 		return nil, nil, err
 	}
 
+	defer rows.Close()
+
 	for rows.Next() {
-		row := new(model.Order)
-		sliceOfPointerOrderedOrder, err := repo.scanSearchOrderColumn(rows, row) // will return slice of pointer of row field (model.Order)
+		history := new(model.OrderStatusHistory)
+		err = rows.Scan(
+			&history.ID,
+			&history.OrderID,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.ChangedBy,
+			&history.CreatedAt,
+		)
+
 		if err != nil {
-			err = fmt.Errorf("repository.ownerRepository.Search: %w", err)
+			err = fmt.Errorf("repository.orderRepository.StatusHistory: %w", err)
 			return nil, nil, err
 		}
 
-		err = rows.Scan(sliceOfPointerOrderedOrder...) // will be mutate row value (side effect)
+		histories = append(histories, history)
+	}
+
+	err = rows.Err()
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(histories) == 0 {
+		err = fmt.Errorf("repository.orderRepository.StatusHistory: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.StatusHistory: %w", err)
+		return nil, nil, err
+	}
+
+	return histories, nil, rows.Close()
+}
+
+// Get return every row (item) of the given order ordered by base_order_id, every row has the order's discount
+func (repo *orderRepository) Get(ctx context.Context, businessID, orderID int64) (orders []*model.Order, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, getOrderByID, orderID, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.Get: %w", err)
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		order := new(model.Order)
+		err = rows.Scan(orderColumns(order)...)
 		if err != nil {
-			err = fmt.Errorf("repository.ownerRepository.Search: %w", err)
+			err = fmt.Errorf("repository.orderRepository.Get: %w", err)
 			return nil, nil, err
 		}
 
-		orders = append(orders, row)
+		orders = append(orders, order)
 	}
+
+	err = rows.Err()
 	// for decision reason see ./owner.go
 	if !rows.Next() && err == nil && len(orders) == 0 {
-		err = fmt.Errorf("repository.menuRepository.Search: %w", sql.ErrNoRows)
+		err = fmt.Errorf("repository.orderRepository.Get: %w", sql.ErrNoRows)
 		return nil, err, nil
 	}
 
 	if err != nil {
-		err = fmt.Errorf("repository.menuRepository.Search: %w", err)
+		err = fmt.Errorf("repository.orderRepository.Get: %w", err)
 		return nil, nil, err
 	}
 
 	return orders, nil, rows.Close()
 }
 
-func (repo *orderRepository) orderMenusInsertQuery(values []*model.Order) (string, []interface{}) {
+// UpdateCustomer change the customer (and the customer's email) of an unpaid order,
+// errNoRow is returned when the order is not found or not editable anymore
+func (repo *orderRepository) UpdateCustomer(ctx context.Context, businessID, orderID, customerID int64, email string) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateOrderCustomer, orderID, customerID, email, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateCustomer: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err == nil && nAffected == 0 {
+		err = fmt.Errorf("repository.orderRepository.UpdateCustomer: %w", sql.ErrNoRows)
+		return 0, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateCustomer: %w", err)
+		return 0, nil, err
+	}
+
+	return nAffected, nil, nil
+}
+
+// AddItem add a menu to an existing unpaid order, customer's email, status and delivery are taken from the order
+// (item.DeliveryDate is the day the portions are taken from the menu). item.Qty is added to the order's item of the menu
+// when there is one, otherwise a new item is inserted, the order is repriced by reprice at once.
+// errNoRow is returned when the order is not found or not editable anymore
+// and MenuSoldOutError when the menu doesn't have enough portions left
+func (repo *orderRepository) AddItem(ctx context.Context, businessID int64, item *model.Order, reprice OrderRepricer) (baseOrderID int64, errNoRow error, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, lockOrder, item.OrderID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, nil, err
+	}
+
+	err = reserveMenus(ctx, tx, businessID, map[int64]int{item.MenuID: item.Qty}, item.DeliveryDate)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, nil, err
+	}
+
+	// the qty is added once the order is locked, the concurrent adds of the same menu don't lose each other's portions
+	err = tx.QueryRowContext(ctx, addOrderItemQty, item.OrderID, item.MenuID, item.Qty, businessID).Scan(&baseOrderID)
+	if err == sql.ErrNoRows {
+		err = tx.QueryRowContext(ctx, insertOrderItem, item.OrderID, item.MenuID, item.MenuName, item.Price, item.Qty, businessID).Scan(&baseOrderID)
+	}
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, nil, err
+	}
+
+	errNoRow, err = repriceItems(ctx, tx, businessID, item.OrderID, reprice)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("repository.orderRepository.AddItem: %w", errNoRow)
+		return 0, errNoRow, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.AddItem: %w", err)
+		return 0, nil, err
+	}
+
+	return baseOrderID, nil, nil
+}
+
+// UpdateItemQty change qty of an item of an unpaid order, the added portions are taken from the menu on the delivery date
+// and the removed ones are given back to its stock, the order is repriced by reprice at once. errNoRow is returned when
+// the item is not found or the order is not editable anymore and MenuSoldOutError when the menu doesn't have enough
+// portions left
+func (repo *orderRepository) UpdateItemQty(ctx context.Context, businessID, orderID, baseOrderID int64, qty int, reprice OrderRepricer) (nAffected int64, errNoRow error, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, lockOrder, orderID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	var (
+		menuID  int64
+		prevQty int
+		day     string
+	)
+	err = tx.QueryRowContext(ctx, getOrderItemForUpdate, orderID, baseOrderID, businessID).Scan(&menuID, &prevQty, &day)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	if qty > prevQty {
+		err = reserveMenus(ctx, tx, businessID, map[int64]int{menuID: qty - prevQty}, day)
+	} else if qty < prevQty {
+		_, err = tx.ExecContext(ctx, updateMenuStock, menuID, prevQty-qty)
+	}
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, updateOrderItemQty, orderID, baseOrderID, qty, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err == nil && nAffected == 0 {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", sql.ErrNoRows)
+		return 0, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	errNoRow, err = repriceItems(ctx, tx, businessID, orderID, reprice)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", errNoRow)
+		return 0, errNoRow, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.UpdateItemQty: %w", err)
+		return 0, nil, err
+	}
+
+	return nAffected, nil, nil
+}
+
+// DeleteItem remove an item of an unpaid order and give its portions back to the menu's stock, the order is repriced
+// by reprice at once. The last item of an order is never removed (cancel the order instead). errNoRow is returned when
+// the item is not found, it's the last item or the order is not editable anymore
+func (repo *orderRepository) DeleteItem(ctx context.Context, businessID, orderID, baseOrderID int64, reprice OrderRepricer) (nAffected int64, errNoRow error, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.DeleteItem: %w", err)
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, lockOrder, orderID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.DeleteItem: %w", err)
+		return 0, nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, deleteOrderItem, orderID, baseOrderID, businessID).Scan(&nAffected)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.DeleteItem: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		err = fmt.Errorf("repository.orderRepository.DeleteItem: %w", sql.ErrNoRows)
+		return 0, err, nil
+	}
+
+	errNoRow, err = repriceItems(ctx, tx, businessID, orderID, reprice)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("repository.orderRepository.DeleteItem: %w", errNoRow)
+		return 0, errNoRow, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.DeleteItem: %w", err)
+		return 0, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.DeleteItem: %w", err)
+		return 0, nil, err
+	}
+
+	return nAffected, nil, nil
+}
+
+// GetDiscount return the discount line of the order, errNoRow is returned when no promotion is applied to the order
+func (repo *orderRepository) GetDiscount(ctx context.Context, businessID, orderID int64) (discount *model.OrderDiscount, errNoRow error, err error) {
+	discount = &model.OrderDiscount{}
+	err = repo.postgres.QueryRowContext(ctx, getOrderDiscount, orderID, businessID).Scan(
+		&discount.ID,
+		&discount.OrderID,
+		&discount.PromotionID,
+		&discount.Code,
+		&discount.CustomerEmail,
+		&discount.Amount,
+		&discount.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.orderRepository.GetDiscount: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.GetDiscount: %w", err)
+		return nil, nil, err
+	}
+
+	return discount, nil, nil
+}
+
+// GetCharges return the charge of every given order which has one
+func (repo *orderRepository) GetCharges(ctx context.Context, businessID int64, orderIDs []int64) (charges []*model.OrderCharge, err error) {
+	charges = make([]*model.OrderCharge, 0, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return charges, nil
+	}
+
+	ids := make([]string, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+
+	rows, err := repo.postgres.QueryContext(ctx, listOrderCharges, strings.Join(ids, ","), businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.GetCharges: %w", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		charge := new(model.OrderCharge)
+		err = rows.Scan(
+			&charge.OrderID,
+			&charge.SubTotal,
+			&charge.Discount,
+			&charge.ServiceCharge,
+			&charge.ServiceChargeRate,
+			&charge.Tax,
+			&charge.TaxInclusive,
+			&charge.DeliveryFee,
+			&charge.GrandTotal,
+			&charge.CreatedAt,
+			&charge.UpdatedAt,
+		)
+		if err != nil {
+			err = fmt.Errorf("repository.orderRepository.GetCharges: %w", err)
+			return nil, err
+		}
+
+		charges = append(charges, charge)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.GetCharges: %w", err)
+		return nil, err
+	}
+
+	return charges, rows.Close()
+}
+
+// ListByDeliveryDate return the rows (with their menu's categories) of the orders delivered on the given date (YYYY-MM-DD)
+// which are neither cancelled nor refunded, ordered by menu's id then delivery slot
+func (repo *orderRepository) ListByDeliveryDate(ctx context.Context, businessID int64, date string) (orders []*model.Order, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listOrderByDeliveryDate, date, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.ListByDeliveryDate: %w", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders = make([]*model.Order, 0)
+	for rows.Next() {
+		order := new(model.Order)
+		err = rows.Scan(
+			&order.BaseOrderID,
+			&order.OrderID,
+			&order.CustomerEmail,
+			&order.MenuID,
+			&order.MenuName,
+			&order.MenuCategories,
+			&order.Qty,
+			&order.Status,
+			&order.DeliveryDate,
+			&order.DeliveryStart,
+			&order.DeliveryEnd,
+			&order.Notes,
+		)
+		if err != nil {
+			err = fmt.Errorf("repository.orderRepository.ListByDeliveryDate: %w", err)
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("repository.orderRepository.ListByDeliveryDate: %w", err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// insertOrders book the delivery slot, take the ordered portions from the menus on the delivery date
+// and insert the orders with their delivery and charge. A customer without id is only created (or found by its email)
+// once the order is accepted, customer.ID and the orders' customer id are set to its id
+func (repo *orderRepository) insertOrders(ctx context.Context, tx *sql.Tx, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, charge *model.OrderCharge) (baseOrderID int64, OrderID int64, err error) {
+	if delivery.MaxOrders > 0 {
+		_, err = tx.ExecContext(ctx, lockDeliverySlot, delivery.Date, delivery.Start, businessID)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		var nOrders int
+		err = tx.QueryRowContext(ctx, countDeliverySlotOrders, delivery.Date, delivery.Start, businessID).Scan(&nOrders)
+		if err != nil {
+			return 0, 0, err
+		}
+		if nOrders >= delivery.MaxOrders {
+			return 0, 0, ErrDeliverySlotFull
+		}
+	}
+
+	qtys := make(map[int64]int, len(orders))
+	for _, order := range orders {
+		qtys[order.MenuID] += order.Qty
+	}
+
+	err = reserveMenus(ctx, tx, businessID, qtys, delivery.Date)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if customer.ID == 0 {
+		err = tx.QueryRowContext(ctx, findOrCreateCustomer,
+			customer.Name, customer.Email, customer.Phone, customer.DietaryNotes, customer.MarketingConsent, businessID).
+			Scan(&customer.ID)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	for _, order := range orders {
+		order.CustomerID = customer.ID
+	}
+
+	query, args := repo.orderMenusInsertQuery(businessID, orders, delivery, charge)
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&baseOrderID, &OrderID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return baseOrderID, OrderID, nil
+}
+
+// repriceItems read the order's rows within tx once its items have been changed and record the discount and the charge
+// returned by reprice, errNoRow is returned when the order is not found or not editable anymore
+func repriceItems(ctx context.Context, tx *sql.Tx, businessID, orderID int64, reprice OrderRepricer) (errNoRow error, err error) {
+	rows, err := tx.QueryContext(ctx, getOrderByID, orderID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := make([]*model.Order, 0)
+	for rows.Next() {
+		order := new(model.Order)
+		err = rows.Scan(orderColumns(order)...)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows must be closed before the next statement of tx
+	err = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return sql.ErrNoRows, nil
+	}
+
+	discount, charge, err := reprice(orders)
+	if err != nil {
+		return nil, err
+	}
+
+	if orders[0].PromoCode != "" {
+		res, err := tx.ExecContext(ctx, updateOrderDiscountAmount, orderID, discount, businessID)
+		if err != nil {
+			return nil, err
+		}
+
+		nAffected, err := res.RowsAffected()
+		if err == nil && nAffected == 0 {
+			return sql.ErrNoRows, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, upsertOrderCharge, orderID, charge.SubTotal, charge.Discount,
+		charge.ServiceCharge, charge.ServiceChargeRate, charge.Tax, charge.TaxInclusive, charge.DeliveryFee, charge.GrandTotal, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	nAffected, err := res.RowsAffected()
+	if err == nil && nAffected == 0 {
+		return sql.ErrNoRows, nil
+	}
+
+	return nil, err
+}
+
+// orderColumns return the destinations of the columns of getOrderByID
+func orderColumns(order *model.Order) []interface{} {
+	return []interface{}{
+		&order.BaseOrderID,
+		&order.OrderID,
+		&order.CustomerID,
+		&order.CustomerEmail,
+		&order.MenuID,
+		&order.MenuName,
+		&order.Price,
+		&order.Qty,
+		&order.Status,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.DeliveryDate,
+		&order.DeliveryStart,
+		&order.DeliveryEnd,
+		&order.Notes,
+		&order.DeliveryAddress,
+		&order.DeliveryPostalCode,
+		&order.DeliveryLatitude,
+		&order.DeliveryLongitude,
+		&order.DeliveryZoneID,
+		&order.PromoCode,
+		&order.Discount,
+		&order.ServiceCharge,
+		&order.Tax,
+		&order.TaxInclusive,
+		&order.DeliveryFee,
+	}
+}
+
+// reserveMenus take the given portions (qty by menu's id) from the stock and the daily capacity of the given day
+// (YYYY-MM-DD, empty is today) of every menu. The menus are locked by ascending id so concurrent orders are serialized
+// without deadlock, MenuSoldOutError is returned when a menu doesn't have enough portions left, has been deleted
+// or isn't a menu of the business.
+// The ordered rows must be inserted in the same transaction afterward since the daily capacity is counted from them.
+func reserveMenus(ctx context.Context, tx *sql.Tx, businessID int64, qtys map[int64]int, day string) error {
+	menuIDs := make([]int64, 0, len(qtys))
+	for menuID := range qtys {
+		menuIDs = append(menuIDs, menuID)
+	}
+	sort.Slice(menuIDs, func(i, j int) bool { return menuIDs[i] < menuIDs[j] })
+
+	for _, menuID := range menuIDs {
+		qty := qtys[menuID]
+		var stock, dailyCapacity sql.NullInt64
+		err := tx.QueryRowContext(ctx, getMenuAvailabilityForUpdate, menuID, businessID).Scan(&stock, &dailyCapacity)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("repository.reserveMenus: %w", &MenuSoldOutError{MenuID: menuID})
+		}
+		if err != nil {
+			return fmt.Errorf("repository.reserveMenus: %w", err)
+		}
+
+		remaining := int64(-1) // unlimited
+		if stock.Valid {
+			remaining = stock.Int64
+		}
+
+		if dailyCapacity.Valid {
+			var ordered int64
+			err = tx.QueryRowContext(ctx, countMenuOrderedQty, menuID, day).Scan(&ordered)
+			if err != nil {
+				return fmt.Errorf("repository.reserveMenus: %w", err)
+			}
+
+			left := dailyCapacity.Int64 - ordered
+			if left < 0 {
+				left = 0
+			}
+			if remaining < 0 || left < remaining {
+				remaining = left
+			}
+		}
+
+		if remaining >= 0 && remaining < int64(qty) {
+			return fmt.Errorf("repository.reserveMenus: %w", &MenuSoldOutError{MenuID: menuID, Remaining: int(remaining)})
+		}
+
+		if stock.Valid {
+			_, err = tx.ExecContext(ctx, updateMenuStock, menuID, -qty)
+			if err != nil {
+				return fmt.Errorf("repository.reserveMenus: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (repo *orderRepository) orderMenusInsertQuery(businessID int64, values []*model.Order, delivery *model.OrderDelivery, charge *model.OrderCharge) (string, []interface{}) {
 	if len(values) == 0 {
 		return "", []interface{}{}
 	}
-	stmt := `INSERT INTO "order"(customer_email, menu_id, menu_name, price, qty, status) VALUES %s RETURNING base_order_id, order_id`
-	nCols := 6
+	// the first status of the order is recorded as well so the history always starts from the creation,
+	// the charge's args follow the orders' args and every order has the same delivery,
+	// the destination's args and then the business follow the charge's args and are shared by every row
+	stmt := `
+	WITH inserted AS (
+		INSERT INTO "order"(customer_id, customer_email, menu_id, menu_name, price, qty, status, delivery_date, delivery_start, delivery_end, notes,
+			delivery_address, delivery_postal_code, delivery_latitude, delivery_longitude, delivery_zone_id, business_id)
+		VALUES %s RETURNING base_order_id, order_id, status
+	), history AS (
+		INSERT INTO order_status_history (order_id, to_status) SELECT DISTINCT order_id, status FROM inserted
+	), charge AS (
+		INSERT INTO order_charge
+			(order_id, sub_total, discount, service_charge, service_charge_rate, tax, tax_inclusive, delivery_fee, grand_total)
+		SELECT order_id, %s FROM inserted LIMIT 1
+	)
+	SELECT base_order_id, order_id FROM inserted ORDER BY base_order_id DESC LIMIT 1`
+	nCols := 11
+	chargeTypes := []string{"BIGINT", "BIGINT", "BIGINT", "INT4", "BIGINT", "BOOLEAN", "BIGINT", "BIGINT"}
+	nDestination := len(values)*nCols + len(chargeTypes) // the last arg before the destination's args
+	destinationStmt := fmt.Sprintf(`$%d, $%d, $%d::FLOAT8, $%d::FLOAT8, NULLIF($%d, 0)::BIGINT, $%d::BIGINT`,
+		nDestination+1, nDestination+2, nDestination+3, nDestination+4, nDestination+5, nDestination+6)
 	valuesStmt := make([]string, 0, len(values))
 	args := make([]interface{}, 0, len(values))
 	nRowArgs := 0 // start with zero for easier calculation
 	for _, val := range values {
 		valuesStmt = append(valuesStmt, fmt.Sprintf(
-			`($%d, $%d, $%d, $%d, $%d, $%d)`, ((nRowArgs*nCols)+1), ((nRowArgs*nCols)+2),
-			((nRowArgs*nCols)+3), ((nRowArgs*nCols)+4), ((nRowArgs*nCols)+5), ((nRowArgs*nCols)+6)))
+			`($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d::DATE, NULLIF($%d, '')::TIME, NULLIF($%d, '')::TIME, $%d, %s)`, ((nRowArgs*nCols)+1), ((nRowArgs*nCols)+2),
+			((nRowArgs*nCols)+3), ((nRowArgs*nCols)+4), ((nRowArgs*nCols)+5), ((nRowArgs*nCols)+6),
+			((nRowArgs*nCols)+7), ((nRowArgs*nCols)+8), ((nRowArgs*nCols)+9), ((nRowArgs*nCols)+10), ((nRowArgs*nCols)+11), destinationStmt))
 		nRowArgs += 1
 
+		args = append(args, val.CustomerID)
 		args = append(args, val.CustomerEmail)
 		args = append(args, val.MenuID)
 		args = append(args, val.MenuName)
 		args = append(args, val.Price)
 		args = append(args, val.Qty)
 		args = append(args, val.Status)
+		args = append(args, delivery.Date)
+		args = append(args, delivery.Start)
+		args = append(args, delivery.End)
+		args = append(args, val.Notes)
+	}
+
+	chargeStmt := make([]string, 0, len(chargeTypes))
+	for i, typ := range chargeTypes {
+		chargeStmt = append(chargeStmt, fmt.Sprintf("$%d::%s", nRowArgs*nCols+i+1, typ))
 	}
-	stmt = fmt.Sprintf(stmt, strings.Join(valuesStmt, ","))
+	args = append(args, charge.SubTotal, charge.Discount, charge.ServiceCharge, charge.ServiceChargeRate,
+		charge.Tax, charge.TaxInclusive, charge.DeliveryFee, charge.GrandTotal)
+
+	destination := delivery.Destination
+	args = append(args, destination.Address, destination.PostalCode, destination.Latitude, destination.Longitude, delivery.ZoneID, businessID)
+
+	stmt = fmt.Sprintf(stmt, strings.Join(valuesStmt, ","), strings.Join(chargeStmt, ", "))
 
 	return stmt, args
 }
@@ -178,6 +934,12 @@ func (repo *orderRepository) scanSearchOrderColumn(rows *sql.Rows, order *model.
 			toScanValue = append(toScanValue, &order.CreatedAt)
 		case "updated_at":
 			toScanValue = append(toScanValue, &order.UpdatedAt)
+		case "delivery_date":
+			toScanValue = append(toScanValue, &order.DeliveryDate)
+		case "delivery_start":
+			toScanValue = append(toScanValue, &order.DeliveryStart)
+		case "delivery_end":
+			toScanValue = append(toScanValue, &order.DeliveryEnd)
 		}
 	}
 	fmt.Println("toScanValue: ", toScanValue)
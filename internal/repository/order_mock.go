@@ -35,6 +35,22 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddItem mocks base method.
+func (m *MockOrderRepository) AddItem(ctx context.Context, businessID int64, item *model.Order, reprice OrderRepricer) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, businessID, item, reprice)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockOrderRepositoryMockRecorder) AddItem(ctx, businessID, item, reprice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockOrderRepository)(nil).AddItem), ctx, businessID, item, reprice)
+}
+
 // CancelUnpaidOrder mocks base method.
 func (m *MockOrderRepository) CancelUnpaidOrder(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -50,42 +66,136 @@ func (mr *MockOrderRepositoryMockRecorder) CancelUnpaidOrder(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUnpaidOrder", reflect.TypeOf((*MockOrderRepository)(nil).CancelUnpaidOrder), ctx)
 }
 
-// ConfirmPayment mocks base method.
-func (m *MockOrderRepository) ConfirmPayment(ctx context.Context, email string) (int64, error, error) {
+// Create mocks base method.
+func (m *MockOrderRepository) Create(ctx context.Context, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, charge *model.OrderCharge) (int64, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, email)
+	ret := m.ctrl.Call(m, "Create", ctx, businessID, customer, orders, delivery, charge)
 	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
+	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// ConfirmPayment indicates an expected call of ConfirmPayment.
-func (mr *MockOrderRepositoryMockRecorder) ConfirmPayment(ctx, email interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockOrderRepositoryMockRecorder) Create(ctx, businessID, customer, orders, delivery, charge interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockOrderRepository)(nil).ConfirmPayment), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepository)(nil).Create), ctx, businessID, customer, orders, delivery, charge)
 }
 
-// Create mocks base method.
-func (m *MockOrderRepository) Create(ctx context.Context, orders []*model.Order) (int64, int64, error) {
+// CreateWithDiscount mocks base method.
+func (m *MockOrderRepository) CreateWithDiscount(ctx context.Context, businessID int64, customer *model.Customer, orders []*model.Order, delivery *model.OrderDelivery, discount *model.OrderDiscount, charge *model.OrderCharge) (int64, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, orders)
+	ret := m.ctrl.Call(m, "CreateWithDiscount", ctx, businessID, customer, orders, delivery, discount, charge)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockOrderRepositoryMockRecorder) Create(ctx, orders interface{}) *gomock.Call {
+// CreateWithDiscount indicates an expected call of CreateWithDiscount.
+func (mr *MockOrderRepositoryMockRecorder) CreateWithDiscount(ctx, businessID, customer, orders, delivery, discount, charge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithDiscount", reflect.TypeOf((*MockOrderRepository)(nil).CreateWithDiscount), ctx, businessID, customer, orders, delivery, discount, charge)
+}
+
+// DeleteItem mocks base method.
+func (m *MockOrderRepository) DeleteItem(ctx context.Context, businessID, orderID, baseOrderID int64, reprice OrderRepricer) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, businessID, orderID, baseOrderID, reprice)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockOrderRepositoryMockRecorder) DeleteItem(ctx, businessID, orderID, baseOrderID, reprice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockOrderRepository)(nil).DeleteItem), ctx, businessID, orderID, baseOrderID, reprice)
+}
+
+// Get mocks base method.
+func (m *MockOrderRepository) Get(ctx context.Context, businessID, orderID int64) ([]*model.Order, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, businessID, orderID)
+	ret0, _ := ret[0].([]*model.Order)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrderRepositoryMockRecorder) Get(ctx, businessID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderRepository)(nil).Get), ctx, businessID, orderID)
+}
+
+// GetCharges mocks base method.
+func (m *MockOrderRepository) GetCharges(ctx context.Context, businessID int64, orderIDs []int64) ([]*model.OrderCharge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCharges", ctx, businessID, orderIDs)
+	ret0, _ := ret[0].([]*model.OrderCharge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCharges indicates an expected call of GetCharges.
+func (mr *MockOrderRepositoryMockRecorder) GetCharges(ctx, businessID, orderIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharges", reflect.TypeOf((*MockOrderRepository)(nil).GetCharges), ctx, businessID, orderIDs)
+}
+
+// GetDiscount mocks base method.
+func (m *MockOrderRepository) GetDiscount(ctx context.Context, businessID, orderID int64) (*model.OrderDiscount, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscount", ctx, businessID, orderID)
+	ret0, _ := ret[0].(*model.OrderDiscount)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDiscount indicates an expected call of GetDiscount.
+func (mr *MockOrderRepositoryMockRecorder) GetDiscount(ctx, businessID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscount", reflect.TypeOf((*MockOrderRepository)(nil).GetDiscount), ctx, businessID, orderID)
+}
+
+// GetStatus mocks base method.
+func (m *MockOrderRepository) GetStatus(ctx context.Context, businessID, orderID int64) (int, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx, businessID, orderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockOrderRepositoryMockRecorder) GetStatus(ctx, businessID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockOrderRepository)(nil).GetStatus), ctx, businessID, orderID)
+}
+
+// ListByDeliveryDate mocks base method.
+func (m *MockOrderRepository) ListByDeliveryDate(ctx context.Context, businessID int64, date string) ([]*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDeliveryDate", ctx, businessID, date)
+	ret0, _ := ret[0].([]*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDeliveryDate indicates an expected call of ListByDeliveryDate.
+func (mr *MockOrderRepositoryMockRecorder) ListByDeliveryDate(ctx, businessID, date interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepository)(nil).Create), ctx, orders)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDeliveryDate", reflect.TypeOf((*MockOrderRepository)(nil).ListByDeliveryDate), ctx, businessID, date)
 }
 
 // Search mocks base method.
-func (m *MockOrderRepository) Search(ctx context.Context, order model.OrderQuery) ([]*model.Order, error, error) {
+func (m *MockOrderRepository) Search(ctx context.Context, businessID int64, order model.OrderQuery) ([]*model.Order, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, order)
+	ret := m.ctrl.Call(m, "Search", ctx, businessID, order)
 	ret0, _ := ret[0].([]*model.Order)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -93,7 +203,71 @@ func (m *MockOrderRepository) Search(ctx context.Context, order model.OrderQuery
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockOrderRepositoryMockRecorder) Search(ctx, order interface{}) *gomock.Call {
+func (mr *MockOrderRepositoryMockRecorder) Search(ctx, businessID, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderRepository)(nil).Search), ctx, businessID, order)
+}
+
+// StatusHistory mocks base method.
+func (m *MockOrderRepository) StatusHistory(ctx context.Context, businessID, orderID int64) ([]*model.OrderStatusHistory, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, businessID, orderID)
+	ret0, _ := ret[0].([]*model.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockOrderRepositoryMockRecorder) StatusHistory(ctx, businessID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockOrderRepository)(nil).StatusHistory), ctx, businessID, orderID)
+}
+
+// UpdateCustomer mocks base method.
+func (m *MockOrderRepository) UpdateCustomer(ctx context.Context, businessID, orderID, customerID int64, email string) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", ctx, businessID, orderID, customerID, email)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer.
+func (mr *MockOrderRepositoryMockRecorder) UpdateCustomer(ctx, businessID, orderID, customerID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockOrderRepository)(nil).UpdateCustomer), ctx, businessID, orderID, customerID, email)
+}
+
+// UpdateItemQty mocks base method.
+func (m *MockOrderRepository) UpdateItemQty(ctx context.Context, businessID, orderID, baseOrderID int64, qty int, reprice OrderRepricer) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemQty", ctx, businessID, orderID, baseOrderID, qty, reprice)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateItemQty indicates an expected call of UpdateItemQty.
+func (mr *MockOrderRepositoryMockRecorder) UpdateItemQty(ctx, businessID, orderID, baseOrderID, qty, reprice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemQty", reflect.TypeOf((*MockOrderRepository)(nil).UpdateItemQty), ctx, businessID, orderID, baseOrderID, qty, reprice)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, businessID, orderID int64, from, to int, changedBy int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, businessID, orderID, from, to, changedBy)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(ctx, businessID, orderID, from, to, changedBy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderRepository)(nil).Search), ctx, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), ctx, businessID, orderID, from, to, changedBy)
 }
@@ -2,11 +2,12 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"family-catering/internal/model"
 	"family-catering/pkg/db/postgres"
-	"regexp"
+	"family-catering/pkg/money"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -26,6 +27,56 @@ func makeNAnyArgs(nRow int, nCols int) []driver.Value {
 	return args
 }
 
+// expect the given menus are locked by reserveMenus, the menus have neither stock nor daily capacity
+// should be use only for testing purpose
+func expectUnlimitedMenus(pgMock sqlmock.Sqlmock, menuIDs ...int64) {
+	for _, menuID := range menuIDs {
+		pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(menuID, int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"stock", "daily_capacity"}).AddRow(nil, nil))
+	}
+}
+
+// expect the customer of the email is created (or found) along with the order
+// should be use only for testing purpose
+func expectCustomerCreated(pgMock sqlmock.Sqlmock, email string, id int64) {
+	pgMock.ExpectQuery(`INSERT INTO customer.+ON CONFLICT \(business_id, email\) DO UPDATE.+RETURNING id`).
+		WithArgs("", email, "", "", false, int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+}
+
+// the columns of getOrderByID
+var orderCols = []string{"base_order_id", "order_id", "customer_id", "customer_email", "menu_id", "menu_name", "price", "qty", "status", "created_at", "updated_at", "delivery_date", "delivery_start", "delivery_end", "notes",
+	"delivery_address", "delivery_postal_code", "delivery_latitude", "delivery_longitude", "delivery_zone_id", "promo_code", "discount", "service_charge", "tax", "tax_inclusive", "delivery_fee"}
+
+// testRepricer reprice every order to testCharge without discount
+// should be use only for testing purpose
+func testRepricer(orders []*model.Order) (money.Money, *model.OrderCharge, error) {
+	return money.FromMinor(0), testCharge, nil
+}
+
+var testCharge = &model.OrderCharge{
+	OrderID: 1, SubTotal: money.MustParse("50000"), ServiceCharge: money.MustParse("2500"), ServiceChargeRate: 500,
+	Tax: money.MustParse("5775"), GrandTotal: money.MustParse("58275"),
+}
+
+// expect the order 1 is locked before its items are changed
+// should be use only for testing purpose
+func expectOrderLocked(pgMock sqlmock.Sqlmock) {
+	pgMock.ExpectExec(`SELECT 1 FROM "order" WHERE order_id = \$1 FOR UPDATE`).WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expect the order 1 (without promo code) is repriced by testRepricer once its items are changed
+// should be use only for testing purpose
+func expectItemsRepriced(pgMock sqlmock.Sqlmock) {
+	pgMock.ExpectQuery(`SELECT.*FROM.*"order" o.*o.order_id = \$1`).WithArgs(int64(1), int64(1)).
+		WillReturnRows(sqlmock.NewRows(orderCols).
+			AddRow(int64(1), int64(1), int64(3), "test@example.com", int64(1), "sate", int64(2_500_000), 2, 1, "2022-11-10 10:00:00", "2022-11-10 10:00:00", "2022-11-11", "", "", "", "", "", nil, nil, nil, "", int64(0), int64(0), int64(0), false, int64(0)))
+	pgMock.ExpectExec(`INSERT INTO order_charge.+status IN \(1, 4\).+ON CONFLICT \(order_id\) DO UPDATE`).
+		WithArgs(int64(1), int64(5_000_000), int64(0), int64(250_000), int64(500), int64(577_500), false, int64(0), int64(5_827_500), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
 func TestNewOrderRepository(t *testing.T) {
 	type args struct {
 		postgres postgres.PostgresClient
@@ -43,8 +94,12 @@ func TestNewOrderRepository(t *testing.T) {
 
 func Test_orderRepository_Create(t *testing.T) {
 	type args struct {
-		ctx    context.Context
-		orders []*model.Order
+		ctx        context.Context
+		businessID int64
+		customer   *model.Customer
+		orders     []*model.Order
+		delivery   *model.OrderDelivery
+		charge     *model.OrderCharge
 	}
 	type mocks struct {
 		numOfOrders int
@@ -63,17 +118,30 @@ func Test_orderRepository_Create(t *testing.T) {
 			name: "success Create",
 			repo: &orderRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
+				customer:   &model.Customer{Email: "test@examle.com"},
 				orders: []*model.Order{
-					{CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: 25_000, Qty: 15, Status: 0},
-					{CustomerEmail: "test@examle.com", MenuID: 4, MenuName: "Bebek Bakar", Price: 75_000, Qty: 3, Status: 0},
-					{CustomerEmail: "test@examle.com", MenuID: 16, MenuName: "Pindang Ikan Kakap", Price: 45_000, Qty: 5, Status: 0}},
+					{CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: money.MustParse("25000"), Qty: 15, Status: 0},
+					{CustomerEmail: "test@examle.com", MenuID: 4, MenuName: "Bebek Bakar", Price: money.MustParse("75000"), Qty: 3, Status: 0},
+					{CustomerEmail: "test@examle.com", MenuID: 16, MenuName: "Pindang Ikan Kakap", Price: money.MustParse("45000"), Qty: 5, Status: 0}},
+				delivery: &model.OrderDelivery{Date: "2026-10-17", Start: "10:00", End: "12:00"},
+				charge: &model.OrderCharge{
+					SubTotal: money.MustParse("585000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("29250"), ServiceChargeRate: 500,
+					Tax: money.MustParse("67567.50"), GrandTotal: money.MustParse("681817.50"),
+				},
 			},
 			prepareMocks: func(m *mocks) {
-				args := makeNAnyArgs(m.numOfOrders, 6) // 6 is number of cols inserted (see orderRepository.orderMenuInsertQuery at ./order.go )
-				m.pgMock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "order"`)).
-					WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"}).AddRow(3, 1)).
+				args := makeNAnyArgs(m.numOfOrders, 11) // 11 is number of cols inserted (see orderRepository.orderMenuInsertQuery at ./order.go )
+				args = append(args, int64(58_500_000), int64(0), int64(2_925_000), int64(500), int64(6_756_750), false, int64(0), int64(68_181_750))
+				args = append(args, "", "", nil, nil, int64(0))
+				m.pgMock.ExpectBegin()
+				expectUnlimitedMenus(m.pgMock, 1, 4, 16)
+				expectCustomerCreated(m.pgMock, "test@examle.com", 3)
+				m.pgMock.ExpectQuery(`INSERT INTO "order".*\$42, \$43, \$44::FLOAT8, \$45::FLOAT8, NULLIF\(\$46, 0\)::BIGINT, \$47::BIGINT\).*INSERT INTO order_charge.*SELECT order_id, \$34::BIGINT.*\$41::BIGINT FROM inserted`).
+					WithArgs(append(args, int64(1))...).WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"}).AddRow(3, 1)).
 					WillReturnError(nil)
+				m.pgMock.ExpectCommit()
 			},
 			wantBaseOrderId: 3,
 			wantOrderID:     1,
@@ -82,17 +150,106 @@ func Test_orderRepository_Create(t *testing.T) {
 			name: "fail Create",
 			repo: &orderRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
+				customer:   &model.Customer{Email: "test@examle.com"},
 				orders: []*model.Order{
-					{CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: 25_000, Qty: 15, Status: 0},
-					{CustomerEmail: "test@examle.com", MenuID: 4, MenuName: "Bebek Bakar", Price: 75_000, Qty: 3, Status: 0},
-					{CustomerEmail: "test@examle.com", MenuID: 16, MenuName: "Pindang Ikan Kakap", Price: 45_000, Qty: 5, Status: 0}},
+					{CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: money.MustParse("25000"), Qty: 15, Status: 0},
+					{CustomerEmail: "test@examle.com", MenuID: 4, MenuName: "Bebek Bakar", Price: money.MustParse("75000"), Qty: 3, Status: 0},
+					{CustomerEmail: "test@examle.com", MenuID: 16, MenuName: "Pindang Ikan Kakap", Price: money.MustParse("45000"), Qty: 5, Status: 0}},
+				delivery: &model.OrderDelivery{Date: "2026-10-17", Start: "10:00", End: "12:00"},
+				charge: &model.OrderCharge{
+					SubTotal: money.MustParse("585000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("29250"), ServiceChargeRate: 500,
+					Tax: money.MustParse("67567.50"), GrandTotal: money.MustParse("681817.50"),
+				},
 			},
 			prepareMocks: func(m *mocks) {
-				args := makeNAnyArgs(m.numOfOrders, 6) // 6 is number of cols inserted (see orderRepository.orderMenuInsertQuery at ./order.go )
+				args := makeNAnyArgs(m.numOfOrders*11+8+5, 1) // 11 is number of cols inserted (see orderRepository.orderMenuInsertQuery at ./order.go ), 8 of the charge and 5 of the destination
+				m.pgMock.ExpectBegin()
+				expectUnlimitedMenus(m.pgMock, 1, 4, 16)
+				expectCustomerCreated(m.pgMock, "test@examle.com", 3)
 				m.pgMock.ExpectQuery(`INSERT INTO "order"`).
-					WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"})).
+					WithArgs(append(args, int64(1))...).WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"})).
 					WillReturnError(errors.New("oops! db error"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (sold out)",
+			repo: &orderRepository{},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				customer:   &model.Customer{Email: "test@examle.com"},
+				orders: []*model.Order{
+					{CustomerEmail: "test@examle.com", MenuID: 4, MenuName: "Bebek Bakar", Price: money.MustParse("75000"), Qty: 3, Status: 0},
+					{CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: money.MustParse("25000"), Qty: 15, Status: 0}},
+				delivery: &model.OrderDelivery{Date: "2026-10-17"},
+				charge:   &model.OrderCharge{SubTotal: money.MustParse("600000"), Discount: money.FromMinor(0), GrandTotal: money.MustParse("600000")},
+			},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectUnlimitedMenus(m.pgMock, 1)
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(4), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"stock", "daily_capacity"}).AddRow(2, nil))
+				// the customer isn't created for a rejected order
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success Create (delivery slot with max orders)",
+			repo: &orderRepository{},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				customer:   &model.Customer{ID: 3, Email: "test@examle.com"},
+				orders: []*model.Order{
+					{CustomerID: 3, CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: money.MustParse("25000"), Qty: 2, Status: 0, Notes: "no peanut"}},
+				delivery: &model.OrderDelivery{Date: "2026-10-17", Start: "10:00", End: "12:00", MaxOrders: 20,
+					Destination: model.DeliveryAddress{
+						Address: "Jl. Kenanga 5", PostalCode: "12430",
+						Latitude: sql.NullFloat64{Float64: -6.2615, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.8106, Valid: true},
+					},
+					ZoneID: 2,
+				},
+				charge: &model.OrderCharge{SubTotal: money.MustParse("50000"), Discount: money.FromMinor(0), DeliveryFee: money.MustParse("10000"), GrandTotal: money.MustParse("60000")},
+			},
+			prepareMocks: func(m *mocks) {
+				args := []driver.Value{int64(3), "test@examle.com", int64(1), "Sate", int64(2_500_000), 2, 0, "2026-10-17", "10:00", "12:00", "no peanut"}
+				args = append(args, int64(5_000_000), int64(0), int64(0), int64(0), int64(0), false, int64(1_000_000), int64(6_000_000))
+				args = append(args, "Jl. Kenanga 5", "12430", -6.2615, 106.8106, int64(2))
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`SELECT pg_advisory_xact_lock`).WithArgs("2026-10-17", "10:00", int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectQuery(`SELECT\s+COUNT\(DISTINCT order_id\)`).WithArgs("2026-10-17", "10:00", int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(19))
+				expectUnlimitedMenus(m.pgMock, 1)
+				m.pgMock.ExpectQuery(`INSERT INTO "order"`).
+					WithArgs(append(args, int64(1))...).WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"}).AddRow(3, 1))
+				m.pgMock.ExpectCommit()
+			},
+			wantBaseOrderId: 3,
+			wantOrderID:     1,
+		},
+		{
+			name: "fail Create (delivery slot is full)",
+			repo: &orderRepository{},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				customer:   &model.Customer{Email: "test@examle.com"},
+				orders: []*model.Order{
+					{CustomerEmail: "test@examle.com", MenuID: 1, MenuName: "Sate", Price: money.MustParse("25000"), Qty: 2, Status: 0}},
+				delivery: &model.OrderDelivery{Date: "2026-10-17", Start: "10:00", End: "12:00", MaxOrders: 20},
+				charge:   &model.OrderCharge{SubTotal: money.MustParse("50000"), Discount: money.FromMinor(0), GrandTotal: money.MustParse("50000")},
+			},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`SELECT pg_advisory_xact_lock`).WithArgs("2026-10-17", "10:00", int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectQuery(`SELECT\s+COUNT\(DISTINCT order_id\)`).WithArgs("2026-10-17", "10:00", int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(20))
+				m.pgMock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -110,10 +267,16 @@ func Test_orderRepository_Create(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock, numOfOrders: len(tt.args.orders)})
 			}
 
-			gotBaseOrderId, gotOrderID, err := tt.repo.Create(tt.args.ctx, tt.args.orders)
+			gotBaseOrderId, gotOrderID, err := tt.repo.Create(tt.args.ctx, tt.args.businessID, tt.args.customer, tt.args.orders, tt.args.delivery, tt.args.charge)
 			assert.Equal(t, tt.wantBaseOrderId, gotBaseOrderId)
 			assert.Equal(t, tt.wantOrderID, gotOrderID)
 			assert.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				for _, order := range tt.args.orders {
+					assert.Equal(t, int64(3), order.CustomerID)
+				}
+			}
+			assert.NoError(t, pgMock.ExpectationsWereMet())
 
 		})
 	}
@@ -139,7 +302,7 @@ func Test_orderRepository_CancelUnpaidOrder(t *testing.T) {
 			repo: &orderRepository{},
 			args: args{ctx: context.Background()},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 1.*`).WillReturnResult(sqlmock.NewResult(0, 10)).WillReturnError(nil)
+				m.pgMock.ExpectExec(`UPDATE "order".*status = 3.*status IN \(1, 4\).*`).WillReturnResult(sqlmock.NewResult(0, 10)).WillReturnError(nil)
 			},
 			want: 10,
 		},
@@ -148,7 +311,7 @@ func Test_orderRepository_CancelUnpaidOrder(t *testing.T) {
 			repo: &orderRepository{},
 			args: args{ctx: context.Background()},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 1.*`).WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(errors.New("oop! error db"))
+				m.pgMock.ExpectExec(`UPDATE "order".*status = 3.*status IN \(1, 4\).*`).WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(errors.New("oop! error db"))
 			},
 			wantErr: true,
 		},
@@ -157,7 +320,7 @@ func Test_orderRepository_CancelUnpaidOrder(t *testing.T) {
 			repo: &orderRepository{},
 			args: args{ctx: context.Background()},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 1.*`).WillReturnResult(sqlmock.NewErrorResult(errors.New("oop! row affected error")))
+				m.pgMock.ExpectExec(`UPDATE "order".*status = 3.*status IN \(1, 4\).*`).WillReturnResult(sqlmock.NewErrorResult(errors.New("oop! row affected error")))
 			},
 			wantErr: true,
 		},
@@ -182,55 +345,129 @@ func Test_orderRepository_CancelUnpaidOrder(t *testing.T) {
 	}
 }
 
-func Test_orderRepository_ConfirmPayment(t *testing.T) {
+func Test_orderRepository_Search(t *testing.T) {
 	type args struct {
-		ctx   context.Context
-		email string
+		ctx        context.Context
+		businessID int64
+		order      model.OrderQuery
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
 	}
 	tests := []struct {
-		name          string
-		repo          *orderRepository
-		args          args
-		prepareMocks  func(*mocks)
-		wantNAffected int64
-		wantErr       bool
+		name         string
+		repo         *orderRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantOrders   []*model.Order
+		wantErr      bool
 	}{
 		{
-			name: "success ConfirmPayment",
+			name: "success Search",
 			repo: &orderRepository{},
-			args: args{ctx: context.Background(), email: "test@example.com"},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				order: model.OrderQuery{
+					MenuNames:           []string{"nasi", "soto"},
+					ExactMenuNamesMatch: false,
+					MinPrice:            money.MustParse("10000"),
+					MaxPrice:            money.MustParse("100000"),
+				},
+			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 2.*email.*`).WillReturnResult(sqlmock.NewResult(0, 1)).WillReturnError(nil)
+				args := makeNAnyArgs(3, 1)
+				m.pgMock.ExpectQuery(`SELECT.*"order".*WHERE`).
+					WithArgs(append(args, int64(1))...).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
+							"price", "qty", "created_at", "updated_at"}).
+							AddRow(22, 48, "nasi lemak", "test1@example.com", int64(2_000_000), 5, "", "").
+							AddRow(3, 7, "nasi goreng", "test6@example.com", int64(2_500_000), 1, "", "").
+							AddRow(56, 92, "soto betawi", "test56@example.com", int64(4_000_000), 3, "", "").
+							AddRow(19, 32, "soto babat", "test112@example.com", int64(3_000_000), 10, "", "")).
+					WillReturnError(nil)
+			},
+			wantOrders: []*model.Order{
+				{OrderID: 22, BaseOrderID: 48, MenuName: "nasi lemak", CustomerEmail: "test1@example.com", Price: money.MustParse("20000"), Qty: 5},
+				{OrderID: 3, BaseOrderID: 7, MenuName: "nasi goreng", CustomerEmail: "test6@example.com", Price: money.MustParse("25000"), Qty: 1},
+				{OrderID: 56, BaseOrderID: 92, MenuName: "soto betawi", CustomerEmail: "test56@example.com", Price: money.MustParse("40000"), Qty: 3},
+				{OrderID: 19, BaseOrderID: 32, MenuName: "soto babat", CustomerEmail: "test112@example.com", Price: money.MustParse("30000"), Qty: 10},
 			},
-			wantNAffected: 1,
 		},
 		{
-			name: "fail ConfirmPayment (no rows affected)",
+			name: "success Search (by created and delivery day)",
 			repo: &orderRepository{},
-			args: args{ctx: context.Background(), email: "not.exists@example.com"},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				order: model.OrderQuery{
+					StartDay:         "2022-11-01",
+					EndDay:           "2022-11-10",
+					DeliveryStartDay: "2022-11-11",
+					DeliveryEndDay:   "2022-11-11",
+				},
+			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 2.*email.*`).WithArgs("not.exists@example.com").WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(nil)
+				m.pgMock.ExpectQuery(`WHERE created_at >= \$1 AND created_at < \$2 AND delivery_date >= \$3::DATE AND delivery_date <= \$4::DATE`).
+					WithArgs("2022-11-01", "2022-11-10", "2022-11-11", "2022-11-11", int64(1)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
+							"price", "qty", "created_at", "updated_at", "delivery_date", "delivery_start", "delivery_end"}).
+							AddRow(22, 48, "nasi lemak", "test1@example.com", int64(2_000_000), 5, "", "", "2022-11-11", "10:00", "12:00"))
+			},
+			wantOrders: []*model.Order{
+				{OrderID: 22, BaseOrderID: 48, MenuName: "nasi lemak", CustomerEmail: "test1@example.com", Price: money.MustParse("20000"), Qty: 5,
+					DeliveryDate: "2022-11-11", DeliveryStart: "10:00", DeliveryEnd: "12:00"},
 			},
-			wantErr: true,
 		},
 		{
-			name: "fail ConfirmPayment (rows affected error)",
+			name: "fail Search (no rows)",
 			repo: &orderRepository{},
-			args: args{ctx: context.Background(), email: "not.exists@example.com"},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				order: model.OrderQuery{
+					MenuNames: []string{"not-exists-name-1", "not-exists-name-2"},
+					MinPrice:  money.MustParse("10000"),
+					MaxPrice:  money.MustParse("100000"),
+				},
+			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 2.*email.*`).WithArgs("not.exists@example.com").WillReturnResult(sqlmock.NewErrorResult(errors.New("oops! row affected error"))).WillReturnError(nil)
+				args := makeNAnyArgs(3, 1)
+				m.pgMock.ExpectQuery(`SELECT.*"order".*WHERE`).
+					WithArgs(append(args, int64(1))...).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
+							"price", "qty", "created_at", "updated_at"})).
+					WillReturnError(nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail ConfirmPayment (db error)",
+			name: "fail Search (db error)",
 			repo: &orderRepository{},
-			args: args{ctx: context.Background(), email: "not.exists@example.com"},
+			args: args{
+				ctx:        context.Background(),
+				businessID: 1,
+				order: model.OrderQuery{
+					MenuNames: []string{"nasi", "soto"},
+					MinPrice:  money.MustParse("10000"),
+					MaxPrice:  money.MustParse("100000"),
+				},
+			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectExec(`UPDATE "order".*status = 2.*email.*`).WithArgs("not.exists@example.com").WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(errors.New("oops! row affected error"))
+				args := makeNAnyArgs(3, 1)
+				m.pgMock.ExpectQuery(`SELECT.*"order".*WHERE`).
+					WithArgs(append(args, int64(1))...).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
+							"price", "qty", "created_at", "updated_at"}).
+							AddRow(22, 48, "nasi lemak", "test1@example.com", int64(2_000_000), 5, "", "").
+							AddRow(3, 7, "nasi goreng", "test6@example.com", int64(2_500_000), 1, "", "").
+							AddRow(56, 92, "soto betawi", "test56@example.com", int64(4_000_000), 3, "", "").
+							AddRow(19, 32, "soto babat", "test112@example.com", int64(3_000_000), 10, "", "")).
+					WillReturnError(errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -248,17 +485,18 @@ func Test_orderRepository_ConfirmPayment(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
-			gotNAffected, errNoRow, err := tt.repo.ConfirmPayment(tt.args.ctx, tt.args.email)
-			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil))
-			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			gotOrders, errNoRow, err := tt.repo.Search(tt.args.ctx, tt.args.businessID, tt.args.order)
+			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil), err)
+			assert.Equal(t, tt.wantOrders, gotOrders)
 		})
 	}
 }
 
-func Test_orderRepository_Search(t *testing.T) {
+func Test_orderRepository_GetStatus(t *testing.T) {
 	type args struct {
-		ctx   context.Context
-		order model.OrderQuery
+		ctx        context.Context
+		businessID int64
+		orderID    int64
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -268,85 +506,209 @@ func Test_orderRepository_Search(t *testing.T) {
 		repo         *orderRepository
 		args         args
 		prepareMocks func(*mocks)
-		wantOrders   []*model.Order
+		wantStatus   int
 		wantErr      bool
 	}{
 		{
-			name: "success Search",
+			name: "success GetStatus",
 			repo: &orderRepository{},
-			args: args{
-				ctx: context.Background(),
-				order: model.OrderQuery{
-					MenuNames:           []string{"nasi", "soto"},
-					ExactMenuNamesMatch: false,
-					MinPrice:            10_000,
-					MaxPrice:            100_000,
-				},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT status FROM "order" WHERE order_id`).
+					WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(4))
+			},
+			wantStatus: 4,
+		},
+		{
+			name: "fail GetStatus (no row)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1_000_000_000},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT status FROM "order" WHERE order_id`).
+					WithArgs(int64(1_000_000_000), int64(1)).
+					WillReturnError(sql.ErrNoRows)
 			},
+			wantErr: true,
+		},
+		{
+			name: "fail GetStatus (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
 			prepareMocks: func(m *mocks) {
-				args := makeNAnyArgs(3, 1)
-				m.pgMock.ExpectQuery(`SELECT.*"order".*WHERE`).
-					WithArgs(args...).
-					WillReturnRows(
-						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
-							"price", "qty", "created_at", "updated_at"}).
-							AddRow(22, 48, "nasi lemak", "test1@example.com", 20_000, 5, "", "").
-							AddRow(3, 7, "nasi goreng", "test6@example.com", 25_000, 1, "", "").
-							AddRow(56, 92, "soto betawi", "test56@example.com", 40_000, 3, "", "").
-							AddRow(19, 32, "soto babat", "test112@example.com", 30_000, 10, "", "")).
-					WillReturnError(nil)
+				m.pgMock.ExpectQuery(`SELECT status FROM "order" WHERE order_id`).
+					WithArgs(int64(1), int64(1)).
+					WillReturnError(errors.New("oops! db error"))
 			},
-			wantOrders: []*model.Order{
-				{OrderID: 22, BaseOrderID: 48, MenuName: "nasi lemak", CustomerEmail: "test1@example.com", Price: 20_000, Qty: 5},
-				{OrderID: 3, BaseOrderID: 7, MenuName: "nasi goreng", CustomerEmail: "test6@example.com", Price: 25_000, Qty: 1},
-				{OrderID: 56, BaseOrderID: 92, MenuName: "soto betawi", CustomerEmail: "test56@example.com", Price: 40_000, Qty: 3},
-				{OrderID: 19, BaseOrderID: 32, MenuName: "soto babat", CustomerEmail: "test112@example.com", Price: 30_000, Qty: 10},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotStatus, errNoRow, err := tt.repo.GetStatus(tt.args.ctx, tt.args.businessID, tt.args.orderID)
+			assert.Equal(t, tt.wantErr, err != nil || errNoRow != nil)
+			assert.Equal(t, tt.wantStatus, gotStatus)
+		})
+	}
+}
+
+func Test_orderRepository_UpdateStatus(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderID    int64
+		from       int
+		to         int
+		changedBy  int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *orderRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success UpdateStatus",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, from: 2, to: 5, changedBy: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE "order" SET status.*INSERT INTO order_status_history`).
+					WithArgs(int64(1), 2, 5, int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
+			wantNAffected: 1,
 		},
 		{
-			name: "fail Search (no rows)",
+			name: "fail UpdateStatus (status has been changed)",
 			repo: &orderRepository{},
-			args: args{
-				ctx: context.Background(),
-				order: model.OrderQuery{
-					MenuNames: []string{"not-exists-name-1", "not-exists-name-2"},
-					MinPrice:  10_000,
-					MaxPrice:  100_000,
-				},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, from: 2, to: 5, changedBy: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE "order" SET status.*INSERT INTO order_status_history`).
+					WithArgs(int64(1), 2, 5, int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail UpdateStatus (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, from: 2, to: 5, changedBy: 1},
 			prepareMocks: func(m *mocks) {
-				args := makeNAnyArgs(3, 1)
-				m.pgMock.ExpectQuery(`SELECT.*"order".*WHERE`).
-					WithArgs(args...).
-					WillReturnRows(
-						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
-							"price", "qty", "created_at", "updated_at"})).
-					WillReturnError(nil)
+				m.pgMock.ExpectExec(`UPDATE "order" SET status.*INSERT INTO order_status_history`).
+					WithArgs(int64(1), 2, 5, int64(1), int64(1)).
+					WillReturnError(errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail Search (db error)",
+			name: "fail UpdateStatus (rows affected error)",
 			repo: &orderRepository{},
-			args: args{
-				ctx: context.Background(),
-				order: model.OrderQuery{
-					MenuNames: []string{"nasi", "soto"},
-					MinPrice:  10_000,
-					MaxPrice:  100_000,
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, from: 2, to: 5, changedBy: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE "order" SET status.*INSERT INTO order_status_history`).
+					WithArgs(int64(1), 2, 5, int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("oops! rows affected error")))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.UpdateStatus(tt.args.ctx, tt.args.businessID, tt.args.orderID, tt.args.from, tt.args.to, tt.args.changedBy)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+		})
+	}
+}
+
+func Test_orderRepository_StatusHistory(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderID    int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := []string{"id", "order_id", "from_status", "to_status", "changed_by", "created_at"}
+	tests := []struct {
+		name          string
+		repo          *orderRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantHistories []*model.OrderStatusHistory
+		wantErr       bool
+	}{
+		{
+			name: "success StatusHistory",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM order_status_history.*order_id`).
+					WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(int64(1), int64(1), nil, 1, nil, "2022-11-10 10:00:00").
+						AddRow(int64(2), int64(1), 1, 2, int64(3), "2022-11-10 11:00:00"))
+			},
+			wantHistories: []*model.OrderStatusHistory{
+				{ID: 1, OrderID: 1, ToStatus: 1, CreatedAt: "2022-11-10 10:00:00"},
+				{
+					ID:         2,
+					OrderID:    1,
+					FromStatus: sql.NullInt32{Int32: 1, Valid: true},
+					ToStatus:   2,
+					ChangedBy:  sql.NullInt64{Int64: 3, Valid: true},
+					CreatedAt:  "2022-11-10 11:00:00",
 				},
 			},
+		},
+		{
+			name: "fail StatusHistory (no row)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1_000_000_000},
 			prepareMocks: func(m *mocks) {
-				args := makeNAnyArgs(3, 1)
-				m.pgMock.ExpectQuery(`SELECT.*"order".*WHERE`).
-					WithArgs(args...).
-					WillReturnRows(
-						sqlmock.NewRows([]string{"order_id", "base_order_id", "menu_name", "customer_email",
-							"price", "qty", "created_at", "updated_at"}).
-							AddRow(22, 48, "nasi lemak", "test1@example.com", 20_000, 5, "", "").
-							AddRow(3, 7, "nasi goreng", "test6@example.com", 25_000, 1, "", "").
-							AddRow(56, 92, "soto betawi", "test56@example.com", 40_000, 3, "", "").
-							AddRow(19, 32, "soto babat", "test112@example.com", 30_000, 10, "", "")).
+				m.pgMock.ExpectQuery(`SELECT.*FROM order_status_history.*order_id`).
+					WithArgs(int64(1_000_000_000), int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail StatusHistory (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM order_status_history.*order_id`).
+					WithArgs(int64(1), int64(1)).
 					WillReturnError(errors.New("oops! db error"))
 			},
 			wantErr: true,
@@ -365,9 +727,1103 @@ func Test_orderRepository_Search(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: pgMock})
 			}
 
-			gotOrders, errNoRow, err := tt.repo.Search(tt.args.ctx, tt.args.order)
-			assert.Equal(t, tt.wantErr, (err != nil || errNoRow != nil), err)
+			gotHistories, errNoRow, err := tt.repo.StatusHistory(tt.args.ctx, tt.args.businessID, tt.args.orderID)
+			assert.Equal(t, tt.wantErr, err != nil || errNoRow != nil)
+			assert.Equal(t, tt.wantHistories, gotHistories)
+		})
+	}
+}
+
+func Test_orderRepository_Get(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderID    int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := orderCols
+	tests := []struct {
+		name         string
+		repo         *orderRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantOrders   []*model.Order
+		wantErr      bool
+	}{
+		{
+			name: "success Get",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*order_id = \$1`).
+					WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(int64(1), int64(1), int64(3), "test@example.com", int64(1), "sate", int64(2_500_000), 2, 1, "2022-11-10 10:00:00", "2022-11-10 10:00:00", "2022-11-11", "10:00", "12:00", "no peanut", "Jl. Kenanga 5", "12430", nil, nil, int64(2), "", int64(0), int64(0), int64(0), false, int64(1_000_000)).
+						AddRow(int64(2), int64(1), int64(3), "test@example.com", int64(2), "es teh", int64(500_000), 3, 1, "2022-11-10 10:00:00", "2022-11-10 10:00:00", "2022-11-11", "10:00", "12:00", "no peanut", "Jl. Kenanga 5", "12430", nil, nil, int64(2), "", int64(0), int64(0), int64(0), false, int64(1_000_000)))
+			},
+			wantOrders: []*model.Order{
+				{BaseOrderID: 1, OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: 1, CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00", DeliveryDate: "2022-11-11", DeliveryStart: "10:00", DeliveryEnd: "12:00", Notes: "no peanut", Discount: money.FromMinor(0), ServiceCharge: money.FromMinor(0), Tax: money.FromMinor(0),
+					DeliveryAddress: "Jl. Kenanga 5", DeliveryPostalCode: "12430", DeliveryZoneID: sql.NullInt64{Int64: 2, Valid: true}, DeliveryFee: money.MustParse("10000")},
+				{BaseOrderID: 2, OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", MenuID: 2, MenuName: "es teh", Price: money.MustParse("5000"), Qty: 3, Status: 1, CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00", DeliveryDate: "2022-11-11", DeliveryStart: "10:00", DeliveryEnd: "12:00", Notes: "no peanut", Discount: money.FromMinor(0), ServiceCharge: money.FromMinor(0), Tax: money.FromMinor(0),
+					DeliveryAddress: "Jl. Kenanga 5", DeliveryPostalCode: "12430", DeliveryZoneID: sql.NullInt64{Int64: 2, Valid: true}, DeliveryFee: money.MustParse("10000")},
+			},
+		},
+		{
+			name: "success Get (with discount)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order" o.*LEFT JOIN.*order_discount.*o.order_id = \$1`).
+					WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(int64(1), int64(1), int64(3), "test@example.com", int64(1), "sate", int64(2_500_000), 2, 1, "2022-11-10 10:00:00", "2022-11-10 10:00:00", "2022-11-11", "", "", "", "", "", nil, nil, nil, "HEMAT10", int64(500_000), int64(225_000), int64(519_750), false, int64(0)))
+			},
+			wantOrders: []*model.Order{
+				{BaseOrderID: 1, OrderID: 1, CustomerID: 3, CustomerEmail: "test@example.com", MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: 1, CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00", DeliveryDate: "2022-11-11", PromoCode: "HEMAT10", Discount: money.MustParse("5000"),
+					ServiceCharge: money.MustParse("2250"), Tax: money.MustParse("5197.50"), DeliveryFee: money.FromMinor(0)},
+			},
+		},
+		{
+			name: "fail Get (no row)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1_000_000_000},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*order_id = \$1`).
+					WithArgs(int64(1_000_000_000), int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Get (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*order_id = \$1`).
+					WithArgs(int64(1), int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotOrders, errNoRow, err := tt.repo.Get(tt.args.ctx, tt.args.businessID, tt.args.orderID)
+			assert.Equal(t, tt.wantErr, err != nil || errNoRow != nil)
+			assert.Equal(t, tt.wantOrders, gotOrders)
+		})
+	}
+}
+
+func Test_orderRepository_UpdateCustomer(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderID    int64
+		customerID int64
+		email      string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *orderRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success UpdateCustomer",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, customerID: 3, email: "new@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE "order" SET customer_id = \$2, customer_email = \$3.*status IN \(1, 4\)`).
+					WithArgs(int64(1), int64(3), "new@example.com", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantNAffected: 2,
+		},
+		{
+			name: "fail UpdateCustomer (not editable)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, customerID: 3, email: "new@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE "order" SET customer_id = \$2, customer_email = \$3.*status IN \(1, 4\)`).
+					WithArgs(int64(1), int64(3), "new@example.com", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail UpdateCustomer (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, customerID: 3, email: "new@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE "order" SET customer_id = \$2, customer_email = \$3.*status IN \(1, 4\)`).
+					WithArgs(int64(1), int64(3), "new@example.com", int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.UpdateCustomer(tt.args.ctx, tt.args.businessID, tt.args.orderID, tt.args.customerID, tt.args.email)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+		})
+	}
+}
+
+func Test_orderRepository_AddItem(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		item       *model.Order
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	item := &model.Order{OrderID: 1, MenuID: 2, MenuName: "es teh", Price: money.MustParse("5000"), Qty: 3}
+	tests := []struct {
+		name            string
+		repo            *orderRepository
+		args            args
+		prepareMocks    func(*mocks)
+		wantBaseOrderID int64
+		wantErrNoRow    bool
+		wantErr         error
+	}{
+		{
+			name: "success AddItem",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, item: item},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"stock", "daily_capacity"}).AddRow(10, nil))
+				m.pgMock.ExpectExec(`UPDATE menu SET stock = stock \+ \$2`).WithArgs(int64(2), -3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectQuery(`UPDATE "order" SET qty = qty \+ \$3.+menu_id = \$2 AND status IN \(1, 4\).+RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id"}))
+				m.pgMock.ExpectQuery(`INSERT INTO "order".*SELECT.*status IN \(1, 4\).*RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), "es teh", int64(500_000), 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id"}).AddRow(int64(10)))
+				expectItemsRepriced(m.pgMock)
+				m.pgMock.ExpectCommit()
+			},
+			wantBaseOrderID: 10,
+		},
+		{
+			name: "success AddItem (menu already ordered)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, item: item},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				expectUnlimitedMenus(m.pgMock, 2)
+				m.pgMock.ExpectQuery(`UPDATE "order" SET qty = qty \+ \$3.+menu_id = \$2 AND status IN \(1, 4\).+RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id"}).AddRow(int64(7)))
+				expectItemsRepriced(m.pgMock)
+				m.pgMock.ExpectCommit()
+			},
+			wantBaseOrderID: 7,
+		},
+		{
+			name: "fail AddItem (not editable)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, item: item},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				expectUnlimitedMenus(m.pgMock, 2)
+				m.pgMock.ExpectQuery(`UPDATE "order" SET qty = qty \+ \$3.+menu_id = \$2 AND status IN \(1, 4\).+RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id"}))
+				m.pgMock.ExpectQuery(`INSERT INTO "order".*SELECT.*status IN \(1, 4\).*RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), "es teh", int64(500_000), 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id"}))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail AddItem (sold out)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, item: item},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"stock", "daily_capacity"}).AddRow(nil, 20))
+				m.pgMock.ExpectQuery(`SELECT.+SUM\(qty\).+FROM.+"order".+menu_id = \$1 AND status NOT IN \(3, 9\)`).WithArgs(int64(2), "").
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(18)))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: ErrMenuSoldOut,
+		},
+		{
+			name: "fail AddItem (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, item: item},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				expectUnlimitedMenus(m.pgMock, 2)
+				m.pgMock.ExpectQuery(`UPDATE "order" SET qty = qty \+ \$3.+menu_id = \$2 AND status IN \(1, 4\).+RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id"}))
+				m.pgMock.ExpectQuery(`INSERT INTO "order".*SELECT.*status IN \(1, 4\).*RETURNING base_order_id`).
+					WithArgs(int64(1), int64(2), "es teh", int64(500_000), 3, int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: errors.New("oops! db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotBaseOrderID, errNoRow, err := tt.repo.AddItem(tt.args.ctx, tt.args.businessID, tt.args.item, testRepricer)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if errors.Is(tt.wantErr, ErrMenuSoldOut) {
+				var soldOut *MenuSoldOutError
+				assert.ErrorAs(t, err, &soldOut)
+				assert.Equal(t, &MenuSoldOutError{MenuID: 2, Remaining: 2}, soldOut)
+			}
+			assert.Equal(t, tt.wantBaseOrderID, gotBaseOrderID)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_orderRepository_UpdateItemQty(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		businessID  int64
+		orderID     int64
+		baseOrderID int64
+		qty         int
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	itemCols := []string{"menu_id", "qty", "created_at"}
+	tests := []struct {
+		name          string
+		repo          *orderRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       error
+	}{
+		{
+			name: "success UpdateItemQty (more portions)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2, qty: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.+menu_id, qty.+FROM.+"order".+base_order_id = \$2.+FOR UPDATE`).WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows(itemCols).AddRow(int64(7), 2, "2022-10-01"))
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(7), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"stock", "daily_capacity"}).AddRow(nil, 20))
+				m.pgMock.ExpectQuery(`SELECT.+SUM\(qty\).+FROM.+"order"`).WithArgs(int64(7), "2022-10-01").
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(17)))
+				m.pgMock.ExpectExec(`UPDATE "order" SET qty.*base_order_id = \$2.*status IN \(1, 4\)`).
+					WithArgs(int64(1), int64(2), 5, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectItemsRepriced(m.pgMock)
+				m.pgMock.ExpectCommit()
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "success UpdateItemQty (less portions)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2, qty: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.+menu_id, qty.+FROM.+"order".+FOR UPDATE`).WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows(itemCols).AddRow(int64(7), 8, "2022-10-01"))
+				m.pgMock.ExpectExec(`UPDATE menu SET stock = stock \+ \$2`).WithArgs(int64(7), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`UPDATE "order" SET qty`).WithArgs(int64(1), int64(2), 5, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectItemsRepriced(m.pgMock)
+				m.pgMock.ExpectCommit()
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail UpdateItemQty (no row)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2, qty: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.+menu_id, qty.+FROM.+"order".+FOR UPDATE`).WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows(itemCols))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail UpdateItemQty (sold out)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2, qty: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.+menu_id, qty.+FROM.+"order".+FOR UPDATE`).WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows(itemCols).AddRow(int64(7), 2, "2022-10-01"))
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(7), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"stock", "daily_capacity"}).AddRow(10, 20))
+				m.pgMock.ExpectQuery(`SELECT.+SUM\(qty\).+FROM.+"order"`).WithArgs(int64(7), "2022-10-01").
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(18)))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: ErrMenuSoldOut,
+		},
+		{
+			name: "fail UpdateItemQty (rows affected error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2, qty: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.+menu_id, qty.+FROM.+"order".+FOR UPDATE`).WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows(itemCols).AddRow(int64(7), 5, "2022-10-01"))
+				m.pgMock.ExpectExec(`UPDATE "order" SET qty.*base_order_id = \$2.*status IN \(1, 4\)`).
+					WithArgs(int64(1), int64(2), 5, int64(1)).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("oops! rows affected error")))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: errors.New("oops! rows affected error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.UpdateItemQty(tt.args.ctx, tt.args.businessID, tt.args.orderID, tt.args.baseOrderID, tt.args.qty, testRepricer)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if errors.Is(tt.wantErr, ErrMenuSoldOut) {
+				assert.ErrorIs(t, err, ErrMenuSoldOut)
+			}
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_orderRepository_DeleteItem(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		businessID  int64
+		orderID     int64
+		baseOrderID int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *orderRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success DeleteItem",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`DELETE FROM "order".*base_order_id = \$2.*status IN \(1, 4\).*COUNT.*UPDATE menu.*stock \+ d.qty.*SELECT COUNT\(\*\) FROM deleted`).
+					WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+				expectItemsRepriced(m.pgMock)
+				m.pgMock.ExpectCommit()
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail DeleteItem (last item or not editable)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`DELETE FROM "order".*base_order_id = \$2.*status IN \(1, 4\).*COUNT`).
+					WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(0)))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail DeleteItem (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`DELETE FROM "order".*base_order_id = \$2.*status IN \(1, 4\).*COUNT`).
+					WithArgs(int64(1), int64(2), int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail DeleteItem (lock error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1, baseOrderID: 2},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`SELECT 1 FROM "order" WHERE order_id = \$1 FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.DeleteItem(tt.args.ctx, tt.args.businessID, tt.args.orderID, tt.args.baseOrderID, testRepricer)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_orderRepository_CreateWithDiscount(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		customer   *model.Customer
+		orders     []*model.Order
+		delivery   *model.OrderDelivery
+		discount   *model.OrderDiscount
+		charge     *model.OrderCharge
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	customer := &model.Customer{ID: 3, Email: "test@example.com"}
+	orders := []*model.Order{
+		{CustomerEmail: "test@example.com", MenuID: 1, MenuName: "Sate", Price: money.MustParse("25000"), Qty: 4, Status: 1},
+		{CustomerEmail: "test@example.com", MenuID: 4, MenuName: "Bebek Bakar", Price: money.MustParse("75000"), Qty: 1, Status: 1},
+	}
+	delivery := &model.OrderDelivery{Date: "2026-10-17"}
+	discount := &model.OrderDiscount{PromotionID: 7, Code: "HEMAT10", CustomerEmail: "test@example.com", Amount: money.MustParse("17500")}
+	charge := &model.OrderCharge{SubTotal: money.MustParse("175000"), Discount: money.MustParse("17500"), GrandTotal: money.MustParse("157500")}
+	usageCols := []string{"usage_limit", "usage_limit_per_customer", "count", "count"}
+	tests := []struct {
+		name            string
+		repo            *orderRepository
+		args            args
+		prepareMocks    func(*mocks)
+		wantBaseOrderID int64
+		wantOrderID     int64
+		wantErr         error
+	}{
+		{
+			name: "success CreateWithDiscount",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: customer, orders: orders, delivery: delivery, discount: discount, charge: charge},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`SELECT.+FROM promotion WHERE id = \$1 FOR UPDATE`).WithArgs(int64(7), "test@example.com").
+					WillReturnRows(sqlmock.NewRows(usageCols).AddRow(100, 1, 99, 0))
+				expectUnlimitedMenus(m.pgMock, 1, 4)
+				m.pgMock.ExpectQuery(`INSERT INTO "order"`).WithArgs(append(makeNAnyArgs(len(orders)*11+8+5, 1), int64(1))...).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"}).AddRow(int64(2), int64(1)))
+				m.pgMock.ExpectExec(`INSERT INTO order_discount`).WithArgs(int64(1), int64(7), "HEMAT10", "test@example.com", int64(1_750_000)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.pgMock.ExpectCommit()
+			},
+			wantBaseOrderID: 2,
+			wantOrderID:     1,
+		},
+		{
+			name: "success CreateWithDiscount (unlimited)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: customer, orders: orders, delivery: delivery, discount: discount, charge: charge},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`SELECT.+FROM promotion WHERE id = \$1 FOR UPDATE`).WithArgs(int64(7), "test@example.com").
+					WillReturnRows(sqlmock.NewRows(usageCols).AddRow(0, 0, 1_000, 10))
+				expectUnlimitedMenus(m.pgMock, 1, 4)
+				m.pgMock.ExpectQuery(`INSERT INTO "order"`).WithArgs(append(makeNAnyArgs(len(orders)*11+8+5, 1), int64(1))...).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"}).AddRow(int64(2), int64(1)))
+				m.pgMock.ExpectExec(`INSERT INTO order_discount`).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.pgMock.ExpectCommit()
+			},
+			wantBaseOrderID: 2,
+			wantOrderID:     1,
+		},
+		{
+			name: "fail CreateWithDiscount (global usage limit)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: customer, orders: orders, delivery: delivery, discount: discount, charge: charge},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`SELECT.+FROM promotion WHERE id = \$1 FOR UPDATE`).WithArgs(int64(7), "test@example.com").
+					WillReturnRows(sqlmock.NewRows(usageCols).AddRow(100, 0, 100, 0))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: ErrPromotionUsageLimit,
+		},
+		{
+			name: "fail CreateWithDiscount (customer usage limit)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: customer, orders: orders, delivery: delivery, discount: discount, charge: charge},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`SELECT.+FROM promotion WHERE id = \$1 FOR UPDATE`).WithArgs(int64(7), "test@example.com").
+					WillReturnRows(sqlmock.NewRows(usageCols).AddRow(100, 1, 10, 1))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: ErrPromotionUsageLimit,
+		},
+		{
+			name: "fail CreateWithDiscount (insert discount error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, customer: customer, orders: orders, delivery: delivery, discount: discount, charge: charge},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectQuery(`SELECT.+FROM promotion WHERE id = \$1 FOR UPDATE`).WithArgs(int64(7), "test@example.com").
+					WillReturnRows(sqlmock.NewRows(usageCols).AddRow(0, 0, 0, 0))
+				expectUnlimitedMenus(m.pgMock, 1, 4)
+				m.pgMock.ExpectQuery(`INSERT INTO "order"`).WithArgs(append(makeNAnyArgs(len(orders)*11+8+5, 1), int64(1))...).
+					WillReturnRows(sqlmock.NewRows([]string{"base_order_id", "order_id"}).AddRow(int64(2), int64(1)))
+				m.pgMock.ExpectExec(`INSERT INTO order_discount`).WillReturnError(errors.New("oops! db error"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: errors.New("oops! db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotBaseOrderID, gotOrderID, err := tt.repo.CreateWithDiscount(tt.args.ctx, tt.args.businessID, tt.args.customer, tt.args.orders, tt.args.delivery, tt.args.discount, tt.args.charge)
+			assert.Equal(t, tt.wantBaseOrderID, gotBaseOrderID)
+			assert.Equal(t, tt.wantOrderID, gotOrderID)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if errors.Is(tt.wantErr, ErrPromotionUsageLimit) {
+				assert.ErrorIs(t, err, ErrPromotionUsageLimit)
+			}
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_orderRepository_GetDiscount(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderID    int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := []string{"id", "order_id", "promotion_id", "code", "customer_email", "amount", "created_at"}
+	tests := []struct {
+		name         string
+		repo         *orderRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantDiscount *model.OrderDiscount
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success GetDiscount",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+order_discount.+order_id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(3), int64(1), int64(7), "HEMAT10", "test@example.com", int64(1_750_000), "2022-11-10 10:00:00"))
+			},
+			wantDiscount: &model.OrderDiscount{ID: 3, OrderID: 1, PromotionID: 7, Code: "HEMAT10", CustomerEmail: "test@example.com", Amount: money.MustParse("17500"), CreatedAt: "2022-11-10 10:00:00"},
+		},
+		{
+			name: "fail GetDiscount (no promotion applied)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+order_discount.+order_id = \$1`).WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows(cols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail GetDiscount (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+order_discount.+order_id = \$1`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotDiscount, errNoRow, err := tt.repo.GetDiscount(tt.args.ctx, tt.args.businessID, tt.args.orderID)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantDiscount, gotDiscount)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_orderRepository_GetCharges(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderIDs   []int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := []string{"order_id", "sub_total", "discount", "service_charge", "service_charge_rate", "tax", "tax_inclusive", "delivery_fee", "grand_total", "created_at", "updated_at"}
+	tests := []struct {
+		name         string
+		repo         *orderRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantCharges  []*model.OrderCharge
+		wantErr      bool
+	}{
+		{
+			name: "success GetCharges",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderIDs: []int64{1, 2}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+order_charge.+order_id = ANY\(string_to_array\(\$1, ','\)::BIGINT\[\]\)`).WithArgs("1,2", int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(int64(1), int64(5_000_000), int64(0), int64(250_000), 500, int64(577_500), false, int64(0), int64(5_827_500), "2022-11-10 10:00:00", "2022-11-10 10:00:00"))
+			},
+			wantCharges: []*model.OrderCharge{{
+				OrderID: 1, SubTotal: money.MustParse("50000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("2500"), ServiceChargeRate: 500,
+				Tax: money.MustParse("5775"), DeliveryFee: money.FromMinor(0), GrandTotal: money.MustParse("58275"), CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00",
+			}},
+		},
+		{
+			name:        "success GetCharges (no order)",
+			repo:        &orderRepository{},
+			args:        args{ctx: context.Background(), businessID: 1},
+			wantCharges: []*model.OrderCharge{},
+		},
+		{
+			name: "fail GetCharges (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderIDs: []int64{1}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+order_charge`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotCharges, err := tt.repo.GetCharges(tt.args.ctx, tt.args.businessID, tt.args.orderIDs)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCharges, gotCharges)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repriceItems(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	withPromo := func(pgMock sqlmock.Sqlmock) {
+		pgMock.ExpectQuery(`SELECT.*FROM.*"order" o.*o.order_id = \$1`).WithArgs(int64(1), int64(1)).
+			WillReturnRows(sqlmock.NewRows(orderCols).
+				AddRow(int64(1), int64(1), int64(3), "test@example.com", int64(1), "sate", int64(2_500_000), 2, 1, "2022-11-10 10:00:00", "2022-11-10 10:00:00", "2022-11-11", "", "", "", "", "", nil, nil, nil, "HEMAT10", int64(500_000), int64(225_000), int64(519_750), false, int64(0)))
+	}
+	discountRepricer := func(orders []*model.Order) (money.Money, *model.OrderCharge, error) {
+		return money.MustParse("5000"), testCharge, nil
+	}
+	tests := []struct {
+		name         string
+		reprice      OrderRepricer
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name:    "success repriceItems",
+			reprice: testRepricer,
+			prepareMocks: func(m *mocks) {
+				expectItemsRepriced(m.pgMock)
+			},
+		},
+		{
+			name:    "success repriceItems (with discount)",
+			reprice: discountRepricer,
+			prepareMocks: func(m *mocks) {
+				withPromo(m.pgMock)
+				m.pgMock.ExpectExec(`UPDATE order_discount SET amount = \$2.+status IN \(1, 4\)`).WithArgs(int64(1), int64(500_000), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`INSERT INTO order_charge`).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "fail repriceItems (no row)",
+			reprice: testRepricer,
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order" o.*o.order_id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(orderCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail repriceItems (reprice error)",
+			reprice: func(orders []*model.Order) (money.Money, *model.OrderCharge, error) {
+				return money.Money{}, nil, errors.New("oops! reprice error")
+			},
+			prepareMocks: func(m *mocks) {
+				withPromo(m.pgMock)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fail repriceItems (discount of an order not editable)",
+			reprice: discountRepricer,
+			prepareMocks: func(m *mocks) {
+				withPromo(m.pgMock)
+				m.pgMock.ExpectExec(`UPDATE order_discount SET amount = \$2`).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name:    "fail repriceItems (charge of an order not editable)",
+			reprice: discountRepricer,
+			prepareMocks: func(m *mocks) {
+				withPromo(m.pgMock)
+				m.pgMock.ExpectExec(`UPDATE order_discount SET amount = \$2`).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`INSERT INTO order_charge`).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name:    "fail repriceItems (db error)",
+			reprice: testRepricer,
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order" o.*o.order_id = \$1`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			pgMock.ExpectBegin()
+			tx, err := db.Begin()
+			if err != nil {
+				panic(err)
+			}
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			errNoRow, err := repriceItems(context.Background(), tx, 1, 1, tt.reprice)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_reserveMenus(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	availabilityCols := []string{"stock", "daily_capacity"}
+	tests := []struct {
+		name         string
+		qtys         map[int64]int
+		day          string
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success reserveMenus (locked by id, stock taken)",
+			qtys: map[int64]int{9: 2, 3: 4},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(3), int64(1)).
+					WillReturnRows(sqlmock.NewRows(availabilityCols).AddRow(4, 10))
+				m.pgMock.ExpectQuery(`SELECT.+SUM\(qty\).+FROM.+"order"`).WithArgs(int64(3), "").
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(6)))
+				m.pgMock.ExpectExec(`UPDATE menu SET stock = stock \+ \$2`).WithArgs(int64(3), -4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(9), int64(1)).
+					WillReturnRows(sqlmock.NewRows(availabilityCols).AddRow(nil, nil))
+			},
+		},
+		{
+			name: "fail reserveMenus (sold out by stock)",
+			qtys: map[int64]int{3: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(3), int64(1)).
+					WillReturnRows(sqlmock.NewRows(availabilityCols).AddRow(4, nil))
+			},
+			wantErr: &MenuSoldOutError{MenuID: 3, Remaining: 4},
+		},
+		{
+			name: "fail reserveMenus (sold out by daily capacity of the item's day)",
+			qtys: map[int64]int{3: 5},
+			day:  "2022-10-01",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(3), int64(1)).
+					WillReturnRows(sqlmock.NewRows(availabilityCols).AddRow(100, 10))
+				m.pgMock.ExpectQuery(`SELECT.+SUM\(qty\).+FROM.+"order"`).WithArgs(int64(3), "2022-10-01").
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(12))) // capacity has been lowered
+			},
+			wantErr: &MenuSoldOutError{MenuID: 3, Remaining: 0},
+		},
+		{
+			name: "fail reserveMenus (menu deleted)",
+			qtys: map[int64]int{3: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(3), int64(1)).
+					WillReturnRows(sqlmock.NewRows(availabilityCols))
+			},
+			wantErr: &MenuSoldOutError{MenuID: 3},
+		},
+		{
+			name: "fail reserveMenus (menu of another business)",
+			qtys: map[int64]int{3: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(3), int64(1)).
+					WillReturnRows(sqlmock.NewRows(availabilityCols)) // the menu belongs to business 2
+			},
+			wantErr: &MenuSoldOutError{MenuID: 3},
+		},
+		{
+			name: "fail reserveMenus (db error)",
+			qtys: map[int64]int{3: 5},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT stock, daily_capacity FROM menu WHERE id = \$1 AND business_id = \$2 FOR UPDATE`).WithArgs(int64(3), int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: errors.New("oops! db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			pgMock.ExpectBegin()
+			tx, err := db.Begin()
+			if err != nil {
+				panic(err)
+			}
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			err = reserveMenus(context.Background(), tx, 1, tt.qtys, tt.day)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			var soldOut *MenuSoldOutError
+			if errors.As(tt.wantErr, &soldOut) {
+				var gotSoldOut *MenuSoldOutError
+				assert.ErrorAs(t, err, &gotSoldOut)
+				assert.Equal(t, soldOut, gotSoldOut)
+				assert.ErrorIs(t, err, ErrMenuSoldOut)
+			}
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_orderRepository_ListByDeliveryDate(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		date       string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := []string{"base_order_id", "order_id", "customer_email", "menu_id", "menu_name", "categories", "qty", "status", "delivery_date", "delivery_start", "delivery_end", "notes"}
+	tests := []struct {
+		name         string
+		repo         *orderRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantOrders   []*model.Order
+		wantErr      bool
+	}{
+		{
+			name: "success ListByDeliveryDate",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+"order" o.+LEFT JOIN.+menu m.+o.delivery_date = \$1::DATE AND o.status NOT IN \(3, 9\)`).WithArgs("2022-11-11", int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(int64(1), int64(1), "test@example.com", int64(1), "sate", "Indonesian food", 2, 2, "2022-11-11", "10:00", "12:00", "no peanut").
+						AddRow(int64(3), int64(2), "test2@example.com", int64(1), "sate", "Indonesian food", 5, 1, "2022-11-11", "", "", ""))
+			},
+			wantOrders: []*model.Order{
+				{BaseOrderID: 1, OrderID: 1, CustomerEmail: "test@example.com", MenuID: 1, MenuName: "sate", MenuCategories: "Indonesian food", Qty: 2, Status: 2,
+					DeliveryDate: "2022-11-11", DeliveryStart: "10:00", DeliveryEnd: "12:00", Notes: "no peanut"},
+				{BaseOrderID: 3, OrderID: 2, CustomerEmail: "test2@example.com", MenuID: 1, MenuName: "sate", MenuCategories: "Indonesian food", Qty: 5, Status: 1,
+					DeliveryDate: "2022-11-11"},
+			},
+		},
+		{
+			name: "success ListByDeliveryDate (no order)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+"order" o`).WithArgs("2022-11-11", int64(1)).WillReturnRows(sqlmock.NewRows(cols))
+			},
+			wantOrders: []*model.Order{},
+		},
+		{
+			name: "fail ListByDeliveryDate (db error)",
+			repo: &orderRepository{},
+			args: args{ctx: context.Background(), businessID: 1, date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+"order" o`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotOrders, err := tt.repo.ListByDeliveryDate(tt.args.ctx, tt.args.businessID, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, tt.wantOrders, gotOrders)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
 		})
 	}
 }
@@ -8,16 +8,25 @@ import (
 	"fmt"
 )
 
+// OwnerRepository the owners are looked up by id or email regardless of their business (an owner is identified by them),
+// listing and managing the owners is restricted to the given business
 type OwnerRepository interface {
+	// Create create an owner (staff) of owner.BusinessID
 	Create(ctx context.Context, owner model.Owner) (id int64, err error)
+	// CreateWithBusiness create a new business named businessName along with the owner as its admin at once
+	CreateWithBusiness(ctx context.Context, owner model.Owner, businessName string) (id int64, err error)
 	Get(ctx context.Context, id int64) (owner *model.Owner, errNoRow error, err error)
 	GetByEmail(ctx context.Context, email string) (owner *model.Owner, errNoRow error, err error)
-	List(ctx context.Context, limit, offset int) (owners []*model.Owner, errNoRow error, err error)
-	Update(ctx context.Context, owner model.Owner) (nAffected int64, errNoRow error, err error)
+	List(ctx context.Context, businessID int64, limit, offset int) (owners []*model.Owner, errNoRow error, err error)
+	Update(ctx context.Context, businessID int64, owner model.Owner) (nAffected int64, errNoRow error, err error)
 	Delete(ctx context.Context, id int64) (nAffected int64, errNoRow error, err error)
 	UpdatePasswordByEmail(ctx context.Context, email, password string) (nAffected int64, errNoRow error, err error)
 	UpdatePasswordByID(ctx context.Context, id int64, password string) (nAffected int64, errNoRow error, err error)
 	UpdateEmailByID(ctx context.Context, id int64, email string) (nAffected int64, errNoRow error, err error)
+	// UpdateRoleByID errNoRow is returned when the owner is not found or is the last admin losing its role
+	UpdateRoleByID(ctx context.Context, businessID, id int64, role string) (nAffected int64, errNoRow error, err error)
+	// DeleteStaffByID errNoRow is returned when the owner is not found or is the last admin
+	DeleteStaffByID(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error)
 }
 
 type ownerRepository struct {
@@ -30,7 +39,7 @@ func NewOwnerRepository(postgreClient postgres.PostgresClient) OwnerRepository {
 
 func (repo *ownerRepository) Create(ctx context.Context, owner model.Owner) (int64, error) {
 	var id int64
-	err := repo.postgres.QueryRowContext(ctx, createOwner, owner.Name, owner.Email, owner.Password, owner.PhoneNumber).Scan(&id)
+	err := repo.postgres.QueryRowContext(ctx, createOwner, owner.Name, owner.Email, owner.Password, owner.PhoneNumber, owner.Role, owner.BusinessID).Scan(&id)
 	if err != nil {
 		err := fmt.Errorf("repository.ownerRepository.Create: %w", err)
 		return 0, err
@@ -39,12 +48,23 @@ func (repo *ownerRepository) Create(ctx context.Context, owner model.Owner) (int
 	return id, nil
 }
 
-func (repo *ownerRepository) Update(ctx context.Context, owner model.Owner) (int64, error, error) {
+func (repo *ownerRepository) CreateWithBusiness(ctx context.Context, owner model.Owner, businessName string) (int64, error) {
+	var id int64
+	err := repo.postgres.QueryRowContext(ctx, createOwnerWithBusiness, owner.Name, owner.Email, owner.Password, owner.PhoneNumber, businessName).Scan(&id)
+	if err != nil {
+		err := fmt.Errorf("repository.ownerRepository.CreateWithBusiness: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo *ownerRepository) Update(ctx context.Context, businessID int64, owner model.Owner) (int64, error, error) {
 	var (
 		err       error
 		nAffected int64
 	)
-	res, err := repo.postgres.ExecContext(ctx, updateOwner, owner.Id, owner.Name, owner.PhoneNumber, owner.DateOfBirth.String)
+	res, err := repo.postgres.ExecContext(ctx, updateOwner, owner.Id, owner.Name, owner.PhoneNumber, owner.DateOfBirth.String, businessID)
 	if err != nil {
 		err = fmt.Errorf("repository.ownerRepository.Update: %w", err)
 		return 0, nil, err
@@ -75,6 +95,9 @@ func (repo *ownerRepository) GetByEmail(ctx context.Context, email string) (owne
 		&owner.PhoneNumber,
 		&owner.DateOfBirth,
 		&owner.Password,
+		&owner.Role,
+		&owner.BusinessID,
+		&owner.EmailVerified,
 	)
 	if err == sql.ErrNoRows {
 		err = fmt.Errorf("repository.ownerRepository.GetByEmail: %w", err)
@@ -99,6 +122,9 @@ func (repo *ownerRepository) Get(ctx context.Context, id int64) (owner *model.Ow
 		&owner.PhoneNumber,
 		&owner.DateOfBirth,
 		&owner.Password,
+		&owner.Role,
+		&owner.BusinessID,
+		&owner.EmailVerified,
 	)
 
 	if err == sql.ErrNoRows {
@@ -114,8 +140,8 @@ func (repo *ownerRepository) Get(ctx context.Context, id int64) (owner *model.Ow
 	return owner, nil, nil
 }
 
-func (repo *ownerRepository) List(ctx context.Context, limit, offset int) (owners []*model.Owner, errNoRow error, err error) {
-	rows, err := repo.postgres.QueryContext(ctx, listOwners, limit, offset)
+func (repo *ownerRepository) List(ctx context.Context, businessID int64, limit, offset int) (owners []*model.Owner, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listOwners, limit, offset, businessID)
 	if err != nil {
 		err = fmt.Errorf("repository.ownerRepository.List: %w", err)
 		return nil, nil, err
@@ -131,6 +157,8 @@ func (repo *ownerRepository) List(ctx context.Context, limit, offset int) (owner
 			&owner.Email,
 			&owner.PhoneNumber,
 			&owner.DateOfBirth,
+			&owner.Role,
+			&owner.BusinessID,
 		)
 
 		if err != nil {
@@ -245,3 +273,43 @@ func (repo *ownerRepository) UpdateEmailByID(ctx context.Context, id int64, emai
 
 	return nAffected, nil, nil
 }
+
+func (repo *ownerRepository) UpdateRoleByID(ctx context.Context, businessID, id int64, role string) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updateOwnerRoleByID, id, role, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.ownerRepository.UpdateRoleByID: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err == nil && nAffected == 0 {
+		err = fmt.Errorf("repository.ownerRepository.UpdateRoleByID: %w", sql.ErrNoRows)
+		return 0, err, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("repository.ownerRepository.UpdateRoleByID: %w", err)
+		return 0, nil, err
+	}
+
+	return nAffected, nil, nil
+}
+
+func (repo *ownerRepository) DeleteStaffByID(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteStaffByID, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.ownerRepository.DeleteStaffByID: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err == nil && nAffected == 0 {
+		err = fmt.Errorf("repository.ownerRepository.DeleteStaffByID: %w", sql.ErrNoRows)
+		return 0, err, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("repository.ownerRepository.DeleteStaffByID: %w", err)
+		return 0, nil, err
+	}
+
+	return nAffected, nil, nil
+}
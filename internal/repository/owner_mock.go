@@ -50,6 +50,21 @@ func (mr *MockOwnerRepositoryMockRecorder) Create(ctx, owner interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOwnerRepository)(nil).Create), ctx, owner)
 }
 
+// CreateWithBusiness mocks base method.
+func (m *MockOwnerRepository) CreateWithBusiness(ctx context.Context, owner model.Owner, businessName string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithBusiness", ctx, owner, businessName)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithBusiness indicates an expected call of CreateWithBusiness.
+func (mr *MockOwnerRepositoryMockRecorder) CreateWithBusiness(ctx, owner, businessName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithBusiness", reflect.TypeOf((*MockOwnerRepository)(nil).CreateWithBusiness), ctx, owner, businessName)
+}
+
 // Delete mocks base method.
 func (m *MockOwnerRepository) Delete(ctx context.Context, id int64) (int64, error, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,22 @@ func (mr *MockOwnerRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOwnerRepository)(nil).Delete), ctx, id)
 }
 
+// DeleteStaffByID mocks base method.
+func (m *MockOwnerRepository) DeleteStaffByID(ctx context.Context, businessID, id int64) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaffByID", ctx, businessID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteStaffByID indicates an expected call of DeleteStaffByID.
+func (mr *MockOwnerRepositoryMockRecorder) DeleteStaffByID(ctx, businessID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaffByID", reflect.TypeOf((*MockOwnerRepository)(nil).DeleteStaffByID), ctx, businessID, id)
+}
+
 // Get mocks base method.
 func (m *MockOwnerRepository) Get(ctx context.Context, id int64) (*model.Owner, error, error) {
 	m.ctrl.T.Helper()
@@ -99,9 +130,9 @@ func (mr *MockOwnerRepositoryMockRecorder) GetByEmail(ctx, email interface{}) *g
 }
 
 // List mocks base method.
-func (m *MockOwnerRepository) List(ctx context.Context, limit, offset int) ([]*model.Owner, error, error) {
+func (m *MockOwnerRepository) List(ctx context.Context, businessID int64, limit, offset int) ([]*model.Owner, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, businessID, limit, offset)
 	ret0, _ := ret[0].([]*model.Owner)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -109,15 +140,15 @@ func (m *MockOwnerRepository) List(ctx context.Context, limit, offset int) ([]*m
 }
 
 // List indicates an expected call of List.
-func (mr *MockOwnerRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+func (mr *MockOwnerRepositoryMockRecorder) List(ctx, businessID, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOwnerRepository)(nil).List), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOwnerRepository)(nil).List), ctx, businessID, limit, offset)
 }
 
 // Update mocks base method.
-func (m *MockOwnerRepository) Update(ctx context.Context, owner model.Owner) (int64, error, error) {
+func (m *MockOwnerRepository) Update(ctx context.Context, businessID int64, owner model.Owner) (int64, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, owner)
+	ret := m.ctrl.Call(m, "Update", ctx, businessID, owner)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
@@ -125,9 +156,9 @@ func (m *MockOwnerRepository) Update(ctx context.Context, owner model.Owner) (in
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockOwnerRepositoryMockRecorder) Update(ctx, owner interface{}) *gomock.Call {
+func (mr *MockOwnerRepositoryMockRecorder) Update(ctx, businessID, owner interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOwnerRepository)(nil).Update), ctx, owner)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOwnerRepository)(nil).Update), ctx, businessID, owner)
 }
 
 // UpdateEmailByID mocks base method.
@@ -177,3 +208,19 @@ func (mr *MockOwnerRepositoryMockRecorder) UpdatePasswordByID(ctx, id, password
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordByID", reflect.TypeOf((*MockOwnerRepository)(nil).UpdatePasswordByID), ctx, id, password)
 }
+
+// UpdateRoleByID mocks base method.
+func (m *MockOwnerRepository) UpdateRoleByID(ctx context.Context, businessID, id int64, role string) (int64, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoleByID", ctx, businessID, id, role)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateRoleByID indicates an expected call of UpdateRoleByID.
+func (mr *MockOwnerRepositoryMockRecorder) UpdateRoleByID(ctx, businessID, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoleByID", reflect.TypeOf((*MockOwnerRepository)(nil).UpdateRoleByID), ctx, businessID, id, role)
+}
@@ -57,7 +57,7 @@ func Test_ownerRepository_Create(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("INSERT INTO owner").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+				m.pgMock.ExpectQuery("INSERT INTO owner").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
 			},
 			wantID: 1,
 		},
@@ -70,7 +70,7 @@ func Test_ownerRepository_Create(t *testing.T) {
 				owner: model.Owner{},
 			},
 			prepareMocks: func(m *mocks) {
-				m.pgMock.ExpectQuery("INSERT INTO owner").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(errors.New("oops! error db"))
+				m.pgMock.ExpectQuery("INSERT INTO owner").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(errors.New("oops! error db"))
 			},
 			wantErr: true,
 		},
@@ -95,10 +95,77 @@ func Test_ownerRepository_Create(t *testing.T) {
 	}
 }
 
+func Test_ownerRepository_CreateWithBusiness(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		owner        model.Owner
+		businessName string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		repo         *ownerRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantID       int64
+		wantErr      bool
+	}{
+		{
+			name: "success CreateWithBusiness",
+			repo: &ownerRepository{},
+			args: args{
+				ctx:          context.Background(),
+				owner:        model.Owner{Name: "test-entity", Email: "test@example.com", Password: "password"},
+				businessName: "Dapur Bu Tini",
+			},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH business AS \(.*INSERT INTO business.*INSERT INTO owner`).
+					WithArgs("test-entity", "test@example.com", "password", "", "Dapur Bu Tini").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+			},
+			wantID: 1,
+		},
+		{
+			name: "fail CreateWithBusiness",
+			repo: &ownerRepository{},
+			args: args{
+				ctx:   context.Background(),
+				owner: model.Owner{},
+			},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO business`).WillReturnError(errors.New("oops! error db"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: mock})
+			}
+
+			gotID, err := tt.repo.CreateWithBusiness(tt.args.ctx, tt.args.owner, tt.args.businessName)
+
+			assert.Equal(t, tt.wantID, gotID)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func Test_ownerRepository_Update(t *testing.T) {
 	type args struct {
-		ctx   context.Context
-		owner model.Owner
+		ctx        context.Context
+		businessID int64
+		owner      model.Owner
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -115,7 +182,8 @@ func Test_ownerRepository_Update(t *testing.T) {
 			name: "success update to database",
 			repo: &ownerRepository{},
 			args: args{
-				ctx: context.Background(),
+				ctx:        context.Background(),
+				businessID: 1,
 				owner: model.Owner{
 					Id:   4,
 					Name: "updated test",
@@ -124,7 +192,7 @@ func Test_ownerRepository_Update(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectExec(`UPDATE owner`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantNAffected: 1,
@@ -133,13 +201,14 @@ func Test_ownerRepository_Update(t *testing.T) {
 			name: "fail update to database (error no rows updated)",
 			repo: &ownerRepository{},
 			args: args{
-				ctx:   context.Background(),
-				owner: model.Owner{},
+				ctx:        context.Background(),
+				businessID: 1,
+				owner:      model.Owner{},
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectExec(`UPDATE owner`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			wantErr: true,
@@ -148,13 +217,14 @@ func Test_ownerRepository_Update(t *testing.T) {
 			name: "fail update to database (error rows affected)",
 			repo: &ownerRepository{},
 			args: args{
-				ctx:   context.Background(),
-				owner: model.Owner{},
+				ctx:        context.Background(),
+				businessID: 1,
+				owner:      model.Owner{},
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectExec(`UPDATE owner`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("oops! error rows affected")))
 			},
 			wantErr: true,
@@ -163,13 +233,14 @@ func Test_ownerRepository_Update(t *testing.T) {
 			name: "fail update to database",
 			repo: &ownerRepository{},
 			args: args{
-				ctx:   context.Background(),
-				owner: model.Owner{},
+				ctx:        context.Background(),
+				businessID: 1,
+				owner:      model.Owner{},
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectExec(`UPDATE owner`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
 					WillReturnError(errors.New("oops! error db"))
 			},
 			wantErr: true,
@@ -187,7 +258,7 @@ func Test_ownerRepository_Update(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: mock})
 			}
 
-			gotNAffected, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.owner)
+			gotNAffected, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.businessID, tt.args.owner)
 			assert.Equal(t, tt.wantNAffected, gotNAffected)
 			assert.Equal(t, tt.wantErr, err != nil || errNoRow != nil)
 
@@ -223,13 +294,16 @@ func Test_ownerRepository_GetByEmail(t *testing.T) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*WHERE.*email").
 					WithArgs(sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password"}).
-						AddRow(int64(1), "test", "test@example.com", "", nil, ""))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password", "role", "business_id", "email_verified"}).
+						AddRow(int64(1), "test", "test@example.com", "", nil, "", "admin", int64(1), true))
 			},
 			wantOwner: &model.Owner{
-				Id:    1,
-				Name:  "test",
-				Email: "test@example.com",
+				Id:            1,
+				Name:          "test",
+				Email:         "test@example.com",
+				Role:          "admin",
+				BusinessID:    1,
+				EmailVerified: true,
 			},
 		},
 		{
@@ -242,7 +316,7 @@ func Test_ownerRepository_GetByEmail(t *testing.T) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*WHERE.*email").
 					WithArgs(sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password", "role", "business_id", "email_verified"})).
 					WillReturnError(errors.New("oops! error db"))
 			},
 			wantErr: true,
@@ -258,7 +332,7 @@ func Test_ownerRepository_GetByEmail(t *testing.T) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*WHERE.*email").
 					WithArgs(sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password", "role", "business_id", "email_verified"})).
 					WillReturnError(sql.ErrNoRows)
 			},
 			wantErr: true,
@@ -310,13 +384,16 @@ func Test_ownerRepository_Get(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*WHERE.*id").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password"}).
-						AddRow(int64(1), "test", "test@example.com", "", nil, ""))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password", "role", "business_id", "email_verified"}).
+						AddRow(int64(1), "test", "test@example.com", "", nil, "", "admin", int64(1), true))
 			},
 			wantOwner: &model.Owner{
-				Id:    1,
-				Name:  "test",
-				Email: "test@example.com",
+				Id:            1,
+				Name:          "test",
+				Email:         "test@example.com",
+				Role:          "admin",
+				BusinessID:    1,
+				EmailVerified: true,
 			},
 		},
 		{
@@ -329,7 +406,7 @@ func Test_ownerRepository_Get(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*WHERE.*id").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password", "role", "business_id", "email_verified"})).
 					WillReturnError(errors.New("oops! error db"))
 			},
 			wantErr: true,
@@ -345,7 +422,7 @@ func Test_ownerRepository_Get(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*WHERE.*id").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "password", "role", "business_id", "email_verified"})).
 					WillReturnError(sql.ErrNoRows)
 			},
 		},
@@ -372,9 +449,10 @@ func Test_ownerRepository_Get(t *testing.T) {
 
 func Test_ownerRepository_List(t *testing.T) {
 	type args struct {
-		ctx    context.Context
-		limit  int
-		offset int
+		ctx        context.Context
+		businessID int64
+		limit      int
+		offset     int
 	}
 	type mocks struct {
 		pgMock sqlmock.Sqlmock
@@ -392,36 +470,38 @@ func Test_ownerRepository_List(t *testing.T) {
 			name: "success List",
 			repo: &ownerRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*LIMIT.*OFFSET").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth"}).
-						AddRow(int64(1), "test1", "", "", nil).
-						AddRow(int64(2), "test2", "", "", nil))
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "role", "business_id"}).
+						AddRow(int64(1), "test1", "", "", nil, "viewer", int64(1)).
+						AddRow(int64(2), "test2", "", "", nil, "viewer", int64(1)))
 			},
 			wantOwners: []*model.Owner{
-				{Id: 1, Name: "test1"},
-				{Id: 2, Name: "test2"},
+				{Id: 1, Name: "test1", Role: "viewer", BusinessID: 1},
+				{Id: 2, Name: "test2", Role: "viewer", BusinessID: 1},
 			},
 		},
 		{
 			name: "fail List (error db)",
 			repo: &ownerRepository{},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*LIMIT.*OFFSET").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth"})).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "role", "business_id"})).
 					WillReturnError(errors.New("oops! error db"))
 			},
 			wantErr: true,
@@ -432,13 +512,14 @@ func Test_ownerRepository_List(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*LIMIT.*OFFSET").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth"}))
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "role", "business_id"}))
 			},
 			args: args{
-				ctx:    context.Background(),
-				limit:  10,
-				offset: 1_000_000_000_000,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      10,
+				offset:     1_000_000_000_000,
 			},
 			wantErr: true,
 		},
@@ -448,14 +529,15 @@ func Test_ownerRepository_List(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*LIMIT.*OFFSET").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth"}).
-						AddRow(int64(1), "test1", "", "", nil).RowError(0, errors.New("error rows")))
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "role", "business_id"}).
+						AddRow(int64(1), "test1", "", "", nil, "viewer", int64(1)).RowError(0, errors.New("error rows")))
 			},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			wantErr: true,
 		},
@@ -465,14 +547,15 @@ func Test_ownerRepository_List(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.pgMock.
 					ExpectQuery("SELECT.*owner.*LIMIT.*OFFSET").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth"}).
-						AddRow("one", "test1", "", "", nil)) // owner_id must be int64 not int
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone_number", "date_of_birth", "role", "business_id"}).
+						AddRow("one", "test1", "", "", nil, "viewer", int64(1))) // owner_id must be int64 not int
 			},
 			args: args{
-				ctx:    context.Background(),
-				limit:  2,
-				offset: 1,
+				ctx:        context.Background(),
+				businessID: 1,
+				limit:      2,
+				offset:     1,
 			},
 			wantErr: true,
 		},
@@ -489,7 +572,7 @@ func Test_ownerRepository_List(t *testing.T) {
 				tt.prepareMocks(&mocks{pgMock: mock})
 			}
 
-			gotOwners, errNoRow, err := tt.repo.List(tt.args.ctx, tt.args.limit, tt.args.offset)
+			gotOwners, errNoRow, err := tt.repo.List(tt.args.ctx, tt.args.businessID, tt.args.limit, tt.args.offset)
 
 			assert.Equal(t, tt.wantOwners, gotOwners)
 			assert.Equal(t, tt.wantErr, err != nil || errNoRow != nil, err)
@@ -835,3 +918,71 @@ func Test_ownerRepository_UpdateEmailByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_ownerRepository_UpdateRoleByID(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+		role       string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *ownerRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success UpdateRoleByID",
+			repo: &ownerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 2, role: "cashier"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec("UPDATE owner SET role").WithArgs(int64(2), "cashier", int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail UpdateRoleByID (not found or last admin)",
+			repo: &ownerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1, role: "viewer"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec("UPDATE owner SET role").WithArgs(int64(1), "viewer", int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail UpdateRoleByID (error db)",
+			repo: &ownerRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1, role: "viewer"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec("UPDATE owner SET role").WithArgs(int64(1), "viewer", int64(1)).WillReturnError(errors.New("oops! error db"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: mock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.UpdateRoleByID(tt.args.ctx, tt.args.businessID, tt.args.id, tt.args.role)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"family-catering/pkg/db/redis"
+	"fmt"
+	"time"
+
+	redisV8 "github.com/go-redis/redis/v8"
+)
+
+const (
+	passwordResetKeyFormat         string = "password:reset:%s"          // by email, the hash of its reset id
+	passwordResetRequestsKeyFormat string = "password:reset:requests:%s" // by email
+)
+
+// consumePasswordReset delete the key when it holds the given hash, a reset id is used once even by concurrent requests
+var consumePasswordReset = redisV8.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type PasswordResetRepository interface {
+	// Create keep the hash of the email's reset id for ttl, the email's previous reset id is voided
+	Create(ctx context.Context, email, idHash string, ttl time.Duration) error
+	// Consume delete the email's reset id hash, errNoRow when it isn't the email's reset id (unknown, used, voided or
+	// expired)
+	Consume(ctx context.Context, email, idHash string) (errNoRow error, err error)
+	// CountRequest count a reset request of the email, the requests are forgotten window after the first one
+	CountRequest(ctx context.Context, email string, window time.Duration) (requests int64, err error)
+}
+
+type passwordResetRepository struct {
+	redis redis.RedisClient
+}
+
+func NewPasswordResetRepository(redis redis.RedisClient) PasswordResetRepository {
+	return &passwordResetRepository{redis: redis}
+}
+
+func (repo *passwordResetRepository) Create(ctx context.Context, email, idHash string, ttl time.Duration) error {
+	err := repo.redis.Set(ctx, fmt.Sprintf(passwordResetKeyFormat, loginEmail(email)), idHash, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("repository.passwordResetRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *passwordResetRepository) Consume(ctx context.Context, email, idHash string) (errNoRow error, err error) {
+	deleted, err := consumePasswordReset.Run(ctx, repo.redis, []string{fmt.Sprintf(passwordResetKeyFormat, loginEmail(email))}, idHash).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("repository.passwordResetRepository.Consume: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("repository.passwordResetRepository.Consume: %w", redisV8.Nil), nil
+	}
+
+	return nil, nil
+}
+
+func (repo *passwordResetRepository) CountRequest(ctx context.Context, email string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(passwordResetRequestsKeyFormat, loginEmail(email))
+	requests, err := repo.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("repository.passwordResetRepository.CountRequest: %w", err)
+	}
+	if requests == 1 {
+		err = repo.redis.Expire(ctx, key, window).Err()
+		if err != nil {
+			return 0, fmt.Errorf("repository.passwordResetRepository.CountRequest: %w", err)
+		}
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"family-catering/pkg/db/redis"
+	"testing"
+	"time"
+
+	redisV8 "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewPasswordResetRepository(t *testing.T) {
+	assert.NotNil(t, NewPasswordResetRepository(nil))
+}
+
+func Test_passwordResetRepository_Create(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	repo := &passwordResetRepository{redis: redisMock}
+	ctx := context.Background()
+
+	err = repo.Create(ctx, "Test@example.com", "id-hash", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "id-hash", redisMock.Get(ctx, "password:reset:test@example.com").Val())
+	ttl := redisMock.TTL(ctx, "password:reset:test@example.com").Val()
+	assert.True(t, ttl > 0 && ttl <= time.Hour, ttl)
+
+	// a new reset id voids the previous one
+	err = repo.Create(ctx, "test@example.com", "new-id-hash", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-id-hash", redisMock.Get(ctx, "password:reset:test@example.com").Val())
+}
+
+func Test_passwordResetRepository_Create_error(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	redisMock.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redisV8.NewStatusResult("", errors.New("oops! error from redis")))
+	repo := &passwordResetRepository{redis: redisMock}
+
+	err = repo.Create(context.Background(), "test@example.com", "id-hash", time.Hour)
+	assert.Error(t, err)
+}
+
+func Test_passwordResetRepository_Consume(t *testing.T) {
+	type args struct {
+		email  string
+		idHash string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		prepare       func(*passwordResetRepository)
+		wantErrNoRow  bool
+		wantRemaining bool
+	}{
+		{
+			name: "success consume",
+			args: args{email: "TEST@example.com", idHash: "id-hash"},
+			prepare: func(repo *passwordResetRepository) {
+				repo.Create(context.Background(), "test@example.com", "id-hash", time.Hour)
+			},
+		},
+		{
+			name: "fail consume (used)",
+			args: args{email: "test@example.com", idHash: "id-hash"},
+			prepare: func(repo *passwordResetRepository) {
+				repo.Create(context.Background(), "test@example.com", "id-hash", time.Hour)
+				repo.Consume(context.Background(), "test@example.com", "id-hash")
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail consume (voided by a new reset id)",
+			args: args{email: "test@example.com", idHash: "id-hash"},
+			prepare: func(repo *passwordResetRepository) {
+				repo.Create(context.Background(), "test@example.com", "id-hash", time.Hour)
+				repo.Create(context.Background(), "test@example.com", "new-id-hash", time.Hour)
+			},
+			wantErrNoRow:  true,
+			wantRemaining: true,
+		},
+		{
+			name: "fail consume (reset id of another email)",
+			args: args{email: "another@example.com", idHash: "id-hash"},
+			prepare: func(repo *passwordResetRepository) {
+				repo.Create(context.Background(), "test@example.com", "id-hash", time.Hour)
+			},
+			wantErrNoRow:  true,
+			wantRemaining: true,
+		},
+		{
+			name:         "fail consume (unknown)",
+			args:         args{email: "test@example.com", idHash: "id-hash"},
+			wantErrNoRow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisMock, err := redis.NewMockWithMiniRedisClient(t)
+			if err != nil {
+				panic(err)
+			}
+			repo := &passwordResetRepository{redis: redisMock}
+			if tt.prepare != nil {
+				tt.prepare(repo)
+			}
+
+			errNoRow, err := repo.Consume(context.Background(), tt.args.email, tt.args.idHash)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			if tt.wantErrNoRow {
+				assert.ErrorIs(t, errNoRow, redisV8.Nil)
+			}
+			remaining := redisMock.Exists(context.Background(), "password:reset:test@example.com").Val()
+			assert.Equal(t, tt.wantRemaining, remaining == 1)
+		})
+	}
+}
+
+func Test_passwordResetRepository_CountRequest(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	repo := &passwordResetRepository{redis: redisMock}
+	ctx := context.Background()
+
+	requests, err := repo.CountRequest(ctx, "test@example.com", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), requests)
+
+	// the email is counted whatever its case
+	requests, err = repo.CountRequest(ctx, "Test@Example.com", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), requests)
+
+	requests, err = repo.CountRequest(ctx, "another@example.com", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), requests)
+
+	// the window starts on the first request
+	ttl := redisMock.TTL(ctx, "password:reset:requests:test@example.com").Val()
+	assert.True(t, ttl > 0 && ttl <= time.Hour, ttl)
+}
+
+func Test_passwordResetRepository_CountRequest_error(t *testing.T) {
+	redisMock, err := redis.NewMockWithMiniRedisClient(t)
+	if err != nil {
+		panic(err)
+	}
+	redisMock.On("Incr", mock.Anything, mock.Anything).Return(redisV8.NewIntResult(0, errors.New("oops! error from redis")))
+	repo := &passwordResetRepository{redis: redisMock}
+
+	_, err = repo.CountRequest(context.Background(), "test@example.com", time.Hour)
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+type PaymentRepository interface {
+	// Pay businessID zero is a payment from a provider's webhook, it's trusted by its signature whatever the order's business
+	Pay(ctx context.Context, businessID int64, payment *model.Payment) (summary *model.PaymentSummary, errNoRow error, err error)
+	Balance(ctx context.Context, businessID, orderID int64) (summary *model.PaymentSummary, errNoRow error, err error)
+	// DeleteRefund forget the refund recorded by Pay for the provider's event, the refund has failed so the replayed
+	// event is refunded again
+	DeleteRefund(ctx context.Context, provider, providerEventID string) error
+}
+
+type paymentRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewPaymentRepository(postgres postgres.PostgresClient) PaymentRepository {
+	return &paymentRepository{postgres: postgres}
+}
+
+// Pay record a (partial) payment of an unpaid order and move a NEW or CONFIRMED order to PAID once the total is covered,
+// an order prepared before it's paid keeps its status. errNoRow is returned when the order is not found (or belongs to
+// another business), ErrOrderNotPayable when the order is PAID, CANCELLED or REFUNDED and ErrPaymentExceedsBalance when
+// the amount is more than the remaining balance.
+// Payment from a provider whose event is already recorded returns ErrDuplicatePaymentEvent, the event of a payment
+// refused with ErrOrderNotPayable or ErrPaymentExceedsBalance is recorded as refunded so it's refunded once.
+func (repo *paymentRepository) Pay(ctx context.Context, businessID int64, payment *model.Payment) (summary *model.PaymentSummary, errNoRow error, err error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	var (
+		nRows           int
+		orderBusinessID int64
+	)
+	// the total is read after the lock so it includes an item change committed while waiting for the lock
+	_, err = tx.ExecContext(ctx, lockOrder, payment.OrderID)
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+		return nil, nil, err
+	}
+
+	summary = &model.PaymentSummary{OrderID: payment.OrderID}
+	err = tx.QueryRowContext(ctx, getOrderTotalForUpdate, payment.OrderID).Scan(&nRows, &summary.TotalPrice, &summary.Status, &orderBusinessID)
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+		return nil, nil, err
+	}
+
+	if nRows == 0 || (businessID != 0 && orderBusinessID != businessID) {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	// replayed event is checked first, the order is likely PAID by the original event
+	if payment.ProviderEventID.Valid {
+		var exists bool
+		err = tx.QueryRowContext(ctx, paymentEventExists, payment.Provider.String, payment.ProviderEventID.String).Scan(&exists)
+		if err != nil {
+			err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+			return nil, nil, err
+		}
+		if exists {
+			err = fmt.Errorf("repository.paymentRepository.Pay: %w", ErrDuplicatePaymentEvent)
+			return summary, nil, err
+		}
+	}
+
+	if summary.Status == consts.StatusPaid || summary.Status == consts.StatusCancelled || summary.Status == consts.StatusRefunded {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", refusePayment(ctx, tx, payment, ErrOrderNotPayable))
+		return summary, nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, getOrderTotalPaid, payment.OrderID).Scan(&summary.TotalPaid)
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+		return nil, nil, err
+	}
+
+	if payment.Amount.Cmp(summary.TotalPrice.Sub(summary.TotalPaid)) > 0 {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", refusePayment(ctx, tx, payment, ErrPaymentExceedsBalance))
+		return summary, nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, insertPayment,
+		payment.OrderID, payment.Amount, payment.Method, payment.ReferenceNumber.String, payment.ReceivedBy.Int64,
+		payment.Provider.String, payment.ProviderEventID.String).
+		Scan(&summary.PaymentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the same event was recorded concurrently, e.g. a webhook replayed for a different order
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", ErrDuplicatePaymentEvent)
+		return summary, nil, err
+	}
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+		return nil, nil, err
+	}
+	summary.TotalPaid = summary.TotalPaid.Add(payment.Amount)
+
+	if summary.TotalPaid.Cmp(summary.TotalPrice) >= 0 && (summary.Status == consts.StatusNew || summary.Status == consts.StatusConfirmed) {
+		_, err = tx.ExecContext(ctx, updateOrderStatus, payment.OrderID, summary.Status, consts.StatusPaid, payment.ReceivedBy.Int64, orderBusinessID)
+		if err != nil {
+			err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+			return nil, nil, err
+		}
+		summary.Status = consts.StatusPaid
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Pay: %w", err)
+		return nil, nil, err
+	}
+
+	return summary, nil, nil
+}
+
+// Balance return total price and total paid of an order without locking it, errNoRow is returned when the order is not found
+func (repo *paymentRepository) Balance(ctx context.Context, businessID, orderID int64) (summary *model.PaymentSummary, errNoRow error, err error) {
+	var nRows int
+	summary = &model.PaymentSummary{OrderID: orderID}
+	err = repo.postgres.QueryRowContext(ctx, getOrderTotal, orderID, businessID).Scan(&nRows, &summary.TotalPrice, &summary.Status)
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Balance: %w", err)
+		return nil, nil, err
+	}
+
+	if nRows == 0 {
+		err = fmt.Errorf("repository.paymentRepository.Balance: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	err = repo.postgres.QueryRowContext(ctx, getOrderTotalPaid, orderID).Scan(&summary.TotalPaid)
+	if err != nil {
+		err = fmt.Errorf("repository.paymentRepository.Balance: %w", err)
+		return nil, nil, err
+	}
+
+	return summary, nil, nil
+}
+
+func (repo *paymentRepository) DeleteRefund(ctx context.Context, provider, providerEventID string) error {
+	_, err := repo.postgres.ExecContext(ctx, deletePaymentRefund, provider, providerEventID)
+	if err != nil {
+		return fmt.Errorf("repository.paymentRepository.DeleteRefund: %w", err)
+	}
+
+	return nil
+}
+
+// refusePayment return reason of a refused payment, the event of a provider's payment is recorded as refunded and tx is
+// committed first so the event's replay is a duplicate (ErrDuplicatePaymentEvent is returned when the event has been
+// recorded concurrently)
+func refusePayment(ctx context.Context, tx *sql.Tx, payment *model.Payment, reason error) error {
+	if !payment.ProviderEventID.Valid {
+		return reason
+	}
+
+	var id int64
+	err := tx.QueryRowContext(ctx, insertPaymentRefund,
+		payment.OrderID, payment.Amount, payment.ReferenceNumber.String, payment.Provider.String, payment.ProviderEventID.String).
+		Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDuplicatePaymentEvent
+	}
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return reason
+}
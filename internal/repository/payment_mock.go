@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\payment.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockPaymentRepository) Balance(ctx context.Context, businessID, orderID int64) (*model.PaymentSummary, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", ctx, businessID, orderID)
+	ret0, _ := ret[0].(*model.PaymentSummary)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockPaymentRepositoryMockRecorder) Balance(ctx, businessID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockPaymentRepository)(nil).Balance), ctx, businessID, orderID)
+}
+
+// DeleteRefund mocks base method.
+func (m *MockPaymentRepository) DeleteRefund(ctx context.Context, provider, providerEventID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRefund", ctx, provider, providerEventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRefund indicates an expected call of DeleteRefund.
+func (mr *MockPaymentRepositoryMockRecorder) DeleteRefund(ctx, provider, providerEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRefund", reflect.TypeOf((*MockPaymentRepository)(nil).DeleteRefund), ctx, provider, providerEventID)
+}
+
+// Pay mocks base method.
+func (m *MockPaymentRepository) Pay(ctx context.Context, businessID int64, payment *model.Payment) (*model.PaymentSummary, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, businessID, payment)
+	ret0, _ := ret[0].(*model.PaymentSummary)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockPaymentRepositoryMockRecorder) Pay(ctx, businessID, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockPaymentRepository)(nil).Pay), ctx, businessID, payment)
+}
@@ -0,0 +1,460 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/money"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPaymentRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewPaymentRepository"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewPaymentRepository(tt.args.postgres))
+		})
+	}
+}
+
+func Test_paymentRepository_Pay(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		payment    *model.Payment
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	payment := func(amount string) *model.Payment {
+		return &model.Payment{
+			OrderID:    1,
+			Amount:     money.MustParse(amount),
+			Method:     "cash",
+			ReceivedBy: sql.NullInt64{Int64: 1, Valid: true},
+		}
+	}
+	providerPayment := &model.Payment{
+		OrderID:         1,
+		Amount:          money.MustParse("50000"),
+		Method:          "card",
+		ReferenceNumber: sql.NullString{String: "ch_1", Valid: true},
+		Provider:        sql.NullString{String: "fake", Valid: true},
+		ProviderEventID: sql.NullString{String: "evt_1", Valid: true},
+	}
+	totalCols := []string{"count", "total", "status", "business_id"}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		repo         *paymentRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantSummary  *model.PaymentSummary
+		wantErrNoRow bool
+		wantErr      error
+	}{
+		{
+			name: "success Pay (partial payment)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("20000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(0)))
+				m.pgMock.ExpectQuery(`INSERT INTO payment`).WithArgs(int64(1), int64(2_000_000), "cash", "", int64(1), "", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+				m.pgMock.ExpectCommit()
+			},
+			wantSummary: &model.PaymentSummary{PaymentID: 1, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: 1},
+		},
+		{
+			name: "success Pay (paid off)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("30000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 4, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(2_000_000)))
+				m.pgMock.ExpectQuery(`INSERT INTO payment`).WithArgs(int64(1), int64(3_000_000), "cash", "", int64(1), "", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.pgMock.ExpectExec(`UPDATE "order" SET status.*INSERT INTO order_status_history`).WithArgs(int64(1), 4, 2, int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectCommit()
+			},
+			wantSummary: &model.PaymentSummary{PaymentID: 2, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: 2},
+		},
+		{
+			name: "success Pay (paid off while preparing, the status is kept)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("50000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 5, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(0)))
+				m.pgMock.ExpectQuery(`INSERT INTO payment`).WithArgs(int64(1), int64(5_000_000), "cash", "", int64(1), "", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(4)))
+				m.pgMock.ExpectCommit()
+			},
+			wantSummary: &model.PaymentSummary{PaymentID: 4, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: 5},
+		},
+		{
+			name: "success Pay (provider payment)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(0)))
+				m.pgMock.ExpectQuery(`INSERT INTO payment.*ON CONFLICT`).WithArgs(int64(1), int64(5_000_000), "card", "ch_1", int64(0), "fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+				m.pgMock.ExpectExec(`UPDATE "order" SET status.*INSERT INTO order_status_history`).WithArgs(int64(1), 1, 2, int64(0), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectCommit()
+			},
+			wantSummary: &model.PaymentSummary{PaymentID: 3, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: 2},
+		},
+		{
+			name: "fail Pay (provider event is replayed)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 2, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.pgMock.ExpectRollback()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: 2},
+			wantErr:     ErrDuplicatePaymentEvent,
+		},
+		{
+			name: "fail Pay (provider event is recorded concurrently)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(0)))
+				m.pgMock.ExpectQuery(`INSERT INTO payment.*ON CONFLICT`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				m.pgMock.ExpectRollback()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("0"), Status: 1},
+			wantErr:     ErrDuplicatePaymentEvent,
+		},
+		{
+			name: "fail Pay (exceeds remaining balance)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("40000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(2_000_000)))
+				m.pgMock.ExpectRollback()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: 1},
+			wantErr:     ErrPaymentExceedsBalance,
+		},
+		{
+			name: "fail Pay (order is not payable)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("10000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 3, int64(1)))
+				m.pgMock.ExpectRollback()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: 3},
+			wantErr:     ErrOrderNotPayable,
+		},
+		{
+			name: "fail Pay (provider event of a cancelled order is recorded as refunded)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 3, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment .*EXISTS.*FROM payment_refund`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.pgMock.ExpectQuery(`INSERT INTO payment_refund.*ON CONFLICT`).WithArgs(int64(1), int64(5_000_000), "ch_1", "fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+				m.pgMock.ExpectCommit()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: 3},
+			wantErr:     ErrOrderNotPayable,
+		},
+		{
+			name: "fail Pay (provider event exceeding the balance is recorded as refunded)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(2_000_000)))
+				m.pgMock.ExpectQuery(`INSERT INTO payment_refund.*ON CONFLICT`).WithArgs(int64(1), int64(5_000_000), "ch_1", "fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+				m.pgMock.ExpectCommit()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: 1},
+			wantErr:     ErrPaymentExceedsBalance,
+		},
+		{
+			name: "fail Pay (refunded provider event is replayed)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 3, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment .*EXISTS.*FROM payment_refund`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.pgMock.ExpectRollback()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: 3},
+			wantErr:     ErrDuplicatePaymentEvent,
+		},
+		{
+			name: "fail Pay (refunded provider event is recorded concurrently)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), payment: providerPayment},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 3, int64(1)))
+				m.pgMock.ExpectQuery(`SELECT.*EXISTS.*FROM payment`).WithArgs("fake", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.pgMock.ExpectQuery(`INSERT INTO payment_refund.*ON CONFLICT`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				m.pgMock.ExpectRollback()
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: 3},
+			wantErr:     ErrDuplicatePaymentEvent,
+		},
+		{
+			name: "fail Pay (order not found)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("10000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(0, int64(0), 0, int64(0)))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Pay (order of another business)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 2, payment: payment("10000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				expectOrderLocked(m.pgMock)
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*FOR UPDATE`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1, int64(1)))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Pay (begin error)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, payment: payment("10000")},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin().WillReturnError(errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotSummary, errNoRow, err := tt.repo.Pay(tt.args.ctx, tt.args.businessID, tt.args.payment)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSummary, gotSummary)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_paymentRepository_Balance(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		orderID    int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	totalCols := []string{"count", "total", "status"}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		repo         *paymentRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantSummary  *model.PaymentSummary
+		wantErrNoRow bool
+		wantErr      error
+	}{
+		{
+			name: "success Balance",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*WHERE.*order_id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(2, int64(5_000_000), 1))
+				m.pgMock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)::BIGINT FROM payment`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(2_000_000)))
+			},
+			wantSummary: &model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: 1},
+		},
+		{
+			name: "fail Balance (order not found)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*WHERE.*order_id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows(totalCols).AddRow(0, int64(0), 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Balance (db error)",
+			repo: &paymentRepository{},
+			args: args{ctx: context.Background(), businessID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.*FROM.*"order".*WHERE.*order_id = \$1`).WithArgs(int64(1), int64(1)).
+					WillReturnError(errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotSummary, errNoRow, err := tt.repo.Balance(tt.args.ctx, tt.args.businessID, tt.args.orderID)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSummary, gotSummary)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_paymentRepository_DeleteRefund(t *testing.T) {
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		prepareMocks func(sqlmock.Sqlmock)
+		wantErr      error
+	}{
+		{
+			name: "success DeleteRefund",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectExec(`DELETE FROM payment_refund WHERE provider = \$1 AND provider_event_id = \$2`).WithArgs("fake", "evt_1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail DeleteRefund (db error)",
+			prepareMocks: func(pgMock sqlmock.Sqlmock) {
+				pgMock.ExpectExec(`DELETE FROM payment_refund`).WithArgs("fake", "evt_1").WillReturnError(errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			tt.prepareMocks(pgMock)
+
+			repo := &paymentRepository{postgres: db}
+			err = repo.DeleteRefund(context.Background(), "fake", "evt_1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
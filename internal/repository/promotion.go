@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+type PromotionRepository interface {
+	GetByID(ctx context.Context, businessID, id int64) (promotion *model.Promotion, errNoRow error, err error)
+	GetByCode(ctx context.Context, businessID int64, code string) (promotion *model.Promotion, errNoRow error, err error)
+	List(ctx context.Context, businessID int64, limit, offset int) (promotions []*model.Promotion, errNoRow error, err error)
+	Create(ctx context.Context, businessID int64, promotion model.Promotion) (id int64, err error)
+	Update(ctx context.Context, businessID int64, promotion model.Promotion) (nAffected int64, errNoRow error, err error)
+	Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error)
+}
+
+type promotionRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewPromotionRepository(postgres postgres.PostgresClient) PromotionRepository {
+	return &promotionRepository{postgres: postgres}
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPromotion scan a row selected by the promotion's queries (see getPromotionByID)
+func scanPromotion(row rowScanner, promotion *model.Promotion) error {
+	return row.Scan(
+		&promotion.ID,
+		&promotion.Code,
+		&promotion.Description,
+		&promotion.DiscountType,
+		&promotion.Percentage,
+		&promotion.Amount,
+		&promotion.MaxDiscount,
+		&promotion.MinSpend,
+		&promotion.MenuIDs,
+		&promotion.Categories,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.UsageLimit,
+		&promotion.UsageLimitPerCustomer,
+		&promotion.Active,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+}
+
+func (repo *promotionRepository) GetByID(ctx context.Context, businessID, id int64) (promotion *model.Promotion, errNoRow error, err error) {
+	promotion = &model.Promotion{}
+	err = scanPromotion(repo.postgres.QueryRowContext(ctx, getPromotionByID, id, businessID), promotion)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.promotionRepository.GetByID: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.GetByID: %w", err)
+		return nil, nil, err
+	}
+
+	return promotion, nil, nil
+}
+
+func (repo *promotionRepository) GetByCode(ctx context.Context, businessID int64, code string) (promotion *model.Promotion, errNoRow error, err error) {
+	promotion = &model.Promotion{}
+	err = scanPromotion(repo.postgres.QueryRowContext(ctx, getPromotionByCode, code, businessID), promotion)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("repository.promotionRepository.GetByCode: %w", err)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.GetByCode: %w", err)
+		return nil, nil, err
+	}
+
+	return promotion, nil, nil
+}
+
+func (repo *promotionRepository) List(ctx context.Context, businessID int64, limit, offset int) (promotions []*model.Promotion, errNoRow error, err error) {
+	rows, err := repo.postgres.QueryContext(ctx, listPromotion, limit, offset, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.List: %w", err)
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		promotion := new(model.Promotion)
+		err = scanPromotion(rows, promotion)
+		if err != nil {
+			err = fmt.Errorf("repository.promotionRepository.List: %w", err)
+			return nil, nil, err
+		}
+
+		promotions = append(promotions, promotion)
+	}
+
+	err = rows.Err()
+	// for decision reason see ./owner.go
+	if !rows.Next() && err == nil && len(promotions) == 0 {
+		err = fmt.Errorf("repository.promotionRepository.List: %w", sql.ErrNoRows)
+		return nil, err, nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.List: %w", err)
+		return nil, nil, err
+	}
+
+	return promotions, nil, rows.Close()
+}
+
+func (repo *promotionRepository) Create(ctx context.Context, businessID int64, promotion model.Promotion) (id int64, err error) {
+	err = repo.postgres.QueryRowContext(ctx, createPromotion,
+		promotion.Code, promotion.Description, promotion.DiscountType, promotion.Percentage, promotion.Amount,
+		promotion.MaxDiscount, promotion.MinSpend, promotion.MenuIDs, promotion.Categories,
+		promotion.StartsAt, promotion.EndsAt.String, promotion.UsageLimit, promotion.UsageLimitPerCustomer, businessID).
+		Scan(&id)
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.Create: %w", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Update replace every field of the promotion, empty StartsAt keeps the current one and NULL EndsAt never ends
+func (repo *promotionRepository) Update(ctx context.Context, businessID int64, promotion model.Promotion) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, updatePromotionByID, promotion.ID,
+		promotion.Code, promotion.Description, promotion.DiscountType, promotion.Percentage, promotion.Amount,
+		promotion.MaxDiscount, promotion.MinSpend, promotion.MenuIDs, promotion.Categories,
+		promotion.StartsAt, promotion.EndsAt.String, promotion.UsageLimit, promotion.UsageLimitPerCustomer, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.Update: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.promotionRepository.Update: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
+
+// Delete delete a promotion which has never been used by an order,
+// errNoRow is returned when the promotion is not found or has been used
+func (repo *promotionRepository) Delete(ctx context.Context, businessID, id int64) (nAffected int64, errNoRow error, err error) {
+	res, err := repo.postgres.ExecContext(ctx, deletePromotionByID, id, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	nAffected, err = res.RowsAffected()
+	if err != nil {
+		err = fmt.Errorf("repository.promotionRepository.Delete: %w", err)
+		return 0, nil, err
+	}
+
+	if nAffected == 0 {
+		return 0, fmt.Errorf("repository.promotionRepository.Delete: %w", sql.ErrNoRows), nil
+	}
+
+	return nAffected, nil, nil
+}
@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/money"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionCols = []string{
+	"id", "code", "description", "discount_type", "percentage", "amount", "max_discount", "min_spend", "menu_ids", "categories",
+	"starts_at", "ends_at", "usage_limit", "usage_limit_per_customer", "active", "created_at", "updated_at",
+}
+
+func TestNewPromotionRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewPromotionRepository"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewPromotionRepository(tt.args.postgres))
+		})
+	}
+}
+
+func Test_promotionRepository_GetByCode(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		code       string
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *promotionRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantPromotion *model.Promotion
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success GetByCode",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, code: "HEMAT10"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+AS active.+FROM.+promotion.+WHERE.+code = \$1`).WithArgs("HEMAT10", int64(1)).
+					WillReturnRows(sqlmock.NewRows(promotionCols).
+						AddRow(int64(1), "HEMAT10", "10% off", "percentage", 10, int64(0), int64(2_000_000), int64(10_000_000), "", "Indonesian food",
+							"2022-11-01T00:00:00Z", nil, 100, 1, true, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantPromotion: &model.Promotion{
+				ID: 1, Code: "HEMAT10", Description: "10% off", DiscountType: "percentage", Percentage: 10,
+				Amount: money.FromMinor(0), MaxDiscount: money.MustParse("20000"), MinSpend: money.MustParse("100000"),
+				Categories: "Indonesian food", StartsAt: "2022-11-01T00:00:00Z", UsageLimit: 100, UsageLimitPerCustomer: 1,
+				Active: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+			},
+		},
+		{
+			name: "fail GetByCode (no row)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, code: "NOTEXISTS"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+promotion.+WHERE.+code = \$1`).WithArgs("NOTEXISTS", int64(1)).
+					WillReturnRows(sqlmock.NewRows(promotionCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail GetByCode (db error)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, code: "HEMAT10"},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+promotion.+WHERE.+code = \$1`).WithArgs("HEMAT10", int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotPromotion, errNoRow, err := tt.repo.GetByCode(tt.args.ctx, tt.args.businessID, tt.args.code)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantPromotion, gotPromotion)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_promotionRepository_List(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		businessID    int64
+		limit, offset int
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name           string
+		repo           *promotionRepository
+		args           args
+		prepareMocks   func(*mocks)
+		wantPromotions []*model.Promotion
+		wantErrNoRow   bool
+		wantErr        bool
+	}{
+		{
+			name: "success List",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, limit: 10, offset: 0},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+promotion.+LIMIT \$1 OFFSET \$2`).WithArgs(10, 0, int64(1)).
+					WillReturnRows(sqlmock.NewRows(promotionCols).
+						AddRow(int64(2), "POTONG5RB", "", "fixed", 0, int64(500_000), int64(0), int64(0), "1,4", "",
+							"2022-11-01T00:00:00Z", "2022-12-01T00:00:00Z", 0, 0, false, "2022-11-01T00:00:00Z", "2022-11-01T00:00:00Z"))
+			},
+			wantPromotions: []*model.Promotion{{
+				ID: 2, Code: "POTONG5RB", DiscountType: "fixed", Amount: money.MustParse("5000"),
+				MaxDiscount: money.FromMinor(0), MinSpend: money.FromMinor(0), MenuIDs: "1,4", StartsAt: "2022-11-01T00:00:00Z",
+				EndsAt: sql.NullString{String: "2022-12-01T00:00:00Z", Valid: true}, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+			}},
+		},
+		{
+			name: "fail List (no row)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, limit: 10, offset: 100},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+promotion.+LIMIT \$1 OFFSET \$2`).WithArgs(10, 100, int64(1)).
+					WillReturnRows(sqlmock.NewRows(promotionCols))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail List (db error)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, limit: 10, offset: 0},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+promotion.+LIMIT \$1 OFFSET \$2`).WithArgs(10, 0, int64(1)).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotPromotions, errNoRow, err := tt.repo.List(tt.args.ctx, tt.args.businessID, tt.args.limit, tt.args.offset)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantPromotions, gotPromotions)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_promotionRepository_Create(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		promotion  model.Promotion
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	promotion := model.Promotion{
+		Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, MaxDiscount: money.MustParse("20000"),
+		Categories: "Indonesian food", EndsAt: sql.NullString{String: "2022-12-01T00:00:00Z", Valid: true}, UsageLimitPerCustomer: 1,
+	}
+	wantArgs := []driver.Value{"HEMAT10", "", "percentage", int64(10), int64(0), int64(2_000_000), int64(0), "", "Indonesian food", "", "2022-12-01T00:00:00Z", int64(0), int64(1)}
+	tests := []struct {
+		name         string
+		repo         *promotionRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantID       int64
+		wantErr      bool
+	}{
+		{
+			name: "success Create",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, promotion: promotion},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO promotion`).WithArgs(append(wantArgs, int64(1))...).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+			},
+			wantID: 1,
+		},
+		{
+			name: "fail Create (db error)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, promotion: promotion},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`INSERT INTO promotion`).WithArgs(append(wantArgs, int64(1))...).
+					WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotID, err := tt.repo.Create(tt.args.ctx, tt.args.businessID, tt.args.promotion)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_promotionRepository_Update(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		promotion  model.Promotion
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	promotion := model.Promotion{ID: 1, Code: "POTONG5RB", DiscountType: "fixed", Amount: money.MustParse("5000"), MenuIDs: "1,4"}
+	tests := []struct {
+		name         string
+		repo         *promotionRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success Update",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, promotion: promotion},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE.+promotion.+SET`).
+					WithArgs(int64(1), "POTONG5RB", "", "fixed", int64(0), int64(500_000), int64(0), int64(0), "1,4", "", "", "", int64(0), int64(0), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail Update (no row)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, promotion: promotion},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE.+promotion.+SET`).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Update (db error)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, promotion: promotion},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE.+promotion.+SET`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			_, errNoRow, err := tt.repo.Update(tt.args.ctx, tt.args.businessID, tt.args.promotion)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_promotionRepository_Delete(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		id         int64
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		repo          *promotionRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErrNoRow  bool
+		wantErr       bool
+	}{
+		{
+			name: "success Delete",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM promotion.+WHERE id = \$1 AND business_id = \$2 AND NOT EXISTS`).WithArgs(int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Delete (not found or used by an order)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM promotion.+WHERE id = \$1 AND business_id = \$2 AND NOT EXISTS`).WithArgs(int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail Delete (db error)",
+			repo: &promotionRepository{},
+			args: args{ctx: context.Background(), businessID: 1, id: 1},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM promotion`).WithArgs(int64(1), int64(1)).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotNAffected, errNoRow, err := tt.repo.Delete(tt.args.ctx, tt.args.businessID, tt.args.id)
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
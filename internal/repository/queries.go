@@ -3,16 +3,30 @@ package repository
 import (
 	"family-catering/internal/model"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 const (
-	// owner's queries (owner table)
+	// owner's queries (owner table), every owner belongs to a business (tenant) and only sees the business' data.
+	// An owner without role is the admin when it's the first one of its business and a viewer otherwise
 	createOwner = `
 	INSERT INTO owner
-		(name, email, password, phone_number)
+		(name, email, password, phone_number, role, business_id)
 	VALUES
-		($1, $2, $3, $4)
+		($1, $2, $3, $4, COALESCE(NULLIF($5, ''), CASE WHEN EXISTS (SELECT 1 FROM owner WHERE business_id = $6) THEN 'viewer' ELSE 'admin' END), $6)
+	RETURNING id`
+	// an owner registering itself runs a new business and is its admin
+	createOwnerWithBusiness = `
+	WITH business AS (
+		INSERT INTO business (name) VALUES ($5) RETURNING id
+	)
+	INSERT INTO owner
+		(name, email, password, phone_number, role, business_id)
+	SELECT
+		$1, $2, $3, $4, 'admin', id
+	FROM
+		business
 	RETURNING id`
 
 	updateOwner = `
@@ -22,67 +36,129 @@ const (
 		name = COALESCE(NULLIF($2, ''), name),
 		phone_number = COALESCE(NULLIF($3, ''), phone_number),
 		date_of_birth = COALESCE(NULLIF($4, '')::date, date_of_birth)
-	WHERE id = $1`
+	WHERE id = $1 AND business_id = $5`
 
 	getOwner = `
 	SELECT
-		id, name, email, phone_number, date_of_birth, password 
+		id, name, email, phone_number, date_of_birth, password, role, business_id, email_verified_at IS NOT NULL
 	FROM 
 		owner 
 	WHERE 
 		id = $1`
 	getOwnerByEmail = `
 	SELECT
-		id, name, email, phone_number, date_of_birth, password 
+		id, name, email, phone_number, date_of_birth, password, role, business_id, email_verified_at IS NOT NULL
 		FROM 
 			owner 
 		WHERE 
 			email = $1`
 	listOwners = `SELECT
-	id, name, email, phone_number, date_of_birth 
+	id, name, email, phone_number, date_of_birth, role, business_id
 	FROM 
 		owner 
+	WHERE
+		business_id = $3
+	ORDER BY id
 	LIMIT $1 OFFSET $2`
 	deleteOwner                = `DELETE FROM owner WHERE id = $1`
 	updateOwnerPasswordByEmail = `UPDATE owner SET password = $2 WHERE email = $1`
 	updateOwnerPasswordByID    = `UPDATE owner SET password = $2 WHERE id = $1`
 	updateEmailByID            = `UPDATE owner SET email = $2 WHERE id = $1`
+	// the last admin of a business keeps its role and isn't deleted, nobody could manage the staff otherwise
+	updateOwnerRoleByID = `
+	UPDATE owner SET role = $2
+	WHERE id = $1 AND business_id = $3
+	AND ($2 = 'admin' OR role <> 'admin' OR EXISTS (SELECT 1 FROM owner WHERE role = 'admin' AND id <> $1 AND business_id = $3))`
+	deleteStaffByID = `
+	DELETE FROM owner
+	WHERE id = $1 AND business_id = $2
+	AND (role <> 'admin' OR EXISTS (SELECT 1 FROM owner WHERE role = 'admin' AND id <> $1 AND business_id = $2))`
+
+	// business's queries (business table)
+	getBusinessByID    = `SELECT id, name, created_at, updated_at FROM business WHERE id = $1`
+	updateBusinessByID = `UPDATE business SET name = $2 WHERE id = $1`
 
 	// auth's queries (session table)
 	insertAuthLogin = `
 	INSERT INTO auth
-		(sid, owner_id, email, refresh_token, jti, expired_at)
-	VALUES($1, $2, $3, $4, $5, $6) RETURNING sid`
+		(sid, owner_id, email, refresh_token, jti, expired_at, user_agent, ip)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING sid`
 
-	getSessionBySessionID = `SELECT sid, owner_id, email, refresh_token, jti, expired_at FROM auth WHERE sid = $1`
-	getSessionByEmail     = `SELECT sid FROM auth WHERE email = $1`
+	getSessionBySessionID = `SELECT sid, owner_id, email, jti, refresh_token, expired_at FROM auth WHERE sid = $1`
 	deleteSession         = `DELETE FROM auth WHERE sid = $1`
+	listSessionsByOwnerID = `
+	SELECT sid, owner_id, user_agent, ip, expired_at, last_seen_at, created_at FROM auth
+	WHERE owner_id = $1 AND expired_at > NOW() ORDER BY last_seen_at DESC`
+	deleteSessionsByOwnerID = `DELETE FROM auth WHERE owner_id = $1 RETURNING sid`
+	// last_seen_at is written at most once a minute, it's touched by every request of the session
+	touchSession = `UPDATE auth SET last_seen_at = NOW() WHERE sid = $1 AND last_seen_at < NOW() - INTERVAL '1 minute'`
+
+	// a renewal rotates the session's refresh token only when it's still the renewed one, the used jti is kept to detect its reuse
+	rotateRefreshToken    = `UPDATE auth SET jti = $3, refresh_token = $4, expired_at = $5 WHERE sid = $1 AND jti = $2`
+	insertUsedJti         = `INSERT INTO auth_used_jti (jti, sid) VALUES ($1, $2)`
+	getSessionIDByUsedJti = `SELECT sid FROM auth_used_jti WHERE jti = $1`
+
+	// two factor's queries (owner_totp and owner_recovery_code tables), an enabled secret is only replaced after a reset
+	getOwnerTOTP       = `SELECT owner_id, secret, enabled, last_step FROM owner_totp WHERE owner_id = $1`
+	setOwnerTOTPSecret = `
+	INSERT INTO owner_totp (owner_id, secret) VALUES ($1, $2)
+	ON CONFLICT (owner_id) DO UPDATE SET secret = EXCLUDED.secret, last_step = 0 WHERE owner_totp.enabled = FALSE`
+	enableOwnerTOTP         = `UPDATE owner_totp SET enabled = TRUE, last_step = $2 WHERE owner_id = $1 AND enabled = FALSE`
+	deleteOwnerRecoveryCode = `DELETE FROM owner_recovery_code WHERE owner_id = $1`
+	insertOwnerRecoveryCode = `INSERT INTO owner_recovery_code (owner_id, code_hash) VALUES ($1, $2)`
+	// a code is used once, a code of the last used step or of a previous one is refused
+	useOwnerTOTPStep        = `UPDATE owner_totp SET last_step = $2 WHERE owner_id = $1 AND enabled = TRUE AND last_step < $2`
+	listUnusedRecoveryCodes = `SELECT id, owner_id, code_hash FROM owner_recovery_code WHERE owner_id = $1 AND used_at IS NULL ORDER BY id`
+	useRecoveryCode         = `UPDATE owner_recovery_code SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+	deleteOwnerTOTP         = `DELETE FROM owner_totp WHERE owner_id = $1`
+
+	// email verification's queries (owner_email_verification table), a new link voids the owner's pending ones
+	createOwnerEmailVerification = `
+	WITH superseded AS (
+		DELETE FROM owner_email_verification WHERE owner_id = $1 AND used_at IS NULL
+	)
+	INSERT INTO owner_email_verification (owner_id, email, token_hash, expired_at) VALUES ($1, $2, $3, $4)`
+	getOwnerEmailVerification = `
+	SELECT id, owner_id, email, token_hash, expired_at, used_at IS NOT NULL FROM owner_email_verification WHERE token_hash = $1`
+	useOwnerEmailVerification = `UPDATE owner_email_verification SET used_at = NOW() WHERE id = $1 AND used_at IS NULL RETURNING owner_id, email`
+	verifyOwnerEmail          = `UPDATE owner SET email = $2, email_verified_at = NOW() WHERE id = $1`
 
 	// menu's queries (menu table)
+	// ordered_today is the portions of the menu delivered today by orders which are neither cancelled nor refunded (see
+	// daily_capacity)
 	getMenuByID = `
 	SELECT 
-		id, name, price, categories 
+		id, name, price, categories, stock, daily_capacity,
+		(SELECT COALESCE(SUM(o.qty), 0) FROM "order" o
+			WHERE o.menu_id = menu.id AND o.status NOT IN (3, 9) AND o.delivery_date = CURRENT_DATE) AS ordered_today
 	FROM 
 		menu 
 	WHERE 
-		id = $1`
+		id = $1 AND business_id = $2`
 	getMenuByName = `
 	SELECT 
-		id, name, price, categories 
+		id, name, price, categories, stock, daily_capacity,
+		(SELECT COALESCE(SUM(o.qty), 0) FROM "order" o
+			WHERE o.menu_id = menu.id AND o.status NOT IN (3, 9) AND o.delivery_date = CURRENT_DATE) AS ordered_today
 	FROM 
 		menu 
 	WHERE 
-		name = $1`
+		name = $1 AND business_id = $2`
 	listMenu = `
 	SELECT 
-		id, name, price, categories 
+		id, name, price, categories, stock, daily_capacity,
+		(SELECT COALESCE(SUM(o.qty), 0) FROM "order" o
+			WHERE o.menu_id = menu.id AND o.status NOT IN (3, 9) AND o.delivery_date = CURRENT_DATE) AS ordered_today
 	FROM
 		menu
+	WHERE
+		business_id = $3
+	ORDER BY id
 	LIMIT $1 OFFSET $2`
 	createMenu = `
 	INSERT INTO menu
-		(name, price, categories) 
-	VALUES($1, $2, $3) RETURNING id`
+		(name, price, categories, business_id) 
+	VALUES($1, $2, $3, $4) RETURNING id`
 	updateMenuByID = `
 	UPDATE 
 		menu 
@@ -91,22 +167,659 @@ const (
 		price = COALESCE(NULLIF($3, 0), price),
 		categories = COALESCE(NULLIF($4, ''), categories)
 	WHERE 
-		id = $1`
-	deleteMenuByID = `DELETE FROM menu WHERE id = $1`
+		id = $1 AND business_id = $5`
+	deleteMenuByID             = `DELETE FROM menu WHERE id = $1 AND business_id = $2`
+	updateMenuAvailabilityByID = `UPDATE menu SET stock = $2, daily_capacity = $3 WHERE id = $1 AND business_id = $4`
+	// the ordered menus are locked one by one (by id) before their portions are counted and taken,
+	// the count must be a separate statement so it sees the orders committed while waiting for the lock.
+	// A menu of another business is not found
+	getMenuAvailabilityForUpdate = `SELECT stock, daily_capacity FROM menu WHERE id = $1 AND business_id = $2 FOR UPDATE`
+	// portions of a menu delivered on a day (today when it's empty) by orders which are neither cancelled nor refunded
+	countMenuOrderedQty = `
+	SELECT
+		COALESCE(SUM(qty), 0)
+	FROM
+		"order"
+	WHERE
+		menu_id = $1 AND status NOT IN (3, 9) AND delivery_date = COALESCE(NULLIF($2, '')::DATE, CURRENT_DATE)`
+	// a negative qty takes portions from the stock, untracked stock (NULL) is left as is
+	updateMenuStock = `UPDATE menu SET stock = stock + $2 WHERE id = $1 AND stock IS NOT NULL`
+
+	// promotion's queries (promotion table), active is computed with the database clock
+	getPromotionByID = `
+	SELECT
+		id, code, description, discount_type, percentage, amount, max_discount, min_spend, menu_ids, categories,
+		starts_at, ends_at, usage_limit, usage_limit_per_customer,
+		(starts_at <= NOW() AND (ends_at IS NULL OR ends_at > NOW())) AS active, created_at, updated_at
+	FROM
+		promotion
+	WHERE
+		id = $1 AND business_id = $2`
+	getPromotionByCode = `
+	SELECT
+		id, code, description, discount_type, percentage, amount, max_discount, min_spend, menu_ids, categories,
+		starts_at, ends_at, usage_limit, usage_limit_per_customer,
+		(starts_at <= NOW() AND (ends_at IS NULL OR ends_at > NOW())) AS active, created_at, updated_at
+	FROM
+		promotion
+	WHERE
+		code = $1 AND business_id = $2`
+	listPromotion = `
+	SELECT
+		id, code, description, discount_type, percentage, amount, max_discount, min_spend, menu_ids, categories,
+		starts_at, ends_at, usage_limit, usage_limit_per_customer,
+		(starts_at <= NOW() AND (ends_at IS NULL OR ends_at > NOW())) AS active, created_at, updated_at
+	FROM
+		promotion
+	WHERE
+		business_id = $3
+	ORDER BY id
+	LIMIT $1 OFFSET $2`
+	createPromotion = `
+	INSERT INTO promotion
+		(code, description, discount_type, percentage, amount, max_discount, min_spend, menu_ids, categories,
+		starts_at, ends_at, usage_limit, usage_limit_per_customer, business_id)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE(NULLIF($10, '')::TIMESTAMPTZ, NOW()), NULLIF($11, '')::TIMESTAMPTZ, $12, $13, $14)
+	RETURNING id`
+	updatePromotionByID = `
+	UPDATE
+		promotion
+	SET
+		code = $2,
+		description = $3,
+		discount_type = $4,
+		percentage = $5,
+		amount = $6,
+		max_discount = $7,
+		min_spend = $8,
+		menu_ids = $9,
+		categories = $10,
+		starts_at = COALESCE(NULLIF($11, '')::TIMESTAMPTZ, starts_at),
+		ends_at = NULLIF($12, '')::TIMESTAMPTZ,
+		usage_limit = $13,
+		usage_limit_per_customer = $14
+	WHERE
+		id = $1 AND business_id = $15`
+	// a promotion used by an order is kept for the order's discount line, end it (ends_at) instead
+	deletePromotionByID = `
+	DELETE FROM promotion
+	WHERE id = $1 AND business_id = $2 AND NOT EXISTS (SELECT 1 FROM order_discount WHERE promotion_id = $1)`
+
+	// delivery zone's queries (delivery_zone table)
+	getDeliveryZoneByID = `
+	SELECT
+		id, name, postal_codes, polygon::TEXT, fee, min_order, active, created_at, updated_at
+	FROM
+		delivery_zone
+	WHERE
+		id = $1 AND business_id = $2`
+	listDeliveryZone = `
+	SELECT
+		id, name, postal_codes, polygon::TEXT, fee, min_order, active, created_at, updated_at
+	FROM
+		delivery_zone
+	WHERE
+		business_id = $3
+	ORDER BY id
+	LIMIT $1 OFFSET $2`
+	// an address is matched against the zones in the order of their id (see service.DeliveryZoneResolver)
+	listActiveDeliveryZone = `
+	SELECT
+		id, name, postal_codes, polygon::TEXT, fee, min_order, active, created_at, updated_at
+	FROM
+		delivery_zone
+	WHERE
+		active AND business_id = $1
+	ORDER BY id`
+	createDeliveryZone = `
+	INSERT INTO delivery_zone
+		(name, postal_codes, polygon, fee, min_order, active, business_id)
+	VALUES($1, $2, NULLIF($3, '')::JSONB, $4, $5, $6, $7)
+	RETURNING id`
+	updateDeliveryZoneByID = `
+	UPDATE
+		delivery_zone
+	SET
+		name = $2,
+		postal_codes = $3,
+		polygon = NULLIF($4, '')::JSONB,
+		fee = $5,
+		min_order = $6,
+		active = $7
+	WHERE
+		id = $1 AND business_id = $8`
+	// the orders of a deleted zone keep their destination and delivery fee (see order_charge)
+	deleteDeliveryZoneByID = `DELETE FROM delivery_zone WHERE id = $1 AND business_id = $2`
 
-	// order's queries (order table)
-	confirmPaymentViaEmail       = `UPDATE "order" SET status = 2 WHERE customer_email = $1 AND status = 1`
-	updateOrderStatusToCancelled = `UPDATE "order" SET status = 3 WHERE status = 1 AND created_at > NOW() - interval '1 day' and created_at <= NOW();`
+	// driver's queries (driver and delivery_assignment table)
+	getDriverByID = `
+	SELECT
+		id, name, phone, active, created_at, updated_at
+	FROM
+		driver
+	WHERE
+		id = $1 AND business_id = $2`
+	listDriver = `
+	SELECT
+		id, name, phone, active, created_at, updated_at
+	FROM
+		driver
+	WHERE
+		business_id = $3
+	ORDER BY id
+	LIMIT $1 OFFSET $2`
+	createDriver = `
+	INSERT INTO driver
+		(name, phone, active, business_id)
+	VALUES($1, $2, $3, $4)
+	RETURNING id`
+	updateDriverByID = `
+	UPDATE
+		driver
+	SET
+		name = $2,
+		phone = $3,
+		active = $4
+	WHERE
+		id = $1 AND business_id = $5`
+	// the deliveries of a driver are kept as the proof of delivery, the undelivered ones are unassigned
+	deleteDriverByID = `
+	DELETE FROM driver
+	WHERE id = $1 AND business_id = $2
+	AND NOT EXISTS (SELECT 1 FROM delivery_assignment WHERE driver_id = $1 AND delivered_at IS NOT NULL)`
+	// cancelled, delivered and refunded orders aren't assigned, neither are the orders delivered on another date
+	// nor the orders of another business than the driver's. An order assigned to another driver is moved unless it has been delivered
+	assignDelivery = `
+	INSERT INTO delivery_assignment
+		(order_id, driver_id, delivery_date)
+	SELECT DISTINCT
+		order_id, $1::BIGINT, delivery_date
+	FROM
+		"order"
+	WHERE
+		order_id = ANY(string_to_array($3, ',')::BIGINT[]) AND delivery_date = $2::DATE AND status NOT IN (3, 8, 9)
+		AND business_id = $4 AND EXISTS (SELECT 1 FROM driver WHERE id = $1 AND business_id = $4)
+	ON CONFLICT (order_id) DO UPDATE SET
+		driver_id = EXCLUDED.driver_id
+	WHERE
+		delivery_assignment.delivered_at IS NULL`
+	unassignDelivery = `
+	DELETE FROM delivery_assignment
+	WHERE
+		driver_id = $1 AND order_id = $2 AND delivered_at IS NULL
+		AND EXISTS (SELECT 1 FROM driver WHERE id = $1 AND business_id = $3)`
+	// the destination of an order is repeated on every of its rows, the first row is taken.
+	// The stops of the orders cancelled or refunded after they were assigned are left out
+	listDeliveryStops = `
+	SELECT DISTINCT ON (a.order_id)
+		a.order_id, a.driver_id, a.delivery_date::TEXT, o.customer_email, o.delivery_address, o.delivery_postal_code,
+		o.delivery_latitude, o.delivery_longitude, COALESCE(TO_CHAR(o.delivery_start, 'HH24:MI'), ''),
+		COALESCE(TO_CHAR(o.delivery_end, 'HH24:MI'), ''), o.status, a.delivered_at
+	FROM
+		delivery_assignment a
+	JOIN
+		"order" o ON o.order_id = a.order_id
+	WHERE
+		a.driver_id = $1 AND a.delivery_date = $2::DATE AND o.status NOT IN (3, 9) AND o.business_id = $3
+	ORDER BY a.order_id, o.base_order_id`
+	getDeliveryStop = `
+	SELECT
+		a.order_id, a.driver_id, a.delivery_date::TEXT, o.customer_email, o.delivery_address, o.delivery_postal_code,
+		o.delivery_latitude, o.delivery_longitude, COALESCE(TO_CHAR(o.delivery_start, 'HH24:MI'), ''),
+		COALESCE(TO_CHAR(o.delivery_end, 'HH24:MI'), ''), o.status, a.delivered_at
+	FROM
+		delivery_assignment a
+	JOIN
+		"order" o ON o.order_id = a.order_id
+	WHERE
+		a.driver_id = $1 AND a.order_id = $2 AND o.business_id = $3
+	ORDER BY o.base_order_id
+	LIMIT 1`
+	// the stop is delivered along with its order (moved from status $3 to DELIVERED) at once,
+	// no row is returned when the stop has been delivered or the order has been moved in the meantime
+	markDeliveryDelivered = `
+	WITH delivered AS (
+		UPDATE delivery_assignment SET delivered_at = NOW()
+		WHERE driver_id = $1 AND order_id = $2 AND delivered_at IS NULL
+		AND EXISTS (SELECT 1 FROM "order" WHERE order_id = $2 AND status = $3 AND business_id = $5)
+		RETURNING order_id, delivered_at
+	), updated AS (
+		UPDATE "order" o SET status = 8 FROM delivered d WHERE o.order_id = d.order_id AND o.status = $3 RETURNING o.order_id
+	), history AS (
+		INSERT INTO order_status_history
+			(order_id, from_status, to_status, changed_by)
+		SELECT DISTINCT order_id, $3::INT4, 8, NULLIF($4::BIGINT, 0) FROM updated
+	)
+	SELECT delivered_at FROM delivered`
+
+	// customer's queries (customer and customer_address table)
+	getCustomerByID = `
+	SELECT
+		id, name, email, phone, dietary_notes, marketing_consent, created_at, updated_at
+	FROM
+		customer
+	WHERE
+		id = $1 AND business_id = $2`
+	getCustomerByEmail = `
+	SELECT
+		id, name, email, phone, dietary_notes, marketing_consent, created_at, updated_at
+	FROM
+		customer
+	WHERE
+		email = $1 AND business_id = $2`
+	listCustomer = `
+	SELECT
+		id, name, email, phone, dietary_notes, marketing_consent, created_at, updated_at
+	FROM
+		customer
+	WHERE
+		business_id = $3
+	ORDER BY id
+	LIMIT $1 OFFSET $2`
+	createCustomer = `
+	INSERT INTO customer
+		(name, email, phone, dietary_notes, marketing_consent, business_id)
+	VALUES($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+	// the details of an existing customer are left as they are, the no-op update makes the row returned
+	findOrCreateCustomer = `
+	INSERT INTO customer
+		(name, email, phone, dietary_notes, marketing_consent, business_id)
+	VALUES($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (business_id, email) DO UPDATE SET email = EXCLUDED.email
+	RETURNING id`
+	updateCustomerByID = `
+	UPDATE
+		customer
+	SET
+		name = $2,
+		email = $3,
+		phone = $4,
+		dietary_notes = $5,
+		marketing_consent = $6
+	WHERE
+		id = $1 AND business_id = $7`
+	// a customer who has ordered is kept for its orders, its addresses are deleted along with it
+	deleteCustomerByID = `
+	DELETE FROM customer
+	WHERE id = $1 AND business_id = $2 AND NOT EXISTS (SELECT 1 FROM "order" WHERE customer_id = $1)`
+	// the addresses are only reached through their customer, a customer of another business has none
+	listCustomerAddresses = `
+	SELECT
+		id, customer_id, label, address, postal_code, latitude, longitude, notes, is_default, created_at, updated_at
+	FROM
+		customer_address
+	WHERE
+		customer_id = $1 AND EXISTS (SELECT 1 FROM customer WHERE id = $1 AND business_id = $2)
+	ORDER BY is_default DESC, id`
+	// the first address of a customer is its default address
+	createCustomerAddress = `
+	INSERT INTO customer_address
+		(customer_id, label, address, postal_code, latitude, longitude, notes, is_default)
+	SELECT
+		$1, $2, $3, $4, $5, $6, $7, $8 OR NOT EXISTS (SELECT 1 FROM customer_address WHERE customer_id = $1)
+	WHERE
+		EXISTS (SELECT 1 FROM customer WHERE id = $1 AND business_id = $9)
+	RETURNING id`
+	updateCustomerAddress = `
+	UPDATE
+		customer_address
+	SET
+		label = $3,
+		address = $4,
+		postal_code = $5,
+		latitude = $6,
+		longitude = $7,
+		notes = $8,
+		is_default = $9
+	WHERE
+		customer_id = $1 AND id = $2 AND EXISTS (SELECT 1 FROM customer WHERE id = $1 AND business_id = $10)`
+	deleteCustomerAddress = `
+	DELETE FROM customer_address
+	WHERE customer_id = $1 AND id = $2 AND EXISTS (SELECT 1 FROM customer WHERE id = $1 AND business_id = $3)`
+	// the default address is unset before another address becomes the default (see uq_customer_address_default)
+	unsetDefaultCustomerAddress = `
+	UPDATE customer_address SET is_default = FALSE
+	WHERE customer_id = $1 AND id <> $2 AND is_default AND EXISTS (SELECT 1 FROM customer WHERE id = $1 AND business_id = $3)`
+
+	// order's queries (order table), the rows of another business are never matched except by the unpaid orders' cancellation
+	// every status change of an order is recorded at order_status_history (one row per order_id)
+	// and the stock of the menus of a cancelled order is given back
+	updateOrderStatusToCancelled = `
+	WITH updated AS (
+		UPDATE "order" o SET status = 3
+		FROM (
+			SELECT base_order_id, status FROM "order"
+			WHERE status IN (1, 4) AND created_at > NOW() - interval '1 day' and created_at <= NOW()
+			AND NOT EXISTS (SELECT 1 FROM payment p WHERE p.order_id = "order".order_id)
+		) old
+		WHERE o.base_order_id = old.base_order_id
+		RETURNING o.order_id, o.menu_id, o.qty, old.status
+	), restocked AS (
+		UPDATE menu m SET stock = m.stock + u.qty
+		FROM (SELECT menu_id, SUM(qty) AS qty FROM updated GROUP BY menu_id) u
+		WHERE m.id = u.menu_id AND m.stock IS NOT NULL
+	)
+	INSERT INTO order_status_history
+		(order_id, from_status, to_status)
+	SELECT DISTINCT order_id, status, 3 FROM updated`
+	getOrderStatus    = `SELECT status FROM "order" WHERE order_id = $1 AND business_id = $2 LIMIT 1`
+	updateOrderStatus = `
+	WITH updated AS (
+		UPDATE "order" SET status = $3 WHERE order_id = $1 AND status = $2 AND business_id = $5 RETURNING order_id, menu_id, qty
+	), restocked AS (
+		UPDATE menu m SET stock = m.stock + u.qty
+		FROM (SELECT menu_id, SUM(qty) AS qty FROM updated WHERE $3::INT4 = 3 GROUP BY menu_id) u
+		WHERE m.id = u.menu_id AND m.stock IS NOT NULL
+	)
+	INSERT INTO order_status_history
+		(order_id, from_status, to_status, changed_by)
+	SELECT DISTINCT order_id, $2::INT4, $3::INT4, NULLIF($4::BIGINT, 0) FROM updated`
+	// an order could only be modified while it's not paid yet (NEW or CONFIRMED)
+	getOrderByID = `
+	SELECT
+		o.base_order_id, o.order_id, o.customer_id, o.customer_email, o.menu_id, o.menu_name, o.price, o.qty, o.status, o.created_at, o.updated_at,
+		o.delivery_date::TEXT, COALESCE(TO_CHAR(o.delivery_start, 'HH24:MI'), ''), COALESCE(TO_CHAR(o.delivery_end, 'HH24:MI'), ''), o.notes,
+		o.delivery_address, o.delivery_postal_code, o.delivery_latitude, o.delivery_longitude, o.delivery_zone_id,
+		COALESCE(d.code, ''), COALESCE(d.amount, 0),
+		COALESCE(c.service_charge, 0), COALESCE(c.tax, 0), COALESCE(c.tax_inclusive, FALSE), COALESCE(c.delivery_fee, 0)
+	FROM
+		"order" o
+	LEFT JOIN
+		order_discount d ON d.order_id = o.order_id
+	LEFT JOIN
+		order_charge c ON c.order_id = o.order_id
+	WHERE
+		o.order_id = $1 AND o.business_id = $2
+	ORDER BY o.base_order_id`
+	updateOrderCustomer = `
+	UPDATE "order" SET customer_id = $2, customer_email = $3 WHERE order_id = $1 AND status IN (1, 4) AND business_id = $4`
+	insertOrderItem = `
+	INSERT INTO "order"
+		(order_id, customer_id, customer_email, menu_id, menu_name, price, qty, status, delivery_date, delivery_start, delivery_end, notes,
+		delivery_address, delivery_postal_code, delivery_latitude, delivery_longitude, delivery_zone_id, business_id)
+	SELECT
+		order_id, customer_id, customer_email, $2, $3, $4, $5, status, delivery_date, delivery_start, delivery_end, notes,
+		delivery_address, delivery_postal_code, delivery_latitude, delivery_longitude, delivery_zone_id, business_id
+	FROM
+		"order"
+	WHERE
+		order_id = $1 AND status IN (1, 4) AND business_id = $6
+	LIMIT 1
+	RETURNING base_order_id`
+	addOrderItemQty = `
+	UPDATE "order" SET qty = qty + $3
+	WHERE order_id = $1 AND menu_id = $2 AND status IN (1, 4) AND business_id = $4
+	RETURNING base_order_id`
+	// the delivery date of an item is the day its portions are counted in (see countMenuOrderedQty)
+	// the order's rows are locked before its items are changed and repriced, a payment waits until the order is repriced.
+	// The statements following the lock see the rows committed while waiting for it
+	lockOrder             = `SELECT 1 FROM "order" WHERE order_id = $1 FOR UPDATE`
+	getOrderItemForUpdate = `
+	SELECT
+		menu_id, qty, delivery_date::TEXT
+	FROM
+		"order"
+	WHERE
+		order_id = $1 AND base_order_id = $2 AND status IN (1, 4) AND business_id = $3
+	FOR UPDATE`
+	updateOrderItemQty = `
+	UPDATE "order" SET qty = $3 WHERE order_id = $1 AND base_order_id = $2 AND status IN (1, 4) AND business_id = $4`
+	deleteOrderItem = `
+	WITH deleted AS (
+		DELETE FROM "order"
+		WHERE
+			order_id = $1 AND base_order_id = $2 AND status IN (1, 4) AND business_id = $3
+			AND (SELECT COUNT(*) FROM "order" WHERE order_id = $1) > 1
+		RETURNING menu_id, qty
+	), restocked AS (
+		UPDATE menu m SET stock = m.stock + d.qty FROM deleted d WHERE m.id = d.menu_id AND m.stock IS NOT NULL
+	)
+	SELECT COUNT(*) FROM deleted`
+	// a delivery slot has no row to lock so the orders of a slot are serialized by a transaction level advisory lock
+	// before they are counted, the count must be a separate statement (see getMenuAvailabilityForUpdate).
+	// Every business has its own slots
+	lockDeliverySlot        = `SELECT pg_advisory_xact_lock(hashtext('delivery_slot:' || $3::TEXT || ' ' || $1::TEXT || ' ' || $2::TEXT))`
+	countDeliverySlotOrders = `
+	SELECT
+		COUNT(DISTINCT order_id)
+	FROM
+		"order"
+	WHERE
+		delivery_date = $1::DATE AND delivery_start = $2::TIME AND status NOT IN (3, 9) AND business_id = $3`
+	// the promotion is locked so its usages are counted and recorded by one order at a time,
+	// usages of cancelled (or refunded) orders are not counted
+	getPromotionUsageForUpdate = `
+	WITH promo AS (
+		SELECT id, usage_limit, usage_limit_per_customer FROM promotion WHERE id = $1 FOR UPDATE
+	)
+	SELECT
+		promo.usage_limit,
+		promo.usage_limit_per_customer,
+		COUNT(d.id),
+		COUNT(d.id) FILTER (WHERE LOWER(d.customer_email) = LOWER($2))
+	FROM
+		promo
+	LEFT JOIN
+		order_discount d ON d.promotion_id = promo.id
+		AND EXISTS (SELECT 1 FROM "order" o WHERE o.order_id = d.order_id AND o.status NOT IN (3, 9))
+	GROUP BY promo.usage_limit, promo.usage_limit_per_customer`
+	insertOrderDiscount = `
+	INSERT INTO order_discount
+		(order_id, promotion_id, code, customer_email, amount)
+	VALUES($1, $2, $3, $4, $5) RETURNING id`
+	getOrderDiscount = `
+	SELECT
+		id, order_id, promotion_id, code, customer_email, amount, created_at
+	FROM
+		order_discount
+	WHERE
+		order_id = $1 AND EXISTS (SELECT 1 FROM "order" WHERE order_id = $1 AND business_id = $2)`
+	updateOrderDiscountAmount = `
+	UPDATE order_discount SET amount = $2
+	WHERE
+		order_id = $1 AND EXISTS (SELECT 1 FROM "order" WHERE order_id = $1 AND status IN (1, 4) AND business_id = $3)`
+	// the categories are the menu's current categories, a deleted menu has none
+	listOrderByDeliveryDate = `
+	SELECT
+		o.base_order_id, o.order_id, o.customer_id, o.customer_email, o.menu_id, o.menu_name, COALESCE(m.categories, ''), o.qty, o.status,
+		o.delivery_date::TEXT, COALESCE(TO_CHAR(o.delivery_start, 'HH24:MI'), ''), COALESCE(TO_CHAR(o.delivery_end, 'HH24:MI'), ''), o.notes
+	FROM
+		"order" o
+	LEFT JOIN
+		menu m ON m.id = o.menu_id
+	WHERE
+		o.delivery_date = $1::DATE AND o.status NOT IN (3, 9) AND o.business_id = $2
+	ORDER BY o.menu_id, o.delivery_start NULLS FIRST, o.order_id, o.base_order_id`
+	listOrderCharges = `
+	SELECT
+		order_id, sub_total, discount, service_charge, service_charge_rate, tax, tax_inclusive, delivery_fee, grand_total, created_at, updated_at
+	FROM
+		order_charge
+	WHERE
+		order_id = ANY(string_to_array($1, ',')::BIGINT[])
+		AND EXISTS (SELECT 1 FROM "order" o WHERE o.order_id = order_charge.order_id AND o.business_id = $2)
+	ORDER BY order_id`
+	upsertOrderCharge = `
+	INSERT INTO order_charge
+		(order_id, sub_total, discount, service_charge, service_charge_rate, tax, tax_inclusive, delivery_fee, grand_total)
+	SELECT
+		$1::BIGINT, $2::BIGINT, $3::BIGINT, $4::BIGINT, $5::INT4, $6::BIGINT, $7::BOOLEAN, $8::BIGINT, $9::BIGINT
+	WHERE
+		EXISTS (SELECT 1 FROM "order" WHERE order_id = $1 AND status IN (1, 4) AND business_id = $10)
+	ON CONFLICT (order_id) DO UPDATE SET
+		sub_total = EXCLUDED.sub_total,
+		discount = EXCLUDED.discount,
+		service_charge = EXCLUDED.service_charge,
+		service_charge_rate = EXCLUDED.service_charge_rate,
+		tax = EXCLUDED.tax,
+		tax_inclusive = EXCLUDED.tax_inclusive,
+		delivery_fee = EXCLUDED.delivery_fee,
+		grand_total = EXCLUDED.grand_total`
+	listOrderStatusHistory = `
+	SELECT
+		id, order_id, from_status, to_status, changed_by, created_at
+	FROM
+		order_status_history
+	WHERE
+		order_id = $1 AND EXISTS (SELECT 1 FROM "order" WHERE order_id = $1 AND business_id = $2)
+	ORDER BY created_at, id`
+
+	// orderPaid is the condition of the reports' order o to be paid: its payments cover its grand total (see getOrderTotal).
+	// Its status doesn't prove it, a CONFIRMED order may be prepared and delivered before it's paid
+	orderPaid = `
+		(SELECT COALESCE(SUM(amount), 0) FROM payment WHERE order_id = o.order_id) >= COALESCE(
+			(SELECT grand_total FROM order_charge WHERE order_id = o.order_id),
+			(SELECT SUM(price * qty) FROM "order" WHERE order_id = o.order_id)
+				- COALESCE((SELECT amount FROM order_discount WHERE order_id = o.order_id), 0)
+		)`
+
+	// report's queries (order table), only the rows of paid orders which haven't been refunded are sales,
+	// the grouping set () is the total of the whole range (see salesReportQuery).
+	// The revenue of an order is its grand total (see getOrderTotal) shared by its rows in proportion to their price * qty,
+	// the share is computed over every row of the order even the rows created out of the range
+	salesReport = `
+	SELECT
+		GROUPING(%[1]s) = 1, COALESCE(%[2]s, ''), COALESCE(%[3]s, 0),
+		COALESCE(ROUND(SUM(o.revenue)), 0)::BIGINT,
+		COUNT(DISTINCT o.order_id),
+		COALESCE(ROUND(SUM(o.revenue) / NULLIF(COUNT(DISTINCT o.order_id), 0)), 0)::BIGINT,
+		COALESCE(SUM(o.qty), 0)::BIGINT
+	FROM (
+		SELECT
+			o.*,
+			o.price * o.qty * COALESCE(c.grand_total, SUM(o.price * o.qty) OVER w - COALESCE(d.amount, 0))::NUMERIC
+				/ NULLIF(SUM(o.price * o.qty) OVER w, 0) AS revenue
+		FROM
+			"order" o
+		LEFT JOIN
+			order_charge c ON c.order_id = o.order_id
+		LEFT JOIN
+			order_discount d ON d.order_id = o.order_id
+		WHERE
+			o.status IN (2, 5, 6, 7, 8) AND o.business_id = $3
+			AND o.order_id IN (SELECT order_id FROM "order" WHERE created_at >= $1::DATE AND created_at < $2::DATE + 1 AND business_id = $3)
+			AND ` + orderPaid + `
+		WINDOW w AS (PARTITION BY o.order_id)
+	) o
+	LEFT JOIN
+		menu m ON m.id = o.menu_id
+	WHERE
+		o.created_at >= $1::DATE AND o.created_at < $2::DATE + 1
+	GROUP BY GROUPING SETS ((%[1]s), ())
+	ORDER BY GROUPING(%[1]s), %[4]s`
+
+	// a customer is identified by the email of its orders, the spend of an order is its grand total (see getOrderTotal)
+	customerReport = `
+	WITH paid AS (
+		SELECT
+			o.order_id, o.customer_email, MIN(o.created_at) AS created_at,
+			COALESCE(MAX(c.grand_total), SUM(o.price * o.qty) - COALESCE(MAX(d.amount), 0)) AS total
+		FROM
+			"order" o
+		LEFT JOIN
+			order_charge c ON c.order_id = o.order_id
+		LEFT JOIN
+			order_discount d ON d.order_id = o.order_id
+		WHERE
+			o.status IN (2, 5, 6, 7, 8) AND o.business_id = $4 AND ` + orderPaid + `
+		GROUP BY o.order_id, o.customer_email
+	), customer AS (
+		SELECT
+			customer_email, SUM(total) AS lifetime_spend, COUNT(*) AS orders, ROUND(AVG(total)) AS average_order_value,
+			MIN(created_at) AS first_order_at, MAX(created_at) AS last_order_at
+		FROM
+			paid
+		GROUP BY customer_email
+	)
+	SELECT
+		customer_email, lifetime_spend::BIGINT, orders, average_order_value::BIGINT,
+		first_order_at::DATE::TEXT, last_order_at::DATE::TEXT,
+		COALESCE(ROUND((EXTRACT(EPOCH FROM last_order_at - first_order_at) / 86400 / NULLIF(orders - 1, 0))::NUMERIC, 1), 0)::FLOAT8,
+		last_order_at < NOW() - MAKE_INTERVAL(days => $1)
+	FROM
+		customer
+	ORDER BY %s %s, customer_email
+	LIMIT $2 OFFSET $3`
+	countCustomerReport = `SELECT COUNT(DISTINCT customer_email) FROM "order" o WHERE status IN (2, 5, 6, 7, 8) AND business_id = $1 AND ` + orderPaid
+	// the favourite menus are the menus each customer ordered the most portions of
+	listCustomerFavouriteMenus = `
+	SELECT
+		customer_email, menu_id, menu_name, qty
+	FROM (
+		SELECT
+			customer_email, menu_id, (ARRAY_AGG(menu_name ORDER BY base_order_id DESC))[1] AS menu_name, SUM(qty)::BIGINT AS qty,
+			ROW_NUMBER() OVER (PARTITION BY customer_email ORDER BY SUM(qty) DESC, menu_id) AS rank
+		FROM
+			"order" o
+		WHERE
+			status IN (2, 5, 6, 7, 8) AND customer_email = ANY(string_to_array($1, ',')) AND business_id = $3 AND ` + orderPaid + `
+		GROUP BY customer_email, menu_id
+	) f
+	WHERE
+		rank <= $2
+	ORDER BY customer_email, rank`
+
+	// payment's queries (payment table)
+	// order's rows are locked so concurrent payments of the same order are serialized
+	// the total price is the order's grand total (see order_charge), orders without charge fall back to the items after discount.
+	// The order's business is returned so the payment is checked against the payer's business (see paymentRepository.Pay)
+	getOrderTotalForUpdate = `
+	SELECT
+		COUNT(*),
+		COALESCE(
+			(SELECT grand_total FROM order_charge WHERE order_id = $1),
+			COALESCE(SUM(price * qty), 0) - COALESCE((SELECT amount FROM order_discount WHERE order_id = $1), 0)
+		)::BIGINT,
+		COALESCE(MIN(status), 0),
+		COALESCE(MIN(business_id), 0)
+	FROM
+		(SELECT price, qty, status, business_id FROM "order" WHERE order_id = $1 FOR UPDATE) o`
+	getOrderTotal = `
+	SELECT
+		COUNT(*),
+		COALESCE(
+			(SELECT grand_total FROM order_charge WHERE order_id = $1),
+			COALESCE(SUM(price * qty), 0) - COALESCE((SELECT amount FROM order_discount WHERE order_id = $1), 0)
+		)::BIGINT,
+		COALESCE(MIN(status), 0)
+	FROM
+		"order"
+	WHERE
+		order_id = $1 AND business_id = $2`
+	getOrderTotalPaid = `SELECT COALESCE(SUM(amount), 0)::BIGINT FROM payment WHERE order_id = $1`
+	// an event is recorded either as payment or as refund
+	paymentEventExists = `
+	SELECT
+		EXISTS(SELECT 1 FROM payment WHERE provider = $1 AND provider_event_id = $2)
+		OR EXISTS(SELECT 1 FROM payment_refund WHERE provider = $1 AND provider_event_id = $2)`
+	insertPayment = `
+	INSERT INTO payment
+		(order_id, amount, method, reference_number, received_by, provider, provider_event_id)
+	VALUES
+		($1, $2, $3, NULLIF($4, ''), NULLIF($5::BIGINT, 0), NULLIF($6, ''), NULLIF($7, ''))
+	ON CONFLICT (provider, provider_event_id) DO NOTHING
+	RETURNING id`
+	insertPaymentRefund = `
+	INSERT INTO payment_refund
+		(order_id, amount, charge_id, provider, provider_event_id)
+	VALUES
+		($1, $2, NULLIF($3, ''), $4, $5)
+	ON CONFLICT (provider, provider_event_id) DO NOTHING
+	RETURNING id`
+	deletePaymentRefund = `DELETE FROM payment_refund WHERE provider = $1 AND provider_event_id = $2`
 )
 
-func menuDynamicSearchQuery(menu model.MenuQuery) (query string, args []interface{}) {
+// menuDynamicSearchQuery return the query searching the menus of the business matching every given criteria,
+// the query is empty when there is no criteria
+func menuDynamicSearchQuery(businessID int64, menu model.MenuQuery) (query string, args []interface{}) {
 	var (
 		nArgs  int
 		val    string
 		values []string
 	)
 	values = []string{}
-	args = make([]interface{}, 0, 4)
+	args = make([]interface{}, 0, 5)
 	searchMenu := `SELECT id, name, price, categories FROM menu WHERE `
 
 	if len(menu.Names) != 0 {
@@ -127,6 +840,17 @@ func menuDynamicSearchQuery(menu model.MenuQuery) (query string, args []interfac
 		values = append(values, val)
 	}
 
+	if len(menu.IDs) != 0 {
+		ids := make([]string, 0, len(menu.IDs))
+		for _, id := range menu.IDs {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		nArgs += 1
+		val = fmt.Sprintf(`id = ANY(string_to_array($%d, ',')::BIGINT[])`, nArgs)
+		args = append(args, strings.Join(ids, ","))
+		values = append(values, val)
+	}
+
 	if menu.Categories != "" {
 		nArgs += 1
 		if strings.Contains(menu.Categories, ",") {
@@ -139,10 +863,10 @@ func menuDynamicSearchQuery(menu model.MenuQuery) (query string, args []interfac
 
 	}
 
-	if menu.MinPrice != 0 {
+	if !menu.MinPrice.IsZero() {
 
 		nArgs += 1
-		if menu.MaxPrice != 0 {
+		if !menu.MaxPrice.IsZero() {
 			nArgs += 1
 			val = fmt.Sprintf(`price BETWEEN $%d AND $%d`, nArgs-1, nArgs)
 			args = append(args, menu.MinPrice)
@@ -158,22 +882,28 @@ func menuDynamicSearchQuery(menu model.MenuQuery) (query string, args []interfac
 	if len(values) == 0 {
 		return "", args
 	}
+	nArgs += 1
+	values = append(values, fmt.Sprintf(`business_id = $%d`, nArgs))
+	args = append(args, businessID)
 	query = fmt.Sprintf("%s%s;", searchMenu, strings.Join(values, " AND "))
 
 	return query, args
 }
 
-func dynamicSearchOrderQuery(order *model.OrderQuery) (query string, args []interface{}) {
+// dynamicSearchOrderQuery return the query searching the orders of the business matching every given criteria
+func dynamicSearchOrderQuery(businessID int64, order *model.OrderQuery) (query string, args []interface{}) {
 	var (
 		stmt, val string
 		values    []string
 		nArgs     int
 	)
 
-	stmt = `SELECT order_id, base_order_id, menu_name, customer_email,price, qty, created_at, updated_at, status FROM "order"`
+	stmt = `SELECT order_id, base_order_id, menu_name, customer_email,price, qty, created_at, updated_at, status,
+	delivery_date::TEXT AS delivery_date, COALESCE(TO_CHAR(delivery_start, 'HH24:MI'), '') AS delivery_start,
+	COALESCE(TO_CHAR(delivery_end, 'HH24:MI'), '') AS delivery_end FROM "order"`
 	stmt = fmt.Sprintf("%s WHERE ", stmt)
-	values = make([]string, 0, 8) // possible value (email, order_id, menu_names, today order, interval day, price, range price, status)
-	args = make([]interface{}, 0, 8)
+	values = make([]string, 0, 11) // possible value (email, order_id, menu_names, today order, interval day, price, range price, status, delivery day, business)
+	args = make([]interface{}, 0, 11)
 
 	if len(order.CustomerEmails) != 0 {
 		var names string
@@ -223,22 +953,36 @@ func dynamicSearchOrderQuery(order *model.OrderQuery) (query string, args []inte
 
 	if order.StartDay != "" {
 		nArgs += 1
-		val = fmt.Sprintf(`created_at >= $%s`, order.StartDay)
+		val = fmt.Sprintf(`created_at >= $%d`, nArgs)
 		args = append(args, order.StartDay)
 		if order.EndDay != "" {
 			nArgs += 1
-			val = fmt.Sprintf(`%s AND created_at < $%s`, val, order.EndDay)
+			val = fmt.Sprintf(`%s AND created_at < $%d`, val, nArgs)
 			args = append(args, order.EndDay)
 		}
 
 		values = append(values, val)
 	}
 
-	if order.MinPrice != 0 {
+	if order.DeliveryStartDay != "" {
+		nArgs += 1
+		val = fmt.Sprintf(`delivery_date >= $%d::DATE`, nArgs)
+		args = append(args, order.DeliveryStartDay)
+		values = append(values, val)
+	}
+
+	if order.DeliveryEndDay != "" {
+		nArgs += 1
+		val = fmt.Sprintf(`delivery_date <= $%d::DATE`, nArgs)
+		args = append(args, order.DeliveryEndDay)
+		values = append(values, val)
+	}
+
+	if !order.MinPrice.IsZero() {
 		nArgs += 1
 		val = fmt.Sprintf(`price >= $%d`, nArgs)
 		args = append(args, order.MinPrice)
-		if order.MaxPrice != 0 {
+		if !order.MaxPrice.IsZero() {
 			nArgs += 1
 			val = fmt.Sprintf(`%s AND price < $%d`, val, nArgs)
 			args = append(args, order.MaxPrice)
@@ -247,6 +991,81 @@ func dynamicSearchOrderQuery(order *model.OrderQuery) (query string, args []inte
 		values = append(values, val)
 	}
 
+	nArgs += 1
+	values = append(values, fmt.Sprintf("business_id = $%d", nArgs))
+	args = append(args, businessID)
+
 	stmt = fmt.Sprintf("%s%s", stmt, strings.Join(values, " AND "))
 	return stmt, args
 }
+
+// salesReportGroup is how the rows of a sales report are grouped, the expressions are put into the salesReport query
+type salesReportGroup struct {
+	groupBy string // the grouping expression
+	group   string // the group's name, an aggregate or an expression of groupBy
+	menuID  string
+	orderBy string
+}
+
+var salesReportGroups = map[string]salesReportGroup{
+	"day": {
+		groupBy: `DATE_TRUNC('day', o.created_at)`, group: `TO_CHAR(DATE_TRUNC('day', o.created_at), 'YYYY-MM-DD')`,
+		menuID: `NULL::BIGINT`, orderBy: `DATE_TRUNC('day', o.created_at)`,
+	},
+	"week": { // ISO week, starts on monday
+		groupBy: `DATE_TRUNC('week', o.created_at)`, group: `TO_CHAR(DATE_TRUNC('week', o.created_at), 'YYYY-MM-DD')`,
+		menuID: `NULL::BIGINT`, orderBy: `DATE_TRUNC('week', o.created_at)`,
+	},
+	"month": {
+		groupBy: `DATE_TRUNC('month', o.created_at)`, group: `TO_CHAR(DATE_TRUNC('month', o.created_at), 'YYYY-MM')`,
+		menuID: `NULL::BIGINT`, orderBy: `DATE_TRUNC('month', o.created_at)`,
+	},
+	"menu": { // the menu's name of the latest order since it could have been renamed
+		groupBy: `o.menu_id`, group: `(ARRAY_AGG(o.menu_name ORDER BY o.base_order_id DESC))[1]`,
+		menuID: `o.menu_id`, orderBy: `SUM(o.revenue) DESC, o.menu_id`,
+	},
+	"category": { // the menu's first category, a deleted menu has none
+		groupBy: `COALESCE(NULLIF(TRIM(SPLIT_PART(m.categories, ',', 1)), ''), 'Uncategorized')`,
+		group:   `COALESCE(NULLIF(TRIM(SPLIT_PART(m.categories, ',', 1)), ''), 'Uncategorized')`,
+		menuID:  `NULL::BIGINT`, orderBy: `SUM(o.revenue) DESC, 2`,
+	},
+	"customer": {
+		groupBy: `o.customer_email`, group: `o.customer_email`,
+		menuID: `NULL::BIGINT`, orderBy: `SUM(o.revenue) DESC, o.customer_email`,
+	},
+}
+
+// salesReportQuery return the salesReport query grouped by one of salesReportGroups, ok is false when the grouping is unknown
+func salesReportQuery(groupBy string) (query string, ok bool) {
+	group, ok := salesReportGroups[groupBy]
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf(salesReport, group.groupBy, group.group, group.menuID, group.orderBy), true
+}
+
+// customerReportSorts are the columns the customerReport query could be sorted by
+var customerReportSorts = map[string]string{
+	"lifetime_spend":      "lifetime_spend",
+	"orders":              "orders",
+	"average_order_value": "average_order_value",
+	"first_order":         "first_order_at",
+	"last_order":          "last_order_at",
+	"email":               "customer_email",
+}
+
+// customerReportQuery return the customerReport query sorted by one of customerReportSorts, ok is false when the sort
+// or the direction (asc or desc) is unknown
+func customerReportQuery(sortBy, direction string) (query string, ok bool) {
+	column, ok := customerReportSorts[sortBy]
+	if !ok {
+		return "", false
+	}
+	direction = strings.ToUpper(direction)
+	if direction != "ASC" && direction != "DESC" {
+		return "", false
+	}
+
+	return fmt.Sprintf(customerReport, column, direction), true
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/db/redis"
+	"fmt"
+	"strings"
+	"time"
+
+	redisV8 "github.com/go-redis/redis/v8"
+)
+
+const salesReportKeyFormat string = "report:sales:%d:%s:%s:%s" // business, group by, start day and end day
+
+type ReportRepository interface {
+	Sales(ctx context.Context, businessID int64, query model.SalesReportQuery) (report *model.SalesReport, err error)
+	CachedSales(ctx context.Context, businessID int64, query model.SalesReportQuery) (report *model.SalesReport, errNoRow error, err error)
+	CacheSales(ctx context.Context, businessID int64, query model.SalesReportQuery, report *model.SalesReport) error
+	Customers(ctx context.Context, businessID int64, query model.CustomerReportQuery) (customers []*model.CustomerStats, total int, err error)
+}
+
+type reportRepository struct {
+	postgres postgres.PostgresClient
+	redis    redis.RedisClient
+	cacheTTL time.Duration
+}
+
+// NewReportRepository create a ReportRepository which caches the reports for cacheTTL, zero disables the cache
+func NewReportRepository(postgres postgres.PostgresClient, redis redis.RedisClient, cacheTTL time.Duration) ReportRepository {
+	return &reportRepository{postgres: postgres, redis: redis, cacheTTL: cacheTTL}
+}
+
+// Sales compute the sales report of the business' orders created within the query's range
+func (repo *reportRepository) Sales(ctx context.Context, businessID int64, query model.SalesReportQuery) (report *model.SalesReport, err error) {
+	stmt, ok := salesReportQuery(query.GroupBy)
+	if !ok {
+		err = fmt.Errorf("repository.reportRepository.Sales: unknown group %q", query.GroupBy)
+		return nil, err
+	}
+
+	rows, err := repo.postgres.QueryContext(ctx, stmt, query.StartDay, query.EndDay, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Sales: %w", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	report = &model.SalesReport{Rows: make([]*model.SalesReportRow, 0)}
+	for rows.Next() {
+		var isTotal bool
+		row := new(model.SalesReportRow)
+		err = rows.Scan(
+			&isTotal,
+			&row.Group,
+			&row.MenuID,
+			&row.Revenue,
+			&row.Orders,
+			&row.AverageOrderValue,
+			&row.ItemsSold,
+		)
+		if err != nil {
+			err = fmt.Errorf("repository.reportRepository.Sales: %w", err)
+			return nil, err
+		}
+
+		if isTotal {
+			row.Group = ""
+			report.Total = row
+			continue
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Sales: %w", err)
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// CachedSales return the cached report of the query, errNoRow is returned when there is none (or the cache is disabled)
+func (repo *reportRepository) CachedSales(ctx context.Context, businessID int64, query model.SalesReportQuery) (report *model.SalesReport, errNoRow error, err error) {
+	if repo.cacheTTL <= 0 {
+		return nil, errors.New("repository.reportRepository.CachedSales: cache is disabled"), nil
+	}
+
+	key := fmt.Sprintf(salesReportKeyFormat, businessID, query.GroupBy, query.StartDay, query.EndDay)
+	val, err := repo.redis.Get(ctx, key).Bytes()
+	if errors.Is(err, redisV8.Nil) {
+		errNoRow = fmt.Errorf("repository.reportRepository.CachedSales: %w", err)
+		return nil, errNoRow, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.CachedSales: %w", err)
+		return nil, nil, err
+	}
+
+	report = new(model.SalesReport)
+	err = json.Unmarshal(val, report)
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.CachedSales: %w", err)
+		return nil, nil, err
+	}
+
+	return report, nil, nil
+}
+
+// CacheSales cache the report of the query for the cache TTL, nothing is cached when the cache is disabled
+func (repo *reportRepository) CacheSales(ctx context.Context, businessID int64, query model.SalesReportQuery, report *model.SalesReport) error {
+	if repo.cacheTTL <= 0 {
+		return nil
+	}
+
+	val, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("repository.reportRepository.CacheSales: %w", err)
+	}
+
+	key := fmt.Sprintf(salesReportKeyFormat, businessID, query.GroupBy, query.StartDay, query.EndDay)
+	err = repo.redis.Set(ctx, key, val, repo.cacheTTL).Err()
+	if err != nil {
+		return fmt.Errorf("repository.reportRepository.CacheSales: %w", err)
+	}
+
+	return nil
+}
+
+// favouriteMenusPerCustomer is the number of favourite menus listed per customer
+const favouriteMenusPerCustomer int = 3
+
+// Customers compute the page of the business' customers' statistic sorted by the query's sort, total is the number
+// of customers who have ever paid an order of the business
+func (repo *reportRepository) Customers(ctx context.Context, businessID int64, query model.CustomerReportQuery) (customers []*model.CustomerStats, total int, err error) {
+	stmt, ok := customerReportQuery(query.SortBy, query.Order)
+	if !ok {
+		err = fmt.Errorf("repository.reportRepository.Customers: unknown sort %q %q", query.SortBy, query.Order)
+		return nil, 0, err
+	}
+
+	rows, err := repo.postgres.QueryContext(ctx, stmt, query.ChurnDays, query.Limit, query.Offset, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	customers = make([]*model.CustomerStats, 0)
+	emails := make([]string, 0)
+	customerByEmail := make(map[string]*model.CustomerStats)
+	for rows.Next() {
+		customer := &model.CustomerStats{FavouriteMenus: make([]*model.CustomerFavouriteMenu, 0)}
+		err = rows.Scan(
+			&customer.Email,
+			&customer.LifetimeSpend,
+			&customer.Orders,
+			&customer.AverageOrderValue,
+			&customer.FirstOrderAt,
+			&customer.LastOrderAt,
+			&customer.AverageDaysBetweenOrders,
+			&customer.Churned,
+		)
+		if err != nil {
+			err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+			return nil, 0, err
+		}
+
+		customers = append(customers, customer)
+		emails = append(emails, customer.Email)
+		customerByEmail[customer.Email] = customer
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+		return nil, 0, err
+	}
+
+	err = repo.postgres.QueryRowContext(ctx, countCustomerReport, businessID).Scan(&total)
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+		return nil, 0, err
+	}
+
+	if len(customers) == 0 {
+		return customers, total, nil
+	}
+
+	menuRows, err := repo.postgres.QueryContext(ctx, listCustomerFavouriteMenus, strings.Join(emails, ","), favouriteMenusPerCustomer, businessID)
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+		return nil, 0, err
+	}
+
+	defer menuRows.Close()
+
+	for menuRows.Next() {
+		var email string
+		menu := new(model.CustomerFavouriteMenu)
+		err = menuRows.Scan(&email, &menu.MenuID, &menu.MenuName, &menu.Qty)
+		if err != nil {
+			err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+			return nil, 0, err
+		}
+
+		if customer, ok := customerByEmail[email]; ok {
+			customer.FavouriteMenus = append(customer.FavouriteMenus, menu)
+		}
+	}
+
+	err = menuRows.Err()
+	if err != nil {
+		err = fmt.Errorf("repository.reportRepository.Customers: %w", err)
+		return nil, 0, err
+	}
+
+	return customers, total, nil
+}
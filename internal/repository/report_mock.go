@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\repository\report.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CacheSales mocks base method.
+func (m *MockReportRepository) CacheSales(ctx context.Context, businessID int64, query model.SalesReportQuery, report *model.SalesReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheSales", ctx, businessID, query, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheSales indicates an expected call of CacheSales.
+func (mr *MockReportRepositoryMockRecorder) CacheSales(ctx, businessID, query, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheSales", reflect.TypeOf((*MockReportRepository)(nil).CacheSales), ctx, businessID, query, report)
+}
+
+// CachedSales mocks base method.
+func (m *MockReportRepository) CachedSales(ctx context.Context, businessID int64, query model.SalesReportQuery) (*model.SalesReport, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedSales", ctx, businessID, query)
+	ret0, _ := ret[0].(*model.SalesReport)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CachedSales indicates an expected call of CachedSales.
+func (mr *MockReportRepositoryMockRecorder) CachedSales(ctx, businessID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedSales", reflect.TypeOf((*MockReportRepository)(nil).CachedSales), ctx, businessID, query)
+}
+
+// Customers mocks base method.
+func (m *MockReportRepository) Customers(ctx context.Context, businessID int64, query model.CustomerReportQuery) ([]*model.CustomerStats, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Customers", ctx, businessID, query)
+	ret0, _ := ret[0].([]*model.CustomerStats)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Customers indicates an expected call of Customers.
+func (mr *MockReportRepositoryMockRecorder) Customers(ctx, businessID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Customers", reflect.TypeOf((*MockReportRepository)(nil).Customers), ctx, businessID, query)
+}
+
+// Sales mocks base method.
+func (m *MockReportRepository) Sales(ctx context.Context, businessID int64, query model.SalesReportQuery) (*model.SalesReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx, businessID, query)
+	ret0, _ := ret[0].(*model.SalesReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockReportRepositoryMockRecorder) Sales(ctx, businessID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockReportRepository)(nil).Sales), ctx, businessID, query)
+}
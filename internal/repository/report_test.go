@@ -0,0 +1,458 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"family-catering/pkg/db/redis"
+	"family-catering/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elliotchance/redismock/v8"
+	redisV8 "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewReportRepository(t *testing.T) {
+	type args struct {
+		postgres postgres.PostgresClient
+		redis    redis.RedisClient
+		cacheTTL time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "success NewReportRepository",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewReportRepository(tt.args.postgres, tt.args.redis, tt.args.cacheTTL))
+		})
+	}
+}
+
+func Test_reportRepository_Sales(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		query      model.SalesReportQuery
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := []string{"is_total", "group", "menu_id", "revenue", "orders", "average_order_value", "items_sold"}
+	tests := []struct {
+		name         string
+		repo         *reportRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantReport   *model.SalesReport
+		wantErr      bool
+	}{
+		{
+			name: "success Sales (by day)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: model.SalesReportQuery{GroupBy: "day", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+GROUPING\(DATE_TRUNC\('day', o.created_at\)\) = 1.+FROM.+"order" o.+o.status IN \(2, 5, 6, 7, 8\).+GROUP BY GROUPING SETS`).
+					WithArgs("2022-11-01", "2022-11-30", int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(false, "2022-11-10", int64(0), int64(6_500_000), 2, int64(3_250_000), 5).
+						AddRow(false, "2022-11-11", int64(0), int64(2_500_000), 1, int64(2_500_000), 1).
+						AddRow(true, "", int64(0), int64(9_000_000), 3, int64(3_000_000), 6))
+			},
+			wantReport: &model.SalesReport{
+				Total: &model.SalesReportRow{Revenue: money.MustParse("90000"), Orders: 3, AverageOrderValue: money.MustParse("30000"), ItemsSold: 6},
+				Rows: []*model.SalesReportRow{
+					{Group: "2022-11-10", Revenue: money.MustParse("65000"), Orders: 2, AverageOrderValue: money.MustParse("32500"), ItemsSold: 5},
+					{Group: "2022-11-11", Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+				},
+			},
+		},
+		{
+			// 2 sate (50000) with 10% discount, 5% service charge and 11% tax
+			name: "success Sales (discounted order)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: model.SalesReportQuery{GroupBy: "day", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+ROUND\(SUM\(o.revenue\)\).+COALESCE\(c.grand_total, SUM\(o.price \* o.qty\) OVER w - COALESCE\(d.amount, 0\)\).+LEFT JOIN.+order_charge c.+LEFT JOIN.+order_discount d.+WINDOW w AS \(PARTITION BY o.order_id\)`).
+					WithArgs("2022-11-01", "2022-11-30", int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(false, "2022-11-10", int64(0), int64(5_244_750), 1, int64(5_244_750), 2).
+						AddRow(true, "", int64(0), int64(5_244_750), 1, int64(5_244_750), 2))
+			},
+			wantReport: &model.SalesReport{
+				Total: &model.SalesReportRow{Revenue: money.MustParse("52447.50"), Orders: 1, AverageOrderValue: money.MustParse("52447.50"), ItemsSold: 2},
+				Rows: []*model.SalesReportRow{
+					{Group: "2022-11-10", Revenue: money.MustParse("52447.50"), Orders: 1, AverageOrderValue: money.MustParse("52447.50"), ItemsSold: 2},
+				},
+			},
+		},
+		{
+			// the order of 2022-11-11 was delivered (status 8) without being paid, its payments don't cover its grand total
+			name: "success Sales (unpaid delivered order)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: model.SalesReportQuery{GroupBy: "day", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+o.status IN \(2, 5, 6, 7, 8\).+AND\s+\(SELECT COALESCE\(SUM\(amount\), 0\) FROM payment WHERE order_id = o.order_id\) >= COALESCE\(.+SELECT grand_total FROM order_charge WHERE order_id = o.order_id.+WINDOW w`).
+					WithArgs("2022-11-01", "2022-11-30", int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(false, "2022-11-10", int64(0), int64(2_500_000), 1, int64(2_500_000), 1).
+						AddRow(true, "", int64(0), int64(2_500_000), 1, int64(2_500_000), 1))
+			},
+			wantReport: &model.SalesReport{
+				Total: &model.SalesReportRow{Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+				Rows: []*model.SalesReportRow{
+					{Group: "2022-11-10", Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+				},
+			},
+		},
+		{
+			name: "success Sales (by menu)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: model.SalesReportQuery{GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+GROUPING\(o.menu_id\) = 1.+ORDER BY GROUPING\(o.menu_id\), SUM\(o.revenue\) DESC, o.menu_id`).
+					WithArgs("2022-11-01", "2022-11-30", int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(false, "sate", int64(1), int64(5_000_000), 2, int64(2_500_000), 2).
+						AddRow(true, "sate", int64(0), int64(5_000_000), 2, int64(2_500_000), 2))
+			},
+			wantReport: &model.SalesReport{
+				Total: &model.SalesReportRow{Revenue: money.MustParse("50000"), Orders: 2, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+				Rows: []*model.SalesReportRow{
+					{Group: "sate", MenuID: 1, Revenue: money.MustParse("50000"), Orders: 2, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+				},
+			},
+		},
+		{
+			name:    "fail Sales (unknown group)",
+			repo:    &reportRepository{},
+			args:    args{ctx: context.Background(), businessID: 1, query: model.SalesReportQuery{GroupBy: "year", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			wantErr: true,
+		},
+		{
+			name: "fail Sales (db error)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: model.SalesReportQuery{GroupBy: "customer", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT.+FROM.+"order" o`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotReport, err := tt.repo.Sales(tt.args.ctx, tt.args.businessID, tt.args.query)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantReport, gotReport)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_reportRepository_CachedSales(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		query      model.SalesReportQuery
+	}
+	type mocks struct {
+		redisMock *redismock.ClientMock
+	}
+	query := model.SalesReportQuery{GroupBy: "day", StartDay: "2022-11-01", EndDay: "2022-11-30"}
+	tests := []struct {
+		name         string
+		repo         *reportRepository
+		args         args
+		prepareMocks func(*mocks)
+		wantReport   *model.SalesReport
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success CachedSales",
+			repo: &reportRepository{cacheTTL: time.Minute},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.redisMock.On("Get", mock.Anything, "report:sales:1:day:2022-11-01:2022-11-30").Return(redisV8.NewStringResult(
+					`{"total":{"group":"","menu_id":0,"revenue":"25000.00","orders":1,"average_order_value":"25000.00","items_sold":1},`+
+						`"rows":[{"group":"2022-11-10","menu_id":0,"revenue":"25000.00","orders":1,"average_order_value":"25000.00","items_sold":1}]}`, nil))
+			},
+			wantReport: &model.SalesReport{
+				Total: &model.SalesReportRow{Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+				Rows: []*model.SalesReportRow{
+					{Group: "2022-11-10", Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+				},
+			},
+		},
+		{
+			name: "fail CachedSales (not cached)",
+			repo: &reportRepository{cacheTTL: time.Minute},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.redisMock.On("Get", mock.Anything, mock.Anything).Return(redisV8.NewStringResult("", redisV8.Nil))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name:         "fail CachedSales (cache is disabled)",
+			repo:         &reportRepository{},
+			args:         args{ctx: context.Background(), businessID: 1, query: query},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail CachedSales (redis error)",
+			repo: &reportRepository{cacheTTL: time.Minute},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.redisMock.On("Get", mock.Anything, mock.Anything).Return(redisV8.NewStringResult("", errors.New("oops! error from redis")))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail CachedSales (invalid cached value)",
+			repo: &reportRepository{cacheTTL: time.Minute},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.redisMock.On("Get", mock.Anything, mock.Anything).Return(redisV8.NewStringResult("{", nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisMock, err := redis.NewMockWithMiniRedisClient(t)
+			if err != nil {
+				panic(err)
+			}
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{redisMock: redisMock})
+			}
+
+			tt.repo.redis = redisMock
+
+			gotReport, errNoRow, err := tt.repo.CachedSales(tt.args.ctx, tt.args.businessID, tt.args.query)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantReport, gotReport)
+		})
+	}
+}
+
+func Test_reportRepository_CacheSales(t *testing.T) {
+	query := model.SalesReportQuery{GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30"}
+	report := &model.SalesReport{
+		Total: &model.SalesReportRow{Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+		Rows: []*model.SalesReportRow{
+			{Group: "sate", MenuID: 1, Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 1},
+		},
+	}
+	tests := []struct {
+		name       string
+		repo       *reportRepository
+		wantCached bool
+	}{
+		{
+			name:       "success CacheSales",
+			repo:       &reportRepository{cacheTTL: time.Minute},
+			wantCached: true,
+		},
+		{
+			name: "success CacheSales (cache is disabled)",
+			repo: &reportRepository{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisMock, err := redis.NewMockWithMiniRedisClient(t)
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.redis = redisMock
+
+			err = tt.repo.CacheSales(context.Background(), 1, query, report)
+			assert.NoError(t, err)
+
+			// read it back through the same repository with the cache enabled
+			reader := &reportRepository{redis: redisMock, cacheTTL: time.Minute}
+			gotReport, errNoRow, err := reader.CachedSales(context.Background(), 1, query)
+			assert.NoError(t, err)
+			assert.Equal(t, !tt.wantCached, errNoRow != nil, errNoRow)
+			if tt.wantCached {
+				assert.Equal(t, report, gotReport)
+			}
+
+			// another business never reads the report of the business
+			_, errNoRow, err = reader.CachedSales(context.Background(), 2, query)
+			assert.NoError(t, err)
+			assert.Error(t, errNoRow)
+		})
+	}
+}
+
+func Test_reportRepository_Customers(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		businessID int64
+		query      model.CustomerReportQuery
+	}
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	cols := []string{"customer_email", "lifetime_spend", "orders", "average_order_value", "first_order_at", "last_order_at", "average_days_between_orders", "churned"}
+	menuCols := []string{"customer_email", "menu_id", "menu_name", "qty"}
+	query := model.CustomerReportQuery{SortBy: "lifetime_spend", Order: "desc", ChurnDays: 60, Limit: 10, Offset: 0}
+	tests := []struct {
+		name          string
+		repo          *reportRepository
+		args          args
+		prepareMocks  func(*mocks)
+		wantCustomers []*model.CustomerStats
+		wantTotal     int
+		wantErr       bool
+	}{
+		{
+			name: "success Customers",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH paid AS.+o.status IN \(2, 5, 6, 7, 8\).+ORDER BY lifetime_spend DESC, customer_email.+LIMIT \$2 OFFSET \$3`).
+					WithArgs(60, 10, 0, int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow("budi@gmail.com", int64(9_000_000), 3, int64(3_000_000), "2022-09-01", "2022-11-10", 35.5, false).
+						AddRow("ani@gmail.com", int64(2_500_000), 1, int64(2_500_000), "2022-08-01", "2022-08-01", 0.0, true))
+				m.pgMock.ExpectQuery(`SELECT COUNT\(DISTINCT customer_email\) FROM "order"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+				m.pgMock.ExpectQuery(`SELECT.+customer_email = ANY\(string_to_array\(\$1, ','\)\).+rank <= \$2`).
+					WithArgs("budi@gmail.com,ani@gmail.com", 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows(menuCols).
+						AddRow("ani@gmail.com", int64(2), "nasi kuning", 1).
+						AddRow("budi@gmail.com", int64(1), "sate", 4).
+						AddRow("budi@gmail.com", int64(2), "nasi kuning", 2))
+			},
+			wantCustomers: []*model.CustomerStats{
+				{
+					Email: "budi@gmail.com", LifetimeSpend: money.MustParse("90000"), Orders: 3, AverageOrderValue: money.MustParse("30000"),
+					FirstOrderAt: "2022-09-01", LastOrderAt: "2022-11-10", AverageDaysBetweenOrders: 35.5,
+					FavouriteMenus: []*model.CustomerFavouriteMenu{{MenuID: 1, MenuName: "sate", Qty: 4}, {MenuID: 2, MenuName: "nasi kuning", Qty: 2}},
+				},
+				{
+					Email: "ani@gmail.com", LifetimeSpend: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"),
+					FirstOrderAt: "2022-08-01", LastOrderAt: "2022-08-01", Churned: true,
+					FavouriteMenus: []*model.CustomerFavouriteMenu{{MenuID: 2, MenuName: "nasi kuning", Qty: 1}},
+				},
+			},
+			wantTotal: 12,
+		},
+		{
+			// ani's only order was delivered without being paid, ani isn't a customer yet
+			name: "success Customers (unpaid delivered order)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH paid AS.+o.status IN \(2, 5, 6, 7, 8\) AND o.business_id = \$4 AND\s+\(SELECT COALESCE\(SUM\(amount\), 0\) FROM payment WHERE order_id = o.order_id\) >= `).
+					WithArgs(60, 10, 0, int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow("budi@gmail.com", int64(2_500_000), 1, int64(2_500_000), "2022-11-10", "2022-11-10", 0.0, false))
+				m.pgMock.ExpectQuery(`SELECT COUNT\(DISTINCT customer_email\) FROM "order" o WHERE .+ AND\s+\(SELECT COALESCE\(SUM\(amount\), 0\) FROM payment WHERE order_id = o.order_id\) >= `).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				m.pgMock.ExpectQuery(`SELECT.+customer_email = ANY\(string_to_array\(\$1, ','\)\).+AND\s+\(SELECT COALESCE\(SUM\(amount\), 0\) FROM payment WHERE order_id = o.order_id\) >= .+rank <= \$2`).
+					WithArgs("budi@gmail.com", 3, int64(1)).
+					WillReturnRows(sqlmock.NewRows(menuCols).
+						AddRow("budi@gmail.com", int64(1), "sate", 1))
+			},
+			wantCustomers: []*model.CustomerStats{
+				{
+					Email: "budi@gmail.com", LifetimeSpend: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"),
+					FirstOrderAt: "2022-11-10", LastOrderAt: "2022-11-10",
+					FavouriteMenus: []*model.CustomerFavouriteMenu{{MenuID: 1, MenuName: "sate", Qty: 1}},
+				},
+			},
+			wantTotal: 1,
+		},
+		{
+			name: "success Customers (page out of range)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: model.CustomerReportQuery{SortBy: "email", Order: "asc", ChurnDays: 30, Limit: 10, Offset: 20}},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH paid AS.+ORDER BY customer_email ASC, customer_email`).
+					WithArgs(30, 10, 20, int64(1)).
+					WillReturnRows(sqlmock.NewRows(cols))
+				m.pgMock.ExpectQuery(`SELECT COUNT\(DISTINCT customer_email\) FROM "order"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+			},
+			wantCustomers: []*model.CustomerStats{},
+			wantTotal:     12,
+		},
+		{
+			name:    "fail Customers (unknown sort)",
+			repo:    &reportRepository{},
+			args:    args{ctx: context.Background(), businessID: 1, query: model.CustomerReportQuery{SortBy: "name", Order: "asc", ChurnDays: 60, Limit: 10}},
+			wantErr: true,
+		},
+		{
+			name: "fail Customers (db error)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH paid AS`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Customers (favourite menus db error)",
+			repo: &reportRepository{},
+			args: args{ctx: context.Background(), businessID: 1, query: query},
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`WITH paid AS`).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow("ani@gmail.com", int64(2_500_000), 1, int64(2_500_000), "2022-08-01", "2022-08-01", 0.0, true))
+				m.pgMock.ExpectQuery(`SELECT COUNT\(DISTINCT customer_email\) FROM "order"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				m.pgMock.ExpectQuery(`SELECT.+rank <= \$2`).WillReturnError(errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+
+			tt.repo.postgres = db
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{pgMock: pgMock})
+			}
+
+			gotCustomers, gotTotal, err := tt.repo.Customers(tt.args.ctx, tt.args.businessID, tt.args.query)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantCustomers, gotCustomers)
+			assert.Equal(t, tt.wantTotal, gotTotal)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
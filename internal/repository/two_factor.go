@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/db/postgres"
+	"fmt"
+)
+
+type TwoFactorRepository interface {
+	// Get return the owner's TOTP, errNoRow when the owner never enrolled
+	Get(ctx context.Context, ownerID int64) (totp *model.OwnerTOTP, errNoRow error, err error)
+	// SetSecret enroll the owner with a new disabled secret, errNoRow when the owner's two-factor authentication is
+	// enabled already
+	SetSecret(ctx context.Context, ownerID int64, secret string) (errNoRow error, err error)
+	// Enable enable the owner's enrolled secret, step is the step of the confirmed code and the recovery codes replace
+	// the previous ones. errNoRow when it's not enrolled or enabled already
+	Enable(ctx context.Context, ownerID int64, step int64, recoveryCodeHashes []string) (errNoRow error, err error)
+	// UseStep keep the step of the owner's used code, errNoRow when a code of the step or a later one was used already
+	UseStep(ctx context.Context, ownerID int64, step int64) (errNoRow error, err error)
+	// ListRecoveryCodes return the owner's unused recovery codes
+	ListRecoveryCodes(ctx context.Context, ownerID int64) ([]model.OwnerRecoveryCode, error)
+	// UseRecoveryCode mark the recovery code used, errNoRow when it's used already
+	UseRecoveryCode(ctx context.Context, id int64) (errNoRow error, err error)
+	// Delete disable the owner's two-factor authentication, its secret and recovery codes are deleted
+	Delete(ctx context.Context, ownerID int64) (nAffected int64, err error)
+}
+
+type twoFactorRepository struct {
+	postgres postgres.PostgresClient
+}
+
+func NewTwoFactorRepository(postgres postgres.PostgresClient) TwoFactorRepository {
+	return &twoFactorRepository{postgres: postgres}
+}
+
+func (repo *twoFactorRepository) Get(ctx context.Context, ownerID int64) (*model.OwnerTOTP, error, error) {
+	totp := model.OwnerTOTP{}
+	err := repo.postgres.QueryRowContext(ctx, getOwnerTOTP, ownerID).Scan(&totp.OwnerID, &totp.Secret, &totp.Enabled, &totp.LastStep)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("repository.twoFactorRepository.Get: %w", err), nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository.twoFactorRepository.Get: %w", err)
+	}
+
+	return &totp, nil, nil
+}
+
+func (repo *twoFactorRepository) SetSecret(ctx context.Context, ownerID int64, secret string) (error, error) {
+	res, err := repo.postgres.ExecContext(ctx, setOwnerTOTPSecret, ownerID, secret)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.SetSecret: %w", err)
+	}
+
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.SetSecret: %w", err)
+	}
+	if nAffected == 0 {
+		return fmt.Errorf("repository.twoFactorRepository.SetSecret: %w", sql.ErrNoRows), nil
+	}
+
+	return nil, nil
+}
+
+func (repo *twoFactorRepository) Enable(ctx context.Context, ownerID int64, step int64, recoveryCodeHashes []string) (error, error) {
+	tx, err := repo.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.Enable: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, enableOwnerTOTP, ownerID, step)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.Enable: %w", err)
+	}
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.Enable: %w", err)
+	}
+	if nAffected == 0 {
+		return fmt.Errorf("repository.twoFactorRepository.Enable: %w", sql.ErrNoRows), nil
+	}
+
+	_, err = tx.ExecContext(ctx, deleteOwnerRecoveryCode, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.Enable: %w", err)
+	}
+	for _, codeHash := range recoveryCodeHashes {
+		_, err = tx.ExecContext(ctx, insertOwnerRecoveryCode, ownerID, codeHash)
+		if err != nil {
+			return nil, fmt.Errorf("repository.twoFactorRepository.Enable: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.Enable: %w", err)
+	}
+
+	return nil, nil
+}
+
+func (repo *twoFactorRepository) UseStep(ctx context.Context, ownerID int64, step int64) (error, error) {
+	res, err := repo.postgres.ExecContext(ctx, useOwnerTOTPStep, ownerID, step)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.UseStep: %w", err)
+	}
+
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.UseStep: %w", err)
+	}
+	if nAffected == 0 {
+		return fmt.Errorf("repository.twoFactorRepository.UseStep: %w", sql.ErrNoRows), nil
+	}
+
+	return nil, nil
+}
+
+func (repo *twoFactorRepository) ListRecoveryCodes(ctx context.Context, ownerID int64) ([]model.OwnerRecoveryCode, error) {
+	rows, err := repo.postgres.QueryContext(ctx, listUnusedRecoveryCodes, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.ListRecoveryCodes: %w", err)
+	}
+	defer rows.Close()
+
+	codes := []model.OwnerRecoveryCode{}
+	for rows.Next() {
+		code := model.OwnerRecoveryCode{}
+		err = rows.Scan(&code.ID, &code.OwnerID, &code.CodeHash)
+		if err != nil {
+			return nil, fmt.Errorf("repository.twoFactorRepository.ListRecoveryCodes: %w", err)
+		}
+		codes = append(codes, code)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.ListRecoveryCodes: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (repo *twoFactorRepository) UseRecoveryCode(ctx context.Context, id int64) (error, error) {
+	res, err := repo.postgres.ExecContext(ctx, useRecoveryCode, id)
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.UseRecoveryCode: %w", err)
+	}
+
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("repository.twoFactorRepository.UseRecoveryCode: %w", err)
+	}
+	if nAffected == 0 {
+		return fmt.Errorf("repository.twoFactorRepository.UseRecoveryCode: %w", sql.ErrNoRows), nil
+	}
+
+	return nil, nil
+}
+
+func (repo *twoFactorRepository) Delete(ctx context.Context, ownerID int64) (int64, error) {
+	res, err := repo.postgres.ExecContext(ctx, deleteOwnerTOTP, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("repository.twoFactorRepository.Delete: %w", err)
+	}
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository.twoFactorRepository.Delete: %w", err)
+	}
+
+	return nAffected, nil
+}
@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTwoFactorRepository(t *testing.T) {
+	assert.NotNil(t, NewTwoFactorRepository(nil))
+}
+
+func Test_twoFactorRepository_Get(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		ownerID      int64
+		prepareMocks func(*mocks)
+		want         *model.OwnerTOTP
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name:    "success get totp",
+			ownerID: 1,
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT owner_id, secret, enabled, last_step FROM owner_totp WHERE owner_id = \$1`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"owner_id", "secret", "enabled", "last_step"}).AddRow(1, "SECRET", true, 42))
+			},
+			want: &model.OwnerTOTP{OwnerID: 1, Secret: "SECRET", Enabled: true, LastStep: 42},
+		},
+		{
+			name:    "fail get totp (not enrolled)",
+			ownerID: 1,
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT owner_id, secret, enabled, last_step FROM owner_totp`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"owner_id", "secret", "enabled", "last_step"}))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name:    "fail get totp (error postgres)",
+			ownerID: 1,
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT owner_id, secret, enabled, last_step FROM owner_totp`).WithArgs(int64(1)).
+					WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			got, errNoRow, err := repo.Get(context.Background(), tt.ownerID)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_twoFactorRepository_SetSecret(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success set secret",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`INSERT INTO owner_totp \(owner_id, secret\) VALUES \(\$1, \$2\)`).WithArgs(int64(1), "SECRET").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail set secret (enabled already)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`INSERT INTO owner_totp`).WithArgs(int64(1), "SECRET").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail set secret (error postgres)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`INSERT INTO owner_totp`).WithArgs(int64(1), "SECRET").WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			errNoRow, err := repo.SetSecret(context.Background(), 1, "SECRET")
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_twoFactorRepository_Enable(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success enable",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE owner_totp SET enabled = TRUE, last_step = \$2 WHERE owner_id = \$1 AND enabled = FALSE`).
+					WithArgs(int64(1), int64(42)).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`DELETE FROM owner_recovery_code WHERE owner_id = \$1`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.pgMock.ExpectExec(`INSERT INTO owner_recovery_code`).WithArgs(int64(1), "hash-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.pgMock.ExpectExec(`INSERT INTO owner_recovery_code`).WithArgs(int64(1), "hash-2").WillReturnResult(sqlmock.NewResult(2, 1))
+				m.pgMock.ExpectCommit()
+			},
+		},
+		{
+			name: "fail enable (not enrolled or enabled already)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE owner_totp SET enabled = TRUE`).WithArgs(int64(1), int64(42)).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.pgMock.ExpectRollback()
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail enable (error postgres)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectBegin()
+				m.pgMock.ExpectExec(`UPDATE owner_totp SET enabled = TRUE`).WithArgs(int64(1), int64(42)).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.pgMock.ExpectExec(`DELETE FROM owner_recovery_code`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.pgMock.ExpectExec(`INSERT INTO owner_recovery_code`).WithArgs(int64(1), "hash-1").WillReturnError(errors.New("oops! error from postgres"))
+				m.pgMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			errNoRow, err := repo.Enable(context.Background(), 1, 42, []string{"hash-1", "hash-2"})
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_twoFactorRepository_UseStep(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success use step",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE owner_totp SET last_step = \$2 WHERE owner_id = \$1 AND enabled = TRUE AND last_step < \$2`).
+					WithArgs(int64(1), int64(42)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail use step (replayed)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE owner_totp SET last_step`).WithArgs(int64(1), int64(42)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail use step (error postgres)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE owner_totp SET last_step`).WithArgs(int64(1), int64(42)).WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			errNoRow, err := repo.UseStep(context.Background(), 1, 42)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_twoFactorRepository_ListRecoveryCodes(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		want         []model.OwnerRecoveryCode
+		wantErr      bool
+	}{
+		{
+			name: "success list recovery codes",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT id, owner_id, code_hash FROM owner_recovery_code WHERE owner_id = \$1 AND used_at IS NULL`).
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "code_hash"}).AddRow(1, 1, "hash-1").AddRow(2, 1, "hash-2"))
+			},
+			want: []model.OwnerRecoveryCode{{ID: 1, OwnerID: 1, CodeHash: "hash-1"}, {ID: 2, OwnerID: 1, CodeHash: "hash-2"}},
+		},
+		{
+			name: "success list recovery codes (none left)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT id, owner_id, code_hash FROM owner_recovery_code`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "code_hash"}))
+			},
+			want: []model.OwnerRecoveryCode{},
+		},
+		{
+			name: "fail list recovery codes (error postgres)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectQuery(`SELECT id, owner_id, code_hash FROM owner_recovery_code`).WithArgs(int64(1)).
+					WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			got, err := repo.ListRecoveryCodes(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_twoFactorRepository_UseRecoveryCode(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		wantErrNoRow bool
+		wantErr      bool
+	}{
+		{
+			name: "success use recovery code",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE owner_recovery_code SET used_at = NOW\(\) WHERE id = \$1 AND used_at IS NULL`).
+					WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail use recovery code (used already)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE owner_recovery_code SET used_at`).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrNoRow: true,
+		},
+		{
+			name: "fail use recovery code (error postgres)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`UPDATE owner_recovery_code SET used_at`).WithArgs(int64(2)).WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			errNoRow, err := repo.UseRecoveryCode(context.Background(), 2)
+			assert.Equal(t, tt.wantErrNoRow, errNoRow != nil, errNoRow)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_twoFactorRepository_Delete(t *testing.T) {
+	type mocks struct {
+		pgMock sqlmock.Sqlmock
+	}
+	tests := []struct {
+		name          string
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErr       bool
+	}{
+		{
+			name: "success delete",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM owner_totp WHERE owner_id = \$1`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "success delete (not enrolled)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM owner_totp`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "fail delete (error postgres)",
+			prepareMocks: func(m *mocks) {
+				m.pgMock.ExpectExec(`DELETE FROM owner_totp`).WithArgs(int64(1)).WillReturnError(errors.New("oops! error from postgres"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgMock, err := sqlmock.New()
+			if err != nil {
+				panic(err)
+			}
+			repo := &twoFactorRepository{postgres: db}
+			tt.prepareMocks(&mocks{pgMock: pgMock})
+
+			nAffected, err := repo.Delete(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantNAffected, nAffected)
+			assert.NoError(t, pgMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"family-catering/config"
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
 	"family-catering/pkg/apperrors"
 	"family-catering/pkg/consts"
+	"family-catering/pkg/logger"
 	"family-catering/pkg/utils"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,19 +22,51 @@ import (
 type AuthService interface {
 	Login(ctx context.Context, req model.AuthLoginRequest) (resp *model.AuthLoginResponse, err error)
 	Logout(ctx context.Context, req model.AuthLogoutRequest) error
+	// ForgotPassword send the reset password link to the email and return the token authorizing the reset, the same answer
+	// is returned whether the email is registered or not. apperrors.ErrPasswordResetLimited when the email requested too many
+	// links
 	ForgotPassword(ctx context.Context, req model.AuthForgotPasswordRequest) (token string, err error)
 	Session(ctx context.Context, sid string) (resp *model.AuthSessionResponse, err error)
 	RenewAccessToken(ctx context.Context) (resp *model.AuthRenewAccessTokenResponse, err error)
+	// Authorize return apperrors.ErrForbidden unless the access token's role is one of roles or, when ownerID
+	// isn't 0, the access token belongs to the owner (self service)
+	Authorize(ctx context.Context, ownerID int64, roles ...string) error
+	// JWKS return the public keys verifying the access tokens
+	JWKS(ctx context.Context) utils.JSONWebKeySet
+	// ListSessions return the devices the access token's owner is logged in on
+	ListSessions(ctx context.Context) (sessions []model.AuthOwnerSession, err error)
+	// DeleteSession log out the owner's session of the id (see model.AuthOwnerSession), apperrors.ErrNotFound unless it's
+	// a session of the access token's owner
+	DeleteSession(ctx context.Context, id string) error
+	// DeleteAllSessions log out the access token's owner from every device
+	DeleteAllSessions(ctx context.Context) error
+	// LoginVerify log in the owner of the pre-auth token (see Login) once its TOTP or recovery code is verified
+	LoginVerify(ctx context.Context, req model.AuthLoginVerifyRequest) (resp *model.AuthLoginResponse, err error)
+	// EnrollTwoFactor generate a new TOTP secret of the access token's owner, it's enabled by ConfirmTwoFactor.
+	// apperrors.ErrTwoFactorEnabled when the owner's two-factor authentication is enabled already
+	EnrollTwoFactor(ctx context.Context) (resp *model.AuthTwoFactorEnrollResponse, err error)
+	// ConfirmTwoFactor enable the enrolled secret when the code is one of its codes, return the recovery codes
+	ConfirmTwoFactor(ctx context.Context, req model.AuthTwoFactorConfirmRequest) (resp *model.AuthTwoFactorConfirmResponse, err error)
 }
 
+// dummyPasswordHash is compared to the password of an unknown email at login, a bcrypt hash of the same cost as the
+// owners' passwords
+const dummyPasswordHash string = "JDJhJDEwJEdkQlBBN0pBNmZMRWU3TTFkcGpOWi5mNjFKdkszSXV5a2dVNnNWWXhSa1JsQ0RkUFUvZ2Vh"
+
 type authService struct {
-	ownerRepo repository.OwnerRepository
-	authRepo  repository.AuthRepository
-	mailer    Mailer
+	ownerRepo         repository.OwnerRepository
+	authRepo          repository.AuthRepository
+	loginAttemptRepo  repository.LoginAttemptRepository
+	twoFactorRepo     repository.TwoFactorRepository
+	passwordResetRepo repository.PasswordResetRepository
+	mailer            Mailer
+	mailing           sync.WaitGroup // the emails being sent in the background (see ForgotPassword)
 }
 
-func NewAuthService(ownerRepo repository.OwnerRepository, authRepo repository.AuthRepository, mailer Mailer) AuthService {
-	return &authService{ownerRepo: ownerRepo, authRepo: authRepo, mailer: mailer}
+func NewAuthService(ownerRepo repository.OwnerRepository, authRepo repository.AuthRepository, loginAttemptRepo repository.LoginAttemptRepository,
+	twoFactorRepo repository.TwoFactorRepository, passwordResetRepo repository.PasswordResetRepository, mailer Mailer) AuthService {
+	return &authService{ownerRepo: ownerRepo, authRepo: authRepo, loginAttemptRepo: loginAttemptRepo, twoFactorRepo: twoFactorRepo,
+		passwordResetRepo: passwordResetRepo, mailer: mailer}
 }
 
 func (svc *authService) Login(ctx context.Context, req model.AuthLoginRequest) (resp *model.AuthLoginResponse, err error) {
@@ -43,56 +79,85 @@ func (svc *authService) Login(ctx context.Context, req model.AuthLoginRequest) (
 		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
 	}
 
+	// an email or ip with too many failed logins waits (back-off) or is locked out
+	err = svc.loginRefused(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// get owner to compare given password with stored password
 	owner, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, req.Email)
-	if errNoRow != nil && err == nil && owner != nil {
-		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
-	}
 	if err != nil {
 		return nil, fmt.Errorf("service.authRepository.Login: %w", err)
 	}
 
-	// validate password
-	err = utils.ValidatePassword(req.Password, owner.Password)
-	if err != nil {
+	// validate password, an unknown email answers as a wrong password. Its password is still compared (to a dummy hash)
+	// so the answer takes as long and doesn't tell which emails are registered
+	hashedPassword := dummyPasswordHash
+	if errNoRow == nil {
+		hashedPassword = owner.Password
+	}
+	err = utils.ValidatePassword(req.Password, hashedPassword)
+	if errNoRow != nil || err != nil {
+		if err = svc.loginFailed(ctx, req); err != nil {
+			return nil, err
+		}
+		err = fmt.Errorf("service.authRepository.Login: wrong password")
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "error wrong password")
 	}
 
-	// check if owner has sid associate with its email (means already logged in in another device)
-	sid, err := svc.authRepo.GetSessionIDByEmail(ctx, req.Email)
+	// a registered owner logs in once it clicked the link sent to its email, see ownerService.VerifyEmail
+	if !owner.EmailVerified {
+		err = fmt.Errorf("service.authRepository.Login: email of owner %d is not verified", owner.Id)
+		return nil, apperrors.WrapError(err, apperrors.ErrEmailNotVerified, "")
+	}
+
+	// an owner with two-factor authentication only gets a pre-auth token, it's logged in once its code is verified (see
+	// LoginVerify). Its failed logins are kept until then, the codes are guessed as the passwords are
+	twoFactor, errNoRow, err := svc.twoFactorRepo.Get(ctx, owner.Id)
 	if err != nil {
 		return nil, fmt.Errorf("service.authRepository.Login: %w", err)
 	}
-
-	// owner has active session associated with its email
-	if err == nil && sid != "" {
-		autLoginResponse := model.AuthLoginResponse{
-			AccessToken:  "",
-			RefreshToken: "",
-			SID:          sid,
+	if errNoRow == nil && twoFactor.Enabled {
+		preAuthToken, err := utils.GeneratePreAuthToken(config.Cfg().Web.TwoFactor.PreAuthTokenTTL, owner.Id)
+		if err != nil {
+			return nil, fmt.Errorf("service.authRepository.Login: %w", err)
 		}
-		return &autLoginResponse, nil
+		return &model.AuthLoginResponse{TwoFactorRequired: true, PreAuthToken: preAuthToken}, nil
+	}
+
+	err = svc.loginAttemptRepo.Reset(ctx, req.Email)
+	if err != nil {
+		return nil, fmt.Errorf("service.authRepository.Login: %w", err)
 	}
 
+	return svc.newSession(ctx, owner, req.UserAgent, req.IP)
+}
+
+// newSession log in the owner on the device, every login is a new session
+func (svc *authService) newSession(ctx context.Context, owner *model.Owner, userAgent, ip string) (*model.AuthLoginResponse, error) {
+	// every login is a new session, the owner is logged in on each of its devices
 	autLogin := model.Auth{}
-	autLogin.Email = req.Email
+	autLogin.Email = owner.Email
 	autLogin.OwnerID = owner.Id
+	autLogin.UserAgent = userAgent
+	autLogin.IP = ip
 	// generate session id used to indicate wether the owner authenticated or not
-	sid = uuid.New().String()
+	sid := uuid.New().String()
 	autLogin.SID = sid
 
 	// generate access token (JWT)
-	// owner which has access token assumed is logged (authenticated)
-	accessToken, err := utils.GenerateToken(svc.authRepo.AccessTokenTTL(), "", "") // id only use in forgot password
+	// owner which has access token assumed is logged (authenticated), its role is used to authorize the requests
+	accessToken, err := utils.GenerateAccessToken(svc.authRepo.AccessTokenTTL(), owner.Id, owner.BusinessID, owner.Role)
 	if err != nil {
-		return nil, fmt.Errorf("service.authRepository.Login: %w", err)
+		return nil, fmt.Errorf("service.authService.newSession: %w", err)
 	}
 
 	// generate refresh token (JWT)
 	autLogin.Jti = uuid.New().String()
 	refreshToken, err := utils.GenerateToken(svc.authRepo.RefreshTokenTTL(), autLogin.Jti, "") // 60 days
 	if err != nil {
-		return nil, fmt.Errorf("service.authRepository.Login: %w", err)
+		return nil, fmt.Errorf("service.authService.newSession: %w", err)
 	}
 
 	autLogin.RefreshToken = refreshToken
@@ -101,10 +166,10 @@ func (svc *authService) Login(ctx context.Context, req model.AuthLoginRequest) (
 	// store userid, sid, refresh token to postgres
 	err = svc.authRepo.Login(ctx, autLogin)
 	if err != nil {
-		return nil, fmt.Errorf("service.authRepository.Login: %w", err)
+		return nil, fmt.Errorf("service.authService.newSession: %w", err)
 	}
 
-	resp = &model.AuthLoginResponse{
+	resp := &model.AuthLoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		SID:          sid,
@@ -115,6 +180,75 @@ func (svc *authService) Login(ctx context.Context, req model.AuthLoginRequest) (
 	return resp, nil
 }
 
+// loginRefused return apperrors.ErrLoginLocked while the email or the ip waits (back-off) or is locked out
+func (svc *authService) loginRefused(ctx context.Context, req model.AuthLoginRequest) error {
+	retryAfter, err := svc.loginAttemptRepo.RetryAfter(ctx, req.Email, req.IP)
+	if err != nil {
+		return fmt.Errorf("service.authService.loginRefused: %w", err)
+	}
+	if retryAfter > 0 {
+		err = fmt.Errorf("service.authService.loginRefused: login of %s from %s refused for %s", req.Email, req.IP, retryAfter)
+		message := fmt.Sprintf("too many failed logins, please retry in %s or reset the password", retryAfter.Round(time.Second))
+		return apperrors.WrapError(err, apperrors.ErrLoginLocked, message)
+	}
+
+	return nil
+}
+
+// loginFailed count the failed login and back off or lock out the email or ip, return apperrors.ErrLoginLocked when it's
+// locked out from now
+func (svc *authService) loginFailed(ctx context.Context, req model.AuthLoginRequest) error {
+	lockout := config.Cfg().Web.LoginLockout
+	emailFailures, ipFailures, err := svc.loginAttemptRepo.Fail(ctx, req.Email, req.IP, lockout.Window)
+	if err != nil {
+		return fmt.Errorf("service.authService.loginFailed: %w", err)
+	}
+
+	// a lock without duration never expires, the lockout is off without it
+	if lockout.Duration <= 0 {
+		return nil
+	}
+
+	switch {
+	case lockout.MaxAttempts > 0 && emailFailures >= int64(lockout.MaxAttempts):
+		err = svc.loginAttemptRepo.LockEmail(ctx, req.Email, lockout.Duration)
+		if err != nil {
+			return fmt.Errorf("service.authService.loginFailed: %w", err)
+		}
+		logger.Warn("security event: login of %s locked out for %s after %d failed logins, the last from ip %s", req.Email, lockout.Duration, emailFailures, req.IP)
+	case lockout.MaxAttemptsPerIP > 0 && ipFailures >= int64(lockout.MaxAttemptsPerIP):
+		err = svc.loginAttemptRepo.LockIP(ctx, req.IP, lockout.Duration)
+		if err != nil {
+			return fmt.Errorf("service.authService.loginFailed: %w", err)
+		}
+		logger.Warn("security event: logins from ip %s locked out for %s after %d failed logins, the last of %s", req.IP, lockout.Duration, ipFailures, req.Email)
+	default:
+		if lockout.Backoff <= 0 {
+			return nil
+		}
+		err = svc.loginAttemptRepo.LockEmail(ctx, req.Email, loginBackoff(lockout.Backoff, lockout.Duration, emailFailures))
+		if err != nil {
+			return fmt.Errorf("service.authService.loginFailed: %w", err)
+		}
+		return nil
+	}
+
+	err = fmt.Errorf("service.authService.loginFailed: login of %s from %s locked out", req.Email, req.IP)
+	return apperrors.WrapError(err, apperrors.ErrLoginLocked, "")
+}
+
+// loginBackoff return how long an email waits after its nth failed login, the back-off doubles on every failure up to max
+func loginBackoff(backoff, max time.Duration, failures int64) time.Duration {
+	for i := int64(1); i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		return max
+	}
+
+	return backoff
+}
+
 func (svc *authService) Logout(ctx context.Context, req model.AuthLogoutRequest) error {
 	// check sid
 	sid, ok := utils.ValueContext(ctx, consts.CtxKeySID).(string)
@@ -191,39 +325,72 @@ func (svc *authService) ForgotPassword(ctx context.Context, req model.AuthForgot
 	// validate request
 	err := utils.ValidateRequest(&req)
 	if err == apperrors.ErrRequiredParam {
-		err = fmt.Errorf("service.authRepository.ForgotPassword: %w", err)
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
 		return "", apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
 	}
 	if err != nil {
-		err = fmt.Errorf("service.authRepository.ForgotPassword: %w", err)
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
 		return "", apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
 	}
 
-	owner, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, req.Email)
-	if errNoRow != nil {
-		err = fmt.Errorf("service.authRepository.ForgotPassword: %w", err)
-		return "", apperrors.WrapError(err, apperrors.ErrNotFound, "email not found")
+	// the requests are counted whether the email is registered or not, the limit mustn't tell either
+	cfg := config.Cfg().Web.PasswordReset
+	requests, err := svc.passwordResetRepo.CountRequest(ctx, req.Email, cfg.Window)
+	if err != nil {
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
+		return "", err
+	}
+	if requests > int64(cfg.MaxRequests) {
+		err = fmt.Errorf("service.authService.ForgotPassword: %d password reset requests within %s", requests, cfg.Window)
+		return "", apperrors.WrapError(err, apperrors.ErrPasswordResetLimited, "")
+	}
+
+	id, err := utils.GenerateOneTimeToken()
+	if err != nil {
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
+		return "", err
 	}
+
+	owner, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		err = fmt.Errorf("service.authRepository.ForgotPassword: %w", err)
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
 		return "", err
 	}
-	uid := uuid.New()
-	id := uid.String()
-	token, err := utils.GenerateToken(svc.authRepo.AccessTokenTTL(), id, owner.Email)
+	// an unregistered email is answered a token as well, it never resets anything as no reset id is kept for it
+	if errNoRow != nil {
+		token, err := utils.GenerateToken(cfg.TokenTTL, id, req.Email)
+		if err != nil {
+			err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
+			return "", err
+		}
+		return token, nil
+	}
+
+	token, err := utils.GenerateToken(cfg.TokenTTL, id, owner.Email)
 	if err != nil {
-		err = fmt.Errorf("service.authRepository.ForgotPassword: %w", err)
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
 		return "", err
 	}
 
-	// email must be sent to the request's email
-	requestLink := fmt.Sprintf("https://%s/api/v1/owner/reset-password/%s", config.Cfg().Server.Addr(), id)
-	err = svc.mailer.SendEMailForgotPassword([]string{owner.Email}, "", "Reset Password", owner.Name, requestLink)
+	// only the reset id's hash is kept, a new one voids the email's previous link
+	err = svc.passwordResetRepo.Create(ctx, owner.Email, utils.HashOneTimeToken(id), cfg.TokenTTL)
 	if err != nil {
-		err = fmt.Errorf("service.authRepository.ForgotPassword: %w", err)
+		err = fmt.Errorf("service.authService.ForgotPassword: %w", err)
 		return "", err
 	}
 
+	// email must be sent to the request's email. It's sent in the background, waiting for the mail server would answer
+	// a registered email slower than an unregistered one
+	requestLink := fmt.Sprintf("https://%s/api/v1/owner/reset-password/%s", config.Cfg().Server.Addr(), id)
+	svc.mailing.Add(1)
+	go func() {
+		defer svc.mailing.Done()
+		err := svc.mailer.SendEMailForgotPassword([]string{owner.Email}, "", "Reset Password", owner.Name, requestLink)
+		if err != nil {
+			logger.Error(err, "error sending the reset password email of owner %d", owner.Id)
+		}
+	}()
+
 	return token, nil
 }
 
@@ -240,21 +407,89 @@ func (svc *authService) RenewAccessToken(ctx context.Context) (*model.AuthRenewA
 		return nil, err
 	}
 
-	if !ok || !Session.Valid || (Session.Jti != payload.Id) || !payload.IsForRefreshToken() {
+	if !Session.Valid || !payload.IsForRefreshToken() {
 		err = fmt.Errorf("service.authRepository.RenewAccessToken: invalid session or claims")
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	accessToken, err := utils.GenerateToken(svc.authRepo.AccessTokenTTL(), "", "")
+	// a refresh token is used once, a rotated one being replayed means it leaked so its whole session is revoked
+	if Session.Jti != payload.Id {
+		sid, errNoRow, err := svc.authRepo.GetSessionIDByUsedJti(ctx, payload.Id)
+		if err != nil {
+			err = fmt.Errorf("service.authRepository.RenewAccessToken: %w", err)
+			return nil, err
+		}
+		if errNoRow == nil {
+			err = svc.revokeSession(ctx, sid, payload.Id, "reused refresh token")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = fmt.Errorf("service.authRepository.RenewAccessToken: invalid session or claims")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	// the role is read again so a role changed by an admin is effective from the renewed access token
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, Session.OwnerID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.authRepository.RenewAccessToken: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrAuth, "")
+	}
 	if err != nil {
 		err = fmt.Errorf("service.authRepository.RenewAccessToken: %w", err)
 		return nil, err
 	}
 
+	accessToken, err := utils.GenerateAccessToken(svc.authRepo.AccessTokenTTL(), owner.Id, owner.BusinessID, owner.Role)
+	if err != nil {
+		err = fmt.Errorf("service.authRepository.RenewAccessToken: %w", err)
+		return nil, err
+	}
+
+	// every renewal rotates the refresh token, the used one is invalid from now
+	rotated := model.Auth{SID: Session.SID, OwnerID: owner.Id, Email: owner.Email, Jti: uuid.New().String()}
+	rotated.RefreshToken, err = utils.GenerateToken(svc.authRepo.RefreshTokenTTL(), rotated.Jti, "")
+	if err != nil {
+		err = fmt.Errorf("service.authRepository.RenewAccessToken: %w", err)
+		return nil, err
+	}
+
+	errNoRow, err = svc.authRepo.RotateRefreshToken(ctx, rotated, payload.Id)
+	if err != nil {
+		err = fmt.Errorf("service.authRepository.RenewAccessToken: %w", err)
+		return nil, err
+	}
+	// another renewal rotated the same refresh token first, it's replayed
+	if errNoRow != nil {
+		err = svc.revokeSession(ctx, Session.SID, payload.Id, "concurrently reused refresh token")
+		if err != nil {
+			return nil, err
+		}
+		errNoRow = fmt.Errorf("service.authRepository.RenewAccessToken: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrAuth, "")
+	}
+
 	expiredAt := time.Now().Add(svc.authRepo.AccessTokenTTL()).Format(time.RFC3339)
 
-	return &model.AuthRenewAccessTokenResponse{AccessToken: accessToken, ExpiredAt: expiredAt}, nil
+	return &model.AuthRenewAccessTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: rotated.RefreshToken,
+		SID:          Session.SID,
+		ExpiredAt:    expiredAt,
+	}, nil
+
+}
+
+// revokeSession delete the session of a reused refresh token and log the security event
+func (svc *authService) revokeSession(ctx context.Context, sid, jti, reason string) error {
+	logger.Warn("security event: %s, jti %s, session %s revoked", reason, jti, sid)
+	err := svc.authRepo.DeleteSession(ctx, sid)
+	if err != nil {
+		return fmt.Errorf("service.authService.revokeSession: %w", err)
+	}
 
+	return nil
 }
 
 func (svc *authService) Session(ctx context.Context, sid string) (*model.AuthSessionResponse, error) {
@@ -266,6 +501,12 @@ func (svc *authService) Session(ctx context.Context, sid string) (*model.AuthSes
 		return nil, nil
 	}
 
+	// last seen is best effort, a failure doesn't fail the request
+	err = svc.authRepo.TouchSession(ctx, sid)
+	if err != nil {
+		logger.Error(err, "error touch session")
+	}
+
 	session := model.AuthSessionResponse{
 		SID:     ownerSession.SID,
 		OwnerID: ownerSession.OwnerID,
@@ -275,3 +516,323 @@ func (svc *authService) Session(ctx context.Context, sid string) (*model.AuthSes
 
 	return &session, nil
 }
+
+func (svc *authService) Authorize(ctx context.Context, ownerID int64, roles ...string) error {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.authService.Authorize: invalid auth token type want string got %T", token)
+		return apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.authService.Authorize: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	// refresh and reset password tokens have neither role nor owner
+	if claims.HasRole(roles...) || (ownerID != 0 && claims.OwnerID == ownerID) {
+		return nil
+	}
+
+	err = fmt.Errorf("service.authService.Authorize: role %q of owner %d is not allowed, want one of %v", claims.Role, claims.OwnerID, roles)
+	return apperrors.WrapError(err, apperrors.ErrForbidden, "")
+}
+
+func (svc *authService) JWKS(ctx context.Context) utils.JSONWebKeySet {
+	return utils.TokenJWKS()
+}
+
+// sessionOwnerID return the owner of the access token
+func sessionOwnerID(ctx context.Context, caller string) (int64, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.authService.%s: invalid auth token type want string got %T", caller, token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	payload, err := utils.ValidateToken(token)
+	if err != nil {
+		err = fmt.Errorf("service.authService.%s: %w", caller, err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid Authorization value")
+	}
+	// a refresh token has no owner
+	if payload.OwnerID == 0 {
+		err = fmt.Errorf("service.authService.%s: not an access token", caller)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid Authorization value")
+	}
+
+	return payload.OwnerID, nil
+}
+
+// sessionPublicID return the id a session is listed by, the sid itself is a credential (cookie) never sent back
+func sessionPublicID(sid string) string {
+	digest := sha256.Sum256([]byte(sid))
+	return hex.EncodeToString(digest[:16])
+}
+
+func (svc *authService) ListSessions(ctx context.Context) ([]model.AuthOwnerSession, error) {
+	ownerID, err := sessionOwnerID(ctx, "ListSessions")
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := svc.authRepo.ListSessions(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.ListSessions: %w", err)
+	}
+
+	currentSID, _ := utils.ValueContext(ctx, consts.CtxKeySID).(string)
+	resp := make([]model.AuthOwnerSession, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, model.AuthOwnerSession{
+			ID:         sessionPublicID(session.SID),
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			Current:    currentSID != "" && session.SID == currentSID,
+			LastSeenAt: session.LastSeenAt,
+			ExpiredAt:  session.ExpiredAt,
+			CreatedAt:  session.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+func (svc *authService) DeleteSession(ctx context.Context, id string) error {
+	ownerID, err := sessionOwnerID(ctx, "DeleteSession")
+	if err != nil {
+		return err
+	}
+
+	// only a session of the owner is found, a session of another owner is not found as well
+	sessions, err := svc.authRepo.ListSessions(ctx, ownerID)
+	if err != nil {
+		return fmt.Errorf("service.authService.DeleteSession: %w", err)
+	}
+	for _, session := range sessions {
+		if sessionPublicID(session.SID) != id {
+			continue
+		}
+
+		err = svc.authRepo.DeleteSession(ctx, session.SID)
+		if err != nil {
+			return fmt.Errorf("service.authService.DeleteSession: %w", err)
+		}
+		return nil
+	}
+
+	err = fmt.Errorf("service.authService.DeleteSession: session %q not found", id)
+	return apperrors.WrapError(err, apperrors.ErrNotFound, "session not found")
+}
+
+func (svc *authService) DeleteAllSessions(ctx context.Context) error {
+	ownerID, err := sessionOwnerID(ctx, "DeleteAllSessions")
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.authRepo.DeleteOwnerSessions(ctx, ownerID)
+	if err != nil {
+		return fmt.Errorf("service.authService.DeleteAllSessions: %w", err)
+	}
+
+	return nil
+}
+
+func (svc *authService) LoginVerify(ctx context.Context, req model.AuthLoginVerifyRequest) (*model.AuthLoginResponse, error) {
+	err := utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		err = fmt.Errorf("service.authService.LoginVerify: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.authService.LoginVerify: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	claims, err := utils.ValidatePreAuthToken(req.PreAuthToken)
+	if err != nil {
+		err = fmt.Errorf("service.authService.LoginVerify: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid or expired pre-auth token, please login again")
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, claims.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.LoginVerify: %w", err)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.authService.LoginVerify: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrAuth, "")
+	}
+
+	// the wrong codes are failed logins of the owner's email, they share its back-off and lockout
+	attempt := model.AuthLoginRequest{Email: owner.Email, IP: req.IP}
+	err = svc.loginRefused(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	// the two-factor authentication could have been reset since the password was verified
+	twoFactor, errNoRow, err := svc.twoFactorRepo.Get(ctx, owner.Id)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.LoginVerify: %w", err)
+	}
+	if errNoRow != nil || !twoFactor.Enabled {
+		err = fmt.Errorf("service.authService.LoginVerify: two-factor authentication of owner %d is not enabled", owner.Id)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "two-factor authentication is not enabled, please login again")
+	}
+
+	ok, err := svc.verifySecondFactor(ctx, twoFactor, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err = svc.loginFailed(ctx, attempt); err != nil {
+			return nil, err
+		}
+		err = fmt.Errorf("service.authService.LoginVerify: wrong two-factor code of owner %d", owner.Id)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid two-factor code")
+	}
+
+	err = svc.loginAttemptRepo.Reset(ctx, owner.Email)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.LoginVerify: %w", err)
+	}
+
+	return svc.newSession(ctx, owner, req.UserAgent, req.IP)
+}
+
+// verifySecondFactor return true when the code is a TOTP code of the secret or one of the owner's recovery codes, a code
+// is used once
+func (svc *authService) verifySecondFactor(ctx context.Context, twoFactor *model.OwnerTOTP, code string) (bool, error) {
+	step, ok := utils.ValidateTOTP(twoFactor.Secret, code, utils.Now())
+	if ok {
+		errNoRow, err := svc.twoFactorRepo.UseStep(ctx, twoFactor.OwnerID, step)
+		if err != nil {
+			return false, fmt.Errorf("service.authService.verifySecondFactor: %w", err)
+		}
+		// the code (or a later one) was used already, it's replayed
+		return errNoRow == nil, nil
+	}
+
+	recoveryCodes, err := svc.twoFactorRepo.ListRecoveryCodes(ctx, twoFactor.OwnerID)
+	if err != nil {
+		return false, fmt.Errorf("service.authService.verifySecondFactor: %w", err)
+	}
+	code = utils.NormalizeRecoveryCode(code)
+	for _, recoveryCode := range recoveryCodes {
+		if utils.ValidatePassword(code, recoveryCode.CodeHash) != nil {
+			continue
+		}
+
+		errNoRow, err := svc.twoFactorRepo.UseRecoveryCode(ctx, recoveryCode.ID)
+		if err != nil {
+			return false, fmt.Errorf("service.authService.verifySecondFactor: %w", err)
+		}
+		if errNoRow != nil {
+			return false, nil
+		}
+		logger.Warn("security event: recovery code of owner %d used, %d left", twoFactor.OwnerID, len(recoveryCodes)-1)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (svc *authService) EnrollTwoFactor(ctx context.Context) (*model.AuthTwoFactorEnrollResponse, error) {
+	ownerID, err := sessionOwnerID(ctx, "EnrollTwoFactor")
+	if err != nil {
+		return nil, err
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.EnrollTwoFactor: %w", err)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.authService.EnrollTwoFactor: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.EnrollTwoFactor: %w", err)
+	}
+
+	// enrolling again replaces the secret which isn't confirmed yet
+	errNoRow, err = svc.twoFactorRepo.SetSecret(ctx, ownerID, secret)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.EnrollTwoFactor: %w", err)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.authService.EnrollTwoFactor: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrTwoFactorEnabled, "")
+	}
+
+	return &model.AuthTwoFactorEnrollResponse{
+		Secret: secret,
+		URI:    utils.TOTPURI(config.Cfg().Web.TwoFactor.Issuer, owner.Email, secret),
+	}, nil
+}
+
+func (svc *authService) ConfirmTwoFactor(ctx context.Context, req model.AuthTwoFactorConfirmRequest) (*model.AuthTwoFactorConfirmResponse, error) {
+	ownerID, err := sessionOwnerID(ctx, "ConfirmTwoFactor")
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		err = fmt.Errorf("service.authService.ConfirmTwoFactor: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.authService.ConfirmTwoFactor: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	twoFactor, errNoRow, err := svc.twoFactorRepo.Get(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.ConfirmTwoFactor: %w", err)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.authService.ConfirmTwoFactor: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "two-factor authentication is not enrolled")
+	}
+	if twoFactor.Enabled {
+		err = fmt.Errorf("service.authService.ConfirmTwoFactor: two-factor authentication of owner %d is enabled already", ownerID)
+		return nil, apperrors.WrapError(err, apperrors.ErrTwoFactorEnabled, "")
+	}
+
+	// the code proves the authenticator app has the secret
+	step, ok := utils.ValidateTOTP(twoFactor.Secret, req.Code, utils.Now())
+	if !ok {
+		err = fmt.Errorf("service.authService.ConfirmTwoFactor: wrong two-factor code of owner %d", ownerID)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "invalid two-factor code")
+	}
+
+	// only the hashes of the recovery codes are kept, the owner sees them once
+	recoveryCodes, err := utils.GenerateRecoveryCodes(config.Cfg().Web.TwoFactor.RecoveryCodes)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.ConfirmTwoFactor: %w", err)
+	}
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		hash, err := utils.HashPassword(utils.NormalizeRecoveryCode(recoveryCode))
+		if err != nil {
+			return nil, fmt.Errorf("service.authService.ConfirmTwoFactor: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	errNoRow, err = svc.twoFactorRepo.Enable(ctx, ownerID, step, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("service.authService.ConfirmTwoFactor: %w", err)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.authService.ConfirmTwoFactor: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrTwoFactorEnabled, "")
+	}
+	logger.Info("security event: two-factor authentication of owner %d enabled", ownerID)
+
+	return &model.AuthTwoFactorConfirmResponse{RecoveryCodes: recoveryCodes}, nil
+}
@@ -6,6 +6,7 @@ package service
 
 import (
 	model "family-catering/internal/model"
+	utils "family-catering/pkg/utils"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,83 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// Authorize mocks base method.
+func (m *MockAuthService) Authorize(ctx context.Context, ownerID int64, roles ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, ownerID}
+	for _, a := range roles {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Authorize", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockAuthServiceMockRecorder) Authorize(ctx, ownerID interface{}, roles ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, ownerID}, roles...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthService)(nil).Authorize), varargs...)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockAuthService) ConfirmTwoFactor(ctx context.Context, req model.AuthTwoFactorConfirmRequest) (*model.AuthTwoFactorConfirmResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", ctx, req)
+	ret0, _ := ret[0].(*model.AuthTwoFactorConfirmResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockAuthServiceMockRecorder) ConfirmTwoFactor(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockAuthService)(nil).ConfirmTwoFactor), ctx, req)
+}
+
+// DeleteAllSessions mocks base method.
+func (m *MockAuthService) DeleteAllSessions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllSessions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllSessions indicates an expected call of DeleteAllSessions.
+func (mr *MockAuthServiceMockRecorder) DeleteAllSessions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllSessions", reflect.TypeOf((*MockAuthService)(nil).DeleteAllSessions), ctx)
+}
+
+// DeleteSession mocks base method.
+func (m *MockAuthService) DeleteSession(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAuthServiceMockRecorder) DeleteSession(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAuthService)(nil).DeleteSession), ctx, id)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockAuthService) EnrollTwoFactor(ctx context.Context) (*model.AuthTwoFactorEnrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", ctx)
+	ret0, _ := ret[0].(*model.AuthTwoFactorEnrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockAuthServiceMockRecorder) EnrollTwoFactor(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockAuthService)(nil).EnrollTwoFactor), ctx)
+}
+
 // ForgotPassword mocks base method.
 func (m *MockAuthService) ForgotPassword(ctx context.Context, req model.AuthForgotPasswordRequest) (string, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +128,35 @@ func (mr *MockAuthServiceMockRecorder) ForgotPassword(ctx, req interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthService)(nil).ForgotPassword), ctx, req)
 }
 
+// JWKS mocks base method.
+func (m *MockAuthService) JWKS(ctx context.Context) utils.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS", ctx)
+	ret0, _ := ret[0].(utils.JSONWebKeySet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockAuthServiceMockRecorder) JWKS(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockAuthService)(nil).JWKS), ctx)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthService) ListSessions(ctx context.Context) ([]model.AuthOwnerSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx)
+	ret0, _ := ret[0].([]model.AuthOwnerSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceMockRecorder) ListSessions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthService)(nil).ListSessions), ctx)
+}
+
 // Login mocks base method.
 func (m *MockAuthService) Login(ctx context.Context, req model.AuthLoginRequest) (*model.AuthLoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +172,21 @@ func (mr *MockAuthServiceMockRecorder) Login(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, req)
 }
 
+// LoginVerify mocks base method.
+func (m *MockAuthService) LoginVerify(ctx context.Context, req model.AuthLoginVerifyRequest) (*model.AuthLoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginVerify", ctx, req)
+	ret0, _ := ret[0].(*model.AuthLoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginVerify indicates an expected call of LoginVerify.
+func (mr *MockAuthServiceMockRecorder) LoginVerify(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginVerify", reflect.TypeOf((*MockAuthService)(nil).LoginVerify), ctx, req)
+}
+
 // Logout mocks base method.
 func (m *MockAuthService) Logout(ctx context.Context, req model.AuthLogoutRequest) error {
 	m.ctrl.T.Helper()
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
 	"family-catering/config"
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
 	"family-catering/pkg/consts"
 	"family-catering/pkg/utils"
 	"fmt"
@@ -19,9 +21,12 @@ import (
 
 func TestNewAuthService(t *testing.T) {
 	type args struct {
-		ownerRepo repository.OwnerRepository
-		authRepo  repository.AuthRepository
-		mailer    Mailer
+		ownerRepo         repository.OwnerRepository
+		authRepo          repository.AuthRepository
+		loginAttemptRepo  repository.LoginAttemptRepository
+		twoFactorRepo     repository.TwoFactorRepository
+		passwordResetRepo repository.PasswordResetRepository
+		mailer            Mailer
 	}
 
 	tests := []struct {
@@ -34,23 +39,34 @@ func TestNewAuthService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NewAuthService(tt.args.ownerRepo, tt.args.authRepo, tt.args.mailer)
+			got := NewAuthService(tt.args.ownerRepo, tt.args.authRepo, tt.args.loginAttemptRepo, tt.args.twoFactorRepo, tt.args.passwordResetRepo, tt.args.mailer)
 			assert.NotNil(t, got)
 		})
 	}
 }
 
+// setLoginLockout set the default lockout of the config
+func setLoginLockout(cfg *config.MockConfig) {
+	cfg.Web.LoginLockout.MaxAttempts = 5
+	cfg.Web.LoginLockout.MaxAttemptsPerIP = 20
+	cfg.Web.LoginLockout.Window = 15 * time.Minute
+	cfg.Web.LoginLockout.Backoff = time.Second
+	cfg.Web.LoginLockout.Duration = 15 * time.Minute
+}
+
 func Test_authService_Login(t *testing.T) {
 	type args struct {
 		ctx context.Context
 		req model.AuthLoginRequest
 	}
 	type mocks struct {
-		ownerRepoMock *repository.MockOwnerRepository
-		authRepoMock  *repository.MockAuthRepository
-		mailerMock    *MockMailer
-		utMock        *utils.Mock
-		cfgMock       *config.MockConfig
+		ownerRepoMock        *repository.MockOwnerRepository
+		authRepoMock         *repository.MockAuthRepository
+		loginAttemptRepoMock *repository.MockLoginAttemptRepository
+		twoFactorRepoMock    *repository.MockTwoFactorRepository
+		mailerMock           *MockMailer
+		utMock               *utils.Mock
+		cfgMock              *config.MockConfig
 	}
 	tests := []struct {
 		name         string
@@ -59,6 +75,7 @@ func Test_authService_Login(t *testing.T) {
 		prepareMocks func(*mocks)
 		wantResp     *model.AuthLoginResponse
 		wantErr      bool
+		wantLocked   bool
 	}{
 		{
 			name: "success login",
@@ -70,11 +87,13 @@ func Test_authService_Login(t *testing.T) {
 				m.utMock.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Email: "test@example.com", Password: "12345pass"}, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Email: "test@example.com", Password: "12345pass", EmailVerified: true}, nil, nil)
 				m.utMock.Patch("ValidatePassword", func(string, string) error {
 					return nil
 				})
-				m.authRepoMock.EXPECT().GetSessionIDByEmail(gomock.Any(), gomock.Any()).Return("", nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows, nil)
+				m.loginAttemptRepoMock.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
 				m.utMock.Patch("GenerateToken", func(t time.Duration, jti string, email string) (string, error) {
 					if email != "" && jti == "" {
 						return "password-token", nil
@@ -84,6 +103,7 @@ func Test_authService_Login(t *testing.T) {
 					}
 					return "access-token", nil
 				})
+				m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) { return "access-token", nil })
 				m.authRepoMock.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil)
 				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
 				m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
@@ -93,24 +113,88 @@ func Test_authService_Login(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name: "success login (multiple logged in)",
+			name: "success login (logged in on another device)",
 			svc:  &authService{},
-			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass"}},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", UserAgent: "Mozilla/5.0 (X11; Linux x86_64)", IP: "10.0.0.3"}},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.RefreshTokenTTL = time.Hour
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Email: "test@example.com", Password: "12345pass"}, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Id: 1, Email: "test@example.com", Password: "12345pass", EmailVerified: true}, nil, nil)
 				m.utMock.Patch("ValidatePassword", func(string, string) error {
 					return nil
 				})
-				m.authRepoMock.EXPECT().GetSessionIDByEmail(gomock.Any(), gomock.Any()).Return("sid", nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows, nil)
+				m.loginAttemptRepoMock.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+				m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) { return "refresh-token", nil })
+				m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) { return "access-token", nil })
+				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
+				m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
+				// a new session is created along the existing ones, with the device logging in
+				m.authRepoMock.EXPECT().Login(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, auth model.Auth) error {
+					if auth.OwnerID != 1 || auth.SID == "" || auth.UserAgent != "Mozilla/5.0 (X11; Linux x86_64)" || auth.IP != "10.0.0.3" {
+						return errors.New("oops! unexpected session")
+					}
+					return nil
+				})
 			},
-			wantResp: &model.AuthLoginResponse{SID: "sid"},
+			wantResp: &model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"},
 			wantErr:  false,
 		},
+		{
+			name: "success login (two-factor authentication)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", IP: "10.0.0.3"}},
+			prepareMocks: func(m *mocks) {
+				m.cfgMock.Web.TwoFactor.PreAuthTokenTTL = 5 * time.Minute
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Id: 1, Email: "test@example.com", Password: "12345pass", EmailVerified: true}, nil, nil)
+				m.utMock.Patch("ValidatePassword", func(string, string) error { return nil })
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.OwnerTOTP{OwnerID: 1, Secret: "SECRET", Enabled: true}, nil, nil)
+				// neither session nor reset of the failed logins until the code is verified
+				m.utMock.Patch("GeneratePreAuthToken", func(ttl time.Duration, ownerID int64) (string, error) {
+					if ttl != 5*time.Minute || ownerID != 1 {
+						return "", errors.New("oops! unexpected pre-auth token")
+					}
+					return "pre-auth-token", nil
+				})
+			},
+			wantResp: &model.AuthLoginResponse{TwoFactorRequired: true, PreAuthToken: "pre-auth-token"},
+		},
+		{
+			name: "success login (two-factor authentication enrolled but not confirmed)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass"}},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Id: 1, Email: "test@example.com", Password: "12345pass", EmailVerified: true}, nil, nil)
+				m.utMock.Patch("ValidatePassword", func(string, string) error { return nil })
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.OwnerTOTP{OwnerID: 1, Secret: "SECRET"}, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+				m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) { return "refresh-token", nil })
+				m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) { return "access-token", nil })
+				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
+				m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
+				m.authRepoMock.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantResp: &model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"},
+		},
+		{
+			name: "fail login (email not verified)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", IP: "10.0.0.3"}},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Id: 1, Email: "test@example.com", Password: "12345pass"}, nil, nil)
+				m.utMock.Patch("ValidatePassword", func(string, string) error { return nil })
+				// neither session nor failed login, the owner verifies its email first
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail login (validate request error)",
 			svc:  &authService{},
@@ -140,11 +224,73 @@ func Test_authService_Login(t *testing.T) {
 			prepareMocks: func(m *mocks) {
 				m.cfgMock.Web.RefreshTokenTTL = time.Hour
 				m.cfgMock.Web.AccessTokenTTL = time.Minute
+				setLoginLockout(m.cfgMock)
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "").Return(time.Duration(0), nil)
 				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Email: "test@example.com", Password: "12345pass"}, nil, nil)
 				m.utMock.Patch("ValidatePassword", func(string, string) error {
 					return errors.New("oops! wrong password")
 				})
+				// the third failure waits 4 times the back-off
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "test@example.com", "", 15*time.Minute).Return(int64(3), int64(3), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "test@example.com", 4*time.Second).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail login (locked out)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "12345pass", IP: "10.0.0.3"}},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(10*time.Minute, nil)
+			},
+			wantErr:    true,
+			wantLocked: true,
+		},
+		{
+			name: "fail login (too many failed logins of the email)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "test@example.com", Password: "wrong-password", IP: "10.0.0.3"}},
+			prepareMocks: func(m *mocks) {
+				setLoginLockout(m.cfgMock)
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Email: "test@example.com", Password: "12345pass"}, nil, nil)
+				m.utMock.Patch("ValidatePassword", func(string, string) error { return errors.New("oops! wrong password") })
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "test@example.com", "10.0.0.3", 15*time.Minute).Return(int64(5), int64(5), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "test@example.com", 15*time.Minute).Return(nil)
+			},
+			wantErr:    true,
+			wantLocked: true,
+		},
+		{
+			name: "fail login (too many failed logins from the ip)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "another@example.com", Password: "wrong-password", IP: "10.0.0.3"}},
+			prepareMocks: func(m *mocks) {
+				setLoginLockout(m.cfgMock)
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "another@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&model.Owner{Email: "another@example.com", Password: "12345pass"}, nil, nil)
+				m.utMock.Patch("ValidatePassword", func(string, string) error { return errors.New("oops! wrong password") })
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "another@example.com", "10.0.0.3", 15*time.Minute).Return(int64(1), int64(20), nil)
+				m.loginAttemptRepoMock.EXPECT().LockIP(gomock.Any(), "10.0.0.3", 15*time.Minute).Return(nil)
+			},
+			wantErr:    true,
+			wantLocked: true,
+		},
+		{
+			name: "fail login (unknown email)",
+			svc:  &authService{},
+			args: args{ctx: context.Background(), req: model.AuthLoginRequest{Email: "unknown@example.com", Password: "12345pass", IP: "10.0.0.3"}},
+			prepareMocks: func(m *mocks) {
+				setLoginLockout(m.cfgMock)
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "unknown@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows, nil)
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "unknown@example.com", "10.0.0.3", 15*time.Minute).Return(int64(1), int64(2), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "unknown@example.com", time.Second).Return(nil)
 			},
 			wantErr: true,
 		},
@@ -156,6 +302,8 @@ func Test_authService_Login(t *testing.T) {
 			config.InitMock()
 			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
 			authRepoMock := repository.NewMockAuthRepository(ctrl)
+			loginAttemptRepoMock := repository.NewMockLoginAttemptRepository(ctrl)
+			twoFactorRepoMock := repository.NewMockTwoFactorRepository(ctrl)
 			mailerMock := NewMockMailer(ctrl)
 
 			mocks := mocks{
@@ -164,8 +312,13 @@ func Test_authService_Login(t *testing.T) {
 				mailerMock:    mailerMock,
 				authRepoMock:  authRepoMock,
 				cfgMock:       config.Cfg(),
+
+				loginAttemptRepoMock: loginAttemptRepoMock,
+				twoFactorRepoMock:    twoFactorRepoMock,
 			}
 			tt.svc.authRepo = authRepoMock
+			tt.svc.loginAttemptRepo = loginAttemptRepoMock
+			tt.svc.twoFactorRepo = twoFactorRepoMock
 			tt.svc.ownerRepo = ownerRepoMock
 			tt.svc.mailer = mailerMock
 
@@ -175,6 +328,7 @@ func Test_authService_Login(t *testing.T) {
 
 			gotResp, err := tt.svc.Login(tt.args.ctx, tt.args.req)
 			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantLocked, errors.Is(err, apperrors.ErrLoginLocked), err)
 			assert.Condition(t, func() bool {
 				if (gotResp == nil || tt.wantResp == nil) && !(gotResp == nil && tt.wantResp == nil) {
 					return false
@@ -183,9 +337,10 @@ func Test_authService_Login(t *testing.T) {
 					return true
 				}
 
-				return strings.Compare(gotResp.RefreshToken, tt.wantResp.RefreshToken)+strings.Compare(gotResp.AccessToken, tt.wantResp.AccessToken) == 0
+				return strings.Compare(gotResp.RefreshToken, tt.wantResp.RefreshToken)+strings.Compare(gotResp.AccessToken, tt.wantResp.AccessToken) == 0 &&
+					gotResp.TwoFactorRequired == tt.wantResp.TwoFactorRequired && gotResp.PreAuthToken == tt.wantResp.PreAuthToken
 
-			}, fmt.Sprintf("expected: %s\nactual: %s\n", tt.wantResp, gotResp))
+			}, fmt.Sprintf("expected: %+v\nactual: %+v\n", tt.wantResp, gotResp))
 
 			utMock.UnpatchAll()
 			config.DestroyMock()
@@ -193,6 +348,44 @@ func Test_authService_Login(t *testing.T) {
 	}
 }
 
+func Test_authService_Login_unknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	utMock := utils.InitMock()
+	defer utMock.UnpatchAll()
+	config.InitMock()
+	defer config.DestroyMock()
+	setLoginLockout(config.Cfg())
+	ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+	loginAttemptRepoMock := repository.NewMockLoginAttemptRepository(ctrl)
+	svc := &authService{ownerRepo: ownerRepoMock, loginAttemptRepo: loginAttemptRepoMock}
+
+	hashed, err := utils.HashPassword("12345pass")
+	if err != nil {
+		panic(err)
+	}
+	loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+	loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), gomock.Any(), "10.0.0.3", 15*time.Minute).Return(int64(1), int64(1), nil).Times(2)
+	loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), gomock.Any(), time.Second).Return(nil).Times(2)
+	ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Email: "test@example.com", Password: hashed, EmailVerified: true}, nil, nil)
+	ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "unknown@example.com").Return(nil, sql.ErrNoRows, nil)
+
+	utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+	// the password of an unknown email is compared too, the answer takes as long as a wrong password's
+	var compared []string
+	utMock.Patch("ValidatePassword", func(password, hashedPassword string) error {
+		compared = append(compared, hashedPassword)
+		return errors.New("oops! wrong password")
+	})
+
+	_, errWrongPassword := svc.Login(context.Background(), model.AuthLoginRequest{Email: "test@example.com", Password: "wrong-password", IP: "10.0.0.3"})
+	_, errUnknownEmail := svc.Login(context.Background(), model.AuthLoginRequest{Email: "unknown@example.com", Password: "wrong-password", IP: "10.0.0.3"})
+
+	assert.ErrorIs(t, errWrongPassword, apperrors.ErrAuth)
+	assert.ErrorIs(t, errUnknownEmail, apperrors.ErrAuth)
+	assert.Equal(t, errWrongPassword.Error(), errUnknownEmail.Error())
+	assert.Equal(t, []string{hashed, dummyPasswordHash}, compared)
+}
+
 func Test_authService_Logout(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -224,7 +417,7 @@ func Test_authService_Logout(t *testing.T) {
 					}
 					return ""
 				})
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{OwnerID: int64(1), Valid: true}, nil, nil)
 				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{}, nil, nil)
@@ -248,7 +441,7 @@ func Test_authService_Logout(t *testing.T) {
 			args: args{ctx: context.Background(), req: model.AuthLogoutRequest{SID: "sid", Password: "12345pass"}},
 			prepareMocks: func(m *mocks) {
 				m.utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return errors.New("oops! error validate request") })
 			},
 			wantErr: true,
@@ -267,7 +460,7 @@ func Test_authService_Logout(t *testing.T) {
 					}
 					return ""
 				})
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(nil, nil, errors.New("error get session"))
 			},
@@ -287,7 +480,7 @@ func Test_authService_Logout(t *testing.T) {
 					}
 					return ""
 				})
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{OwnerID: int64(1), Valid: false}, nil, nil)
 			},
@@ -307,7 +500,7 @@ func Test_authService_Logout(t *testing.T) {
 					}
 					return ""
 				})
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{OwnerID: int64(1), Valid: true}, nil, nil)
 				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, nil, errors.New("error get owner"))
@@ -328,7 +521,7 @@ func Test_authService_Logout(t *testing.T) {
 					}
 					return ""
 				})
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{OwnerID: int64(1), Valid: true}, nil, nil)
 				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{}, nil, nil)
@@ -350,7 +543,7 @@ func Test_authService_Logout(t *testing.T) {
 					}
 					return ""
 				})
-				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{OwnerID: int64(1), Valid: true}, nil, nil)
 				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{}, nil, nil)
@@ -394,11 +587,19 @@ func Test_authService_ForgotPassword(t *testing.T) {
 		req model.AuthForgotPasswordRequest
 	}
 	type mocks struct {
-		ownerRepoMock *repository.MockOwnerRepository
-		authRepoMock  *repository.MockAuthRepository
-		mailerMock    *MockMailer
-		utMock        *utils.Mock
-		cfgMock       *config.MockConfig
+		ownerRepoMock         *repository.MockOwnerRepository
+		passwordResetRepoMock *repository.MockPasswordResetRepository
+		mailerMock            *MockMailer
+		utMock                *utils.Mock
+		cfgMock               *config.MockConfig
+	}
+	errRepo := errors.New("oops! error repo")
+	// generateToken want the reset password token of the id "rpid" valid for the config's ttl
+	generateToken := func(t time.Duration, jti string, email string) (string, error) {
+		if t != 30*time.Minute || jti != "rpid" || email != "test@example.com" {
+			return "", fmt.Errorf("unexpected token of %s %s %s", t, jti, email)
+		}
+		return "password-token", nil
 	}
 	tests := []struct {
 		name         string
@@ -406,7 +607,7 @@ func Test_authService_ForgotPassword(t *testing.T) {
 		args         args
 		prepareMocks func(*mocks)
 		want         string
-		wantErr      bool
+		wantErr      error
 	}{
 		{
 			name: "success forgot password",
@@ -418,12 +619,36 @@ func Test_authService_ForgotPassword(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(3), nil)
 				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Email: "test@example.com", Name: "test"}, nil, nil)
-				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
-				m.utMock.Patch("GenerateToken", func(t time.Duration, jti string, email string) (string, error) { return "password-token", nil }) // bot email and jti must not be empty
-				m.mailerMock.EXPECT().SendEMailForgotPassword([]string{"test@example.com"}, gomock.Any(), gomock.Any(), "test", gomock.Any()).Return(nil)
+				m.utMock.Patch("GenerateToken", generateToken)
+				// only the reset id's hash is kept
+				m.passwordResetRepoMock.EXPECT().Create(gomock.Any(), "test@example.com", utils.HashOneTimeToken("rpid"), 30*time.Minute).Return(nil)
+				m.mailerMock.EXPECT().SendEMailForgotPassword([]string{"test@example.com"}, gomock.Any(), gomock.Any(), "test", gomock.Any()).
+					DoAndReturn(func(_ []string, _, _, _, link string) error {
+						if !strings.HasSuffix(link, "/api/v1/owner/reset-password/rpid") {
+							return fmt.Errorf("unexpected link %s", link)
+						}
+						return nil
+					})
+			},
+			want: "password-token",
+		},
+		{
+			name: "success forgot password (email not registered, nothing sent)",
+			svc:  &authService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.AuthForgotPasswordRequest{
+					Email: "test@example.com",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(1), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("oops! error email not found"), nil)
+				m.utMock.Patch("GenerateToken", generateToken)
 			},
 			want: "password-token",
 		},
@@ -437,13 +662,12 @@ func Test_authService_ForgotPassword(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return errors.New("oops! error validate request") })
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrFieldValidation,
 		},
 		{
-			name: "fail forgot password (error email not found)",
+			name: "fail forgot password (too many requests)",
 			svc:  &authService{},
 			args: args{
 				ctx: context.Background(),
@@ -452,11 +676,25 @@ func Test_authService_ForgotPassword(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
-				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("oops! error email not found"), nil)
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(4), nil)
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrPasswordResetLimited,
+		},
+		{
+			name: "fail forgot password (error count request)",
+			svc:  &authService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.AuthForgotPasswordRequest{
+					Email: "test@example.com",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(0), errRepo)
+			},
+			wantErr: errRepo,
 		},
 		{
 			name: "fail forgot password (error get email)",
@@ -468,11 +706,11 @@ func Test_authService_ForgotPassword(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
-				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, nil, errors.New("oops! error server get email"))
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(1), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, nil, errRepo)
 			},
-			wantErr: true,
+			wantErr: errRepo,
 		},
 		{
 			name: "fail forgot password (error generate token)",
@@ -484,18 +722,17 @@ func Test_authService_ForgotPassword(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(1), nil)
 				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Email: "test@example.com", Name: "test"}, nil, nil)
-				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
 				m.utMock.Patch("GenerateToken", func(t time.Duration, jti string, email string) (string, error) {
-					return "", errors.New("oops! error generate token")
+					return "", errRepo
 				})
 			},
-			wantErr: true,
+			wantErr: errRepo,
 		},
 		{
-			name: "fail forgot password (email not sent)",
+			name: "fail forgot password (error keep reset id)",
 			svc:  &authService{},
 			args: args{
 				ctx: context.Background(),
@@ -504,14 +741,33 @@ func Test_authService_ForgotPassword(t *testing.T) {
 				},
 			},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(1), nil)
 				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Email: "test@example.com", Name: "test"}, nil, nil)
-				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
-				m.utMock.Patch("GenerateToken", func(t time.Duration, jti string, email string) (string, error) { return "password-token", nil }) // bot email and jti must not be empty
-				m.mailerMock.EXPECT().SendEMailForgotPassword([]string{"test@example.com"}, gomock.Any(), gomock.Any(), "test", gomock.Any()).Return(errors.New("oops! some smtp error"))
+				m.utMock.Patch("GenerateToken", generateToken)
+				m.passwordResetRepoMock.EXPECT().Create(gomock.Any(), "test@example.com", gomock.Any(), gomock.Any()).Return(errRepo)
 			},
-			wantErr: true,
+			wantErr: errRepo,
+		},
+		{
+			// the email is sent in the background, its error is only logged
+			name: "success forgot password (email not sent)",
+			svc:  &authService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.AuthForgotPasswordRequest{
+					Email: "test@example.com",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.passwordResetRepoMock.EXPECT().CountRequest(gomock.Any(), "test@example.com", time.Hour).Return(int64(1), nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Email: "test@example.com", Name: "test"}, nil, nil)
+				m.utMock.Patch("GenerateToken", generateToken)
+				m.passwordResetRepoMock.EXPECT().Create(gomock.Any(), "test@example.com", gomock.Any(), gomock.Any()).Return(nil)
+				m.mailerMock.EXPECT().SendEMailForgotPassword([]string{"test@example.com"}, gomock.Any(), gomock.Any(), "test", gomock.Any()).Return(errRepo)
+			},
+			want: "password-token",
 		},
 	}
 	for _, tt := range tests {
@@ -519,30 +775,38 @@ func Test_authService_ForgotPassword(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			utMock := utils.InitMock()
 			config.InitMock()
+			config.Cfg().Web.PasswordReset.TokenTTL = 30 * time.Minute
+			config.Cfg().Web.PasswordReset.MaxRequests = 3
+			config.Cfg().Web.PasswordReset.Window = time.Hour
+			utMock.Patch("GenerateOneTimeToken", func() (string, error) { return "rpid", nil })
 			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
 			mailerMock := NewMockMailer(ctrl)
-			authRepoMock := repository.NewMockAuthRepository(ctrl)
+			passwordResetRepoMock := repository.NewMockPasswordResetRepository(ctrl)
 
 			mocks := mocks{
-				utMock:        &utMock,
-				ownerRepoMock: ownerRepoMock,
-				mailerMock:    mailerMock,
-				cfgMock:       config.Cfg(),
-				authRepoMock:  authRepoMock,
+				utMock:                &utMock,
+				ownerRepoMock:         ownerRepoMock,
+				mailerMock:            mailerMock,
+				cfgMock:               config.Cfg(),
+				passwordResetRepoMock: passwordResetRepoMock,
 			}
 
 			tt.svc.ownerRepo = ownerRepoMock
 			tt.svc.mailer = mailerMock
-			tt.svc.authRepo = authRepoMock
+			tt.svc.passwordResetRepo = passwordResetRepoMock
 
 			if tt.prepareMocks != nil {
 				tt.prepareMocks(&mocks)
 			}
 
 			got, err := tt.svc.ForgotPassword(tt.args.ctx, tt.args.req)
+			tt.svc.mailing.Wait()
 
-			assert.Equal(t, err != nil, tt.wantErr)
-			assert.Equal(t, got, tt.want)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
 
 			utMock.UnpatchAll()
 			config.DestroyMock()
@@ -555,9 +819,10 @@ func Test_authService_RenewAccessToken(t *testing.T) {
 		ctx context.Context
 	}
 	type mocks struct {
-		utMock       *utils.Mock
-		authRepoMock *repository.MockAuthRepository
-		cfgMock      *config.MockConfig
+		utMock        *utils.Mock
+		ownerRepoMock *repository.MockOwnerRepository
+		authRepoMock  *repository.MockAuthRepository
+		cfgMock       *config.MockConfig
 	}
 	tests := []struct {
 		name         string
@@ -585,46 +850,115 @@ func Test_authService_RenewAccessToken(t *testing.T) {
 				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
 					return utils.NewJWTClaimTesting("jti"), nil
 				})
-				m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) { return "access-token", nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Role: consts.RoleCashier, BusinessID: 2}, nil, nil)
+				m.utMock.Patch("GenerateAccessToken", func(_ time.Duration, ownerID, businessID int64, role string) (string, error) {
+					if ownerID != 1 || businessID != 2 || role != consts.RoleCashier {
+						return "", errors.New("oops! unexpected claims")
+					}
+					return "access-token", nil
+				})
+				m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) {
+					return "new-refresh-token", nil
+				})
 				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute).Times(2)
-
+				m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
+				m.authRepoMock.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), "jti").
+					DoAndReturn(func(_ context.Context, auth model.Auth, _ string) (error, error) {
+						if auth.SID != "sid" || auth.Jti == "" || auth.Jti == "jti" || auth.RefreshToken != "new-refresh-token" {
+							return nil, errors.New("oops! unexpected rotated refresh token")
+						}
+						return nil, nil
+					})
 			},
 			want: &model.AuthRenewAccessTokenResponse{
-				AccessToken: "access-token",
+				AccessToken:  "access-token",
+				RefreshToken: "new-refresh-token",
 			},
 		},
 		{
-			name: "fail renew access token (error invalid token)",
+			name: "fail renew access token (reused refresh token revokes the session)",
 			svc:  &authService{},
 			args: args{ctx: context.Background()},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
-					if key == "Session" {
+					if key == consts.CtxKeySession {
+						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "new-jti", OwnerID: 1}
+					}
+					if key == consts.CtxKeyAuthorization {
+						return "refresh-token"
+					}
+					return nil
+				})
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+				m.authRepoMock.EXPECT().GetSessionIDByUsedJti(gomock.Any(), "jti").Return("sid", nil, nil)
+				m.authRepoMock.EXPECT().DeleteSession(gomock.Any(), "sid").Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail renew access token (unknown refresh token)",
+			svc:  &authService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+					if key == consts.CtxKeySession {
+						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "new-jti", OwnerID: 1}
+					}
+					if key == consts.CtxKeyAuthorization {
+						return "refresh-token"
+					}
+					return nil
+				})
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+				m.authRepoMock.EXPECT().GetSessionIDByUsedJti(gomock.Any(), "jti").Return("", sql.ErrNoRows, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail renew access token (refresh token rotated concurrently)",
+			svc:  &authService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+					if key == consts.CtxKeySession {
 						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "jti", OwnerID: 1}
 					}
-					if key == "Authorization" {
-						return "invalid-refresh-token"
+					if key == consts.CtxKeyAuthorization {
+						return "refresh-token"
 					}
 					return nil
 				})
 				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return nil, errors.New("oops! error validate token")
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Role: consts.RoleCashier, BusinessID: 2}, nil, nil)
+				m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) {
+					return "access-token", nil
+				})
+				m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) {
+					return "new-refresh-token", nil
 				})
+				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
+				m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
+				m.authRepoMock.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), "jti").Return(sql.ErrNoRows, nil)
+				m.authRepoMock.EXPECT().DeleteSession(gomock.Any(), "sid").Return(nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail renew access token (error generate token)",
+			name: "fail renew access token (error rotate refresh token)",
 			svc:  &authService{},
 			args: args{ctx: context.Background()},
 			prepareMocks: func(m *mocks) {
-				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
-					if key == "Session" {
+					if key == consts.CtxKeySession {
 						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "jti", OwnerID: 1}
 					}
-					if key == "Authorization" {
+					if key == consts.CtxKeyAuthorization {
 						return "refresh-token"
 					}
 					return nil
@@ -632,55 +966,132 @@ func Test_authService_RenewAccessToken(t *testing.T) {
 				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
 					return utils.NewJWTClaimTesting("jti"), nil
 				})
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Role: consts.RoleCashier, BusinessID: 2}, nil, nil)
+				m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) {
+					return "access-token", nil
+				})
 				m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) {
-					return "", errors.New("oops! error generate token")
+					return "new-refresh-token", nil
 				})
-				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute).Times(1)
-
+				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
+				m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
+				m.authRepoMock.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), "jti").Return(nil, errors.New("oops! error from postgres"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail renew access token (error session invalid)",
+			name: "fail renew access token (error invalid token)",
 			svc:  &authService{},
 			args: args{ctx: context.Background()},
 			prepareMocks: func(m *mocks) {
 				m.cfgMock.Web.AccessTokenTTL = time.Minute
 				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
 					if key == "Session" {
-						return &model.AuthSessionResponse{SID: "sid", Valid: false, Jti: "jti", OwnerID: 1}
+						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "jti", OwnerID: 1}
 					}
 					if key == "Authorization" {
-						return "refresh-token"
+						return "invalid-refresh-token"
 					}
 					return nil
 				})
 				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting("jti"), nil
+					return nil, errors.New("oops! error validate token")
 				})
 			},
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			utMock := utils.InitMock()
-			config.InitMock()
-
-			authRepoMock := repository.NewMockAuthRepository(ctrl)
-
-			mocks := mocks{
-				utMock:       &utMock,
-				cfgMock:      config.Cfg(),
-				authRepoMock: authRepoMock,
-			}
-
-			tt.svc.authRepo = authRepoMock
-
-			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks)
-			}
+		{
+			name: "fail renew access token (error generate token)",
+			svc:  &authService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.cfgMock.Web.AccessTokenTTL = time.Minute
+				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+					if key == "Session" {
+						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "jti", OwnerID: 1}
+					}
+					if key == "Authorization" {
+						return "refresh-token"
+					}
+					return nil
+				})
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Role: consts.RoleCashier}, nil, nil)
+				m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) {
+					return "", errors.New("oops! error generate token")
+				})
+				m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute).Times(1)
+
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail renew access token (error owner not found)",
+			svc:  &authService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+					if key == consts.CtxKeySession {
+						return &model.AuthSessionResponse{SID: "sid", Valid: true, Jti: "jti", OwnerID: 1}
+					}
+					if key == consts.CtxKeyAuthorization {
+						return "refresh-token"
+					}
+					return nil
+				})
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail renew access token (error session invalid)",
+			svc:  &authService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.cfgMock.Web.AccessTokenTTL = time.Minute
+				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+					if key == "Session" {
+						return &model.AuthSessionResponse{SID: "sid", Valid: false, Jti: "jti", OwnerID: 1}
+					}
+					if key == "Authorization" {
+						return "refresh-token"
+					}
+					return nil
+				})
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			config.InitMock()
+
+			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+			authRepoMock := repository.NewMockAuthRepository(ctrl)
+
+			mocks := mocks{
+				utMock:        &utMock,
+				cfgMock:       config.Cfg(),
+				ownerRepoMock: ownerRepoMock,
+				authRepoMock:  authRepoMock,
+			}
+
+			tt.svc.ownerRepo = ownerRepoMock
+			tt.svc.authRepo = authRepoMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks)
+			}
 
 			got, err := tt.svc.RenewAccessToken(tt.args.ctx)
 			var expf, gotf string
@@ -701,7 +1112,7 @@ func Test_authService_RenewAccessToken(t *testing.T) {
 					return false
 				}
 
-				return strings.Compare(tt.want.AccessToken, got.AccessToken) == 0
+				return strings.Compare(tt.want.AccessToken, got.AccessToken) == 0 && tt.want.RefreshToken == got.RefreshToken
 			}, fmt.Sprintf("expected: %s\nactual: %s\n", expf, gotf))
 		})
 	}
@@ -732,6 +1143,20 @@ func Test_authService_Session(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{SID: "sid", OwnerID: 1, Valid: true, Jti: "jti"}, nil, nil)
+				m.authRepoMock.EXPECT().TouchSession(gomock.Any(), "sid").Return(nil)
+			},
+			want: &model.AuthSessionResponse{SID: "sid", OwnerID: 1, Valid: true, Jti: "jti"},
+		},
+		{
+			name: "success get session (error touch session)",
+			svc:  &authService{},
+			args: args{
+				ctx: context.Background(),
+				sid: "sid",
+			},
+			prepareMocks: func(m *mocks) {
+				m.authRepoMock.EXPECT().Session(gomock.Any(), "sid").Return(&model.Auth{SID: "sid", OwnerID: 1, Valid: true, Jti: "jti"}, nil, nil)
+				m.authRepoMock.EXPECT().TouchSession(gomock.Any(), "sid").Return(errors.New("oops! error from postgres"))
 			},
 			want: &model.AuthSessionResponse{SID: "sid", OwnerID: 1, Valid: true, Jti: "jti"},
 		},
@@ -779,3 +1204,683 @@ func Test_authService_Session(t *testing.T) {
 		})
 	}
 }
+
+func Test_authService_Authorize(t *testing.T) {
+	type args struct {
+		ownerID int64
+		roles   []string
+	}
+	tests := []struct {
+		name    string
+		claims  *utils.JwtClaims
+		args    args
+		wantErr error
+	}{
+		{
+			name:   "success authorize (allowed role)",
+			claims: &utils.JwtClaims{OwnerID: 2, Role: consts.RoleKitchen},
+			args:   args{roles: []string{consts.RoleAdmin, consts.RoleKitchen}},
+		},
+		{
+			name:   "success authorize (self)",
+			claims: &utils.JwtClaims{OwnerID: 2, Role: consts.RoleViewer},
+			args:   args{ownerID: 2, roles: []string{consts.RoleAdmin}},
+		},
+		{
+			name:    "fail authorize (role not allowed)",
+			claims:  &utils.JwtClaims{OwnerID: 2, Role: consts.RoleDriver},
+			args:    args{roles: []string{consts.RoleAdmin, consts.RoleCashier}},
+			wantErr: apperrors.ErrForbidden,
+		},
+		{
+			name:    "fail authorize (other owner)",
+			claims:  &utils.JwtClaims{OwnerID: 2, Role: consts.RoleCashier},
+			args:    args{ownerID: 3},
+			wantErr: apperrors.ErrForbidden,
+		},
+		{
+			name:    "fail authorize (token without role nor owner)",
+			claims:  &utils.JwtClaims{},
+			args:    args{roles: []string{consts.RoleViewer}},
+			wantErr: apperrors.ErrForbidden,
+		},
+		{
+			name:    "fail authorize (invalid token)",
+			args:    args{roles: []string{consts.RoleAdmin}},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utMock := utils.InitMock()
+			utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+			utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+				if tt.claims == nil {
+					return nil, errors.New("oops! error validate token")
+				}
+				return tt.claims, nil
+			})
+			svc := &authService{}
+
+			err := svc.Authorize(context.Background(), tt.args.ownerID, tt.args.roles...)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			utMock.UnpatchAll()
+		})
+	}
+}
+
+func Test_authService_ListSessions(t *testing.T) {
+	type mocks struct {
+		utMock       *utils.Mock
+		authRepoMock *repository.MockAuthRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *authService
+		prepareMocks func(*mocks)
+		want         []model.AuthOwnerSession
+		wantErr      error
+	}{
+		{
+			name: "success list sessions",
+			svc:  &authService{},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+					if key == consts.CtxKeySID {
+						return "sid-laptop"
+					}
+					return "access-token"
+				})
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{OwnerID: 1}, nil
+				})
+				m.authRepoMock.EXPECT().ListSessions(gomock.Any(), int64(1)).Return([]model.Auth{
+					{SID: "sid-laptop", UserAgent: "Mozilla/5.0 (X11; Linux x86_64)", IP: "10.0.0.3", LastSeenAt: "2023-01-02T10:00:00Z", ExpiredAt: "2023-04-02T10:00:00Z", CreatedAt: "2023-01-02T09:00:00Z"},
+					{SID: "sid-phone", UserAgent: "Mozilla/5.0 (Linux; Android 13)", IP: "10.0.0.2", LastSeenAt: "2023-01-01T10:00:00Z", ExpiredAt: "2023-04-01T10:00:00Z", CreatedAt: "2023-01-01T09:00:00Z"},
+				}, nil)
+			},
+			want: []model.AuthOwnerSession{
+				{ID: sessionPublicID("sid-laptop"), UserAgent: "Mozilla/5.0 (X11; Linux x86_64)", IP: "10.0.0.3", Current: true, LastSeenAt: "2023-01-02T10:00:00Z", ExpiredAt: "2023-04-02T10:00:00Z", CreatedAt: "2023-01-02T09:00:00Z"},
+				{ID: sessionPublicID("sid-phone"), UserAgent: "Mozilla/5.0 (Linux; Android 13)", IP: "10.0.0.2", LastSeenAt: "2023-01-01T10:00:00Z", ExpiredAt: "2023-04-01T10:00:00Z", CreatedAt: "2023-01-01T09:00:00Z"},
+			},
+		},
+		{
+			name: "fail list sessions (refresh token)",
+			svc:  &authService{},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "refresh-token" })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return utils.NewJWTClaimTesting("jti"), nil
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail list sessions (error repository)",
+			svc:  &authService{},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{OwnerID: 1}, nil
+				})
+				m.authRepoMock.EXPECT().ListSessions(gomock.Any(), int64(1)).Return(nil, errors.New("oops! error from postgres"))
+			},
+			wantErr: errors.New("oops! error from postgres"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			authRepoMock := repository.NewMockAuthRepository(ctrl)
+			tt.svc.authRepo = authRepoMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{utMock: &utMock, authRepoMock: authRepoMock})
+			}
+
+			got, err := tt.svc.ListSessions(context.Background())
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil && errors.Is(tt.wantErr, apperrors.ErrAuth) {
+				assert.ErrorIs(t, err, apperrors.ErrAuth)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_authService_DeleteSession(t *testing.T) {
+	type mocks struct {
+		utMock       *utils.Mock
+		authRepoMock *repository.MockAuthRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *authService
+		id           string
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success delete session",
+			svc:  &authService{},
+			id:   sessionPublicID("sid-phone"),
+			prepareMocks: func(m *mocks) {
+				m.authRepoMock.EXPECT().ListSessions(gomock.Any(), int64(1)).Return([]model.Auth{{SID: "sid-laptop"}, {SID: "sid-phone"}}, nil)
+				m.authRepoMock.EXPECT().DeleteSession(gomock.Any(), "sid-phone").Return(nil)
+			},
+		},
+		{
+			name: "fail delete session (session of another owner)",
+			svc:  &authService{},
+			id:   sessionPublicID("sid-of-another-owner"),
+			prepareMocks: func(m *mocks) {
+				m.authRepoMock.EXPECT().ListSessions(gomock.Any(), int64(1)).Return([]model.Auth{{SID: "sid-laptop"}, {SID: "sid-phone"}}, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail delete session (error repository)",
+			svc:  &authService{},
+			id:   sessionPublicID("sid-phone"),
+			prepareMocks: func(m *mocks) {
+				m.authRepoMock.EXPECT().ListSessions(gomock.Any(), int64(1)).Return([]model.Auth{{SID: "sid-phone"}}, nil)
+				m.authRepoMock.EXPECT().DeleteSession(gomock.Any(), "sid-phone").Return(errors.New("oops! error from postgres"))
+			},
+			wantErr: errors.New("oops! error from postgres"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+			utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+				return &utils.JwtClaims{OwnerID: 1}, nil
+			})
+			authRepoMock := repository.NewMockAuthRepository(ctrl)
+			tt.svc.authRepo = authRepoMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{utMock: &utMock, authRepoMock: authRepoMock})
+			}
+
+			err := tt.svc.DeleteSession(context.Background(), tt.id)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if errors.Is(tt.wantErr, apperrors.ErrNotFound) {
+				assert.ErrorIs(t, err, apperrors.ErrNotFound)
+			}
+		})
+	}
+}
+
+func Test_authService_DeleteAllSessions(t *testing.T) {
+	tests := []struct {
+		name         string
+		svc          *authService
+		prepareMocks func(*repository.MockAuthRepository)
+		wantErr      bool
+	}{
+		{
+			name: "success delete all sessions",
+			svc:  &authService{},
+			prepareMocks: func(authRepoMock *repository.MockAuthRepository) {
+				authRepoMock.EXPECT().DeleteOwnerSessions(gomock.Any(), int64(1)).Return(int64(2), nil)
+			},
+		},
+		{
+			name: "fail delete all sessions (error repository)",
+			svc:  &authService{},
+			prepareMocks: func(authRepoMock *repository.MockAuthRepository) {
+				authRepoMock.EXPECT().DeleteOwnerSessions(gomock.Any(), int64(1)).Return(int64(0), errors.New("oops! error from redis"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+			utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+				return &utils.JwtClaims{OwnerID: 1}, nil
+			})
+			authRepoMock := repository.NewMockAuthRepository(ctrl)
+			tt.svc.authRepo = authRepoMock
+			tt.prepareMocks(authRepoMock)
+
+			err := tt.svc.DeleteAllSessions(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func Test_loginBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int64
+		want     time.Duration
+	}{
+		{name: "first failure", failures: 1, want: time.Second},
+		{name: "doubled on every failure", failures: 4, want: 8 * time.Second},
+		{name: "capped by the lockout", failures: 60, want: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, loginBackoff(time.Second, time.Minute, tt.failures))
+		})
+	}
+}
+
+// the TOTP tests run on a fixed clock, the codes are the codes of twoFactorSecret at twoFactorNow
+var (
+	twoFactorNow    = time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)
+	twoFactorStep   = twoFactorNow.Unix() / 30
+	twoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+)
+
+// twoFactorCode return the code of twoFactorSecret at twoFactorNow shifted by d
+func twoFactorCode(t *testing.T, d time.Duration) string {
+	code, err := utils.TOTPCode(twoFactorSecret, twoFactorNow.Add(d))
+	assert.NoError(t, err)
+	return code
+}
+
+func Test_authService_LoginVerify(t *testing.T) {
+	type mocks struct {
+		ownerRepoMock        *repository.MockOwnerRepository
+		authRepoMock         *repository.MockAuthRepository
+		loginAttemptRepoMock *repository.MockLoginAttemptRepository
+		twoFactorRepoMock    *repository.MockTwoFactorRepository
+		utMock               *utils.Mock
+		cfgMock              *config.MockConfig
+	}
+	owner := &model.Owner{Id: 1, Email: "test@example.com", BusinessID: 1, Role: consts.RoleAdmin}
+	enabled := &model.OwnerTOTP{OwnerID: 1, Secret: twoFactorSecret, Enabled: true}
+	// expectSession expect the owner to be logged in
+	expectSession := func(m *mocks) {
+		m.loginAttemptRepoMock.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+		m.utMock.Patch("GenerateToken", func(time.Duration, string, string) (string, error) { return "refresh-token", nil })
+		m.utMock.Patch("GenerateAccessToken", func(time.Duration, int64, int64, string) (string, error) { return "access-token", nil })
+		m.authRepoMock.EXPECT().AccessTokenTTL().Return(time.Minute)
+		m.authRepoMock.EXPECT().RefreshTokenTTL().Return(time.Hour)
+		m.authRepoMock.EXPECT().Login(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, auth model.Auth) error {
+			if auth.OwnerID != 1 || auth.Email != "test@example.com" || auth.IP != "10.0.0.3" {
+				return errors.New("oops! unexpected session")
+			}
+			return nil
+		})
+	}
+	tests := []struct {
+		name         string
+		req          func(t *testing.T) model.AuthLoginVerifyRequest
+		prepareMocks func(*mocks)
+		wantResp     *model.AuthLoginResponse
+		wantErr      error
+	}{
+		{
+			name: "success login verify (totp code)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: twoFactorCode(t, 0), IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().UseStep(gomock.Any(), int64(1), twoFactorStep).Return(nil, nil)
+				expectSession(m)
+			},
+			wantResp: &model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"},
+		},
+		{
+			name: "success login verify (totp code of the previous step)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: twoFactorCode(t, -30*time.Second), IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().UseStep(gomock.Any(), int64(1), twoFactorStep-1).Return(nil, nil)
+				expectSession(m)
+			},
+			wantResp: &model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"},
+		},
+		{
+			name: "success login verify (recovery code)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: "ABCDE-23456", IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().ListRecoveryCodes(gomock.Any(), int64(1)).Return([]model.OwnerRecoveryCode{
+					{ID: 1, OwnerID: 1, CodeHash: "hash-fghjk78923"},
+					{ID: 2, OwnerID: 1, CodeHash: "hash-abcde23456"},
+				}, nil)
+				m.twoFactorRepoMock.EXPECT().UseRecoveryCode(gomock.Any(), int64(2)).Return(nil, nil)
+				expectSession(m)
+			},
+			wantResp: &model.AuthLoginResponse{SID: "sid", AccessToken: "access-token", RefreshToken: "refresh-token"},
+		},
+		{
+			name: "fail login verify (replayed totp code)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: twoFactorCode(t, 0), IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().UseStep(gomock.Any(), int64(1), twoFactorStep).Return(sql.ErrNoRows, nil)
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "test@example.com", "10.0.0.3", 15*time.Minute).Return(int64(1), int64(1), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "test@example.com", time.Second).Return(nil)
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail login verify (expired totp code)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: twoFactorCode(t, -2*time.Minute), IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().ListRecoveryCodes(gomock.Any(), int64(1)).Return([]model.OwnerRecoveryCode{}, nil)
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "test@example.com", "10.0.0.3", 15*time.Minute).Return(int64(2), int64(2), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "test@example.com", 2*time.Second).Return(nil)
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail login verify (used recovery code)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: "abcde-23456", IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().ListRecoveryCodes(gomock.Any(), int64(1)).Return([]model.OwnerRecoveryCode{{ID: 2, OwnerID: 1, CodeHash: "hash-abcde23456"}}, nil)
+				// used concurrently since it was listed
+				m.twoFactorRepoMock.EXPECT().UseRecoveryCode(gomock.Any(), int64(2)).Return(sql.ErrNoRows, nil)
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "test@example.com", "10.0.0.3", 15*time.Minute).Return(int64(1), int64(1), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "test@example.com", time.Second).Return(nil)
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail login verify (too many wrong codes)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: "000000", IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().ListRecoveryCodes(gomock.Any(), int64(1)).Return([]model.OwnerRecoveryCode{}, nil)
+				m.loginAttemptRepoMock.EXPECT().Fail(gomock.Any(), "test@example.com", "10.0.0.3", 15*time.Minute).Return(int64(5), int64(5), nil)
+				m.loginAttemptRepoMock.EXPECT().LockEmail(gomock.Any(), "test@example.com", 15*time.Minute).Return(nil)
+			},
+			wantErr: apperrors.ErrLoginLocked,
+		},
+		{
+			name: "fail login verify (locked out)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: twoFactorCode(t, 0), IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(10*time.Minute, nil)
+			},
+			wantErr: apperrors.ErrLoginLocked,
+		},
+		{
+			name: "fail login verify (two-factor authentication reset meanwhile)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token", Code: twoFactorCode(t, 0), IP: "10.0.0.3"}
+			},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(owner, nil, nil)
+				m.loginAttemptRepoMock.EXPECT().RetryAfter(gomock.Any(), "test@example.com", "10.0.0.3").Return(time.Duration(0), nil)
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail login verify (invalid pre-auth token)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "access-token", Code: twoFactorCode(t, 0)}
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidatePreAuthToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! unexpected token type")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail login verify (missing code)",
+			req: func(t *testing.T) model.AuthLoginVerifyRequest {
+				return model.AuthLoginVerifyRequest{PreAuthToken: "pre-auth-token"}
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			config.InitMock()
+			m := mocks{
+				ownerRepoMock:        repository.NewMockOwnerRepository(ctrl),
+				authRepoMock:         repository.NewMockAuthRepository(ctrl),
+				loginAttemptRepoMock: repository.NewMockLoginAttemptRepository(ctrl),
+				twoFactorRepoMock:    repository.NewMockTwoFactorRepository(ctrl),
+				utMock:               &utMock,
+				cfgMock:              config.Cfg(),
+			}
+			svc := &authService{ownerRepo: m.ownerRepoMock, authRepo: m.authRepoMock, loginAttemptRepo: m.loginAttemptRepoMock, twoFactorRepo: m.twoFactorRepoMock}
+			setLoginLockout(m.cfgMock)
+			utMock.Patch("Now", func() time.Time { return twoFactorNow })
+			utMock.Patch("ValidatePreAuthToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1}, nil })
+			// the recovery codes are "hashed" by prefixing them
+			utMock.Patch("ValidatePassword", func(password, hash string) error {
+				if hash != "hash-"+password {
+					return errors.New("oops! wrong password")
+				}
+				return nil
+			})
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&m)
+			}
+
+			gotResp, err := svc.LoginVerify(context.Background(), tt.req(t))
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantResp != nil && assert.NotNil(t, gotResp) {
+				assert.NotEmpty(t, gotResp.SID)
+				assert.Equal(t, tt.wantResp.AccessToken, gotResp.AccessToken)
+				assert.Equal(t, tt.wantResp.RefreshToken, gotResp.RefreshToken)
+			}
+
+			utMock.UnpatchAll()
+			config.DestroyMock()
+		})
+	}
+}
+
+func Test_authService_EnrollTwoFactor(t *testing.T) {
+	type mocks struct {
+		ownerRepoMock     *repository.MockOwnerRepository
+		twoFactorRepoMock *repository.MockTwoFactorRepository
+		utMock            *utils.Mock
+	}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		want         *model.AuthTwoFactorEnrollResponse
+		wantErr      error
+	}{
+		{
+			name: "success enroll two-factor",
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com"}, nil, nil)
+				m.twoFactorRepoMock.EXPECT().SetSecret(gomock.Any(), int64(1), twoFactorSecret).Return(nil, nil)
+			},
+			want: &model.AuthTwoFactorEnrollResponse{
+				Secret: twoFactorSecret,
+				URI:    utils.TOTPURI("Family Catering", "test@example.com", twoFactorSecret),
+			},
+		},
+		{
+			name: "fail enroll two-factor (enabled already)",
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com"}, nil, nil)
+				m.twoFactorRepoMock.EXPECT().SetSecret(gomock.Any(), int64(1), twoFactorSecret).Return(sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrTwoFactorEnabled,
+		},
+		{
+			name: "fail enroll two-factor (refresh token)",
+			prepareMocks: func(m *mocks) {
+				m.utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return utils.NewJWTClaimTesting("jti"), nil })
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			config.InitMock()
+			config.Cfg().Web.TwoFactor.Issuer = "Family Catering"
+			m := mocks{
+				ownerRepoMock:     repository.NewMockOwnerRepository(ctrl),
+				twoFactorRepoMock: repository.NewMockTwoFactorRepository(ctrl),
+				utMock:            &utMock,
+			}
+			svc := &authService{ownerRepo: m.ownerRepoMock, twoFactorRepo: m.twoFactorRepoMock}
+			utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+			utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1}, nil })
+			utMock.Patch("GenerateTOTPSecret", func() (string, error) { return twoFactorSecret, nil })
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&m)
+			}
+
+			got, err := svc.EnrollTwoFactor(context.Background())
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+
+			utMock.UnpatchAll()
+			config.DestroyMock()
+		})
+	}
+}
+
+func Test_authService_ConfirmTwoFactor(t *testing.T) {
+	type mocks struct {
+		twoFactorRepoMock *repository.MockTwoFactorRepository
+		utMock            *utils.Mock
+	}
+	enrolled := &model.OwnerTOTP{OwnerID: 1, Secret: twoFactorSecret}
+	tests := []struct {
+		name         string
+		code         func(t *testing.T) string
+		prepareMocks func(*mocks)
+		wantCodes    int
+		wantErr      error
+	}{
+		{
+			name: "success confirm two-factor",
+			code: func(t *testing.T) string { return twoFactorCode(t, 0) },
+			prepareMocks: func(m *mocks) {
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enrolled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().Enable(gomock.Any(), int64(1), twoFactorStep, gomock.Len(10)).Return(nil, nil)
+			},
+			wantCodes: 10,
+		},
+		{
+			name: "fail confirm two-factor (wrong code)",
+			code: func(t *testing.T) string { return twoFactorCode(t, 5*time.Minute) },
+			prepareMocks: func(m *mocks) {
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enrolled, nil, nil)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name:    "fail confirm two-factor (not a code)",
+			code:    func(t *testing.T) string { return "12ab56" },
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail confirm two-factor (not enrolled)",
+			code: func(t *testing.T) string { return twoFactorCode(t, 0) },
+			prepareMocks: func(m *mocks) {
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail confirm two-factor (enabled already)",
+			code: func(t *testing.T) string { return twoFactorCode(t, 0) },
+			prepareMocks: func(m *mocks) {
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.OwnerTOTP{OwnerID: 1, Secret: twoFactorSecret, Enabled: true}, nil, nil)
+			},
+			wantErr: apperrors.ErrTwoFactorEnabled,
+		},
+		{
+			name: "fail confirm two-factor (confirmed concurrently)",
+			code: func(t *testing.T) string { return twoFactorCode(t, 0) },
+			prepareMocks: func(m *mocks) {
+				m.twoFactorRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(enrolled, nil, nil)
+				m.twoFactorRepoMock.EXPECT().Enable(gomock.Any(), int64(1), twoFactorStep, gomock.Any()).Return(sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrTwoFactorEnabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			config.InitMock()
+			config.Cfg().Web.TwoFactor.RecoveryCodes = 10
+			m := mocks{
+				twoFactorRepoMock: repository.NewMockTwoFactorRepository(ctrl),
+				utMock:            &utMock,
+			}
+			svc := &authService{twoFactorRepo: m.twoFactorRepoMock}
+			utMock.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+			utMock.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1}, nil })
+			utMock.Patch("Now", func() time.Time { return twoFactorNow })
+			utMock.Patch("HashPassword", func(password string) (string, error) { return "hash-" + password, nil })
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&m)
+			}
+
+			got, err := svc.ConfirmTwoFactor(context.Background(), model.AuthTwoFactorConfirmRequest{Code: tt.code(t)})
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantCodes > 0 && assert.NotNil(t, got) {
+				assert.Len(t, got.RecoveryCodes, tt.wantCodes)
+			}
+
+			utMock.UnpatchAll()
+			config.DestroyMock()
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"fmt"
+	"strings"
+)
+
+// BusinessService is the business (tenant) of the logged in owner, an owner never reads nor edits another business
+type BusinessService interface {
+	Get(ctx context.Context) (*model.GetBusinessResponse, error)
+	Update(ctx context.Context, req model.UpdateBusinessRequest) (*model.UpdateBusinessResponse, error)
+}
+
+type businessService struct {
+	businessRepo repository.BusinessRepository
+}
+
+func NewBusinessService(businessRepo repository.BusinessRepository) BusinessService {
+	return &businessService{businessRepo: businessRepo}
+}
+
+func (svc *businessService) Get(ctx context.Context) (*model.GetBusinessResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.businessService.Get: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.businessService.Get: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	business, errNoRow, err := svc.businessRepo.Get(ctx, claims.BusinessID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.businessService.Get: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.businessService.Get: %w", err)
+		return nil, err
+	}
+
+	return newBusinessResponse(business), nil
+}
+
+func (svc *businessService) Update(ctx context.Context, req model.UpdateBusinessRequest) (*model.UpdateBusinessResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.businessService.Update: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.businessService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	err = utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.businessService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.businessService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	_, errNoRow, err := svc.businessRepo.Update(ctx, model.Business{ID: claims.BusinessID, Name: req.Name})
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.businessService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.businessService.Update: %w", err)
+		return nil, err
+	}
+
+	business, errNoRow, err := svc.businessRepo.Get(ctx, claims.BusinessID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.businessService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.businessService.Update: %w", err)
+		return nil, err
+	}
+
+	return newBusinessResponse(business), nil
+}
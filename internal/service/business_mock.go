@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\business.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBusinessService is a mock of BusinessService interface.
+type MockBusinessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusinessServiceMockRecorder
+}
+
+// MockBusinessServiceMockRecorder is the mock recorder for MockBusinessService.
+type MockBusinessServiceMockRecorder struct {
+	mock *MockBusinessService
+}
+
+// NewMockBusinessService creates a new mock instance.
+func NewMockBusinessService(ctrl *gomock.Controller) *MockBusinessService {
+	mock := &MockBusinessService{ctrl: ctrl}
+	mock.recorder = &MockBusinessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusinessService) EXPECT() *MockBusinessServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBusinessService) Get(ctx context.Context) (*model.GetBusinessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx)
+	ret0, _ := ret[0].(*model.GetBusinessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBusinessServiceMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBusinessService)(nil).Get), ctx)
+}
+
+// Update mocks base method.
+func (m *MockBusinessService) Update(ctx context.Context, req model.UpdateBusinessRequest) (*model.UpdateBusinessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(*model.UpdateBusinessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBusinessServiceMockRecorder) Update(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBusinessService)(nil).Update), ctx, req)
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_businessService_Get(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		businessRepoMock *repository.MockBusinessRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *businessService
+		args         args
+		prepareMocks func(*mocks)
+		want         *model.GetBusinessResponse
+		wantErr      error
+	}{
+		{
+			name: "success Get",
+			svc:  &businessService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 2}, nil })
+				m.businessRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Business{
+					ID: 2, Name: "Dapur Bu Tini", CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00",
+				}, nil, nil)
+			},
+			want: &model.GetBusinessResponse{ID: 2, Name: "Dapur Bu Tini", CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00"},
+		},
+		{
+			name: "fail Get (not found)",
+			svc:  &businessService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 2}, nil })
+				m.businessRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail Get (invalid token)",
+			svc:  &businessService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return nil, errors.New("oops! invalid token") })
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			businessRepoMock := repository.NewMockBusinessRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{businessRepoMock: businessRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.businessRepo = businessRepoMock
+
+			got, err := tt.svc.Get(tt.args.ctx)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_businessService_Update(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.UpdateBusinessRequest
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		businessRepoMock *repository.MockBusinessRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *businessService
+		args         args
+		prepareMocks func(*mocks)
+		want         *model.UpdateBusinessResponse
+		wantErr      error
+	}{
+		{
+			name: "success Update",
+			svc:  &businessService{},
+			args: args{ctx: context.Background(), req: model.UpdateBusinessRequest{Name: " Dapur Bu Tini "}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 2}, nil })
+				gomock.InOrder(
+					m.businessRepoMock.EXPECT().Update(gomock.Any(), model.Business{ID: 2, Name: "Dapur Bu Tini"}).Return(int64(1), nil, nil),
+					m.businessRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Business{
+						ID: 2, Name: "Dapur Bu Tini", CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-11 10:00:00",
+					}, nil, nil),
+				)
+			},
+			want: &model.UpdateBusinessResponse{ID: 2, Name: "Dapur Bu Tini", CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-11 10:00:00"},
+		},
+		{
+			name: "fail Update (blank name)",
+			svc:  &businessService{},
+			args: args{ctx: context.Background(), req: model.UpdateBusinessRequest{Name: "   "}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 2}, nil })
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name: "fail Update (not found)",
+			svc:  &businessService{},
+			args: args{ctx: context.Background(), req: model.UpdateBusinessRequest{Name: "Dapur Bu Tini"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 2}, nil })
+				m.businessRepoMock.EXPECT().Update(gomock.Any(), model.Business{ID: 2, Name: "Dapur Bu Tini"}).Return(int64(0), errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail Update (invalid token)",
+			svc:  &businessService{},
+			args: args{ctx: context.Background(), req: model.UpdateBusinessRequest{Name: "Dapur Bu Tini"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return nil, errors.New("oops! invalid token") })
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			businessRepoMock := repository.NewMockBusinessRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{businessRepoMock: businessRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.businessRepo = businessRepoMock
+
+			got, err := tt.svc.Update(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"fmt"
+	"strings"
+)
+
+type CustomerService interface {
+	GetByID(ctx context.Context, id int64) (*model.GetCustomerResponse, error)
+	List(ctx context.Context, limit, offset int) ([]*model.GetCustomerResponse, error)
+	Create(ctx context.Context, req model.CreateCustomerRequest) (*model.CreateCustomerResponse, error)
+	Update(ctx context.Context, id int64, req model.UpdateCustomerRequest) (*model.UpdateCustomerResponse, error)
+	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+	// CreateAddress, UpdateAddress and DeleteAddress return the customer along with its addresses
+	CreateAddress(ctx context.Context, customerID int64, req model.CustomerAddressRequest) (*model.GetCustomerResponse, error)
+	UpdateAddress(ctx context.Context, customerID, addressID int64, req model.CustomerAddressRequest) (*model.GetCustomerResponse, error)
+	DeleteAddress(ctx context.Context, customerID, addressID int64) (*model.GetCustomerResponse, error)
+}
+
+type customerService struct {
+	customerRepo repository.CustomerRepository
+}
+
+func NewCustomerService(customerRepo repository.CustomerRepository) CustomerService {
+	return &customerService{customerRepo: customerRepo}
+}
+
+func (svc *customerService) GetByID(ctx context.Context, id int64) (*model.GetCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.GetByID: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.GetByID: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	res, err := svc.get(ctx, claims.BusinessID, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.GetByID: %w", err)
+	}
+
+	return res, nil
+}
+
+func (svc *customerService) List(ctx context.Context, limit, offset int) ([]*model.GetCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.List: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.List: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	customers, errNoRow, err := svc.customerRepo.List(ctx, claims.BusinessID, limit, offset)
+	if errNoRow != nil && err == nil {
+		return []*model.GetCustomerResponse{}, nil
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.customerService.List: %w", err)
+		return nil, err
+	}
+
+	ress := make([]*model.GetCustomerResponse, 0, len(customers))
+	for _, customer := range customers {
+		ress = append(ress, newCustomerResponse(customer))
+	}
+
+	return ress, nil
+}
+
+func (svc *customerService) Create(ctx context.Context, req model.CreateCustomerRequest) (*model.CreateCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.Create: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	customer, err := newCustomer(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.Create: %w", err)
+	}
+
+	_, errNoRow, err := svc.customerRepo.GetByEmail(ctx, claims.BusinessID, customer.Email)
+	if errNoRow == nil && err == nil {
+		err = fmt.Errorf("service.customerService.Create: customer's email %s already registered", customer.Email)
+		return nil, apperrors.WrapError(err, apperrors.ErrEmailRegistered, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.Create: %w", err)
+		return nil, err
+	}
+
+	id, err := svc.customerRepo.Create(ctx, claims.BusinessID, customer)
+	if err != nil {
+		err = fmt.Errorf("service.customerService.Create: %w", err)
+		return nil, err
+	}
+
+	res, err := svc.get(ctx, claims.BusinessID, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.Create: %w", err)
+	}
+
+	return res, nil
+}
+
+func (svc *customerService) Update(ctx context.Context, id int64, req model.UpdateCustomerRequest) (*model.UpdateCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.Update: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	customer, err := newCustomer(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.Update: %w", err)
+	}
+	customer.ID = id
+
+	current, errNoRow, err := svc.customerRepo.GetByEmail(ctx, claims.BusinessID, customer.Email)
+	if errNoRow == nil && err == nil && current.ID != id {
+		err = fmt.Errorf("service.customerService.Update: customer's email %s already registered", customer.Email)
+		return nil, apperrors.WrapError(err, apperrors.ErrEmailRegistered, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.Update: %w", err)
+		return nil, err
+	}
+
+	_, errNoRow, err = svc.customerRepo.Update(ctx, claims.BusinessID, customer)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.Update: %w", err)
+		return nil, err
+	}
+
+	res, err := svc.get(ctx, claims.BusinessID, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.Update: %w", err)
+	}
+
+	return res, nil
+}
+
+// Delete delete a customer who has never ordered along with its addresses
+func (svc *customerService) Delete(ctx context.Context, id int64) (nAffected int64, err error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.Delete: invalid auth token type want string got %T", token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.customerService.Delete: %w", err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	_, errNoRow, err := svc.customerRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.Delete: %w", err)
+		return 0, err
+	}
+
+	nAffected, errNoRow, err = svc.customerRepo.Delete(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrCustomerInUse, "customer has ordered, it's kept for its orders")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.Delete: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+func (svc *customerService) CreateAddress(ctx context.Context, customerID int64, req model.CustomerAddressRequest) (*model.GetCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.CreateAddress: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.CreateAddress: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	address, err := newCustomerAddress(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.CreateAddress: %w", err)
+	}
+	address.CustomerID = customerID
+
+	_, errNoRow, err := svc.customerRepo.GetByID(ctx, claims.BusinessID, customerID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.CreateAddress: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.CreateAddress: %w", err)
+		return nil, err
+	}
+
+	_, err = svc.customerRepo.CreateAddress(ctx, claims.BusinessID, address)
+	if err != nil {
+		err = fmt.Errorf("service.customerService.CreateAddress: %w", err)
+		return nil, err
+	}
+
+	res, err := svc.get(ctx, claims.BusinessID, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.CreateAddress: %w", err)
+	}
+
+	return res, nil
+}
+
+func (svc *customerService) UpdateAddress(ctx context.Context, customerID, addressID int64, req model.CustomerAddressRequest) (*model.GetCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.UpdateAddress: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.UpdateAddress: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	address, err := newCustomerAddress(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.UpdateAddress: %w", err)
+	}
+	address.ID = addressID
+	address.CustomerID = customerID
+
+	_, errNoRow, err := svc.customerRepo.UpdateAddress(ctx, claims.BusinessID, address)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.UpdateAddress: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.UpdateAddress: %w", err)
+		return nil, err
+	}
+
+	res, err := svc.get(ctx, claims.BusinessID, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.UpdateAddress: %w", err)
+	}
+
+	return res, nil
+}
+
+func (svc *customerService) DeleteAddress(ctx context.Context, customerID, addressID int64) (*model.GetCustomerResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.customerService.DeleteAddress: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.customerService.DeleteAddress: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	_, errNoRow, err := svc.customerRepo.DeleteAddress(ctx, claims.BusinessID, customerID, addressID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.DeleteAddress: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.customerService.DeleteAddress: %w", err)
+		return nil, err
+	}
+
+	res, err := svc.get(ctx, claims.BusinessID, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.DeleteAddress: %w", err)
+	}
+
+	return res, nil
+}
+
+// get return the customer along with its addresses, the error is already wrapped with apperrors
+func (svc *customerService) get(ctx context.Context, businessID, id int64) (*model.GetCustomerResponse, error) {
+	customer, errNoRow, err := svc.customerRepo.GetByID(ctx, businessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.customerService.get: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("service.customerService.get: %w", err)
+	}
+
+	return newCustomerResponse(customer), nil
+}
+
+// newCustomer validate the request and return the customer to be stored, the email is stored lower case
+func newCustomer(req model.CreateCustomerRequest) (model.Customer, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	err := utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.newCustomer: %w", err)
+		return model.Customer{}, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.newCustomer: %w", err)
+		return model.Customer{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	customer := model.Customer{
+		Name:             strings.TrimSpace(req.Name),
+		Email:            req.Email,
+		Phone:            strings.TrimSpace(req.Phone),
+		DietaryNotes:     strings.TrimSpace(req.DietaryNotes),
+		MarketingConsent: req.MarketingConsent,
+	}
+
+	return customer, nil
+}
+
+func newCustomerAddress(req model.CustomerAddressRequest) (model.CustomerAddress, error) {
+	req.Address = strings.TrimSpace(req.Address)
+	err := utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.newCustomerAddress: %w", err)
+		return model.CustomerAddress{}, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.newCustomerAddress: %w", err)
+		return model.CustomerAddress{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	address := model.CustomerAddress{
+		Label:      strings.TrimSpace(req.Label),
+		Address:    req.Address,
+		PostalCode: strings.TrimSpace(req.PostalCode),
+		Latitude:   nullFloat64(req.Latitude),
+		Longitude:  nullFloat64(req.Longitude),
+		Notes:      strings.TrimSpace(req.Notes),
+		IsDefault:  req.IsDefault,
+	}
+
+	return address, nil
+}
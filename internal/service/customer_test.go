@@ -0,0 +1,537 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCustomerService(t *testing.T) {
+	type args struct {
+		customerRepo repository.CustomerRepository
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewCustomerService"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewCustomerService(tt.args.customerRepo))
+		})
+	}
+}
+
+func Test_customerService_Create(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.CreateCustomerRequest
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		customerRepoMock *repository.MockCustomerRepository
+	}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		svc          *customerService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.CreateCustomerResponse
+		wantErr      error
+	}{
+		{
+			name: "success Create",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), req: model.CreateCustomerRequest{
+				Name: " Budi ", Email: "Budi@Example.com", Phone: "0812", DietaryNotes: "no peanut", MarketingConsent: true,
+			}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				customer := model.Customer{Name: "Budi", Email: "budi@example.com", Phone: "0812", DietaryNotes: "no peanut", MarketingConsent: true}
+				gomock.InOrder(
+					m.customerRepoMock.EXPECT().GetByEmail(gomock.Any(), int64(1), "budi@example.com").Return(nil, errors.New("oops! no row"), nil),
+					m.customerRepoMock.EXPECT().Create(gomock.Any(), int64(1), customer).Return(int64(1), nil),
+					m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{
+						ID: 1, Name: "Budi", Email: "budi@example.com", Phone: "0812", DietaryNotes: "no peanut", MarketingConsent: true,
+						Addresses: []*model.CustomerAddress{},
+					}, nil, nil),
+				)
+			},
+			wantResp: &model.CreateCustomerResponse{
+				ID: 1, Name: "Budi", Email: "budi@example.com", Phone: "0812", DietaryNotes: "no peanut", MarketingConsent: true,
+				Addresses: []*model.CustomerAddressResponse{},
+			},
+		},
+		{
+			name: "fail Create (email registered)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), req: model.CreateCustomerRequest{Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByEmail(gomock.Any(), int64(1), "budi@example.com").Return(&model.Customer{ID: 1}, nil, nil)
+			},
+			wantErr: apperrors.ErrEmailRegistered,
+		},
+		{
+			name: "fail Create (missing email)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), req: model.CreateCustomerRequest{Name: "Budi"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name: "fail Create (invalid email)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), req: model.CreateCustomerRequest{Email: "not-an-email"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Create (db error)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), req: model.CreateCustomerRequest{Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByEmail(gomock.Any(), int64(1), "budi@example.com").Return(nil, errors.New("oops! no row"), nil)
+				m.customerRepoMock.EXPECT().Create(gomock.Any(), int64(1), model.Customer{Email: "budi@example.com"}).Return(int64(0), errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name: "fail Create (invalid/no token)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), req: model.CreateCustomerRequest{Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{customerRepoMock: customerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.customerRepo = customerRepoMock
+
+			gotResp, err := tt.svc.Create(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_customerService_Update(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+		req model.UpdateCustomerRequest
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		customerRepoMock *repository.MockCustomerRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *customerService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.UpdateCustomerResponse
+		wantErr      error
+	}{
+		{
+			name: "success Update (same email)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdateCustomerRequest{Name: "Budi Santoso", Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.customerRepoMock.EXPECT().GetByEmail(gomock.Any(), int64(1), "budi@example.com").Return(&model.Customer{ID: 1}, nil, nil),
+					m.customerRepoMock.EXPECT().Update(gomock.Any(), int64(1), model.Customer{ID: 1, Name: "Budi Santoso", Email: "budi@example.com"}).
+						Return(int64(1), nil, nil),
+					m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{
+						ID: 1, Name: "Budi Santoso", Email: "budi@example.com",
+						Addresses: []*model.CustomerAddress{{ID: 2, CustomerID: 1, Address: "Jl. Kenanga 5", IsDefault: true}},
+					}, nil, nil),
+				)
+			},
+			wantResp: &model.UpdateCustomerResponse{
+				ID: 1, Name: "Budi Santoso", Email: "budi@example.com",
+				Addresses: []*model.CustomerAddressResponse{{ID: 2, Address: "Jl. Kenanga 5", IsDefault: true}},
+			},
+		},
+		{
+			name: "fail Update (email of another customer)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdateCustomerRequest{Email: "siti@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByEmail(gomock.Any(), int64(1), "siti@example.com").Return(&model.Customer{ID: 2}, nil, nil)
+			},
+			wantErr: apperrors.ErrEmailRegistered,
+		},
+		{
+			name: "fail Update (not found)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdateCustomerRequest{Email: "budi@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByEmail(gomock.Any(), int64(1), "budi@example.com").Return(nil, errors.New("oops! no row"), nil)
+				m.customerRepoMock.EXPECT().Update(gomock.Any(), int64(1), model.Customer{ID: 1, Email: "budi@example.com"}).
+					Return(int64(0), errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{customerRepoMock: customerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.customerRepo = customerRepoMock
+
+			gotResp, err := tt.svc.Update(tt.args.ctx, tt.args.id, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_customerService_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		customerRepoMock *repository.MockCustomerRepository
+	}
+	tests := []struct {
+		name          string
+		svc           *customerService
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErr       error
+	}{
+		{
+			name: "success Delete",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{ID: 1}, nil, nil)
+				m.customerRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(int64(1), nil, nil)
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Delete (not found)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail Delete (customer has ordered)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{ID: 1}, nil, nil)
+				m.customerRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(int64(0), errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrCustomerInUse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{customerRepoMock: customerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.customerRepo = customerRepoMock
+
+			gotNAffected, err := tt.svc.Delete(tt.args.ctx, tt.args.id)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_customerService_CreateAddress(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		customerID int64
+		req        model.CustomerAddressRequest
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		customerRepoMock *repository.MockCustomerRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *customerService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.GetCustomerResponse
+		wantErr      error
+	}{
+		{
+			name: "success CreateAddress",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), customerID: 1, req: model.CustomerAddressRequest{Label: "home", Address: " Jl. Kenanga 5 "}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{ID: 1, Addresses: []*model.CustomerAddress{}}, nil, nil),
+					m.customerRepoMock.EXPECT().CreateAddress(gomock.Any(), int64(1), model.CustomerAddress{CustomerID: 1, Label: "home", Address: "Jl. Kenanga 5"}).
+						Return(int64(2), nil),
+					m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{
+						ID: 1, Addresses: []*model.CustomerAddress{{ID: 2, CustomerID: 1, Label: "home", Address: "Jl. Kenanga 5", IsDefault: true}},
+					}, nil, nil),
+				)
+			},
+			wantResp: &model.GetCustomerResponse{
+				ID: 1, Addresses: []*model.CustomerAddressResponse{{ID: 2, Label: "home", Address: "Jl. Kenanga 5", IsDefault: true}},
+			},
+		},
+		{
+			name: "fail CreateAddress (customer not found)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), customerID: 1, req: model.CustomerAddressRequest{Address: "Jl. Kenanga 5"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail CreateAddress (missing address)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), customerID: 1, req: model.CustomerAddressRequest{Label: "home", Address: "  "}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{customerRepoMock: customerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.customerRepo = customerRepoMock
+
+			gotResp, err := tt.svc.CreateAddress(tt.args.ctx, tt.args.customerID, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_customerService_DeleteAddress(t *testing.T) {
+	type args struct {
+		ctx                   context.Context
+		customerID, addressID int64
+	}
+	type mocks struct {
+		utMocks          utils.Mock
+		customerRepoMock *repository.MockCustomerRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *customerService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.GetCustomerResponse
+		wantErr      error
+	}{
+		{
+			name: "success DeleteAddress",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), customerID: 1, addressID: 2},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().DeleteAddress(gomock.Any(), int64(1), int64(1), int64(2)).Return(int64(1), nil, nil)
+				m.customerRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Customer{ID: 1, Addresses: []*model.CustomerAddress{}}, nil, nil)
+			},
+			wantResp: &model.GetCustomerResponse{ID: 1, Addresses: []*model.CustomerAddressResponse{}},
+		},
+		{
+			name: "fail DeleteAddress (address of another customer)",
+			svc:  &customerService{},
+			args: args{ctx: context.Background(), customerID: 1, addressID: 5},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.customerRepoMock.EXPECT().DeleteAddress(gomock.Any(), int64(1), int64(1), int64(5)).Return(int64(0), errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{customerRepoMock: customerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.customerRepo = customerRepoMock
+
+			gotResp, err := tt.svc.DeleteAddress(tt.args.ctx, tt.args.customerID, tt.args.addressID)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
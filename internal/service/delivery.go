@@ -0,0 +1,215 @@
+package service
+
+import (
+	"family-catering/internal/model"
+	"family-catering/pkg/apperrors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	deliveryDateLayout = "2006-01-02"
+	deliveryTimeLayout = "15:04"
+)
+
+// DeliverySlotOption is a delivery time window of a day, Start and End are HH:MM (24-hour clock)
+type DeliverySlotOption struct {
+	Start string
+	End   string
+	// MaxOrders is how many orders could be delivered on the slot of a day, 0 is unlimited
+	MaxOrders int
+}
+
+// DeliveryOption is the delivery schedule configuration, an order could be delivered at any time of the day
+// when there is no slot
+type DeliveryOption struct {
+	Slots []DeliverySlotOption
+	// LeadTime is the minimum time between an order is created and its delivery slot starts
+	LeadTime time.Duration
+	// SameDayCutoff (HH:MM) is the time of the day after which orders can't be delivered on the same day, empty is no cutoff
+	SameDayCutoff string
+	// MaxDaysAhead is how many days ahead an order could be delivered, 0 is unlimited
+	MaxDaysAhead int
+}
+
+// DeliveryScheduler check the requested delivery of new orders against the delivery slots and the lead time rules
+type DeliveryScheduler interface {
+	// Schedule return the delivery of an order created at now for the requested date (YYYY-MM-DD) and slot (HH:MM-HH:MM),
+	// the slot's max orders are checked while the order is created (see repository.OrderRepository.Create).
+	// The error is already wrapped with apperrors.
+	Schedule(date, slot string, now time.Time) (*model.OrderDelivery, error)
+}
+
+type deliverySlot struct {
+	start, end time.Duration // since midnight
+	maxOrders  int
+}
+
+type deliveryScheduler struct {
+	slots         []deliverySlot
+	leadTime      time.Duration
+	sameDayCutoff time.Duration // since midnight, 0 is no cutoff
+	maxDaysAhead  int
+}
+
+func NewDeliveryScheduler(opt DeliveryOption) (DeliveryScheduler, error) {
+	if opt.LeadTime < 0 {
+		return nil, fmt.Errorf("service.NewDeliveryScheduler: negative lead time %s", opt.LeadTime)
+	}
+	if opt.MaxDaysAhead < 0 {
+		return nil, fmt.Errorf("service.NewDeliveryScheduler: negative max days ahead %d", opt.MaxDaysAhead)
+	}
+
+	var sameDayCutoff time.Duration
+	if opt.SameDayCutoff != "" {
+		cutoff, err := parseClock(opt.SameDayCutoff)
+		if err != nil {
+			return nil, fmt.Errorf("service.NewDeliveryScheduler: same day cutoff: %w", err)
+		}
+		sameDayCutoff = cutoff
+	}
+
+	slots := make([]deliverySlot, 0, len(opt.Slots))
+	for _, slotOpt := range opt.Slots {
+		start, err := parseClock(slotOpt.Start)
+		if err != nil {
+			return nil, fmt.Errorf("service.NewDeliveryScheduler: slot start: %w", err)
+		}
+		end, err := parseClock(slotOpt.End)
+		if err != nil {
+			return nil, fmt.Errorf("service.NewDeliveryScheduler: slot end: %w", err)
+		}
+		if end <= start {
+			return nil, fmt.Errorf("service.NewDeliveryScheduler: slot %s-%s ends before it starts", slotOpt.Start, slotOpt.End)
+		}
+		if slotOpt.MaxOrders < 0 {
+			return nil, fmt.Errorf("service.NewDeliveryScheduler: slot %s-%s has negative max orders", slotOpt.Start, slotOpt.End)
+		}
+		for _, slot := range slots {
+			if start < slot.end && slot.start < end {
+				return nil, fmt.Errorf("service.NewDeliveryScheduler: slot %s-%s overlaps another slot", slotOpt.Start, slotOpt.End)
+			}
+		}
+		slots = append(slots, deliverySlot{start: start, end: end, maxOrders: slotOpt.MaxOrders})
+	}
+
+	return &deliveryScheduler{
+		slots:         slots,
+		leadTime:      opt.LeadTime,
+		sameDayCutoff: sameDayCutoff,
+		maxDaysAhead:  opt.MaxDaysAhead,
+	}, nil
+}
+
+// Schedule check the requested delivery in now's location:
+//   - the date is neither in the past nor more than the max days ahead
+//   - orders for the same day are refused after the same day cutoff
+//   - the slot is one of the configured slots (required when there are slots) and starts at least the lead time after now
+func (scheduler *deliveryScheduler) Schedule(date, slot string, now time.Time) (*model.OrderDelivery, error) {
+	day, err := time.ParseInLocation(deliveryDateLayout, date, now.Location())
+	if err != nil {
+		err = fmt.Errorf("service.deliveryScheduler.Schedule: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "delivery_date must be formatted as YYYY-MM-DD")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if day.Before(today) {
+		err = fmt.Errorf("service.deliveryScheduler.Schedule: delivery date %s has passed", date)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "delivery_date has passed")
+	}
+	if scheduler.maxDaysAhead > 0 && day.After(today.AddDate(0, 0, scheduler.maxDaysAhead)) {
+		err = fmt.Errorf("service.deliveryScheduler.Schedule: delivery date %s is more than %d days ahead", date, scheduler.maxDaysAhead)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation,
+			fmt.Sprintf("delivery_date could be at most %d days ahead", scheduler.maxDaysAhead))
+	}
+	if scheduler.sameDayCutoff > 0 && day.Equal(today) && now.Sub(today) >= scheduler.sameDayCutoff {
+		err = fmt.Errorf("service.deliveryScheduler.Schedule: same day delivery is closed at %s", now.Format(deliveryTimeLayout))
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation,
+			fmt.Sprintf("same day delivery is closed after %s", formatClock(scheduler.sameDayCutoff)))
+	}
+
+	delivery := &model.OrderDelivery{Date: day.Format(deliveryDateLayout)}
+	if len(scheduler.slots) == 0 {
+		if slot != "" {
+			err = fmt.Errorf("service.deliveryScheduler.Schedule: there is no delivery slot but %s is requested", slot)
+			return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "delivery_slot is not available, leave it empty")
+		}
+
+		return delivery, nil
+	}
+
+	chosen, ok := scheduler.findSlot(slot)
+	if !ok {
+		err = fmt.Errorf("service.deliveryScheduler.Schedule: delivery slot %q is not found", slot)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation,
+			fmt.Sprintf("delivery_slot must be one of %s", strings.Join(scheduler.slotNames(), ", ")))
+	}
+
+	// the clock of the slot is added to the date instead of the duration so the slot holds over DST changes
+	startAt := time.Date(day.Year(), day.Month(), day.Day(), 0, int(chosen.start/time.Minute), 0, 0, day.Location())
+	if startAt.Before(now.Add(scheduler.leadTime)) {
+		err = fmt.Errorf("service.deliveryScheduler.Schedule: delivery slot %s of %s starts within the lead time %s", slot, date, scheduler.leadTime)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation,
+			fmt.Sprintf("delivery must be ordered at least %s before its slot starts", scheduler.leadTime))
+	}
+
+	delivery.Start = formatClock(chosen.start)
+	delivery.End = formatClock(chosen.end)
+	delivery.MaxOrders = chosen.maxOrders
+
+	return delivery, nil
+}
+
+// findSlot return the slot formatted as HH:MM-HH:MM, the hour may have a single digit
+func (scheduler *deliveryScheduler) findSlot(slot string) (deliverySlot, bool) {
+	clocks := strings.Split(slot, "-")
+	if len(clocks) != 2 {
+		return deliverySlot{}, false
+	}
+	start, errStart := parseClock(strings.TrimSpace(clocks[0]))
+	end, errEnd := parseClock(strings.TrimSpace(clocks[1]))
+	if errStart != nil || errEnd != nil {
+		return deliverySlot{}, false
+	}
+
+	for _, s := range scheduler.slots {
+		if s.start == start && s.end == end {
+			return s, true
+		}
+	}
+
+	return deliverySlot{}, false
+}
+
+func (scheduler *deliveryScheduler) slotNames() []string {
+	names := make([]string, 0, len(scheduler.slots))
+	for _, slot := range scheduler.slots {
+		names = append(names, formatSlot(formatClock(slot.start), formatClock(slot.end)))
+	}
+
+	return names
+}
+
+// parseClock return the duration since midnight of a HH:MM clock
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse(deliveryTimeLayout, clock)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// formatSlot return the slot of a delivery as HH:MM-HH:MM, empty when there is no slot
+func formatSlot(start, end string) string {
+	if start == "" {
+		return ""
+	}
+
+	return start + "-" + end
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"family-catering/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeliveryScheduler(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     DeliveryOption
+		wantErr bool
+	}{
+		{
+			name: "success NewDeliveryScheduler",
+			opt: DeliveryOption{
+				Slots:    []DeliverySlotOption{{Start: "07:00", End: "09:00", MaxOrders: 20}, {Start: "11:00", End: "13:00"}},
+				LeadTime: 2 * time.Hour, SameDayCutoff: "10:00", MaxDaysAhead: 30,
+			},
+		},
+		{
+			name: "success NewDeliveryScheduler (no slot)",
+			opt:  DeliveryOption{},
+		},
+		{
+			name:    "fail NewDeliveryScheduler (invalid same day cutoff)",
+			opt:     DeliveryOption{SameDayCutoff: "10 AM"},
+			wantErr: true,
+		},
+		{
+			name:    "fail NewDeliveryScheduler (slot ends before it starts)",
+			opt:     DeliveryOption{Slots: []DeliverySlotOption{{Start: "13:00", End: "11:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail NewDeliveryScheduler (overlapping slots)",
+			opt:     DeliveryOption{Slots: []DeliverySlotOption{{Start: "07:00", End: "09:00"}, {Start: "08:30", End: "10:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail NewDeliveryScheduler (negative lead time)",
+			opt:     DeliveryOption{LeadTime: -time.Hour},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotScheduler, err := NewDeliveryScheduler(tt.opt)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantErr, gotScheduler == nil)
+		})
+	}
+}
+
+func Test_deliveryScheduler_Schedule(t *testing.T) {
+	opt := DeliveryOption{
+		Slots:    []DeliverySlotOption{{Start: "07:00", End: "09:00", MaxOrders: 20}, {Start: "17:00", End: "19:00"}},
+		LeadTime: 2 * time.Hour, SameDayCutoff: "10:00", MaxDaysAhead: 30,
+	}
+	morning := time.Date(2022, 11, 10, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		opt          DeliveryOption
+		date         string
+		slot         string
+		now          time.Time
+		wantDelivery *model.OrderDelivery
+		wantErr      bool
+	}{
+		{
+			name:         "success Schedule (tomorrow)",
+			opt:          opt,
+			date:         "2022-11-11",
+			slot:         "07:00-09:00",
+			now:          morning,
+			wantDelivery: &model.OrderDelivery{Date: "2022-11-11", Start: "07:00", End: "09:00", MaxOrders: 20},
+		},
+		{
+			name:         "success Schedule (same day before the cutoff)",
+			opt:          opt,
+			date:         "2022-11-10",
+			slot:         "17:00-19:00",
+			now:          morning,
+			wantDelivery: &model.OrderDelivery{Date: "2022-11-10", Start: "17:00", End: "19:00"},
+		},
+		{
+			name:         "success Schedule (single digit hour)",
+			opt:          opt,
+			date:         "2022-11-11",
+			slot:         "7:00-9:00",
+			now:          morning,
+			wantDelivery: &model.OrderDelivery{Date: "2022-11-11", Start: "07:00", End: "09:00", MaxOrders: 20},
+		},
+		{
+			name:         "success Schedule (no slot)",
+			opt:          DeliveryOption{},
+			date:         "2022-11-10",
+			now:          morning,
+			wantDelivery: &model.OrderDelivery{Date: "2022-11-10"},
+		},
+		{
+			name:    "fail Schedule (same day after the cutoff)",
+			opt:     opt,
+			date:    "2022-11-10",
+			slot:    "17:00-19:00",
+			now:     time.Date(2022, 11, 10, 10, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+		{
+			name:    "fail Schedule (within the lead time)",
+			opt:     opt,
+			date:    "2022-11-11",
+			slot:    "07:00-09:00",
+			now:     time.Date(2022, 11, 11, 6, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+		{
+			name:    "fail Schedule (date has passed)",
+			opt:     opt,
+			date:    "2022-11-09",
+			slot:    "17:00-19:00",
+			now:     morning,
+			wantErr: true,
+		},
+		{
+			name:    "fail Schedule (too many days ahead)",
+			opt:     opt,
+			date:    "2022-12-11",
+			slot:    "17:00-19:00",
+			now:     morning,
+			wantErr: true,
+		},
+		{
+			name:    "fail Schedule (slot is not found)",
+			opt:     opt,
+			date:    "2022-11-11",
+			slot:    "09:00-11:00",
+			now:     morning,
+			wantErr: true,
+		},
+		{
+			name:    "fail Schedule (missing slot)",
+			opt:     opt,
+			date:    "2022-11-11",
+			now:     morning,
+			wantErr: true,
+		},
+		{
+			name:    "fail Schedule (slot without configured slots)",
+			opt:     DeliveryOption{},
+			date:    "2022-11-11",
+			slot:    "07:00-09:00",
+			now:     morning,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, err := NewDeliveryScheduler(tt.opt)
+			assert.NoError(t, err)
+
+			gotDelivery, err := scheduler.Schedule(tt.date, tt.slot, tt.now)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantDelivery, gotDelivery)
+		})
+	}
+}
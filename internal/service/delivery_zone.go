@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type DeliveryZoneService interface {
+	GetByID(ctx context.Context, id int64) (*model.GetDeliveryZoneResponse, error)
+	List(ctx context.Context, limit, offset int) ([]*model.GetDeliveryZoneResponse, error)
+	Create(ctx context.Context, req model.CreateDeliveryZoneRequest) (*model.CreateDeliveryZoneResponse, error)
+	Update(ctx context.Context, id int64, req model.UpdateDeliveryZoneRequest) (*model.UpdateDeliveryZoneResponse, error)
+	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+	// Quote return the delivery fee and the minimum order of the zone delivering to the address
+	Quote(ctx context.Context, req model.DeliveryQuoteRequest) (*model.GetDeliveryQuoteResponse, error)
+}
+
+// DeliveryZoneResolver find the delivery zone of the destination of new orders
+type DeliveryZoneResolver interface {
+	// Resolve return the first active zone (by id) of the business matching the destination's postal code, or containing its
+	// coordinates when no zone matches the postal code. Every destination is delivered to for free (nil zone)
+	// when the business has no active zone. The error is already wrapped with apperrors.
+	Resolve(ctx context.Context, businessID int64, destination model.DeliveryAddress) (*model.DeliveryZone, error)
+}
+
+type deliveryZoneService struct {
+	zoneRepo repository.DeliveryZoneRepository
+	resolver DeliveryZoneResolver
+}
+
+func NewDeliveryZoneService(zoneRepo repository.DeliveryZoneRepository) DeliveryZoneService {
+	return &deliveryZoneService{zoneRepo: zoneRepo, resolver: NewDeliveryZoneResolver(zoneRepo)}
+}
+
+func (svc *deliveryZoneService) GetByID(ctx context.Context, id int64) (*model.GetDeliveryZoneResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.deliveryZoneService.GetByID: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.deliveryZoneService.GetByID: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	zone, errNoRow, err := svc.zoneRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow := fmt.Errorf("service.deliveryZoneService.GetByID: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.deliveryZoneService.GetByID: %w", err)
+		return nil, err
+	}
+
+	return newDeliveryZoneResponse(zone), nil
+}
+
+func (svc *deliveryZoneService) List(ctx context.Context, limit, offset int) ([]*model.GetDeliveryZoneResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.deliveryZoneService.List: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.deliveryZoneService.List: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	zones, errNoRow, err := svc.zoneRepo.List(ctx, claims.BusinessID, limit, offset)
+	if errNoRow != nil && err == nil {
+		return []*model.GetDeliveryZoneResponse{}, nil
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.deliveryZoneService.List: %w", err)
+		return nil, err
+	}
+
+	return newDeliveryZonesResponse(zones), nil
+}
+
+func (svc *deliveryZoneService) Create(ctx context.Context, req model.CreateDeliveryZoneRequest) (*model.CreateDeliveryZoneResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.deliveryZoneService.Create: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.deliveryZoneService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	zone, err := newDeliveryZone(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.deliveryZoneService.Create: %w", err)
+	}
+
+	id, err := svc.zoneRepo.Create(ctx, claims.BusinessID, zone)
+	if err != nil {
+		err = fmt.Errorf("service.deliveryZoneService.Create: %w", err)
+		return nil, err
+	}
+
+	created, errNoRow, err := svc.zoneRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.deliveryZoneService.Create: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.deliveryZoneService.Create: %w", err)
+		return nil, err
+	}
+
+	return newDeliveryZoneResponse(created), nil
+}
+
+// Update replace the zone, the orders already placed keep their delivery fee
+func (svc *deliveryZoneService) Update(ctx context.Context, id int64, req model.UpdateDeliveryZoneRequest) (*model.UpdateDeliveryZoneResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.deliveryZoneService.Update: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.deliveryZoneService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	zone, err := newDeliveryZone(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.deliveryZoneService.Update: %w", err)
+	}
+	zone.ID = id
+
+	_, errNoRow, err := svc.zoneRepo.Update(ctx, claims.BusinessID, zone)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.deliveryZoneService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.deliveryZoneService.Update: %w", err)
+		return nil, err
+	}
+
+	updated, errNoRow, err := svc.zoneRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.deliveryZoneService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.deliveryZoneService.Update: %w", err)
+		return nil, err
+	}
+
+	return newDeliveryZoneResponse(updated), nil
+}
+
+// Delete delete the zone, the orders already placed keep their destination and delivery fee
+func (svc *deliveryZoneService) Delete(ctx context.Context, id int64) (nAffected int64, err error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.deliveryZoneService.Delete: invalid auth token type want string got %T", token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.deliveryZoneService.Delete: %w", err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	nAffected, errNoRow, err := svc.zoneRepo.Delete(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.deliveryZoneService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.deliveryZoneService.Delete: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+func (svc *deliveryZoneService) Quote(ctx context.Context, req model.DeliveryQuoteRequest) (*model.GetDeliveryQuoteResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.deliveryZoneService.Quote: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.deliveryZoneService.Quote: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	err = utils.ValidateRequest(&req)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.deliveryZoneService.Quote: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	destination := model.DeliveryAddress{
+		PostalCode: strings.TrimSpace(req.PostalCode),
+		Latitude:   nullFloat64(req.Latitude),
+		Longitude:  nullFloat64(req.Longitude),
+	}
+	zone, err := svc.resolver.Resolve(ctx, claims.BusinessID, destination)
+	if err != nil {
+		return nil, fmt.Errorf("service.deliveryZoneService.Quote: %w", err)
+	}
+
+	res := &model.GetDeliveryQuoteResponse{}
+	if zone != nil {
+		res.ZoneID, res.ZoneName, res.Fee, res.MinOrder = zone.ID, zone.Name, zone.Fee, zone.MinOrder
+	}
+
+	return res, nil
+}
+
+type deliveryZoneResolver struct {
+	zoneRepo repository.DeliveryZoneRepository
+}
+
+func NewDeliveryZoneResolver(zoneRepo repository.DeliveryZoneRepository) DeliveryZoneResolver {
+	return &deliveryZoneResolver{zoneRepo: zoneRepo}
+}
+
+func (r *deliveryZoneResolver) Resolve(ctx context.Context, businessID int64, destination model.DeliveryAddress) (*model.DeliveryZone, error) {
+	zones, errNoRow, err := r.zoneRepo.ListActive(ctx, businessID)
+	if errNoRow != nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("service.deliveryZoneResolver.Resolve: %w", err)
+	}
+
+	postalCode := normalizePostalCode(destination.PostalCode)
+	hasCoordinates := destination.Latitude.Valid && destination.Longitude.Valid
+	if postalCode == "" && !hasCoordinates {
+		err = errors.New("service.deliveryZoneResolver.Resolve: destination has neither postal code nor coordinates")
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "delivery address with postal code or coordinates is required")
+	}
+
+	if postalCode != "" {
+		for _, zone := range zones {
+			for _, code := range splitPostalCodes(zone.PostalCodes) {
+				if code == postalCode {
+					return zone, nil
+				}
+			}
+		}
+	}
+
+	if hasCoordinates {
+		for _, zone := range zones {
+			if !zone.Polygon.Valid {
+				continue
+			}
+			polygon, err := parsePolygon(zone.Polygon.String)
+			if err != nil {
+				return nil, fmt.Errorf("service.deliveryZoneResolver.Resolve: zone %d: %w", zone.ID, err)
+			}
+			if polygonContains(polygon, destination.Latitude.Float64, destination.Longitude.Float64) {
+				return zone, nil
+			}
+		}
+	}
+
+	err = fmt.Errorf("service.deliveryZoneResolver.Resolve: no zone delivers to postal code %q", postalCode)
+	return nil, apperrors.WrapError(err, apperrors.ErrOutOfDeliveryArea, "")
+}
+
+// applyDeliveryFee add the zone's delivery fee to the charge of a new order, the fee is neither discounted nor taxed.
+// The minimum order is compared with the order's total after discount. The error is already wrapped with apperrors.
+func applyDeliveryFee(charge *model.OrderCharge, zone *model.DeliveryZone) error {
+	if zone == nil {
+		return nil
+	}
+
+	if charge.SubTotal.Sub(charge.Discount).Cmp(zone.MinOrder) < 0 {
+		err := fmt.Errorf("service.applyDeliveryFee: order total is below the minimum order %s of zone %d", zone.MinOrder, zone.ID)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidation, fmt.Sprintf("order total is below the delivery zone's minimum order (%s)", zone.MinOrder))
+	}
+
+	charge.DeliveryFee = zone.Fee
+	charge.GrandTotal = charge.GrandTotal.Add(zone.Fee)
+
+	return nil
+}
+
+// newDeliveryZone validate the request and return the zone to be stored
+func newDeliveryZone(req model.CreateDeliveryZoneRequest) (model.DeliveryZone, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	err := utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.newDeliveryZone: %w", err)
+		return model.DeliveryZone{}, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.newDeliveryZone: %w", err)
+		return model.DeliveryZone{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	postalCodes := joinPostalCodes(req.PostalCodes)
+	if postalCodes == "" && len(req.Polygon) == 0 {
+		err = errors.New("service.newDeliveryZone: zone has neither postal codes nor polygon")
+		return model.DeliveryZone{}, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "postal_codes or polygon is required")
+	}
+
+	zone := model.DeliveryZone{
+		Name:        req.Name,
+		PostalCodes: postalCodes,
+		Fee:         req.Fee,
+		MinOrder:    req.MinOrder,
+		Active:      req.Active,
+	}
+
+	if len(req.Polygon) > 0 {
+		for _, point := range req.Polygon {
+			if point[0] < -90 || point[0] > 90 || point[1] < -180 || point[1] > 180 {
+				err = fmt.Errorf("service.newDeliveryZone: invalid polygon's point %v", point)
+				return model.DeliveryZone{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "polygon's points must be [latitude, longitude]")
+			}
+		}
+		polygon, err := json.Marshal(req.Polygon)
+		if err != nil {
+			return model.DeliveryZone{}, fmt.Errorf("service.newDeliveryZone: %w", err)
+		}
+		zone.Polygon = sql.NullString{String: string(polygon), Valid: true}
+	}
+
+	return zone, nil
+}
+
+// newDeliveryAddress return the destination of the request, the request is validated along with the order's request
+func newDeliveryAddress(req model.DeliveryAddressRequest) model.DeliveryAddress {
+	return model.DeliveryAddress{
+		Address:    strings.TrimSpace(req.Address),
+		PostalCode: strings.TrimSpace(req.PostalCode),
+		Latitude:   nullFloat64(req.Latitude),
+		Longitude:  nullFloat64(req.Longitude),
+	}
+}
+
+// normalizePostalCode return the postal code in upper case without spaces, e.g. "sw1a 1aa" is "SW1A1AA"
+func normalizePostalCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
+
+// joinPostalCodes return the normalized and sorted postal codes without duplicates, separated by comma
+func joinPostalCodes(codes []string) string {
+	seen := make(map[string]bool, len(codes))
+	normalized := make([]string, 0, len(codes))
+	for _, code := range codes {
+		code = normalizePostalCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		normalized = append(normalized, code)
+	}
+	sort.Strings(normalized)
+
+	return strings.Join(normalized, ",")
+}
+
+func splitPostalCodes(codes string) []string {
+	if codes == "" {
+		return []string{}
+	}
+
+	return strings.Split(codes, ",")
+}
+
+// parsePolygon parse the polygon of a zone stored as JSON [[latitude, longitude], ...]
+func parsePolygon(polygon string) ([][2]float64, error) {
+	var points [][2]float64
+	err := json.Unmarshal([]byte(polygon), &points)
+	if err != nil {
+		return nil, fmt.Errorf("service.parsePolygon: %w", err)
+	}
+
+	return points, nil
+}
+
+// polygonContains tell whether the point is inside the polygon by casting a ray along its latitude (even-odd rule),
+// the earth's curvature is ignored which is fine for areas as small as a city
+func polygonContains(polygon [][2]float64, lat, lng float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		latI, lngI := polygon[i][0], polygon[i][1]
+		latJ, lngJ := polygon[j][0], polygon[j][1]
+		if (latI > lat) != (latJ > lat) && lng < (lngJ-lngI)*(lat-latI)/(latJ-latI)+lngI {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+func nullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\delivery_zone.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeliveryZoneService is a mock of DeliveryZoneService interface.
+type MockDeliveryZoneService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryZoneServiceMockRecorder
+}
+
+// MockDeliveryZoneServiceMockRecorder is the mock recorder for MockDeliveryZoneService.
+type MockDeliveryZoneServiceMockRecorder struct {
+	mock *MockDeliveryZoneService
+}
+
+// NewMockDeliveryZoneService creates a new mock instance.
+func NewMockDeliveryZoneService(ctrl *gomock.Controller) *MockDeliveryZoneService {
+	mock := &MockDeliveryZoneService{ctrl: ctrl}
+	mock.recorder = &MockDeliveryZoneServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryZoneService) EXPECT() *MockDeliveryZoneServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDeliveryZoneService) Create(ctx context.Context, req model.CreateDeliveryZoneRequest) (*model.CreateDeliveryZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*model.CreateDeliveryZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryZoneServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryZoneService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockDeliveryZoneService) Delete(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeliveryZoneServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeliveryZoneService)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockDeliveryZoneService) GetByID(ctx context.Context, id int64) (*model.GetDeliveryZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.GetDeliveryZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDeliveryZoneServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeliveryZoneService)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockDeliveryZoneService) List(ctx context.Context, limit, offset int) ([]*model.GetDeliveryZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].([]*model.GetDeliveryZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeliveryZoneServiceMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeliveryZoneService)(nil).List), ctx, limit, offset)
+}
+
+// Quote mocks base method.
+func (m *MockDeliveryZoneService) Quote(ctx context.Context, req model.DeliveryQuoteRequest) (*model.GetDeliveryQuoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, req)
+	ret0, _ := ret[0].(*model.GetDeliveryQuoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockDeliveryZoneServiceMockRecorder) Quote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockDeliveryZoneService)(nil).Quote), ctx, req)
+}
+
+// Update mocks base method.
+func (m *MockDeliveryZoneService) Update(ctx context.Context, id int64, req model.UpdateDeliveryZoneRequest) (*model.UpdateDeliveryZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*model.UpdateDeliveryZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeliveryZoneServiceMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeliveryZoneService)(nil).Update), ctx, id, req)
+}
+
+// MockDeliveryZoneResolver is a mock of DeliveryZoneResolver interface.
+type MockDeliveryZoneResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryZoneResolverMockRecorder
+}
+
+// MockDeliveryZoneResolverMockRecorder is the mock recorder for MockDeliveryZoneResolver.
+type MockDeliveryZoneResolverMockRecorder struct {
+	mock *MockDeliveryZoneResolver
+}
+
+// NewMockDeliveryZoneResolver creates a new mock instance.
+func NewMockDeliveryZoneResolver(ctrl *gomock.Controller) *MockDeliveryZoneResolver {
+	mock := &MockDeliveryZoneResolver{ctrl: ctrl}
+	mock.recorder = &MockDeliveryZoneResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryZoneResolver) EXPECT() *MockDeliveryZoneResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockDeliveryZoneResolver) Resolve(ctx context.Context, businessID int64, destination model.DeliveryAddress) (*model.DeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, businessID, destination)
+	ret0, _ := ret[0].(*model.DeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockDeliveryZoneResolverMockRecorder) Resolve(ctx, businessID, destination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockDeliveryZoneResolver)(nil).Resolve), ctx, businessID, destination)
+}
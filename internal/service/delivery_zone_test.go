@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"family-catering/pkg/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPolygon covers the area around Jl. Sudirman, Jakarta
+const testPolygon = "[[-6.2,106.8],[-6.2,106.85],[-6.25,106.85],[-6.25,106.8]]"
+
+func TestNewDeliveryZoneService(t *testing.T) {
+	type args struct {
+		zoneRepo repository.DeliveryZoneRepository
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewDeliveryZoneService"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewDeliveryZoneService(tt.args.zoneRepo))
+		})
+	}
+}
+
+func Test_deliveryZoneResolver_Resolve(t *testing.T) {
+	zones := []*model.DeliveryZone{
+		{ID: 1, Name: "South Jakarta", PostalCodes: "12430,12440", Fee: money.MustParse("10000")},
+		{ID: 2, Name: "Sudirman", Polygon: sql.NullString{String: testPolygon, Valid: true}, Fee: money.MustParse("8000")},
+		{ID: 3, Name: "Sudirman (postal code)", PostalCodes: "10220", Polygon: sql.NullString{String: testPolygon, Valid: true}},
+	}
+	coordinate := func(f float64) sql.NullFloat64 { return sql.NullFloat64{Float64: f, Valid: true} }
+	tests := []struct {
+		name         string
+		destination  model.DeliveryAddress
+		prepareMocks func(*repository.MockDeliveryZoneRepository)
+		wantZoneID   int64
+		wantErr      error
+	}{
+		{
+			name:        "success Resolve (postal code)",
+			destination: model.DeliveryAddress{PostalCode: " 12440"},
+			prepareMocks: func(m *repository.MockDeliveryZoneRepository) {
+				m.EXPECT().ListActive(gomock.Any(), int64(1)).Return(zones, nil, nil)
+			},
+			wantZoneID: 1,
+		},
+		{
+			name:        "success Resolve (postal code before polygon)",
+			destination: model.DeliveryAddress{PostalCode: "10220", Latitude: coordinate(-6.22), Longitude: coordinate(106.82)},
+			prepareMocks: func(m *repository.MockDeliveryZoneRepository) {
+				m.EXPECT().ListActive(gomock.Any(), int64(1)).Return(zones, nil, nil)
+			},
+			wantZoneID: 3,
+		},
+		{
+			name:        "success Resolve (polygon)",
+			destination: model.DeliveryAddress{PostalCode: "10110", Latitude: coordinate(-6.22), Longitude: coordinate(106.82)},
+			prepareMocks: func(m *repository.MockDeliveryZoneRepository) {
+				m.EXPECT().ListActive(gomock.Any(), int64(1)).Return(zones, nil, nil)
+			},
+			wantZoneID: 2,
+		},
+		{
+			name:        "success Resolve (no active zone)",
+			destination: model.DeliveryAddress{},
+			prepareMocks: func(m *repository.MockDeliveryZoneRepository) {
+				m.EXPECT().ListActive(gomock.Any(), int64(1)).Return(nil, errors.New("oops! no row"), nil)
+			},
+		},
+		{
+			name:        "fail Resolve (no postal code nor coordinates)",
+			destination: model.DeliveryAddress{Address: "Jl. Kenanga 5"},
+			prepareMocks: func(m *repository.MockDeliveryZoneRepository) {
+				m.EXPECT().ListActive(gomock.Any(), int64(1)).Return(zones, nil, nil)
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name:        "fail Resolve (out of delivery area)",
+			destination: model.DeliveryAddress{PostalCode: "40111", Latitude: coordinate(-6.9), Longitude: coordinate(107.6)},
+			prepareMocks: func(m *repository.MockDeliveryZoneRepository) {
+				m.EXPECT().ListActive(gomock.Any(), int64(1)).Return(zones, nil, nil)
+			},
+			wantErr: apperrors.ErrOutOfDeliveryArea,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			zoneRepoMock := repository.NewMockDeliveryZoneRepository(ctrl)
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(zoneRepoMock)
+			}
+
+			zone, err := NewDeliveryZoneResolver(zoneRepoMock).Resolve(context.Background(), 1, tt.destination)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, zone)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantZoneID == 0 {
+				assert.Nil(t, zone)
+				return
+			}
+			assert.Equal(t, tt.wantZoneID, zone.ID)
+		})
+	}
+}
+
+func Test_applyDeliveryFee(t *testing.T) {
+	tests := []struct {
+		name       string
+		charge     model.OrderCharge
+		zone       *model.DeliveryZone
+		wantCharge model.OrderCharge
+		wantErr    error
+	}{
+		{
+			name:       "success applyDeliveryFee",
+			charge:     model.OrderCharge{SubTotal: money.MustParse("120000"), Discount: money.MustParse("20000"), GrandTotal: money.MustParse("110000")},
+			zone:       &model.DeliveryZone{ID: 1, Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000")},
+			wantCharge: model.OrderCharge{SubTotal: money.MustParse("120000"), Discount: money.MustParse("20000"), DeliveryFee: money.MustParse("10000"), GrandTotal: money.MustParse("120000")},
+		},
+		{
+			name:       "success applyDeliveryFee (no zone)",
+			charge:     model.OrderCharge{SubTotal: money.MustParse("120000"), GrandTotal: money.MustParse("132000")},
+			wantCharge: model.OrderCharge{SubTotal: money.MustParse("120000"), GrandTotal: money.MustParse("132000")},
+		},
+		{
+			name:       "fail applyDeliveryFee (below minimum order after discount)",
+			charge:     model.OrderCharge{SubTotal: money.MustParse("120000"), Discount: money.MustParse("20001"), GrandTotal: money.MustParse("109999")},
+			zone:       &model.DeliveryZone{ID: 1, Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000")},
+			wantCharge: model.OrderCharge{SubTotal: money.MustParse("120000"), Discount: money.MustParse("20001"), GrandTotal: money.MustParse("109999")},
+			wantErr:    apperrors.ErrFieldValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := applyDeliveryFee(&tt.charge, tt.zone)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCharge, tt.charge)
+		})
+	}
+}
+
+func Test_newDeliveryZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      model.CreateDeliveryZoneRequest
+		wantZone model.DeliveryZone
+		wantErr  error
+	}{
+		{
+			name: "success newDeliveryZone (postal codes)",
+			req: model.CreateDeliveryZoneRequest{
+				Name: " South Jakarta ", PostalCodes: []string{"12440", " 12430", "12440"}, Fee: money.MustParse("10000"), Active: true,
+			},
+			wantZone: model.DeliveryZone{Name: "South Jakarta", PostalCodes: "12430,12440", Fee: money.MustParse("10000"), Active: true},
+		},
+		{
+			name: "success newDeliveryZone (polygon)",
+			req: model.CreateDeliveryZoneRequest{
+				Name: "Sudirman", Polygon: [][2]float64{{-6.2, 106.8}, {-6.2, 106.85}, {-6.25, 106.85}, {-6.25, 106.8}},
+			},
+			wantZone: model.DeliveryZone{Name: "Sudirman", Polygon: sql.NullString{String: testPolygon, Valid: true}},
+		},
+		{
+			name:    "fail newDeliveryZone (neither postal codes nor polygon)",
+			req:     model.CreateDeliveryZoneRequest{Name: "South Jakarta"},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name:    "fail newDeliveryZone (polygon with 2 points)",
+			req:     model.CreateDeliveryZoneRequest{Name: "Sudirman", Polygon: [][2]float64{{-6.2, 106.8}, {-6.2, 106.85}}},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name:    "fail newDeliveryZone (longitude as latitude)",
+			req:     model.CreateDeliveryZoneRequest{Name: "Sudirman", Polygon: [][2]float64{{106.8, -6.2}, {106.85, -6.2}, {106.85, -6.25}}},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotZone, err := newDeliveryZone(tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantZone, gotZone)
+		})
+	}
+}
+
+func Test_deliveryZoneService_Create(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.CreateDeliveryZoneRequest
+	}
+	type mocks struct {
+		utMocks      utils.Mock
+		zoneRepoMock *repository.MockDeliveryZoneRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *deliveryZoneService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.CreateDeliveryZoneResponse
+		wantErr      error
+	}{
+		{
+			name: "success Create",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.CreateDeliveryZoneRequest{
+				Name: "South Jakarta", PostalCodes: []string{"12430"}, Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000"), Active: true,
+			}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.zoneRepoMock.EXPECT().Create(gomock.Any(), int64(1), model.DeliveryZone{
+						Name: "South Jakarta", PostalCodes: "12430", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000"), Active: true,
+					}).Return(int64(1), nil),
+					m.zoneRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.DeliveryZone{
+						ID: 1, Name: "South Jakarta", PostalCodes: "12430", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000"),
+						Active: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+					}, nil, nil),
+				)
+			},
+			wantResp: &model.CreateDeliveryZoneResponse{
+				ID: 1, Name: "South Jakarta", PostalCodes: []string{"12430"}, Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000"),
+				Active: true, CreatedAt: "2022-11-01T00:00:00Z", UpdatedAt: "2022-11-01T00:00:00Z",
+			},
+		},
+		{
+			name: "fail Create (missing name)",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.CreateDeliveryZoneRequest{Name: " ", PostalCodes: []string{"12430"}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name: "fail Create (invalid/no token)",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.CreateDeliveryZoneRequest{Name: "South Jakarta", PostalCodes: []string{"12430"}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			zoneRepoMock := repository.NewMockDeliveryZoneRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{zoneRepoMock: zoneRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.zoneRepo = zoneRepoMock
+
+			gotResp, err := tt.svc.Create(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_deliveryZoneService_Quote(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.DeliveryQuoteRequest
+	}
+	type mocks struct {
+		utMocks      utils.Mock
+		resolverMock *MockDeliveryZoneResolver
+	}
+	latitude, longitude := -6.22, 106.82
+	tests := []struct {
+		name         string
+		svc          *deliveryZoneService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.GetDeliveryQuoteResponse
+		wantErr      error
+	}{
+		{
+			name: "success Quote",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.DeliveryQuoteRequest{PostalCode: " 12430 ", Latitude: &latitude, Longitude: &longitude}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.resolverMock.EXPECT().Resolve(gomock.Any(), int64(1), model.DeliveryAddress{
+					PostalCode: "12430", Latitude: sql.NullFloat64{Float64: latitude, Valid: true}, Longitude: sql.NullFloat64{Float64: longitude, Valid: true},
+				}).Return(&model.DeliveryZone{ID: 1, Name: "South Jakarta", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000")}, nil)
+			},
+			wantResp: &model.GetDeliveryQuoteResponse{ZoneID: 1, ZoneName: "South Jakarta", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000")},
+		},
+		{
+			name: "success Quote (no active zone)",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.DeliveryQuoteRequest{PostalCode: "12430"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.resolverMock.EXPECT().Resolve(gomock.Any(), int64(1), model.DeliveryAddress{PostalCode: "12430"}).Return(nil, nil)
+			},
+			wantResp: &model.GetDeliveryQuoteResponse{},
+		},
+		{
+			name: "fail Quote (latitude without longitude)",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.DeliveryQuoteRequest{Latitude: &latitude}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Quote (out of delivery area)",
+			svc:  &deliveryZoneService{},
+			args: args{ctx: context.Background(), req: model.DeliveryQuoteRequest{PostalCode: "40111"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.resolverMock.EXPECT().Resolve(gomock.Any(), int64(1), model.DeliveryAddress{PostalCode: "40111"}).
+					Return(nil, apperrors.WrapError(errors.New("oops! no zone"), apperrors.ErrOutOfDeliveryArea, ""))
+			},
+			wantErr: apperrors.ErrOutOfDeliveryArea,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			resolverMock := NewMockDeliveryZoneResolver(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{resolverMock: resolverMock, utMocks: utMocks})
+			}
+
+			tt.svc.resolver = resolverMock
+
+			gotResp, err := tt.svc.Quote(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DriverService interface {
+	GetByID(ctx context.Context, id int64) (*model.GetDriverResponse, error)
+	List(ctx context.Context, limit, offset int) ([]*model.GetDriverResponse, error)
+	Create(ctx context.Context, req model.CreateDriverRequest) (*model.CreateDriverResponse, error)
+	Update(ctx context.Context, id int64, req model.UpdateDriverRequest) (*model.UpdateDriverResponse, error)
+	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+	// Assign assign the orders to the driver and return the driver's route of the day
+	Assign(ctx context.Context, driverID int64, req model.AssignDeliveryRequest) (*model.GetDeliveryRouteResponse, error)
+	Unassign(ctx context.Context, driverID, orderID int64) (nAffected int64, err error)
+	// Route return the stops of the driver on the date (YYYY-MM-DD) in the order they should be delivered
+	Route(ctx context.Context, driverID int64, date string) (*model.GetDeliveryRouteResponse, error)
+	// MarkDelivered record the stop as delivered now and move its order to DELIVERED
+	MarkDelivered(ctx context.Context, driverID, orderID int64) (*model.GetDeliveryStopResponse, error)
+}
+
+type driverService struct {
+	driverRepo   repository.DriverRepository
+	routePlanner RoutePlanner
+}
+
+func NewDriverService(driverRepo repository.DriverRepository, routePlanner RoutePlanner) DriverService {
+	return &driverService{driverRepo: driverRepo, routePlanner: routePlanner}
+}
+
+func (svc *driverService) GetByID(ctx context.Context, id int64) (*model.GetDriverResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.GetByID: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.driverService.GetByID: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	driver, errNoRow, err := svc.driverRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow := fmt.Errorf("service.driverService.GetByID: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.driverService.GetByID: %w", err)
+		return nil, err
+	}
+
+	return newDriverResponse(driver), nil
+}
+
+func (svc *driverService) List(ctx context.Context, limit, offset int) ([]*model.GetDriverResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.List: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.driverService.List: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	drivers, errNoRow, err := svc.driverRepo.List(ctx, claims.BusinessID, limit, offset)
+	if errNoRow != nil && err == nil {
+		return []*model.GetDriverResponse{}, nil
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.driverService.List: %w", err)
+		return nil, err
+	}
+
+	return newDriversResponse(drivers), nil
+}
+
+func (svc *driverService) Create(ctx context.Context, req model.CreateDriverRequest) (*model.CreateDriverResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.Create: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.driverService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	driver, err := newDriver(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.driverService.Create: %w", err)
+	}
+
+	id, err := svc.driverRepo.Create(ctx, claims.BusinessID, driver)
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Create: %w", err)
+		return nil, err
+	}
+
+	created, errNoRow, err := svc.driverRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Create: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Create: %w", err)
+		return nil, err
+	}
+
+	return newDriverResponse(created), nil
+}
+
+func (svc *driverService) Update(ctx context.Context, id int64, req model.UpdateDriverRequest) (*model.UpdateDriverResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.Update: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.driverService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	driver, err := newDriver(req)
+	if err != nil {
+		return nil, fmt.Errorf("service.driverService.Update: %w", err)
+	}
+	driver.ID = id
+
+	_, errNoRow, err := svc.driverRepo.Update(ctx, claims.BusinessID, driver)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Update: %w", err)
+		return nil, err
+	}
+
+	updated, errNoRow, err := svc.driverRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Update: %w", err)
+		return nil, err
+	}
+
+	return newDriverResponse(updated), nil
+}
+
+// Delete delete the driver along with its undelivered stops, a driver who has delivered orders is kept
+// as their proof of delivery and should be deactivated instead
+func (svc *driverService) Delete(ctx context.Context, id int64) (nAffected int64, err error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.Delete: invalid auth token type want string got %T", token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.driverService.Delete: %w", err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	_, errNoRow, err := svc.driverRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Delete: %w", err)
+		return 0, err
+	}
+
+	nAffected, errNoRow, err = svc.driverRepo.Delete(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrDriverInUse, "driver has delivered orders, deactivate it instead")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Delete: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+func (svc *driverService) Assign(ctx context.Context, driverID int64, req model.AssignDeliveryRequest) (*model.GetDeliveryRouteResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.Assign: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.driverService.Assign: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	err = utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.driverService.Assign: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.driverService.Assign: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	driver, errNoRow, err := svc.driverRepo.GetByID(ctx, claims.BusinessID, driverID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Assign: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Assign: %w", err)
+		return nil, err
+	}
+	if !driver.Active {
+		err = fmt.Errorf("service.driverService.Assign: driver %d is inactive", driverID)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "driver is inactive")
+	}
+
+	_, err = svc.driverRepo.Assign(ctx, claims.BusinessID, driverID, req.Date, req.OrderIDs)
+	if errors.Is(err, repository.ErrOrderNotAssignable) {
+		err = fmt.Errorf("service.driverService.Assign: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrOrderNotAssignable,
+			"orders must be delivered on the date, neither cancelled nor delivered")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Assign: %w", err)
+		return nil, err
+	}
+
+	route, err := svc.route(ctx, claims.BusinessID, driverID, req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("service.driverService.Assign: %w", err)
+	}
+
+	return route, nil
+}
+
+func (svc *driverService) Unassign(ctx context.Context, driverID, orderID int64) (nAffected int64, err error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.Unassign: invalid auth token type want string got %T", token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.driverService.Unassign: %w", err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	nAffected, errNoRow, err := svc.driverRepo.Unassign(ctx, claims.BusinessID, driverID, orderID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Unassign: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "undelivered stop not found")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Unassign: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+func (svc *driverService) Route(ctx context.Context, driverID int64, date string) (*model.GetDeliveryRouteResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.Route: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.driverService.Route: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	_, err = time.Parse(deliveryDateLayout, date)
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Route: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "date must be formatted as YYYY-MM-DD")
+	}
+
+	_, errNoRow, err := svc.driverRepo.GetByID(ctx, claims.BusinessID, driverID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.Route: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.Route: %w", err)
+		return nil, err
+	}
+
+	route, err := svc.route(ctx, claims.BusinessID, driverID, date)
+	if err != nil {
+		return nil, fmt.Errorf("service.driverService.Route: %w", err)
+	}
+
+	return route, nil
+}
+
+// route plan the stops of the driver on the date, a driver without stop has an empty route
+func (svc *driverService) route(ctx context.Context, businessID, driverID int64, date string) (*model.GetDeliveryRouteResponse, error) {
+	stops, errNoRow, err := svc.driverRepo.ListStops(ctx, businessID, driverID, date)
+	if errNoRow != nil {
+		stops = []*model.DeliveryStop{}
+	} else if err != nil {
+		return nil, fmt.Errorf("service.driverService.route: %w", err)
+	}
+
+	return newDeliveryRouteResponse(driverID, date, svc.routePlanner.Plan(stops)), nil
+}
+
+func (svc *driverService) MarkDelivered(ctx context.Context, driverID, orderID int64) (*model.GetDeliveryStopResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.driverService.MarkDelivered: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	// session is used to record who moved the order
+	session, ok := utils.ValueContext(ctx, consts.CtxKeySession).(*model.AuthSessionResponse)
+	if !ok || !session.Valid {
+		err := fmt.Errorf("service.driverService.MarkDelivered: invalid session")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.driverService.MarkDelivered: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	stop, errNoRow, err := svc.driverRepo.GetStop(ctx, claims.BusinessID, driverID, orderID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.MarkDelivered: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "stop not found")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.MarkDelivered: %w", err)
+		return nil, err
+	}
+
+	err = validateOrderStatusTransition(stop.Status, consts.StatusDelivered)
+	if err != nil {
+		return nil, fmt.Errorf("service.driverService.MarkDelivered: %w", err)
+	}
+
+	deliveredAt, errNoRow, err := svc.driverRepo.MarkDelivered(ctx, claims.BusinessID, driverID, orderID, stop.Status, session.OwnerID)
+	// the stop has been delivered or its order has been moved by another request between GetStop and MarkDelivered
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.driverService.MarkDelivered: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrOrderStatusTransition, "order status has been changed, please try again")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.driverService.MarkDelivered: %w", err)
+		return nil, err
+	}
+
+	stop.Status = consts.StatusDelivered
+	stop.DeliveredAt.String, stop.DeliveredAt.Valid = deliveredAt, true
+
+	return newDeliveryStopResponse(stop), nil
+}
+
+// newDriver validate the request and return the driver to be stored
+func newDriver(req model.CreateDriverRequest) (model.Driver, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Phone = strings.TrimSpace(req.Phone)
+	err := utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.newDriver: %w", err)
+		return model.Driver{}, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.newDriver: %w", err)
+		return model.Driver{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	return model.Driver{Name: req.Name, Phone: req.Phone, Active: req.Active}, nil
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\driver.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDriverService is a mock of DriverService interface.
+type MockDriverService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriverServiceMockRecorder
+}
+
+// MockDriverServiceMockRecorder is the mock recorder for MockDriverService.
+type MockDriverServiceMockRecorder struct {
+	mock *MockDriverService
+}
+
+// NewMockDriverService creates a new mock instance.
+func NewMockDriverService(ctrl *gomock.Controller) *MockDriverService {
+	mock := &MockDriverService{ctrl: ctrl}
+	mock.recorder = &MockDriverServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriverService) EXPECT() *MockDriverServiceMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockDriverService) Assign(ctx context.Context, driverID int64, req model.AssignDeliveryRequest) (*model.GetDeliveryRouteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, driverID, req)
+	ret0, _ := ret[0].(*model.GetDeliveryRouteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockDriverServiceMockRecorder) Assign(ctx, driverID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockDriverService)(nil).Assign), ctx, driverID, req)
+}
+
+// Create mocks base method.
+func (m *MockDriverService) Create(ctx context.Context, req model.CreateDriverRequest) (*model.CreateDriverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*model.CreateDriverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDriverServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDriverService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockDriverService) Delete(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDriverServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDriverService)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockDriverService) GetByID(ctx context.Context, id int64) (*model.GetDriverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.GetDriverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDriverServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDriverService)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockDriverService) List(ctx context.Context, limit, offset int) ([]*model.GetDriverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].([]*model.GetDriverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDriverServiceMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDriverService)(nil).List), ctx, limit, offset)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockDriverService) MarkDelivered(ctx context.Context, driverID, orderID int64) (*model.GetDeliveryStopResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, driverID, orderID)
+	ret0, _ := ret[0].(*model.GetDeliveryStopResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockDriverServiceMockRecorder) MarkDelivered(ctx, driverID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockDriverService)(nil).MarkDelivered), ctx, driverID, orderID)
+}
+
+// Route mocks base method.
+func (m *MockDriverService) Route(ctx context.Context, driverID int64, date string) (*model.GetDeliveryRouteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Route", ctx, driverID, date)
+	ret0, _ := ret[0].(*model.GetDeliveryRouteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Route indicates an expected call of Route.
+func (mr *MockDriverServiceMockRecorder) Route(ctx, driverID, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Route", reflect.TypeOf((*MockDriverService)(nil).Route), ctx, driverID, date)
+}
+
+// Unassign mocks base method.
+func (m *MockDriverService) Unassign(ctx context.Context, driverID, orderID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unassign", ctx, driverID, orderID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unassign indicates an expected call of Unassign.
+func (mr *MockDriverServiceMockRecorder) Unassign(ctx, driverID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockDriverService)(nil).Unassign), ctx, driverID, orderID)
+}
+
+// Update mocks base method.
+func (m *MockDriverService) Update(ctx context.Context, id int64, req model.UpdateDriverRequest) (*model.UpdateDriverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*model.UpdateDriverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDriverServiceMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDriverService)(nil).Update), ctx, id, req)
+}
@@ -0,0 +1,579 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRouteStops return the unpinned stops of driver 1 on 2023-01-02, listed by order's id
+func testRouteStops() []*model.DeliveryStop {
+	return []*model.DeliveryStop{
+		{OrderID: 1, DriverID: 1, DeliveryDate: "2023-01-02", CustomerEmail: "budi@example.com", Address: "Jl. Kenanga 5",
+			DeliveryStart: "10:00", DeliveryEnd: "12:00", Status: consts.StatusReady},
+		{OrderID: 2, DriverID: 1, DeliveryDate: "2023-01-02", CustomerEmail: "ani@example.com", Address: "Jl. Melati 1",
+			DeliveryStart: "08:00", DeliveryEnd: "10:00", Status: consts.StatusDelivered,
+			DeliveredAt: sql.NullString{String: "2023-01-02T08:30:00Z", Valid: true}},
+	}
+}
+
+// testRouteResponse is the route of testRouteStops, earliest slot first
+func testRouteResponse() *model.GetDeliveryRouteResponse {
+	return &model.GetDeliveryRouteResponse{DriverID: 1, Date: "2023-01-02", Stops: []*model.GetDeliveryStopResponse{
+		{Sequence: 1, OrderID: 2, CustomerEmail: "ani@example.com", Slot: "08:00-10:00",
+			Address: &model.DeliveryAddressResponse{Address: "Jl. Melati 1"}, Status: "DELIVERED", DeliveredAt: "2023-01-02T08:30:00Z"},
+		{Sequence: 2, OrderID: 1, CustomerEmail: "budi@example.com", Slot: "10:00-12:00",
+			Address: &model.DeliveryAddressResponse{Address: "Jl. Kenanga 5"}, Status: "READY"},
+	}}
+}
+
+func TestNewDriverService(t *testing.T) {
+	type args struct {
+		driverRepo   repository.DriverRepository
+		routePlanner RoutePlanner
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewDriverService"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewDriverService(tt.args.driverRepo, tt.args.routePlanner))
+		})
+	}
+}
+
+func Test_driverService_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	type mocks struct {
+		utMocks        utils.Mock
+		driverRepoMock *repository.MockDriverRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *driverService
+		args         args
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success Delete",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1}, nil, nil),
+					m.driverRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(int64(1), nil, nil),
+				)
+			},
+		},
+		{
+			name: "fail Delete (driver not found)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail Delete (driver has delivered orders)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1}, nil, nil),
+					m.driverRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(int64(0), errors.New("oops! no row"), nil),
+				)
+			},
+			wantErr: apperrors.ErrDriverInUse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverRepoMock := repository.NewMockDriverRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{driverRepoMock: driverRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.driverRepo = driverRepoMock
+
+			_, err := tt.svc.Delete(tt.args.ctx, tt.args.id)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_driverService_Assign(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		driverID int64
+		req      model.AssignDeliveryRequest
+	}
+	type mocks struct {
+		utMocks        utils.Mock
+		driverRepoMock *repository.MockDriverRepository
+	}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		svc          *driverService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.GetDeliveryRouteResponse
+		wantErr      error
+	}{
+		{
+			name: "success Assign",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{1, 2}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1, Active: true}, nil, nil),
+					m.driverRepoMock.EXPECT().Assign(gomock.Any(), int64(1), int64(1), "2023-01-02", []int64{1, 2}).Return(int64(2), nil),
+					m.driverRepoMock.EXPECT().ListStops(gomock.Any(), int64(1), int64(1), "2023-01-02").Return(testRouteStops(), nil, nil),
+				)
+			},
+			wantResp: testRouteResponse(),
+		},
+		{
+			name: "fail Assign (invalid date)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "02-01-2023", OrderIDs: []int64{1}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Assign (no order)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "2023-01-02"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name: "fail Assign (driver is inactive)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{1}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1}, nil, nil)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Assign (order not assignable)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{1, 3}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1, Active: true}, nil, nil),
+					m.driverRepoMock.EXPECT().Assign(gomock.Any(), int64(1), int64(1), "2023-01-02", []int64{1, 3}).
+						Return(int64(0), fmt.Errorf("oops! %w", repository.ErrOrderNotAssignable)),
+				)
+			},
+			wantErr: apperrors.ErrOrderNotAssignable,
+		},
+		{
+			name: "fail Assign (db error)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{1}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1, Active: true}, nil, nil),
+					m.driverRepoMock.EXPECT().Assign(gomock.Any(), int64(1), int64(1), "2023-01-02", []int64{1}).Return(int64(0), errDB),
+				)
+			},
+			wantErr: errDB,
+		},
+		{
+			name: "fail Assign (invalid/no token)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, req: model.AssignDeliveryRequest{Date: "2023-01-02", OrderIDs: []int64{1}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverRepoMock := repository.NewMockDriverRepository(ctrl)
+			routePlanner, err := NewRoutePlanner(RouteOption{})
+			assert.NoError(t, err)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{driverRepoMock: driverRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.driverRepo = driverRepoMock
+			tt.svc.routePlanner = routePlanner
+
+			gotResp, err := tt.svc.Assign(tt.args.ctx, tt.args.driverID, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_driverService_Route(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		driverID int64
+		date     string
+	}
+	type mocks struct {
+		utMocks        utils.Mock
+		driverRepoMock *repository.MockDriverRepository
+	}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		svc          *driverService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.GetDeliveryRouteResponse
+		wantErr      error
+	}{
+		{
+			name: "success Route",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, date: "2023-01-02"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1}, nil, nil),
+					m.driverRepoMock.EXPECT().ListStops(gomock.Any(), int64(1), int64(1), "2023-01-02").Return(testRouteStops(), nil, nil),
+				)
+			},
+			wantResp: testRouteResponse(),
+		},
+		{
+			name: "success Route (no stop)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, date: "2023-01-02"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1}, nil, nil),
+					m.driverRepoMock.EXPECT().ListStops(gomock.Any(), int64(1), int64(1), "2023-01-02").Return(nil, errors.New("oops! no row"), nil),
+				)
+			},
+			wantResp: &model.GetDeliveryRouteResponse{DriverID: 1, Date: "2023-01-02", Stops: []*model.GetDeliveryStopResponse{}},
+		},
+		{
+			name: "fail Route (invalid date)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, date: "tomorrow"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Route (driver not found)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, date: "2023-01-02"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail Route (db error)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, date: "2023-01-02"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Driver{ID: 1}, nil, nil),
+					m.driverRepoMock.EXPECT().ListStops(gomock.Any(), int64(1), int64(1), "2023-01-02").Return(nil, nil, errDB),
+				)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverRepoMock := repository.NewMockDriverRepository(ctrl)
+			routePlanner, err := NewRoutePlanner(RouteOption{})
+			assert.NoError(t, err)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{driverRepoMock: driverRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.driverRepo = driverRepoMock
+			tt.svc.routePlanner = routePlanner
+
+			gotResp, err := tt.svc.Route(tt.args.ctx, tt.args.driverID, tt.args.date)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_driverService_MarkDelivered(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		driverID int64
+		orderID  int64
+	}
+	type mocks struct {
+		utMocks        utils.Mock
+		driverRepoMock *repository.MockDriverRepository
+	}
+	validContext := func(_ context.Context, key string) interface{} {
+		if key == consts.CtxKeySession {
+			return &model.AuthSessionResponse{OwnerID: 1, Valid: true}
+		}
+		return "access-token"
+	}
+	stop := func(status int) *model.DeliveryStop {
+		return &model.DeliveryStop{OrderID: 1, DriverID: 1, DeliveryDate: "2023-01-02", CustomerEmail: "budi@example.com",
+			Address: "Jl. Kenanga 5", DeliveryStart: "10:00", DeliveryEnd: "12:00", Status: status}
+	}
+	tests := []struct {
+		name         string
+		svc          *driverService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.GetDeliveryStopResponse
+		wantErr      error
+	}{
+		{
+			name: "success MarkDelivered",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetStop(gomock.Any(), int64(1), int64(1), int64(1)).Return(stop(consts.StatusOutForDelivery), nil, nil),
+					m.driverRepoMock.EXPECT().MarkDelivered(gomock.Any(), int64(1), int64(1), int64(1), consts.StatusOutForDelivery, int64(1)).
+						Return("2023-01-02T10:45:00Z", nil, nil),
+				)
+			},
+			wantResp: &model.GetDeliveryStopResponse{OrderID: 1, CustomerEmail: "budi@example.com", Slot: "10:00-12:00",
+				Address: &model.DeliveryAddressResponse{Address: "Jl. Kenanga 5"}, Status: "DELIVERED", DeliveredAt: "2023-01-02T10:45:00Z"},
+		},
+		{
+			name: "fail MarkDelivered (stop not found)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.driverRepoMock.EXPECT().GetStop(gomock.Any(), int64(1), int64(1), int64(1)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail MarkDelivered (order not ready)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.driverRepoMock.EXPECT().GetStop(gomock.Any(), int64(1), int64(1), int64(1)).Return(stop(consts.StatusPreparing), nil, nil)
+			},
+			wantErr: apperrors.ErrOrderStatusTransition,
+		},
+		{
+			name: "fail MarkDelivered (delivered already)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.driverRepoMock.EXPECT().GetStop(gomock.Any(), int64(1), int64(1), int64(1)).Return(stop(consts.StatusDelivered), nil, nil)
+			},
+			wantErr: apperrors.ErrOrderStatusTransition,
+		},
+		{
+			name: "fail MarkDelivered (order status changed concurrently)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.driverRepoMock.EXPECT().GetStop(gomock.Any(), int64(1), int64(1), int64(1)).Return(stop(consts.StatusReady), nil, nil),
+					m.driverRepoMock.EXPECT().MarkDelivered(gomock.Any(), int64(1), int64(1), int64(1), consts.StatusReady, int64(1)).
+						Return("", errors.New("oops! no row"), nil),
+				)
+			},
+			wantErr: apperrors.ErrOrderStatusTransition,
+		},
+		{
+			name: "fail MarkDelivered (invalid session)",
+			svc:  &driverService{},
+			args: args{ctx: context.Background(), driverID: 1, orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(_ context.Context, key string) interface{} {
+					if key == consts.CtxKeySession {
+						return &model.AuthSessionResponse{Valid: false}
+					}
+					return "access-token"
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			driverRepoMock := repository.NewMockDriverRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{driverRepoMock: driverRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.driverRepo = driverRepoMock
+
+			gotResp, err := tt.svc.MarkDelivered(tt.args.ctx, tt.args.driverID, tt.args.orderID)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package service
 
-import "family-catering/internal/model"
+import (
+	"database/sql"
+	"family-catering/internal/model"
+	"family-catering/pkg/money"
+	"math"
+)
 
 func newOwnerResponse(owner *model.Owner) *model.GetOwnerResponse {
 	res := &model.GetOwnerResponse{
@@ -8,6 +13,7 @@ func newOwnerResponse(owner *model.Owner) *model.GetOwnerResponse {
 		Name:        owner.Name,
 		Email:       owner.Email,
 		PhoneNumber: owner.PhoneNumber,
+		Role:        owner.Role,
 	}
 
 	if owner.DateOfBirth.Valid {
@@ -65,8 +71,343 @@ func newMenusResponse(menus []*model.Menu) []*model.GetMenuResponse {
 
 	for _, menu := range menus {
 		res := newMenuResponse(menu)
+		res.Availability = newMenuAvailabilityResponse(menu)
 		ress = append(ress, res)
 	}
 
 	return ress
 }
+
+// newMenuAvailabilityResponse return the portions of the menu could still be ordered today,
+// the remaining is the lower of the stock and what is left of the daily capacity
+func newMenuAvailabilityResponse(menu *model.Menu) *model.MenuAvailabilityResponse {
+	res := &model.MenuAvailabilityResponse{
+		Stock:         menu.Stock,
+		DailyCapacity: menu.DailyCapacity,
+		OrderedToday:  menu.OrderedToday,
+	}
+
+	if menu.Stock != nil {
+		remaining := *menu.Stock
+		res.Remaining = &remaining
+	}
+
+	if menu.DailyCapacity != nil {
+		left := *menu.DailyCapacity - menu.OrderedToday
+		if left < 0 {
+			left = 0
+		}
+		if res.Remaining == nil || left < *res.Remaining {
+			res.Remaining = &left
+		}
+	}
+
+	res.SoldOut = res.Remaining != nil && *res.Remaining == 0
+
+	return res
+}
+
+// order
+func newOrderDetailResponse(orders []*model.Order) *model.OrderDetailResponse {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	// order-level fields are the same for every row of an order
+	destination := model.DeliveryAddress{
+		Address:    orders[0].DeliveryAddress,
+		PostalCode: orders[0].DeliveryPostalCode,
+		Latitude:   orders[0].DeliveryLatitude,
+		Longitude:  orders[0].DeliveryLongitude,
+	}
+	res := &model.OrderDetailResponse{
+		OrderID:       orders[0].OrderID,
+		CustomerID:    orders[0].CustomerID,
+		CustomerEmail: orders[0].CustomerEmail,
+		Status:        orderStatusName(orders[0].Status),
+		Items:         make([]*model.OrderItemResponse, 0, len(orders)),
+		Delivery:      newOrderDeliveryResponse(orders[0].DeliveryDate, orders[0].DeliveryStart, orders[0].DeliveryEnd, destination, orders[0].DeliveryZoneID.Int64),
+		Notes:         orders[0].Notes,
+		CreatedAt:     orders[0].CreatedAt,
+		UpdatedAt:     orders[0].UpdatedAt,
+	}
+
+	for _, order := range orders {
+		subTotal := order.Price.Mul(int64(order.Qty))
+		res.Items = append(res.Items, &model.OrderItemResponse{
+			BaseOrderID: order.BaseOrderID,
+			MenuID:      order.MenuID,
+			MenuName:    order.MenuName,
+			Price:       order.Price,
+			Qty:         order.Qty,
+			SubTotal:    subTotal,
+		})
+		res.TotalPrice = res.TotalPrice.Add(subTotal)
+		if order.UpdatedAt > res.UpdatedAt {
+			res.UpdatedAt = order.UpdatedAt
+		}
+	}
+
+	// the discount and the charges are order-level
+	subTotal := res.TotalPrice
+	discount := money.FromMinor(0)
+	if orders[0].PromoCode != "" {
+		discount = orders[0].Discount
+		res.Discount = &model.OrderDiscountResponse{PromoCode: orders[0].PromoCode, Amount: discount}
+	}
+
+	grandTotal := subTotal.Sub(discount).Add(orders[0].ServiceCharge).Add(orders[0].DeliveryFee)
+	if !orders[0].TaxInclusive {
+		grandTotal = grandTotal.Add(orders[0].Tax)
+	}
+
+	res.Charge = &model.OrderChargeResponse{
+		SubTotal:      subTotal,
+		Discount:      discount,
+		ServiceCharge: orders[0].ServiceCharge,
+		Tax:           orders[0].Tax,
+		TaxInclusive:  orders[0].TaxInclusive,
+		DeliveryFee:   orders[0].DeliveryFee,
+		GrandTotal:    grandTotal,
+	}
+	res.TotalPrice = grandTotal
+
+	return res
+}
+
+func newOrderDeliveryResponse(date, start, end string, destination model.DeliveryAddress, zoneID int64) *model.OrderDeliveryResponse {
+	if date == "" {
+		return nil
+	}
+
+	return &model.OrderDeliveryResponse{
+		Date:    date,
+		Slot:    formatSlot(start, end),
+		Address: newDeliveryAddressResponse(destination),
+		ZoneID:  zoneID,
+	}
+}
+
+// newDeliveryAddressResponse return nil when there is no destination
+func newDeliveryAddressResponse(destination model.DeliveryAddress) *model.DeliveryAddressResponse {
+	if destination.Address == "" && destination.PostalCode == "" && !destination.Latitude.Valid {
+		return nil
+	}
+
+	return &model.DeliveryAddressResponse{
+		Address:    destination.Address,
+		PostalCode: destination.PostalCode,
+		Latitude:   float64Ptr(destination.Latitude),
+		Longitude:  float64Ptr(destination.Longitude),
+	}
+}
+
+func newOrderChargeResponse(charge *model.OrderCharge) *model.OrderChargeResponse {
+	return &model.OrderChargeResponse{
+		OrderID:       charge.OrderID,
+		SubTotal:      charge.SubTotal,
+		Discount:      charge.Discount,
+		ServiceCharge: charge.ServiceCharge,
+		Tax:           charge.Tax,
+		TaxInclusive:  charge.TaxInclusive,
+		DeliveryFee:   charge.DeliveryFee,
+		GrandTotal:    charge.GrandTotal,
+	}
+}
+
+// promotion
+func newPromotionResponse(promotion *model.Promotion) *model.GetPromotionResponse {
+	res := &model.GetPromotionResponse{
+		ID:                    promotion.ID,
+		Code:                  promotion.Code,
+		Description:           promotion.Description,
+		DiscountType:          promotion.DiscountType,
+		Percentage:            promotion.Percentage,
+		Amount:                promotion.Amount,
+		MaxDiscount:           promotion.MaxDiscount,
+		MinSpend:              promotion.MinSpend,
+		MenuIDs:               splitIDs(promotion.MenuIDs),
+		Categories:            promotion.Categories,
+		StartsAt:              promotion.StartsAt,
+		UsageLimit:            promotion.UsageLimit,
+		UsageLimitPerCustomer: promotion.UsageLimitPerCustomer,
+		Active:                promotion.Active,
+	}
+
+	if promotion.EndsAt.Valid {
+		res.EndsAt = promotion.EndsAt.String
+	}
+
+	return res
+}
+
+func newPromotionsResponse(promotions []*model.Promotion) []*model.GetPromotionResponse {
+	ress := make([]*model.GetPromotionResponse, 0, len(promotions))
+	for _, promotion := range promotions {
+		ress = append(ress, newPromotionResponse(promotion))
+	}
+
+	return ress
+}
+
+func newOrderStatusHistoriesResponse(histories []*model.OrderStatusHistory) []*model.OrderStatusHistoryResponse {
+	ress := make([]*model.OrderStatusHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		res := &model.OrderStatusHistoryResponse{
+			ToStatus:  orderStatusName(history.ToStatus),
+			CreatedAt: history.CreatedAt,
+		}
+		if history.FromStatus.Valid {
+			res.FromStatus = orderStatusName(int(history.FromStatus.Int32))
+		}
+		if history.ChangedBy.Valid {
+			res.ChangedBy = history.ChangedBy.Int64
+		}
+		ress = append(ress, res)
+	}
+
+	return ress
+}
+
+func hasOrderItem(orders []*model.Order, baseOrderID int64) bool {
+	for _, order := range orders {
+		if order.BaseOrderID == baseOrderID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newCustomerResponse(customer *model.Customer) *model.GetCustomerResponse {
+	res := &model.GetCustomerResponse{
+		ID:               customer.ID,
+		Name:             customer.Name,
+		Email:            customer.Email,
+		Phone:            customer.Phone,
+		DietaryNotes:     customer.DietaryNotes,
+		MarketingConsent: customer.MarketingConsent,
+		CreatedAt:        customer.CreatedAt,
+		UpdatedAt:        customer.UpdatedAt,
+	}
+
+	if customer.Addresses != nil {
+		res.Addresses = make([]*model.CustomerAddressResponse, 0, len(customer.Addresses))
+	}
+	for _, address := range customer.Addresses {
+		res.Addresses = append(res.Addresses, &model.CustomerAddressResponse{
+			ID:         address.ID,
+			Label:      address.Label,
+			Address:    address.Address,
+			PostalCode: address.PostalCode,
+			Latitude:   float64Ptr(address.Latitude),
+			Longitude:  float64Ptr(address.Longitude),
+			Notes:      address.Notes,
+			IsDefault:  address.IsDefault,
+		})
+	}
+
+	return res
+}
+
+// delivery zone
+func newDeliveryZoneResponse(zone *model.DeliveryZone) *model.GetDeliveryZoneResponse {
+	res := &model.GetDeliveryZoneResponse{
+		ID:          zone.ID,
+		Name:        zone.Name,
+		PostalCodes: splitPostalCodes(zone.PostalCodes),
+		Fee:         zone.Fee,
+		MinOrder:    zone.MinOrder,
+		Active:      zone.Active,
+		CreatedAt:   zone.CreatedAt,
+		UpdatedAt:   zone.UpdatedAt,
+	}
+
+	if zone.Polygon.Valid {
+		// the polygon has been validated before it's stored
+		res.Polygon, _ = parsePolygon(zone.Polygon.String)
+	}
+
+	return res
+}
+
+func newDeliveryZonesResponse(zones []*model.DeliveryZone) []*model.GetDeliveryZoneResponse {
+	ress := make([]*model.GetDeliveryZoneResponse, 0, len(zones))
+	for _, zone := range zones {
+		ress = append(ress, newDeliveryZoneResponse(zone))
+	}
+
+	return ress
+}
+
+func newDriverResponse(driver *model.Driver) *model.GetDriverResponse {
+	return &model.GetDriverResponse{
+		ID:        driver.ID,
+		Name:      driver.Name,
+		Phone:     driver.Phone,
+		Active:    driver.Active,
+		CreatedAt: driver.CreatedAt,
+		UpdatedAt: driver.UpdatedAt,
+	}
+}
+
+func newDriversResponse(drivers []*model.Driver) []*model.GetDriverResponse {
+	ress := make([]*model.GetDriverResponse, 0, len(drivers))
+	for _, driver := range drivers {
+		ress = append(ress, newDriverResponse(driver))
+	}
+
+	return ress
+}
+
+func newDeliveryStopResponse(stop *model.DeliveryStop) *model.GetDeliveryStopResponse {
+	return &model.GetDeliveryStopResponse{
+		OrderID:       stop.OrderID,
+		CustomerEmail: stop.CustomerEmail,
+		Slot:          formatSlot(stop.DeliveryStart, stop.DeliveryEnd),
+		Address: newDeliveryAddressResponse(model.DeliveryAddress{
+			Address:    stop.Address,
+			PostalCode: stop.PostalCode,
+			Latitude:   stop.Latitude,
+			Longitude:  stop.Longitude,
+		}),
+		Status:      orderStatusName(stop.Status),
+		DeliveredAt: stop.DeliveredAt.String,
+	}
+}
+
+// newDeliveryRouteResponse number the stops of the route from 1, distances are rounded to 10 m
+func newDeliveryRouteResponse(driverID int64, date string, route []*RouteStop) *model.GetDeliveryRouteResponse {
+	res := &model.GetDeliveryRouteResponse{DriverID: driverID, Date: date, Stops: make([]*model.GetDeliveryStopResponse, 0, len(route))}
+	for i, stop := range route {
+		stopRes := newDeliveryStopResponse(stop.DeliveryStop)
+		stopRes.Sequence = i + 1
+		stopRes.Distance = roundDistance(stop.Distance)
+		res.Stops = append(res.Stops, stopRes)
+		res.Distance += stop.Distance
+	}
+	res.Distance = roundDistance(res.Distance)
+
+	return res
+}
+
+func roundDistance(km float64) float64 {
+	return math.Round(km*100) / 100
+}
+
+func float64Ptr(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+
+	return &f.Float64
+}
+
+func newBusinessResponse(business *model.Business) *model.GetBusinessResponse {
+	return &model.GetBusinessResponse{
+		ID:        business.ID,
+		Name:      business.Name,
+		CreatedAt: business.CreatedAt,
+		UpdatedAt: business.UpdatedAt,
+	}
+}
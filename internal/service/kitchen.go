@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// uncategorized is the category of the menus without any category (or which have been deleted)
+const uncategorized = "Uncategorized"
+
+type KitchenService interface {
+	// Production return the production sheet of the orders delivered on the given date (YYYY-MM-DD)
+	Production(ctx context.Context, date string) (*model.ProductionSheetResponse, error)
+}
+
+type kitchenService struct {
+	orderRepo repository.OrderRepository
+}
+
+func NewKitchenService(orderRepo repository.OrderRepository) KitchenService {
+	return &kitchenService{orderRepo: orderRepo}
+}
+
+func (svc *kitchenService) Production(ctx context.Context, date string) (*model.ProductionSheetResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.kitchenService.Production: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.kitchenService.Production: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	_, err = time.Parse(deliveryDateLayout, date)
+	if err != nil {
+		err = fmt.Errorf("service.kitchenService.Production: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "date must be formatted as YYYY-MM-DD")
+	}
+
+	orders, err := svc.orderRepo.ListByDeliveryDate(ctx, claims.BusinessID, date)
+	if err != nil {
+		err = fmt.Errorf("service.kitchenService.Production: %w", err)
+		return nil, err
+	}
+
+	return newProductionSheetResponse(date, orders), nil
+}
+
+// newProductionSheetResponse sum the qty of the order's rows by menu and group the menus by their first category,
+// the rows of the same menu in an order are merged. Categories and menus are sorted by name, the orders of a menu
+// keep the repository's order (by delivery slot).
+func newProductionSheetResponse(date string, orders []*model.Order) *model.ProductionSheetResponse {
+	sheet := &model.ProductionSheetResponse{Date: date, Categories: make([]*model.ProductionCategoryResponse, 0)}
+
+	categories := make(map[string]*model.ProductionCategoryResponse)
+	menus := make(map[int64]*model.ProductionMenuResponse)
+	menuOrders := make(map[int64]map[int64]*model.ProductionOrderResponse) // by menu's id then order's id
+	orderIDs := make(map[int64]struct{})
+	for _, order := range orders {
+		category := firstCategory(order.MenuCategories)
+		categoryRes, ok := categories[category]
+		if !ok {
+			categoryRes = &model.ProductionCategoryResponse{Category: category, Menus: make([]*model.ProductionMenuResponse, 0)}
+			categories[category] = categoryRes
+			sheet.Categories = append(sheet.Categories, categoryRes)
+		}
+
+		menuRes, ok := menus[order.MenuID]
+		if !ok {
+			menuRes = &model.ProductionMenuResponse{MenuID: order.MenuID, MenuName: order.MenuName, Orders: make([]*model.ProductionOrderResponse, 0)}
+			menus[order.MenuID] = menuRes
+			menuOrders[order.MenuID] = make(map[int64]*model.ProductionOrderResponse)
+			categoryRes.Menus = append(categoryRes.Menus, menuRes)
+		}
+
+		orderRes, ok := menuOrders[order.MenuID][order.OrderID]
+		if !ok {
+			orderRes = &model.ProductionOrderResponse{
+				OrderID:       order.OrderID,
+				CustomerEmail: order.CustomerEmail,
+				DeliverySlot:  formatSlot(order.DeliveryStart, order.DeliveryEnd),
+				Status:        orderStatusName(order.Status),
+				Notes:         order.Notes,
+			}
+			menuOrders[order.MenuID][order.OrderID] = orderRes
+			menuRes.Orders = append(menuRes.Orders, orderRes)
+		}
+
+		orderRes.Qty += order.Qty
+		menuRes.TotalQty += order.Qty
+		categoryRes.TotalQty += order.Qty
+		sheet.TotalQty += order.Qty
+		orderIDs[order.OrderID] = struct{}{}
+	}
+	sheet.TotalOrders = len(orderIDs)
+
+	sort.Slice(sheet.Categories, func(i, j int) bool {
+		// uncategorized menus are listed last
+		if (sheet.Categories[i].Category == uncategorized) != (sheet.Categories[j].Category == uncategorized) {
+			return sheet.Categories[j].Category == uncategorized
+		}
+		return sheet.Categories[i].Category < sheet.Categories[j].Category
+	})
+	for _, category := range sheet.Categories {
+		sort.SliceStable(category.Menus, func(i, j int) bool { return category.Menus[i].MenuName < category.Menus[j].MenuName })
+	}
+
+	return sheet
+}
+
+// firstCategory return the first of the comma separated categories of a menu
+func firstCategory(categories string) string {
+	category := strings.TrimSpace(strings.Split(categories, ",")[0])
+	if category == "" {
+		return uncategorized
+	}
+
+	return category
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\kitchen.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKitchenService is a mock of KitchenService interface.
+type MockKitchenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockKitchenServiceMockRecorder
+}
+
+// MockKitchenServiceMockRecorder is the mock recorder for MockKitchenService.
+type MockKitchenServiceMockRecorder struct {
+	mock *MockKitchenService
+}
+
+// NewMockKitchenService creates a new mock instance.
+func NewMockKitchenService(ctrl *gomock.Controller) *MockKitchenService {
+	mock := &MockKitchenService{ctrl: ctrl}
+	mock.recorder = &MockKitchenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKitchenService) EXPECT() *MockKitchenServiceMockRecorder {
+	return m.recorder
+}
+
+// Production mocks base method.
+func (m *MockKitchenService) Production(ctx context.Context, date string) (*model.ProductionSheetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Production", ctx, date)
+	ret0, _ := ret[0].(*model.ProductionSheetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Production indicates an expected call of Production.
+func (mr *MockKitchenServiceMockRecorder) Production(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Production", reflect.TypeOf((*MockKitchenService)(nil).Production), ctx, date)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKitchenService(t *testing.T) {
+	type args struct {
+		orderRepo repository.OrderRepository
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewKitchenService"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewKitchenService(tt.args.orderRepo))
+		})
+	}
+}
+
+func Test_kitchenService_Production(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		date string
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	orders := []*model.Order{
+		{OrderID: 1, CustomerEmail: "test@example.com", MenuID: 2, MenuName: "sate", MenuCategories: "Indonesian food, Spicy", Qty: 2, Status: 2,
+			DeliveryDate: "2022-11-11", DeliveryStart: "07:00", DeliveryEnd: "09:00", Notes: "no peanut"},
+		{OrderID: 1, CustomerEmail: "test@example.com", MenuID: 3, MenuName: "es teh", MenuCategories: "Drink", Qty: 2, Status: 2,
+			DeliveryDate: "2022-11-11", DeliveryStart: "07:00", DeliveryEnd: "09:00", Notes: "no peanut"},
+		{OrderID: 2, CustomerEmail: "test2@example.com", MenuID: 2, MenuName: "sate", MenuCategories: "Indonesian food, Spicy", Qty: 3, Status: 5,
+			DeliveryDate: "2022-11-11", DeliveryStart: "11:00", DeliveryEnd: "13:00"},
+		{OrderID: 2, CustomerEmail: "test2@example.com", MenuID: 2, MenuName: "sate", MenuCategories: "Indonesian food, Spicy", Qty: 1, Status: 5,
+			DeliveryDate: "2022-11-11", DeliveryStart: "11:00", DeliveryEnd: "13:00"},
+		{OrderID: 3, CustomerEmail: "test3@example.com", MenuID: 1, MenuName: "nasi goreng", MenuCategories: "Indonesian food", Qty: 1, Status: 1,
+			DeliveryDate: "2022-11-11"},
+		{OrderID: 3, CustomerEmail: "test3@example.com", MenuID: 9, MenuName: "deleted menu", Qty: 1, Status: 1,
+			DeliveryDate: "2022-11-11"},
+	}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		svc          *kitchenService
+		args         args
+		prepareMocks func(*mocks)
+		wantSheet    *model.ProductionSheetResponse
+		wantErr      error
+	}{
+		{
+			name: "success Production",
+			svc:  &kitchenService{},
+			args: args{ctx: context.Background(), date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().ListByDeliveryDate(gomock.Any(), int64(1), "2022-11-11").Return(orders, nil)
+			},
+			wantSheet: &model.ProductionSheetResponse{
+				Date: "2022-11-11", TotalOrders: 3, TotalQty: 10,
+				Categories: []*model.ProductionCategoryResponse{
+					{Category: "Drink", TotalQty: 2, Menus: []*model.ProductionMenuResponse{
+						{MenuID: 3, MenuName: "es teh", TotalQty: 2, Orders: []*model.ProductionOrderResponse{
+							{OrderID: 1, CustomerEmail: "test@example.com", Qty: 2, DeliverySlot: "07:00-09:00", Status: "PAID", Notes: "no peanut"},
+						}},
+					}},
+					{Category: "Indonesian food", TotalQty: 7, Menus: []*model.ProductionMenuResponse{
+						{MenuID: 1, MenuName: "nasi goreng", TotalQty: 1, Orders: []*model.ProductionOrderResponse{
+							{OrderID: 3, CustomerEmail: "test3@example.com", Qty: 1, Status: "NEW"},
+						}},
+						{MenuID: 2, MenuName: "sate", TotalQty: 6, Orders: []*model.ProductionOrderResponse{
+							{OrderID: 1, CustomerEmail: "test@example.com", Qty: 2, DeliverySlot: "07:00-09:00", Status: "PAID", Notes: "no peanut"},
+							{OrderID: 2, CustomerEmail: "test2@example.com", Qty: 4, DeliverySlot: "11:00-13:00", Status: "PREPARING"},
+						}},
+					}},
+					{Category: "Uncategorized", TotalQty: 1, Menus: []*model.ProductionMenuResponse{
+						{MenuID: 9, MenuName: "deleted menu", TotalQty: 1, Orders: []*model.ProductionOrderResponse{
+							{OrderID: 3, CustomerEmail: "test3@example.com", Qty: 1, Status: "NEW"},
+						}},
+					}},
+				},
+			},
+		},
+		{
+			name: "success Production (no order)",
+			svc:  &kitchenService{},
+			args: args{ctx: context.Background(), date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().ListByDeliveryDate(gomock.Any(), int64(1), "2022-11-11").Return([]*model.Order{}, nil)
+			},
+			wantSheet: &model.ProductionSheetResponse{Date: "2022-11-11", Categories: []*model.ProductionCategoryResponse{}},
+		},
+		{
+			name: "fail Production (invalid date)",
+			svc:  &kitchenService{},
+			args: args{ctx: context.Background(), date: "11-11-2022"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Production (invalid token)",
+			svc:  &kitchenService{},
+			args: args{ctx: context.Background(), date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail Production (db error)",
+			svc:  &kitchenService{},
+			args: args{ctx: context.Background(), date: "2022-11-11"},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().ListByDeliveryDate(gomock.Any(), int64(1), "2022-11-11").Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+
+			gotSheet, err := tt.svc.Production(tt.args.ctx, tt.args.date)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantSheet, gotSheet)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
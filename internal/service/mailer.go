@@ -14,6 +14,10 @@ import (
 type Mailer interface {
 	SendEMailForgotPassword(to []string, cc, subject, name, authlink string) error
 	SendEmailNotifyLogin(to []string, cc, subject, name string) error
+	// SendEmailVerification send the link verifying the email to
+	SendEmailVerification(to []string, cc, subject, name, link string) error
+	// SendEmailChanged notify the previous email of an owner its email changed to newEmail
+	SendEmailChanged(to []string, cc, subject, name, newEmail string) error
 }
 
 type mailer struct {
@@ -27,6 +31,8 @@ type mailer struct {
 	port                       int
 	address                    string
 	temp                       *template.Template
+	tempVerifyEmail            *template.Template
+	tempEmailChanged           *template.Template
 }
 
 func NewMailer(opts MailerOption) Mailer {
@@ -43,6 +49,8 @@ func NewMailer(opts MailerOption) Mailer {
 	path := filepath.Join(config.Path(), m.templateForgotPasswordName)
 
 	m.temp = template.Must(template.ParseFiles(path))
+	m.tempVerifyEmail = template.Must(template.ParseFiles(filepath.Join(config.Path(), opts.TemplateVerifyEmailName)))
+	m.tempEmailChanged = template.Must(template.ParseFiles(filepath.Join(config.Path(), opts.TemplateEmailChangedName)))
 	m.address = fmt.Sprintf("%s:%d", m.host, m.port)
 	// ? does necessary to use tls.Config to give option skipVerify through config?
 
@@ -56,6 +64,8 @@ type MailerOption struct {
 	SupportEmail               string
 	AppName                    string
 	TemplateForgotPasswordName string
+	TemplateVerifyEmailName    string
+	TemplateEmailChangedName   string
 	Identity                   string
 	Port                       int
 }
@@ -71,6 +81,17 @@ type forgotPasswordEmailTemplate struct {
 	SupportEmail string
 }
 
+type emailChangedEmailTemplate struct {
+	AppName      string
+	Sender       string
+	To           string
+	Cc           string
+	Subject      string
+	ToName       string
+	NewEmail     string
+	SupportEmail string
+}
+
 func (m *mailer) SendEMailForgotPassword(to []string, cc, subject, name, link string) error {
 	tos := strings.Join(to, ",")
 	templateBody := forgotPasswordEmailTemplate{
@@ -96,6 +117,54 @@ func (m *mailer) SendEMailForgotPassword(to []string, cc, subject, name, link st
 	return err
 }
 
+func (m *mailer) SendEmailVerification(to []string, cc, subject, name, link string) error {
+	templateBody := forgotPasswordEmailTemplate{
+		SupportEmail: m.supportEmail,
+		AppName:      m.appName,
+		Sender:       m.email,
+		To:           strings.Join(to, ","),
+		Cc:           cc,
+		ToName:       name,
+		Subject:      subject,
+		Link:         link,
+	}
+
+	body := bytes.Buffer{}
+	m.tempVerifyEmail.Execute(&body, templateBody)
+	emailAuth := smtp.CRAMMD5Auth(m.email, m.password)
+	err := smtp.SendMail(m.address, emailAuth, fmt.Sprintf("<%s>", m.email), to, body.Bytes())
+	if err != nil {
+		err = fmt.Errorf("service.mailer.SendEmailVerification: %w", err)
+		logger.Error(err, "error sending email for email verification")
+	}
+
+	return err
+}
+
+func (m *mailer) SendEmailChanged(to []string, cc, subject, name, newEmail string) error {
+	templateBody := emailChangedEmailTemplate{
+		SupportEmail: m.supportEmail,
+		AppName:      m.appName,
+		Sender:       m.email,
+		To:           strings.Join(to, ","),
+		Cc:           cc,
+		ToName:       name,
+		Subject:      subject,
+		NewEmail:     newEmail,
+	}
+
+	body := bytes.Buffer{}
+	m.tempEmailChanged.Execute(&body, templateBody)
+	emailAuth := smtp.CRAMMD5Auth(m.email, m.password)
+	err := smtp.SendMail(m.address, emailAuth, fmt.Sprintf("<%s>", m.email), to, body.Bytes())
+	if err != nil {
+		err = fmt.Errorf("service.mailer.SendEmailChanged: %w", err)
+		logger.Error(err, "error sending email for email changed")
+	}
+
+	return err
+}
+
 func (m *mailer) SendEmailNotifyLogin(to []string, cc, subject, name string) error {
 	panic("not yet implemented")
 }
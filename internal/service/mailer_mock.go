@@ -47,6 +47,20 @@ func (mr *MockMailerMockRecorder) SendEMailForgotPassword(to, cc, subject, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEMailForgotPassword", reflect.TypeOf((*MockMailer)(nil).SendEMailForgotPassword), to, cc, subject, name, authlink)
 }
 
+// SendEmailChanged mocks base method.
+func (m *MockMailer) SendEmailChanged(to []string, cc, subject, name, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChanged", to, cc, subject, name, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChanged indicates an expected call of SendEmailChanged.
+func (mr *MockMailerMockRecorder) SendEmailChanged(to, cc, subject, name, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChanged", reflect.TypeOf((*MockMailer)(nil).SendEmailChanged), to, cc, subject, name, newEmail)
+}
+
 // SendEmailNotifyLogin mocks base method.
 func (m *MockMailer) SendEmailNotifyLogin(to []string, cc, subject, name string) error {
 	m.ctrl.T.Helper()
@@ -60,3 +74,17 @@ func (mr *MockMailerMockRecorder) SendEmailNotifyLogin(to, cc, subject, name int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailNotifyLogin", reflect.TypeOf((*MockMailer)(nil).SendEmailNotifyLogin), to, cc, subject, name)
 }
+
+// SendEmailVerification mocks base method.
+func (m *MockMailer) SendEmailVerification(to []string, cc, subject, name, link string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailVerification", to, cc, subject, name, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailVerification indicates an expected call of SendEmailVerification.
+func (mr *MockMailerMockRecorder) SendEmailVerification(to, cc, subject, name, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailVerification", reflect.TypeOf((*MockMailer)(nil).SendEmailVerification), to, cc, subject, name, link)
+}
@@ -18,6 +18,7 @@ type MenuService interface {
 	Create(ctx context.Context, req model.CreateMenuRequest) (*model.CreateMenuResponse, error)
 	Update(ctx context.Context, id int64, req model.UpdateMenuRequest) (*model.UpdateMenuResponse, error)
 	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+	UpdateAvailability(ctx context.Context, id int64, req model.UpdateMenuAvailabilityRequest) (*model.GetMenuResponse, error)
 }
 
 type menuService struct {
@@ -35,13 +36,13 @@ func (svc *menuService) GetByID(ctx context.Context, id int64) (*model.GetMenuRe
 		err := fmt.Errorf("service.menuService.GetByID: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err := fmt.Errorf("service.menuService.GetByID: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	menu, errNoRow, err := svc.menuRepo.GetByID(ctx, id)
+	menu, errNoRow, err := svc.menuRepo.GetByID(ctx, claims.BusinessID, id)
 	if errNoRow != nil {
 		errNoRow := fmt.Errorf("service.menuService.GetByID: %w", errNoRow)
 		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
@@ -52,7 +53,10 @@ func (svc *menuService) GetByID(ctx context.Context, id int64) (*model.GetMenuRe
 		return nil, err
 	}
 
-	return newMenuResponse(menu), nil
+	resp := newMenuResponse(menu)
+	resp.Availability = newMenuAvailabilityResponse(menu)
+
+	return resp, nil
 }
 
 func (svc *menuService) GetByName(ctx context.Context, name string) (*model.GetMenuResponse, error) {
@@ -62,13 +66,13 @@ func (svc *menuService) GetByName(ctx context.Context, name string) (*model.GetM
 		err := fmt.Errorf("service.menuService.GetByName: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err := fmt.Errorf("service.menuService.GetByName: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	menu, errNoRow, err := svc.menuRepo.GetByName(ctx, name)
+	menu, errNoRow, err := svc.menuRepo.GetByName(ctx, claims.BusinessID, name)
 	if errNoRow != nil {
 		errNoRow := fmt.Errorf("service.menuService.GetByName: %w", errNoRow)
 		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
@@ -79,7 +83,10 @@ func (svc *menuService) GetByName(ctx context.Context, name string) (*model.GetM
 		return nil, err
 	}
 
-	return newMenuResponse(menu), nil
+	resp := newMenuResponse(menu)
+	resp.Availability = newMenuAvailabilityResponse(menu)
+
+	return resp, nil
 }
 
 func (svc *menuService) List(ctx context.Context, limit, offset int) ([]*model.GetMenuResponse, error) {
@@ -89,13 +96,13 @@ func (svc *menuService) List(ctx context.Context, limit, offset int) ([]*model.G
 		err := fmt.Errorf("service.menuService.List: invalid token type want string, got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err := fmt.Errorf("service.menuService.List: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	menus, errNoRow, err := svc.menuRepo.List(ctx, limit, offset)
+	menus, errNoRow, err := svc.menuRepo.List(ctx, claims.BusinessID, limit, offset)
 
 	if errNoRow != nil && err == nil {
 		return []*model.GetMenuResponse{}, nil
@@ -116,7 +123,7 @@ func (svc *menuService) Create(ctx context.Context, req model.CreateMenuRequest)
 		err := fmt.Errorf("service.menuService.Create: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err := fmt.Errorf("service.menuService.Create: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
@@ -138,7 +145,7 @@ func (svc *menuService) Create(ctx context.Context, req model.CreateMenuRequest)
 		Categories: req.Categories,
 	}
 
-	id, err := svc.menuRepo.Create(ctx, menu)
+	id, err := svc.menuRepo.Create(ctx, claims.BusinessID, menu)
 	if err != nil {
 		err = fmt.Errorf("service.menuService.Create: %w", err)
 		return nil, err
@@ -155,7 +162,7 @@ func (svc *menuService) Update(ctx context.Context, id int64, req model.UpdateMe
 		err := fmt.Errorf("service.menuService.Update: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err := fmt.Errorf("service.menuService.Update: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
@@ -179,7 +186,7 @@ func (svc *menuService) Update(ctx context.Context, id int64, req model.UpdateMe
 		Categories: req.Categories,
 	}
 
-	_, errNoRow, err := svc.menuRepo.Update(ctx, menu)
+	_, errNoRow, err := svc.menuRepo.Update(ctx, claims.BusinessID, menu)
 	if errNoRow != nil {
 		err := fmt.Errorf("service.menuService.Update: %w", errNoRow)
 		return nil, err
@@ -200,13 +207,13 @@ func (svc *menuService) Delete(ctx context.Context, id int64) (nAffected int64,
 		err := fmt.Errorf("service.menuService.Delete: invalid auth token type want string got %T", token)
 		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err = utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err = fmt.Errorf("service.menuService.Delete: %w", err)
 		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	nAffected, errNoRow, err := svc.menuRepo.Delete(ctx, id)
+	nAffected, errNoRow, err := svc.menuRepo.Delete(ctx, claims.BusinessID, id)
 	if errNoRow != nil && nAffected <= 0 {
 		err = fmt.Errorf("service.menuService.Delete: %w", err)
 		return 0, apperrors.WrapError(err, apperrors.ErrNotFound, "")
@@ -218,3 +225,52 @@ func (svc *menuService) Delete(ctx context.Context, id int64) (nAffected int64,
 
 	return nAffected, nil
 }
+
+// UpdateAvailability replace the stock and the daily capacity of the menu and return the menu with its availability
+func (svc *menuService) UpdateAvailability(ctx context.Context, id int64, req model.UpdateMenuAvailabilityRequest) (*model.GetMenuResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.menuService.UpdateAvailability: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.menuService.UpdateAvailability: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	// request validation
+	err = utils.ValidateRequest(&req)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.menuService.UpdateAvailability: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	_, errNoRow, err := svc.menuRepo.UpdateAvailability(ctx, claims.BusinessID, id, req.Stock, req.DailyCapacity)
+	if errNoRow != nil {
+		errNoRow := fmt.Errorf("service.menuService.UpdateAvailability: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.menuService.UpdateAvailability: %w", err)
+		return nil, err
+	}
+
+	menu, errNoRow, err := svc.menuRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow := fmt.Errorf("service.menuService.UpdateAvailability: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.menuService.UpdateAvailability: %w", err)
+		return nil, err
+	}
+
+	resp := newMenuResponse(menu)
+	resp.Availability = newMenuAvailabilityResponse(menu)
+
+	return resp, nil
+}
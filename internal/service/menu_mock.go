@@ -124,3 +124,18 @@ func (mr *MockMenuServiceMockRecorder) Update(ctx, id, req interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMenuService)(nil).Update), ctx, id, req)
 }
+
+// UpdateAvailability mocks base method.
+func (m *MockMenuService) UpdateAvailability(ctx context.Context, id int64, req model.UpdateMenuAvailabilityRequest) (*model.GetMenuResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvailability", ctx, id, req)
+	ret0, _ := ret[0].(*model.GetMenuResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAvailability indicates an expected call of UpdateAvailability.
+func (mr *MockMenuServiceMockRecorder) UpdateAvailability(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvailability", reflect.TypeOf((*MockMenuService)(nil).UpdateAvailability), ctx, id, req)
+}
@@ -6,6 +6,7 @@ import (
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
 	"family-catering/pkg/consts"
+	"family-catering/pkg/money"
 	"family-catering/pkg/utils"
 	"testing"
 
@@ -13,6 +14,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func intPtr(i int) *int {
+	return &i
+}
+
 func TestNewMenuService(t *testing.T) {
 	type args struct {
 		menuRepo repository.MenuRepository
@@ -61,16 +66,39 @@ func Test_menuService_GetByID(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Menu{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"}, nil, nil)
+				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Menu{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food",
+					Stock: intPtr(10), DailyCapacity: intPtr(20), OrderedToday: 15}, nil, nil)
 			},
 			want: &model.GetMenuResponse{
 				ID:         1,
 				Name:       "sate",
-				Price:      25_000,
+				Price:      money.MustParse("25000"),
 				Categories: "Indonesian food",
+				Availability: &model.MenuAvailabilityResponse{
+					Stock: intPtr(10), DailyCapacity: intPtr(20), OrderedToday: 15, Remaining: intPtr(5), // what is left of the daily capacity
+				},
+			},
+		},
+		{
+			name: "fail GetByID (menu of another business)",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  1,
 			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 2}, nil
+				})
+				// the menu belongs to business 1, it's not found for business 2
+				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(2), int64(1)).Return(nil, errors.New("oops! db error no row"), nil)
+			},
+			wantErr: true,
 		},
 		{
 			name: "fail GetByID (invalid token)",
@@ -101,9 +129,9 @@ func Test_menuService_GetByID(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errors.New("oops! db error no row"), nil)
+				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(nil, errors.New("oops! db error no row"), nil)
 			},
 			wantErr: true,
 		},
@@ -119,9 +147,9 @@ func Test_menuService_GetByID(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil, errors.New("oops! db error"))
+				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(nil, nil, errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -176,15 +204,18 @@ func Test_menuService_GetByName(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().GetByName(gomock.Any(), "soto betawi").Return(&model.Menu{ID: 6, Name: "soto betawi", Price: 30_000, Categories: "Indonesian food"}, nil, nil)
+				m.menuRepoMock.EXPECT().GetByName(gomock.Any(), int64(1), "soto betawi").Return(&model.Menu{ID: 6, Name: "soto betawi", Price: money.MustParse("30000"), Categories: "Indonesian food"}, nil, nil)
 			},
 			want: &model.GetMenuResponse{
 				ID:         6,
 				Name:       "soto betawi",
-				Price:      30_000,
+				Price:      money.MustParse("30000"),
 				Categories: "Indonesian food",
+				Availability: &model.MenuAvailabilityResponse{
+					OrderedToday: 0, // unlimited
+				},
 			},
 		},
 		{
@@ -217,9 +248,9 @@ func Test_menuService_GetByName(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().GetByName(gomock.Any(), "not-exists-food-name").Return(nil, errors.New("oops! error no rows"), nil)
+				m.menuRepoMock.EXPECT().GetByName(gomock.Any(), int64(1), "not-exists-food-name").Return(nil, errors.New("oops! error no rows"), nil)
 			},
 			wantErr: true,
 		},
@@ -235,9 +266,9 @@ func Test_menuService_GetByName(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().GetByName(gomock.Any(), "soto betawi").Return(nil, nil, errors.New("oops! db error"))
+				m.menuRepoMock.EXPECT().GetByName(gomock.Any(), int64(1), "soto betawi").Return(nil, nil, errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -294,15 +325,17 @@ func Test_menuService_List(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().List(gomock.Any(), 2, 1).Return([]*model.Menu{
-					{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"},
-					{ID: 1, Name: "kerang saus tiram", Price: 44_000, Categories: "Indonesian food"}}, nil, nil)
+				m.menuRepoMock.EXPECT().List(gomock.Any(), int64(1), 2, 1).Return([]*model.Menu{
+					{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food", Stock: intPtr(0), OrderedToday: 8},
+					{ID: 1, Name: "kerang saus tiram", Price: money.MustParse("44000"), Categories: "Indonesian food", DailyCapacity: intPtr(5), OrderedToday: 7}}, nil, nil)
 			},
 			want: []*model.GetMenuResponse{
-				{ID: 1, Name: "sate", Price: 25_000, Categories: "Indonesian food"},
-				{ID: 1, Name: "kerang saus tiram", Price: 44_000, Categories: "Indonesian food"}},
+				{ID: 1, Name: "sate", Price: money.MustParse("25000"), Categories: "Indonesian food",
+					Availability: &model.MenuAvailabilityResponse{Stock: intPtr(0), OrderedToday: 8, Remaining: intPtr(0), SoldOut: true}},
+				{ID: 1, Name: "kerang saus tiram", Price: money.MustParse("44000"), Categories: "Indonesian food",
+					Availability: &model.MenuAvailabilityResponse{DailyCapacity: intPtr(5), OrderedToday: 7, Remaining: intPtr(0), SoldOut: true}}},
 		},
 		{
 			name: "fail GetListMenu (invalid token)",
@@ -335,9 +368,9 @@ func Test_menuService_List(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().List(gomock.Any(), 2, 1).Return(nil, errors.New("oops! no rows"), nil)
+				m.menuRepoMock.EXPECT().List(gomock.Any(), int64(1), 2, 1).Return(nil, errors.New("oops! no rows"), nil)
 			},
 			want:    []*model.GetMenuResponse{},
 			wantErr: false, // for list err no rows is not consider as an error (will be used for http 200 with no data)
@@ -355,9 +388,9 @@ func Test_menuService_List(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().List(gomock.Any(), 2, 1).Return(nil, nil, errors.New("oops! db error"))
+				m.menuRepoMock.EXPECT().List(gomock.Any(), int64(1), 2, 1).Return(nil, nil, errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -405,7 +438,7 @@ func Test_menuService_Create(t *testing.T) {
 				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
 				req: model.CreateMenuRequest{
 					Name:       "Udon Rice",
-					Price:      40_000,
+					Price:      money.MustParse("40000"),
 					Categories: "Japanese food",
 				},
 			},
@@ -414,17 +447,17 @@ func Test_menuService_Create(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.menuRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+				m.menuRepoMock.EXPECT().Create(gomock.Any(), int64(1), gomock.Any()).Return(int64(10), nil)
 			},
 			want: &model.CreateMenuResponse{
 				ID:         10,
 				Name:       "Udon Rice",
-				Price:      40_000,
+				Price:      money.MustParse("40000"),
 				Categories: "Japanese food",
 			},
 		},
@@ -435,7 +468,7 @@ func Test_menuService_Create(t *testing.T) {
 				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "invalid-token"),
 				req: model.CreateMenuRequest{
 					Name:       "Udon Rice",
-					Price:      40_000,
+					Price:      money.MustParse("40000"),
 					Categories: "Japanese food",
 				},
 			},
@@ -456,7 +489,7 @@ func Test_menuService_Create(t *testing.T) {
 				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
 				req: model.CreateMenuRequest{
 					Name:       "Udon Rice",
-					Price:      1e-10, // must be greater than 0.05
+					Price:      money.MustParse("0.01"), // must be at least 0.05
 					Categories: "Japanese food",
 				},
 			},
@@ -465,7 +498,7 @@ func Test_menuService_Create(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return errors.New("invalid request")
@@ -480,7 +513,7 @@ func Test_menuService_Create(t *testing.T) {
 				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
 				req: model.CreateMenuRequest{
 					Name:       "Udon Rice",
-					Price:      40_000,
+					Price:      money.MustParse("40000"),
 					Categories: "Japanese food",
 				},
 			},
@@ -489,12 +522,12 @@ func Test_menuService_Create(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.menuRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("oops! db error"))
+				m.menuRepoMock.EXPECT().Create(gomock.Any(), int64(1), gomock.Any()).Return(int64(0), errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -545,7 +578,7 @@ func Test_menuService_Update(t *testing.T) {
 				id:  11,
 				req: model.UpdateMenuRequest{
 					Name:       "Kerak Telor",
-					Price:      30_000,
+					Price:      money.MustParse("30000"),
 					Categories: "Indonesian food",
 				},
 			},
@@ -554,20 +587,47 @@ func Test_menuService_Update(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.menuRepoMock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(int64(1), nil, nil)
+				m.menuRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).Return(int64(1), nil, nil)
 			},
 			want: &model.UpdateMenuResponse{
 				ID:         11,
 				Name:       "Kerak Telor",
-				Price:      30_000,
+				Price:      money.MustParse("30000"),
 				Categories: "Indonesian food",
 			},
 		},
+		{
+			name: "fail Update (menu of another business)",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  11,
+				req: model.UpdateMenuRequest{
+					Name:       "Kerak Telor",
+					Price:      money.MustParse("30000"),
+					Categories: "Indonesian food",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 2}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
+					return nil
+				})
+				// the menu belongs to business 1, it's not found for business 2
+				m.menuRepoMock.EXPECT().Update(gomock.Any(), int64(2), gomock.Any()).Return(int64(0), errors.New("oops! no rows"), nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail Update (no rows)",
 			svc:  &menuService{},
@@ -576,7 +636,7 @@ func Test_menuService_Update(t *testing.T) {
 				id:  11,
 				req: model.UpdateMenuRequest{
 					Name:       "Kerak Telor",
-					Price:      30_000,
+					Price:      money.MustParse("30000"),
 					Categories: "Indonesian food",
 				},
 			},
@@ -585,12 +645,12 @@ func Test_menuService_Update(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.menuRepoMock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("oops! err no rows"), nil)
+				m.menuRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).Return(int64(0), errors.New("oops! err no rows"), nil)
 			},
 			wantErr: true,
 		},
@@ -602,7 +662,7 @@ func Test_menuService_Update(t *testing.T) {
 				id:  11,
 				req: model.UpdateMenuRequest{
 					Name:       "Kerak Telor",
-					Price:      30_000,
+					Price:      money.MustParse("30000"),
 					Categories: "Indonesian food",
 				},
 			},
@@ -624,7 +684,7 @@ func Test_menuService_Update(t *testing.T) {
 				id:  11,
 				req: model.UpdateMenuRequest{
 					Name:       "Kerak Telor",
-					Price:      3e-5,
+					Price:      money.MustParse("0.01"),
 					Categories: "Indonesian food",
 				},
 			},
@@ -633,7 +693,7 @@ func Test_menuService_Update(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return errors.New("oops! invalid request")
@@ -649,7 +709,7 @@ func Test_menuService_Update(t *testing.T) {
 				id:  11,
 				req: model.UpdateMenuRequest{
 					Name:       "Kerak Telor",
-					Price:      35_000,
+					Price:      money.MustParse("35000"),
 					Categories: "Indonesian food",
 				},
 			},
@@ -658,12 +718,12 @@ func Test_menuService_Update(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error {
 					return nil
 				})
-				m.menuRepoMock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(int64(0), nil, errors.New("oops! db error"))
+				m.menuRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).Return(int64(0), nil, errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -718,12 +778,31 @@ func Test_menuService_Delete(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(10)).Return(int64(1), nil, nil)
+				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(10)).Return(int64(1), nil, nil)
 			},
 			wantNAffected: 1,
 		},
+		{
+			name: "fail Delete (menu of another business)",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  10,
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 2}, nil
+				})
+				// the menu belongs to business 1, it's not found for business 2
+				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(2), int64(10)).Return(int64(0), errors.New("oops! no rows"), nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail Delete (invalid token)",
 			svc:  &menuService{},
@@ -753,9 +832,9 @@ func Test_menuService_Delete(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(1_000_000_000_000_000)).Return(int64(0), errors.New("oops! no row"), nil)
+				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1_000_000_000_000_000)).Return(int64(0), errors.New("oops! no row"), nil)
 			},
 			wantErr: true,
 		}, {
@@ -770,9 +849,9 @@ func Test_menuService_Delete(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(10)).Return(int64(0), nil, errors.New("oops! db error"))
+				m.menuRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(10)).Return(int64(0), nil, errors.New("oops! db error"))
 			},
 			wantErr: true,
 		},
@@ -797,3 +876,112 @@ func Test_menuService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_menuService_UpdateAvailability(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+		req model.UpdateMenuAvailabilityRequest
+	}
+	type mocks struct {
+		utMocks      utils.Mock
+		menuRepoMock *repository.MockMenuRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *menuService
+		args         args
+		prepareMocks func(*mocks)
+		want         *model.GetMenuResponse
+		wantErr      bool
+	}{
+		{
+			name: "success UpdateAvailability",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  1,
+				req: model.UpdateMenuAvailabilityRequest{Stock: intPtr(30)},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().UpdateAvailability(gomock.Any(), int64(1), int64(1), intPtr(30), nil).Return(int64(1), nil, nil)
+				m.menuRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Menu{ID: 1, Name: "sate", Price: money.MustParse("25000"),
+					Categories: "Indonesian food", Stock: intPtr(30), OrderedToday: 12}, nil, nil)
+			},
+			want: &model.GetMenuResponse{
+				ID:           1,
+				Name:         "sate",
+				Price:        money.MustParse("25000"),
+				Categories:   "Indonesian food",
+				Availability: &model.MenuAvailabilityResponse{Stock: intPtr(30), OrderedToday: 12, Remaining: intPtr(30)},
+			},
+		},
+		{
+			name: "fail UpdateAvailability (negative stock)",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  1,
+				req: model.UpdateMenuAvailabilityRequest{Stock: intPtr(-1)},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateAvailability (menu not found)",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  1_000_000,
+				req: model.UpdateMenuAvailabilityRequest{DailyCapacity: intPtr(50)},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().UpdateAvailability(gomock.Any(), int64(1), int64(1_000_000), nil, intPtr(50)).Return(int64(0), errors.New("oops! no row"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateAvailability (db error)",
+			svc:  &menuService{},
+			args: args{
+				ctx: utils.ContextWithValue(context.Background(), consts.CtxKeyAuthorization, "access-token"),
+				id:  1,
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().UpdateAvailability(gomock.Any(), int64(1), int64(1), nil, nil).Return(int64(0), nil, errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMocks := utils.InitMock()
+			menuRepoMock := repository.NewMockMenuRepository(ctrl)
+
+			tt.svc.menuRepo = menuRepoMock
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{utMocks: utMocks, menuRepoMock: menuRepoMock})
+			}
+
+			got, err := tt.svc.UpdateAvailability(tt.args.ctx, tt.args.id, tt.args.req)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			utMocks.UnpatchAll()
+		})
+	}
+}
@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
 	"family-catering/pkg/apperrors"
 	"family-catering/pkg/consts"
+	"family-catering/pkg/money"
 	"family-catering/pkg/utils"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // const (
@@ -21,16 +25,35 @@ type OrderService interface {
 	Create(ctx context.Context, req model.CreateOrderRequest) (resp *model.CreateOrderResponse, err error)
 	Search(ctx context.Context, req model.OrderQuery) (resp *model.SearchOrdersResponse, err error)
 	CancelUnpaidOrder(ctx context.Context) (resp *model.CancelUnpaidOrderResponse, err error)
-	ConfirmPayment(ctx context.Context, req model.ConfirmPaymentRequest) error
+	ConfirmPayment(ctx context.Context, req model.ConfirmPaymentRequest) (resp *model.ConfirmPaymentResponse, err error)
+	ConfirmProviderPayment(ctx context.Context, payment *model.Payment) (resp *model.ConfirmPaymentResponse, err error)
+	UpdateStatus(ctx context.Context, orderID int64, req model.UpdateOrderStatusRequest) (resp *model.UpdateOrderStatusResponse, err error)
+	StatusHistory(ctx context.Context, orderID int64) (resp []*model.OrderStatusHistoryResponse, err error)
+	Get(ctx context.Context, orderID int64) (resp *model.OrderDetailResponse, err error)
+	Update(ctx context.Context, orderID int64, req model.UpdateOrderRequest) (resp *model.OrderDetailResponse, err error)
+	Cancel(ctx context.Context, orderID int64) (resp *model.UpdateOrderStatusResponse, err error)
+	AddItem(ctx context.Context, orderID int64, req model.BaseOrderRequest) (resp *model.OrderDetailResponse, err error)
+	UpdateItem(ctx context.Context, orderID, baseOrderID int64, req model.UpdateOrderItemRequest) (resp *model.OrderDetailResponse, err error)
+	DeleteItem(ctx context.Context, orderID, baseOrderID int64) (resp *model.OrderDetailResponse, err error)
 }
 
 type orderService struct {
-	orderRepo repository.OrderRepository
-	menuRepo  repository.MenuRepository
+	orderRepo            repository.OrderRepository
+	menuRepo             repository.MenuRepository
+	paymentRepo          repository.PaymentRepository
+	promotionRepo        repository.PromotionRepository
+	customerRepo         repository.CustomerRepository
+	taxCalculator        TaxCalculator
+	deliveryScheduler    DeliveryScheduler
+	deliveryZoneResolver DeliveryZoneResolver
 }
 
-func NewOrderService(orderRepo repository.OrderRepository, menuRepo repository.MenuRepository) OrderService {
-	return &orderService{orderRepo: orderRepo, menuRepo: menuRepo}
+func NewOrderService(orderRepo repository.OrderRepository, menuRepo repository.MenuRepository, paymentRepo repository.PaymentRepository,
+	promotionRepo repository.PromotionRepository, customerRepo repository.CustomerRepository, taxCalculator TaxCalculator,
+	deliveryScheduler DeliveryScheduler, deliveryZoneResolver DeliveryZoneResolver) OrderService {
+	return &orderService{orderRepo: orderRepo, menuRepo: menuRepo, paymentRepo: paymentRepo, promotionRepo: promotionRepo,
+		customerRepo: customerRepo, taxCalculator: taxCalculator, deliveryScheduler: deliveryScheduler,
+		deliveryZoneResolver: deliveryZoneResolver}
 }
 
 func (svc *orderService) Create(ctx context.Context, req model.CreateOrderRequest) (resp *model.CreateOrderResponse, err error) {
@@ -39,7 +62,7 @@ func (svc *orderService) Create(ctx context.Context, req model.CreateOrderReques
 		err := fmt.Errorf("service.orderService.Create: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err = utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err = fmt.Errorf("service.orderService.Create: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
@@ -52,6 +75,11 @@ func (svc *orderService) Create(ctx context.Context, req model.CreateOrderReques
 		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
 	}
 
+	delivery, err := svc.deliveryScheduler.Schedule(req.DeliveryDate, req.DeliverySlot, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Create: %w", err)
+	}
+
 	menusName := make([]string, 0, len(req.Orders))
 	qtys := make(map[string]int, len(req.Orders)) // not sure order of rows from search menu, so this would be better for now
 	for _, order := range req.Orders {
@@ -59,7 +87,7 @@ func (svc *orderService) Create(ctx context.Context, req model.CreateOrderReques
 		qtys[order.Name] = order.Qty
 	}
 
-	menus, errNoRow, err := svc.menuRepo.Search(ctx, model.MenuQuery{
+	menus, errNoRow, err := svc.menuRepo.Search(ctx, claims.BusinessID, model.MenuQuery{
 		Names:           menusName,
 		ExactNamesMatch: true,
 	})
@@ -74,23 +102,96 @@ func (svc *orderService) Create(ctx context.Context, req model.CreateOrderReques
 		return nil, err
 	}
 
+	customer, err := svc.orderCustomer(ctx, claims.BusinessID, req)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Create: %w", err)
+	}
+
+	delivery.Destination, err = svc.orderDestination(ctx, claims.BusinessID, req, customer)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Create: %w", err)
+	}
+
+	zone, err := svc.deliveryZoneResolver.Resolve(ctx, claims.BusinessID, delivery.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Create: %w", err)
+	}
+	if zone != nil {
+		delivery.ZoneID = zone.ID
+	}
+
 	ordersDB := []*model.Order{}
-	var totalPrice float32
 	for _, menu := range menus {
 		ordersDB = append(ordersDB, &model.Order{
-			CustomerEmail: req.CustomerEmail,
+			CustomerID:    customer.ID,
+			CustomerEmail: customer.Email,
 			MenuName:      menu.Name,
 			MenuID:        menu.ID,
 			Price:         menu.Price,
 			Qty:           qtys[menu.Name],
 			Status:        consts.StatusNew,
+			Notes:         strings.TrimSpace(req.Notes),
 		})
+	}
+
+	if req.PromoCode == "" {
+		charge := svc.taxCalculator.Calculate(ordersDB, menus, money.FromMinor(0))
+		err = applyDeliveryFee(charge, zone)
+		if err != nil {
+			return nil, fmt.Errorf("service.orderService.Create: %w", err)
+		}
+
+		_, orderID, err := svc.orderRepo.Create(ctx, claims.BusinessID, customer, ordersDB, delivery, charge)
+		if errors.Is(err, repository.ErrDeliverySlotFull) {
+			err = fmt.Errorf("service.orderService.Create: %w", err)
+			return nil, apperrors.WrapError(err, apperrors.ErrDeliverySlotFull, "")
+		}
+		if errors.Is(err, repository.ErrMenuSoldOut) {
+			err = fmt.Errorf("service.orderService.Create: %w", err)
+			return nil, wrapMenuSoldOut(err, ordersDB)
+		}
+		if err != nil {
+			err = fmt.Errorf("service.orderService.Create: %w", err)
+			return nil, err
+		}
+
+		resp = &model.CreateOrderResponse{
+			OrderID:       orderID,
+			CustomerID:    customer.ID,
+			CustomerEmail: customer.Email,
+			Message:       "success create orders",
+			Charge:        newOrderChargeResponse(charge),
+			Delivery:      newOrderDeliveryResponse(delivery.Date, delivery.Start, delivery.End, delivery.Destination, delivery.ZoneID),
+			TotalPrice:    charge.GrandTotal,
+		}
+
+		return resp, nil
+	}
 
-		totalPrice += (menu.Price * float32(qtys[menu.Name]))
+	discount, err := svc.applyPromotion(ctx, claims.BusinessID, req.PromoCode, customer.Email, ordersDB, menus)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Create: %w", err)
 	}
 
-	_, orderID, err := svc.orderRepo.Create(ctx, ordersDB)
+	charge := svc.taxCalculator.Calculate(ordersDB, menus, discount.Amount)
+	err = applyDeliveryFee(charge, zone)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Create: %w", err)
+	}
 
+	_, orderID, err := svc.orderRepo.CreateWithDiscount(ctx, claims.BusinessID, customer, ordersDB, delivery, discount, charge)
+	if errors.Is(err, repository.ErrPromotionUsageLimit) {
+		err = fmt.Errorf("service.orderService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "promo code has reached its usage limit")
+	}
+	if errors.Is(err, repository.ErrDeliverySlotFull) {
+		err = fmt.Errorf("service.orderService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrDeliverySlotFull, "")
+	}
+	if errors.Is(err, repository.ErrMenuSoldOut) {
+		err = fmt.Errorf("service.orderService.Create: %w", err)
+		return nil, wrapMenuSoldOut(err, ordersDB)
+	}
 	if err != nil {
 		err = fmt.Errorf("service.orderService.Create: %w", err)
 		return nil, err
@@ -98,27 +199,233 @@ func (svc *orderService) Create(ctx context.Context, req model.CreateOrderReques
 
 	resp = &model.CreateOrderResponse{
 		OrderID:       orderID,
-		CustomerEmail: req.CustomerEmail,
+		CustomerID:    customer.ID,
+		CustomerEmail: customer.Email,
 		Message:       "success create orders",
-		TotalPrice:    totalPrice,
+		Discount:      &model.OrderDiscountResponse{PromoCode: discount.Code, Amount: discount.Amount},
+		Charge:        newOrderChargeResponse(charge),
+		Delivery:      newOrderDeliveryResponse(delivery.Date, delivery.Start, delivery.End, delivery.Destination, delivery.ZoneID),
+		TotalPrice:    charge.GrandTotal,
 	}
 
 	return resp, nil
 }
 
+// orderCustomer return the existing customer of the request's customer id or the customer of the request's details.
+// A customer whose email is not registered yet has no id, it's only created along with the order (see
+// repository.OrderRepository.Create) so a rejected order doesn't leave it behind. The error is already wrapped with apperrors.
+func (svc *orderService) orderCustomer(ctx context.Context, businessID int64, req model.CreateOrderRequest) (*model.Customer, error) {
+	if req.CustomerID != 0 {
+		customer, errNoRow, err := svc.customerRepo.GetByID(ctx, businessID, req.CustomerID)
+		if errNoRow != nil {
+			errNoRow = fmt.Errorf("service.orderService.orderCustomer: %w", errNoRow)
+			return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "customer not found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("service.orderService.orderCustomer: %w", err)
+		}
+
+		return customer, nil
+	}
+
+	details := req.Customer
+	if details == nil {
+		details = &model.CreateCustomerRequest{Email: req.CustomerEmail}
+	}
+	customer, err := newCustomer(*details)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.orderCustomer: %w", err)
+	}
+
+	// the details of an existing customer are left as they are
+	existing, errNoRow, err := svc.customerRepo.GetByEmail(ctx, businessID, customer.Email)
+	if errNoRow != nil {
+		return &customer, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.orderCustomer: %w", err)
+	}
+
+	return existing, nil
+}
+
+// orderDestination return the request's delivery address, the customer's saved address of the request's customer address id
+// or the customer's default address, in that order. The destination is empty when there is none of them.
+// The error is already wrapped with apperrors.
+func (svc *orderService) orderDestination(ctx context.Context, businessID int64, req model.CreateOrderRequest, customer *model.Customer) (model.DeliveryAddress, error) {
+	if req.DeliveryAddress != nil {
+		return newDeliveryAddress(*req.DeliveryAddress), nil
+	}
+
+	addresses := customer.Addresses
+	if req.CustomerAddressID != 0 && addresses == nil && customer.ID != 0 {
+		// the addresses are only loaded along with a customer found by its id, a new customer has none
+		found, errNoRow, err := svc.customerRepo.GetByID(ctx, businessID, customer.ID)
+		if errNoRow != nil {
+			errNoRow = fmt.Errorf("service.orderService.orderDestination: %w", errNoRow)
+			return model.DeliveryAddress{}, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "customer not found")
+		}
+		if err != nil {
+			return model.DeliveryAddress{}, fmt.Errorf("service.orderService.orderDestination: %w", err)
+		}
+		addresses = found.Addresses
+	}
+
+	for _, address := range addresses {
+		if (req.CustomerAddressID == 0 && address.IsDefault) || address.ID == req.CustomerAddressID {
+			return model.DeliveryAddress{
+				Address:    address.Address,
+				PostalCode: address.PostalCode,
+				Latitude:   address.Latitude,
+				Longitude:  address.Longitude,
+			}, nil
+		}
+	}
+
+	if req.CustomerAddressID != 0 {
+		err := fmt.Errorf("service.orderService.orderDestination: address %d of customer %d not found", req.CustomerAddressID, customer.ID)
+		return model.DeliveryAddress{}, apperrors.WrapError(err, apperrors.ErrNotFound, "customer address not found")
+	}
+
+	return model.DeliveryAddress{}, nil
+}
+
+// applyPromotion return the discount of an active promotion for the new order, its usage limits are checked
+// while the order is created (see repository.OrderRepository.CreateWithDiscount). The error is already wrapped with apperrors.
+func (svc *orderService) applyPromotion(ctx context.Context, businessID int64, code, customerEmail string, orders []*model.Order, menus []*model.Menu) (*model.OrderDiscount, error) {
+	promotion, errNoRow, err := svc.promotionRepo.GetByCode(ctx, businessID, strings.ToUpper(code))
+	if errNoRow != nil || (err == nil && !promotion.Active) {
+		err = fmt.Errorf("service.orderService.applyPromotion: promo code %s is not found or not active", code)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "promo code is not valid")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.applyPromotion: %w", err)
+		return nil, err
+	}
+
+	amount, err := promotionDiscount(promotion, orders, menus)
+	if errors.Is(err, errPromotionMinSpend) {
+		err = fmt.Errorf("service.orderService.applyPromotion: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, fmt.Sprintf("order total is below the promo code's minimum spend (%s)", promotion.MinSpend))
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.applyPromotion: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "promo code is not applicable to the ordered menus")
+	}
+
+	discount := &model.OrderDiscount{
+		PromotionID:   promotion.ID,
+		Code:          promotion.Code,
+		CustomerEmail: customerEmail,
+		Amount:        amount,
+	}
+
+	return discount, nil
+}
+
+// repricer return the repricer of an order whose items are being changed, it's run by the repository within
+// the transaction changing the items so a payment never sees the items without their new charge
+func (svc *orderService) repricer(ctx context.Context, businessID int64) repository.OrderRepricer {
+	return func(orders []*model.Order) (money.Money, *model.OrderCharge, error) {
+		discount, err := svc.repriceDiscount(ctx, businessID, orders)
+		if err != nil {
+			return money.Money{}, nil, fmt.Errorf("service.orderService.repricer: %w", err)
+		}
+
+		for _, order := range orders {
+			order.Discount = discount
+		}
+
+		charge, err := svc.repriceCharge(ctx, businessID, orders)
+		if err != nil {
+			return money.Money{}, nil, fmt.Errorf("service.orderService.repricer: %w", err)
+		}
+
+		return discount, charge, nil
+	}
+}
+
+// repriceDiscount recompute the discount of an order whose items have been changed with the current terms of its promotion,
+// the validity and the usage limits were checked when the order was created. The discount becomes zero while the order
+// doesn't meet the promotion's terms (e.g. below the minimum spend) and comes back once it does.
+func (svc *orderService) repriceDiscount(ctx context.Context, businessID int64, orders []*model.Order) (money.Money, error) {
+	if orders[0].PromoCode == "" {
+		return orders[0].Discount, nil
+	}
+
+	discount, errNoRow, err := svc.orderRepo.GetDiscount(ctx, businessID, orders[0].OrderID)
+	if errNoRow != nil {
+		// the discount has been removed concurrently
+		return orders[0].Discount, nil
+	}
+	if err != nil {
+		return money.Money{}, fmt.Errorf("service.orderService.repriceDiscount: %w", err)
+	}
+
+	// a used promotion is never deleted so errNoRow is unexpected here
+	promotion, errNoRow, err := svc.promotionRepo.GetByID(ctx, businessID, discount.PromotionID)
+	if errNoRow != nil {
+		err = errNoRow
+	}
+	if err != nil {
+		return money.Money{}, fmt.Errorf("service.orderService.repriceDiscount: %w", err)
+	}
+
+	var menus []*model.Menu
+	if promotion.Categories != "" {
+		ids := make([]int64, 0, len(orders))
+		for _, order := range orders {
+			ids = append(ids, order.MenuID)
+		}
+		menus, _, err = svc.menuRepo.Search(ctx, businessID, model.MenuQuery{IDs: ids})
+		if err != nil {
+			return money.Money{}, fmt.Errorf("service.orderService.repriceDiscount: %w", err)
+		}
+	}
+
+	amount, _ := promotionDiscount(promotion, orders, menus)
+
+	return amount, nil
+}
+
+// repriceCharge recompute the charge (service charge and tax) of an order whose items or discount have been changed,
+// the delivery fee is kept as it was charged when the order was created
+func (svc *orderService) repriceCharge(ctx context.Context, businessID int64, orders []*model.Order) (*model.OrderCharge, error) {
+	var menus []*model.Menu
+	if svc.taxCalculator.NeedsCategories() {
+		ids := make([]int64, 0, len(orders))
+		for _, order := range orders {
+			ids = append(ids, order.MenuID)
+		}
+		// a deleted menu is taxed at the default rate
+		var err error
+		menus, _, err = svc.menuRepo.Search(ctx, businessID, model.MenuQuery{IDs: ids})
+		if err != nil {
+			return nil, fmt.Errorf("service.orderService.repriceCharge: %w", err)
+		}
+	}
+
+	charge := svc.taxCalculator.Calculate(orders, menus, orders[0].Discount)
+	charge.OrderID = orders[0].OrderID
+	charge.DeliveryFee = orders[0].DeliveryFee
+	charge.GrandTotal = charge.GrandTotal.Add(charge.DeliveryFee)
+
+	return charge, nil
+}
+
 func (svc *orderService) Search(ctx context.Context, req model.OrderQuery) (resp *model.SearchOrdersResponse, err error) {
 	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
 	if !ok {
 		err := fmt.Errorf("service.orderService.Search: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err = utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err = fmt.Errorf("service.orderService.Search: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	ordersDB, errNoRow, err := svc.orderRepo.Search(ctx, req)
+	ordersDB, errNoRow, err := svc.orderRepo.Search(ctx, claims.BusinessID, req)
 	if errNoRow != nil {
 		errNoRow = fmt.Errorf("service.orderService.Search: %w", errNoRow)
 		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
@@ -129,8 +436,14 @@ func (svc *orderService) Search(ctx context.Context, req model.OrderQuery) (resp
 	}
 
 	searchedOrders := make([]*model.SearchResponse, 0, len(ordersDB))
-	totalPrice := float32(0)
+	totalPrice := money.FromMinor(0)
+	orderIDs := make([]int64, 0, len(ordersDB))
+	seen := make(map[int64]bool, len(ordersDB))
 	for _, order := range ordersDB {
+		if !seen[order.OrderID] {
+			seen[order.OrderID] = true
+			orderIDs = append(orderIDs, order.OrderID)
+		}
 		searchedOrders = append(searchedOrders, &model.SearchResponse{
 			OrderID:       order.OrderID,
 			CustomerEmail: order.CustomerEmail,
@@ -139,14 +452,32 @@ func (svc *orderService) Search(ctx context.Context, req model.OrderQuery) (resp
 			Price:         order.Price,
 			Qty:           order.Qty,
 			Status:        order.Status,
+			DeliveryDate:  order.DeliveryDate,
+			DeliverySlot:  formatSlot(order.DeliveryStart, order.DeliveryEnd),
 			CreatedAt:     order.CreatedAt,
 		})
 
-		totalPrice += float32(order.Qty) * order.Price
+		totalPrice = totalPrice.Add(order.Price.Mul(int64(order.Qty)))
+	}
+
+	charges, err := svc.orderRepo.GetCharges(ctx, claims.BusinessID, orderIDs)
+	if err != nil {
+		err = fmt.Errorf("service.orderService.Search: %w", err)
+		return nil, err
 	}
+
+	chargesResp := make([]*model.OrderChargeResponse, 0, len(charges))
+	grandTotal := money.FromMinor(0)
+	for _, charge := range charges {
+		chargesResp = append(chargesResp, newOrderChargeResponse(charge))
+		grandTotal = grandTotal.Add(charge.GrandTotal)
+	}
+
 	orders := model.SearchOrdersResponse{
 		Orders:     searchedOrders,
 		TotalPrice: totalPrice,
+		Charges:    chargesResp,
+		GrandTotal: grandTotal,
 	}
 
 	return &orders, nil
@@ -169,35 +500,539 @@ func (svc *orderService) CancelUnpaidOrder(ctx context.Context) (resp *model.Can
 	return resp, nil
 }
 
-func (svc *orderService) ConfirmPayment(ctx context.Context, req model.ConfirmPaymentRequest) error {
+// ConfirmPayment record a (partial) payment of an order, the order becomes PAID once the payments cover its total price
+func (svc *orderService) ConfirmPayment(ctx context.Context, req model.ConfirmPaymentRequest) (*model.ConfirmPaymentResponse, error) {
 	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
 	if !ok {
 		err := fmt.Errorf("service.orderService.ConfirmPayment: invalid auth token type want string got %T", token)
-		return apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	// session is used to record who received the payment
+	session, ok := utils.ValueContext(ctx, consts.CtxKeySession).(*model.AuthSessionResponse)
+	if !ok || !session.Valid {
+		err := fmt.Errorf("service.orderService.ConfirmPayment: invalid session")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err = fmt.Errorf("service.orderService.ConfirmPayment: %w", err)
-		return apperrors.WrapError(err, apperrors.ErrAuth, "")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 	err = utils.ValidateRequest(&req)
 	if err == apperrors.ErrRequiredParam {
-		return apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
 	}
 	if err != nil {
-		return apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
 	}
 
-	_, errNoRow, err := svc.orderRepo.ConfirmPayment(ctx, req.Email)
-	if errNoRow != nil && err == nil {
-		errNoRow = fmt.Errorf("service.orderService.ConfirmPayment: %w", errNoRow)
-		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	payment := &model.Payment{
+		OrderID:         req.OrderID,
+		Amount:          req.Amount,
+		Method:          req.Method,
+		ReferenceNumber: sql.NullString{String: req.ReferenceNumber, Valid: req.ReferenceNumber != ""},
+		ReceivedBy:      sql.NullInt64{Int64: session.OwnerID, Valid: session.OwnerID != 0},
+	}
+	if payment.Method == "" {
+		payment.Method = consts.PaymentMethodCash
 	}
 
+	resp, err := svc.pay(ctx, claims.BusinessID, payment)
 	if err != nil {
 		err = fmt.Errorf("service.orderService.ConfirmPayment: %w", err)
-		return err
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ConfirmProviderPayment record a payment reported by a payment provider, it skips the auth check
+// so it must only be called with a payment that is already verified (e.g. a signed webhook).
+// Replayed provider event returns error wrapping repository.ErrDuplicatePaymentEvent.
+func (svc *orderService) ConfirmProviderPayment(ctx context.Context, payment *model.Payment) (*model.ConfirmPaymentResponse, error) {
+	if !payment.Provider.Valid || !payment.ProviderEventID.Valid {
+		err := fmt.Errorf("service.orderService.ConfirmProviderPayment: provider and provider event id are required")
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+
+	// the order of a signed event is trusted whatever business it belongs to
+	resp, err := svc.pay(ctx, 0, payment)
+	if err != nil {
+		err = fmt.Errorf("service.orderService.ConfirmProviderPayment: %w", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// pay record the payment and map the repository errors, the repository errors stay in the chain
+// so callers could still check them with errors.Is
+func (svc *orderService) pay(ctx context.Context, businessID int64, payment *model.Payment) (*model.ConfirmPaymentResponse, error) {
+	summary, errNoRow, err := svc.paymentRepo.Pay(ctx, businessID, payment)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.pay: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if errors.Is(err, repository.ErrOrderNotPayable) {
+		err = fmt.Errorf("service.orderService.pay: %w", err)
+		msg := fmt.Sprintf("order is %s, it can't be paid anymore", orderStatusName(summary.Status))
+		return nil, apperrors.WrapError(err, apperrors.ErrOrderStatusTransition, msg)
+	}
+	if errors.Is(err, repository.ErrPaymentExceedsBalance) {
+		err = fmt.Errorf("service.orderService.pay: %w", err)
+		msg := fmt.Sprintf("amount exceeds the remaining balance (%s)", summary.TotalPrice.Sub(summary.TotalPaid))
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, msg)
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.pay: %w", err)
+		return nil, err
+	}
+
+	resp := &model.ConfirmPaymentResponse{
+		PaymentID:        summary.PaymentID,
+		OrderID:          summary.OrderID,
+		Amount:           payment.Amount,
+		TotalPrice:       summary.TotalPrice,
+		TotalPaid:        summary.TotalPaid,
+		RemainingBalance: summary.TotalPrice.Sub(summary.TotalPaid).Max(money.FromMinor(0)),
+		Status:           orderStatusName(summary.Status),
+	}
+
+	return resp, nil
+}
+
+func (svc *orderService) UpdateStatus(ctx context.Context, orderID int64, req model.UpdateOrderStatusRequest) (*model.UpdateOrderStatusResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.UpdateStatus: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	// session is used to record who moved the order
+	session, ok := utils.ValueContext(ctx, consts.CtxKeySession).(*model.AuthSessionResponse)
+	if !ok || !session.Valid {
+		err := fmt.Errorf("service.orderService.UpdateStatus: invalid session")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.UpdateStatus: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	err = utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	to, ok := parseOrderStatus(req.Status)
+	if !ok {
+		err = fmt.Errorf("service.orderService.UpdateStatus: unknown order status %q", req.Status)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "unknown order status")
+	}
+	// an order becomes PAID only when its payments cover the total price
+	if to == consts.StatusPaid {
+		err = fmt.Errorf("service.orderService.UpdateStatus: order can't be moved to PAID manually")
+		return nil, apperrors.WrapError(err, apperrors.ErrOrderStatusTransition, "order is paid through confirm-payment")
+	}
+	if !canMoveOrderTo(claims.Role, to) {
+		err = fmt.Errorf("service.orderService.UpdateStatus: role %q can't move order to %s", claims.Role, orderStatusName(to))
+		return nil, apperrors.WrapError(err, apperrors.ErrForbidden, fmt.Sprintf("%s can't move order to %s", claims.Role, orderStatusName(to)))
+	}
+
+	resp, err := svc.changeStatus(ctx, claims.BusinessID, orderID, to, session.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.UpdateStatus: %w", err)
+	}
+
+	return resp, nil
+}
+
+// changeStatus move the order to status `to` when it's allowed by the order lifecycle and record who moved it
+func (svc *orderService) changeStatus(ctx context.Context, businessID int64, orderID int64, to int, changedBy int64) (*model.UpdateOrderStatusResponse, error) {
+	from, errNoRow, err := svc.orderRepo.GetStatus(ctx, businessID, orderID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.changeStatus: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.changeStatus: %w", err)
+		return nil, err
+	}
+
+	err = validateOrderStatusTransition(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.changeStatus: %w", err)
+	}
+
+	// an order paid even partly isn't cancelled, its payments would be left without refund (see CancelUnpaidOrder)
+	if to == consts.StatusCancelled {
+		summary, errNoRow, err := svc.paymentRepo.Balance(ctx, businessID, orderID)
+		if errNoRow != nil {
+			errNoRow = fmt.Errorf("service.orderService.changeStatus: %w", errNoRow)
+			return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+		}
+		if err != nil {
+			err = fmt.Errorf("service.orderService.changeStatus: %w", err)
+			return nil, err
+		}
+		if summary.TotalPaid.IsPositive() {
+			err = fmt.Errorf("service.orderService.changeStatus: order %d has payments of %s, it can't be cancelled", orderID, summary.TotalPaid)
+			return nil, apperrors.WrapError(err, apperrors.ErrOrderStatusTransition, "order has payments, it can't be cancelled")
+		}
+	}
+
+	_, errNoRow, err = svc.orderRepo.UpdateStatus(ctx, businessID, orderID, from, to, changedBy)
+	// the order has been moved by another request between GetStatus and UpdateStatus
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.changeStatus: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrOrderStatusTransition, "order status has been changed, please try again")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.changeStatus: %w", err)
+		return nil, err
+	}
+
+	resp := &model.UpdateOrderStatusResponse{
+		OrderID:        orderID,
+		PreviousStatus: orderStatusName(from),
+		Status:         orderStatusName(to),
+	}
+
+	return resp, nil
+}
+
+func (svc *orderService) StatusHistory(ctx context.Context, orderID int64) ([]*model.OrderStatusHistoryResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.StatusHistory: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.StatusHistory: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	histories, errNoRow, err := svc.orderRepo.StatusHistory(ctx, claims.BusinessID, orderID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.StatusHistory: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.StatusHistory: %w", err)
+		return nil, err
+	}
+
+	return newOrderStatusHistoriesResponse(histories), nil
+}
+
+func (svc *orderService) Get(ctx context.Context, orderID int64) (*model.OrderDetailResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.Get: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.Get: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	orders, err := svc.getOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Get: %w", err)
+	}
+
+	return newOrderDetailResponse(orders), nil
+}
+
+func (svc *orderService) Update(ctx context.Context, orderID int64, req model.UpdateOrderRequest) (*model.OrderDetailResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.Update: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	err = utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	_, err = svc.getEditableOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Update: %w", err)
+	}
+
+	customer, err := newCustomer(model.CreateCustomerRequest{Email: req.CustomerEmail})
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Update: %w", err)
+	}
+	customer.ID, err = svc.customerRepo.FindOrCreate(ctx, claims.BusinessID, customer)
+	if err != nil {
+		err = fmt.Errorf("service.orderService.Update: %w", err)
+		return nil, err
+	}
+
+	_, errNoRow, err := svc.orderRepo.UpdateCustomer(ctx, claims.BusinessID, orderID, customer.ID, customer.Email)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrOrderNotEditable, "order has been changed, please try again")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.Update: %w", err)
+		return nil, err
+	}
+
+	orders, err := svc.getOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Update: %w", err)
+	}
+
+	return newOrderDetailResponse(orders), nil
+}
+
+// Cancel cancel a single unpaid order, paid order must be refunded instead (see UpdateStatus)
+func (svc *orderService) Cancel(ctx context.Context, orderID int64) (*model.UpdateOrderStatusResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.Cancel: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	session, ok := utils.ValueContext(ctx, consts.CtxKeySession).(*model.AuthSessionResponse)
+	if !ok || !session.Valid {
+		err := fmt.Errorf("service.orderService.Cancel: invalid session")
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.Cancel: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	resp, err := svc.changeStatus(ctx, claims.BusinessID, orderID, consts.StatusCancelled, session.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.Cancel: %w", err)
+	}
+
+	return resp, nil
+}
+
+// AddItem add a menu to an unpaid order, when the menu is already ordered its qty is increased instead
+func (svc *orderService) AddItem(ctx context.Context, orderID int64, req model.BaseOrderRequest) (*model.OrderDetailResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.AddItem: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.AddItem: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	err = utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	orders, err := svc.getEditableOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.AddItem: %w", err)
+	}
+
+	menu, errNoRow, err := svc.menuRepo.GetByName(ctx, claims.BusinessID, req.Name)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.AddItem: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "menu not found")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.AddItem: %w", err)
+		return nil, err
+	}
+
+	// the qty is added to the menu's item of the order by the repository, within the order's lock
+	_, errNoRow, err = svc.orderRepo.AddItem(ctx, claims.BusinessID, &model.Order{
+		OrderID:      orderID,
+		MenuID:       menu.ID,
+		MenuName:     menu.Name,
+		Price:        menu.Price,
+		Qty:          req.Qty,
+		DeliveryDate: orders[0].DeliveryDate,
+	}, svc.repricer(ctx, claims.BusinessID))
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.AddItem: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrOrderNotEditable, "order has been changed, please try again")
+	}
+	if errors.Is(err, repository.ErrMenuSoldOut) {
+		err = fmt.Errorf("service.orderService.AddItem: %w", err)
+		return nil, wrapMenuSoldOut(err, []*model.Order{{MenuID: menu.ID, MenuName: menu.Name}})
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.AddItem: %w", err)
+		return nil, err
+	}
+
+	orders, err = svc.getOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.AddItem: %w", err)
+	}
+
+	return newOrderDetailResponse(orders), nil
+}
+
+func (svc *orderService) UpdateItem(ctx context.Context, orderID, baseOrderID int64, req model.UpdateOrderItemRequest) (*model.OrderDetailResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.UpdateItem: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.UpdateItem: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	err = utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	orders, err := svc.getEditableOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.UpdateItem: %w", err)
+	}
+
+	if !hasOrderItem(orders, baseOrderID) {
+		err = fmt.Errorf("service.orderService.UpdateItem: item %d not found in order %d", baseOrderID, orderID)
+		return nil, apperrors.WrapError(err, apperrors.ErrNotFound, "order item not found")
+	}
+
+	_, errNoRow, err := svc.orderRepo.UpdateItemQty(ctx, claims.BusinessID, orderID, baseOrderID, req.Qty, svc.repricer(ctx, claims.BusinessID))
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.UpdateItem: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrOrderNotEditable, "order has been changed, please try again")
+	}
+	if errors.Is(err, repository.ErrMenuSoldOut) {
+		err = fmt.Errorf("service.orderService.UpdateItem: %w", err)
+		return nil, wrapMenuSoldOut(err, orders)
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.UpdateItem: %w", err)
+		return nil, err
+	}
+
+	orders, err = svc.getOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.UpdateItem: %w", err)
+	}
+
+	return newOrderDetailResponse(orders), nil
+}
+
+func (svc *orderService) DeleteItem(ctx context.Context, orderID, baseOrderID int64) (*model.OrderDetailResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.orderService.DeleteItem: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.orderService.DeleteItem: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	orders, err := svc.getEditableOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.DeleteItem: %w", err)
+	}
+
+	if !hasOrderItem(orders, baseOrderID) {
+		err = fmt.Errorf("service.orderService.DeleteItem: item %d not found in order %d", baseOrderID, orderID)
+		return nil, apperrors.WrapError(err, apperrors.ErrNotFound, "order item not found")
+	}
+
+	if len(orders) == 1 {
+		err = fmt.Errorf("service.orderService.DeleteItem: can't remove the last item of order %d", orderID)
+		return nil, apperrors.WrapError(err, apperrors.ErrOrderNotEditable, "can't remove the last item of an order, cancel the order instead")
+	}
+
+	_, errNoRow, err := svc.orderRepo.DeleteItem(ctx, claims.BusinessID, orderID, baseOrderID, svc.repricer(ctx, claims.BusinessID))
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.DeleteItem: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrOrderNotEditable, "order has been changed, please try again")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.DeleteItem: %w", err)
+		return nil, err
+	}
+
+	orders, err = svc.getOrder(ctx, claims.BusinessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.DeleteItem: %w", err)
+	}
+
+	return newOrderDetailResponse(orders), nil
+}
+
+// wrapMenuSoldOut wrap an error matching repository.ErrMenuSoldOut with apperrors.ErrMenuSoldOut,
+// the sold out menu is named by the ordered items
+func wrapMenuSoldOut(err error, orders []*model.Order) error {
+	message := apperrors.ErrMenuSoldOut.Error()
+	var soldOut *repository.MenuSoldOutError
+	if errors.As(err, &soldOut) {
+		name := fmt.Sprintf("#%d", soldOut.MenuID)
+		for _, order := range orders {
+			if order.MenuID == soldOut.MenuID {
+				name = order.MenuName
+				break
+			}
+		}
+		message = fmt.Sprintf("menu %s is sold out, %d portion(s) left", name, soldOut.Remaining)
+	}
+
+	return apperrors.WrapError(err, apperrors.ErrMenuSoldOut, message)
+}
+
+// getOrder return every item of the order, the error is already wrapped with apperrors
+func (svc *orderService) getOrder(ctx context.Context, businessID int64, orderID int64) ([]*model.Order, error) {
+	orders, errNoRow, err := svc.orderRepo.Get(ctx, businessID, orderID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.orderService.getOrder: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.orderService.getOrder: %w", err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// getEditableOrder same as getOrder but refuse order which is not editable anymore
+func (svc *orderService) getEditableOrder(ctx context.Context, businessID int64, orderID int64) ([]*model.Order, error) {
+	orders, err := svc.getOrder(ctx, businessID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.orderService.getEditableOrder: %w", err)
+	}
+
+	if !isOrderEditable(orders[0].Status) {
+		status := orderStatusName(orders[0].Status)
+		err = fmt.Errorf("service.orderService.getEditableOrder: order %d is %s", orderID, status)
+		return nil, apperrors.WrapError(err, apperrors.ErrOrderNotEditable, fmt.Sprintf("order is %s, only NEW or CONFIRMED order could be modified", status))
 	}
 
-	return nil
+	return orders, nil
 }
@@ -35,6 +35,36 @@ func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
 	return m.recorder
 }
 
+// AddItem mocks base method.
+func (m *MockOrderService) AddItem(ctx context.Context, orderID int64, req model.BaseOrderRequest) (*model.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, orderID, req)
+	ret0, _ := ret[0].(*model.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockOrderServiceMockRecorder) AddItem(ctx, orderID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockOrderService)(nil).AddItem), ctx, orderID, req)
+}
+
+// Cancel mocks base method.
+func (m *MockOrderService) Cancel(ctx context.Context, orderID int64) (*model.UpdateOrderStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, orderID)
+	ret0, _ := ret[0].(*model.UpdateOrderStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderServiceMockRecorder) Cancel(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderService)(nil).Cancel), ctx, orderID)
+}
+
 // CancelUnpaidOrder mocks base method.
 func (m *MockOrderService) CancelUnpaidOrder(ctx context.Context) (*model.CancelUnpaidOrderResponse, error) {
 	m.ctrl.T.Helper()
@@ -51,11 +81,12 @@ func (mr *MockOrderServiceMockRecorder) CancelUnpaidOrder(ctx interface{}) *gomo
 }
 
 // ConfirmPayment mocks base method.
-func (m *MockOrderService) ConfirmPayment(ctx context.Context, req model.ConfirmPaymentRequest) error {
+func (m *MockOrderService) ConfirmPayment(ctx context.Context, req model.ConfirmPaymentRequest) (*model.ConfirmPaymentResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, req)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*model.ConfirmPaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ConfirmPayment indicates an expected call of ConfirmPayment.
@@ -64,6 +95,21 @@ func (mr *MockOrderServiceMockRecorder) ConfirmPayment(ctx, req interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockOrderService)(nil).ConfirmPayment), ctx, req)
 }
 
+// ConfirmProviderPayment mocks base method.
+func (m *MockOrderService) ConfirmProviderPayment(ctx context.Context, payment *model.Payment) (*model.ConfirmPaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmProviderPayment", ctx, payment)
+	ret0, _ := ret[0].(*model.ConfirmPaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmProviderPayment indicates an expected call of ConfirmProviderPayment.
+func (mr *MockOrderServiceMockRecorder) ConfirmProviderPayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmProviderPayment", reflect.TypeOf((*MockOrderService)(nil).ConfirmProviderPayment), ctx, payment)
+}
+
 // Create mocks base method.
 func (m *MockOrderService) Create(ctx context.Context, req model.CreateOrderRequest) (*model.CreateOrderResponse, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +125,36 @@ func (mr *MockOrderServiceMockRecorder) Create(ctx, req interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderService)(nil).Create), ctx, req)
 }
 
+// DeleteItem mocks base method.
+func (m *MockOrderService) DeleteItem(ctx context.Context, orderID, baseOrderID int64) (*model.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, orderID, baseOrderID)
+	ret0, _ := ret[0].(*model.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockOrderServiceMockRecorder) DeleteItem(ctx, orderID, baseOrderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockOrderService)(nil).DeleteItem), ctx, orderID, baseOrderID)
+}
+
+// Get mocks base method.
+func (m *MockOrderService) Get(ctx context.Context, orderID int64) (*model.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, orderID)
+	ret0, _ := ret[0].(*model.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrderServiceMockRecorder) Get(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderService)(nil).Get), ctx, orderID)
+}
+
 // Search mocks base method.
 func (m *MockOrderService) Search(ctx context.Context, req model.OrderQuery) (*model.SearchOrdersResponse, error) {
 	m.ctrl.T.Helper()
@@ -93,3 +169,63 @@ func (mr *MockOrderServiceMockRecorder) Search(ctx, req interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderService)(nil).Search), ctx, req)
 }
+
+// StatusHistory mocks base method.
+func (m *MockOrderService) StatusHistory(ctx context.Context, orderID int64) ([]*model.OrderStatusHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, orderID)
+	ret0, _ := ret[0].([]*model.OrderStatusHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockOrderServiceMockRecorder) StatusHistory(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockOrderService)(nil).StatusHistory), ctx, orderID)
+}
+
+// Update mocks base method.
+func (m *MockOrderService) Update(ctx context.Context, orderID int64, req model.UpdateOrderRequest) (*model.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, orderID, req)
+	ret0, _ := ret[0].(*model.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrderServiceMockRecorder) Update(ctx, orderID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderService)(nil).Update), ctx, orderID, req)
+}
+
+// UpdateItem mocks base method.
+func (m *MockOrderService) UpdateItem(ctx context.Context, orderID, baseOrderID int64, req model.UpdateOrderItemRequest) (*model.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, orderID, baseOrderID, req)
+	ret0, _ := ret[0].(*model.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockOrderServiceMockRecorder) UpdateItem(ctx, orderID, baseOrderID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockOrderService)(nil).UpdateItem), ctx, orderID, baseOrderID, req)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderService) UpdateStatus(ctx context.Context, orderID int64, req model.UpdateOrderStatusRequest) (*model.UpdateOrderStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, orderID, req)
+	ret0, _ := ret[0].(*model.UpdateOrderStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderServiceMockRecorder) UpdateStatus(ctx, orderID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderService)(nil).UpdateStatus), ctx, orderID, req)
+}
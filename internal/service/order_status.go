@@ -0,0 +1,108 @@
+package service
+
+import (
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"fmt"
+	"strings"
+)
+
+// order lifecycle:
+//
+//	NEW -> CONFIRMED -> PREPARING -> READY -> OUT_FOR_DELIVERY -> DELIVERED
+//
+// PAID, CANCELLED and REFUNDED are side states: a NEW or CONFIRMED order moves to PAID once it's fully paid and is
+// prepared from there, while a CONFIRMED order (e.g. cash on delivery) may be prepared before it's paid, its payments
+// are recorded without moving it back. unpaid order (NEW or CONFIRMED) could be CANCELLED while an order being
+// prepared or paid could only be REFUNDED.
+var orderStatusTransitions = map[int][]int{
+	consts.StatusNew:            {consts.StatusConfirmed, consts.StatusPaid, consts.StatusCancelled},
+	consts.StatusConfirmed:      {consts.StatusPreparing, consts.StatusPaid, consts.StatusCancelled},
+	consts.StatusPaid:           {consts.StatusPreparing, consts.StatusRefunded},
+	consts.StatusPreparing:      {consts.StatusReady, consts.StatusRefunded},
+	consts.StatusReady:          {consts.StatusOutForDelivery, consts.StatusDelivered, consts.StatusRefunded}, // READY -> DELIVERED for self pick-up
+	consts.StatusOutForDelivery: {consts.StatusDelivered, consts.StatusRefunded},
+	consts.StatusDelivered:      {consts.StatusRefunded},
+	consts.StatusCancelled:      {},
+	consts.StatusRefunded:       {},
+}
+
+// orderStatusesByRole is the statuses a staff role moves an order to (see orderService.UpdateStatus), the kitchen
+// prepares the orders and the drivers deliver them. Cashiers and admins move an order to any status, cancelling and
+// refunding an order are theirs only
+var orderStatusesByRole = map[string][]int{
+	consts.RoleKitchen: {consts.StatusPreparing, consts.StatusReady},
+	consts.RoleDriver:  {consts.StatusOutForDelivery, consts.StatusDelivered},
+}
+
+var orderStatusNames = map[int]string{
+	consts.StatusNew:            "NEW",
+	consts.StatusConfirmed:      "CONFIRMED",
+	consts.StatusPaid:           "PAID",
+	consts.StatusPreparing:      "PREPARING",
+	consts.StatusReady:          "READY",
+	consts.StatusOutForDelivery: "OUT_FOR_DELIVERY",
+	consts.StatusDelivered:      "DELIVERED",
+	consts.StatusCancelled:      "CANCELLED",
+	consts.StatusRefunded:       "REFUNDED",
+}
+
+func orderStatusName(status int) string {
+	name, ok := orderStatusNames[status]
+	if !ok {
+		return fmt.Sprintf("UNKNOWN(%d)", status)
+	}
+
+	return name
+}
+
+// parseOrderStatus return status value of given name (case insensitive), ok is false when the name is unknown
+func parseOrderStatus(name string) (status int, ok bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for status, statusName := range orderStatusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+
+	return 0, false
+}
+
+// validateOrderStatusTransition return error wrapped with apperrors.ErrOrderStatusTransition
+// when the order is not allowed to move from status `from` to status `to`
+func validateOrderStatusTransition(from, to int) error {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+
+	err := fmt.Errorf("service.validateOrderStatusTransition: can't move order from %s to %s", orderStatusName(from), orderStatusName(to))
+	return apperrors.WrapError(err, apperrors.ErrOrderStatusTransition, fmt.Sprintf("can't move order from %s to %s", orderStatusName(from), orderStatusName(to)))
+}
+
+// canMoveOrderTo report whether the role is allowed to move an order to status `to`
+func canMoveOrderTo(role string, to int) bool {
+	if role == consts.RoleAdmin || role == consts.RoleCashier {
+		return true
+	}
+	for _, status := range orderStatusesByRole[role] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOrderEditable report whether items of an order with the given status could still be changed,
+// once the order is paid the kitchen may already use it so it's final
+func isOrderEditable(status int) bool {
+	return status == consts.StatusNew || status == consts.StatusConfirmed
+}
+
+// isOrderPayable report whether an order with the given status could still be paid (see paymentRepository.Pay), a
+// CONFIRMED order may be prepared and delivered before it's paid so only a PAID, CANCELLED or REFUNDED order isn't
+func isOrderPayable(status int) bool {
+	return status != consts.StatusPaid && status != consts.StatusCancelled && status != consts.StatusRefunded
+}
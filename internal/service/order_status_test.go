@@ -0,0 +1,62 @@
+package service
+
+import (
+	"family-catering/pkg/consts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateOrderStatusTransition(t *testing.T) {
+	type args struct {
+		from int
+		to   int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "NEW to CONFIRMED", args: args{from: consts.StatusNew, to: consts.StatusConfirmed}},
+		{name: "NEW to PAID", args: args{from: consts.StatusNew, to: consts.StatusPaid}},
+		{name: "CONFIRMED to CANCELLED", args: args{from: consts.StatusConfirmed, to: consts.StatusCancelled}},
+		{name: "PAID to PREPARING", args: args{from: consts.StatusPaid, to: consts.StatusPreparing}},
+		{name: "CONFIRMED to PREPARING (paid later)", args: args{from: consts.StatusConfirmed, to: consts.StatusPreparing}},
+		{name: "READY to DELIVERED (self pick-up)", args: args{from: consts.StatusReady, to: consts.StatusDelivered}},
+		{name: "DELIVERED to REFUNDED", args: args{from: consts.StatusDelivered, to: consts.StatusRefunded}},
+		{name: "NEW to PREPARING (not paid yet)", args: args{from: consts.StatusNew, to: consts.StatusPreparing}, wantErr: true},
+		{name: "PAID to CANCELLED (must be refunded)", args: args{from: consts.StatusPaid, to: consts.StatusCancelled}, wantErr: true},
+		{name: "DELIVERED to READY (backward)", args: args{from: consts.StatusDelivered, to: consts.StatusReady}, wantErr: true},
+		{name: "CANCELLED is final", args: args{from: consts.StatusCancelled, to: consts.StatusNew}, wantErr: true},
+		{name: "REFUNDED is final", args: args{from: consts.StatusRefunded, to: consts.StatusPaid}, wantErr: true},
+		{name: "same status", args: args{from: consts.StatusPaid, to: consts.StatusPaid}, wantErr: true},
+		{name: "unknown status", args: args{from: 100, to: consts.StatusPaid}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOrderStatusTransition(tt.args.from, tt.args.to)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_parseOrderStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusName string
+		wantStatus int
+		wantOk     bool
+	}{
+		{name: "upper case", statusName: "OUT_FOR_DELIVERY", wantStatus: consts.StatusOutForDelivery, wantOk: true},
+		{name: "lower case with spaces", statusName: " paid ", wantStatus: consts.StatusPaid, wantOk: true},
+		{name: "unknown", statusName: "EATEN"},
+		{name: "empty", statusName: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStatus, gotOk := parseOrderStatus(tt.statusName)
+			assert.Equal(t, tt.wantStatus, gotStatus)
+			assert.Equal(t, tt.wantOk, gotOk)
+		})
+	}
+}
@@ -2,20 +2,73 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/money"
 	"family-catering/pkg/utils"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestTaxCalculator return a calculator of 11% tax (exclusive) and 5% service charge
+func newTestTaxCalculator() TaxCalculator {
+	taxCalculator, err := NewTaxCalculator(TaxOption{Rate: 11, ServiceChargeRate: 5})
+	if err != nil {
+		panic(err)
+	}
+
+	return taxCalculator
+}
+
+// runRepricer run the repricer given to the repository on the order's rows read by the repository, an error is returned
+// when the discount or the charge isn't the wanted one
+func runRepricer(reprice repository.OrderRepricer, orders []*model.Order, wantDiscount money.Money, wantCharge *model.OrderCharge) error {
+	rows := make([]*model.Order, 0, len(orders))
+	for _, order := range orders {
+		row := *order
+		rows = append(rows, &row)
+	}
+
+	discount, charge, err := reprice(rows)
+	if err != nil {
+		return err
+	}
+	if discount.Cmp(wantDiscount) != 0 || !assert.ObjectsAreEqual(wantCharge, charge) {
+		return fmt.Errorf("oops! unexpected reprice %s %+v", discount, charge)
+	}
+
+	return nil
+}
+
+// newTestDeliveryScheduler return a scheduler of a single 11:00-13:00 delivery slot for at most 20 orders a day
+func newTestDeliveryScheduler() DeliveryScheduler {
+	deliveryScheduler, err := NewDeliveryScheduler(DeliveryOption{Slots: []DeliverySlotOption{{Start: "11:00", End: "13:00", MaxOrders: 20}}})
+	if err != nil {
+		panic(err)
+	}
+
+	return deliveryScheduler
+}
+
 func TestNewOrderService(t *testing.T) {
 	type args struct {
-		orderRepo repository.OrderRepository
-		menuRepo  repository.MenuRepository
+		orderRepo            repository.OrderRepository
+		menuRepo             repository.MenuRepository
+		paymentRepo          repository.PaymentRepository
+		promotionRepo        repository.PromotionRepository
+		customerRepo         repository.CustomerRepository
+		taxCalculator        TaxCalculator
+		deliveryScheduler    DeliveryScheduler
+		deliveryZoneResolver DeliveryZoneResolver
 	}
 	tests := []struct {
 		name string
@@ -23,7 +76,8 @@ func TestNewOrderService(t *testing.T) {
 	}{{name: "success NewOrderService"}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.NotNil(t, NewOrderService(tt.args.orderRepo, tt.args.menuRepo))
+			assert.NotNil(t, NewOrderService(tt.args.orderRepo, tt.args.menuRepo, tt.args.paymentRepo, tt.args.promotionRepo, tt.args.customerRepo, tt.args.taxCalculator,
+				tt.args.deliveryScheduler, tt.args.deliveryZoneResolver))
 		})
 	}
 }
@@ -34,10 +88,16 @@ func Test_orderService_Create(t *testing.T) {
 		req model.CreateOrderRequest
 	}
 	type mocks struct {
-		utMocks       utils.Mock
-		orderRepoMock *repository.MockOrderRepository
-		menuRepoMock  *repository.MockMenuRepository
+		utMocks           utils.Mock
+		orderRepoMock     *repository.MockOrderRepository
+		menuRepoMock      *repository.MockMenuRepository
+		promotionRepoMock *repository.MockPromotionRepository
+		customerRepoMock  *repository.MockCustomerRepository
+		// the zones are resolved by the real resolver (see DeliveryZoneResolver)
+		deliveryZoneRepoMock *repository.MockDeliveryZoneRepository
 	}
+	deliveryDate := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	delivery := &model.OrderDelivery{Date: deliveryDate, Start: "11:00", End: "13:00", MaxOrders: 20}
 	tests := []struct {
 		name         string
 		svc          *orderService
@@ -45,6 +105,8 @@ func Test_orderService_Create(t *testing.T) {
 		prepareMocks func(*mocks)
 		wantResp     *model.CreateOrderResponse
 		wantErr      bool
+		wantErrIs    error
+		wantAPIError string
 	}{
 		{
 			name: "success Create",
@@ -53,7 +115,456 @@ func Test_orderService_Create(t *testing.T) {
 				ctx: context.Background(),
 				req: model.CreateOrderRequest{
 					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{
+						{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"},
+						{ID: 20, Name: "Ayam Penyet", Price: money.MustParse("20000"), Categories: "Indonesian food"},
+					}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(nil, sql.ErrNoRows, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				// the new customer is created along with the order
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), &model.Customer{Email: "test@example.com"}, gomock.AssignableToTypeOf([]*model.Order{}), delivery, &model.OrderCharge{
+					SubTotal: money.MustParse("340000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("17000"), ServiceChargeRate: 500,
+					Tax: money.MustParse("39270"), GrandTotal: money.MustParse("396270"),
+				}).DoAndReturn(func(_ context.Context, _ int64, customer *model.Customer, _ []*model.Order, _ *model.OrderDelivery, _ *model.OrderCharge) (int64, int64, error) {
+					customer.ID = 3
+					return int64(2), int64(1), nil
+				})
+			},
+			wantResp: &model.CreateOrderResponse{
+				OrderID:       1,
+				CustomerID:    3,
+				CustomerEmail: "test@example.com",
+				Message:       "success create orders",
+				Charge: &model.OrderChargeResponse{
+					SubTotal: money.MustParse("340000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("17000"),
+					Tax: money.MustParse("39270"), GrandTotal: money.MustParse("396270"),
+				},
+				Delivery:   &model.OrderDeliveryResponse{Date: deliveryDate, Slot: "11:00-13:00"},
+				TotalPrice: money.MustParse("396270"),
+			},
+		},
+		{
+			name: "success Create (with promo code)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
 					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+					PromoCode:     "hemat10",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{
+						{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"},
+						{ID: 20, Name: "Ayam Penyet", Price: money.MustParse("20000"), Categories: "Indonesian food"},
+					}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.promotionRepoMock.EXPECT().GetByCode(context.Background(), int64(1), "HEMAT10").Return(&model.Promotion{
+					ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, MaxDiscount: money.MustParse("30000"), Active: true,
+				}, nil, nil)
+				m.orderRepoMock.EXPECT().CreateWithDiscount(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), delivery, &model.OrderDiscount{
+					PromotionID: 1, Code: "HEMAT10", CustomerEmail: "test@example.com", Amount: money.MustParse("30000"),
+				}, &model.OrderCharge{
+					SubTotal: money.MustParse("340000"), Discount: money.MustParse("30000"), ServiceCharge: money.MustParse("15500"), ServiceChargeRate: 500,
+					Tax: money.MustParse("35805"), GrandTotal: money.MustParse("361305"),
+				}).Return(int64(2), int64(1), nil)
+			},
+			wantResp: &model.CreateOrderResponse{
+				OrderID:       1,
+				CustomerID:    3,
+				CustomerEmail: "test@example.com",
+				Message:       "success create orders",
+				Discount:      &model.OrderDiscountResponse{PromoCode: "HEMAT10", Amount: money.MustParse("30000")},
+				Charge: &model.OrderChargeResponse{
+					SubTotal: money.MustParse("340000"), Discount: money.MustParse("30000"), ServiceCharge: money.MustParse("15500"),
+					Tax: money.MustParse("35805"), GrandTotal: money.MustParse("361305"),
+				},
+				Delivery:   &model.OrderDeliveryResponse{Date: deliveryDate, Slot: "11:00-13:00"},
+				TotalPrice: money.MustParse("361305"),
+			},
+		},
+		{
+			name: "success Create (existing customer)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerID:   3,
+					DeliveryDate: deliveryDate,
+					DeliverySlot: "11:00-13:00",
+					Orders:       []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByID(context.Background(), int64(1), int64(3)).
+					Return(&model.Customer{ID: 3, Name: "Budi", Email: "budi@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), []*model.Order{{
+					CustomerID: 3, CustomerEmail: "budi@example.com", MenuName: "Sop Iga", MenuID: 83, Price: money.MustParse("60000"), Qty: 4, Status: consts.StatusNew,
+				}}, delivery, gomock.AssignableToTypeOf(&model.OrderCharge{})).Return(int64(1), int64(1), nil)
+			},
+			wantResp: &model.CreateOrderResponse{
+				OrderID:       1,
+				CustomerID:    3,
+				CustomerEmail: "budi@example.com",
+				Message:       "success create orders",
+				Charge: &model.OrderChargeResponse{
+					SubTotal: money.MustParse("240000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("12000"),
+					Tax: money.MustParse("27720"), GrandTotal: money.MustParse("279720"),
+				},
+				Delivery:   &model.OrderDeliveryResponse{Date: deliveryDate, Slot: "11:00-13:00"},
+				TotalPrice: money.MustParse("279720"),
+			},
+		},
+		{
+			name: "success Create (delivery zone)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail:   "test@example.com",
+					DeliveryDate:    deliveryDate,
+					DeliverySlot:    "11:00-13:00",
+					DeliveryAddress: &model.DeliveryAddressRequest{Address: " Jl. Kenanga 5 ", PostalCode: "12430"},
+					Orders:          []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{
+						{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"},
+						{ID: 20, Name: "Ayam Penyet", Price: money.MustParse("20000"), Categories: "Indonesian food"},
+					}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return([]*model.DeliveryZone{
+					{ID: 1, Name: "Central Jakarta", PostalCodes: "10110,10220", Fee: money.MustParse("5000")},
+					{ID: 2, Name: "South Jakarta", PostalCodes: "12430,12440", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000")},
+				}, nil, nil)
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), &model.OrderDelivery{
+					Date: deliveryDate, Start: "11:00", End: "13:00", MaxOrders: 20,
+					Destination: model.DeliveryAddress{Address: "Jl. Kenanga 5", PostalCode: "12430"}, ZoneID: 2,
+				}, &model.OrderCharge{
+					SubTotal: money.MustParse("340000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("17000"), ServiceChargeRate: 500,
+					Tax: money.MustParse("39270"), DeliveryFee: money.MustParse("10000"), GrandTotal: money.MustParse("406270"),
+				}).Return(int64(2), int64(1), nil)
+			},
+			wantResp: &model.CreateOrderResponse{
+				OrderID:       1,
+				CustomerID:    3,
+				CustomerEmail: "test@example.com",
+				Message:       "success create orders",
+				Charge: &model.OrderChargeResponse{
+					SubTotal: money.MustParse("340000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("17000"),
+					Tax: money.MustParse("39270"), DeliveryFee: money.MustParse("10000"), GrandTotal: money.MustParse("406270"),
+				},
+				Delivery: &model.OrderDeliveryResponse{
+					Date: deliveryDate, Slot: "11:00-13:00", ZoneID: 2,
+					Address: &model.DeliveryAddressResponse{Address: "Jl. Kenanga 5", PostalCode: "12430"},
+				},
+				TotalPrice: money.MustParse("406270"),
+			},
+		},
+		{
+			name: "success Create (default address in a polygon zone)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerID:   3,
+					DeliveryDate: deliveryDate,
+					DeliverySlot: "11:00-13:00",
+					Orders:       []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByID(context.Background(), int64(1), int64(3)).
+					Return(&model.Customer{ID: 3, Name: "Budi", Email: "budi@example.com", Addresses: []*model.CustomerAddress{
+						{ID: 5, CustomerID: 3, Address: "Jl. Sudirman 1", Latitude: sql.NullFloat64{Float64: -6.22, Valid: true},
+							Longitude: sql.NullFloat64{Float64: 106.82, Valid: true}, IsDefault: true},
+						{ID: 4, CustomerID: 3, Address: "Jl. Kenanga 5", PostalCode: "12430"},
+					}}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return([]*model.DeliveryZone{
+					{ID: 2, Name: "South Jakarta", PostalCodes: "12430", Fee: money.MustParse("10000")},
+					{ID: 3, Name: "Sudirman", Polygon: sql.NullString{String: "[[-6.2, 106.8], [-6.2, 106.85], [-6.25, 106.85], [-6.25, 106.8]]", Valid: true},
+						Fee: money.MustParse("8000")},
+				}, nil, nil)
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), &model.OrderDelivery{
+					Date: deliveryDate, Start: "11:00", End: "13:00", MaxOrders: 20,
+					Destination: model.DeliveryAddress{
+						Address: "Jl. Sudirman 1", Latitude: sql.NullFloat64{Float64: -6.22, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.82, Valid: true},
+					},
+					ZoneID: 3,
+				}, gomock.AssignableToTypeOf(&model.OrderCharge{})).Return(int64(1), int64(1), nil)
+			},
+			wantResp: &model.CreateOrderResponse{
+				OrderID:       1,
+				CustomerID:    3,
+				CustomerEmail: "budi@example.com",
+				Message:       "success create orders",
+				Charge: &model.OrderChargeResponse{
+					SubTotal: money.MustParse("240000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("12000"),
+					Tax: money.MustParse("27720"), DeliveryFee: money.MustParse("8000"), GrandTotal: money.MustParse("287720"),
+				},
+				Delivery: &model.OrderDeliveryResponse{
+					Date: deliveryDate, Slot: "11:00-13:00", ZoneID: 3,
+					Address: &model.DeliveryAddressResponse{Address: "Jl. Sudirman 1", Latitude: func() *float64 { f := -6.22; return &f }(),
+						Longitude: func() *float64 { f := 106.82; return &f }()},
+				},
+				TotalPrice: money.MustParse("287720"),
+			},
+		},
+		{
+			name: "fail Create (out of delivery area)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail:   "test@example.com",
+					DeliveryDate:    deliveryDate,
+					DeliverySlot:    "11:00-13:00",
+					DeliveryAddress: &model.DeliveryAddressRequest{Address: "Jl. Merdeka 1", PostalCode: "40111"},
+					Orders:          []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				// the unregistered customer isn't created as the order is rejected
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(nil, sql.ErrNoRows, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return([]*model.DeliveryZone{
+					{ID: 2, Name: "South Jakarta", PostalCodes: "12430,12440", Fee: money.MustParse("10000")},
+				}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (below the zone's minimum order)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail:   "test@example.com",
+					DeliveryDate:    deliveryDate,
+					DeliverySlot:    "11:00-13:00",
+					DeliveryAddress: &model.DeliveryAddressRequest{Address: "Jl. Kenanga 5", PostalCode: "12430"},
+					Orders:          []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 1}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return([]*model.DeliveryZone{
+					{ID: 2, Name: "South Jakarta", PostalCodes: "12430", Fee: money.MustParse("10000"), MinOrder: money.MustParse("100000")},
+				}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (missing delivery address)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 1}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return([]*model.DeliveryZone{
+					{ID: 2, Name: "South Jakarta", PostalCodes: "12430", Fee: money.MustParse("10000")},
+				}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (customer not found)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerID:   3,
+					DeliveryDate: deliveryDate,
+					DeliverySlot: "11:00-13:00",
+					Orders:       []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByID(context.Background(), int64(1), int64(3)).Return(nil, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (missing customer)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					DeliveryDate: deliveryDate,
+					DeliverySlot: "11:00-13:00",
+					Orders:       []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (promo code is not active)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+					PromoCode:     "HEMAT10",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000")}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.promotionRepoMock.EXPECT().GetByCode(context.Background(), int64(1), "HEMAT10").Return(&model.Promotion{
+					ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, Active: false,
+				}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (below promo code's minimum spend)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 1}},
+					PromoCode:     "HEMAT10",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000")}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.promotionRepoMock.EXPECT().GetByCode(context.Background(), int64(1), "HEMAT10").Return(&model.Promotion{
+					ID: 1, Code: "HEMAT10", DiscountType: "fixed", Amount: money.MustParse("10000"), MinSpend: money.MustParse("100000"), Active: true,
+				}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (promo code's usage limit reached)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 1}},
+					PromoCode:     "HEMAT10",
 				},
 			},
 			prepareMocks: func(m *mocks) {
@@ -61,89 +572,1212 @@ func Test_orderService_Create(t *testing.T) {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000")}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.promotionRepoMock.EXPECT().GetByCode(context.Background(), int64(1), "HEMAT10").Return(&model.Promotion{
+					ID: 1, Code: "HEMAT10", DiscountType: "fixed", Amount: money.MustParse("10000"), UsageLimitPerCustomer: 1, Active: true,
+				}, nil, nil)
+				m.orderRepoMock.EXPECT().CreateWithDiscount(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), delivery, gomock.Any(), gomock.Any()).
+					Return(int64(0), int64(0), fmt.Errorf("repository.orderRepository.CreateWithDiscount: %w", repository.ErrPromotionUsageLimit))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (partially/all no row)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "not-exists-menu-name", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{
+						{ID: 20, Name: "Ayam Penyet", Price: money.MustParse("20000"), Categories: "Indonesian food"},
+					}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (invalid/no token)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{
+						{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"},
+						{ID: 20, Name: "Ayam Penyet", Price: money.MustParse("20000"), Categories: "Indonesian food"},
+					}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), delivery, gomock.AssignableToTypeOf(&model.OrderCharge{})).Return(int64(0), int64(0), errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (menu sold out)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{
+						{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"},
+						{ID: 20, Name: "Ayam Penyet", Price: money.MustParse("20000"), Categories: "Indonesian food"},
+					}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), delivery, gomock.AssignableToTypeOf(&model.OrderCharge{})).
+					Return(int64(0), int64(0), fmt.Errorf("repository.orderRepository.Create: %w", &repository.MenuSoldOutError{MenuID: 20, Remaining: 3}))
+			},
+			wantErr:      true,
+			wantErrIs:    apperrors.ErrMenuSoldOut,
+			wantAPIError: "menu Ayam Penyet is sold out, 3 portion(s) left",
+		},
+		{
+			name: "fail Create (delivery slot is full)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "11:00-13:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.menuRepoMock.EXPECT().Search(context.Background(), int64(1), gomock.AssignableToTypeOf(model.MenuQuery{})).
+					Return([]*model.Menu{{ID: 83, Name: "Sop Iga", Price: money.MustParse("60000"), Categories: "Indonesian food"}}, nil, nil)
+				m.customerRepoMock.EXPECT().GetByEmail(context.Background(), int64(1), "test@example.com").Return(&model.Customer{ID: 3, Email: "test@example.com"}, nil, nil)
+				m.deliveryZoneRepoMock.EXPECT().ListActive(context.Background(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+				m.orderRepoMock.EXPECT().Create(context.Background(), int64(1), gomock.AssignableToTypeOf(&model.Customer{}), gomock.AssignableToTypeOf([]*model.Order{}), delivery, gomock.AssignableToTypeOf(&model.OrderCharge{})).
+					Return(int64(0), int64(0), fmt.Errorf("repository.orderRepository.Create: %w", repository.ErrDeliverySlotFull))
+			},
+			wantErr:      true,
+			wantErrIs:    apperrors.ErrDeliverySlotFull,
+			wantAPIError: "delivery slot is full, please choose another slot",
+		},
+		{
+			name: "fail Create (delivery slot is not found)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					DeliveryDate:  deliveryDate,
+					DeliverySlot:  "14:00-16:00",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Create (missing delivery date)",
+			svc:  &orderService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOrderRequest{
+					CustomerEmail: "test@example.com",
+					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}},
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMock := utils.InitMock()
+			menuRepoMock := repository.NewMockMenuRepository(ctrl)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			promotionRepoMock := repository.NewMockPromotionRepository(ctrl)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			deliveryZoneRepoMock := repository.NewMockDeliveryZoneRepository(ctrl)
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{menuRepoMock: menuRepoMock, orderRepoMock: orderRepoMock, promotionRepoMock: promotionRepoMock,
+					customerRepoMock: customerRepoMock, deliveryZoneRepoMock: deliveryZoneRepoMock, utMocks: utMock})
+			}
+
+			tt.svc.menuRepo = menuRepoMock
+			tt.svc.orderRepo = orderRepoMock
+			tt.svc.promotionRepo = promotionRepoMock
+			tt.svc.customerRepo = customerRepoMock
+			tt.svc.taxCalculator = newTestTaxCalculator()
+			tt.svc.deliveryScheduler = newTestDeliveryScheduler()
+			tt.svc.deliveryZoneResolver = NewDeliveryZoneResolver(deliveryZoneRepoMock)
+
+			gotResp, err := tt.svc.Create(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, gotResp)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				var apiErr apperrors.APIError
+				assert.ErrorAs(t, err, &apiErr)
+				code, message := apiErr.APIError()
+				assert.Equal(t, http.StatusConflict, code)
+				assert.Equal(t, tt.wantAPIError, message)
+			}
+
+			utMock.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_CancelUnpaidOrder(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.CancelUnpaidOrderResponse
+		wantErr      bool
+	}{
+		{
+			name: "success CancelUnpaidOrder",
+			svc:  &orderService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.orderRepoMock.EXPECT().CancelUnpaidOrder(context.Background()).Return(int64(5), nil)
+			},
+			wantResp: &model.CancelUnpaidOrderResponse{
+				Message:             "success cancel unpaid order",
+				TotalOrderCancelled: 5,
+			},
+		},
+		{
+			name: "fail CancelUnpaidOrder",
+			svc:  &orderService{},
+			args: args{ctx: context.Background()},
+			prepareMocks: func(m *mocks) {
+				m.orderRepoMock.EXPECT().CancelUnpaidOrder(context.Background()).Return(int64(0), errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+
+			gotResp, err := tt.svc.CancelUnpaidOrder(tt.args.ctx)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_ConfirmPayment(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.ConfirmPaymentRequest
+	}
+	type mocks struct {
+		utMocks         utils.Mock
+		paymentRepoMock *repository.MockPaymentRepository
+	}
+	validContext := func(_ context.Context, key string) interface{} {
+		if key == consts.CtxKeySession {
+			return &model.AuthSessionResponse{OwnerID: 1, Valid: true}
+		}
+		return "access-token"
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.ConfirmPaymentResponse
+		wantErr      bool
+	}{
+		{
+			name: "success ConfirmPayment (partial payment)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("20000")}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(1), &model.Payment{
+						OrderID:    1,
+						Amount:     money.MustParse("20000"),
+						Method:     "cash",
+						ReceivedBy: sql.NullInt64{Int64: 1, Valid: true},
+					}).
+					Return(&model.PaymentSummary{PaymentID: 1, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: consts.StatusNew}, nil, nil)
+			},
+			wantResp: &model.ConfirmPaymentResponse{PaymentID: 1, OrderID: 1, Amount: money.MustParse("20000"), TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), RemainingBalance: money.MustParse("30000"), Status: "NEW"},
+		},
+		{
+			name: "success ConfirmPayment (paid off)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("30000"), Method: "transfer", ReferenceNumber: "TRX-001"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(1), &model.Payment{
+						OrderID:         1,
+						Amount:          money.MustParse("30000"),
+						Method:          "transfer",
+						ReferenceNumber: sql.NullString{String: "TRX-001", Valid: true},
+						ReceivedBy:      sql.NullInt64{Int64: 1, Valid: true},
+					}).
+					Return(&model.PaymentSummary{PaymentID: 2, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: consts.StatusPaid}, nil, nil)
+			},
+			wantResp: &model.ConfirmPaymentResponse{PaymentID: 2, OrderID: 1, Amount: money.MustParse("30000"), TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), RemainingBalance: money.MustParse("0"), Status: "PAID"},
+		},
+		{
+			name: "fail ConfirmPayment (exceeds remaining balance)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("60000")}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.Any()).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusNew}, nil, fmt.Errorf("oops! %w", repository.ErrPaymentExceedsBalance))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail ConfirmPayment (order is not payable)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("10000")}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.Any()).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusCancelled}, nil, fmt.Errorf("oops! %w", repository.ErrOrderNotPayable))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail ConfirmPayment (order not found)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{OrderID: 1_000_000_000, Amount: money.MustParse("10000")}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.Any()).
+					Return(nil, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail ConfirmPayment (invalid/no token)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{OrderID: 1, Amount: money.MustParse("10000")}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			paymentRepoMock := repository.NewMockPaymentRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{paymentRepoMock: paymentRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.paymentRepo = paymentRepoMock
+
+			gotResp, err := tt.svc.ConfirmPayment(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_ConfirmProviderPayment(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		payment *model.Payment
+	}
+	type mocks struct {
+		paymentRepoMock *repository.MockPaymentRepository
+	}
+	payment := &model.Payment{
+		OrderID:         1,
+		Amount:          money.MustParse("50000"),
+		Method:          "card",
+		ReferenceNumber: sql.NullString{String: "ch_1", Valid: true},
+		Provider:        sql.NullString{String: "fake", Valid: true},
+		ProviderEventID: sql.NullString{String: "evt_1", Valid: true},
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.ConfirmPaymentResponse
+		wantErr      error
+	}{
+		{
+			name: "success ConfirmProviderPayment",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), payment: payment},
+			prepareMocks: func(m *mocks) {
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(0), payment).
+					Return(&model.PaymentSummary{PaymentID: 1, OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: consts.StatusPaid}, nil, nil)
+			},
+			wantResp: &model.ConfirmPaymentResponse{PaymentID: 1, OrderID: 1, Amount: money.MustParse("50000"), TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), RemainingBalance: money.MustParse("0"), Status: "PAID"},
+		},
+		{
+			name: "fail ConfirmProviderPayment (replayed event)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), payment: payment},
+			prepareMocks: func(m *mocks) {
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(0), payment).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusPaid}, nil, fmt.Errorf("oops! %w", repository.ErrDuplicatePaymentEvent))
+			},
+			wantErr: repository.ErrDuplicatePaymentEvent,
+		},
+		{
+			name: "fail ConfirmProviderPayment (order is not payable)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), payment: payment},
+			prepareMocks: func(m *mocks) {
+				m.paymentRepoMock.EXPECT().
+					Pay(gomock.AssignableToTypeOf(context.Background()), int64(0), payment).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusCancelled}, nil, fmt.Errorf("oops! %w", repository.ErrOrderNotPayable))
+			},
+			wantErr: repository.ErrOrderNotPayable,
+		},
+		{
+			name:    "fail ConfirmProviderPayment (missing provider event id)",
+			svc:     &orderService{},
+			args:    args{ctx: context.Background(), payment: &model.Payment{OrderID: 1, Amount: money.MustParse("50000"), Method: "card"}},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			paymentRepoMock := repository.NewMockPaymentRepository(ctrl)
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{paymentRepoMock: paymentRepoMock})
+			}
+
+			tt.svc.paymentRepo = paymentRepoMock
+
+			gotResp, err := tt.svc.ConfirmProviderPayment(tt.args.ctx, tt.args.payment)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+		})
+	}
+}
+
+func Test_orderService_Search(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.OrderQuery
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.SearchOrdersResponse
+		wantErr      bool
+	}{
+		{
+			name: "success Search",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"test1@example.com", "test2@example.com"}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().
+					Search(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(model.OrderQuery{})).
+					Return([]*model.Order{
+						{
+							OrderID:       1,
+							CustomerEmail: "test1@example.com",
+							MenuName:      "nasi kepal isi salmon",
+							Price:         money.MustParse("44000"),
+							Qty:           2,
+							Status:        2,
+						},
+						{
+							OrderID:       2,
+							CustomerEmail: "test2@example.com",
+							MenuName:      "soto kambing",
+							Price:         money.MustParse("30000"),
+							Qty:           1,
+							Status:        2,
+						},
+						{
+							OrderID:       2,
+							CustomerEmail: "test2@example.com",
+							MenuName:      "nasi lemak",
+							Price:         money.MustParse("20000"),
+							Qty:           2,
+							Status:        2,
+						},
+					}, nil, nil)
+				m.orderRepoMock.EXPECT().GetCharges(gomock.AssignableToTypeOf(context.Background()), int64(1), []int64{1, 2}).
+					Return([]*model.OrderCharge{
+						{OrderID: 1, SubTotal: money.MustParse("88000"), Discount: money.FromMinor(0), GrandTotal: money.MustParse("88000")},
+						{
+							OrderID: 2, SubTotal: money.MustParse("70000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("3500"), ServiceChargeRate: 500,
+							Tax: money.MustParse("8085"), GrandTotal: money.MustParse("81585"),
+						},
+					}, nil)
+			},
+			wantResp: &model.SearchOrdersResponse{
+				Orders: []*model.SearchResponse{
+					{
+						OrderID:       1,
+						CustomerEmail: "test1@example.com",
+						MenuName:      "nasi kepal isi salmon",
+						Price:         money.MustParse("44000"),
+						Qty:           2,
+						Status:        2,
+					},
+					{
+						OrderID:       2,
+						CustomerEmail: "test2@example.com",
+						MenuName:      "soto kambing",
+						Price:         money.MustParse("30000"),
+						Qty:           1,
+						Status:        2,
+					},
+					{
+						OrderID:       2,
+						CustomerEmail: "test2@example.com",
+						MenuName:      "nasi lemak",
+						Price:         money.MustParse("20000"),
+						Qty:           2,
+						Status:        2,
+					},
+				},
+				TotalPrice: money.MustParse("158000"),
+				Charges: []*model.OrderChargeResponse{
+					{OrderID: 1, SubTotal: money.MustParse("88000"), Discount: money.FromMinor(0), GrandTotal: money.MustParse("88000")},
+					{
+						OrderID: 2, SubTotal: money.MustParse("70000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("3500"),
+						Tax: money.MustParse("8085"), GrandTotal: money.MustParse("81585"),
+					},
+				},
+				GrandTotal: money.MustParse("169585"),
+			},
+		},
+		{
+			name: "fail Search (partially/no rows)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"not.found@example.com"}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().
+					Search(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(model.OrderQuery{})).
+					Return(nil, errors.New("oops! no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Search (invalid/no token)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"not.found@example.com"}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Search ",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"not.found@example.com"}}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().
+					Search(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(model.OrderQuery{})).
+					Return(nil, nil, errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+
+			gotResp, err := tt.svc.Search(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_UpdateStatus(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		orderID int64
+		req     model.UpdateOrderStatusRequest
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	validContext := func(_ context.Context, key string) interface{} {
+		if key == consts.CtxKeySession {
+			return &model.AuthSessionResponse{OwnerID: 1, Valid: true}
+		}
+		return "access-token"
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.UpdateOrderStatusResponse
+		wantErr      bool
+		wantErrIs    error
+	}{
+		{
+			name: "success UpdateStatus",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "preparing"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleCashier}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusPaid, nil, nil)
+				m.orderRepoMock.EXPECT().UpdateStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), consts.StatusPaid, consts.StatusPreparing, int64(1)).Return(int64(1), nil, nil)
+			},
+			wantResp: &model.UpdateOrderStatusResponse{OrderID: 1, PreviousStatus: "PAID", Status: "PREPARING"},
+		},
+		{
+			name: "success UpdateStatus (driver delivers)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "DELIVERED"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleDriver}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusOutForDelivery, nil, nil)
+				m.orderRepoMock.EXPECT().UpdateStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), consts.StatusOutForDelivery, consts.StatusDelivered, int64(1)).Return(int64(1), nil, nil)
+			},
+			wantResp: &model.UpdateOrderStatusResponse{OrderID: 1, PreviousStatus: "OUT_FOR_DELIVERY", Status: "DELIVERED"},
+		},
+		{
+			// cancelling is for the cashiers and admins (see DELETE /order/{order_id})
+			name: "fail UpdateStatus (kitchen cancels)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "CANCELLED"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleKitchen}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+			},
+			wantErr:   true,
+			wantErrIs: apperrors.ErrForbidden,
+		},
+		{
+			name: "fail UpdateStatus (driver refunds)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "REFUNDED"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleDriver}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+			},
+			wantErr:   true,
+			wantErrIs: apperrors.ErrForbidden,
+		},
+		{
+			name: "fail UpdateStatus (invalid transition)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "PREPARING"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleCashier}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusNew, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateStatus (status changed concurrently)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "CONFIRMED"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleCashier}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusNew, nil, nil)
+				m.orderRepoMock.EXPECT().UpdateStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), consts.StatusNew, consts.StatusConfirmed, int64(1)).Return(int64(0), errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateStatus (PAID is only set by payment)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "PAID"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleCashier}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateStatus (order not found)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1_000_000_000, req: model.UpdateOrderStatusRequest{Status: "CONFIRMED"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleCashier}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1_000_000_000)).Return(0, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateStatus (unknown status)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "EATEN"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1, Role: consts.RoleCashier}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail UpdateStatus (invalid session)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderStatusRequest{Status: "PAID"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(_ context.Context, key string) interface{} {
+					if key == consts.CtxKeySession {
+						return &model.AuthSessionResponse{Valid: false}
+					}
+					return "access-token"
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+
+			gotResp, err := tt.svc.UpdateStatus(tt.args.ctx, tt.args.orderID, tt.args.req)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_StatusHistory(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		orderID int64
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     []*model.OrderStatusHistoryResponse
+		wantErr      bool
+	}{
+		{
+			name: "success StatusHistory",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().StatusHistory(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return([]*model.OrderStatusHistory{
+						{ID: 1, OrderID: 1, ToStatus: consts.StatusNew, CreatedAt: "2022-11-10 10:00:00"},
+						{
+							ID:         2,
+							OrderID:    1,
+							FromStatus: sql.NullInt32{Int32: int32(consts.StatusNew), Valid: true},
+							ToStatus:   consts.StatusPaid,
+							ChangedBy:  sql.NullInt64{Int64: 1, Valid: true},
+							CreatedAt:  "2022-11-10 11:00:00",
+						},
+					}, nil, nil)
+			},
+			wantResp: []*model.OrderStatusHistoryResponse{
+				{ToStatus: "NEW", CreatedAt: "2022-11-10 10:00:00"},
+				{FromStatus: "NEW", ToStatus: "PAID", ChangedBy: 1, CreatedAt: "2022-11-10 11:00:00"},
+			},
+		},
+		{
+			name: "fail StatusHistory (no rows)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1_000_000_000},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().StatusHistory(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1_000_000_000)).Return(nil, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail StatusHistory (invalid/no token)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+
+			gotResp, err := tt.svc.StatusHistory(tt.args.ctx, tt.args.orderID)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_Get(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		orderID int64
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.OrderDetailResponse
+		wantErr      bool
+	}{
+		{
+			name: "success Get",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return([]*model.Order{
+						{BaseOrderID: 1, OrderID: 1, CustomerEmail: "test@example.com", MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: consts.StatusNew, CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 10:00:00",
+							ServiceCharge: money.MustParse("3250"), Tax: money.MustParse("7507.50")},
+						{BaseOrderID: 2, OrderID: 1, CustomerEmail: "test@example.com", MenuID: 2, MenuName: "es teh", Price: money.MustParse("5000"), Qty: 3, Status: consts.StatusNew, CreatedAt: "2022-11-10 10:00:00", UpdatedAt: "2022-11-10 11:00:00",
+							ServiceCharge: money.MustParse("3250"), Tax: money.MustParse("7507.50")},
+					}, nil, nil)
+			},
+			wantResp: &model.OrderDetailResponse{
+				OrderID:       1,
+				CustomerEmail: "test@example.com",
+				Status:        "NEW",
+				Items: []*model.OrderItemResponse{
+					{BaseOrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, SubTotal: money.MustParse("50000")},
+					{BaseOrderID: 2, MenuID: 2, MenuName: "es teh", Price: money.MustParse("5000"), Qty: 3, SubTotal: money.MustParse("15000")},
+				},
+				Charge: &model.OrderChargeResponse{
+					SubTotal: money.MustParse("65000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("3250"),
+					Tax: money.MustParse("7507.50"), GrandTotal: money.MustParse("75757.50"),
+				},
+				TotalPrice: money.MustParse("75757.50"),
+				CreatedAt:  "2022-11-10 10:00:00",
+				UpdatedAt:  "2022-11-10 11:00:00",
+			},
+		},
+		{
+			name: "fail Get (not found)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1_000_000_000},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1_000_000_000)).Return(nil, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Get (order of another business)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 2}, nil
+				})
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(2), int64(1)).Return(nil, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Get (invalid/no token)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+
+			gotResp, err := tt.svc.Get(tt.args.ctx, tt.args.orderID)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_Update(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		orderID int64
+		req     model.UpdateOrderRequest
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock    *repository.MockOrderRepository
+		customerRepoMock *repository.MockCustomerRepository
+	}
+	newOrder := []*model.Order{{BaseOrderID: 1, OrderID: 1, CustomerEmail: "test@example.com", Price: money.MustParse("25000"), Qty: 2, Status: consts.StatusNew}}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantErr      bool
+	}{
+		{
+			name: "success Update",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderRequest{CustomerEmail: "new@example.com"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().Search(context.Background(), gomock.AssignableToTypeOf(model.MenuQuery{})).
-					Return([]*model.Menu{
-						{ID: 83, Name: "Sop Iga", Price: 60_000, Categories: "Indonesian food"},
-						{ID: 20, Name: "Ayam Penyet", Price: 20_000, Categories: "Indonesian food"},
-					}, nil, nil)
-				m.orderRepoMock.EXPECT().Create(context.Background(), gomock.AssignableToTypeOf([]*model.Order{})).Return(int64(2), int64(1), nil)
-			},
-			wantResp: &model.CreateOrderResponse{
-				OrderID:       1,
-				CustomerEmail: "test@example.com",
-				Message:       "success create orders",
-				TotalPrice:    340_000,
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				gomock.InOrder(
+					m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil),
+					m.customerRepoMock.EXPECT().FindOrCreate(gomock.AssignableToTypeOf(context.Background()), int64(1), model.Customer{Email: "new@example.com"}).Return(int64(3), nil),
+					m.orderRepoMock.EXPECT().UpdateCustomer(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), int64(3), "new@example.com").Return(int64(1), nil, nil),
+					m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil),
+				)
 			},
 		},
 		{
-			name: "fail Create (partially/all no row)",
+			name: "fail Update (order is paid)",
 			svc:  &orderService{},
-			args: args{
-				ctx: context.Background(),
-				req: model.CreateOrderRequest{
-					CustomerEmail: "test@example.com",
-					Orders:        []model.BaseOrderRequest{{Name: "not-exists-menu-name", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
-				},
-			},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderRequest{CustomerEmail: "new@example.com"}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().Search(context.Background(), gomock.AssignableToTypeOf(model.MenuQuery{})).
-					Return([]*model.Menu{
-						{ID: 20, Name: "Ayam Penyet", Price: 20_000, Categories: "Indonesian food"},
-					}, nil, nil)
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return([]*model.Order{{BaseOrderID: 1, OrderID: 1, Status: consts.StatusPaid}}, nil, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail Create (invalid/no token)",
+			name: "fail Update (status changed concurrently)",
 			svc:  &orderService{},
-			args: args{
-				ctx: context.Background(),
-				req: model.CreateOrderRequest{
-					CustomerEmail: "test@example.com",
-					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
-				},
-			},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderRequest{CustomerEmail: "new@example.com"}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
-					return "invalid-token"
+					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return nil, errors.New("oops! invalid token")
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil)
+				m.customerRepoMock.EXPECT().FindOrCreate(gomock.AssignableToTypeOf(context.Background()), int64(1), model.Customer{Email: "new@example.com"}).Return(int64(3), nil)
+				m.orderRepoMock.EXPECT().UpdateCustomer(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), int64(3), "new@example.com").Return(int64(0), errors.New("oops! error no rows"), nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail Create",
+			name: "fail Update (invalid request)",
 			svc:  &orderService{},
-			args: args{
-				ctx: context.Background(),
-				req: model.CreateOrderRequest{
-					CustomerEmail: "test@example.com",
-					Orders:        []model.BaseOrderRequest{{Name: "Sop Iga", Qty: 4}, {Name: "Ayam Penyet", Qty: 5}},
-				},
-			},
+			args: args{ctx: context.Background(), orderID: 1, req: model.UpdateOrderRequest{CustomerEmail: "not-an-email"}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.menuRepoMock.EXPECT().Search(context.Background(), gomock.AssignableToTypeOf(model.MenuQuery{})).
-					Return([]*model.Menu{
-						{ID: 83, Name: "Sop Iga", Price: 60_000, Categories: "Indonesian food"},
-						{ID: 20, Name: "Ayam Penyet", Price: 20_000, Categories: "Indonesian food"},
-					}, nil, nil)
-				m.orderRepoMock.EXPECT().Create(context.Background(), gomock.AssignableToTypeOf([]*model.Order{})).Return(int64(0), int64(0), errors.New("oops! db error"))
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return errors.New("oops! invalid email") })
 			},
 			wantErr: true,
 		},
@@ -151,61 +1785,109 @@ func Test_orderService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			utMock := utils.InitMock()
-			menuRepoMock := repository.NewMockMenuRepository(ctrl)
 			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			customerRepoMock := repository.NewMockCustomerRepository(ctrl)
+			utMocks := utils.InitMock()
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{menuRepoMock: menuRepoMock, orderRepoMock: orderRepoMock, utMocks: utMock})
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, customerRepoMock: customerRepoMock, utMocks: utMocks})
 			}
 
-			tt.svc.menuRepo = menuRepoMock
 			tt.svc.orderRepo = orderRepoMock
+			tt.svc.customerRepo = customerRepoMock
 
-			gotResp, err := tt.svc.Create(tt.args.ctx, tt.args.req)
+			gotResp, err := tt.svc.Update(tt.args.ctx, tt.args.orderID, tt.args.req)
 
 			assert.Equal(t, tt.wantErr, err != nil)
-			assert.Equal(t, tt.wantResp, gotResp)
+			assert.Equal(t, tt.wantErr, gotResp == nil)
 
-			utMock.UnpatchAll()
+			utMocks.UnpatchAll()
 		})
 	}
 }
 
-func Test_orderService_CancelUnpaidOrder(t *testing.T) {
+func Test_orderService_Cancel(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx     context.Context
+		orderID int64
 	}
 	type mocks struct {
-		utMocks       utils.Mock
-		orderRepoMock *repository.MockOrderRepository
+		utMocks         utils.Mock
+		orderRepoMock   *repository.MockOrderRepository
+		paymentRepoMock *repository.MockPaymentRepository
+	}
+	validContext := func(_ context.Context, key string) interface{} {
+		if key == consts.CtxKeySession {
+			return &model.AuthSessionResponse{OwnerID: 1, Valid: true}
+		}
+		return "access-token"
 	}
 	tests := []struct {
 		name         string
 		svc          *orderService
 		args         args
 		prepareMocks func(*mocks)
-		wantResp     *model.CancelUnpaidOrderResponse
+		wantResp     *model.UpdateOrderStatusResponse
 		wantErr      bool
+		wantErrIs    error
 	}{
 		{
-			name: "success CancelUnpaidOrder",
+			name: "success Cancel",
 			svc:  &orderService{},
-			args: args{ctx: context.Background()},
+			args: args{ctx: context.Background(), orderID: 1},
 			prepareMocks: func(m *mocks) {
-				m.orderRepoMock.EXPECT().CancelUnpaidOrder(context.Background()).Return(int64(5), nil)
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusConfirmed, nil, nil)
+				m.paymentRepoMock.EXPECT().Balance(gomock.Any(), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusConfirmed}, nil, nil)
+				m.orderRepoMock.EXPECT().UpdateStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), consts.StatusConfirmed, consts.StatusCancelled, int64(1)).Return(int64(1), nil, nil)
 			},
-			wantResp: &model.CancelUnpaidOrderResponse{
-				Message:             "success cancel unpaid order",
-				TotalOrderCancelled: 5,
+			wantResp: &model.UpdateOrderStatusResponse{OrderID: 1, PreviousStatus: "CONFIRMED", Status: "CANCELLED"},
+		},
+		{
+			// its payment would be left without refund
+			name: "fail Cancel (order is partly paid)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusNew, nil, nil)
+				m.paymentRepoMock.EXPECT().Balance(gomock.Any(), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: consts.StatusNew}, nil, nil)
 			},
+			wantErr:   true,
+			wantErrIs: apperrors.ErrOrderStatusTransition,
 		},
 		{
-			name: "fail CancelUnpaidOrder",
+			name: "fail Cancel (order is paid)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background()},
+			args: args{ctx: context.Background(), orderID: 1},
 			prepareMocks: func(m *mocks) {
-				m.orderRepoMock.EXPECT().CancelUnpaidOrder(context.Background()).Return(int64(0), errors.New("oops! db error"))
+				m.utMocks.Patch("ValueContext", validContext)
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().GetStatus(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(consts.StatusPaid, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail Cancel (invalid session)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(_ context.Context, key string) interface{} {
+					if key == consts.CtxKeySession {
+						return nil
+					}
+					return "access-token"
+				})
 			},
 			wantErr: true,
 		},
@@ -214,17 +1896,22 @@ func Test_orderService_CancelUnpaidOrder(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			paymentRepoMock := repository.NewMockPaymentRepository(ctrl)
 			utMocks := utils.InitMock()
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, paymentRepoMock: paymentRepoMock, utMocks: utMocks})
 			}
 
 			tt.svc.orderRepo = orderRepoMock
+			tt.svc.paymentRepo = paymentRepoMock
 
-			gotResp, err := tt.svc.CancelUnpaidOrder(tt.args.ctx)
+			gotResp, err := tt.svc.Cancel(tt.args.ctx, tt.args.orderID)
 
 			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
 			assert.Equal(t, tt.wantResp, gotResp)
 
 			utMocks.UnpatchAll()
@@ -232,15 +1919,18 @@ func Test_orderService_CancelUnpaidOrder(t *testing.T) {
 	}
 }
 
-func Test_orderService_ConfirmPayment(t *testing.T) {
+func Test_orderService_AddItem(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		req model.ConfirmPaymentRequest
+		ctx     context.Context
+		orderID int64
+		req     model.BaseOrderRequest
 	}
 	type mocks struct {
 		utMocks       utils.Mock
 		orderRepoMock *repository.MockOrderRepository
+		menuRepoMock  *repository.MockMenuRepository
 	}
+	newOrder := []*model.Order{{BaseOrderID: 1, OrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: consts.StatusNew}}
 	tests := []struct {
 		name         string
 		svc          *orderService
@@ -249,48 +1939,98 @@ func Test_orderService_ConfirmPayment(t *testing.T) {
 		wantErr      bool
 	}{
 		{
-			name: "success ConfirmPayment",
+			name: "success AddItem (new menu)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.BaseOrderRequest{Name: "es teh", Qty: 3}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil).Times(2)
+				m.menuRepoMock.EXPECT().GetByName(gomock.AssignableToTypeOf(context.Background()), int64(1), "es teh").Return(&model.Menu{ID: 2, Name: "es teh", Price: money.MustParse("5000")}, nil, nil)
+				m.orderRepoMock.EXPECT().AddItem(gomock.AssignableToTypeOf(context.Background()), int64(1), &model.Order{OrderID: 1, MenuID: 2, MenuName: "es teh", Price: money.MustParse("5000"), Qty: 3}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int64, item *model.Order, reprice repository.OrderRepricer) (int64, error, error) {
+						// the order is repriced with the added item
+						return int64(2), nil, runRepricer(reprice, append(newOrder[:1:1], item), money.FromMinor(0), &model.OrderCharge{
+							OrderID: 1, SubTotal: money.MustParse("65000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("3250"), ServiceChargeRate: 500,
+							Tax: money.MustParse("7507.50"), GrandTotal: money.MustParse("75757.50"),
+						})
+					})
+			},
+		},
+		{
+			name: "success AddItem (menu already ordered)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{Email: "test@example.com"}},
+			args: args{ctx: context.Background(), orderID: 1, req: model.BaseOrderRequest{Name: "sate", Qty: 3}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
-				m.orderRepoMock.EXPECT().ConfirmPayment(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf("")).Return(int64(1), nil, nil)
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil).Times(2)
+				m.menuRepoMock.EXPECT().GetByName(gomock.AssignableToTypeOf(context.Background()), int64(1), "sate").Return(&model.Menu{ID: 1, Name: "sate", Price: money.MustParse("25000")}, nil, nil)
+				// the requested qty is added to the ordered one by the repository, within the order's lock
+				m.orderRepoMock.EXPECT().AddItem(gomock.AssignableToTypeOf(context.Background()), int64(1), &model.Order{OrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 3}, gomock.Any()).
+					Return(int64(1), nil, nil)
 			},
 		},
 		{
-			name: "fail ConfirmPayment (not rows)",
+			name: "fail AddItem (menu not found)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{Email: "not.found@example.com"}},
+			args: args{ctx: context.Background(), orderID: 1, req: model.BaseOrderRequest{Name: "not exists", Qty: 3}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
 				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
-				m.orderRepoMock.EXPECT().ConfirmPayment(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf("")).Return(int64(0), errors.New("oops! error no rows"), nil)
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil)
+				m.menuRepoMock.EXPECT().GetByName(gomock.AssignableToTypeOf(context.Background()), int64(1), "not exists").Return(nil, errors.New("oops! error no rows"), nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail ConfirmPayment (invalid/no token)",
+			name: "fail AddItem (menu sold out)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.ConfirmPaymentRequest{Email: "test@example.com"}},
+			args: args{ctx: context.Background(), orderID: 1, req: model.BaseOrderRequest{Name: "es teh", Qty: 3}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
-					return "invalid-token"
+					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return nil, errors.New("oops! invalid token")
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil)
+				m.menuRepoMock.EXPECT().GetByName(gomock.AssignableToTypeOf(context.Background()), int64(1), "es teh").Return(&model.Menu{ID: 2, Name: "es teh", Price: money.MustParse("5000")}, nil, nil)
+				m.orderRepoMock.EXPECT().AddItem(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(&model.Order{}), gomock.Any()).
+					Return(int64(0), nil, &repository.MenuSoldOutError{MenuID: 2})
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail AddItem (order is paid)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, req: model.BaseOrderRequest{Name: "es teh", Qty: 3}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return([]*model.Order{{BaseOrderID: 1, OrderID: 1, Status: consts.StatusPaid}}, nil, nil)
 			},
 			wantErr: true,
 		},
@@ -299,149 +2039,225 @@ func Test_orderService_ConfirmPayment(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			menuRepoMock := repository.NewMockMenuRepository(ctrl)
 			utMocks := utils.InitMock()
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, utMocks: utMocks})
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, menuRepoMock: menuRepoMock, utMocks: utMocks})
 			}
 
 			tt.svc.orderRepo = orderRepoMock
+			tt.svc.taxCalculator = newTestTaxCalculator()
+			tt.svc.menuRepo = menuRepoMock
 
-			err := tt.svc.ConfirmPayment(tt.args.ctx, tt.args.req)
+			gotResp, err := tt.svc.AddItem(tt.args.ctx, tt.args.orderID, tt.args.req)
 
 			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantErr, gotResp == nil)
 
 			utMocks.UnpatchAll()
 		})
 	}
 }
 
-func Test_orderService_Search(t *testing.T) {
+func Test_orderService_UpdateItem(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		req model.OrderQuery
+		ctx         context.Context
+		orderID     int64
+		baseOrderID int64
+		req         model.UpdateOrderItemRequest
 	}
 	type mocks struct {
-		utMocks       utils.Mock
-		orderRepoMock *repository.MockOrderRepository
+		utMocks           utils.Mock
+		orderRepoMock     *repository.MockOrderRepository
+		promotionRepoMock *repository.MockPromotionRepository
 	}
+	newOrder := []*model.Order{{BaseOrderID: 1, OrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: consts.StatusNew}}
+	discountedOrder := []*model.Order{{
+		BaseOrderID: 1, OrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: consts.StatusNew,
+		PromoCode: "HEMAT10", Discount: money.MustParse("5000"),
+	}}
 	tests := []struct {
 		name         string
 		svc          *orderService
 		args         args
 		prepareMocks func(*mocks)
-		wantResp     *model.SearchOrdersResponse
 		wantErr      bool
 	}{
 		{
-			name: "success Search",
+			name: "success UpdateItem",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"test1@example.com", "test2@example.com"}}},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 1, req: model.UpdateOrderItemRequest{Qty: 4}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.orderRepoMock.EXPECT().
-					Search(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.OrderQuery{})).
-					Return([]*model.Order{
-						{
-							CustomerEmail: "test1@example.com",
-							MenuName:      "nasi kepal isi salmon",
-							Price:         44_000,
-							Qty:           2,
-							Status:        2,
-						},
-						{
-							CustomerEmail: "test2@example.com",
-							MenuName:      "soto kambing",
-							Price:         30_000,
-							Qty:           1,
-							Status:        2,
-						},
-						{
-							CustomerEmail: "test2@example.com",
-							MenuName:      "nasi lemak",
-							Price:         20_000,
-							Qty:           2,
-							Status:        2,
-						},
-					}, nil, nil)
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil).Times(2)
+				m.orderRepoMock.EXPECT().UpdateItemQty(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), int64(1), 4, gomock.Any()).Return(int64(1), nil, nil)
 			},
-			wantResp: &model.SearchOrdersResponse{
-				Orders: []*model.SearchResponse{
-					{
-						CustomerEmail: "test1@example.com",
-						MenuName:      "nasi kepal isi salmon",
-						Price:         44_000,
-						Qty:           2,
-						Status:        2,
-					},
-					{
-						CustomerEmail: "test2@example.com",
-						MenuName:      "soto kambing",
-						Price:         30_000,
-						Qty:           1,
-						Status:        2,
-					},
-					{
-						CustomerEmail: "test2@example.com",
-						MenuName:      "nasi lemak",
-						Price:         20_000,
-						Qty:           2,
-						Status:        2,
-					},
-				},
-				TotalPrice: 158_000,
+		},
+		{
+			name: "success UpdateItem (reprice the discount)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 1, req: model.UpdateOrderItemRequest{Qty: 4}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(discountedOrder, nil, nil).Times(2)
+				m.orderRepoMock.EXPECT().UpdateItemQty(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), int64(1), 4, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _, _ int64, _ int, reprice repository.OrderRepricer) (int64, error, error) {
+						return int64(1), nil, runRepricer(reprice, discountedOrder, money.MustParse("10000"), &model.OrderCharge{
+							OrderID: 1, SubTotal: money.MustParse("50000"), Discount: money.MustParse("10000"), ServiceCharge: money.MustParse("2000"), ServiceChargeRate: 500,
+							Tax: money.MustParse("4620"), GrandTotal: money.MustParse("46620"),
+						})
+					})
+				m.orderRepoMock.EXPECT().GetDiscount(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.OrderDiscount{OrderID: 1, PromotionID: 1, Code: "HEMAT10", Amount: money.MustParse("5000")}, nil, nil)
+				m.promotionRepoMock.EXPECT().GetByID(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.Promotion{ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 20}, nil, nil)
 			},
 		},
 		{
-			name: "fail Search (partially/no rows)",
+			name: "fail UpdateItem (item not in the order)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"not.found@example.com"}}},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 100, req: model.UpdateOrderItemRequest{Qty: 4}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.orderRepoMock.EXPECT().
-					Search(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.OrderQuery{})).
-					Return(nil, errors.New("oops! no rows"), nil)
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail Search (invalid/no token)",
+			name: "fail UpdateItem (db error)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"not.found@example.com"}}},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 1, req: model.UpdateOrderItemRequest{Qty: 4}},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
-					return "invalid-token"
+					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return nil, errors.New("oops! invalid token")
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(newOrder, nil, nil)
+				m.orderRepoMock.EXPECT().UpdateItemQty(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), int64(1), 4, gomock.Any()).Return(int64(0), nil, errors.New("oops! db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			promotionRepoMock := repository.NewMockPromotionRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderRepoMock: orderRepoMock, promotionRepoMock: promotionRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.orderRepo = orderRepoMock
+			tt.svc.taxCalculator = newTestTaxCalculator()
+			tt.svc.promotionRepo = promotionRepoMock
+
+			gotResp, err := tt.svc.UpdateItem(tt.args.ctx, tt.args.orderID, tt.args.baseOrderID, tt.args.req)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantErr, gotResp == nil)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_orderService_DeleteItem(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		orderID     int64
+		baseOrderID int64
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		orderRepoMock *repository.MockOrderRepository
+	}
+	twoItemsOrder := []*model.Order{
+		{BaseOrderID: 1, OrderID: 1, MenuID: 1, MenuName: "sate", Price: money.MustParse("25000"), Qty: 2, Status: consts.StatusNew},
+		{BaseOrderID: 2, OrderID: 1, MenuID: 2, MenuName: "es teh", Price: money.MustParse("5000"), Qty: 3, Status: consts.StatusNew},
+	}
+	tests := []struct {
+		name         string
+		svc          *orderService
+		args         args
+		prepareMocks func(*mocks)
+		wantErr      bool
+	}{
+		{
+			name: "success DeleteItem",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 2},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
+				gomock.InOrder(
+					m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(twoItemsOrder, nil, nil),
+					m.orderRepoMock.EXPECT().DeleteItem(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1), int64(2), gomock.Any()).
+						DoAndReturn(func(_ context.Context, _, _, _ int64, reprice repository.OrderRepricer) (int64, error, error) {
+							return int64(1), nil, runRepricer(reprice, twoItemsOrder[:1], money.FromMinor(0), &model.OrderCharge{
+								OrderID: 1, SubTotal: money.MustParse("50000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("2500"), ServiceChargeRate: 500,
+								Tax: money.MustParse("5775"), GrandTotal: money.MustParse("58275"),
+							})
+						}),
+					m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(twoItemsOrder[:1], nil, nil),
+				)
+			},
+		},
+		{
+			name: "fail DeleteItem (last item)",
+			svc:  &orderService{},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).Return(twoItemsOrder[:1], nil, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "fail Search ",
+			name: "fail DeleteItem (order is cancelled)",
 			svc:  &orderService{},
-			args: args{ctx: context.Background(), req: model.OrderQuery{CustomerEmails: []string{"not.found@example.com"}}},
+			args: args{ctx: context.Background(), orderID: 1, baseOrderID: 2},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
 					return "access-token"
 				})
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return &utils.JwtClaims{}, nil
+					return &utils.JwtClaims{BusinessID: 1}, nil
 				})
-				m.orderRepoMock.EXPECT().
-					Search(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.OrderQuery{})).
-					Return(nil, nil, errors.New("oops! db error"))
+				m.orderRepoMock.EXPECT().Get(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return([]*model.Order{{BaseOrderID: 1, OrderID: 1, Status: consts.StatusCancelled}, {BaseOrderID: 2, OrderID: 1, Status: consts.StatusCancelled}}, nil, nil)
 			},
 			wantErr: true,
 		},
@@ -457,11 +2273,12 @@ func Test_orderService_Search(t *testing.T) {
 			}
 
 			tt.svc.orderRepo = orderRepoMock
+			tt.svc.taxCalculator = newTestTaxCalculator()
 
-			gotResp, err := tt.svc.Search(tt.args.ctx, tt.args.req)
+			gotResp, err := tt.svc.DeleteItem(tt.args.ctx, tt.args.orderID, tt.args.baseOrderID)
 
 			assert.Equal(t, tt.wantErr, err != nil)
-			assert.Equal(t, tt.wantResp, gotResp)
+			assert.Equal(t, tt.wantErr, gotResp == nil)
 
 			utMocks.UnpatchAll()
 		})
@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"family-catering/config"
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
 	"family-catering/pkg/apperrors"
 	"family-catering/pkg/consts"
+	"family-catering/pkg/logger"
 	"fmt"
+	"strings"
 
 	utils "family-catering/pkg/utils"
 )
@@ -19,17 +22,32 @@ type OwnerService interface {
 	List(ctx context.Context, limit, offset int) (resp []*model.GetOwnerResponse, err error)
 	Update(ctx context.Context, id int64, req model.UpdateOwnerRequest) (resp *model.UpdateOwnerResponse, err error)
 	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+	// ResetPasswordByEmail reset the password of the forgot password token's owner (see AuthService.ForgotPassword), the
+	// reset id is used once and the owner is logged out of every device
 	ResetPasswordByEmail(ctx context.Context, passwordResetID string, req model.ResetPasswordRequest) error
 	ResetPasswordByID(ctx context.Context, id int64, req model.ResetPasswordRequest) error
+	// UpdateEmailByID send the verification link to the new email, the email changes once the link is clicked
 	UpdateEmailByID(ctx context.Context, id int64, req model.UpdateEmailRequest) error
+	// VerifyEmail verify the email of the link's token, the registered email of a new owner or the new email of an owner
+	// changing it
+	VerifyEmail(ctx context.Context, token string) error
+	// ResendEmailVerification send a new verification link to a registered owner whose email is not verified yet
+	ResendEmailVerification(ctx context.Context, req model.ResendEmailVerificationRequest) error
 }
 
 type ownerService struct {
-	ownerRepo repository.OwnerRepository
+	ownerRepo             repository.OwnerRepository
+	authRepo              repository.AuthRepository
+	loginAttemptRepo      repository.LoginAttemptRepository
+	passwordResetRepo     repository.PasswordResetRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	mailer                Mailer
 }
 
-func NewOwnerService(ownerRepo repository.OwnerRepository) OwnerService {
-	return &ownerService{ownerRepo: ownerRepo}
+func NewOwnerService(ownerRepo repository.OwnerRepository, authRepo repository.AuthRepository, loginAttemptRepo repository.LoginAttemptRepository,
+	passwordResetRepo repository.PasswordResetRepository, emailVerificationRepo repository.EmailVerificationRepository, mailer Mailer) OwnerService {
+	return &ownerService{ownerRepo: ownerRepo, authRepo: authRepo, loginAttemptRepo: loginAttemptRepo, passwordResetRepo: passwordResetRepo,
+		emailVerificationRepo: emailVerificationRepo, mailer: mailer}
 }
 
 func (svc *ownerService) Create(ctx context.Context, req model.CreateOwnerRequest) (*model.CreateOwnerResponse, error) {
@@ -69,21 +87,51 @@ func (svc *ownerService) Create(ctx context.Context, req model.CreateOwnerReques
 		Email:       req.Email,
 		Password:    hashedPassword,
 		PhoneNumber: req.PhoneNumber,
+		Role:        consts.RoleAdmin,
 	}
 
-	id, err := svc.ownerRepo.Create(ctx, owner)
+	// a registered owner starts a new business of its own, staff of an existing business are created by its admins
+	businessName := strings.TrimSpace(req.BusinessName)
+	if businessName == "" {
+		businessName = req.Name
+	}
+	id, err := svc.ownerRepo.CreateWithBusiness(ctx, owner, businessName)
 	if err != nil {
 		err = fmt.Errorf("service.ownerService.Create: %w", err)
 		return nil, err
 	}
+	owner.Id = id
+
+	// the owner logs in once its email is verified, an email failing to be sent is resent on the owner's request
+	err = sendEmailVerification(ctx, svc.emailVerificationRepo, svc.mailer, &owner, owner.Email)
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.Create: %w", err)
+		logger.Error(err, "error sending email verification of the registered owner")
+	}
 
 	ownerResp := newOwnerCreateResponse(&owner)
 	ownerResp.Id = id
 	return ownerResp, nil
 }
 
+// Get return an owner of the business of the logged in owner, owner of another business is not found
 func (svc *ownerService) Get(ctx context.Context, id int64) (*model.GetOwnerResponse, error) {
+	// validate authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.ownerService.Get: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.ownerService.Get: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
 	owner, errNoRow, err := svc.ownerRepo.Get(ctx, id)
+	if errNoRow == nil && err == nil && owner.BusinessID != claims.BusinessID {
+		errNoRow = fmt.Errorf("owner %d belongs to another business", id)
+	}
 	if errNoRow != nil {
 		errNoRow = fmt.Errorf("service.ownerService.Get: %w", errNoRow)
 		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
@@ -98,8 +146,19 @@ func (svc *ownerService) Get(ctx context.Context, id int64) (*model.GetOwnerResp
 }
 
 func (svc *ownerService) List(ctx context.Context, limit, offset int) ([]*model.GetOwnerResponse, error) {
+	// validate authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.ownerService.List: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.ownerService.List: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
 
-	owners, errNoRow, err := svc.ownerRepo.List(ctx, limit, offset)
+	owners, errNoRow, err := svc.ownerRepo.List(ctx, claims.BusinessID, limit, offset)
 	if errNoRow != nil {
 		return []*model.GetOwnerResponse{}, nil
 	}
@@ -119,7 +178,7 @@ func (svc *ownerService) Update(ctx context.Context, id int64, req model.UpdateO
 		err := fmt.Errorf("service.ownerService.Update: invalid auth token type want string got %T", token)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
 	}
-	_, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
 		err = fmt.Errorf("service.ownerService.Update: %w", err)
 		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
@@ -146,7 +205,7 @@ func (svc *ownerService) Update(ctx context.Context, id int64, req model.UpdateO
 	}
 
 	owner.Id = id
-	_, errNoRow, err := svc.ownerRepo.Update(ctx, owner)
+	_, errNoRow, err := svc.ownerRepo.Update(ctx, claims.BusinessID, owner)
 	if errNoRow != nil {
 		errNoRow = fmt.Errorf("service.ownerService.Update: %w", errNoRow)
 		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
@@ -196,6 +255,17 @@ func (svc *ownerService) Delete(ctx context.Context, id int64) (int64, error) {
 }
 
 func (svc *ownerService) ResetPasswordByEmail(ctx context.Context, passwordResetID string, req model.ResetPasswordRequest) error {
+	// the password is validated before the reset id is consumed, a rejected password doesn't use the link up
+	err := utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
 	// validate authorization
 	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
 	if !ok {
@@ -213,12 +283,34 @@ func (svc *ownerService) ResetPasswordByEmail(ctx context.Context, passwordReset
 		err := fmt.Errorf("service.ownerService.ResetPasswordByEmail: invalid jwt claim or request value")
 		return apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
+
+	// the reset id is consumed first, the same link can't reset the password twice even concurrently
+	errNoRow, err := svc.passwordResetRepo.Consume(ctx, payload.Email, utils.HashOneTimeToken(passwordResetID))
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", errNoRow)
+		return apperrors.WrapError(errNoRow, apperrors.ErrAuth, "invalid, used or expired reset password link")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		return err
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, payload.Email)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", errNoRow)
+		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		return err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
 		return err
 	}
-	_, errNoRow, err := svc.ownerRepo.UpdatePasswordByEmail(context.Background(), payload.Email, hashedPassword)
+	_, errNoRow, err = svc.ownerRepo.UpdatePasswordByID(ctx, owner.Id, hashedPassword)
 	if errNoRow != nil {
 		errNoRow = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", errNoRow)
 		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
@@ -228,8 +320,21 @@ func (svc *ownerService) ResetPasswordByEmail(ctx context.Context, passwordReset
 		return err
 	}
 
-	return nil
+	// whoever knew the previous password is logged out
+	_, err = svc.authRepo.DeleteOwnerSessions(ctx, owner.Id)
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		return err
+	}
+
+	// the owner proved it holds the email, a login locked out by failed logins is unlocked
+	err = svc.loginAttemptRepo.Reset(ctx, payload.Email)
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		return err
+	}
 
+	return nil
 }
 
 func (svc *ownerService) ResetPasswordByID(ctx context.Context, id int64, req model.ResetPasswordRequest) error {
@@ -286,23 +391,181 @@ func (svc *ownerService) UpdateEmailByID(ctx context.Context, id int64, req mode
 	}
 	_, err := utils.ValidateToken(token)
 	if !errors.Is(err, nil) {
-		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: %w", err)
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", err)
 		return apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 	if !session.Valid || (id != session.OwnerID) {
-		err = fmt.Errorf("service.ownerService.ResetPasswordByEmail: invalid session or claims")
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: invalid session or claims")
 		return apperrors.WrapError(err, apperrors.ErrAuth, "")
 	}
 
-	_, errNoRow, err := svc.ownerRepo.UpdateEmailByID(ctx, id, req.Email)
+	// validating request
+	err = utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, id)
 	if errNoRow != nil {
-		errNoRow = fmt.Errorf("service.ownerService.ResetPasswordByID: %w", errNoRow)
+		errNoRow = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", errNoRow)
 		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
 	}
 	if err != nil {
-		err = fmt.Errorf("service.ownerService.ResetPasswordByID: %w", err)
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", err)
+		return err
+	}
+	if owner.Email == req.Email {
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: email of owner %d is not changed", id)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidation, "email is the current email")
+	}
+
+	registered, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, req.Email)
+	if err == nil && errNoRow == nil && registered != nil {
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: email already registered")
+		return apperrors.WrapError(err, apperrors.ErrEmailRegistered, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", err)
+		return err
+	}
+
+	// the email is changed once the owner clicked the link sent to the new email (see VerifyEmail), a mistyped email
+	// leaves the current one as is
+	err = sendEmailVerification(ctx, svc.emailVerificationRepo, svc.mailer, owner, req.Email)
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.UpdateEmailByID: %w", err)
+		return err
+	}
+
+	return nil
+}
+
+func (svc *ownerService) VerifyEmail(ctx context.Context, token string) error {
+	verification, errNoRow, err := svc.emailVerificationRepo.GetByTokenHash(ctx, utils.HashOneTimeToken(token))
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.VerifyEmail: %w", err)
+		return err
+	}
+	if errNoRow == nil && (verification.Used || !utils.Now().Before(verification.ExpiredAt)) {
+		errNoRow = fmt.Errorf("verification %d is used or expired", verification.ID)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.ownerService.VerifyEmail: %w", errNoRow)
+		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "invalid or expired verification link")
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, verification.OwnerID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.ownerService.VerifyEmail: %w", errNoRow)
+		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.VerifyEmail: %w", err)
+		return err
+	}
+
+	// the new email of an owner changing it may be registered by another owner since the link was sent
+	changed := owner.Email != verification.Email
+	if changed {
+		registered, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, verification.Email)
+		if err == nil && errNoRow == nil && registered != nil {
+			err = fmt.Errorf("service.ownerService.VerifyEmail: email already registered")
+			return apperrors.WrapError(err, apperrors.ErrEmailRegistered, "")
+		}
+		if err != nil {
+			err = fmt.Errorf("service.ownerService.VerifyEmail: %w", err)
+			return err
+		}
+	}
+
+	errNoRow, err = svc.emailVerificationRepo.Verify(ctx, verification.ID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.ownerService.VerifyEmail: %w", errNoRow)
+		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "invalid or expired verification link")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.VerifyEmail: %w", err)
+		return err
+	}
+
+	if changed {
+		// the previous email is told so an owner whose account is taken over knows it, the email is changed already
+		// whether it's sent or not
+		logger.Info("owner %d changed its email from %s to %s", owner.Id, owner.Email, verification.Email)
+		err = svc.mailer.SendEmailChanged([]string{owner.Email}, "", "Email Changed", owner.Name, verification.Email)
+		if err != nil {
+			err = fmt.Errorf("service.ownerService.VerifyEmail: %w", err)
+			logger.Error(err, "error notifying the previous email of the owner")
+		}
+	}
+
+	return nil
+}
+
+func (svc *ownerService) ResendEmailVerification(ctx context.Context, req model.ResendEmailVerificationRequest) error {
+	// validating request
+	err := utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.ownerService.ResendEmailVerification: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.ownerService.ResendEmailVerification: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	// the answer is the same whether the email is registered or verified so the emails can't be discovered
+	owner, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, req.Email)
+	if errNoRow != nil {
+		logger.Info("email verification not sent to %s, the email is not registered", req.Email)
+		return nil
+	}
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResendEmailVerification: %w", err)
 		return err
 	}
+	if owner.EmailVerified {
+		logger.Info("email verification not sent to owner %d, its email is verified already", owner.Id)
+		return nil
+	}
+
+	err = sendEmailVerification(ctx, svc.emailVerificationRepo, svc.mailer, owner, owner.Email)
+	if err != nil {
+		err = fmt.Errorf("service.ownerService.ResendEmailVerification: %w", err)
+		return err
+	}
+
+	return nil
+}
+
+// sendEmailVerification send the owner a single use link verifying the email, its registered email or the one it
+// changes to. The owner's pending links are voided
+func sendEmailVerification(ctx context.Context, emailVerificationRepo repository.EmailVerificationRepository, mailer Mailer, owner *model.Owner, email string) error {
+	token, err := utils.GenerateOneTimeToken()
+	if err != nil {
+		return fmt.Errorf("service.sendEmailVerification: %w", err)
+	}
+
+	err = emailVerificationRepo.Create(ctx, model.OwnerEmailVerification{
+		OwnerID:   owner.Id,
+		Email:     email,
+		TokenHash: utils.HashOneTimeToken(token),
+		ExpiredAt: utils.Now().Add(config.Cfg().Web.EmailVerification.TokenTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("service.sendEmailVerification: %w", err)
+	}
+
+	link := fmt.Sprintf("https://%s/api/v1/owner/verify-email/%s", config.Cfg().Server.Addr(), token)
+	err = mailer.SendEmailVerification([]string{email}, "", "Verify Email", owner.Name, link)
+	if err != nil {
+		return fmt.Errorf("service.sendEmailVerification: %w", err)
+	}
 
 	return nil
 }
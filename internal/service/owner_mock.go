@@ -95,6 +95,20 @@ func (mr *MockOwnerServiceMockRecorder) List(ctx, limit, offset interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOwnerService)(nil).List), ctx, limit, offset)
 }
 
+// ResendEmailVerification mocks base method.
+func (m *MockOwnerService) ResendEmailVerification(ctx context.Context, req model.ResendEmailVerificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerification", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockOwnerServiceMockRecorder) ResendEmailVerification(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*MockOwnerService)(nil).ResendEmailVerification), ctx, req)
+}
+
 // ResetPasswordByEmail mocks base method.
 func (m *MockOwnerService) ResetPasswordByEmail(ctx context.Context, passwordResetID string, req model.ResetPasswordRequest) error {
 	m.ctrl.T.Helper()
@@ -151,3 +165,17 @@ func (mr *MockOwnerServiceMockRecorder) UpdateEmailByID(ctx, id, req interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailByID", reflect.TypeOf((*MockOwnerService)(nil).UpdateEmailByID), ctx, id, req)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockOwnerService) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockOwnerServiceMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockOwnerService)(nil).VerifyEmail), ctx, token)
+}
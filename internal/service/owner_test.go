@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"family-catering/config"
 	"family-catering/internal/model"
 	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
 	"family-catering/pkg/consts"
 	utils "family-catering/pkg/utils"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +20,12 @@ import (
 
 func TestNewOwnerService(t *testing.T) {
 	type args struct {
-		ownerRepo repository.OwnerRepository
+		ownerRepo             repository.OwnerRepository
+		authRepo              repository.AuthRepository
+		loginAttemptRepo      repository.LoginAttemptRepository
+		passwordResetRepo     repository.PasswordResetRepository
+		emailVerificationRepo repository.EmailVerificationRepository
+		mailer                Mailer
 	}
 	tests := []struct {
 		name string
@@ -23,7 +33,8 @@ func TestNewOwnerService(t *testing.T) {
 	}{{name: "success create new owner service"}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.NotNil(t, NewOwnerService(tt.args.ownerRepo))
+			assert.NotNil(t, NewOwnerService(tt.args.ownerRepo, tt.args.authRepo, tt.args.loginAttemptRepo, tt.args.passwordResetRepo, tt.args.emailVerificationRepo,
+				tt.args.mailer))
 		})
 	}
 }
@@ -34,8 +45,10 @@ func Test_ownerService_Create(t *testing.T) {
 		req model.CreateOwnerRequest
 	}
 	type mocks struct {
-		utMocks       utils.Mock
-		ownerRepoMock *repository.MockOwnerRepository
+		utMocks                   utils.Mock
+		ownerRepoMock             *repository.MockOwnerRepository
+		emailVerificationRepoMock *repository.MockEmailVerificationRepository
+		mailerMock                *MockMailer
 	}
 	tests := []struct {
 		name         string
@@ -69,8 +82,59 @@ func Test_ownerService_Create(t *testing.T) {
 				})
 				m.ownerRepoMock.
 					EXPECT().
-					Create(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.Owner{})).
+					CreateWithBusiness(gomock.AssignableToTypeOf(context.Background()), model.Owner{
+						Name: "test", Email: "test@example.com", Password: "hashed-password", Role: consts.RoleAdmin,
+					}, "test").
+					Return(int64(1), nil)
+				// the owner logs in once it verified its email
+				m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), model.OwnerEmailVerification{
+					OwnerID: 1, Email: "test@example.com", TokenHash: utils.HashOneTimeToken("verification-token"), ExpiredAt: time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC),
+				}).Return(nil)
+				m.mailerMock.EXPECT().SendEmailVerification([]string{"test@example.com"}, "", "Verify Email", "test", gomock.Any()).
+					DoAndReturn(func(_ []string, _, _, _, link string) error {
+						if !strings.HasSuffix(link, "/api/v1/owner/verify-email/verification-token") {
+							return errors.New("oops! unexpected link")
+						}
+						return nil
+					})
+			},
+			wantResp: &model.CreateOwnerResponse{
+				Id:       1,
+				Name:     "test",
+				Email:    "test@example.com",
+				Password: "hashed-password",
+			},
+		},
+		{
+			name: "success Create (with business name, email verification not sent)",
+			svc:  &ownerService{},
+			args: args{
+				ctx: context.Background(),
+				req: model.CreateOwnerRequest{
+					Name:         "test",
+					Email:        "test@example.com",
+					Password:     "password",
+					BusinessName: " Dapur Bu Tini ",
+				},
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValidateRequest", func(s interface{}) error {
+					return nil
+				})
+				m.ownerRepoMock.
+					EXPECT().
+					GetByEmail(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf("")).
+					Return(nil, nil, nil)
+				m.utMocks.Patch("HashPassword", func(s string) (string, error) {
+					return "hashed-password", nil
+				})
+				m.ownerRepoMock.
+					EXPECT().
+					CreateWithBusiness(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.Owner{}), "Dapur Bu Tini").
 					Return(int64(1), nil)
+				// the owner is created whether the email verification is sent or not, it's resent on the owner's request
+				m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				m.mailerMock.EXPECT().SendEmailVerification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("oops! error smtp"))
 			},
 			wantResp: &model.CreateOwnerResponse{
 				Id:       1,
@@ -149,12 +213,20 @@ func Test_ownerService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			utMocks := utils.InitMock()
+			config.InitMock()
+			config.Cfg().Web.EmailVerification.TokenTTL = 24 * time.Hour
+			utMocks.Patch("Now", func() time.Time { return time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC) })
+			utMocks.Patch("GenerateOneTimeToken", func() (string, error) { return "verification-token", nil })
 			ctrl := gomock.NewController(t)
 			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+			emailVerificationRepoMock := repository.NewMockEmailVerificationRepository(ctrl)
+			mailerMock := NewMockMailer(ctrl)
 			tt.svc.ownerRepo = ownerRepoMock
+			tt.svc.emailVerificationRepo = emailVerificationRepoMock
+			tt.svc.mailer = mailerMock
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{utMocks: utMocks, ownerRepoMock: ownerRepoMock})
+				tt.prepareMocks(&mocks{utMocks: utMocks, ownerRepoMock: ownerRepoMock, emailVerificationRepoMock: emailVerificationRepoMock, mailerMock: mailerMock})
 			}
 
 			gotResp, err := tt.svc.Create(tt.args.ctx, tt.args.req)
@@ -163,6 +235,7 @@ func Test_ownerService_Create(t *testing.T) {
 			assert.Equal(t, err != nil, tt.wantErr)
 
 			utMocks.UnpatchAll()
+			config.DestroyMock()
 
 		})
 	}
@@ -174,6 +247,7 @@ func Test_ownerService_Get(t *testing.T) {
 		id  int64
 	}
 	type mocks struct {
+		utMocks       utils.Mock
 		ownerRepoMock *repository.MockOwnerRepository
 	}
 	tests := []struct {
@@ -192,10 +266,12 @@ func Test_ownerService_Get(t *testing.T) {
 				id:  1,
 			},
 			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
 					Get(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(1))).
-					Return(&model.Owner{Id: 1, Name: "test"}, nil, nil)
+					Return(&model.Owner{Id: 1, Name: "test", BusinessID: 1}, nil, nil)
 			},
 			wantResp: &model.GetOwnerResponse{
 				Id:   1,
@@ -203,6 +279,23 @@ func Test_ownerService_Get(t *testing.T) {
 			},
 		},
 
+		{
+			name: "fail GetByID (owner of another business)",
+			svc:  &ownerService{},
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.ownerRepoMock.
+					EXPECT().
+					Get(gomock.AssignableToTypeOf(context.Background()), int64(2)).
+					Return(&model.Owner{Id: 2, Name: "other", BusinessID: 2}, nil, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail GetByID (error repo)",
 			svc:  &ownerService{},
@@ -211,6 +304,8 @@ func Test_ownerService_Get(t *testing.T) {
 				id:  1,
 			},
 			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
 					Get(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(1))).
@@ -226,6 +321,8 @@ func Test_ownerService_Get(t *testing.T) {
 				id:  0,
 			},
 			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
 					Get(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(1))).
@@ -236,18 +333,21 @@ func Test_ownerService_Get(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			utMocks := utils.InitMock()
 			ctrl := gomock.NewController(t)
 			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
 			tt.svc.ownerRepo = ownerRepoMock
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{ownerRepoMock: ownerRepoMock})
+				tt.prepareMocks(&mocks{utMocks: utMocks, ownerRepoMock: ownerRepoMock})
 			}
 
 			gotResp, err := tt.svc.Get(tt.args.ctx, tt.args.id)
 
 			assert.Equal(t, tt.wantResp, gotResp)
 			assert.Equal(t, tt.wantErr, err != nil)
+
+			utMocks.UnpatchAll()
 		})
 	}
 }
@@ -259,6 +359,7 @@ func Test_ownerService_List(t *testing.T) {
 		offset int
 	}
 	type mocks struct {
+		utMocks       utils.Mock
 		ownerRepoMock *repository.MockOwnerRepository
 	}
 	tests := []struct {
@@ -278,9 +379,11 @@ func Test_ownerService_List(t *testing.T) {
 				offset: 1,
 			},
 			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
-					List(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(1), gomock.AssignableToTypeOf(1)).
+					List(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(1), gomock.AssignableToTypeOf(1)).
 					Return([]*model.Owner{{Id: 1, Name: "test-1"}, {Id: 2, Name: "test-2"}}, nil, nil)
 			},
 			wantResp: []*model.GetOwnerResponse{
@@ -297,9 +400,11 @@ func Test_ownerService_List(t *testing.T) {
 				offset: 2,
 			},
 			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
-					List(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(1), gomock.AssignableToTypeOf(1)).
+					List(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(1), gomock.AssignableToTypeOf(1)).
 					Return(nil, nil, errors.New("oops! error repo"))
 			},
 			wantErr: true,
@@ -313,9 +418,11 @@ func Test_ownerService_List(t *testing.T) {
 				offset: 1,
 			},
 			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
-					List(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(1), gomock.AssignableToTypeOf(1)).
+					List(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(1), gomock.AssignableToTypeOf(1)).
 					Return(nil, errors.New("oops! error no rows"), nil)
 			},
 			wantResp: []*model.GetOwnerResponse{},
@@ -324,18 +431,21 @@ func Test_ownerService_List(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			utMocks := utils.InitMock()
 			ctrl := gomock.NewController(t)
 			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
 			tt.svc.ownerRepo = ownerRepoMock
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{ownerRepoMock: ownerRepoMock})
+				tt.prepareMocks(&mocks{utMocks: utMocks, ownerRepoMock: ownerRepoMock})
 			}
 
 			gotResp, err := tt.svc.List(tt.args.ctx, tt.args.limit, tt.args.offset)
 			assert.Equal(t, tt.wantResp, gotResp)
 			assert.Equal(t, tt.wantErr, err != nil)
 
+			utMocks.UnpatchAll()
+
 		})
 	}
 }
@@ -368,11 +478,11 @@ func Test_ownerService_Update(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.ownerRepoMock.
 					EXPECT().
-					Update(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.Owner{})).
+					Update(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(model.Owner{})).
 					Return(int64(1), nil, nil)
 
 			},
@@ -392,11 +502,11 @@ func Test_ownerService_Update(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
 				m.ownerRepoMock.
 					EXPECT().
-					Update(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(model.Owner{})).
+					Update(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf(model.Owner{})).
 					Return(int64(0), errors.New("oops! error no rows"), nil)
 
 			},
@@ -426,7 +536,7 @@ func Test_ownerService_Update(t *testing.T) {
 			},
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "invalid-token" })
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return errors.New("oops! invalid request") })
 
 			},
@@ -486,7 +596,7 @@ func Test_ownerService_Delete(t *testing.T) {
 					}
 					return nil
 				})
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
 					Delete(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(0))).
@@ -511,7 +621,7 @@ func Test_ownerService_Delete(t *testing.T) {
 					}
 					return nil
 				})
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
 					Delete(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(0))).
@@ -557,7 +667,7 @@ func Test_ownerService_Delete(t *testing.T) {
 					}
 					return nil
 				})
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{}, nil })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
 				m.ownerRepoMock.
 					EXPECT().
 					Delete(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(0))).
@@ -594,132 +704,202 @@ func Test_ownerService_ResetPasswordByEmail(t *testing.T) {
 		req             model.ResetPasswordRequest
 	}
 	type mocks struct {
-		utMocks       utils.Mock
-		ownerRepoMock *repository.MockOwnerRepository
+		utMocks               utils.Mock
+		ownerRepoMock         *repository.MockOwnerRepository
+		authRepoMock          *repository.MockAuthRepository
+		loginAttemptRepoMock  *repository.MockLoginAttemptRepository
+		passwordResetRepoMock *repository.MockPasswordResetRepository
+	}
+	errRepo := errors.New("oops! error repo")
+	resetClaims := func(id string) *utils.JwtClaims {
+		claims := utils.NewJWTClaimTesting(id)
+		claims.Email = "test@example.com"
+		return claims
+	}
+	validRequest := args{
+		ctx:             context.Background(),
+		passwordResetID: "rpid",
+		req: model.ResetPasswordRequest{
+			Password:        "UpdatedPassword1",
+			PasswordConfirm: "UpdatedPassword1"},
 	}
 	tests := []struct {
 		name         string
 		svc          *ownerService
 		args         args
 		prepareMocks func(*mocks)
-		wantErr      bool
+		wantErr      error
 	}{
 		{
 			name: "success ResetPasswordByEmail",
 			svc:  &ownerService{},
-			args: args{
-				ctx:             context.Background(),
-				passwordResetID: "rpid",
-				req: model.ResetPasswordRequest{
-					Password:        "updated-plain-password",
-					PasswordConfirm: "updated-plain-password"},
-			},
+			args: validRequest,
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting("rpid"), nil
+					return resetClaims("rpid"), nil
 				})
+				m.passwordResetRepoMock.EXPECT().Consume(gomock.Any(), "test@example.com", utils.HashOneTimeToken("rpid")).Return(nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Id: 1, Email: "test@example.com"}, nil, nil)
 				m.ownerRepoMock.
 					EXPECT().
-					UpdatePasswordByEmail(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf("")).
+					UpdatePasswordByID(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf("")).
 					Return(int64(1), nil, nil)
+				// every device is logged out
+				m.authRepoMock.EXPECT().DeleteOwnerSessions(gomock.Any(), int64(1)).Return(int64(2), nil)
+				// a locked out login is unlocked
+				m.loginAttemptRepoMock.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
 
 			},
 		},
 		{
 			name: "fail ResetPasswordByEmail (error invalid token)",
 			svc:  &ownerService{},
-			args: args{
-				ctx:             context.Background(),
-				passwordResetID: "rpid",
-				req: model.ResetPasswordRequest{
-					Password:        "updated-plain-password",
-					PasswordConfirm: "updated-plain-password"},
-			},
+			args: validRequest,
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
 					return nil, errors.New("oops! error invalid token")
 				})
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrAuth,
 		},
 		{
 			name: "fail ResetPasswordByEmail (invalid claim token)",
 			svc:  &ownerService{},
+			args: validRequest,
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return resetClaims("diff-rpid"), nil
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			// the link isn't used up by a rejected password
+			name: "fail ResetPasswordByEmail (password too short)",
+			svc:  &ownerService{},
 			args: args{
 				ctx:             context.Background(),
 				passwordResetID: "rpid",
-				req: model.ResetPasswordRequest{
-					Password:        "updated-plain-password",
-					PasswordConfirm: "updated-plain-password"},
+				req:             model.ResetPasswordRequest{Password: "Short1", PasswordConfirm: "Short1"},
 			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail ResetPasswordByEmail (password required)",
+			svc:  &ownerService{},
+			args: args{
+				ctx:             context.Background(),
+				passwordResetID: "rpid",
+				req:             model.ResetPasswordRequest{PasswordConfirm: "UpdatedPassword1"},
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name: "fail ResetPasswordByEmail (link used, voided or expired)",
+			svc:  &ownerService{},
+			args: validRequest,
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting("diff-rpid"), nil
+					return resetClaims("rpid"), nil
 				})
+				m.passwordResetRepoMock.EXPECT().Consume(gomock.Any(), "test@example.com", utils.HashOneTimeToken("rpid")).Return(errors.New("oops! error no rows"), nil)
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail ResetPasswordByEmail (error consume)",
+			svc:  &ownerService{},
+			args: validRequest,
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return resetClaims("rpid"), nil
+				})
+				m.passwordResetRepoMock.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errRepo)
+			},
+			wantErr: errRepo,
 		},
 		{
 			name: "fail ResetPasswordByEmail (error no rows)",
 			svc:  &ownerService{},
-			args: args{
-				ctx:             context.Background(),
-				passwordResetID: "rpid",
-				req: model.ResetPasswordRequest{
-					Password:        "updated-plain-password",
-					PasswordConfirm: "updated-plain-password"},
+			args: validRequest,
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return resetClaims("rpid"), nil
+				})
+				m.passwordResetRepoMock.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("oops! error no rows"), nil)
 			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail ResetPasswordByEmail (error repo)",
+			svc:  &ownerService{},
+			args: validRequest,
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting("rpid"), nil
+					return resetClaims("rpid"), nil
 				})
+				m.passwordResetRepoMock.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Id: 1, Email: "test@example.com"}, nil, nil)
 				m.ownerRepoMock.
 					EXPECT().
-					UpdatePasswordByEmail(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf("")).
-					Return(int64(0), errors.New("oops! error no rows"), nil)
+					UpdatePasswordByID(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf("")).
+					Return(int64(0), nil, errRepo)
 			},
-			wantErr: true,
+			wantErr: errRepo,
 		},
 		{
-			name: "fail ResetPasswordByEmail (error repo)",
+			name: "fail ResetPasswordByEmail (error delete sessions)",
 			svc:  &ownerService{},
-			args: args{
-				ctx:             context.Background(),
-				passwordResetID: "rpid",
-				req: model.ResetPasswordRequest{
-					Password:        "updated-plain-password",
-					PasswordConfirm: "updated-plain-password"},
-			},
+			args: validRequest,
 			prepareMocks: func(m *mocks) {
 				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "password-token" })
 				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting("rpid"), nil
+					return resetClaims("rpid"), nil
 				})
+				m.passwordResetRepoMock.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Id: 1, Email: "test@example.com"}, nil, nil)
 				m.ownerRepoMock.
 					EXPECT().
-					UpdatePasswordByEmail(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf("")).
-					Return(int64(0), nil, errors.New("oops! error repo"))
+					UpdatePasswordByID(gomock.AssignableToTypeOf(context.Background()), int64(1), gomock.AssignableToTypeOf("")).
+					Return(int64(1), nil, nil)
+				m.authRepoMock.EXPECT().DeleteOwnerSessions(gomock.Any(), int64(1)).Return(int64(0), errRepo)
 			},
-			wantErr: true,
+			wantErr: errRepo,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			utMocks := utils.InitMock()
 			ctrl := gomock.NewController(t)
-			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
-			tt.svc.ownerRepo = ownerRepoMock
+			m := &mocks{
+				utMocks:               utMocks,
+				ownerRepoMock:         repository.NewMockOwnerRepository(ctrl),
+				authRepoMock:          repository.NewMockAuthRepository(ctrl),
+				loginAttemptRepoMock:  repository.NewMockLoginAttemptRepository(ctrl),
+				passwordResetRepoMock: repository.NewMockPasswordResetRepository(ctrl),
+			}
+			tt.svc.ownerRepo = m.ownerRepoMock
+			tt.svc.authRepo = m.authRepoMock
+			tt.svc.loginAttemptRepo = m.loginAttemptRepoMock
+			tt.svc.passwordResetRepo = m.passwordResetRepoMock
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{utMocks: utMocks, ownerRepoMock: ownerRepoMock})
+				tt.prepareMocks(m)
 			}
 
 			err := tt.svc.ResetPasswordByEmail(tt.args.ctx, tt.args.passwordResetID, tt.args.req)
-			assert.Equal(t, err != nil, tt.wantErr)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
 
 			utMocks.UnpatchAll()
 
@@ -918,18 +1098,37 @@ func Test_ownerService_UpdateEmailByID(t *testing.T) {
 		req model.UpdateEmailRequest
 	}
 	type mocks struct {
-		utMocks       utils.Mock
-		ownerRepoMock *repository.MockOwnerRepository
+		utMocks                   utils.Mock
+		ownerRepoMock             *repository.MockOwnerRepository
+		emailVerificationRepoMock *repository.MockEmailVerificationRepository
+		mailerMock                *MockMailer
+	}
+	errSMTP := errors.New("oops! error smtp")
+	// loggedIn patch the context of the logged in owner 1
+	loggedIn := func(m *mocks) {
+		m.utMocks.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
+			if key == consts.CtxKeyAuthorization {
+				return "access-token"
+			}
+			if key == consts.CtxKeySession {
+				return &model.AuthSessionResponse{Valid: true, OwnerID: 1}
+			}
+
+			return nil
+		})
+		m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+			return utils.NewJWTClaimTesting(""), nil
+		})
 	}
 	tests := []struct {
 		name         string
 		svc          *ownerService
 		args         args
 		prepareMocks func(*mocks)
-		wantErr      bool
+		wantErr      error
 	}{
 		{
-			name: "success UpdateEmailByID",
+			name: "success UpdateEmailByID (verification sent to the new email)",
 			svc:  &ownerService{},
 			args: args{
 				ctx: context.Background(),
@@ -937,24 +1136,14 @@ func Test_ownerService_UpdateEmailByID(t *testing.T) {
 				req: model.UpdateEmailRequest{Email: "test.updated@example.com"},
 			},
 			prepareMocks: func(m *mocks) {
-				m.utMocks.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
-					if key == consts.CtxKeyAuthorization {
-						return "access-token"
-					}
-					if key == consts.CtxKeySession {
-						return &model.AuthSessionResponse{Valid: true, OwnerID: 1}
-					}
-
-					return nil
-				})
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting(""), nil
-				})
-				m.ownerRepoMock.
-					EXPECT().
-					UpdateEmailByID(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(0)), gomock.AssignableToTypeOf("")).
-					Return(int64(1), nil, nil)
-
+				loggedIn(m)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Name: "test", Email: "test@example.com", EmailVerified: true}, nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test.updated@example.com").Return(nil, sql.ErrNoRows, nil)
+				// the email is left as is until the new email is verified
+				m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), model.OwnerEmailVerification{
+					OwnerID: 1, Email: "test.updated@example.com", TokenHash: utils.HashOneTimeToken("verification-token"), ExpiredAt: time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC),
+				}).Return(nil)
+				m.mailerMock.EXPECT().SendEmailVerification([]string{"test.updated@example.com"}, "", "Verify Email", "test", gomock.Any()).Return(nil)
 			},
 		},
 		{
@@ -980,7 +1169,7 @@ func Test_ownerService_UpdateEmailByID(t *testing.T) {
 					return nil, errors.New("oops! error invalid token")
 				})
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrAuth,
 		},
 		{
 			name: "fail UpdateEmailByID (invalid claim token)",
@@ -1005,39 +1194,64 @@ func Test_ownerService_UpdateEmailByID(t *testing.T) {
 					return utils.NewJWTClaimTesting(""), nil
 				})
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrAuth,
 		},
 		{
-			name: "fail ResetPasswordByID (error no rows)",
+			name: "fail UpdateEmailByID (invalid email)",
 			svc:  &ownerService{},
 			args: args{
 				ctx: context.Background(),
-				id:  0,
+				id:  1,
+				req: model.UpdateEmailRequest{Email: "test.updated@example"},
+			},
+			prepareMocks: loggedIn,
+			wantErr:      apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail UpdateEmailByID (error no rows)",
+			svc:  &ownerService{},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
 				req: model.UpdateEmailRequest{Email: "test.updated@example.com"},
 			},
 			prepareMocks: func(m *mocks) {
-				m.utMocks.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
-					if key == consts.CtxKeyAuthorization {
-						return "password-token"
-					}
-					if key == consts.CtxKeySession {
-						return &model.AuthSessionResponse{Valid: true, OwnerID: 0}
-					}
-
-					return nil
-				})
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting(""), nil
-				})
-				m.ownerRepoMock.
-					EXPECT().
-					UpdateEmailByID(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(0)), gomock.AssignableToTypeOf("")).
-					Return(int64(0), errors.New("oops! error no rows"), nil)
+				loggedIn(m)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows, nil)
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail UpdateEmailByID (current email)",
+			svc:  &ownerService{},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				req: model.UpdateEmailRequest{Email: "test@example.com"},
+			},
+			prepareMocks: func(m *mocks) {
+				loggedIn(m)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com", EmailVerified: true}, nil, nil)
+			},
+			wantErr: apperrors.ErrFieldValidation,
 		},
 		{
-			name: "fail UpdateEmailByID (error repo)",
+			name: "fail UpdateEmailByID (email already registered)",
+			svc:  &ownerService{},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				req: model.UpdateEmailRequest{Email: "another@example.com"},
+			},
+			prepareMocks: func(m *mocks) {
+				loggedIn(m)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com", EmailVerified: true}, nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "another@example.com").Return(&model.Owner{Id: 2, Email: "another@example.com"}, nil, nil)
+			},
+			wantErr: apperrors.ErrEmailRegistered,
+		},
+		{
+			name: "fail UpdateEmailByID (error mailer)",
 			svc:  &ownerService{},
 			args: args{
 				ctx: context.Background(),
@@ -1045,42 +1259,242 @@ func Test_ownerService_UpdateEmailByID(t *testing.T) {
 				req: model.UpdateEmailRequest{Email: "test.updated@example.com"},
 			},
 			prepareMocks: func(m *mocks) {
-				m.utMocks.Patch("ValueContext", func(ctx context.Context, key string) interface{} {
-					if key == consts.CtxKeyAuthorization {
-						return "access-token"
-					}
-					if key == consts.CtxKeySession {
-						return &model.AuthSessionResponse{Valid: true, OwnerID: 1}
-					}
-
-					return nil
-				})
-				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
-					return utils.NewJWTClaimTesting("rpid"), nil
-				})
-				m.ownerRepoMock.
-					EXPECT().
-					UpdateEmailByID(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(int64(0)), gomock.AssignableToTypeOf("")).
-					Return(int64(0), nil, errors.New("oops! error repo"))
+				loggedIn(m)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com", EmailVerified: true}, nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test.updated@example.com").Return(nil, sql.ErrNoRows, nil)
+				m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				m.mailerMock.EXPECT().SendEmailVerification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSMTP)
 			},
-			wantErr: true,
+			wantErr: errSMTP,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			utMocks := utils.InitMock()
+			config.InitMock()
+			config.Cfg().Web.EmailVerification.TokenTTL = 24 * time.Hour
+			utMocks.Patch("Now", func() time.Time { return time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC) })
+			utMocks.Patch("GenerateOneTimeToken", func() (string, error) { return "verification-token", nil })
 			ctrl := gomock.NewController(t)
-			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
-			tt.svc.ownerRepo = ownerRepoMock
+			m := mocks{
+				utMocks:                   utMocks,
+				ownerRepoMock:             repository.NewMockOwnerRepository(ctrl),
+				emailVerificationRepoMock: repository.NewMockEmailVerificationRepository(ctrl),
+				mailerMock:                NewMockMailer(ctrl),
+			}
+			tt.svc.ownerRepo = m.ownerRepoMock
+			tt.svc.emailVerificationRepo = m.emailVerificationRepoMock
+			tt.svc.mailer = m.mailerMock
 
 			if tt.prepareMocks != nil {
-				tt.prepareMocks(&mocks{utMocks: utMocks, ownerRepoMock: ownerRepoMock})
+				tt.prepareMocks(&m)
 			}
 
 			err := tt.svc.UpdateEmailByID(tt.args.ctx, tt.args.id, tt.args.req)
-			assert.Equal(t, err != nil, tt.wantErr)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			utMocks.UnpatchAll()
+			config.DestroyMock()
+		})
+	}
+}
+
+func Test_ownerService_VerifyEmail(t *testing.T) {
+	type mocks struct {
+		ownerRepoMock             *repository.MockOwnerRepository
+		emailVerificationRepoMock *repository.MockEmailVerificationRepository
+		mailerMock                *MockMailer
+	}
+	now := time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)
+	tokenHash := utils.HashOneTimeToken("verification-token")
+	registration := &model.OwnerEmailVerification{ID: 3, OwnerID: 1, Email: "test@example.com", TokenHash: tokenHash, ExpiredAt: now.Add(time.Hour)}
+	change := &model.OwnerEmailVerification{ID: 4, OwnerID: 1, Email: "test.updated@example.com", TokenHash: tokenHash, ExpiredAt: now.Add(time.Hour)}
+	tests := []struct {
+		name         string
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success VerifyEmail (registered email)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(registration, nil, nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Name: "test", Email: "test@example.com"}, nil, nil)
+				m.emailVerificationRepoMock.EXPECT().Verify(gomock.Any(), int64(3)).Return(nil, nil)
+			},
+		},
+		{
+			name: "success VerifyEmail (changed email, the previous email is notified)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(change, nil, nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Name: "test", Email: "test@example.com", EmailVerified: true}, nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test.updated@example.com").Return(nil, sql.ErrNoRows, nil)
+				m.emailVerificationRepoMock.EXPECT().Verify(gomock.Any(), int64(4)).Return(nil, nil)
+				m.mailerMock.EXPECT().SendEmailChanged([]string{"test@example.com"}, "", "Email Changed", "test", "test.updated@example.com").Return(nil)
+			},
+		},
+		{
+			name: "success VerifyEmail (changed email, the previous email failed to be notified)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(change, nil, nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Name: "test", Email: "test@example.com", EmailVerified: true}, nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test.updated@example.com").Return(nil, sql.ErrNoRows, nil)
+				m.emailVerificationRepoMock.EXPECT().Verify(gomock.Any(), int64(4)).Return(nil, nil)
+				m.mailerMock.EXPECT().SendEmailChanged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("oops! error smtp"))
+			},
+		},
+		{
+			name: "fail VerifyEmail (unknown token)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(nil, sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail VerifyEmail (used link)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(&model.OwnerEmailVerification{ID: 3, OwnerID: 1, Email: "test@example.com", ExpiredAt: now.Add(time.Hour), Used: true}, nil, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail VerifyEmail (expired link)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(&model.OwnerEmailVerification{ID: 3, OwnerID: 1, Email: "test@example.com", ExpiredAt: now}, nil, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail VerifyEmail (link clicked concurrently)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(registration, nil, nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com"}, nil, nil)
+				m.emailVerificationRepoMock.EXPECT().Verify(gomock.Any(), int64(3)).Return(sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail VerifyEmail (new email registered since)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(change, nil, nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Email: "test@example.com", EmailVerified: true}, nil, nil)
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test.updated@example.com").Return(&model.Owner{Id: 2, Email: "test.updated@example.com"}, nil, nil)
+			},
+			wantErr: apperrors.ErrEmailRegistered,
+		},
+		{
+			name: "fail VerifyEmail (owner deleted)",
+			prepareMocks: func(m *mocks) {
+				m.emailVerificationRepoMock.EXPECT().GetByTokenHash(gomock.Any(), tokenHash).Return(registration, nil, nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMocks := utils.InitMock()
+			utMocks.Patch("Now", func() time.Time { return now })
+			m := mocks{
+				ownerRepoMock:             repository.NewMockOwnerRepository(ctrl),
+				emailVerificationRepoMock: repository.NewMockEmailVerificationRepository(ctrl),
+				mailerMock:                NewMockMailer(ctrl),
+			}
+			svc := &ownerService{ownerRepo: m.ownerRepoMock, emailVerificationRepo: m.emailVerificationRepoMock, mailer: m.mailerMock}
+			tt.prepareMocks(&m)
+
+			err := svc.VerifyEmail(context.Background(), "verification-token")
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_ownerService_ResendEmailVerification(t *testing.T) {
+	type mocks struct {
+		ownerRepoMock             *repository.MockOwnerRepository
+		emailVerificationRepoMock *repository.MockEmailVerificationRepository
+		mailerMock                *MockMailer
+	}
+	errDB := errors.New("oops! db error")
+	tests := []struct {
+		name         string
+		req          model.ResendEmailVerificationRequest
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success ResendEmailVerification",
+			req:  model.ResendEmailVerificationRequest{Email: "test@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Id: 1, Name: "test", Email: "test@example.com"}, nil, nil)
+				m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), model.OwnerEmailVerification{
+					OwnerID: 1, Email: "test@example.com", TokenHash: utils.HashOneTimeToken("verification-token"), ExpiredAt: time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC),
+				}).Return(nil)
+				m.mailerMock.EXPECT().SendEmailVerification([]string{"test@example.com"}, "", "Verify Email", "test", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			// answered like a sent link, nothing is sent
+			name: "success ResendEmailVerification (verified already)",
+			req:  model.ResendEmailVerificationRequest{Email: "test@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(&model.Owner{Id: 1, Email: "test@example.com", EmailVerified: true}, nil, nil)
+			},
+		},
+		{
+			// answered like a sent link, nothing is sent
+			name: "success ResendEmailVerification (email not registered)",
+			req:  model.ResendEmailVerificationRequest{Email: "unknown@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "unknown@example.com").Return(nil, sql.ErrNoRows, nil)
+			},
+		},
+		{
+			name: "fail ResendEmailVerification (db error)",
+			req:  model.ResendEmailVerificationRequest{Email: "test@example.com"},
+			prepareMocks: func(m *mocks) {
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, nil, errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name:         "fail ResendEmailVerification (missing email)",
+			prepareMocks: func(m *mocks) {},
+			wantErr:      apperrors.ErrFieldValidationRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utMocks := utils.InitMock()
+			config.InitMock()
+			config.Cfg().Web.EmailVerification.TokenTTL = 24 * time.Hour
+			utMocks.Patch("Now", func() time.Time { return time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC) })
+			utMocks.Patch("GenerateOneTimeToken", func() (string, error) { return "verification-token", nil })
+			m := mocks{
+				ownerRepoMock:             repository.NewMockOwnerRepository(ctrl),
+				emailVerificationRepoMock: repository.NewMockEmailVerificationRepository(ctrl),
+				mailerMock:                NewMockMailer(ctrl),
+			}
+			svc := &ownerService{ownerRepo: m.ownerRepoMock, emailVerificationRepo: m.emailVerificationRepoMock, mailer: m.mailerMock}
+			tt.prepareMocks(&m)
+
+			err := svc.ResendEmailVerification(context.Background(), tt.req)
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
 
 			utMocks.UnpatchAll()
+			config.DestroyMock()
 		})
 	}
 }
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/logger"
+	"family-catering/pkg/utils"
+	"fmt"
+)
+
+// results of a handled webhook
+const (
+	webhookResultProcessed = "processed"
+	webhookResultIgnored   = "ignored"
+	webhookResultDuplicate = "duplicate"
+	webhookResultRefunded  = "refunded"
+)
+
+type PaymentService interface {
+	CreateCharge(ctx context.Context, provider string, req model.CreateChargeRequest) (resp *model.CreateChargeResponse, err error)
+	HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) (resp *model.PaymentWebhookResponse, err error)
+}
+
+type paymentService struct {
+	paymentRepo  repository.PaymentRepository
+	orderService OrderService
+	providers    map[string]PaymentProvider
+}
+
+func NewPaymentService(paymentRepo repository.PaymentRepository, orderService OrderService, providers ...PaymentProvider) PaymentService {
+	svc := &paymentService{
+		paymentRepo:  paymentRepo,
+		orderService: orderService,
+		providers:    make(map[string]PaymentProvider, len(providers)),
+	}
+	for _, provider := range providers {
+		svc.providers[provider.Name()] = provider
+	}
+
+	return svc
+}
+
+// CreateCharge request a charge of an unpaid order to the provider, amount default to the remaining balance
+func (svc *paymentService) CreateCharge(ctx context.Context, providerName string, req model.CreateChargeRequest) (*model.CreateChargeResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.paymentService.CreateCharge: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.paymentService.CreateCharge: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	err = utils.ValidateRequest(&req)
+	if err == apperrors.ErrRequiredParam {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	provider, err := svc.provider(providerName)
+	if err != nil {
+		err = fmt.Errorf("service.paymentService.CreateCharge: %w", err)
+		return nil, err
+	}
+
+	summary, errNoRow, err := svc.paymentRepo.Balance(ctx, claims.BusinessID, req.OrderID)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.paymentService.CreateCharge: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.paymentService.CreateCharge: %w", err)
+		return nil, err
+	}
+
+	if !isOrderPayable(summary.Status) {
+		err = fmt.Errorf("service.paymentService.CreateCharge: %w", repository.ErrOrderNotPayable)
+		msg := fmt.Sprintf("order is %s, it can't be paid anymore", orderStatusName(summary.Status))
+		return nil, apperrors.WrapError(err, apperrors.ErrOrderStatusTransition, msg)
+	}
+
+	remaining := summary.TotalPrice.Sub(summary.TotalPaid)
+	amount := req.Amount
+	if amount.IsZero() {
+		amount = remaining
+	}
+	if amount.Cmp(remaining) > 0 {
+		err = fmt.Errorf("service.paymentService.CreateCharge: %w", repository.ErrPaymentExceedsBalance)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, fmt.Sprintf("amount exceeds the remaining balance (%s)", remaining))
+	}
+
+	charge, err := provider.CreateCharge(ctx, req.OrderID, amount)
+	if err != nil {
+		err = fmt.Errorf("service.paymentService.CreateCharge: %w", err)
+		return nil, err
+	}
+
+	resp := &model.CreateChargeResponse{
+		ChargeID:    charge.ID,
+		Provider:    charge.Provider,
+		OrderID:     charge.OrderID,
+		Amount:      charge.Amount,
+		CheckoutURL: charge.CheckoutURL,
+	}
+
+	return resp, nil
+}
+
+// HandleWebhook verify and record a payment event sent by the provider. The provider retries a webhook until it gets
+// a successful response, so replayed event is acknowledged without recording it again and payment that can't be
+// accepted anymore (e.g. the order is cancelled meanwhile) is refunded instead of failing the webhook. The refunded event
+// is recorded as well so its replay isn't refunded again.
+func (svc *paymentService) HandleWebhook(ctx context.Context, providerName string, payload []byte, signature string) (*model.PaymentWebhookResponse, error) {
+	provider, err := svc.provider(providerName)
+	if err != nil {
+		err = fmt.Errorf("service.paymentService.HandleWebhook: %w", err)
+		return nil, err
+	}
+
+	event, err := provider.VerifyWebhook(payload, signature)
+	if errors.Is(err, ErrWebhookSignature) {
+		err = fmt.Errorf("service.paymentService.HandleWebhook: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrInvalidSignature, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.paymentService.HandleWebhook: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "invalid webhook payload")
+	}
+
+	resp := &model.PaymentWebhookResponse{EventID: event.ID}
+	if event.Type != PaymentEventSucceeded {
+		resp.Result = webhookResultIgnored
+		return resp, nil
+	}
+
+	payment := &model.Payment{
+		OrderID:         event.OrderID,
+		Amount:          event.Amount,
+		Method:          consts.PaymentMethodCard,
+		ReferenceNumber: sql.NullString{String: event.ChargeID, Valid: event.ChargeID != ""},
+		Provider:        sql.NullString{String: provider.Name(), Valid: true},
+		ProviderEventID: sql.NullString{String: event.ID, Valid: true},
+	}
+	paid, err := svc.orderService.ConfirmProviderPayment(ctx, payment)
+	if errors.Is(err, repository.ErrDuplicatePaymentEvent) {
+		resp.Result = webhookResultDuplicate
+		return resp, nil
+	}
+	if errors.Is(err, repository.ErrOrderNotPayable) || errors.Is(err, repository.ErrPaymentExceedsBalance) {
+		logger.Warn("refund payment event %s of order %d: %s", event.ID, event.OrderID, err.Error())
+		_, err = provider.Refund(ctx, event.ChargeID, event.Amount)
+		if err != nil {
+			err = fmt.Errorf("service.paymentService.HandleWebhook: %w", err)
+			// the failed webhook is retried by the provider and refunded then
+			errDelete := svc.paymentRepo.DeleteRefund(ctx, provider.Name(), event.ID)
+			if errDelete != nil {
+				logger.Error(errDelete, "refund of payment event %s of order %d failed and can't be retried", event.ID, event.OrderID)
+			}
+			return nil, err
+		}
+		resp.Result = webhookResultRefunded
+		return resp, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("service.paymentService.HandleWebhook: %w", err)
+		return nil, err
+	}
+
+	resp.Result = webhookResultProcessed
+	resp.OrderStatus = paid.Status
+
+	return resp, nil
+}
+
+func (svc *paymentService) provider(name string) (PaymentProvider, error) {
+	provider, ok := svc.providers[name]
+	if !ok {
+		err := fmt.Errorf("service.paymentService.provider: unknown payment provider %q", name)
+		return nil, apperrors.WrapError(err, apperrors.ErrNotFound, "unknown payment provider")
+	}
+
+	return provider, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/money"
+)
+
+// payment event's types sent through provider's webhook, the provider implementation maps its own types to these
+const (
+	PaymentEventSucceeded = "payment.succeeded"
+	PaymentEventFailed    = "payment.failed"
+)
+
+// ErrWebhookSignature is returned by PaymentProvider.VerifyWebhook when the payload is not signed by the provider
+var ErrWebhookSignature = errors.New("invalid webhook signature")
+
+// PaymentProvider is a payment gateway used to receive online payment,
+// each provider is registered to PaymentService by its Name.
+type PaymentProvider interface {
+	Name() string
+	// CreateCharge request a charge of the order to the provider, the customer pays it through the charge's checkout url
+	CreateCharge(ctx context.Context, orderID int64, amount money.Money) (*model.Charge, error)
+	// VerifyWebhook check signature of the raw webhook's payload and parse it into PaymentEvent,
+	// ErrWebhookSignature is returned when the signature doesn't match
+	VerifyWebhook(payload []byte, signature string) (*model.PaymentEvent, error)
+	Refund(ctx context.Context, chargeID string, amount money.Money) (*model.Refund, error)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/pkg/money"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const FakePaymentProviderName = "fake"
+
+// fakePaymentProvider is a local payment provider without network, it's used for development and tests.
+// Webhook's payload is signed with HMAC-SHA256 of the secret (hex encoded), see SignFakePaymentWebhook.
+type fakePaymentProvider struct {
+	secret      string
+	checkoutURL string
+
+	mu       sync.Mutex
+	nCharges int
+	nRefunds int
+}
+
+func NewFakePaymentProvider(opts FakePaymentProviderOption) PaymentProvider {
+	return &fakePaymentProvider{
+		secret:      opts.Secret,
+		checkoutURL: strings.TrimSuffix(opts.CheckoutURL, "/"),
+	}
+}
+
+type FakePaymentProviderOption struct {
+	Secret      string
+	CheckoutURL string
+}
+
+// SignFakePaymentWebhook return signature of a webhook's payload sent by the fake provider
+func SignFakePaymentWebhook(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *fakePaymentProvider) Name() string {
+	return FakePaymentProviderName
+}
+
+func (p *fakePaymentProvider) CreateCharge(ctx context.Context, orderID int64, amount money.Money) (*model.Charge, error) {
+	p.mu.Lock()
+	p.nCharges++
+	id := fmt.Sprintf("fake_ch_%d_%d", orderID, p.nCharges)
+	p.mu.Unlock()
+
+	return &model.Charge{
+		ID:          id,
+		Provider:    FakePaymentProviderName,
+		OrderID:     orderID,
+		Amount:      amount,
+		CheckoutURL: fmt.Sprintf("%s/%s", p.checkoutURL, id),
+	}, nil
+}
+
+func (p *fakePaymentProvider) VerifyWebhook(payload []byte, signature string) (*model.PaymentEvent, error) {
+	// empty secret would make every payload could be signed by anyone
+	if p.secret == "" {
+		return nil, fmt.Errorf("service.fakePaymentProvider.VerifyWebhook: %w", errors.New("secret is not configured"))
+	}
+
+	want := SignFakePaymentWebhook(p.secret, payload)
+	if !hmac.Equal([]byte(want), []byte(strings.ToLower(strings.TrimSpace(signature)))) {
+		return nil, fmt.Errorf("service.fakePaymentProvider.VerifyWebhook: %w", ErrWebhookSignature)
+	}
+
+	event := &model.PaymentEvent{}
+	err := json.Unmarshal(payload, event)
+	if err != nil {
+		return nil, fmt.Errorf("service.fakePaymentProvider.VerifyWebhook: %w", err)
+	}
+	if event.ID == "" {
+		return nil, fmt.Errorf("service.fakePaymentProvider.VerifyWebhook: event id is empty")
+	}
+
+	return event, nil
+}
+
+func (p *fakePaymentProvider) Refund(ctx context.Context, chargeID string, amount money.Money) (*model.Refund, error) {
+	p.mu.Lock()
+	p.nRefunds++
+	id := fmt.Sprintf("fake_re_%d", p.nRefunds)
+	p.mu.Unlock()
+
+	return &model.Refund{ID: id, ChargeID: chargeID, Amount: amount}, nil
+}
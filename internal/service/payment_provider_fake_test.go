@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"family-catering/internal/model"
+	"family-catering/pkg/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fakePaymentProvider_VerifyWebhook(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded","charge_id":"ch_1","order_id":1,"amount":50000}`)
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		wantEvent *model.PaymentEvent
+		wantErr   error
+	}{
+		{
+			name:      "success VerifyWebhook",
+			secret:    "secret",
+			payload:   payload,
+			signature: SignFakePaymentWebhook("secret", payload),
+			wantEvent: &model.PaymentEvent{ID: "evt_1", Type: PaymentEventSucceeded, ChargeID: "ch_1", OrderID: 1, Amount: money.MustParse("50000")},
+		},
+		{
+			name:      "fail VerifyWebhook (forged signature)",
+			secret:    "secret",
+			payload:   payload,
+			signature: SignFakePaymentWebhook("other-secret", payload),
+			wantErr:   ErrWebhookSignature,
+		},
+		{
+			name:      "fail VerifyWebhook (no signature)",
+			secret:    "secret",
+			payload:   payload,
+			signature: "",
+			wantErr:   ErrWebhookSignature,
+		},
+		{
+			name:      "fail VerifyWebhook (secret is not configured)",
+			secret:    "",
+			payload:   payload,
+			signature: SignFakePaymentWebhook("", payload),
+		},
+		{
+			name:      "fail VerifyWebhook (invalid json)",
+			secret:    "secret",
+			payload:   []byte(`{`),
+			signature: SignFakePaymentWebhook("secret", []byte(`{`)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFakePaymentProvider(FakePaymentProviderOption{Secret: tt.secret})
+
+			gotEvent, err := p.VerifyWebhook(tt.payload, tt.signature)
+
+			assert.Equal(t, tt.wantEvent, gotEvent)
+			if tt.wantEvent != nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_fakePaymentProvider_CreateCharge_Refund(t *testing.T) {
+	p := NewFakePaymentProvider(FakePaymentProviderOption{Secret: "secret", CheckoutURL: "http://localhost/checkout"})
+
+	charge, err := p.CreateCharge(context.Background(), 1, money.MustParse("50000"))
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Charge{ID: "fake_ch_1_1", Provider: "fake", OrderID: 1, Amount: money.MustParse("50000"), CheckoutURL: "http://localhost/checkout/fake_ch_1_1"}, charge)
+
+	refund, err := p.Refund(context.Background(), charge.ID, charge.Amount)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Refund{ID: "fake_re_1", ChargeID: "fake_ch_1_1", Amount: money.MustParse("50000")}, refund)
+}
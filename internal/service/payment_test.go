@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/money"
+	"family-catering/pkg/utils"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPaymentService(t *testing.T) {
+	provider := NewFakePaymentProvider(FakePaymentProviderOption{Secret: "secret"})
+	svc := NewPaymentService(nil, nil, provider)
+
+	assert.NotNil(t, svc)
+	assert.Equal(t, provider, svc.(*paymentService).providers[FakePaymentProviderName])
+}
+
+func Test_paymentService_CreateCharge(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		provider string
+		req      model.CreateChargeRequest
+	}
+	type mocks struct {
+		utMocks         utils.Mock
+		paymentRepoMock *repository.MockPaymentRepository
+	}
+	validToken := func(m *mocks) {
+		m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+		m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+			return &utils.JwtClaims{BusinessID: 1}, nil
+		})
+		m.utMocks.Patch("ValidateRequest", func(s interface{}) error { return nil })
+	}
+	tests := []struct {
+		name         string
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.CreateChargeResponse
+		wantErr      error
+	}{
+		{
+			name: "success CreateCharge (remaining balance)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+				m.paymentRepoMock.EXPECT().Balance(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("20000"), Status: consts.StatusNew}, nil, nil)
+			},
+			wantResp: &model.CreateChargeResponse{ChargeID: "fake_ch_1_1", Provider: "fake", OrderID: 1, Amount: money.MustParse("30000"), CheckoutURL: "http://localhost/checkout/fake_ch_1_1"},
+		},
+		{
+			name: "success CreateCharge (partial amount)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1, Amount: money.MustParse("10000")}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+				m.paymentRepoMock.EXPECT().Balance(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusConfirmed}, nil, nil)
+			},
+			wantResp: &model.CreateChargeResponse{ChargeID: "fake_ch_1_1", Provider: "fake", OrderID: 1, Amount: money.MustParse("10000"), CheckoutURL: "http://localhost/checkout/fake_ch_1_1"},
+		},
+		{
+			// a CONFIRMED order may be prepared (and delivered) before it's paid
+			name: "success CreateCharge (order delivered before it's paid)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+				m.paymentRepoMock.EXPECT().Balance(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusDelivered}, nil, nil)
+			},
+			wantResp: &model.CreateChargeResponse{ChargeID: "fake_ch_1_1", Provider: "fake", OrderID: 1, Amount: money.MustParse("50000"), CheckoutURL: "http://localhost/checkout/fake_ch_1_1"},
+		},
+		{
+			name: "fail CreateCharge (exceeds remaining balance)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1, Amount: money.MustParse("60000")}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+				m.paymentRepoMock.EXPECT().Balance(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), Status: consts.StatusNew}, nil, nil)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail CreateCharge (order is not payable)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+				m.paymentRepoMock.EXPECT().Balance(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(&model.PaymentSummary{OrderID: 1, TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: consts.StatusPaid}, nil, nil)
+			},
+			wantErr: apperrors.ErrOrderStatusTransition,
+		},
+		{
+			name: "fail CreateCharge (order not found)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+				m.paymentRepoMock.EXPECT().Balance(gomock.AssignableToTypeOf(context.Background()), int64(1), int64(1)).
+					Return(nil, errors.New("oops! error no rows"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail CreateCharge (unknown provider)",
+			args: args{ctx: context.Background(), provider: "unknown", req: model.CreateChargeRequest{OrderID: 1}},
+			prepareMocks: func(m *mocks) {
+				validToken(m)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail CreateCharge (invalid/no token)",
+			args: args{ctx: context.Background(), provider: "fake", req: model.CreateChargeRequest{OrderID: 1}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			paymentRepoMock := repository.NewMockPaymentRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{paymentRepoMock: paymentRepoMock, utMocks: utMocks})
+			}
+
+			provider := NewFakePaymentProvider(FakePaymentProviderOption{Secret: "secret", CheckoutURL: "http://localhost/checkout/"})
+			svc := NewPaymentService(paymentRepoMock, nil, provider)
+
+			gotResp, err := svc.CreateCharge(tt.args.ctx, tt.args.provider, tt.args.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_paymentService_HandleWebhook(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		provider  string
+		payload   []byte
+		signature string
+	}
+	type mocks struct {
+		orderServiceMock *MockOrderService
+	}
+	const secret = "secret"
+	errDB := errors.New("oops! db error")
+	succeeded := []byte(`{"id":"evt_1","type":"payment.succeeded","charge_id":"ch_1","order_id":1,"amount":50000}`)
+	payment := &model.Payment{
+		OrderID:         1,
+		Amount:          money.MustParse("50000"),
+		Method:          "card",
+		ReferenceNumber: sql.NullString{String: "ch_1", Valid: true},
+		Provider:        sql.NullString{String: "fake", Valid: true},
+		ProviderEventID: sql.NullString{String: "evt_1", Valid: true},
+	}
+	tests := []struct {
+		name         string
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.PaymentWebhookResponse
+		wantErr      error
+	}{
+		{
+			name: "success HandleWebhook (processed)",
+			args: args{ctx: context.Background(), provider: "fake", payload: succeeded, signature: SignFakePaymentWebhook(secret, succeeded)},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), payment).
+					Return(&model.ConfirmPaymentResponse{PaymentID: 1, OrderID: 1, Amount: money.MustParse("50000"), TotalPrice: money.MustParse("50000"), TotalPaid: money.MustParse("50000"), Status: "PAID"}, nil)
+			},
+			wantResp: &model.PaymentWebhookResponse{EventID: "evt_1", Result: "processed", OrderStatus: "PAID"},
+		},
+		{
+			name: "success HandleWebhook (replayed event)",
+			args: args{ctx: context.Background(), provider: "fake", payload: succeeded, signature: SignFakePaymentWebhook(secret, succeeded)},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), payment).
+					Return(nil, fmt.Errorf("oops! %w", repository.ErrDuplicatePaymentEvent))
+			},
+			wantResp: &model.PaymentWebhookResponse{EventID: "evt_1", Result: "duplicate"},
+		},
+		{
+			name: "success HandleWebhook (refunded, order is cancelled)",
+			args: args{ctx: context.Background(), provider: "fake", payload: succeeded, signature: SignFakePaymentWebhook(secret, succeeded)},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), payment).
+					Return(nil, apperrors.WrapError(fmt.Errorf("oops! %w", repository.ErrOrderNotPayable), apperrors.ErrOrderStatusTransition, ""))
+			},
+			wantResp: &model.PaymentWebhookResponse{EventID: "evt_1", Result: "refunded"},
+		},
+		{
+			name:     "success HandleWebhook (ignored event type)",
+			args:     args{ctx: context.Background(), provider: "fake", payload: []byte(`{"id":"evt_2","type":"payment.failed","order_id":1}`), signature: SignFakePaymentWebhook(secret, []byte(`{"id":"evt_2","type":"payment.failed","order_id":1}`))},
+			wantResp: &model.PaymentWebhookResponse{EventID: "evt_2", Result: "ignored"},
+		},
+		{
+			name:    "fail HandleWebhook (forged signature)",
+			args:    args{ctx: context.Background(), provider: "fake", payload: succeeded, signature: SignFakePaymentWebhook("not-the-secret", succeeded)},
+			wantErr: apperrors.ErrInvalidSignature,
+		},
+		{
+			name:    "fail HandleWebhook (tampered payload)",
+			args:    args{ctx: context.Background(), provider: "fake", payload: []byte(`{"id":"evt_1","type":"payment.succeeded","charge_id":"ch_1","order_id":1,"amount":1}`), signature: SignFakePaymentWebhook(secret, succeeded)},
+			wantErr: apperrors.ErrInvalidSignature,
+		},
+		{
+			name:    "fail HandleWebhook (invalid payload)",
+			args:    args{ctx: context.Background(), provider: "fake", payload: []byte(`{"type":"payment.succeeded"}`), signature: SignFakePaymentWebhook(secret, []byte(`{"type":"payment.succeeded"}`))},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name:    "fail HandleWebhook (unknown provider)",
+			args:    args{ctx: context.Background(), provider: "unknown", payload: succeeded, signature: SignFakePaymentWebhook(secret, succeeded)},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail HandleWebhook (order service error)",
+			args: args{ctx: context.Background(), provider: "fake", payload: succeeded, signature: SignFakePaymentWebhook(secret, succeeded)},
+			prepareMocks: func(m *mocks) {
+				m.orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), payment).
+					Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			orderServiceMock := NewMockOrderService(ctrl)
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{orderServiceMock: orderServiceMock})
+			}
+
+			provider := NewFakePaymentProvider(FakePaymentProviderOption{Secret: secret})
+			svc := NewPaymentService(nil, orderServiceMock, provider)
+
+			gotResp, err := svc.HandleWebhook(tt.args.ctx, tt.args.provider, tt.args.payload, tt.args.signature)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+		})
+	}
+}
+
+func Test_paymentService_HandleWebhook_refundOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderServiceMock := NewMockOrderService(ctrl)
+	providerMock := NewMockPaymentProvider(ctrl)
+
+	event := &model.PaymentEvent{ID: "evt_1", Type: PaymentEventSucceeded, ChargeID: "ch_1", OrderID: 1, Amount: money.MustParse("50000")}
+	providerMock.EXPECT().Name().Return("fake").AnyTimes()
+	providerMock.EXPECT().VerifyWebhook([]byte("payload"), "signature").Return(event, nil).Times(2)
+	// the refused event is recorded as refunded by the payment, its replay is a duplicate
+	gomock.InOrder(
+		orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+			Return(nil, fmt.Errorf("oops! %w", repository.ErrOrderNotPayable)),
+		orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+			Return(nil, fmt.Errorf("oops! %w", repository.ErrDuplicatePaymentEvent)),
+	)
+	providerMock.EXPECT().Refund(gomock.AssignableToTypeOf(context.Background()), "ch_1", money.MustParse("50000")).
+		Return(&model.Refund{ID: "re_1", ChargeID: "ch_1", Amount: money.MustParse("50000")}, nil).Times(1)
+
+	svc := NewPaymentService(nil, orderServiceMock, providerMock)
+
+	gotResp, err := svc.HandleWebhook(context.Background(), "fake", []byte("payload"), "signature")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.PaymentWebhookResponse{EventID: "evt_1", Result: "refunded"}, gotResp)
+
+	gotResp, err = svc.HandleWebhook(context.Background(), "fake", []byte("payload"), "signature")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.PaymentWebhookResponse{EventID: "evt_1", Result: "duplicate"}, gotResp)
+}
+
+func Test_paymentService_HandleWebhook_refundError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderServiceMock := NewMockOrderService(ctrl)
+	paymentRepoMock := repository.NewMockPaymentRepository(ctrl)
+	providerMock := NewMockPaymentProvider(ctrl)
+	errRefund := errors.New("oops! refund error")
+
+	event := &model.PaymentEvent{ID: "evt_1", Type: PaymentEventSucceeded, ChargeID: "ch_1", OrderID: 1, Amount: money.MustParse("50000")}
+	providerMock.EXPECT().Name().Return("fake").AnyTimes()
+	providerMock.EXPECT().VerifyWebhook([]byte("payload"), "signature").Return(event, nil)
+	orderServiceMock.EXPECT().ConfirmProviderPayment(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+		Return(nil, fmt.Errorf("oops! %w", repository.ErrPaymentExceedsBalance))
+	providerMock.EXPECT().Refund(gomock.AssignableToTypeOf(context.Background()), "ch_1", money.MustParse("50000")).Return(nil, errRefund)
+	// the recorded refund is forgotten so the provider's retry is refunded
+	paymentRepoMock.EXPECT().DeleteRefund(gomock.AssignableToTypeOf(context.Background()), "fake", "evt_1").Return(nil)
+
+	svc := NewPaymentService(paymentRepoMock, orderServiceMock, providerMock)
+
+	gotResp, err := svc.HandleWebhook(context.Background(), "fake", []byte("payload"), "signature")
+	assert.ErrorIs(t, err, errRefund)
+	assert.Nil(t, gotResp)
+}
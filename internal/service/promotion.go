@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/money"
+	"family-catering/pkg/utils"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errPromotionMinSpend      = errors.New("order is below the promotion's minimum spend")
+	errPromotionNotApplicable = errors.New("promotion is not applicable to the ordered menus")
+)
+
+type PromotionService interface {
+	GetByID(ctx context.Context, id int64) (*model.GetPromotionResponse, error)
+	List(ctx context.Context, limit, offset int) ([]*model.GetPromotionResponse, error)
+	Create(ctx context.Context, req model.CreatePromotionRequest) (*model.CreatePromotionResponse, error)
+	Update(ctx context.Context, id int64, req model.UpdatePromotionRequest) (*model.UpdatePromotionResponse, error)
+	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+}
+
+type promotionService struct {
+	promotionRepo repository.PromotionRepository
+}
+
+func NewPromotionService(promotionRepo repository.PromotionRepository) PromotionService {
+	return &promotionService{promotionRepo: promotionRepo}
+}
+
+func (svc *promotionService) GetByID(ctx context.Context, id int64) (*model.GetPromotionResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.promotionService.GetByID: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.promotionService.GetByID: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	promotion, errNoRow, err := svc.promotionRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow := fmt.Errorf("service.promotionService.GetByID: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.promotionService.GetByID: %w", err)
+		return nil, err
+	}
+
+	return newPromotionResponse(promotion), nil
+}
+
+func (svc *promotionService) List(ctx context.Context, limit, offset int) ([]*model.GetPromotionResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.promotionService.List: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.promotionService.List: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	promotions, errNoRow, err := svc.promotionRepo.List(ctx, claims.BusinessID, limit, offset)
+	if errNoRow != nil && err == nil {
+		return []*model.GetPromotionResponse{}, nil
+	}
+
+	if err != nil {
+		err := fmt.Errorf("service.promotionService.List: %w", err)
+		return nil, err
+	}
+
+	return newPromotionsResponse(promotions), nil
+}
+
+func (svc *promotionService) Create(ctx context.Context, req model.CreatePromotionRequest) (*model.CreatePromotionResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.promotionService.Create: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.promotionService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	promotion, err := newPromotion(req, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("service.promotionService.Create: %w", err)
+	}
+
+	_, errNoRow, err := svc.promotionRepo.GetByCode(ctx, claims.BusinessID, promotion.Code)
+	if errNoRow == nil && err == nil {
+		err = fmt.Errorf("service.promotionService.Create: promo code %s already registered", promotion.Code)
+		return nil, apperrors.WrapError(err, apperrors.ErrPromoCodeRegistered, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Create: %w", err)
+		return nil, err
+	}
+
+	id, err := svc.promotionRepo.Create(ctx, claims.BusinessID, promotion)
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Create: %w", err)
+		return nil, err
+	}
+
+	created, errNoRow, err := svc.promotionRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.promotionService.Create: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Create: %w", err)
+		return nil, err
+	}
+
+	return newPromotionResponse(created), nil
+}
+
+func (svc *promotionService) Update(ctx context.Context, id int64, req model.UpdatePromotionRequest) (*model.UpdatePromotionResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.promotionService.Update: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.promotionService.Update: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	current, errNoRow, err := svc.promotionRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.promotionService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Update: %w", err)
+		return nil, err
+	}
+
+	promotion, err := newPromotion(req, current.StartsAt)
+	if err != nil {
+		return nil, fmt.Errorf("service.promotionService.Update: %w", err)
+	}
+	promotion.ID = id
+
+	if promotion.Code != current.Code {
+		_, errNoRow, err := svc.promotionRepo.GetByCode(ctx, claims.BusinessID, promotion.Code)
+		if errNoRow == nil && err == nil {
+			err = fmt.Errorf("service.promotionService.Update: promo code %s already registered", promotion.Code)
+			return nil, apperrors.WrapError(err, apperrors.ErrPromoCodeRegistered, "")
+		}
+		if err != nil {
+			err = fmt.Errorf("service.promotionService.Update: %w", err)
+			return nil, err
+		}
+	}
+
+	_, errNoRow, err = svc.promotionRepo.Update(ctx, claims.BusinessID, promotion)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.promotionService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Update: %w", err)
+		return nil, err
+	}
+
+	updated, errNoRow, err := svc.promotionRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.promotionService.Update: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Update: %w", err)
+		return nil, err
+	}
+
+	return newPromotionResponse(updated), nil
+}
+
+// Delete delete a promotion which has never been used, a used promotion must be ended instead (see Update)
+// since it's referenced by the order's discount
+func (svc *promotionService) Delete(ctx context.Context, id int64) (nAffected int64, err error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.promotionService.Delete: invalid auth token type want string got %T", token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.promotionService.Delete: %w", err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	_, errNoRow, err := svc.promotionRepo.GetByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.promotionService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Delete: %w", err)
+		return 0, err
+	}
+
+	nAffected, errNoRow, err = svc.promotionRepo.Delete(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.promotionService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrPromotionInUse, "promotion has been used by an order, set its ends_at to end it instead")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.promotionService.Delete: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+// newPromotion validate the request and return the promotion to be stored,
+// defaultStartsAt (RFC3339) is used to validate ends_at when the request has no starts_at
+func newPromotion(req model.CreatePromotionRequest, defaultStartsAt string) (model.Promotion, error) {
+	err := utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.newPromotion: %w", err)
+		return model.Promotion{}, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.newPromotion: %w", err)
+		return model.Promotion{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	if req.EndsAt != "" {
+		startsAt := req.StartsAt
+		if startsAt == "" {
+			startsAt = defaultStartsAt
+		}
+		start, errStart := time.Parse(time.RFC3339, startsAt)
+		end, errEnd := time.Parse(time.RFC3339, req.EndsAt)
+		if errStart == nil && errEnd == nil && !end.After(start) {
+			err = fmt.Errorf("service.newPromotion: ends_at %s is not after starts_at %s", req.EndsAt, startsAt)
+			return model.Promotion{}, apperrors.WrapError(err, apperrors.ErrFieldValidation, "ends_at must be after starts_at")
+		}
+	}
+
+	promotion := model.Promotion{
+		Code:                  strings.ToUpper(req.Code),
+		Description:           req.Description,
+		DiscountType:          req.DiscountType,
+		MinSpend:              req.MinSpend,
+		MenuIDs:               joinIDs(req.MenuIDs),
+		Categories:            req.Categories,
+		StartsAt:              req.StartsAt,
+		EndsAt:                sql.NullString{String: req.EndsAt, Valid: req.EndsAt != ""},
+		UsageLimit:            req.UsageLimit,
+		UsageLimitPerCustomer: req.UsageLimitPerCustomer,
+	}
+	// only the fields of the discount type are kept
+	if promotion.DiscountType == consts.DiscountTypePercentage {
+		promotion.Percentage = req.Percentage
+		promotion.MaxDiscount = req.MaxDiscount
+	} else {
+		promotion.Amount = req.Amount
+	}
+
+	return promotion, nil
+}
+
+// promotionDiscount return the discount of the promotion for the order's items. The minimum spend is compared with
+// the order's total while the discount is computed once from the total of the targeted items (see package money).
+// menus are the ordered menus, they're needed when the promotion targets menu's categories.
+// errPromotionMinSpend or errPromotionNotApplicable is returned when the promotion gives no discount.
+func promotionDiscount(promotion *model.Promotion, orders []*model.Order, menus []*model.Menu) (money.Money, error) {
+	total, eligible := money.FromMinor(0), money.FromMinor(0)
+	menuIDs := splitIDs(promotion.MenuIDs)
+	categories := splitCategories(promotion.Categories)
+	menuCategories := make(map[int64]string, len(menus))
+	for _, menu := range menus {
+		menuCategories[menu.ID] = menu.Categories
+	}
+
+	for _, order := range orders {
+		subTotal := order.Price.Mul(int64(order.Qty))
+		total = total.Add(subTotal)
+
+		targeted := len(menuIDs) == 0 && len(categories) == 0
+		for _, id := range menuIDs {
+			targeted = targeted || id == order.MenuID
+		}
+		for category := range splitCategories(menuCategories[order.MenuID]) {
+			targeted = targeted || categories[category]
+		}
+		if targeted {
+			eligible = eligible.Add(subTotal)
+		}
+	}
+
+	if total.Cmp(promotion.MinSpend) < 0 {
+		return money.FromMinor(0), fmt.Errorf("%w (%s)", errPromotionMinSpend, promotion.MinSpend)
+	}
+	if !eligible.IsPositive() {
+		return money.FromMinor(0), errPromotionNotApplicable
+	}
+
+	var discount money.Money
+	switch promotion.DiscountType {
+	case consts.DiscountTypePercentage:
+		discount = eligible.Percent(int64(promotion.Percentage) * 100)
+		if promotion.MaxDiscount.IsPositive() && discount.Cmp(promotion.MaxDiscount) > 0 {
+			discount = promotion.MaxDiscount
+		}
+	case consts.DiscountTypeFixed:
+		discount = promotion.Amount
+	}
+
+	// the discount never exceeds the targeted items
+	if discount.Cmp(eligible) > 0 {
+		discount = eligible
+	}
+
+	return discount, nil
+}
+
+func joinIDs(ids []int64) string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, strconv.FormatInt(id, 10))
+	}
+
+	return strings.Join(strs, ",")
+}
+
+func splitIDs(s string) []int64 {
+	ids := []int64{}
+	for _, str := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// splitCategories split comma separated categories into a case-insensitive set
+func splitCategories(s string) map[string]bool {
+	categories := map[string]bool{}
+	for _, category := range strings.Split(s, ",") {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if category != "" {
+			categories[category] = true
+		}
+	}
+
+	return categories
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\promotion.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPromotionService is a mock of PromotionService interface.
+type MockPromotionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServiceMockRecorder
+}
+
+// MockPromotionServiceMockRecorder is the mock recorder for MockPromotionService.
+type MockPromotionServiceMockRecorder struct {
+	mock *MockPromotionService
+}
+
+// NewMockPromotionService creates a new mock instance.
+func NewMockPromotionService(ctrl *gomock.Controller) *MockPromotionService {
+	mock := &MockPromotionService{ctrl: ctrl}
+	mock.recorder = &MockPromotionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionService) EXPECT() *MockPromotionServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotionService) Create(ctx context.Context, req model.CreatePromotionRequest) (*model.CreatePromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*model.CreatePromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockPromotionService) Delete(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromotionServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromotionService)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockPromotionService) GetByID(ctx context.Context, id int64) (*model.GetPromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.GetPromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPromotionServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPromotionService)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockPromotionService) List(ctx context.Context, limit, offset int) ([]*model.GetPromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].([]*model.GetPromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPromotionServiceMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPromotionService)(nil).List), ctx, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockPromotionService) Update(ctx context.Context, id int64, req model.UpdatePromotionRequest) (*model.UpdatePromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*model.UpdatePromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromotionServiceMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromotionService)(nil).Update), ctx, id, req)
+}
@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"family-catering/pkg/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPromotionService(t *testing.T) {
+	type args struct {
+		promotionRepo repository.PromotionRepository
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewPromotionService"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewPromotionService(tt.args.promotionRepo))
+		})
+	}
+}
+
+func Test_promotionDiscount(t *testing.T) {
+	orders := []*model.Order{
+		{MenuID: 1, Price: money.MustParse("25000"), Qty: 4},    // 100.000
+		{MenuID: 2, Price: money.MustParse("5000"), Qty: 3},     // 15.000
+		{MenuID: 3, Price: money.MustParse("12345.67"), Qty: 1}, // 12.345,67
+	}
+	menus := []*model.Menu{
+		{ID: 1, Categories: "Indonesian food,Spicy"},
+		{ID: 2, Categories: "Drink"},
+		{ID: 3, Categories: "dessert"},
+	}
+	tests := []struct {
+		name         string
+		promotion    *model.Promotion
+		wantDiscount money.Money
+		wantErr      error
+	}{
+		{
+			name:         "success promotionDiscount (percentage of every menu)",
+			promotion:    &model.Promotion{DiscountType: "percentage", Percentage: 10},
+			wantDiscount: money.MustParse("12734.57"), // 10% of 127.345,67 rounded half away from zero
+		},
+		{
+			name:         "success promotionDiscount (percentage capped by max discount)",
+			promotion:    &model.Promotion{DiscountType: "percentage", Percentage: 50, MaxDiscount: money.MustParse("20000")},
+			wantDiscount: money.MustParse("20000"),
+		},
+		{
+			name:         "success promotionDiscount (fixed targeting menu's ids)",
+			promotion:    &model.Promotion{DiscountType: "fixed", Amount: money.MustParse("10000"), MenuIDs: "2"},
+			wantDiscount: money.MustParse("10000"),
+		},
+		{
+			name:         "success promotionDiscount (fixed never exceeds the targeted items)",
+			promotion:    &model.Promotion{DiscountType: "fixed", Amount: money.MustParse("50000"), MenuIDs: "2"},
+			wantDiscount: money.MustParse("15000"),
+		},
+		{
+			name:         "success promotionDiscount (targeting categories case-insensitively)",
+			promotion:    &model.Promotion{DiscountType: "percentage", Percentage: 20, Categories: "drink, Dessert"},
+			wantDiscount: money.MustParse("5469.13"), // 20% of 27.345,67
+		},
+		{
+			name:         "success promotionDiscount (menu's ids and categories)",
+			promotion:    &model.Promotion{DiscountType: "percentage", Percentage: 10, MenuIDs: "1", Categories: "Drink"},
+			wantDiscount: money.MustParse("11500"),
+		},
+		{
+			name:      "fail promotionDiscount (below minimum spend)",
+			promotion: &model.Promotion{DiscountType: "percentage", Percentage: 10, MinSpend: money.MustParse("150000")},
+			wantErr:   errPromotionMinSpend,
+		},
+		{
+			name:      "fail promotionDiscount (no targeted menu)",
+			promotion: &model.Promotion{DiscountType: "percentage", Percentage: 10, MenuIDs: "99", Categories: "Western"},
+			wantErr:   errPromotionNotApplicable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDiscount, err := promotionDiscount(tt.promotion, orders, menus)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, gotDiscount.IsZero())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDiscount, gotDiscount)
+		})
+	}
+}
+
+func Test_promotionService_Create(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.CreatePromotionRequest
+	}
+	type mocks struct {
+		utMocks           utils.Mock
+		promotionRepoMock *repository.MockPromotionRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *promotionService
+		args         args
+		prepareMocks func(*mocks)
+		wantResp     *model.CreatePromotionResponse
+		wantErr      error
+	}{
+		{
+			name: "success Create",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), req: model.CreatePromotionRequest{
+				Code: "hemat10", DiscountType: "percentage", Percentage: 10, Amount: money.MustParse("5000"),
+				MenuIDs: []int64{1, 4}, UsageLimitPerCustomer: 1,
+			}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.promotionRepoMock.EXPECT().GetByCode(gomock.Any(), int64(1), "HEMAT10").Return(nil, errors.New("oops! no row"), nil),
+					// the amount of a percentage discount is dropped
+					m.promotionRepoMock.EXPECT().Create(gomock.Any(), int64(1), model.Promotion{
+						Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, MenuIDs: "1,4", UsageLimitPerCustomer: 1,
+					}).Return(int64(1), nil),
+					m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Promotion{
+						ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, MenuIDs: "1,4", UsageLimitPerCustomer: 1,
+						StartsAt: "2022-11-01T00:00:00Z", Active: true,
+					}, nil, nil),
+				)
+			},
+			wantResp: &model.CreatePromotionResponse{
+				ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, MenuIDs: []int64{1, 4}, UsageLimitPerCustomer: 1,
+				StartsAt: "2022-11-01T00:00:00Z", Active: true,
+			},
+		},
+		{
+			name: "fail Create (promo code registered)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), req: model.CreatePromotionRequest{Code: "HEMAT10", DiscountType: "fixed", Amount: money.MustParse("5000")}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByCode(gomock.Any(), int64(1), "HEMAT10").Return(&model.Promotion{ID: 1, Code: "HEMAT10"}, nil, nil)
+			},
+			wantErr: apperrors.ErrPromoCodeRegistered,
+		},
+		{
+			name: "fail Create (fixed discount without amount)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), req: model.CreatePromotionRequest{Code: "HEMAT10", DiscountType: "fixed", Percentage: 10}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Create (unknown discount type)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), req: model.CreatePromotionRequest{Code: "HEMAT10", DiscountType: "bogo"}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Create (ends before it starts)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), req: model.CreatePromotionRequest{
+				Code: "HEMAT10", DiscountType: "percentage", Percentage: 10,
+				StartsAt: "2022-12-01T00:00:00+07:00", EndsAt: "2022-11-30T23:00:00+07:00",
+			}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Create (invalid/no token)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), req: model.CreatePromotionRequest{Code: "HEMAT10", DiscountType: "percentage", Percentage: 10}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "invalid-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			promotionRepoMock := repository.NewMockPromotionRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{promotionRepoMock: promotionRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.promotionRepo = promotionRepoMock
+
+			gotResp, err := tt.svc.Create(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResp, gotResp)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_promotionService_Update(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+		req model.UpdatePromotionRequest
+	}
+	type mocks struct {
+		utMocks           utils.Mock
+		promotionRepoMock *repository.MockPromotionRepository
+	}
+	current := &model.Promotion{ID: 1, Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, StartsAt: "2022-11-01T00:00:00Z"}
+	tests := []struct {
+		name         string
+		svc          *promotionService
+		args         args
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success Update (end the promotion)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdatePromotionRequest{
+				Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, EndsAt: "2022-11-15T00:00:00Z",
+			}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				gomock.InOrder(
+					m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(current, nil, nil),
+					m.promotionRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.AssignableToTypeOf(model.Promotion{})).Return(int64(1), nil, nil),
+					m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(current, nil, nil),
+				)
+			},
+		},
+		{
+			name: "fail Update (ends before the current starts_at)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdatePromotionRequest{
+				Code: "HEMAT10", DiscountType: "percentage", Percentage: 10, EndsAt: "2022-10-01T00:00:00Z",
+			}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(current, nil, nil)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Update (new code registered)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdatePromotionRequest{Code: "POTONG5RB", DiscountType: "percentage", Percentage: 10}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(current, nil, nil)
+				m.promotionRepoMock.EXPECT().GetByCode(gomock.Any(), int64(1), "POTONG5RB").Return(&model.Promotion{ID: 2, Code: "POTONG5RB"}, nil, nil)
+			},
+			wantErr: apperrors.ErrPromoCodeRegistered,
+		},
+		{
+			name: "fail Update (not found)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1_000_000, req: model.UpdatePromotionRequest{Code: "HEMAT10", DiscountType: "percentage", Percentage: 10}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1_000_000)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			promotionRepoMock := repository.NewMockPromotionRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{promotionRepoMock: promotionRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.promotionRepo = promotionRepoMock
+
+			gotResp, err := tt.svc.Update(tt.args.ctx, tt.args.id, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantErr != nil, gotResp == nil)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_promotionService_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	type mocks struct {
+		utMocks           utils.Mock
+		promotionRepoMock *repository.MockPromotionRepository
+	}
+	tests := []struct {
+		name          string
+		svc           *promotionService
+		args          args
+		prepareMocks  func(*mocks)
+		wantNAffected int64
+		wantErr       error
+	}{
+		{
+			name: "success Delete",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Promotion{ID: 1}, nil, nil)
+				m.promotionRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(int64(1), nil, nil)
+			},
+			wantNAffected: 1,
+		},
+		{
+			name: "fail Delete (used by an order)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1)).Return(&model.Promotion{ID: 1}, nil, nil)
+				m.promotionRepoMock.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(int64(0), errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrPromotionInUse,
+		},
+		{
+			name: "fail Delete (not found)",
+			svc:  &promotionService{},
+			args: args{ctx: context.Background(), id: 1_000_000},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return &utils.JwtClaims{BusinessID: 1}, nil
+				})
+				m.promotionRepoMock.EXPECT().GetByID(gomock.Any(), int64(1), int64(1_000_000)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			promotionRepoMock := repository.NewMockPromotionRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{promotionRepoMock: promotionRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.promotionRepo = promotionRepoMock
+
+			gotNAffected, err := tt.svc.Delete(tt.args.ctx, tt.args.id)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantNAffected, gotNAffected)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/logger"
+	"family-catering/pkg/utils"
+	"fmt"
+	"time"
+)
+
+type ReportService interface {
+	// Sales return the sales of the orders created within the query's range grouped by the query's grouping,
+	// the report is cached (see repository.ReportRepository)
+	Sales(ctx context.Context, query model.SalesReportQuery) (*model.SalesReportResponse, error)
+	// Customers return the page of the customers' lifetime statistic sorted by the query's sort
+	Customers(ctx context.Context, query model.CustomerReportQuery) (*model.CustomerReportResponse, error)
+}
+
+type reportService struct {
+	reportRepo repository.ReportRepository
+}
+
+func NewReportService(reportRepo repository.ReportRepository) ReportService {
+	return &reportService{reportRepo: reportRepo}
+}
+
+func (svc *reportService) Sales(ctx context.Context, query model.SalesReportQuery) (*model.SalesReportResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.reportService.Sales: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.reportService.Sales: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	err = utils.ValidateRequest(&query)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.reportService.Sales: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.reportService.Sales: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+	startDay, _ := time.Parse(deliveryDateLayout, query.StartDay)
+	endDay, _ := time.Parse(deliveryDateLayout, query.EndDay)
+	if endDay.Before(startDay) {
+		err = fmt.Errorf("service.reportService.Sales: end day %s is before start day %s", query.EndDay, query.StartDay)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "end-day must not be before start-day")
+	}
+
+	// a broken cache must not break the report, it is only logged
+	report, errNoRow, err := svc.reportRepo.CachedSales(ctx, claims.BusinessID, query)
+	if err != nil {
+		logger.Error(err, "error reading cached sales report")
+	}
+	if report == nil || errNoRow != nil || err != nil {
+		report, err = svc.reportRepo.Sales(ctx, claims.BusinessID, query)
+		if err != nil {
+			err = fmt.Errorf("service.reportService.Sales: %w", err)
+			return nil, err
+		}
+
+		err = svc.reportRepo.CacheSales(ctx, claims.BusinessID, query, report)
+		if err != nil {
+			logger.Error(err, "error caching sales report")
+		}
+	}
+
+	return newSalesReportResponse(query, report), nil
+}
+
+func (svc *reportService) Customers(ctx context.Context, query model.CustomerReportQuery) (*model.CustomerReportResponse, error) {
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.reportService.Customers: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.reportService.Customers: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	err = utils.ValidateRequest(&query)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.reportService.Customers: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.reportService.Customers: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	customers, total, err := svc.reportRepo.Customers(ctx, claims.BusinessID, query)
+	if err != nil {
+		err = fmt.Errorf("service.reportService.Customers: %w", err)
+		return nil, err
+	}
+
+	res := &model.CustomerReportResponse{
+		SortBy:    query.SortBy,
+		Order:     query.Order,
+		ChurnDays: query.ChurnDays,
+		Total:     total,
+		Customers: make([]*model.CustomerStatsResponse, 0, len(customers)),
+	}
+	for _, customer := range customers {
+		res.Customers = append(res.Customers, newCustomerStatsResponse(customer))
+	}
+
+	return res, nil
+}
+
+func newSalesReportResponse(query model.SalesReportQuery, report *model.SalesReport) *model.SalesReportResponse {
+	res := &model.SalesReportResponse{
+		GroupBy:  query.GroupBy,
+		StartDay: query.StartDay,
+		EndDay:   query.EndDay,
+		Total:    &model.SalesReportRowResponse{},
+		Rows:     make([]*model.SalesReportRowResponse, 0, len(report.Rows)),
+	}
+	if report.Total != nil {
+		res.Total = newSalesReportRowResponse(report.Total)
+	}
+	for _, row := range report.Rows {
+		res.Rows = append(res.Rows, newSalesReportRowResponse(row))
+	}
+
+	return res
+}
+
+func newSalesReportRowResponse(row *model.SalesReportRow) *model.SalesReportRowResponse {
+	return &model.SalesReportRowResponse{
+		Group:             row.Group,
+		MenuID:            row.MenuID,
+		Revenue:           row.Revenue,
+		Orders:            row.Orders,
+		AverageOrderValue: row.AverageOrderValue,
+		ItemsSold:         row.ItemsSold,
+	}
+}
+
+func newCustomerStatsResponse(customer *model.CustomerStats) *model.CustomerStatsResponse {
+	favouriteMenus := customer.FavouriteMenus
+	if favouriteMenus == nil {
+		favouriteMenus = make([]*model.CustomerFavouriteMenu, 0)
+	}
+
+	return &model.CustomerStatsResponse{
+		Email:                    customer.Email,
+		LifetimeSpend:            customer.LifetimeSpend,
+		Orders:                   customer.Orders,
+		AverageOrderValue:        customer.AverageOrderValue,
+		AverageDaysBetweenOrders: customer.AverageDaysBetweenOrders,
+		FirstOrderAt:             customer.FirstOrderAt,
+		LastOrderAt:              customer.LastOrderAt,
+		Churned:                  customer.Churned,
+		FavouriteMenus:           favouriteMenus,
+	}
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\report.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Customers mocks base method.
+func (m *MockReportService) Customers(ctx context.Context, query model.CustomerReportQuery) (*model.CustomerReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Customers", ctx, query)
+	ret0, _ := ret[0].(*model.CustomerReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Customers indicates an expected call of Customers.
+func (mr *MockReportServiceMockRecorder) Customers(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Customers", reflect.TypeOf((*MockReportService)(nil).Customers), ctx, query)
+}
+
+// Sales mocks base method.
+func (m *MockReportService) Sales(ctx context.Context, query model.SalesReportQuery) (*model.SalesReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx, query)
+	ret0, _ := ret[0].(*model.SalesReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockReportServiceMockRecorder) Sales(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockReportService)(nil).Sales), ctx, query)
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"family-catering/pkg/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReportService(t *testing.T) {
+	type args struct {
+		reportRepo repository.ReportRepository
+	}
+	tests := []struct {
+		name string
+		args args
+	}{{name: "success NewReportService"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, NewReportService(tt.args.reportRepo))
+		})
+	}
+}
+
+func Test_reportService_Sales(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		query model.SalesReportQuery
+	}
+	type mocks struct {
+		utMocks        utils.Mock
+		reportRepoMock *repository.MockReportRepository
+	}
+	query := model.SalesReportQuery{GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30"}
+	report := &model.SalesReport{
+		Total: &model.SalesReportRow{Revenue: money.MustParse("75000"), Orders: 2, AverageOrderValue: money.MustParse("37500"), ItemsSold: 4},
+		Rows: []*model.SalesReportRow{
+			{Group: "sate", MenuID: 1, Revenue: money.MustParse("50000"), Orders: 2, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+			{Group: "soto", MenuID: 2, Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+		},
+	}
+	wantReport := &model.SalesReportResponse{
+		GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30",
+		Total: &model.SalesReportRowResponse{Revenue: money.MustParse("75000"), Orders: 2, AverageOrderValue: money.MustParse("37500"), ItemsSold: 4},
+		Rows: []*model.SalesReportRowResponse{
+			{Group: "sate", MenuID: 1, Revenue: money.MustParse("50000"), Orders: 2, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+			{Group: "soto", MenuID: 2, Revenue: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"), ItemsSold: 2},
+		},
+	}
+	errDB := errors.New("oops! db error")
+	patchAuth := func(m *mocks) {
+		m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+			return "access-token"
+		})
+		m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+			return &utils.JwtClaims{BusinessID: 1}, nil
+		})
+	}
+	tests := []struct {
+		name         string
+		svc          *reportService
+		args         args
+		prepareMocks func(*mocks)
+		wantReport   *model.SalesReportResponse
+		wantErr      error
+	}{
+		{
+			name: "success Sales (cached)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().CachedSales(gomock.Any(), int64(1), query).Return(report, nil, nil)
+			},
+			wantReport: wantReport,
+		},
+		{
+			name: "success Sales (not cached)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().CachedSales(gomock.Any(), int64(1), query).Return(nil, errors.New("oops! no row"), nil)
+				m.reportRepoMock.EXPECT().Sales(gomock.Any(), int64(1), query).Return(report, nil)
+				m.reportRepoMock.EXPECT().CacheSales(gomock.Any(), int64(1), query, report).Return(nil)
+			},
+			wantReport: wantReport,
+		},
+		{
+			name: "success Sales (cache error)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().CachedSales(gomock.Any(), int64(1), query).Return(nil, nil, errors.New("oops! error from redis"))
+				m.reportRepoMock.EXPECT().Sales(gomock.Any(), int64(1), query).Return(report, nil)
+				m.reportRepoMock.EXPECT().CacheSales(gomock.Any(), int64(1), query, report).Return(errors.New("oops! error from redis"))
+			},
+			wantReport: wantReport,
+		},
+		{
+			name: "success Sales (no sale)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().CachedSales(gomock.Any(), int64(1), query).Return(nil, errors.New("oops! no row"), nil)
+				m.reportRepoMock.EXPECT().Sales(gomock.Any(), int64(1), query).Return(&model.SalesReport{Rows: []*model.SalesReportRow{}}, nil)
+				m.reportRepoMock.EXPECT().CacheSales(gomock.Any(), int64(1), query, gomock.Any()).Return(nil)
+			},
+			wantReport: &model.SalesReportResponse{
+				GroupBy: "menu", StartDay: "2022-11-01", EndDay: "2022-11-30",
+				Total: &model.SalesReportRowResponse{}, Rows: []*model.SalesReportRowResponse{},
+			},
+		},
+		{
+			name: "fail Sales (unknown group)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: model.SalesReportQuery{GroupBy: "year", StartDay: "2022-11-01", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Sales (missing start day)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: model.SalesReportQuery{GroupBy: "day", EndDay: "2022-11-30"}},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+			},
+			wantErr: apperrors.ErrFieldValidationRequired,
+		},
+		{
+			name: "fail Sales (end day before start day)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: model.SalesReportQuery{GroupBy: "day", StartDay: "2022-11-30", EndDay: "2022-11-01"}},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Sales (invalid token)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail Sales (db error)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().CachedSales(gomock.Any(), int64(1), query).Return(nil, errors.New("oops! no row"), nil)
+				m.reportRepoMock.EXPECT().Sales(gomock.Any(), int64(1), query).Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			reportRepoMock := repository.NewMockReportRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{reportRepoMock: reportRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.reportRepo = reportRepoMock
+
+			gotReport, err := tt.svc.Sales(tt.args.ctx, tt.args.query)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantReport, gotReport)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_reportService_Customers(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		query model.CustomerReportQuery
+	}
+	type mocks struct {
+		utMocks        utils.Mock
+		reportRepoMock *repository.MockReportRepository
+	}
+	query := model.CustomerReportQuery{SortBy: "lifetime_spend", Order: "desc", ChurnDays: 60, Limit: 10}
+	customers := []*model.CustomerStats{
+		{
+			Email: "budi@gmail.com", LifetimeSpend: money.MustParse("90000"), Orders: 3, AverageOrderValue: money.MustParse("30000"),
+			FirstOrderAt: "2022-09-01", LastOrderAt: "2022-11-10", AverageDaysBetweenOrders: 35.5,
+			FavouriteMenus: []*model.CustomerFavouriteMenu{{MenuID: 1, MenuName: "sate", Qty: 4}},
+		},
+		{
+			Email: "ani@gmail.com", LifetimeSpend: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"),
+			FirstOrderAt: "2022-08-01", LastOrderAt: "2022-08-01", Churned: true,
+		},
+	}
+	errDB := errors.New("oops! db error")
+	patchAuth := func(m *mocks) {
+		m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+			return "access-token"
+		})
+		m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+			return &utils.JwtClaims{BusinessID: 1}, nil
+		})
+	}
+	tests := []struct {
+		name         string
+		svc          *reportService
+		args         args
+		prepareMocks func(*mocks)
+		wantReport   *model.CustomerReportResponse
+		wantErr      error
+	}{
+		{
+			name: "success Customers",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().Customers(gomock.Any(), int64(1), query).Return(customers, 12, nil)
+			},
+			wantReport: &model.CustomerReportResponse{
+				SortBy: "lifetime_spend", Order: "desc", ChurnDays: 60, Total: 12,
+				Customers: []*model.CustomerStatsResponse{
+					{
+						Email: "budi@gmail.com", LifetimeSpend: money.MustParse("90000"), Orders: 3, AverageOrderValue: money.MustParse("30000"),
+						FirstOrderAt: "2022-09-01", LastOrderAt: "2022-11-10", AverageDaysBetweenOrders: 35.5,
+						FavouriteMenus: []*model.CustomerFavouriteMenu{{MenuID: 1, MenuName: "sate", Qty: 4}},
+					},
+					{
+						Email: "ani@gmail.com", LifetimeSpend: money.MustParse("25000"), Orders: 1, AverageOrderValue: money.MustParse("25000"),
+						FirstOrderAt: "2022-08-01", LastOrderAt: "2022-08-01", Churned: true,
+						FavouriteMenus: []*model.CustomerFavouriteMenu{},
+					},
+				},
+			},
+		},
+		{
+			name: "success Customers (no customer)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().Customers(gomock.Any(), int64(1), query).Return([]*model.CustomerStats{}, 0, nil)
+			},
+			wantReport: &model.CustomerReportResponse{
+				SortBy: "lifetime_spend", Order: "desc", ChurnDays: 60, Customers: []*model.CustomerStatsResponse{},
+			},
+		},
+		{
+			name: "fail Customers (unknown sort)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: model.CustomerReportQuery{SortBy: "name", Order: "desc", ChurnDays: 60, Limit: 10}},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Customers (invalid churn days)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: model.CustomerReportQuery{SortBy: "orders", Order: "desc", ChurnDays: 0, Limit: 10}},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Customers (invalid token)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} {
+					return "access-token"
+				})
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) {
+					return nil, errors.New("oops! invalid token")
+				})
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+		{
+			name: "fail Customers (db error)",
+			svc:  &reportService{},
+			args: args{ctx: context.Background(), query: query},
+			prepareMocks: func(m *mocks) {
+				patchAuth(m)
+				m.reportRepoMock.EXPECT().Customers(gomock.Any(), int64(1), query).Return(nil, 0, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			reportRepoMock := repository.NewMockReportRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{reportRepoMock: reportRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.reportRepo = reportRepoMock
+
+			gotReport, err := tt.svc.Customers(tt.args.ctx, tt.args.query)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantReport, gotReport)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
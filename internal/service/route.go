@@ -0,0 +1,119 @@
+package service
+
+import (
+	"family-catering/internal/model"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// earthRadius is the mean radius of the earth in km
+const earthRadius = 6371.0
+
+// RouteOption is the route planning configuration
+type RouteOption struct {
+	// Kitchen is where the drivers pick up the orders of every delivery slot
+	Kitchen model.GeoPoint
+}
+
+// RouteStop is a stop of a planned route, Distance is the straight line (km) from the previous pinned stop
+// or the kitchen, 0 when the stop isn't pinned on the map
+type RouteStop struct {
+	*model.DeliveryStop
+	Distance float64
+}
+
+// RoutePlanner order the stops of a driver's day
+type RoutePlanner interface {
+	// Plan return the stops in the order they should be delivered. The stops are batched by their delivery slot
+	// (earliest first, the stops without slot come first) and every batch is a round from the kitchen visiting
+	// the nearest stop not visited yet (nearest neighbour), the stops which aren't pinned on the map end their batch.
+	// The plan only depends on the stops so a route is stable while its stops are being delivered.
+	Plan(stops []*model.DeliveryStop) []*RouteStop
+}
+
+type routePlanner struct {
+	kitchen model.GeoPoint
+}
+
+func NewRoutePlanner(opt RouteOption) (RoutePlanner, error) {
+	if opt.Kitchen.Latitude < -90 || opt.Kitchen.Latitude > 90 || opt.Kitchen.Longitude < -180 || opt.Kitchen.Longitude > 180 {
+		return nil, fmt.Errorf("service.NewRoutePlanner: invalid kitchen location %+v", opt.Kitchen)
+	}
+
+	return &routePlanner{kitchen: opt.Kitchen}, nil
+}
+
+func (planner *routePlanner) Plan(stops []*model.DeliveryStop) []*RouteStop {
+	sorted := make([]*model.DeliveryStop, len(stops))
+	copy(sorted, stops)
+	// ties are broken by order's id so the plan doesn't depend on the order of the stops
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].DeliveryStart != sorted[j].DeliveryStart {
+			return sorted[i].DeliveryStart < sorted[j].DeliveryStart
+		}
+		return sorted[i].OrderID < sorted[j].OrderID
+	})
+
+	route := make([]*RouteStop, 0, len(sorted))
+	for start := 0; start < len(sorted); {
+		end := start
+		for end < len(sorted) && sorted[end].DeliveryStart == sorted[start].DeliveryStart {
+			end++
+		}
+		route = append(route, planner.planBatch(sorted[start:end])...)
+		start = end
+	}
+
+	return route
+}
+
+// planBatch return the nearest neighbour round from the kitchen of the stops (sorted by order's id)
+func (planner *routePlanner) planBatch(stops []*model.DeliveryStop) []*RouteStop {
+	route := make([]*RouteStop, 0, len(stops))
+	pinned := make([]*model.DeliveryStop, 0, len(stops))
+	unpinned := make([]*model.DeliveryStop, 0)
+	for _, stop := range stops {
+		if stop.Latitude.Valid && stop.Longitude.Valid {
+			pinned = append(pinned, stop)
+		} else {
+			unpinned = append(unpinned, stop)
+		}
+	}
+
+	current := planner.kitchen
+	for len(pinned) > 0 {
+		nearest, nearestDistance := 0, distance(current, stopLocation(pinned[0]))
+		for i := 1; i < len(pinned); i++ {
+			d := distance(current, stopLocation(pinned[i]))
+			if d < nearestDistance {
+				nearest, nearestDistance = i, d
+			}
+		}
+
+		route = append(route, &RouteStop{DeliveryStop: pinned[nearest], Distance: nearestDistance})
+		current = stopLocation(pinned[nearest])
+		pinned = append(pinned[:nearest], pinned[nearest+1:]...)
+	}
+
+	for _, stop := range unpinned {
+		route = append(route, &RouteStop{DeliveryStop: stop})
+	}
+
+	return route
+}
+
+func stopLocation(stop *model.DeliveryStop) model.GeoPoint {
+	return model.GeoPoint{Latitude: stop.Latitude.Float64, Longitude: stop.Longitude.Float64}
+}
+
+// distance return the great-circle distance (km) between the points (haversine formula)
+func distance(from, to model.GeoPoint) float64 {
+	lat1, lat2 := from.Latitude*math.Pi/180, to.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\route.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRoutePlanner is a mock of RoutePlanner interface.
+type MockRoutePlanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoutePlannerMockRecorder
+}
+
+// MockRoutePlannerMockRecorder is the mock recorder for MockRoutePlanner.
+type MockRoutePlannerMockRecorder struct {
+	mock *MockRoutePlanner
+}
+
+// NewMockRoutePlanner creates a new mock instance.
+func NewMockRoutePlanner(ctrl *gomock.Controller) *MockRoutePlanner {
+	mock := &MockRoutePlanner{ctrl: ctrl}
+	mock.recorder = &MockRoutePlannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoutePlanner) EXPECT() *MockRoutePlannerMockRecorder {
+	return m.recorder
+}
+
+// Plan mocks base method.
+func (m *MockRoutePlanner) Plan(stops []*model.DeliveryStop) []*RouteStop {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", stops)
+	ret0, _ := ret[0].([]*RouteStop)
+	return ret0
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockRoutePlannerMockRecorder) Plan(stops interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockRoutePlanner)(nil).Plan), stops)
+}
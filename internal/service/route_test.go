@@ -0,0 +1,128 @@
+package service
+
+import (
+	"database/sql"
+	"family-catering/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStop return a delivery stop on the equator, lng 0 is the test kitchen
+func testStop(orderID int64, start string, lng float64, pinned bool) *model.DeliveryStop {
+	stop := &model.DeliveryStop{OrderID: orderID, DriverID: 1, DeliveryDate: "2023-01-02", DeliveryStart: start}
+	if start != "" {
+		stop.DeliveryEnd = "12:00"
+	}
+	if pinned {
+		stop.Latitude = sql.NullFloat64{Float64: 0, Valid: true}
+		stop.Longitude = sql.NullFloat64{Float64: lng, Valid: true}
+	}
+
+	return stop
+}
+
+func TestNewRoutePlanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     RouteOption
+		wantErr bool
+	}{
+		{name: "success NewRoutePlanner", opt: RouteOption{Kitchen: model.GeoPoint{Latitude: -6.2088, Longitude: 106.8456}}},
+		{name: "success NewRoutePlanner (zero kitchen)", opt: RouteOption{}},
+		{name: "fail NewRoutePlanner (invalid latitude)", opt: RouteOption{Kitchen: model.GeoPoint{Latitude: 106.8456, Longitude: -6.2088}}, wantErr: true},
+		{name: "fail NewRoutePlanner (invalid longitude)", opt: RouteOption{Kitchen: model.GeoPoint{Latitude: -6.2088, Longitude: 206.8456}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner, err := NewRoutePlanner(tt.opt)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantErr, planner == nil)
+		})
+	}
+}
+
+func Test_routePlanner_Plan(t *testing.T) {
+	kitchen := model.GeoPoint{}
+	point := func(lng float64) model.GeoPoint { return model.GeoPoint{Longitude: lng} }
+	tests := []struct {
+		name          string
+		stops         []*model.DeliveryStop
+		wantOrderIDs  []int64
+		wantDistances []float64
+	}{
+		{
+			name: "success Plan (nearest neighbour per slot)",
+			stops: []*model.DeliveryStop{
+				testStop(2, "10:00", 0.03, true),
+				testStop(4, "10:00", 0, false),
+				testStop(6, "10:00", -0.015, true),
+				testStop(5, "08:00", 0.05, true),
+				testStop(3, "10:00", 0.01, true),
+				testStop(1, "", 0.02, true),
+			},
+			// no slot first, then 08:00 and 10:00 from the kitchen: 3 (nearest), 2, 6 and the unpinned 4
+			wantOrderIDs: []int64{1, 5, 3, 2, 6, 4},
+			wantDistances: []float64{
+				distance(kitchen, point(0.02)),
+				distance(kitchen, point(0.05)),
+				distance(kitchen, point(0.01)),
+				distance(point(0.01), point(0.03)),
+				distance(point(0.03), point(-0.015)),
+				0,
+			},
+		},
+		{
+			name: "success Plan (ties broken by order's id)",
+			stops: []*model.DeliveryStop{
+				testStop(9, "10:00", 0.01, true),
+				testStop(8, "10:00", -0.01, true),
+				testStop(7, "10:00", 0, false),
+				testStop(10, "10:00", 0, false),
+			},
+			wantOrderIDs:  []int64{8, 9, 7, 10},
+			wantDistances: []float64{distance(kitchen, point(-0.01)), distance(point(-0.01), point(0.01)), 0, 0},
+		},
+		{
+			name:          "success Plan (no stop)",
+			stops:         []*model.DeliveryStop{},
+			wantOrderIDs:  []int64{},
+			wantDistances: []float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner, err := NewRoutePlanner(RouteOption{Kitchen: kitchen})
+			assert.NoError(t, err)
+
+			route := planner.Plan(tt.stops)
+
+			gotOrderIDs := make([]int64, 0, len(route))
+			gotDistances := make([]float64, 0, len(route))
+			for _, stop := range route {
+				gotOrderIDs = append(gotOrderIDs, stop.OrderID)
+				gotDistances = append(gotDistances, stop.Distance)
+			}
+			assert.Equal(t, tt.wantOrderIDs, gotOrderIDs)
+			assert.InDeltaSlice(t, tt.wantDistances, gotDistances, 1e-9)
+		})
+	}
+}
+
+func Test_distance(t *testing.T) {
+	tests := []struct {
+		name string
+		from model.GeoPoint
+		to   model.GeoPoint
+		want float64
+	}{
+		{name: "same point", from: model.GeoPoint{Latitude: -6.2, Longitude: 106.8}, to: model.GeoPoint{Latitude: -6.2, Longitude: 106.8}},
+		{name: "one degree on the equator", from: model.GeoPoint{}, to: model.GeoPoint{Longitude: 1}, want: 111.19},
+		{name: "Jakarta to Bandung", from: model.GeoPoint{Latitude: -6.2088, Longitude: 106.8456}, to: model.GeoPoint{Latitude: -6.9175, Longitude: 107.6191}, want: 116.24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, distance(tt.from, tt.to), 0.01)
+		})
+	}
+}
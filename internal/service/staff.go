@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/logger"
+	"family-catering/pkg/utils"
+	"fmt"
+	"strings"
+)
+
+// StaffService is the admin's management of the staff accounts (owners with a role) of its business, the routes are
+// restricted to admins so the methods only validate the token
+type StaffService interface {
+	List(ctx context.Context, limit, offset int) ([]*model.GetStaffResponse, error)
+	Create(ctx context.Context, req model.CreateStaffRequest) (*model.GetStaffResponse, error)
+	// UpdateRole change the role of the staff, it's effective from the staff's next (renewed) access token
+	UpdateRole(ctx context.Context, id int64, req model.UpdateStaffRoleRequest) (*model.GetStaffResponse, error)
+	Delete(ctx context.Context, id int64) (nAffected int64, err error)
+	// ResetTwoFactor disable the two-factor authentication of the staff who lost its authenticator app and recovery
+	// codes, the staff logs in with its password only until it enrolls again
+	ResetTwoFactor(ctx context.Context, id int64) error
+}
+
+type staffService struct {
+	ownerRepo             repository.OwnerRepository
+	twoFactorRepo         repository.TwoFactorRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	mailer                Mailer
+}
+
+func NewStaffService(ownerRepo repository.OwnerRepository, twoFactorRepo repository.TwoFactorRepository, emailVerificationRepo repository.EmailVerificationRepository, mailer Mailer) StaffService {
+	return &staffService{ownerRepo: ownerRepo, twoFactorRepo: twoFactorRepo, emailVerificationRepo: emailVerificationRepo, mailer: mailer}
+}
+
+func (svc *staffService) List(ctx context.Context, limit, offset int) ([]*model.GetStaffResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.staffService.List: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.staffService.List: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	owners, errNoRow, err := svc.ownerRepo.List(ctx, claims.BusinessID, limit, offset)
+	if errNoRow != nil && err == nil {
+		return []*model.GetStaffResponse{}, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.List: %w", err)
+		return nil, err
+	}
+
+	return newListOwnersResponse(owners), nil
+}
+
+func (svc *staffService) Create(ctx context.Context, req model.CreateStaffRequest) (*model.GetStaffResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.staffService.Create: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	err = utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	registered, errNoRow, err := svc.ownerRepo.GetByEmail(ctx, req.Email)
+	if err == nil && errNoRow == nil && registered != nil {
+		err = fmt.Errorf("service.staffService.Create: email already registered")
+		return nil, apperrors.WrapError(err, apperrors.ErrEmailRegistered, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, err
+	}
+
+	id, err := svc.ownerRepo.Create(ctx, model.Owner{
+		Name:        req.Name,
+		Email:       req.Email,
+		Password:    hashedPassword,
+		PhoneNumber: req.PhoneNumber,
+		Role:        req.Role,
+		BusinessID:  claims.BusinessID,
+	})
+	if err != nil {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, err
+	}
+
+	created, errNoRow, err := svc.ownerRepo.Get(ctx, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.staffService.Create: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		return nil, err
+	}
+
+	// the staff logs in once its email is verified as a registered owner does, it resends the email when it failed
+	err = sendEmailVerification(ctx, svc.emailVerificationRepo, svc.mailer, created, created.Email)
+	if err != nil {
+		err = fmt.Errorf("service.staffService.Create: %w", err)
+		logger.Error(err, "error sending email verification of the created staff")
+	}
+
+	return newOwnerResponse(created), nil
+}
+
+func (svc *staffService) UpdateRole(ctx context.Context, id int64, req model.UpdateStaffRoleRequest) (*model.GetStaffResponse, error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.staffService.UpdateRole: invalid auth token type want string got %T", token)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err := fmt.Errorf("service.staffService.UpdateRole: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	err = utils.ValidateRequest(&req)
+	if errors.Is(err, apperrors.ErrRequiredParam) {
+		err = fmt.Errorf("service.staffService.UpdateRole: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidationRequired, "")
+	}
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.staffService.UpdateRole: %w", err)
+		return nil, apperrors.WrapError(err, apperrors.ErrFieldValidation, "")
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, id)
+	if errNoRow == nil && err == nil && owner.BusinessID != claims.BusinessID {
+		errNoRow = fmt.Errorf("staff %d belongs to another business", id)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.staffService.UpdateRole: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.UpdateRole: %w", err)
+		return nil, err
+	}
+
+	_, errNoRow, err = svc.ownerRepo.UpdateRoleByID(ctx, claims.BusinessID, id, req.Role)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.staffService.UpdateRole: %w", errNoRow)
+		return nil, apperrors.WrapError(errNoRow, apperrors.ErrLastAdmin, "the last admin can't lose its role")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.UpdateRole: %w", err)
+		return nil, err
+	}
+
+	owner.Role = req.Role
+	return newOwnerResponse(owner), nil
+}
+
+func (svc *staffService) Delete(ctx context.Context, id int64) (nAffected int64, err error) {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.staffService.Delete: invalid auth token type want string got %T", token)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.staffService.Delete: %w", err)
+		return 0, apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, id)
+	if errNoRow == nil && err == nil && owner.BusinessID != claims.BusinessID {
+		errNoRow = fmt.Errorf("staff %d belongs to another business", id)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.staffService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.Delete: %w", err)
+		return 0, err
+	}
+
+	nAffected, errNoRow, err = svc.ownerRepo.DeleteStaffByID(ctx, claims.BusinessID, id)
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.staffService.Delete: %w", errNoRow)
+		return 0, apperrors.WrapError(errNoRow, apperrors.ErrLastAdmin, "the last admin can't be deleted")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.Delete: %w", err)
+		return 0, err
+	}
+
+	return nAffected, nil
+}
+
+func (svc *staffService) ResetTwoFactor(ctx context.Context, id int64) error {
+	// Authorization
+	token, ok := utils.ValueContext(ctx, consts.CtxKeyAuthorization).(string)
+	if !ok {
+		err := fmt.Errorf("service.staffService.ResetTwoFactor: invalid auth token type want string got %T", token)
+		return apperrors.WrapError(err, apperrors.ErrAuth, "invalid auth token type")
+	}
+	claims, err := utils.ValidateToken(token)
+	if !errors.Is(err, nil) {
+		err = fmt.Errorf("service.staffService.ResetTwoFactor: %w", err)
+		return apperrors.WrapError(err, apperrors.ErrAuth, "")
+	}
+
+	owner, errNoRow, err := svc.ownerRepo.Get(ctx, id)
+	if errNoRow == nil && err == nil && owner.BusinessID != claims.BusinessID {
+		errNoRow = fmt.Errorf("staff %d belongs to another business", id)
+	}
+	if errNoRow != nil {
+		errNoRow = fmt.Errorf("service.staffService.ResetTwoFactor: %w", errNoRow)
+		return apperrors.WrapError(errNoRow, apperrors.ErrNotFound, "")
+	}
+	if err != nil {
+		err = fmt.Errorf("service.staffService.ResetTwoFactor: %w", err)
+		return err
+	}
+
+	// resetting a staff without two-factor authentication does nothing
+	nAffected, err := svc.twoFactorRepo.Delete(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("service.staffService.ResetTwoFactor: %w", err)
+		return err
+	}
+	if nAffected > 0 {
+		logger.Warn("security event: two-factor authentication of owner %d reset by admin %d", id, claims.OwnerID)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\staff.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	model "family-catering/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStaffService is a mock of StaffService interface.
+type MockStaffService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStaffServiceMockRecorder
+}
+
+// MockStaffServiceMockRecorder is the mock recorder for MockStaffService.
+type MockStaffServiceMockRecorder struct {
+	mock *MockStaffService
+}
+
+// NewMockStaffService creates a new mock instance.
+func NewMockStaffService(ctrl *gomock.Controller) *MockStaffService {
+	mock := &MockStaffService{ctrl: ctrl}
+	mock.recorder = &MockStaffServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStaffService) EXPECT() *MockStaffServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStaffService) Create(ctx context.Context, req model.CreateStaffRequest) (*model.GetStaffResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*model.GetStaffResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStaffServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStaffService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockStaffService) Delete(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStaffServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStaffService)(nil).Delete), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockStaffService) List(ctx context.Context, limit, offset int) ([]*model.GetStaffResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].([]*model.GetStaffResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockStaffServiceMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStaffService)(nil).List), ctx, limit, offset)
+}
+
+// ResetTwoFactor mocks base method.
+func (m *MockStaffService) ResetTwoFactor(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTwoFactor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTwoFactor indicates an expected call of ResetTwoFactor.
+func (mr *MockStaffServiceMockRecorder) ResetTwoFactor(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTwoFactor", reflect.TypeOf((*MockStaffService)(nil).ResetTwoFactor), ctx, id)
+}
+
+// UpdateRole mocks base method.
+func (m *MockStaffService) UpdateRole(ctx context.Context, id int64, req model.UpdateStaffRoleRequest) (*model.GetStaffResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, id, req)
+	ret0, _ := ret[0].(*model.GetStaffResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockStaffServiceMockRecorder) UpdateRole(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockStaffService)(nil).UpdateRole), ctx, id, req)
+}
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"family-catering/config"
+	"family-catering/internal/model"
+	"family-catering/internal/repository"
+	"family-catering/pkg/apperrors"
+	"family-catering/pkg/consts"
+	"family-catering/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_staffService_Create(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req model.CreateStaffRequest
+	}
+	type mocks struct {
+		utMocks                   utils.Mock
+		ownerRepoMock             *repository.MockOwnerRepository
+		emailVerificationRepoMock *repository.MockEmailVerificationRepository
+		mailerMock                *MockMailer
+	}
+	validReq := model.CreateStaffRequest{Name: " Budi ", Email: "budi@example.com", Password: "12345pass", Role: consts.RoleCashier}
+	tests := []struct {
+		name         string
+		svc          *staffService
+		args         args
+		prepareMocks func(*mocks)
+		want         *model.GetStaffResponse
+		wantErr      error
+	}{
+		{
+			name: "success Create",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), req: validReq},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.utMocks.Patch("HashPassword", func(string) (string, error) { return "hashed", nil })
+				gomock.InOrder(
+					m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "budi@example.com").Return(nil, errors.New("oops! no row"), nil),
+					m.ownerRepoMock.EXPECT().Create(gomock.Any(), model.Owner{
+						Name: "Budi", Email: "budi@example.com", Password: "hashed", Role: consts.RoleCashier, BusinessID: 1,
+					}).Return(int64(2), nil),
+					m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{
+						Id: 2, Name: "Budi", Email: "budi@example.com", Role: consts.RoleCashier,
+					}, nil, nil),
+					// the staff logs in once it verified its email
+					m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), model.OwnerEmailVerification{
+						OwnerID: 2, Email: "budi@example.com", TokenHash: utils.HashOneTimeToken("verification-token"), ExpiredAt: time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC),
+					}).Return(nil),
+					m.mailerMock.EXPECT().SendEmailVerification([]string{"budi@example.com"}, "", "Verify Email", "Budi", gomock.Any()).Return(nil),
+				)
+			},
+			want: &model.GetStaffResponse{Id: 2, Name: "Budi", Email: "budi@example.com", Role: consts.RoleCashier},
+		},
+		{
+			name: "success Create (email verification not sent)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), req: validReq},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.utMocks.Patch("HashPassword", func(string) (string, error) { return "hashed", nil })
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "budi@example.com").Return(nil, errors.New("oops! no row"), nil)
+				m.ownerRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(2), nil)
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{Id: 2, Name: "Budi", Email: "budi@example.com", Role: consts.RoleCashier}, nil, nil)
+				// the staff is created anyway, it resends the email verification
+				m.emailVerificationRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("oops! error from postgres"))
+			},
+			want: &model.GetStaffResponse{Id: 2, Name: "Budi", Email: "budi@example.com", Role: consts.RoleCashier},
+		},
+		{
+			name: "fail Create (email already registered)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), req: validReq},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.ownerRepoMock.EXPECT().GetByEmail(gomock.Any(), "budi@example.com").Return(&model.Owner{Id: 2, BusinessID: 1}, nil, nil)
+			},
+			wantErr: apperrors.ErrEmailRegistered,
+		},
+		{
+			name: "fail Create (invalid role)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), req: validReq},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return errors.New("oops! invalid role") })
+			},
+			wantErr: apperrors.ErrFieldValidation,
+		},
+		{
+			name: "fail Create (invalid token)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), req: validReq},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return nil, errors.New("oops! invalid token") })
+			},
+			wantErr: apperrors.ErrAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+			emailVerificationRepoMock := repository.NewMockEmailVerificationRepository(ctrl)
+			mailerMock := NewMockMailer(ctrl)
+			utMocks := utils.InitMock()
+			config.InitMock()
+			config.Cfg().Web.EmailVerification.TokenTTL = 24 * time.Hour
+			utMocks.Patch("Now", func() time.Time { return time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC) })
+			utMocks.Patch("GenerateOneTimeToken", func() (string, error) { return "verification-token", nil })
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{ownerRepoMock: ownerRepoMock, emailVerificationRepoMock: emailVerificationRepoMock, mailerMock: mailerMock, utMocks: utMocks})
+			}
+
+			tt.svc.ownerRepo = ownerRepoMock
+			tt.svc.emailVerificationRepo = emailVerificationRepoMock
+			tt.svc.mailer = mailerMock
+
+			got, err := tt.svc.Create(tt.args.ctx, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+
+			utMocks.UnpatchAll()
+			config.DestroyMock()
+		})
+	}
+}
+
+func Test_staffService_UpdateRole(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+		req model.UpdateStaffRoleRequest
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		ownerRepoMock *repository.MockOwnerRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *staffService
+		args         args
+		prepareMocks func(*mocks)
+		want         *model.GetStaffResponse
+		wantErr      error
+	}{
+		{
+			name: "success UpdateRole",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 2, req: model.UpdateStaffRoleRequest{Role: consts.RoleKitchen}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				gomock.InOrder(
+					m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{Id: 2, Name: "Budi", Role: consts.RoleCashier, BusinessID: 1}, nil, nil),
+					m.ownerRepoMock.EXPECT().UpdateRoleByID(gomock.Any(), int64(1), int64(2), consts.RoleKitchen).Return(int64(1), nil, nil),
+				)
+			},
+			want: &model.GetStaffResponse{Id: 2, Name: "Budi", Role: consts.RoleKitchen},
+		},
+		{
+			name: "fail UpdateRole (staff not found)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 2, req: model.UpdateStaffRoleRequest{Role: consts.RoleKitchen}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(nil, errors.New("oops! no row"), nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail UpdateRole (last admin)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 1, req: model.UpdateStaffRoleRequest{Role: consts.RoleViewer}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				gomock.InOrder(
+					m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Role: consts.RoleAdmin, BusinessID: 1}, nil, nil),
+					m.ownerRepoMock.EXPECT().UpdateRoleByID(gomock.Any(), int64(1), int64(1), consts.RoleViewer).Return(int64(0), errors.New("oops! no row"), nil),
+				)
+			},
+			wantErr: apperrors.ErrLastAdmin,
+		},
+		{
+			name: "fail UpdateRole (staff of another business)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 3, req: model.UpdateStaffRoleRequest{Role: consts.RoleAdmin}},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.utMocks.Patch("ValidateRequest", func(interface{}) error { return nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(3)).Return(&model.Owner{Id: 3, Role: consts.RoleCashier, BusinessID: 2}, nil, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{ownerRepoMock: ownerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.ownerRepo = ownerRepoMock
+
+			got, err := tt.svc.UpdateRole(tt.args.ctx, tt.args.id, tt.args.req)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_staffService_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	type mocks struct {
+		utMocks       utils.Mock
+		ownerRepoMock *repository.MockOwnerRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *staffService
+		args         args
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success Delete",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 2},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				gomock.InOrder(
+					m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{Id: 2, BusinessID: 1}, nil, nil),
+					m.ownerRepoMock.EXPECT().DeleteStaffByID(gomock.Any(), int64(1), int64(2)).Return(int64(1), nil, nil),
+				)
+			},
+		},
+		{
+			name: "fail Delete (last admin)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 1},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				gomock.InOrder(
+					m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.Owner{Id: 1, Role: consts.RoleAdmin, BusinessID: 1}, nil, nil),
+					m.ownerRepoMock.EXPECT().DeleteStaffByID(gomock.Any(), int64(1), int64(1)).Return(int64(0), errors.New("oops! no row"), nil),
+				)
+			},
+			wantErr: apperrors.ErrLastAdmin,
+		},
+		{
+			name: "fail Delete (staff of another business)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 3},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{BusinessID: 1}, nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(3)).Return(&model.Owner{Id: 3, BusinessID: 2}, nil, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{ownerRepoMock: ownerRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.ownerRepo = ownerRepoMock
+
+			_, err := tt.svc.Delete(tt.args.ctx, tt.args.id)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
+
+func Test_staffService_ResetTwoFactor(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	type mocks struct {
+		utMocks           utils.Mock
+		ownerRepoMock     *repository.MockOwnerRepository
+		twoFactorRepoMock *repository.MockTwoFactorRepository
+	}
+	tests := []struct {
+		name         string
+		svc          *staffService
+		args         args
+		prepareMocks func(*mocks)
+		wantErr      error
+	}{
+		{
+			name: "success ResetTwoFactor",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 2},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1, BusinessID: 1}, nil })
+				gomock.InOrder(
+					m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{Id: 2, BusinessID: 1}, nil, nil),
+					m.twoFactorRepoMock.EXPECT().Delete(gomock.Any(), int64(2)).Return(int64(1), nil),
+				)
+			},
+		},
+		{
+			name: "success ResetTwoFactor (two-factor authentication not enabled)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 2},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1, BusinessID: 1}, nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{Id: 2, BusinessID: 1}, nil, nil)
+				m.twoFactorRepoMock.EXPECT().Delete(gomock.Any(), int64(2)).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "fail ResetTwoFactor (staff of another business)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 3},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1, BusinessID: 1}, nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(3)).Return(&model.Owner{Id: 3, BusinessID: 2}, nil, nil)
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "fail ResetTwoFactor (error repository)",
+			svc:  &staffService{},
+			args: args{ctx: context.Background(), id: 2},
+			prepareMocks: func(m *mocks) {
+				m.utMocks.Patch("ValueContext", func(context.Context, string) interface{} { return "access-token" })
+				m.utMocks.Patch("ValidateToken", func(string) (*utils.JwtClaims, error) { return &utils.JwtClaims{OwnerID: 1, BusinessID: 1}, nil })
+				m.ownerRepoMock.EXPECT().Get(gomock.Any(), int64(2)).Return(&model.Owner{Id: 2, BusinessID: 1}, nil, nil)
+				m.twoFactorRepoMock.EXPECT().Delete(gomock.Any(), int64(2)).Return(int64(0), errors.New("oops! error from postgres"))
+			},
+			wantErr: errors.New("oops! error from postgres"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ownerRepoMock := repository.NewMockOwnerRepository(ctrl)
+			twoFactorRepoMock := repository.NewMockTwoFactorRepository(ctrl)
+			utMocks := utils.InitMock()
+
+			if tt.prepareMocks != nil {
+				tt.prepareMocks(&mocks{ownerRepoMock: ownerRepoMock, twoFactorRepoMock: twoFactorRepoMock, utMocks: utMocks})
+			}
+
+			tt.svc.ownerRepo = ownerRepoMock
+			tt.svc.twoFactorRepo = twoFactorRepoMock
+
+			err := tt.svc.ResetTwoFactor(tt.args.ctx, tt.args.id)
+
+			assert.Equal(t, tt.wantErr != nil, err != nil, err)
+			if tt.wantErr != nil && errors.Is(tt.wantErr, apperrors.ErrNotFound) {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			utMocks.UnpatchAll()
+		})
+	}
+}
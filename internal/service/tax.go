@@ -0,0 +1,156 @@
+package service
+
+import (
+	"family-catering/internal/model"
+	"family-catering/pkg/money"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// TaxOption is the tax and service charge configuration, every rate is a percentage with at most 2 fraction digits (e.g. 11 or 2.5)
+type TaxOption struct {
+	// Inclusive means the menu's prices already include the tax, the tax is only broken out and the grand total doesn't grow
+	Inclusive bool
+	// Rate is the tax rate of menus without a category listed in CategoryRates
+	Rate float64
+	// CategoryRates is the tax rate by menu's category (case-insensitive), the first category of a menu listed here is used
+	CategoryRates     map[string]float64
+	ServiceChargeRate float64
+}
+
+// TaxCalculator compute the charges (service charge and tax) of an order
+type TaxCalculator interface {
+	// Calculate return the charges of the order's items after the order's discount, menus are used to find
+	// the tax rate of each item by its categories (see NeedsCategories)
+	Calculate(orders []*model.Order, menus []*model.Menu, discount money.Money) *model.OrderCharge
+	// NeedsCategories report whether the menus passed to Calculate affect the result
+	NeedsCategories() bool
+}
+
+type taxCalculator struct {
+	inclusive         bool
+	rate              int64 // basis points
+	categoryRates     map[string]int64
+	serviceChargeRate int64
+}
+
+func NewTaxCalculator(opt TaxOption) (TaxCalculator, error) {
+	rate, err := percentToBasisPoints(opt.Rate)
+	if err != nil {
+		return nil, fmt.Errorf("service.NewTaxCalculator: rate: %w", err)
+	}
+	serviceChargeRate, err := percentToBasisPoints(opt.ServiceChargeRate)
+	if err != nil {
+		return nil, fmt.Errorf("service.NewTaxCalculator: service charge rate: %w", err)
+	}
+
+	categoryRates := make(map[string]int64, len(opt.CategoryRates))
+	for category, percent := range opt.CategoryRates {
+		categoryRate, err := percentToBasisPoints(percent)
+		if err != nil {
+			return nil, fmt.Errorf("service.NewTaxCalculator: rate of category %q: %w", category, err)
+		}
+		categoryRates[strings.ToLower(strings.TrimSpace(category))] = categoryRate
+	}
+
+	return &taxCalculator{
+		inclusive:         opt.Inclusive,
+		rate:              rate,
+		categoryRates:     categoryRates,
+		serviceChargeRate: serviceChargeRate,
+	}, nil
+}
+
+func (calc *taxCalculator) NeedsCategories() bool {
+	return len(calc.categoryRates) > 0
+}
+
+// Calculate compute the charges exactly and round each of them once (half away from zero):
+//   - the service charge is a percentage of the items after discount
+//   - the discount and the service charge are spread across the items by their amount, so each part is taxed at its item's rate
+//   - with inclusive pricing the tax is the part of the items and service charge which is tax
+func (calc *taxCalculator) Calculate(orders []*model.Order, menus []*model.Menu, discount money.Money) *model.OrderCharge {
+	categories := make(map[int64]string, len(menus))
+	for _, menu := range menus {
+		categories[menu.ID] = menu.Categories
+	}
+
+	subTotal := money.FromMinor(0)
+	taxed := new(big.Rat) // sum of item's amount times its tax fraction
+	for _, order := range orders {
+		amount := order.Price.Mul(int64(order.Qty))
+		subTotal = subTotal.Add(amount)
+
+		rate := calc.itemRate(categories[order.MenuID])
+		fraction := big.NewRat(rate, 10_000)
+		if calc.inclusive {
+			fraction = big.NewRat(rate, 10_000+rate)
+		}
+		taxed.Add(taxed, new(big.Rat).Mul(new(big.Rat).SetInt64(amount.Amount), fraction))
+	}
+
+	net := subTotal.Sub(discount)
+	if net.IsNegative() {
+		net = money.FromMinor(0)
+	}
+	serviceCharge := net.Percent(calc.serviceChargeRate)
+
+	tax := money.FromMinor(0)
+	if subTotal.IsPositive() {
+		// tax = (net + service charge) * taxed / subTotal
+		base := net.Add(serviceCharge)
+		r := new(big.Rat).Mul(new(big.Rat).SetInt64(base.Amount), taxed)
+		r.Quo(r, new(big.Rat).SetInt64(subTotal.Amount))
+		tax = money.FromMinor(roundRat(r))
+	}
+
+	grandTotal := net.Add(serviceCharge)
+	if !calc.inclusive {
+		grandTotal = grandTotal.Add(tax)
+	}
+
+	return &model.OrderCharge{
+		SubTotal:          subTotal,
+		Discount:          subTotal.Sub(net),
+		ServiceCharge:     serviceCharge,
+		ServiceChargeRate: int(calc.serviceChargeRate),
+		Tax:               tax,
+		TaxInclusive:      calc.inclusive,
+		GrandTotal:        grandTotal,
+	}
+}
+
+// itemRate return the rate of the first category (comma separated) listed in categoryRates or the default rate
+func (calc *taxCalculator) itemRate(categories string) int64 {
+	for _, category := range strings.Split(categories, ",") {
+		if rate, ok := calc.categoryRates[strings.ToLower(strings.TrimSpace(category))]; ok {
+			return rate
+		}
+	}
+
+	return calc.rate
+}
+
+func percentToBasisPoints(percent float64) (int64, error) {
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("%v%% is out of range 0 - 100", percent)
+	}
+	basisPoints := math.Round(percent * 100)
+	if math.Abs(percent*100-basisPoints) > 1e-6 {
+		return 0, fmt.Errorf("%v%% has more than 2 fraction digits", percent)
+	}
+
+	return int64(basisPoints), nil
+}
+
+// roundRat round a non negative rational to the nearest integer, half away from zero
+func roundRat(r *big.Rat) int64 {
+	// floor((2 * num + denom) / (2 * denom))
+	num := new(big.Int).Mul(r.Num(), big.NewInt(2))
+	num.Add(num, r.Denom())
+	denom := new(big.Int).Mul(r.Denom(), big.NewInt(2))
+
+	return num.Quo(num, denom).Int64()
+}
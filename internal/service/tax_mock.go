@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: C:\Users\ff\Documents\coding\golang\family-catering\internal\service\tax.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	model "family-catering/internal/model"
+	money "family-catering/pkg/money"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaxCalculator is a mock of TaxCalculator interface.
+type MockTaxCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxCalculatorMockRecorder
+}
+
+// MockTaxCalculatorMockRecorder is the mock recorder for MockTaxCalculator.
+type MockTaxCalculatorMockRecorder struct {
+	mock *MockTaxCalculator
+}
+
+// NewMockTaxCalculator creates a new mock instance.
+func NewMockTaxCalculator(ctrl *gomock.Controller) *MockTaxCalculator {
+	mock := &MockTaxCalculator{ctrl: ctrl}
+	mock.recorder = &MockTaxCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxCalculator) EXPECT() *MockTaxCalculatorMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockTaxCalculator) Calculate(orders []*model.Order, menus []*model.Menu, discount money.Money) *model.OrderCharge {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", orders, menus, discount)
+	ret0, _ := ret[0].(*model.OrderCharge)
+	return ret0
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockTaxCalculatorMockRecorder) Calculate(orders, menus, discount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockTaxCalculator)(nil).Calculate), orders, menus, discount)
+}
+
+// NeedsCategories mocks base method.
+func (m *MockTaxCalculator) NeedsCategories() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsCategories")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsCategories indicates an expected call of NeedsCategories.
+func (mr *MockTaxCalculatorMockRecorder) NeedsCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsCategories", reflect.TypeOf((*MockTaxCalculator)(nil).NeedsCategories))
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"family-catering/internal/model"
+	"family-catering/pkg/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaxCalculator(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     TaxOption
+		wantErr bool
+	}{
+		{
+			name: "success NewTaxCalculator",
+			opt:  TaxOption{Rate: 11, CategoryRates: map[string]float64{"Raw ingredients": 0, "Drink": 2.5}, ServiceChargeRate: 5},
+		},
+		{
+			name:    "fail NewTaxCalculator (rate out of range)",
+			opt:     TaxOption{Rate: 101},
+			wantErr: true,
+		},
+		{
+			name:    "fail NewTaxCalculator (negative service charge rate)",
+			opt:     TaxOption{Rate: 11, ServiceChargeRate: -5},
+			wantErr: true,
+		},
+		{
+			name:    "fail NewTaxCalculator (category rate with more than 2 fraction digits)",
+			opt:     TaxOption{Rate: 11, CategoryRates: map[string]float64{"Drink": 2.555}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCalculator, err := NewTaxCalculator(tt.opt)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantErr, gotCalculator == nil)
+		})
+	}
+}
+
+func Test_taxCalculator_Calculate(t *testing.T) {
+	orders := []*model.Order{
+		{MenuID: 1, Price: money.MustParse("25000"), Qty: 2}, // 50.000
+		{MenuID: 2, Price: money.MustParse("5000"), Qty: 3},  // 15.000
+	}
+	menus := []*model.Menu{
+		{ID: 1, Categories: "Indonesian food"},
+		{ID: 2, Categories: "Vegetables, raw ingredients"},
+	}
+	tests := []struct {
+		name       string
+		opt        TaxOption
+		orders     []*model.Order
+		discount   money.Money
+		wantCharge *model.OrderCharge
+	}{
+		{
+			name:   "success Calculate (exclusive, service charge is taxed)",
+			opt:    TaxOption{Rate: 11, ServiceChargeRate: 5},
+			orders: orders,
+			wantCharge: &model.OrderCharge{
+				SubTotal: money.MustParse("65000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("3250"), ServiceChargeRate: 500,
+				Tax: money.MustParse("7507.50"), GrandTotal: money.MustParse("75757.50"),
+			},
+		},
+		{
+			name:   "success Calculate (rate by category)",
+			opt:    TaxOption{Rate: 11, CategoryRates: map[string]float64{"Raw ingredients": 0}},
+			orders: orders,
+			wantCharge: &model.OrderCharge{
+				SubTotal: money.MustParse("65000"), Discount: money.FromMinor(0), ServiceCharge: money.FromMinor(0),
+				Tax: money.MustParse("5500"), GrandTotal: money.MustParse("70500"),
+			},
+		},
+		{
+			name:     "success Calculate (discount is spread across the items)",
+			opt:      TaxOption{Rate: 11, CategoryRates: map[string]float64{"Raw ingredients": 0}},
+			orders:   orders,
+			discount: money.MustParse("6500"),
+			wantCharge: &model.OrderCharge{
+				SubTotal: money.MustParse("65000"), Discount: money.MustParse("6500"), ServiceCharge: money.FromMinor(0),
+				Tax: money.MustParse("4950"), GrandTotal: money.MustParse("63450"),
+			},
+		},
+		{
+			name:   "success Calculate (inclusive)",
+			opt:    TaxOption{Inclusive: true, Rate: 11, ServiceChargeRate: 5},
+			orders: []*model.Order{{MenuID: 1, Price: money.MustParse("111000"), Qty: 1}},
+			wantCharge: &model.OrderCharge{
+				SubTotal: money.MustParse("111000"), Discount: money.FromMinor(0), ServiceCharge: money.MustParse("5550"), ServiceChargeRate: 500,
+				Tax: money.MustParse("11550"), TaxInclusive: true, GrandTotal: money.MustParse("116550"),
+			},
+		},
+		{
+			name:   "success Calculate (rounded once to the nearest cent)",
+			opt:    TaxOption{Rate: 11},
+			orders: []*model.Order{{MenuID: 1, Price: money.MustParse("0.05"), Qty: 3}, {MenuID: 2, Price: money.MustParse("0.05"), Qty: 1}},
+			wantCharge: &model.OrderCharge{
+				SubTotal: money.MustParse("0.20"), Discount: money.FromMinor(0), ServiceCharge: money.FromMinor(0),
+				Tax: money.MustParse("0.02"), GrandTotal: money.MustParse("0.22"),
+			},
+		},
+		{
+			name:     "success Calculate (discount greater than the items)",
+			opt:      TaxOption{Rate: 11, ServiceChargeRate: 5},
+			orders:   []*model.Order{{MenuID: 1, Price: money.MustParse("5000"), Qty: 1}},
+			discount: money.MustParse("10000"),
+			wantCharge: &model.OrderCharge{
+				SubTotal: money.MustParse("5000"), Discount: money.MustParse("5000"), ServiceCharge: money.FromMinor(0), ServiceChargeRate: 500,
+				Tax: money.FromMinor(0), GrandTotal: money.FromMinor(0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator, err := NewTaxCalculator(tt.opt)
+			assert.NoError(t, err)
+
+			gotCharge := calculator.Calculate(tt.orders, menus, tt.discount)
+			assert.Equal(t, tt.wantCharge, gotCharge)
+		})
+	}
+}
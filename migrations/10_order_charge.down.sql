@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_charge;
+DROP TRIGGER IF EXISTS tg_order_charge_set_updated_at ON order_charge RESTRICT;
+DROP FUNCTION IF EXISTS tgf_order_charge_set_updated_at();
@@ -0,0 +1,37 @@
+CREATE OR REPLACE FUNCTION tgf_order_charge_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+-- the invoice's breakdown of an order (one row per order_id), repriced while the order is editable
+CREATE TABLE IF NOT EXISTS order_charge(
+    order_id BIGINT NOT NULL PRIMARY KEY,
+    sub_total BIGINT NOT NULL CHECK (sub_total >= 0),
+    discount BIGINT NOT NULL DEFAULT 0 CHECK (discount >= 0),
+    service_charge BIGINT NOT NULL DEFAULT 0 CHECK (service_charge >= 0),
+    service_charge_rate INT4 NOT NULL DEFAULT 0 CHECK (service_charge_rate >= 0), -- basis points
+    tax BIGINT NOT NULL DEFAULT 0 CHECK (tax >= 0),
+    tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE, -- the tax is already included in sub_total
+    grand_total BIGINT NOT NULL CHECK (grand_total >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tg_order_charge_set_updated_at
+BEFORE UPDATE ON order_charge
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_order_charge_set_updated_at();
+
+-- orders created before this table exists have neither service charge nor tax
+INSERT INTO order_charge
+    (order_id, sub_total, discount, grand_total)
+SELECT
+    o.order_id, SUM(o.price * o.qty), COALESCE(MIN(d.amount), 0), SUM(o.price * o.qty) - COALESCE(MIN(d.amount), 0)
+FROM
+    "order" o
+LEFT JOIN
+    order_discount d ON d.order_id = o.order_id
+GROUP BY o.order_id;
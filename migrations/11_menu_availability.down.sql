@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_order_menu_created_at;
+ALTER TABLE menu
+    DROP COLUMN IF EXISTS stock,
+    DROP COLUMN IF EXISTS daily_capacity;
@@ -0,0 +1,7 @@
+-- NULL stock is not tracked and NULL daily_capacity is unlimited
+ALTER TABLE menu
+    ADD COLUMN IF NOT EXISTS stock INT4 NULL CHECK (stock >= 0),
+    ADD COLUMN IF NOT EXISTS daily_capacity INT4 NULL CHECK (daily_capacity >= 0);
+
+-- the portions ordered of a menu on a day are counted from the orders which are not cancelled
+CREATE INDEX IF NOT EXISTS idx_order_menu_created_at ON "order"(menu_id, created_at);
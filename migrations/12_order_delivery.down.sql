@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_order_delivery_date;
+DROP INDEX IF EXISTS idx_order_menu_delivery_date;
+CREATE INDEX IF NOT EXISTS idx_order_menu_created_at ON "order"(menu_id, created_at);
+ALTER TABLE "order"
+    DROP COLUMN IF EXISTS delivery_date,
+    DROP COLUMN IF EXISTS delivery_start,
+    DROP COLUMN IF EXISTS delivery_end;
@@ -0,0 +1,15 @@
+-- every item (row) of an order has the order's delivery, delivery_start and delivery_end are NULL
+-- when the order could be delivered at any time of the day (no delivery slots are configured)
+ALTER TABLE "order"
+    ADD COLUMN IF NOT EXISTS delivery_date DATE NULL,
+    ADD COLUMN IF NOT EXISTS delivery_start TIME NULL,
+    ADD COLUMN IF NOT EXISTS delivery_end TIME NULL CHECK (delivery_end > delivery_start);
+
+-- orders created before this column exists are delivered on the day they were made
+UPDATE "order" SET delivery_date = created_at::DATE WHERE delivery_date IS NULL;
+ALTER TABLE "order" ALTER COLUMN delivery_date SET NOT NULL;
+
+-- the daily capacity of a menu is counted by the delivery date of the orders instead of their creation
+DROP INDEX IF EXISTS idx_order_menu_created_at;
+CREATE INDEX IF NOT EXISTS idx_order_menu_delivery_date ON "order"(menu_id, delivery_date);
+CREATE INDEX IF NOT EXISTS idx_order_delivery_date ON "order"(delivery_date, delivery_start);
@@ -0,0 +1,2 @@
+-- notes of an order for the kitchen and the courier (e.g. allergies), every item (row) of an order has the order's notes
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS notes VARCHAR(500) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_order_created_at_status;
@@ -0,0 +1,2 @@
+-- sales reports scan the paid rows by their creation date
+CREATE INDEX IF NOT EXISTS idx_order_created_at_status ON "order"(created_at, status);
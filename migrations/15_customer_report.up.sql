@@ -0,0 +1,2 @@
+-- customer reports aggregate the paid rows of each customer's email
+CREATE INDEX IF NOT EXISTS idx_order_customer_email_status ON "order"(customer_email, status);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_order_customer_id;
+ALTER TABLE "order" DROP COLUMN IF EXISTS customer_id;
+DROP TABLE IF EXISTS customer_address;
+DROP TABLE IF EXISTS customer;
+DROP FUNCTION IF EXISTS tgf_customer_set_updated_at();
@@ -0,0 +1,55 @@
+CREATE OR REPLACE FUNCTION tgf_customer_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+CREATE TABLE IF NOT EXISTS customer(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL UNIQUE, -- stored lower case
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    dietary_notes VARCHAR(500) NOT NULL DEFAULT '', -- e.g. allergies
+    marketing_consent BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tg_customer_set_updated_at
+BEFORE UPDATE ON customer
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_customer_set_updated_at();
+
+CREATE TABLE IF NOT EXISTS customer_address(
+    id BIGSERIAL PRIMARY KEY,
+    customer_id BIGINT NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+    label VARCHAR(64) NOT NULL DEFAULT '', -- e.g. home, office
+    address VARCHAR(500) NOT NULL,
+    postal_code VARCHAR(16) NOT NULL DEFAULT '',
+    notes VARCHAR(255) NOT NULL DEFAULT '', -- e.g. landmark, gate code
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_customer_address_customer_id ON customer_address(customer_id);
+-- a customer has at most one default address
+CREATE UNIQUE INDEX IF NOT EXISTS uq_customer_address_default ON customer_address(customer_id) WHERE is_default;
+
+CREATE TRIGGER tg_customer_address_set_updated_at
+BEFORE UPDATE ON customer_address
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_customer_set_updated_at();
+
+-- every email ordered with becomes a customer, customer_email of the order is kept as the email the order was placed with
+INSERT INTO customer (email, created_at)
+SELECT LOWER(customer_email), MIN(created_at) FROM "order" GROUP BY LOWER(customer_email)
+ON CONFLICT (email) DO NOTHING;
+
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS customer_id BIGINT NULL REFERENCES customer(id);
+UPDATE "order" o SET customer_id = c.id FROM customer c WHERE c.email = LOWER(o.customer_email);
+ALTER TABLE "order" ALTER COLUMN customer_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_order_customer_id ON "order"(customer_id);
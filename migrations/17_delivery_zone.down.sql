@@ -0,0 +1,10 @@
+ALTER TABLE order_charge DROP COLUMN IF EXISTS delivery_fee;
+ALTER TABLE "order" DROP COLUMN IF EXISTS delivery_zone_id;
+ALTER TABLE "order" DROP COLUMN IF EXISTS delivery_longitude;
+ALTER TABLE "order" DROP COLUMN IF EXISTS delivery_latitude;
+ALTER TABLE "order" DROP COLUMN IF EXISTS delivery_postal_code;
+ALTER TABLE "order" DROP COLUMN IF EXISTS delivery_address;
+ALTER TABLE customer_address DROP COLUMN IF EXISTS longitude;
+ALTER TABLE customer_address DROP COLUMN IF EXISTS latitude;
+DROP TABLE IF EXISTS delivery_zone;
+DROP FUNCTION IF EXISTS tgf_delivery_zone_set_updated_at();
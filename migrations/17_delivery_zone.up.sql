@@ -0,0 +1,38 @@
+CREATE OR REPLACE FUNCTION tgf_delivery_zone_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+-- an area delivered to, matched by the postal code of the address first then by its coordinates
+CREATE TABLE IF NOT EXISTS delivery_zone(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    postal_codes TEXT NOT NULL DEFAULT '', -- comma separated, stored upper case without spaces
+    polygon JSONB NULL, -- [[latitude, longitude], ...], the ring is closed implicitly
+    fee BIGINT NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    min_order BIGINT NOT NULL DEFAULT 0 CHECK (min_order >= 0), -- compared with the order's total after discount
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (postal_codes <> '' OR polygon IS NOT NULL)
+);
+
+CREATE TRIGGER tg_delivery_zone_set_updated_at
+BEFORE UPDATE ON delivery_zone
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_delivery_zone_set_updated_at();
+
+ALTER TABLE customer_address ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION NULL;
+ALTER TABLE customer_address ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION NULL;
+
+-- the destination is order-level like the delivery date, it's repeated on every item of the order
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS delivery_address VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS delivery_postal_code VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS delivery_latitude DOUBLE PRECISION NULL;
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS delivery_longitude DOUBLE PRECISION NULL;
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS delivery_zone_id BIGINT NULL REFERENCES delivery_zone(id) ON DELETE SET NULL;
+
+ALTER TABLE order_charge ADD COLUMN IF NOT EXISTS delivery_fee BIGINT NOT NULL DEFAULT 0 CHECK (delivery_fee >= 0);
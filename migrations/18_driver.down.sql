@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS delivery_assignment;
+DROP TABLE IF EXISTS driver;
+DROP FUNCTION IF EXISTS tgf_driver_set_updated_at();
@@ -0,0 +1,39 @@
+CREATE OR REPLACE FUNCTION tgf_driver_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+CREATE TABLE IF NOT EXISTS driver(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE, -- inactive drivers can't be assigned new orders
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tg_driver_set_updated_at
+BEFORE UPDATE ON driver
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_driver_set_updated_at();
+
+-- an order is delivered by a single driver on its delivery date, the stops of a driver's day are ordered
+-- when its route is requested (see service.DriverService.Route)
+CREATE TABLE IF NOT EXISTS delivery_assignment(
+    order_id BIGINT PRIMARY KEY,
+    driver_id BIGINT NOT NULL REFERENCES driver(id) ON DELETE CASCADE,
+    delivery_date DATE NOT NULL,
+    delivered_at TIMESTAMP NULL, -- NULL while the order is on its way
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_assignment_driver_id_delivery_date ON delivery_assignment(driver_id, delivery_date);
+
+CREATE TRIGGER tg_delivery_assignment_set_updated_at
+BEFORE UPDATE ON delivery_assignment
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_driver_set_updated_at();
@@ -0,0 +1 @@
+ALTER TABLE "owner" DROP COLUMN IF EXISTS role;
@@ -0,0 +1,4 @@
+-- role of an owner (staff account), the owners registered before roles existed run the business so they are admins
+ALTER TABLE "owner" ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'admin'
+    CHECK (role IN ('admin', 'cashier', 'kitchen', 'driver', 'viewer'));
+ALTER TABLE "owner" ALTER COLUMN role SET DEFAULT 'viewer';
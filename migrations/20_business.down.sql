@@ -0,0 +1,15 @@
+ALTER TABLE customer DROP CONSTRAINT IF EXISTS customer_business_id_email_key;
+ALTER TABLE customer ADD CONSTRAINT customer_email_key UNIQUE (email);
+ALTER TABLE promotion DROP CONSTRAINT IF EXISTS promotion_business_id_code_key;
+ALTER TABLE promotion ADD CONSTRAINT promotion_code_key UNIQUE (code);
+
+ALTER TABLE driver DROP COLUMN IF EXISTS business_id;
+ALTER TABLE delivery_zone DROP COLUMN IF EXISTS business_id;
+ALTER TABLE customer DROP COLUMN IF EXISTS business_id;
+ALTER TABLE promotion DROP COLUMN IF EXISTS business_id;
+ALTER TABLE "order" DROP COLUMN IF EXISTS business_id;
+ALTER TABLE menu DROP COLUMN IF EXISTS business_id;
+ALTER TABLE "owner" DROP COLUMN IF EXISTS business_id;
+
+DROP TABLE IF EXISTS business;
+DROP FUNCTION IF EXISTS tgf_business_set_updated_at();
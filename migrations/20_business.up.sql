@@ -0,0 +1,55 @@
+CREATE OR REPLACE FUNCTION tgf_business_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+-- a business (tenant) is a catering kitchen hosted on the deployment, its owners (staff) only see its own data
+CREATE TABLE IF NOT EXISTS business(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tg_business_set_updated_at
+BEFORE UPDATE ON business
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_business_set_updated_at();
+
+-- the data recorded before businesses existed belongs to the first business
+INSERT INTO business (id, name) VALUES (1, 'Family Catering') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('business', 'id'), (SELECT MAX(id) FROM business));
+
+ALTER TABLE "owner" ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+ALTER TABLE menu ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+ALTER TABLE promotion ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+ALTER TABLE customer ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+ALTER TABLE delivery_zone ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+ALTER TABLE driver ADD COLUMN IF NOT EXISTS business_id BIGINT NOT NULL DEFAULT 1 REFERENCES business(id) ON DELETE CASCADE;
+
+-- new rows always name their business
+ALTER TABLE "owner" ALTER COLUMN business_id DROP DEFAULT;
+ALTER TABLE menu ALTER COLUMN business_id DROP DEFAULT;
+ALTER TABLE "order" ALTER COLUMN business_id DROP DEFAULT;
+ALTER TABLE promotion ALTER COLUMN business_id DROP DEFAULT;
+ALTER TABLE customer ALTER COLUMN business_id DROP DEFAULT;
+ALTER TABLE delivery_zone ALTER COLUMN business_id DROP DEFAULT;
+ALTER TABLE driver ALTER COLUMN business_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_owner_business_id ON "owner"(business_id);
+CREATE INDEX IF NOT EXISTS idx_menu_business_id ON menu(business_id);
+CREATE INDEX IF NOT EXISTS idx_order_business_id ON "order"(business_id);
+CREATE INDEX IF NOT EXISTS idx_promotion_business_id ON promotion(business_id);
+CREATE INDEX IF NOT EXISTS idx_customer_business_id ON customer(business_id);
+CREATE INDEX IF NOT EXISTS idx_delivery_zone_business_id ON delivery_zone(business_id);
+CREATE INDEX IF NOT EXISTS idx_driver_business_id ON driver(business_id);
+
+-- promotion codes and customers' emails are unique within a business, two kitchens may have the same customer
+ALTER TABLE promotion DROP CONSTRAINT IF EXISTS promotion_code_key;
+ALTER TABLE promotion ADD CONSTRAINT promotion_business_id_code_key UNIQUE (business_id, code);
+ALTER TABLE customer DROP CONSTRAINT IF EXISTS customer_email_key;
+ALTER TABLE customer ADD CONSTRAINT customer_business_id_email_key UNIQUE (business_id, email);
@@ -0,0 +1,9 @@
+-- the refresh tokens (jti) a session was renewed from, every renewal rotates the session's refresh token.
+-- A used jti presented again means the refresh token leaked and its session (the token family) is revoked
+CREATE TABLE IF NOT EXISTS auth_used_jti(
+    jti TEXT NOT NULL PRIMARY KEY,
+    sid TEXT NOT NULL REFERENCES "auth"(sid) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_used_jti_sid ON auth_used_jti(sid);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_auth_owner_id;
+
+ALTER TABLE "auth" DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE "auth" DROP COLUMN IF EXISTS ip;
+ALTER TABLE "auth" DROP COLUMN IF EXISTS user_agent;
@@ -0,0 +1,6 @@
+-- an owner has a session per device, the device is told apart by its user agent and the ip it logged in from
+ALTER TABLE "auth" ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE "auth" ADD COLUMN IF NOT EXISTS ip VARCHAR(45) NOT NULL DEFAULT '';
+ALTER TABLE "auth" ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_auth_owner_id ON "auth"(owner_id);
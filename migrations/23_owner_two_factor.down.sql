@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS owner_recovery_code;
+DROP TABLE IF EXISTS owner_totp;
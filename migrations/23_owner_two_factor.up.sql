@@ -0,0 +1,21 @@
+-- an owner's TOTP second factor, enabled once the owner confirmed a code of the secret. The secret is kept as is, the
+-- codes are computed from it
+CREATE TABLE IF NOT EXISTS owner_totp(
+    owner_id BIGINT PRIMARY KEY REFERENCES "owner"(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_step BIGINT NOT NULL DEFAULT 0, -- the time step of the last used code, a code is used once
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- the single use codes replacing a TOTP code, only their bcrypt hash is kept
+CREATE TABLE IF NOT EXISTS owner_recovery_code(
+    id BIGSERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL REFERENCES owner_totp(owner_id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_owner_recovery_code_owner_id ON owner_recovery_code(owner_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS owner_email_verification;
+ALTER TABLE "owner" DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,17 @@
+-- an owner logs in once its email is verified, the owners registered before the verification are trusted as verified
+ALTER TABLE "owner" ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP NULL;
+UPDATE "owner" SET email_verified_at = NOW() WHERE email_verified_at IS NULL;
+
+-- the links sent to verify an email, the registered email or the new email of an owner changing it. Only the sha256
+-- hash of the token is kept and a token is used once
+CREATE TABLE IF NOT EXISTS owner_email_verification(
+    id BIGSERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL REFERENCES "owner"(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expired_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_owner_email_verification_owner_id ON owner_email_verification(owner_id);
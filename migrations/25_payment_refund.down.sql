@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uq_payment_refund_provider_event;
+DROP TABLE IF EXISTS payment_refund;
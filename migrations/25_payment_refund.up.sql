@@ -0,0 +1,13 @@
+-- the provider's payment events refunded instead of recorded as payment (e.g. the order was cancelled meanwhile),
+-- an event is recorded once so its replay isn't refunded again
+CREATE TABLE IF NOT EXISTS payment_refund(
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    amount BIGINT NOT NULL,
+    charge_id VARCHAR(255) NULL,
+    provider VARCHAR(32) NOT NULL,
+    provider_event_id VARCHAR(255) NOT NULL,
+    refunded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_refund_provider_event ON payment_refund(provider, provider_event_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_order_status_history_order_id;
+DROP TABLE IF EXISTS order_status_history;
+DROP SEQUENCE IF EXISTS order_status_history_id_seq;
+
+-- fold the lifecycle statuses back to NEW, PAID and CANCELLED
+UPDATE "order" SET status = 1 WHERE status = 4;
+UPDATE "order" SET status = 2 WHERE status IN (5, 6, 7, 8);
+UPDATE "order" SET status = 3 WHERE status = 9;
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_status_check;
+ALTER TABLE "order" ADD CONSTRAINT order_status_check CHECK (status > 0 AND status < 4);
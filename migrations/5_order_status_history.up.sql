@@ -0,0 +1,17 @@
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_status_check;
+ALTER TABLE "order" ADD CONSTRAINT order_status_check CHECK (status > 0 AND status < 10);
+
+CREATE TABLE IF NOT EXISTS order_status_history(
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    from_status INT4 NULL,
+    to_status INT4 NOT NULL,
+    changed_by BIGINT NULL, -- owner's id, NULL when changed by the system (e.g. cron)
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id);
+
+INSERT INTO order_status_history
+    (order_id, to_status, created_at)
+SELECT order_id, MIN(status), MIN(created_at) FROM "order" GROUP BY order_id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payment_order_id;
+DROP TABLE IF EXISTS payment;
+DROP SEQUENCE IF EXISTS payment_id_seq;
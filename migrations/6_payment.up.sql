@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS payment(
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    amount FLOAT4 NOT NULL CHECK (amount > 0),
+    method VARCHAR(16) NOT NULL CHECK (method IN ('cash', 'transfer', 'card')),
+    reference_number VARCHAR(255) NULL,
+    received_by BIGINT NULL, -- owner's id
+    paid_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_order_id ON payment(order_id);
+
+-- orders paid before this table exists are assumed to be paid in full by cash
+INSERT INTO payment
+    (order_id, amount, method, paid_at)
+SELECT order_id, SUM(price * qty), 'cash', MAX(updated_at) FROM "order" WHERE status = 2 GROUP BY order_id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_payment_provider_event;
+ALTER TABLE payment DROP COLUMN IF EXISTS provider_event_id;
+ALTER TABLE payment DROP COLUMN IF EXISTS provider;
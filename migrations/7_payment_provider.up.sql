@@ -0,0 +1,5 @@
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS provider VARCHAR(32) NULL;
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS provider_event_id VARCHAR(255) NULL;
+
+-- a webhook event of a provider is recorded at most once (NULLs are distinct so manual payments are unaffected)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_provider_event ON payment(provider, provider_event_id);
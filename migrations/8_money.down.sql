@@ -0,0 +1,16 @@
+ALTER TABLE payment DROP CONSTRAINT IF EXISTS payment_amount_check;
+ALTER TABLE payment ALTER COLUMN amount TYPE FLOAT4 USING (amount::NUMERIC / 100)::FLOAT4;
+ALTER TABLE payment ADD CONSTRAINT payment_amount_check CHECK (amount > 0);
+
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_price_check;
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_qty_check;
+ALTER TABLE "order" ALTER COLUMN price TYPE FLOAT4 USING (price::NUMERIC / 100)::FLOAT4;
+ALTER TABLE "order" ALTER COLUMN qty DROP DEFAULT;
+ALTER TABLE "order" ALTER COLUMN qty TYPE FLOAT4;
+ALTER TABLE "order" ALTER COLUMN qty SET DEFAULT 1;
+ALTER TABLE "order" ADD CONSTRAINT order_price_check CHECK (price > 0.05);
+ALTER TABLE "order" ADD CONSTRAINT order_qty_check CHECK (qty > 0);
+
+ALTER TABLE menu DROP CONSTRAINT IF EXISTS menu_price_check;
+ALTER TABLE menu ALTER COLUMN price TYPE FLOAT4 USING (price::NUMERIC / 100)::FLOAT4;
+ALTER TABLE menu ADD CONSTRAINT menu_price_check CHECK (price > 0.05);
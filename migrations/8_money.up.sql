@@ -0,0 +1,18 @@
+-- money columns store minor units (cents) of money.Currency as BIGINT instead of FLOAT4 so totals are exact,
+-- existing values are rounded to the nearest cent. qty is a whole number of portions.
+ALTER TABLE menu DROP CONSTRAINT IF EXISTS menu_price_check;
+ALTER TABLE menu ALTER COLUMN price TYPE BIGINT USING ROUND(price::NUMERIC * 100)::BIGINT;
+ALTER TABLE menu ADD CONSTRAINT menu_price_check CHECK (price >= 5);
+
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_price_check;
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_qty_check;
+ALTER TABLE "order" ALTER COLUMN price TYPE BIGINT USING ROUND(price::NUMERIC * 100)::BIGINT;
+ALTER TABLE "order" ALTER COLUMN qty DROP DEFAULT;
+ALTER TABLE "order" ALTER COLUMN qty TYPE INT4 USING ROUND(qty)::INT4;
+ALTER TABLE "order" ALTER COLUMN qty SET DEFAULT 1;
+ALTER TABLE "order" ADD CONSTRAINT order_price_check CHECK (price >= 5);
+ALTER TABLE "order" ADD CONSTRAINT order_qty_check CHECK (qty > 0);
+
+ALTER TABLE payment DROP CONSTRAINT IF EXISTS payment_amount_check;
+ALTER TABLE payment ALTER COLUMN amount TYPE BIGINT USING ROUND(amount::NUMERIC * 100)::BIGINT;
+ALTER TABLE payment ADD CONSTRAINT payment_amount_check CHECK (amount > 0);
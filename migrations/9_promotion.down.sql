@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS order_discount;
+DROP TABLE IF EXISTS promotion;
+DROP TRIGGER IF EXISTS tg_promotion_set_updated_at ON promotion RESTRICT;
+DROP FUNCTION IF EXISTS tgf_promotion_set_updated_at();
@@ -0,0 +1,46 @@
+CREATE OR REPLACE FUNCTION tgf_promotion_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+CREATE TABLE IF NOT EXISTS promotion(
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE, -- stored upper case
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    discount_type VARCHAR(16) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    percentage INT4 NOT NULL DEFAULT 0 CHECK (percentage >= 0 AND percentage <= 100),
+    amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    max_discount BIGINT NOT NULL DEFAULT 0 CHECK (max_discount >= 0), -- cap of percentage discount, 0 is no cap
+    min_spend BIGINT NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    menu_ids TEXT NOT NULL DEFAULT '', -- comma separated menu's id, empty (with categories) targets every menu
+    categories TEXT NOT NULL DEFAULT '', -- comma separated menu's categories
+    starts_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMP NULL, -- NULL never ends
+    usage_limit INT4 NOT NULL DEFAULT 0 CHECK (usage_limit >= 0), -- 0 is unlimited
+    usage_limit_per_customer INT4 NOT NULL DEFAULT 0 CHECK (usage_limit_per_customer >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((discount_type = 'percentage' AND percentage > 0) OR (discount_type = 'fixed' AND amount > 0)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE TRIGGER tg_promotion_set_updated_at
+BEFORE UPDATE ON promotion
+FOR EACH ROW
+EXECUTE PROCEDURE tgf_promotion_set_updated_at();
+
+-- the discount line of an order, amount is repriced when the order's items change (0 when the order is below the minimum spend)
+CREATE TABLE IF NOT EXISTS order_discount(
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL UNIQUE,
+    promotion_id BIGINT NOT NULL REFERENCES promotion(id),
+    code VARCHAR(32) NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_discount_promotion ON order_discount(promotion_id, customer_email);
@@ -9,10 +9,27 @@ var (
 	ErrRequiredParam = errors.New("missing required param")
 
 	ErrAuth                    = &sentinelError{statusCode: http.StatusUnauthorized, message: "not authorized"}
+	ErrForbidden               = &sentinelError{statusCode: http.StatusForbidden, message: "forbidden"}
 	ErrNotFound                = &sentinelError{statusCode: http.StatusNotFound, message: "resource not found"}
 	ErrFieldValidation         = &sentinelError{statusCode: http.StatusUnprocessableEntity, message: "invalid request's param"}
 	ErrFieldValidationRequired = &sentinelError{statusCode: http.StatusBadRequest, message: ErrRequiredParam.Error()}
 	ErrEmailRegistered         = &sentinelError{statusCode: http.StatusConflict, message: "email already registered"}
+	ErrOrderStatusTransition   = &sentinelError{statusCode: http.StatusConflict, message: "invalid order status transition"}
+	ErrOrderNotEditable        = &sentinelError{statusCode: http.StatusConflict, message: "order can't be modified anymore"}
+	ErrInvalidSignature        = &sentinelError{statusCode: http.StatusUnauthorized, message: "invalid signature"}
+	ErrPromoCodeRegistered     = &sentinelError{statusCode: http.StatusConflict, message: "promo code already registered"}
+	ErrPromotionInUse          = &sentinelError{statusCode: http.StatusConflict, message: "promotion has been used by an order"}
+	ErrCustomerInUse           = &sentinelError{statusCode: http.StatusConflict, message: "customer has ordered"}
+	ErrMenuSoldOut             = &sentinelError{statusCode: http.StatusConflict, message: "menu is sold out"}
+	ErrDeliverySlotFull        = &sentinelError{statusCode: http.StatusConflict, message: "delivery slot is full, please choose another slot"}
+	ErrOutOfDeliveryArea       = &sentinelError{statusCode: http.StatusUnprocessableEntity, message: "address is outside of the delivery area"}
+	ErrOrderNotAssignable      = &sentinelError{statusCode: http.StatusConflict, message: "order can't be assigned to the driver"}
+	ErrDriverInUse             = &sentinelError{statusCode: http.StatusConflict, message: "driver has delivered orders"}
+	ErrLastAdmin               = &sentinelError{statusCode: http.StatusConflict, message: "there must be at least one admin"}
+	ErrLoginLocked             = &sentinelError{statusCode: http.StatusLocked, message: "too many failed logins, please retry later or reset the password"}
+	ErrTwoFactorEnabled        = &sentinelError{statusCode: http.StatusConflict, message: "two-factor authentication is enabled already"}
+	ErrEmailNotVerified        = &sentinelError{statusCode: http.StatusForbidden, message: "email is not verified yet, please click the link sent to the email"}
+	ErrPasswordResetLimited    = &sentinelError{statusCode: http.StatusTooManyRequests, message: "too many password reset requests, please retry later"}
 )
 
 type APIError interface {
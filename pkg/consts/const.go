@@ -10,11 +10,33 @@ const (
 	CtxKeyStatusCode         = "StatusCode"
 	CookieResetPasswordToken = "rpt"
 	CookieSID                = "sid" // session id
+	HeaderWebhookSignature   = "X-Webhook-Signature"
 
 	DefaultAttemptsMigration = 10
 	DefaultTimeoutMigration  = time.Second
 
-	StatusNew       = 1
-	StatusPaid      = 2
-	StatusCancelled = 3
+	// order status, the value is stored at "order".status so never renumber an existing one
+	StatusNew            = 1
+	StatusPaid           = 2
+	StatusCancelled      = 3
+	StatusConfirmed      = 4
+	StatusPreparing      = 5
+	StatusReady          = 6
+	StatusOutForDelivery = 7
+	StatusDelivered      = 8
+	StatusRefunded       = 9
+
+	PaymentMethodCash     = "cash"
+	PaymentMethodTransfer = "transfer"
+	PaymentMethodCard     = "card"
+
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+
+	// staff's roles, the value is stored at owner.role and embedded in the access token
+	RoleAdmin   = "admin" // the owners of the business, manage the staff
+	RoleCashier = "cashier"
+	RoleKitchen = "kitchen"
+	RoleDriver  = "driver"
+	RoleViewer  = "viewer" // read only
 )
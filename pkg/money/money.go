@@ -0,0 +1,221 @@
+// Package money provides an exact amount of money stored as integer minor units (e.g. cents) of Currency.
+// It's used for every price, total and payment instead of float so totals reconcile with the bank to the cent.
+//
+// The application runs in a single currency, Currency, so an amount doesn't carry its currency: the database columns
+// store its minor units (BIGINT) and the JSON its decimal amount. Supporting another currency means storing and sending
+// the currency with every amount.
+//
+// Rounding rule: amounts are always kept in minor units, only a derived amount (tax, service charge or discount)
+// is rounded, once, to the nearest minor unit with half away from zero (see Money.Percent).
+// Compute such amount from the subtotal instead of summing per item rounded amounts.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Currency is the ISO 4217 code of every amount
+const Currency = "IDR"
+
+// exponent is the number of digits of Currency's minor unit (ISO 4217)
+const exponent = 2
+
+var ErrInvalidAmount = errors.New("money: invalid amount")
+
+type Money struct {
+	Amount int64 // minor units of Currency
+}
+
+// FromMinor return money of the given minor units
+func FromMinor(amount int64) Money {
+	return Money{Amount: amount}
+}
+
+// MustParse is like Parse but panics on invalid amount, it's meant for constants and tests
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Parse parse a decimal amount (e.g. "12500.50"), it never rounds: amount with more fraction digits than the
+// minor unit returns ErrInvalidAmount
+func Parse(s string) (Money, error) {
+	str := strings.TrimSpace(s)
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	if intPart == "" || len(fracPart) > exponent || !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	fracPart += strings.Repeat("0", exponent-len(fracPart))
+
+	amount, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if neg {
+		amount = -amount
+	}
+
+	return FromMinor(amount), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) Add(o Money) Money {
+	return FromMinor(m.Amount + o.Amount)
+}
+
+func (m Money) Sub(o Money) Money {
+	return FromMinor(m.Amount - o.Amount)
+}
+
+// Mul multiply the amount by a quantity
+func (m Money) Mul(qty int64) Money {
+	return FromMinor(m.Amount * qty)
+}
+
+// Percent return the given rate (in basis points, 1% = 100) of the amount
+// rounded half away from zero to the minor unit, e.g. 11% tax is Percent(1100)
+func (m Money) Percent(basisPoints int64) Money {
+	return FromMinor(divRound(m.Amount*basisPoints, 10_000))
+}
+
+// divRound divide a by positive b rounded half away from zero
+func divRound(a, b int64) int64 {
+	q, r := a/b, a%b
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// Cmp return -1, 0 or +1 when m is less than, equal to or greater than o
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// Max return the greater of m and o
+func (m Money) Max(o Money) Money {
+	if m.Cmp(o) < 0 {
+		return o
+	}
+	return m
+}
+
+// String return the decimal amount without currency, e.g. "12500.50"
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := fmt.Sprintf("%0*d", exponent+1, amount)
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// MarshalJSON encode the amount as a decimal string (e.g. "12500.50") so clients never parse it as float
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accept a decimal string or a JSON number, both are parsed exactly (see Parse)
+func (m *Money) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" {
+		return nil
+	}
+	if strings.HasPrefix(str, `"`) {
+		unquoted, err := strconv.Unquote(str)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, str)
+		}
+		str = unquoted
+	}
+
+	parsed, err := Parse(str)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+
+	return nil
+}
+
+// Scan read minor units from BIGINT (or NUMERIC without fraction, e.g. SUM of BIGINT) column
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = FromMinor(0)
+	case int64:
+		*m = FromMinor(v)
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	default:
+		return fmt.Errorf("money: can't scan %T into Money", src)
+	}
+
+	return nil
+}
+
+func (m *Money) scanString(s string) error {
+	// NUMERIC is sent as text, e.g. "1250050" or "1250050.0000"
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		if strings.Trim(s[i+1:], "0") != "" {
+			return fmt.Errorf("%w: %q is not in minor units", ErrInvalidAmount, s)
+		}
+		s = s[:i]
+	}
+
+	amount, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	*m = FromMinor(amount)
+
+	return nil
+}
+
+// Value store the minor units of Currency
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
@@ -0,0 +1,153 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Money
+		wantErr bool
+	}{
+		{name: "success Parse (integer)", s: "12500", want: Money{Amount: 1_250_000}},
+		{name: "success Parse (fraction)", s: "12500.5", want: Money{Amount: 1_250_050}},
+		{name: "success Parse (cents)", s: "0.05", want: Money{Amount: 5}},
+		{name: "success Parse (negative)", s: "-10.25", want: Money{Amount: -1025}},
+		{name: "fail Parse (more fraction digits than minor unit)", s: "0.125", wantErr: true},
+		{name: "fail Parse (exponent)", s: "1e3", wantErr: true},
+		{name: "fail Parse (empty)", s: "", wantErr: true},
+		{name: "fail Parse (no integer part)", s: ".5", wantErr: true},
+		{name: "fail Parse (overflow)", s: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.s)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAmount)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{name: "success String", money: FromMinor(1_250_050), want: "12500.50"},
+		{name: "success String (below one)", money: FromMinor(5), want: "0.05"},
+		{name: "success String (negative)", money: FromMinor(-5), want: "-0.05"},
+		{name: "success String (zero value)", money: Money{}, want: "0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.String())
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := MustParse("0.10")
+
+	// 0.1 + 0.2 drift with float, never with minor units
+	assert.Equal(t, MustParse("0.30"), price.Add(MustParse("0.20")))
+	assert.Equal(t, MustParse("3000000.30"), MustParse("100000.01").Mul(30))
+	assert.Equal(t, MustParse("0.05"), price.Sub(MustParse("0.05")))
+	assert.Equal(t, MustParse("0.10"), Money{}.Add(price))
+	assert.Equal(t, 1, price.Cmp(MustParse("0.05")))
+	assert.Equal(t, 0, price.Cmp(FromMinor(10)))
+	assert.Equal(t, -1, price.Cmp(MustParse("0.11")))
+	assert.Equal(t, price, price.Max(Money{}))
+}
+
+func TestMoney_Percent(t *testing.T) {
+	tests := []struct {
+		name        string
+		money       Money
+		basisPoints int64
+		want        Money
+	}{
+		{name: "success Percent (exact)", money: MustParse("100000"), basisPoints: 1100, want: MustParse("11000")},
+		{name: "success Percent (round half up)", money: MustParse("0.05"), basisPoints: 1000, want: MustParse("0.01")},
+		{name: "success Percent (round down)", money: MustParse("0.14"), basisPoints: 1000, want: MustParse("0.01")},
+		{name: "success Percent (round half away from zero)", money: MustParse("-0.05"), basisPoints: 1000, want: MustParse("-0.01")},
+		{name: "success Percent (fraction rate)", money: MustParse("12345.67"), basisPoints: 1250, want: MustParse("1543.21")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.Percent(tt.basisPoints))
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	type payload struct {
+		Price Money `json:"price"`
+	}
+
+	b, err := json.Marshal(payload{Price: MustParse("12500.5")})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":"12500.50"}`, string(b))
+
+	tests := []struct {
+		name    string
+		data    string
+		want    Money
+		wantErr bool
+	}{
+		{name: "success UnmarshalJSON (string)", data: `{"price":"12500.50"}`, want: MustParse("12500.50")},
+		{name: "success UnmarshalJSON (number)", data: `{"price":12500.5}`, want: MustParse("12500.50")},
+		{name: "success UnmarshalJSON (null)", data: `{"price":null}`, want: Money{}},
+		{name: "fail UnmarshalJSON (more fraction digits than minor unit)", data: `{"price":0.001}`, wantErr: true},
+		{name: "fail UnmarshalJSON (not a number)", data: `{"price":"abc"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := payload{}
+			err := json.Unmarshal([]byte(tt.data), &got)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got.Price)
+			}
+		})
+	}
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Money
+		wantErr bool
+	}{
+		{name: "success Scan (BIGINT)", src: int64(1_250_050), want: FromMinor(1_250_050)},
+		{name: "success Scan (NUMERIC)", src: []byte("1250050"), want: FromMinor(1_250_050)},
+		{name: "success Scan (NUMERIC with zero fraction)", src: "1250050.000", want: FromMinor(1_250_050)},
+		{name: "success Scan (NULL)", src: nil, want: FromMinor(0)},
+		{name: "fail Scan (fraction of minor unit)", src: "1250050.5", wantErr: true},
+		{name: "fail Scan (float)", src: float64(1.5), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Money{}
+			err := got.Scan(tt.src)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	v, err := FromMinor(1_250_050).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1_250_050), v)
+}
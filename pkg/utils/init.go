@@ -1,6 +1,9 @@
 package utils
 
-import "family-catering/pkg/consts"
+import (
+	"family-catering/pkg/consts"
+	"time"
+)
 
 // any function should be pure or method which not remember it internal state
 // this is due to code design on this package in order to make mock/patch easier
@@ -12,8 +15,14 @@ func init() {
 	// GenerateAccessToken = generateAccessToken
 	// GenerateRefreshToken = generateRefreshToken
 	GenerateToken = generateToken
+	GenerateAccessToken = generateAccessToken
 	ValidateRequest = validateRequest
 	ValidateToken = validateToken
+	GeneratePreAuthToken = generatePreAuthToken
+	ValidatePreAuthToken = validatePreAuthToken
+	GenerateTOTPSecret = generateTOTPSecret
+	GenerateOneTimeToken = generateOneTimeToken
+	Now = time.Now
 	ValueContext = valueContext
 	ContextWithValue = contextWithValue
 	keys[consts.CtxKeyAuthorization] = &contextKey{consts.CtxKeyAuthorization}
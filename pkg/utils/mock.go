@@ -61,6 +61,13 @@ func (m Mock) Patch(targetFunc string, f interface{}) {
 			panic(err)
 		}
 		GenerateToken = newF
+	case "generateaccesstoken":
+		newF, ok := f.(func(time.Duration, int64, int64, string) (string, error))
+		if !ok {
+			err := fmt.Errorf("utils.Mock.Patch: GenerateAccessToken type miss match, want func(time.Duration, int64, int64, string) (string, error), got %T", f)
+			panic(err)
+		}
+		GenerateAccessToken = newF
 	case "validatetoken":
 		newF, ok := f.(func(string) (*JwtClaims, error))
 		if !ok {
@@ -68,6 +75,41 @@ func (m Mock) Patch(targetFunc string, f interface{}) {
 			panic(err)
 		}
 		ValidateToken = newF
+	case "generatepreauthtoken":
+		newF, ok := f.(func(time.Duration, int64) (string, error))
+		if !ok {
+			err := fmt.Errorf("utils.Mock.Patch: GeneratePreAuthToken type miss match, want func(time.Duration, int64) (string, error), got %T", f)
+			panic(err)
+		}
+		GeneratePreAuthToken = newF
+	case "validatepreauthtoken":
+		newF, ok := f.(func(string) (*JwtClaims, error))
+		if !ok {
+			err := fmt.Errorf("utils.Mock.Patch: ValidatePreAuthToken type miss match, want func(string) (*JwtClaims, error), got %T", f)
+			panic(err)
+		}
+		ValidatePreAuthToken = newF
+	case "generatetotpsecret":
+		newF, ok := f.(func() (string, error))
+		if !ok {
+			err := fmt.Errorf("utils.Mock.Patch: GenerateTOTPSecret type miss match, want func() (string, error), got %T", f)
+			panic(err)
+		}
+		GenerateTOTPSecret = newF
+	case "generateonetimetoken":
+		newF, ok := f.(func() (string, error))
+		if !ok {
+			err := fmt.Errorf("utils.Mock.Patch: GenerateOneTimeToken type miss match, want func() (string, error), got %T", f)
+			panic(err)
+		}
+		GenerateOneTimeToken = newF
+	case "now":
+		newF, ok := f.(func() time.Time)
+		if !ok {
+			err := fmt.Errorf("utils.Mock.Patch: Now type miss match, want func() time.Time, got %T", f)
+			panic(err)
+		}
+		Now = newF
 	case "contextwithvalue":
 		newF, ok := f.(func(context.Context, string, interface{}) context.Context)
 		if !ok {
@@ -93,6 +135,18 @@ func (m Mock) Unpatch(targetFunc string) {
 		ValidatePassword = validatePassword
 	case "generatetoken":
 		GenerateToken = generateToken
+	case "generateaccesstoken":
+		GenerateAccessToken = generateAccessToken
+	case "generatepreauthtoken":
+		GeneratePreAuthToken = generatePreAuthToken
+	case "validatepreauthtoken":
+		ValidatePreAuthToken = validatePreAuthToken
+	case "generatetotpsecret":
+		GenerateTOTPSecret = generateTOTPSecret
+	case "generateonetimetoken":
+		GenerateOneTimeToken = generateOneTimeToken
+	case "now":
+		Now = time.Now
 	// case "generaterandomint64":
 	// 	GenerateRandomInt64 = generateRandomInt64
 	// case "generateaccesstoken":
@@ -110,10 +164,16 @@ func (m Mock) UnpatchAll() {
 	HashPassword = hashPassword
 	ValidatePassword = validatePassword
 	GenerateToken = generateToken
+	GenerateAccessToken = generateAccessToken
 	// GenerateRandomInt64 = generateRandomInt64
 	// GenerateAccessToken = generateAccessToken
 	// GenerateRefreshToken = generateRefreshToken
 	ValidateRequest = validateRequest
+	GeneratePreAuthToken = generatePreAuthToken
+	ValidatePreAuthToken = validatePreAuthToken
+	GenerateTOTPSecret = generateTOTPSecret
+	GenerateOneTimeToken = generateOneTimeToken
+	Now = time.Now
 	ValueContext = valueContext
 	ContextWithValue = contextWithValue
 }
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// oneTimeTokenSize is the random bytes of a one time token, 256 bits can't be guessed
+const oneTimeTokenSize = 32
+
+// GenerateOneTimeToken generate the random token of the links sent by email, only its HashOneTimeToken is stored so a
+// leaked database doesn't leak usable links
+var GenerateOneTimeToken func() (string, error)
+
+func generateOneTimeToken() (string, error) {
+	token := make([]byte, oneTimeTokenSize)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", fmt.Errorf("utils.GenerateOneTimeToken: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// HashOneTimeToken return the hash of the token as it's stored and looked up. The token is random enough for a plain
+// sha256, unlike a password it doesn't need bcrypt
+func HashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
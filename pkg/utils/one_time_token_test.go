@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateOneTimeToken(t *testing.T) {
+	token, err := generateOneTimeToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 43) // 32 bytes
+	assert.NotContains(t, token, "/")
+	assert.NotContains(t, token, "+")
+
+	other, err := generateOneTimeToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestHashOneTimeToken(t *testing.T) {
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", HashOneTimeToken("test"))
+	assert.Len(t, HashOneTimeToken("an other token"), 64)
+	assert.NotEqual(t, HashOneTimeToken("test"), HashOneTimeToken("Test"))
+}
@@ -30,10 +30,14 @@ var (
 	// GenerateRefreshToken func(string, time.Duration) (string, error)
 	// GenerateRandomString func(int) (string, error)
 	GenerateToken func(expire time.Duration, jti string, email string) (string, error)
-	ValidateToken func(token string) (*JwtClaims, error)
-
-	secretKeyAccessToken  string = "secretKeyAccessToken"  // TODO: refactored, must not be hardcoded
-	secretKeyRefreshToken string = "secretKeyRefreshToken" // TODO: refactored, must not be hardcoded
+	// GenerateAccessToken generate the access token of a logged in owner, the business and role are embedded in the token
+	// so the authorization doesn't need a round trip to the database
+	GenerateAccessToken func(expire time.Duration, ownerID, businessID int64, role string) (string, error)
+	ValidateToken       func(token string) (*JwtClaims, error)
+	// GeneratePreAuthToken generate the token of an owner whose password is verified but not its second factor yet, it's
+	// only accepted by ValidatePreAuthToken so it authorizes nothing else
+	GeneratePreAuthToken func(expire time.Duration, ownerID int64) (string, error)
+	ValidatePreAuthToken func(token string) (*JwtClaims, error)
 )
 
 // const letters string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -41,12 +45,16 @@ var (
 const (
 	accessTokenType  string = "at"
 	refreshTokenType string = "rt"
+	preAuthTokenType string = "pa"
 )
 
 type JwtClaims struct {
 	Type             string `json:"type,omitempty"`
 	Email            string `json:"email,omitempty"`
 	ForResetPassword bool   `json:"for_reset_password,omitempty"`
+	OwnerID          int64  `json:"owner_id,omitempty"`    // empty on refresh and reset password tokens, the owner of a pre-auth token
+	BusinessID       int64  `json:"business_id,omitempty"` // the tenant every data access is scoped by
+	Role             string `json:"role,omitempty"`
 	forTesting       bool   // use only for bypassing
 	jwt.StandardClaims
 }
@@ -63,6 +71,17 @@ func (j *JwtClaims) IsForResetPassword() bool {
 // 	return j.email
 // }
 
+// HasRole return true when the claims' role is one of roles
+func (j *JwtClaims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if j.Role != "" && j.Role == role {
+			return true
+		}
+	}
+
+	return false
+}
+
 func NewJWTClaimTesting(id string) *JwtClaims {
 	j := JwtClaims{}
 	j.forTesting = true
@@ -136,8 +155,6 @@ func generateToken(expire time.Duration, id, email string) (string, error) {
 			ExpiresAt: createdAt.Add(expire).Unix(),
 		},
 	}
-	var secret []byte
-	secret = []byte(secretKeyAccessToken)
 	if id != "" {
 		claims.Id = id
 
@@ -148,12 +165,40 @@ func generateToken(expire time.Duration, id, email string) (string, error) {
 
 		} else {
 			claims.Type = refreshTokenType
-			secret = []byte(secretKeyRefreshToken)
 		}
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secret)
+	return signToken(claims)
+
+}
+
+func generateAccessToken(expire time.Duration, ownerID, businessID int64, role string) (string, error) {
+	createdAt := time.Now()
+	claims := JwtClaims{
+		Type:       accessTokenType,
+		OwnerID:    ownerID,
+		BusinessID: businessID,
+		Role:       role,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  createdAt.Unix(),
+			ExpiresAt: createdAt.Add(expire).Unix(),
+		},
+	}
+
+	return signToken(claims)
+}
+
+func generatePreAuthToken(expire time.Duration, ownerID int64) (string, error) {
+	createdAt := time.Now()
+	claims := JwtClaims{
+		Type:    preAuthTokenType,
+		OwnerID: ownerID,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  createdAt.Unix(),
+			ExpiresAt: createdAt.Add(expire).Unix(),
+		},
+	}
 
+	return signToken(claims)
 }
 
 // func generateRefreshToken(jti string, expire time.Duration) (string, error) {
@@ -174,29 +219,47 @@ func generateToken(expire time.Duration, id, email string) (string, error) {
 // }
 
 func validateToken(tokenString string) (*JwtClaims, error) {
+	return parseToken(tokenString, accessTokenType, refreshTokenType)
+}
+
+func validatePreAuthToken(tokenString string) (*JwtClaims, error) {
+	return parseToken(tokenString, preAuthTokenType)
+}
+
+// parseToken verify the token, its type must be one of types
+func parseToken(tokenString string, types ...string) (*JwtClaims, error) {
 	claims := &JwtClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-		var secret string
-		// validate method
-		if t.Method != jwt.SigningMethodHS256 {
-			return nil, fmt.Errorf("utils.ValidateToken: unexpected signing method, want HS256 got %s", t.Header["alg"])
+		if !isTokenType(claims.Type, types...) {
+			return nil, fmt.Errorf("utils.ValidateToken: unexpected token type %q", claims.Type)
 		}
 
-		switch claims.Type {
-		case accessTokenType:
-			secret = secretKeyAccessToken
-		case refreshTokenType:
-			secret = secretKeyRefreshToken
-		default:
-			secret = ""
+		// a token without kid was signed before the keys rotation and is not trusted anymore
+		kid, _ := t.Header["kid"].(string)
+		key, ok := tokenKeySet(claims.Type).key(kid, time.Now())
+		if !ok {
+			return nil, fmt.Errorf("utils.ValidateToken: unknown or expired kid %q", kid)
 		}
 
-		return []byte(secret), nil
+		// validate method, the key is only valid for its own algorithm
+		if t.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("utils.ValidateToken: unexpected signing method, want %s got %s", key.Algorithm, t.Header["alg"])
+		}
+
+		return key.verifyKey, nil
 	})
 
 	if err != nil {
 		return nil, fmt.Errorf("utils.ValidateToken: err %w", err)
 	}
+
+	issuer, audience := tokenIssuer()
+	if issuer != "" && !claims.VerifyIssuer(issuer, true) {
+		return nil, fmt.Errorf("utils.ValidateToken: unexpected issuer %q", claims.Issuer)
+	}
+	if audience != "" && !claims.VerifyAudience(audience, true) {
+		return nil, fmt.Errorf("utils.ValidateToken: unexpected audience %q", claims.Audience)
+	}
 	payload, ok := token.Claims.(*JwtClaims)
 	if !ok {
 		return nil, fmt.Errorf("utils.ValidateToken: invalid payload data")
@@ -205,6 +268,16 @@ func validateToken(tokenString string) (*JwtClaims, error) {
 	return payload, nil
 }
 
+func isTokenType(tokenType string, types ...string) bool {
+	for _, t := range types {
+		if tokenType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
 // func generateRandomString(n int) (string, error) {
 // 	var err error
 // 	b := strings.Builder{}
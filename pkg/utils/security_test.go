@@ -0,0 +1,320 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTokenKeys(t *testing.T) {
+	validSet := TokenKeySet{Primary: "2", Keys: []TokenKey{{ID: "2", Secret: "secret-2"}}}
+	type args struct {
+		access  TokenKeySet
+		refresh TokenKeySet
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success SetTokenKeys",
+			args: args{
+				access: TokenKeySet{Primary: "2", Keys: []TokenKey{
+					{ID: "2", Secret: "secret-2"},
+					{ID: "1", Secret: "secret-1", ExpiresAt: time.Now().Add(time.Hour)},
+				}},
+				refresh: validSet,
+			},
+		},
+		{
+			name:    "fail SetTokenKeys (primary not found)",
+			args:    args{access: TokenKeySet{Primary: "3", Keys: validSet.Keys}, refresh: validSet},
+			wantErr: true,
+		},
+		{
+			name: "fail SetTokenKeys (primary expires)",
+			args: args{
+				access:  TokenKeySet{Primary: "2", Keys: []TokenKey{{ID: "2", Secret: "secret-2", ExpiresAt: time.Now().Add(time.Hour)}}},
+				refresh: validSet,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail SetTokenKeys (duplicate kid)",
+			args: args{
+				access:  validSet,
+				refresh: TokenKeySet{Primary: "2", Keys: []TokenKey{{ID: "2", Secret: "secret-2"}, {ID: "2", Secret: "secret-3"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail SetTokenKeys (empty secret)",
+			args: args{
+				access:  TokenKeySet{Primary: "2", Keys: []TokenKey{{ID: "2"}}},
+				refresh: validSet,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fail SetTokenKeys (no keys)",
+			args:    args{access: validSet},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetTokenKeys(tt.args.access, tt.args.refresh)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+// writeTokenKey generate a key pair of alg in dir, return the private and public key files
+func writeTokenKey(t *testing.T, dir, alg string) (privateFile, publicFile string) {
+	privatePEM, publicPEM, err := GenerateTokenKeyPEM(alg)
+	assert.NoError(t, err)
+	privateFile, publicFile = filepath.Join(dir, alg+".pem"), filepath.Join(dir, alg+".pub.pem")
+	assert.NoError(t, os.WriteFile(privateFile, privatePEM, 0600))
+	assert.NoError(t, os.WriteFile(publicFile, publicPEM, 0644))
+	return privateFile, publicFile
+}
+
+func TestValidateToken(t *testing.T) {
+	dir := t.TempDir()
+	rsaPrivate, rsaPublic := writeTokenKey(t, dir, TokenAlgRS256)
+	edPrivate, _ := writeTokenKey(t, dir, TokenAlgEdDSA)
+	rsaAccess := TokenKeySet{Primary: "rsa", Keys: []TokenKey{{ID: "rsa", Algorithm: TokenAlgRS256, KeyFile: rsaPrivate}}}
+	oldAccess := TokenKeySet{Primary: "1", Keys: []TokenKey{{ID: "1", Secret: "secret-1"}}}
+	refresh := TokenKeySet{Primary: "1", Keys: []TokenKey{{ID: "1", Secret: "secret-refresh"}}}
+	tests := []struct {
+		name string
+		// signKeys are the access token keys when the token is generated and verifyKeys the ones when it's validated
+		signKeys   TokenKeySet
+		verifyKeys TokenKeySet
+		token      func() (string, error)
+		wantErr    bool
+	}{
+		{
+			name:       "success ValidateToken",
+			signKeys:   oldAccess,
+			verifyKeys: oldAccess,
+			token:      func() (string, error) { return generateAccessToken(time.Minute, 1, 1, "admin") },
+		},
+		{
+			name:     "success ValidateToken (signed by a retired key)",
+			signKeys: oldAccess,
+			verifyKeys: TokenKeySet{Primary: "2", Keys: []TokenKey{
+				{ID: "2", Secret: "secret-2"},
+				{ID: "1", Secret: "secret-1", ExpiresAt: time.Now().Add(time.Hour)},
+			}},
+			token: func() (string, error) { return generateAccessToken(time.Minute, 1, 1, "admin") },
+		},
+		{
+			name:     "success ValidateToken (refresh token)",
+			signKeys: oldAccess,
+			verifyKeys: TokenKeySet{Primary: "2", Keys: []TokenKey{
+				{ID: "2", Secret: "secret-2"},
+			}},
+			token: func() (string, error) { return generateToken(time.Minute, "jti", "") },
+		},
+		{
+			name:     "fail ValidateToken (signed by a removed key)",
+			signKeys: oldAccess,
+			verifyKeys: TokenKeySet{Primary: "2", Keys: []TokenKey{
+				{ID: "2", Secret: "secret-2"},
+			}},
+			token:   func() (string, error) { return generateAccessToken(time.Minute, 1, 1, "admin") },
+			wantErr: true,
+		},
+		{
+			name:     "fail ValidateToken (kid of another secret)",
+			signKeys: oldAccess,
+			verifyKeys: TokenKeySet{Primary: "1", Keys: []TokenKey{
+				{ID: "1", Secret: "leaked-secret-replaced"},
+			}},
+			token:   func() (string, error) { return generateAccessToken(time.Minute, 1, 1, "admin") },
+			wantErr: true,
+		},
+		{
+			name:       "success ValidateToken (RS256)",
+			signKeys:   rsaAccess,
+			verifyKeys: rsaAccess,
+			token:      func() (string, error) { return generateAccessToken(time.Minute, 1, 1, "admin") },
+		},
+		{
+			name:       "success ValidateToken (EdDSA)",
+			signKeys:   TokenKeySet{Primary: "ed", Keys: []TokenKey{{ID: "ed", Algorithm: TokenAlgEdDSA, KeyFile: edPrivate}}},
+			verifyKeys: TokenKeySet{Primary: "ed", Keys: []TokenKey{{ID: "ed", Algorithm: TokenAlgEdDSA, KeyFile: edPrivate}}},
+			token:      func() (string, error) { return generateToken(time.Minute, "rpid", "test@example.com") },
+		},
+		{
+			name:     "success ValidateToken (verified by a public key)",
+			signKeys: rsaAccess,
+			verifyKeys: TokenKeySet{Primary: "1", Keys: []TokenKey{
+				{ID: "1", Secret: "secret-1"},
+				{ID: "rsa", Algorithm: TokenAlgRS256, KeyFile: rsaPublic, ExpiresAt: time.Now().Add(time.Hour)},
+			}},
+			token: func() (string, error) { return generateAccessToken(time.Minute, 1, 1, "admin") },
+		},
+		{
+			name:       "fail ValidateToken (HS256 signed with the public key of a RS256 kid)",
+			signKeys:   rsaAccess,
+			verifyKeys: rsaAccess,
+			token: func() (string, error) {
+				publicPEM, err := os.ReadFile(rsaPublic)
+				if err != nil {
+					return "", err
+				}
+				claims := JwtClaims{Type: accessTokenType, StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Minute).Unix()}}
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				token.Header["kid"] = "rsa"
+				return token.SignedString(publicPEM)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "fail ValidateToken (no kid)",
+			signKeys:   oldAccess,
+			verifyKeys: oldAccess,
+			token: func() (string, error) {
+				claims := JwtClaims{Type: accessTokenType, StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Minute).Unix()}}
+				return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret-1"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, SetTokenKeys(tt.signKeys, refresh))
+			token, err := tt.token()
+			assert.NoError(t, err)
+
+			assert.NoError(t, SetTokenKeys(tt.verifyKeys, refresh))
+			_, err = validateToken(token)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestValidatePreAuthToken(t *testing.T) {
+	keys := TokenKeySet{Primary: "1", Keys: []TokenKey{{ID: "1", Secret: "secret-1"}}}
+	assert.NoError(t, SetTokenKeys(keys, keys))
+
+	preAuthToken, err := generatePreAuthToken(time.Minute, 1)
+	assert.NoError(t, err)
+	accessToken, err := generateAccessToken(time.Minute, 1, 1, "admin")
+	assert.NoError(t, err)
+	expiredPreAuthToken, err := generatePreAuthToken(-time.Minute, 1)
+	assert.NoError(t, err)
+
+	claims, err := validatePreAuthToken(preAuthToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), claims.OwnerID)
+
+	// a pre-auth token is not an access token and the other way round
+	_, err = validateToken(preAuthToken)
+	assert.Error(t, err)
+	_, err = validatePreAuthToken(accessToken)
+	assert.Error(t, err)
+	_, err = validatePreAuthToken(expiredPreAuthToken)
+	assert.Error(t, err)
+}
+
+func TestValidateToken_issuer(t *testing.T) {
+	keys := TokenKeySet{Primary: "1", Keys: []TokenKey{{ID: "1", Secret: "secret-1"}}}
+	assert.NoError(t, SetTokenKeys(keys, keys))
+	defer SetTokenIssuer("", "")
+	tests := []struct {
+		name string
+		// the issuer and audience when the token is generated
+		signIssuer, signAudience string
+		wantErr                  bool
+	}{
+		{
+			name:       "success ValidateToken (same issuer and audience)",
+			signIssuer: "family-catering", signAudience: "family-catering",
+		},
+		{
+			name:       "fail ValidateToken (another issuer)",
+			signIssuer: "another-catering", signAudience: "family-catering",
+			wantErr: true,
+		},
+		{
+			name:       "fail ValidateToken (another audience)",
+			signIssuer: "family-catering", signAudience: "another-service",
+			wantErr: true,
+		},
+		{
+			name:    "fail ValidateToken (no issuer)",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTokenIssuer(tt.signIssuer, tt.signAudience)
+			token, err := generateAccessToken(time.Minute, 1, 1, "admin")
+			assert.NoError(t, err)
+
+			SetTokenIssuer("family-catering", "family-catering")
+			claims, err := validateToken(token)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				assert.Equal(t, "family-catering", claims.Issuer)
+			}
+		})
+	}
+}
+
+func TestTokenJWKS(t *testing.T) {
+	dir := t.TempDir()
+	rsaPrivate, _ := writeTokenKey(t, dir, TokenAlgRS256)
+	_, edPublic := writeTokenKey(t, dir, TokenAlgEdDSA)
+	access := TokenKeySet{Primary: "rsa", Keys: []TokenKey{
+		{ID: "rsa", Algorithm: TokenAlgRS256, KeyFile: rsaPrivate},
+		{ID: "ed", Algorithm: TokenAlgEdDSA, KeyFile: edPublic},
+		{ID: "hs", Secret: "secret-never-published"},
+	}}
+	refresh := TokenKeySet{Primary: "refresh", Keys: []TokenKey{{ID: "refresh", Algorithm: TokenAlgRS256, KeyFile: rsaPrivate}}}
+	assert.NoError(t, SetTokenKeys(access, refresh))
+
+	jwks := TokenJWKS()
+
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, JSONWebKey{Kty: "RSA", Kid: "rsa", Use: "sig", Alg: TokenAlgRS256, N: jwks.Keys[0].N, E: "AQAB"}, jwks.Keys[0])
+	assert.NotEmpty(t, jwks.Keys[0].N)
+	assert.Equal(t, JSONWebKey{Kty: "OKP", Kid: "ed", Use: "sig", Alg: TokenAlgEdDSA, Crv: "Ed25519", X: jwks.Keys[1].X}, jwks.Keys[1])
+	assert.NotEmpty(t, jwks.Keys[1].X)
+}
+
+func TestGenerateTokenKeyPEM(t *testing.T) {
+	tests := []struct {
+		name    string
+		alg     string
+		wantErr bool
+	}{
+		{name: "success GenerateTokenKeyPEM (RS256)", alg: TokenAlgRS256},
+		{name: "success GenerateTokenKeyPEM (EdDSA)", alg: TokenAlgEdDSA},
+		{name: "fail GenerateTokenKeyPEM (HS256)", alg: TokenAlgHS256, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privatePEM, publicPEM, err := GenerateTokenKeyPEM(tt.alg)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if tt.wantErr {
+				return
+			}
+
+			signKey, verifyKey, err := parseKeyPEM(tt.alg, privatePEM)
+			assert.NoError(t, err)
+			assert.NotNil(t, signKey)
+			_, publicKey, err := parseKeyPEM(tt.alg, publicPEM)
+			assert.NoError(t, err)
+			assert.Equal(t, verifyKey, publicKey)
+		})
+	}
+}
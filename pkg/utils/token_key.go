@@ -0,0 +1,292 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// the algorithms a token key could sign with
+const (
+	TokenAlgHS256 string = "HS256"
+	TokenAlgRS256 string = "RS256"
+	TokenAlgEdDSA string = "EdDSA"
+)
+
+const rsaKeyBits = 2048
+
+// tokenKeys are set from the config on start up (see SetTokenKeys), no token is signed nor valid before
+var tokenKeys struct {
+	sync.RWMutex
+	access   TokenKeySet
+	refresh  TokenKeySet
+	issuer   string
+	audience string
+}
+
+// TokenKey is a key signing and verifying tokens, a token names the key it is signed with on its kid header
+type TokenKey struct {
+	ID string `yaml:"kid"`
+	// Algorithm is HS256 (default) signed with the Secret, RS256 and EdDSA are signed with the private key of KeyFile
+	Algorithm string `yaml:"alg"`
+	Secret    string `yaml:"secret"`
+	// KeyFile is the PEM of a private key, a key of a public key PEM only verifies tokens
+	KeyFile string `yaml:"key-file"`
+	// a retired key keeps verifying the tokens it signed until ExpiresAt, zero never expires
+	ExpiresAt time.Time `yaml:"expires-at"`
+
+	// set by SetTokenKeys, signKey is nil for a public key
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// TokenKeySet the keys of a token type, the Primary key signs the new tokens and every unexpired key verifies them
+type TokenKeySet struct {
+	Primary string     `yaml:"primary"`
+	Keys    []TokenKey `yaml:"keys"`
+}
+
+// JSONWebKey is the public part of an asymmetric token key (RFC 7517)
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// load parse the key's secret or PEM file
+func (key TokenKey) load() (TokenKey, error) {
+	switch key.Algorithm {
+	case "", TokenAlgHS256:
+		if key.Secret == "" {
+			return key, fmt.Errorf("empty secret of kid %q", key.ID)
+		}
+		key.Algorithm = TokenAlgHS256
+		key.signKey, key.verifyKey = []byte(key.Secret), []byte(key.Secret)
+	case TokenAlgRS256, TokenAlgEdDSA:
+		if key.KeyFile == "" {
+			return key, fmt.Errorf("empty key file of kid %q", key.ID)
+		}
+		keyPEM, err := os.ReadFile(key.KeyFile)
+		if err != nil {
+			return key, fmt.Errorf("key file of kid %q: %w", key.ID, err)
+		}
+		key.signKey, key.verifyKey, err = parseKeyPEM(key.Algorithm, keyPEM)
+		if err != nil {
+			return key, fmt.Errorf("key file of kid %q: %w", key.ID, err)
+		}
+	default:
+		return key, fmt.Errorf("unsupported alg %q of kid %q", key.Algorithm, key.ID)
+	}
+
+	return key, nil
+}
+
+// parseKeyPEM return the private and public keys of a private key PEM, or only the public key of a public key PEM
+func parseKeyPEM(alg string, keyPEM []byte) (signKey, verifyKey interface{}, err error) {
+	if alg == TokenAlgRS256 {
+		if private, err := jwt.ParseRSAPrivateKeyFromPEM(keyPEM); err == nil {
+			return private, &private.PublicKey, nil
+		}
+		public, err := jwt.ParseRSAPublicKeyFromPEM(keyPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("not a RSA key PEM: %w", err)
+		}
+		return nil, public, nil
+	}
+
+	if private, err := jwt.ParseEdPrivateKeyFromPEM(keyPEM); err == nil {
+		return private, private.(ed25519.PrivateKey).Public(), nil
+	}
+	public, err := jwt.ParseEdPublicKeyFromPEM(keyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not an Ed25519 key PEM: %w", err)
+	}
+	return nil, public, nil
+}
+
+func (set TokenKeySet) load(now time.Time) (TokenKeySet, error) {
+	loaded := TokenKeySet{Primary: set.Primary, Keys: make([]TokenKey, 0, len(set.Keys))}
+	ids := make(map[string]bool, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.ID == "" {
+			return loaded, fmt.Errorf("a key without kid")
+		}
+		if ids[key.ID] {
+			return loaded, fmt.Errorf("duplicate kid %q", key.ID)
+		}
+		ids[key.ID] = true
+
+		key, err := key.load()
+		if err != nil {
+			return loaded, err
+		}
+		loaded.Keys = append(loaded.Keys, key)
+	}
+
+	primary, ok := loaded.key(loaded.Primary, now)
+	if !ok {
+		return loaded, fmt.Errorf("primary kid %q not found or expired", loaded.Primary)
+	}
+	if !primary.ExpiresAt.IsZero() {
+		return loaded, fmt.Errorf("primary kid %q must not expire", loaded.Primary)
+	}
+	if primary.signKey == nil {
+		return loaded, fmt.Errorf("primary kid %q has no private key", loaded.Primary)
+	}
+
+	return loaded, nil
+}
+
+// key return the unexpired key of the kid
+func (set TokenKeySet) key(id string, now time.Time) (TokenKey, bool) {
+	for _, key := range set.Keys {
+		if key.ID != id {
+			continue
+		}
+		if !key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt) {
+			return TokenKey{}, false
+		}
+		return key, true
+	}
+
+	return TokenKey{}, false
+}
+
+// SetTokenKeys replace the keys signing and verifying the tokens, it's safe to call while the tokens are in use so the keys
+// are rotated without restart. The access token keys also sign the reset password tokens
+func SetTokenKeys(access, refresh TokenKeySet) error {
+	now := time.Now()
+	access, err := access.load(now)
+	if err != nil {
+		return fmt.Errorf("utils.SetTokenKeys: access token keys: %w", err)
+	}
+	refresh, err = refresh.load(now)
+	if err != nil {
+		return fmt.Errorf("utils.SetTokenKeys: refresh token keys: %w", err)
+	}
+
+	tokenKeys.Lock()
+	defer tokenKeys.Unlock()
+	tokenKeys.access, tokenKeys.refresh = access, refresh
+
+	return nil
+}
+
+// SetTokenIssuer set the iss and aud claims of the new tokens, a token of another issuer or audience is invalid.
+// An empty issuer or audience isn't claimed nor validated
+func SetTokenIssuer(issuer, audience string) {
+	tokenKeys.Lock()
+	defer tokenKeys.Unlock()
+	tokenKeys.issuer, tokenKeys.audience = issuer, audience
+}
+
+func tokenIssuer() (issuer, audience string) {
+	tokenKeys.RLock()
+	defer tokenKeys.RUnlock()
+	return tokenKeys.issuer, tokenKeys.audience
+}
+
+func tokenKeySet(tokenType string) TokenKeySet {
+	tokenKeys.RLock()
+	defer tokenKeys.RUnlock()
+	if tokenType == refreshTokenType {
+		return tokenKeys.refresh
+	}
+	return tokenKeys.access
+}
+
+// signToken sign the claims with the primary key of its type
+func signToken(claims JwtClaims) (string, error) {
+	set := tokenKeySet(claims.Type)
+	key, ok := set.key(set.Primary, time.Now())
+	if !ok {
+		return "", fmt.Errorf("utils.signToken: no signing key of token type %q", claims.Type)
+	}
+	claims.Issuer, claims.Audience = tokenIssuer()
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.signKey)
+}
+
+// TokenJWKS return the public keys of the unexpired asymmetric access token keys, the other services verify the access
+// tokens with them. The HS256 secrets and the refresh token keys are never published
+func TokenJWKS() JSONWebKeySet {
+	jwks := JSONWebKeySet{Keys: []JSONWebKey{}}
+	now := time.Now()
+	for _, key := range tokenKeySet(accessTokenType).Keys {
+		if !key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt) {
+			continue
+		}
+
+		jwk := JSONWebKey{Kid: key.ID, Use: "sig", Alg: key.Algorithm}
+		switch public := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// GenerateTokenKeyPEM generate a RS256 or EdDSA key pair, the private key is PKCS #8 and the public key PKIX encoded
+func GenerateTokenKeyPEM(alg string) (privatePEM, publicPEM []byte, err error) {
+	var private, public interface{}
+	switch alg {
+	case TokenAlgRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, nil, fmt.Errorf("utils.GenerateTokenKeyPEM: %w", err)
+		}
+		private, public = rsaKey, &rsaKey.PublicKey
+	case TokenAlgEdDSA:
+		edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("utils.GenerateTokenKeyPEM: %w", err)
+		}
+		private, public = edPrivate, edPublic
+	default:
+		return nil, nil, fmt.Errorf("utils.GenerateTokenKeyPEM: unsupported alg %q, want %s or %s", alg, TokenAlgRS256, TokenAlgEdDSA)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, nil, fmt.Errorf("utils.GenerateTokenKeyPEM: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, nil, fmt.Errorf("utils.GenerateTokenKeyPEM: %w", err)
+	}
+
+	privatePEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return privatePEM, publicPEM, nil
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP (RFC 6238) of the authenticator apps: HMAC-SHA1, 6 digits and a 30 seconds step
+const (
+	totpDigits     = 6
+	totpModulo     = 1000000 // 10^totpDigits
+	totpPeriod     = 30 * time.Second
+	totpSkew       = 1 // steps accepted before and after the current one, the clocks of the phones drift
+	totpSecretSize = 20
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789" // without the look-alike characters
+	recoveryCodeLength   = 10
+)
+
+var (
+	// Now is the clock of the time based codes, patch it to test them at a fixed time
+	Now func() time.Time
+	// GenerateTOTPSecret generate the base32 (without padding) secret shared with the owner's authenticator app
+	GenerateTOTPSecret func() (string, error)
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func generateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", fmt.Errorf("utils.GenerateTOTPSecret: %w", err)
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPURI return the otpauth URI of the secret, the authenticator apps enroll it from a QR code
+func TOTPURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode())
+}
+
+// TOTPCode return the code of the secret at the time
+func TOTPCode(secret string, at time.Time) (string, error) {
+	key, err := totpKey(secret)
+	if err != nil {
+		return "", fmt.Errorf("utils.TOTPCode: %w", err)
+	}
+
+	return totpCode(key, totpStep(at)), nil
+}
+
+// ValidateTOTP return the step of the code when it's a code of the secret at the time (give or take a step), the step is
+// kept to refuse the code being replayed
+func ValidateTOTP(secret, code string, at time.Time) (step int64, ok bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpKey(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := totpStep(at)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func totpKey(secret string) ([]byte, error) {
+	return totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+}
+
+func totpStep(at time.Time) int64 {
+	return at.Unix() / int64(totpPeriod.Seconds())
+}
+
+// totpCode is the HOTP (RFC 4226) code of the key at the counter
+func totpCode(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulo)
+}
+
+// GenerateRecoveryCodes generate n single use codes (xxxxx-xxxxx) replacing the TOTP code when the owner lost its phone
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	random := make([]byte, recoveryCodeLength)
+	for i := 0; i < n; i++ {
+		_, err := rand.Read(random)
+		if err != nil {
+			return nil, fmt.Errorf("utils.GenerateRecoveryCodes: %w", err)
+		}
+
+		code := make([]byte, recoveryCodeLength)
+		for j, b := range random {
+			// 256 isn't a multiple of the alphabet's size, the bias is negligible for a 10 characters code
+			code[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		codes = append(codes, string(code[:recoveryCodeLength/2])+"-"+string(code[recoveryCodeLength/2:]))
+	}
+
+	return codes, nil
+}
+
+// NormalizeRecoveryCode return the recovery code as it's hashed, whatever its case and separator
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
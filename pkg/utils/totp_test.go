@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors ("12345678901234567890")
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// the RFC 6238 vectors are 8 digits codes, a 6 digits code is their last 6 digits
+	tests := []struct {
+		name     string
+		at       time.Time
+		wantCode string
+	}{
+		{name: "success TOTPCode (59)", at: time.Unix(59, 0), wantCode: "287082"},
+		{name: "success TOTPCode (1111111109)", at: time.Unix(1111111109, 0), wantCode: "081804"},
+		{name: "success TOTPCode (1111111111)", at: time.Unix(1111111111, 0), wantCode: "050471"},
+		{name: "success TOTPCode (1234567890)", at: time.Unix(1234567890, 0), wantCode: "005924"},
+		{name: "success TOTPCode (2000000000)", at: time.Unix(2000000000, 0), wantCode: "279037"},
+		{name: "success TOTPCode (20000000000)", at: time.Unix(20000000000, 0), wantCode: "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCode, err := TOTPCode(rfc6238Secret, tt.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, gotCode)
+		})
+	}
+
+	_, err := TOTPCode("not base32!", time.Unix(59, 0))
+	assert.Error(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0) // step 37037037
+	tests := []struct {
+		name     string
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOk   bool
+	}{
+		{name: "success ValidateTOTP", code: "050471", at: now, wantStep: 37037037, wantOk: true},
+		{name: "success ValidateTOTP (previous step)", code: "050471", at: now.Add(30 * time.Second), wantStep: 37037037, wantOk: true},
+		{name: "success ValidateTOTP (next step)", code: "050471", at: now.Add(-30 * time.Second), wantStep: 37037037, wantOk: true},
+		{name: "success ValidateTOTP (spaces)", code: " 050471 ", at: now, wantStep: 37037037, wantOk: true},
+		{name: "fail ValidateTOTP (expired)", code: "050471", at: now.Add(time.Minute)},
+		{name: "fail ValidateTOTP (wrong code)", code: "050472", at: now},
+		{name: "fail ValidateTOTP (too short)", code: "05047", at: now},
+		{name: "fail ValidateTOTP (empty)", code: "", at: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOk := ValidateTOTP(rfc6238Secret, tt.code, tt.at)
+			assert.Equal(t, tt.wantOk, gotOk)
+			assert.Equal(t, tt.wantStep, gotStep)
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32) // 20 bytes
+
+	// the secret is usable right away
+	code, err := TOTPCode(secret, time.Unix(59, 0))
+	assert.NoError(t, err)
+	_, ok := ValidateTOTP(secret, code, time.Unix(59, 0))
+	assert.True(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Family Catering", "test@example.com", rfc6238Secret)
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/Family Catering:test@example.com", parsed.Path)
+	assert.Equal(t, rfc6238Secret, parsed.Query().Get("secret"))
+	assert.Equal(t, "Family Catering", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	unique := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, "-", code[5:6])
+		assert.Equal(t, code, strings.ToLower(code))
+		unique[code] = true
+	}
+	assert.Len(t, unique, 10)
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "abcde23456", NormalizeRecoveryCode("abcde-23456"))
+	assert.Equal(t, "abcde23456", NormalizeRecoveryCode("ABCDE 23456"))
+	assert.Equal(t, "abcde23456", NormalizeRecoveryCode("abcde23456"))
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"family-catering/pkg/apperrors"
+	"family-catering/pkg/money"
+	"reflect"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -27,10 +29,20 @@ import (
 // func resetValidator() { vdr = validator.New() }
 
 var (
-	vdr             *validator.Validate = validator.New()
+	vdr             *validator.Validate = newValidator()
 	ValidateRequest func(s interface{}) error
 )
 
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// money is validated by its minor units, e.g. `validate:"gte=5"` means at least 0.05
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(money.Money).Amount
+	}, money.Money{})
+
+	return v
+}
+
 func validateRequest(s interface{}) error {
 	err := vdr.Struct(s)
 
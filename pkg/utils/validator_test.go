@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"family-catering/pkg/money"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,6 +48,37 @@ func TestValidateRequest(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "success ValidateRequest (money)",
+			args: args{
+				s: struct {
+					Price money.Money `validate:"required,gte=5"`
+				}{
+					Price: money.MustParse("0.05"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid ValidateRequest (money is less than minimum)",
+			args: args{
+				s: struct {
+					Price money.Money `validate:"required,gte=5"`
+				}{
+					Price: money.MustParse("0.04"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid ValidateRequest (money is required)",
+			args: args{
+				s: struct {
+					Price money.Money `validate:"required"`
+				}{},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
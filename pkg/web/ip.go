@@ -41,3 +41,55 @@ func RealIP(r *http.Request) string {
 
 	return xRealIP
 }
+
+// ClientIP return the ip of the request's client. The forwarded headers (X-Forwarded-For then X-Real-IP) are only
+// trusted when the request comes from one of the trusted proxies (ips or CIDRs), any client could send them otherwise.
+// The client is then the right-most forwarded ip which isn't a trusted proxy
+func ClientIP(r *http.Request, trustedProxies []string) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remoteIP := net.ParseIP(host)
+	if remoteIP == nil {
+		return ""
+	}
+	if !isTrustedProxy(remoteIP, trustedProxies) {
+		return remoteIP.String()
+	}
+
+	forwardedIPs := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwardedIPs) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwardedIPs[i]))
+		if ip == nil {
+			break
+		}
+		if !isTrustedProxy(ip, trustedProxies) {
+			return ip.String()
+		}
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP")))
+	if ip != nil {
+		return ip.String()
+	}
+
+	return remoteIP.String()
+}
+
+func isTrustedProxy(ip net.IP, trustedProxies []string) bool {
+	for _, proxy := range trustedProxies {
+		if strings.Contains(proxy, "/") {
+			_, network, err := net.ParseCIDR(proxy)
+			if err == nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if ip.Equal(net.ParseIP(proxy)) {
+			return true
+		}
+	}
+
+	return false
+}
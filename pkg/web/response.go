@@ -1,9 +1,13 @@
 package web
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"family-catering/pkg/apperrors"
+	"html/template"
+	"mime"
 	"net/http"
 	"time"
 
@@ -14,7 +18,7 @@ type JSONResponse struct {
 	Success     bool          `json:"success" example:"true"`
 	Status      string        `json:"status"`
 	Data        interface{}   `json:"data,omitempty" extensions:"x-omitempty"`
-	ProcessTime time.Duration `json:"process_time"`
+	ProcessTime time.Duration `json:"process_time" swaggertype:"string"`
 }
 
 type Error struct {
@@ -24,7 +28,7 @@ type Error struct {
 type ErrJSONResponse struct {
 	Success     bool          `json:"success" example:"false"`
 	Status      string        `json:"status"`
-	ProcessTime time.Duration `json:"process_time"`
+	ProcessTime time.Duration `json:"process_time" swaggertype:"string"`
 	Error       Error         `json:"error,omitempty"`
 }
 
@@ -40,6 +44,51 @@ func WriteSuccessJSON(w http.ResponseWriter, payload interface{}, startRequestTi
 	json.NewEncoder(w).Encode(body)
 }
 
+// WriteJSON write the body as is, without the envelope of the API responses (e.g. the well-known documents)
+func WriteJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// WriteCSV write the records as a CSV file attachment, nothing is written when the records can't be encoded
+func WriteCSV(w http.ResponseWriter, filename string, records [][]string) error {
+	buf := new(bytes.Buffer)
+	csvWriter := csv.NewWriter(buf)
+	err := csvWriter.WriteAll(records)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		log.Error(err, "failed to write csv response")
+	}
+
+	return nil
+}
+
+// WriteHTML write the page rendered by tmpl with data, nothing is written when the template fails
+func WriteHTML(w http.ResponseWriter, tmpl *template.Template, data interface{}) error {
+	buf := new(bytes.Buffer)
+	err := tmpl.Execute(buf, data)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		log.Error(err, "failed to write html response")
+	}
+
+	return nil
+}
+
 func WriteFailJSON(w http.ResponseWriter, statusCode int, message string, startRequestTime time.Time) {
 	w.Header().Set("Content-Type", "application/json")
 	body := ErrJSONResponse{
@@ -6,6 +6,7 @@ import (
 	"family-catering/internal/model"
 	"family-catering/pkg/consts"
 	"family-catering/pkg/logger"
+	"family-catering/pkg/money"
 	"family-catering/pkg/utils"
 	"net/http"
 	"strconv"
@@ -96,19 +97,19 @@ func ParseSearchQueryParams(r *http.Request) (model.OrderQuery, error) {
 	}
 	val = r.URL.Query().Get("max-price")
 	if val != "" {
-		price, err := strconv.ParseFloat(val, 32)
+		price, err := money.Parse(val)
 		if err != nil {
 			return req, err
 		}
-		req.MaxPrice = float32(price)
+		req.MaxPrice = price
 	}
 	val = r.URL.Query().Get("min-price")
 	if val != "" {
-		price, err := strconv.ParseFloat(val, 32)
+		price, err := money.Parse(val)
 		if err != nil {
 			return req, err
 		}
-		req.MinPrice = float32(price)
+		req.MinPrice = price
 	}
 	val = r.URL.Query().Get("status")
 	if val != "" {
@@ -126,9 +127,53 @@ func ParseSearchQueryParams(r *http.Request) (model.OrderQuery, error) {
 	if val != "" {
 		req.EndDay = val
 	}
+	// delivery-date is a shorthand of the same delivery-start-day and delivery-end-day
+	val = r.URL.Query().Get("delivery-date")
+	if val != "" {
+		day, err := ParseDay(val)
+		if err != nil {
+			return req, err
+		}
+		req.DeliveryStartDay = day
+		req.DeliveryEndDay = day
+	}
+	val = r.URL.Query().Get("delivery-start-day")
+	if val != "" {
+		day, err := ParseDay(val)
+		if err != nil {
+			return req, err
+		}
+		req.DeliveryStartDay = day
+	}
+	val = r.URL.Query().Get("delivery-end-day")
+	if val != "" {
+		day, err := ParseDay(val)
+		if err != nil {
+			return req, err
+		}
+		req.DeliveryEndDay = day
+	}
 	return req, nil
 }
 
+// ParseDay return the day (YYYY-MM-DD) of a date formatted as YYYY-MM-DD or the relative day today or tomorrow
+func ParseDay(val string) (string, error) {
+	const layout = "2006-01-02"
+	switch strings.ToLower(val) {
+	case "today":
+		return timeNow().Format(layout), nil
+	case "tomorrow":
+		return timeNow().AddDate(0, 0, 1).Format(layout), nil
+	}
+
+	day, err := time.Parse(layout, val)
+	if err != nil {
+		return "", err
+	}
+
+	return day.Format(layout), nil
+}
+
 func RequestStartTimeFromContext(ctx context.Context) time.Time {
 	t := utils.ValueContext(ctx, consts.CtxKeyRequestTime)
 	s, ok := t.(time.Time)